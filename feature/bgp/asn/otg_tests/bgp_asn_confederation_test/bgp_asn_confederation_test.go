@@ -0,0 +1,404 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_asn_confederation_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+)
+
+const (
+	// dutSubAS is the DUT's member AS inside the confederation.
+	dutSubAS = 65001
+	// confedPeerAS is the member AS of the confederation peer on ATE port-2.
+	confedPeerAS = 65002
+	// confedID is the 4-byte AS the confederation presents to external peers.
+	confedID = 4200000000
+	// extPeerAS is the 4-byte AS of the external peer on ATE port-1.
+	extPeerAS = 4200000001
+	// extPeer2ByteAS is the AS of the external peer on ATE port-1 when it
+	// only supports 2-byte ASNs.
+	extPeer2ByteAS = 64700
+	// remoteAS is a 4-byte AS behind the external peer.
+	remoteAS = 4200000002
+	originAS = 64512
+	// asTrans is the AS_TRANS value from RFC 6793.
+	asTrans = 23456
+
+	peerGrpExt    = "EXTERNAL"
+	peerGrpConfed = "CONFED"
+	policyName    = "ALLOW"
+
+	extPrefix        = "198.51.100.0"
+	extLoopPrefix    = "198.18.1.0"
+	confedPrefix     = "203.0.113.0"
+	confedLoopPrefix = "198.18.0.0"
+	prefixLen        = 24
+
+	bgpTimeout   = 2 * time.Minute
+	routeTimeout = time.Minute
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		IPv4:    "192.0.2.2",
+		MAC:     "02:00:01:01:01:01",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		IPv4:    "192.0.2.6",
+		MAC:     "02:00:02:01:01:01",
+		IPv4Len: 30,
+	}
+
+	extPeerName    = atePort1.Name + ".BGP4.peer"
+	confedPeerName = atePort2.Name + ".BGP4.peer"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure the DUT as member AS 65001 of confederation 4200000000 with
+//     an external peer on port-1 and a confederation peer (member AS 65002)
+//     on port-2.
+//  2. Verify both sessions are established and the DUT reports the 4-byte
+//     confederation identifier and peer AS.
+//  3. Verify a route from the external peer is re-advertised to the
+//     confederation peer with the DUT's member AS in an AS_CONFED_SEQUENCE
+//     and the external AS_SEQUENCE, including 4-byte ASNs, unchanged.
+//  4. Verify a route from the confederation peer is re-advertised to the
+//     external peer with the confederation identifier prepended and no
+//     AS_CONFED segments.
+//  5. Verify routes whose AS path already contains the confederation
+//     identifier or the DUT's member AS are rejected and not re-advertised.
+//  6. Repeat with an external peer that only supports 2-byte ASNs, so 4-byte
+//     ASNs are carried in AS4_PATH.
+//
+// Topology:
+//
+//	ATE port-1 (external) <--> port-1 DUT port-2 <--> ATE port-2 (confederation)
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+	configureRoutePolicy(t, dut)
+}
+
+func configureRoutePolicy(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	d := &oc.Root{}
+	rp := d.GetOrCreateRoutingPolicy()
+	st, err := rp.GetOrCreatePolicyDefinition(policyName).AppendNewStatement("id-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+	gnmi.Replace(t, dut, gnmi.OC().RoutingPolicy().Config(), rp)
+}
+
+// bgpWithConfederation returns the DUT BGP config with the external peer in
+// extAS and the confederation peer.
+func bgpWithConfederation(dut *ondatra.DUTDevice, extAS uint32) *oc.NetworkInstance_Protocol {
+	d := &oc.Root{}
+	ni := d.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut))
+	niProto := ni.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP")
+	bgp := niProto.GetOrCreateBgp()
+
+	global := bgp.GetOrCreateGlobal()
+	global.As = ygot.Uint32(dutSubAS)
+	global.RouterId = ygot.String(dutPort1.IPv4)
+	global.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	confed := global.GetOrCreateConfederation()
+	confed.Identifier = ygot.Uint32(confedID)
+	confed.MemberAs = []uint32{dutSubAS, confedPeerAS}
+
+	for _, nbr := range []struct {
+		pg, addr string
+		as       uint32
+	}{
+		{pg: peerGrpExt, addr: atePort1.IPv4, as: extAS},
+		{pg: peerGrpConfed, addr: atePort2.IPv4, as: confedPeerAS},
+	} {
+		pg := bgp.GetOrCreatePeerGroup(nbr.pg)
+		pg.PeerAs = ygot.Uint32(nbr.as)
+		if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+			rpl := pg.GetOrCreateApplyPolicy()
+			rpl.ImportPolicy = []string{policyName}
+			rpl.ExportPolicy = []string{policyName}
+		} else {
+			rpl := pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateApplyPolicy()
+			rpl.ImportPolicy = []string{policyName}
+			rpl.ExportPolicy = []string{policyName}
+		}
+
+		n := bgp.GetOrCreateNeighbor(nbr.addr)
+		n.PeerAs = ygot.Uint32(nbr.as)
+		n.Enabled = ygot.Bool(true)
+		n.PeerGroup = ygot.String(nbr.pg)
+		n.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	}
+	return niProto
+}
+
+// addRoute advertises prefix from peer with exactly the given AS path
+// segments.
+func addRoute(peer gosnappi.BgpV4Peer, name, nextHop, prefix string, segs ...gosnappi.BgpAsPathSegment) {
+	r := peer.V4Routes().Add().SetName(name).SetNextHopIpv4Address(nextHop).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+	r.Addresses().Add().SetAddress(prefix).SetPrefix(prefixLen)
+	asp := r.AsPath().SetAsSetMode(gosnappi.BgpAsPathAsSetMode.DO_NOT_INCLUDE_LOCAL_AS)
+	for _, s := range segs {
+		asp.Segments().Add().SetType(s.Type()).SetAsNumbers(s.AsNumbers())
+	}
+}
+
+func segment(typ gosnappi.BgpAsPathSegmentTypeEnum, asns ...uint32) gosnappi.BgpAsPathSegment {
+	return gosnappi.NewBgpAsPathSegment().SetType(typ).SetAsNumbers(asns)
+}
+
+func addBGPPeer(dev gosnappi.Device, ate, dut attrs.Attributes, name string, as uint32, width gosnappi.BgpV4PeerAsNumberWidthEnum) gosnappi.BgpV4Peer {
+	ip := dev.Ethernets().Items()[0].Ipv4Addresses().Items()[0]
+	bgp := dev.Bgp().SetRouterId(ate.IPv4)
+	return bgp.Ipv4Interfaces().Add().SetIpv4Name(ip.Name()).Peers().Add().
+		SetName(name).
+		SetPeerAddress(dut.IPv4).
+		SetAsNumber(as).
+		SetAsType(gosnappi.BgpV4PeerAsType.EBGP).
+		SetAsNumberWidth(width)
+}
+
+// configureATE builds the OTG config with the external peer in extAS using
+// ASNs of the given width.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice, extAS uint32, width gosnappi.BgpV4PeerAsNumberWidthEnum) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	dev1 := atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	dev2 := atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+
+	ext := addBGPPeer(dev1, atePort1, dutPort1, extPeerName, extAS, width)
+	addRoute(ext, "extRoute", atePort1.IPv4, extPrefix,
+		segment(gosnappi.BgpAsPathSegmentType.AS_SEQ, extAS, remoteAS, originAS))
+	addRoute(ext, "extLoopRoute", atePort1.IPv4, extLoopPrefix,
+		segment(gosnappi.BgpAsPathSegmentType.AS_SEQ, extAS, remoteAS, confedID, originAS))
+
+	confed := addBGPPeer(dev2, atePort2, dutPort2, confedPeerName, confedPeerAS, gosnappi.BgpV4PeerAsNumberWidth.FOUR)
+	addRoute(confed, "confedRoute", atePort2.IPv4, confedPrefix,
+		segment(gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ, confedPeerAS),
+		segment(gosnappi.BgpAsPathSegmentType.AS_SEQ, originAS))
+	addRoute(confed, "confedLoopRoute", atePort2.IPv4, confedLoopPrefix,
+		segment(gosnappi.BgpAsPathSegmentType.AS_CONFED_SEQ, confedPeerAS, dutSubAS),
+		segment(gosnappi.BgpAsPathSegmentType.AS_SEQ, originAS))
+	return top
+}
+
+func awaitSessions(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	bgpPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP").Bgp()
+	for _, addr := range []string{atePort1.IPv4, atePort2.IPv4} {
+		nbrPath := bgpPath.Neighbor(addr)
+		_, ok := gnmi.Watch(t, dut, nbrPath.SessionState().State(), bgpTimeout, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+			state, ok := val.Val()
+			return ok && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+		}).Await(t)
+		if !ok {
+			fptest.LogQuery(t, "BGP reported state", nbrPath.State(), gnmi.Get(t, dut, nbrPath.State()))
+			t.Fatalf("BGP session with %s not established", addr)
+		}
+	}
+}
+
+func verifyDUTState(t *testing.T, dut *ondatra.DUTDevice, extAS uint32) {
+	t.Helper()
+	bgpPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP").Bgp()
+	confed := gnmi.Get(t, dut, bgpPath.Global().Confederation().State())
+	if got := confed.GetIdentifier(); got != confedID {
+		t.Errorf("Confederation identifier: got %d, want %d", got, confedID)
+	}
+	if got, want := confed.GetMemberAs(), []uint32{dutSubAS, confedPeerAS}; !cmp.Equal(got, want) {
+		t.Errorf("Confederation member-as: got %v, want %v", got, want)
+	}
+	for addr, want := range map[string]uint32{atePort1.IPv4: extAS, atePort2.IPv4: confedPeerAS} {
+		if got := gnmi.Get(t, dut, bgpPath.Neighbor(addr).PeerAs().State()); got != want {
+			t.Errorf("Neighbor %s peer-as: got %d, want %d", addr, got, want)
+		}
+		// Only the route without an AS path loop should be installed.
+		if got := gnmi.Get(t, dut, bgpPath.Neighbor(addr).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Installed().State()); got != 1 {
+			t.Errorf("Neighbor %s installed prefixes: got %d, want 1", addr, got)
+		}
+	}
+}
+
+type asSegment struct {
+	typ  otgtelemetry.E_State_SegmentType
+	asns []uint32
+}
+
+func (s asSegment) String() string {
+	return fmt.Sprintf("%v%v", s.typ, s.asns)
+}
+
+// learnedPath waits for prefix to be learned by the OTG peer and returns its
+// AS path.
+func learnedPath(t *testing.T, ate *ondatra.ATEDevice, peer, prefix string) ([]asSegment, bool) {
+	t.Helper()
+	p := gnmi.OTG().BgpPeer(peer).UnicastIpv4Prefix(prefix, prefixLen, otgtelemetry.UnicastIpv4Prefix_Origin_IGP, 0)
+	v, ok := gnmi.Watch(t, ate.OTG(), p.State(), routeTimeout, func(v *ygnmi.Value[*otgtelemetry.BgpPeer_UnicastIpv4Prefix]) bool {
+		return v.IsPresent()
+	}).Await(t)
+	if !ok {
+		return nil, false
+	}
+	route, _ := v.Val()
+	var segs []asSegment
+	for _, s := range route.AsPath {
+		segs = append(segs, asSegment{typ: s.GetSegmentType(), asns: s.GetAsNumbers()})
+	}
+	return segs, true
+}
+
+// verifyNotLearned checks that prefix is not learned by the OTG peer.
+func verifyNotLearned(t *testing.T, ate *ondatra.ATEDevice, peer, prefix string) {
+	t.Helper()
+	p := gnmi.OTG().BgpPeer(peer).UnicastIpv4Prefix(prefix, prefixLen, otgtelemetry.UnicastIpv4Prefix_Origin_IGP, 0)
+	if v, ok := gnmi.Lookup(t, ate.OTG(), p.State()).Val(); ok {
+		t.Errorf("%s learned %s/%d with AS path %v, want not learned", peer, prefix, prefixLen, v.AsPath)
+	}
+}
+
+func TestConfederation4ByteASN(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	dutBGPPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP")
+
+	cases := []struct {
+		desc  string
+		extAS uint32
+		width gosnappi.BgpV4PeerAsNumberWidthEnum
+		// wantExtPath lists the acceptable AS paths for the confederation
+		// route as learned by the external peer.
+		wantExtPath [][]asSegment
+	}{{
+		desc:  "4-byte external peer",
+		extAS: extPeerAS,
+		width: gosnappi.BgpV4PeerAsNumberWidth.FOUR,
+		wantExtPath: [][]asSegment{
+			{{typ: otgtelemetry.State_SegmentType_AS_SEQUENCE, asns: []uint32{confedID, originAS}}},
+		},
+	}, {
+		desc:  "2-byte external peer with AS4_PATH",
+		extAS: extPeer2ByteAS,
+		width: gosnappi.BgpV4PeerAsNumberWidth.TWO,
+		// The OTG may report either the AS_PATH as received, with AS_TRANS
+		// standing in for the confederation identifier, or the path merged
+		// with AS4_PATH.
+		wantExtPath: [][]asSegment{
+			{{typ: otgtelemetry.State_SegmentType_AS_SEQUENCE, asns: []uint32{confedID, originAS}}},
+			{{typ: otgtelemetry.State_SegmentType_AS_SEQUENCE, asns: []uint32{asTrans, originAS}}},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gnmi.Delete(t, dut, dutBGPPath.Config())
+			gnmi.Replace(t, dut, dutBGPPath.Config(), bgpWithConfederation(dut, tc.extAS))
+
+			top := configureATE(t, ate, tc.extAS, tc.width)
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			defer ate.OTG().StopProtocols(t)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+			awaitSessions(t, dut)
+
+			t.Run("DUT state", func(t *testing.T) {
+				verifyDUTState(t, dut, tc.extAS)
+			})
+
+			t.Run("External route to confederation peer", func(t *testing.T) {
+				got, ok := learnedPath(t, ate, confedPeerName, extPrefix)
+				if !ok {
+					t.Fatalf("%s did not learn %s/%d", confedPeerName, extPrefix, prefixLen)
+				}
+				want := []asSegment{
+					{typ: otgtelemetry.State_SegmentType_AS_CONFED_SEQUENCE, asns: []uint32{dutSubAS}},
+					{typ: otgtelemetry.State_SegmentType_AS_SEQUENCE, asns: []uint32{tc.extAS, remoteAS, originAS}},
+				}
+				if diff := cmp.Diff(want, got, cmp.AllowUnexported(asSegment{})); diff != "" {
+					t.Errorf("AS path of %s/%d learned by %s (-want +got):\n%s", extPrefix, prefixLen, confedPeerName, diff)
+				}
+			})
+
+			t.Run("Confederation route to external peer", func(t *testing.T) {
+				got, ok := learnedPath(t, ate, extPeerName, confedPrefix)
+				if !ok {
+					t.Fatalf("%s did not learn %s/%d", extPeerName, confedPrefix, prefixLen)
+				}
+				for _, want := range tc.wantExtPath {
+					if cmp.Equal(want, got, cmp.AllowUnexported(asSegment{})) {
+						return
+					}
+				}
+				t.Errorf("AS path of %s/%d learned by %s: got %v, want one of %v", confedPrefix, prefixLen, extPeerName, got, tc.wantExtPath)
+			})
+
+			t.Run("AS path loop detection", func(t *testing.T) {
+				verifyNotLearned(t, ate, confedPeerName, extLoopPrefix)
+				verifyNotLearned(t, ate, extPeerName, confedLoopPrefix)
+			})
+		})
+	}
+}
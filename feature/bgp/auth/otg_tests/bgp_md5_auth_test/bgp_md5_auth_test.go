@@ -0,0 +1,160 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_md5_auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	key1        = "BGP-MD5-KEY-1"
+	key2        = "BGP-MD5-KEY-2"
+	mismatchKey = "BGP-MD5-MISMATCH"
+
+	// The hold time is short so that a session with mismatched keys is
+	// detected quickly.
+	holdTime          = 15
+	keepaliveInterval = 5
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Matching keys: with the same MD5 key on the DUT and the ATE, the IPv4
+//     and IPv6 sessions are established.
+//  2. Mismatched key: with another key on the ATE, the sessions go down and
+//     are not established again for twice the hold time.
+//  3. Key change: after a new key is set on both the DUT and the ATE, the
+//     sessions are established again.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut
+//
+// Test notes:
+//   - The ATE does not support TCP-AO, so TCP-AO is covered by a DUT to DUT
+//     test.
+//   - The key is changed on the ATE by pushing its config again and
+//     restarting its protocols, which also resets the sessions on devices
+//     with the bgp_md5_requires_reset deviation.
+
+// neighbors returns the DUT BGP neighbors of bs.
+func neighbors(t *testing.T, bs *cfgplugins.BGPSession) map[string]*oc.NetworkInstance_Protocol_Bgp_Neighbor {
+	t.Helper()
+	bgp := bs.DUTConf.GetNetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).GetProtocol(cfgplugins.PTBGP, "BGP").GetBgp()
+	if len(bgp.Neighbor) == 0 {
+		t.Fatal("No BGP neighbors in the DUT config")
+	}
+	return bgp.Neighbor
+}
+
+// setATEKey sets key and the timers on the BGP peers of the ATE.
+func setATEKey(bs *cfgplugins.BGPSession, key string) {
+	for _, d := range bs.ATETop.Devices().Items() {
+		for _, intf := range d.Bgp().Ipv4Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				peer.Advanced().SetMd5Key(key).SetHoldTimeInterval(holdTime).SetKeepAliveInterval(keepaliveInterval)
+			}
+		}
+		for _, intf := range d.Bgp().Ipv6Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				peer.Advanced().SetMd5Key(key).SetHoldTimeInterval(holdTime).SetKeepAliveInterval(keepaliveInterval)
+			}
+		}
+	}
+}
+
+// awaitSessions waits for the sessions of all neighbors to be established,
+// or not, as set by established.
+func awaitSessions(t *testing.T, dut *ondatra.DUTDevice, nbrs map[string]*oc.NetworkInstance_Protocol_Bgp_Neighbor, established bool, timeout time.Duration) {
+	t.Helper()
+	bgp := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp()
+	for addr := range nbrs {
+		_, ok := gnmi.Watch(t, dut, bgp.Neighbor(addr).SessionState().State(), timeout, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+			state, present := val.Val()
+			return present && (state == oc.Bgp_Neighbor_SessionState_ESTABLISHED) == established
+		}).Await(t)
+		if !ok {
+			want := "established"
+			if !established {
+				want = "down"
+			}
+			t.Fatalf("BGP session to %s was not %s within %v", addr, want, timeout)
+		}
+	}
+}
+
+// verifyNotEstablished checks that no BGP session is established for d.
+func verifyNotEstablished(t *testing.T, dut *ondatra.DUTDevice, d time.Duration) {
+	t.Helper()
+	state := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp().NeighborAny().SessionState().State()
+	got, ok := gnmi.WatchAll(t, dut, state, d, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		state, present := val.Val()
+		return present && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+	}).Await(t)
+	if ok {
+		t.Errorf("BGP session established with mismatched MD5 keys: %v", got)
+	}
+}
+
+func TestMD5Auth(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST, oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST}, []string{"port1"}, true, false)
+	nbrs := neighbors(t, bs)
+	for _, n := range nbrs {
+		n.AuthPassword = ygot.String(key1)
+		timers := n.GetOrCreateTimers()
+		timers.HoldTime = ygot.Uint16(holdTime)
+		timers.KeepaliveInterval = ygot.Uint16(keepaliveInterval)
+	}
+	setATEKey(bs, key1)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to configure BGP: %v", err)
+	}
+	dut := bs.DUT
+	bgp := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp()
+
+	t.Run("Matching keys", func(t *testing.T) {
+		awaitSessions(t, dut, nbrs, true, 2*time.Minute)
+	})
+
+	t.Run("Mismatched key", func(t *testing.T) {
+		setATEKey(bs, mismatchKey)
+		bs.PushAndStartATE(t)
+		awaitSessions(t, dut, nbrs, false, (holdTime+10)*time.Second)
+		verifyNotEstablished(t, dut, 2*holdTime*time.Second)
+	})
+
+	t.Run("Key change", func(t *testing.T) {
+		for addr := range nbrs {
+			gnmi.Replace(t, dut, bgp.Neighbor(addr).AuthPassword().Config(), key2)
+		}
+		setATEKey(bs, key2)
+		bs.PushAndStartATE(t)
+		awaitSessions(t, dut, nbrs, true, 2*time.Minute)
+	})
+}
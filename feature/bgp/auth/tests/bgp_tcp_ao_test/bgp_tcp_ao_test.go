@@ -0,0 +1,288 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_tcp_ao_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/topology"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	bgpName      = "BGP"
+	keychainName = "BGP-TCP-AO"
+	secret1      = "BGP-TCP-AO-KEY-1"
+	secret2      = "BGP-TCP-AO-KEY-2"
+	mismatch     = "BGP-TCP-AO-MISMATCH"
+	cryptoAlgo   = oc.KeychainTypes_CRYPTO_TYPE_HMAC_SHA_1_96
+
+	// The hold time is short so that a session with mismatched keys is
+	// detected quickly.
+	holdTime          = 15
+	keepaliveInterval = 5
+
+	// rolloverDelay is the time from the configuration of the new key to
+	// the end of the send lifetime of the old key, and receiveOverlap the
+	// time the old key is still accepted after that.
+	rolloverDelay  = time.Minute
+	receiveOverlap = time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Matching keys: with the same keychain on both DUTs, the eBGP session
+//     between them is established with TCP-AO.
+//  2. Mismatched key: with another secret for the key on DUT2, the session
+//     goes down and is not established again for twice the hold time.  With
+//     the secret restored, the session is established again.
+//  3. Key rotation: a second key is added on both DUTs, sent from when the
+//     first key stops being sent, and the first key is then removed.  The
+//     session stays established through the rotation.
+//
+// Topology:
+//
+//	dut1:port1 <--> port1:dut2
+//
+// Test notes:
+//   - The keychain is configured through OpenConfig.  OpenConfig does not
+//     model TCP-AO for a BGP neighbor, so the keychain of the neighbor is set
+//     through CLI on devices with the bgp_tcp_ao_oc_unsupported deviation.
+//   - Key lifetimes are in nanoseconds since the Unix epoch.
+
+// aoCLI holds the vendor CLI to set the keychain of a BGP neighbor.
+type aoCLI struct {
+	// set sets keychainName for a neighbor, with a %d verb for the local AS
+	// and a %s verb for the neighbor address.
+	set string
+}
+
+// aoCLIs returns the TCP-AO CLI for dut.
+func aoCLIs(t testing.TB, dut *ondatra.DUTDevice) aoCLI {
+	t.Helper()
+	switch dut.Vendor() {
+	case ondatra.CISCO:
+		return aoCLI{
+			set: fmt.Sprintf(`
+tcp ao
+ keychain %[1]s
+  key-id 1 send-id 1 receive-id 1
+  key-id 2 send-id 2 receive-id 2
+!
+router bgp %%d
+ neighbor %%s
+  ao %[1]s include-tcp-options enable
+`, keychainName),
+		}
+	default:
+		t.Fatalf("TCP-AO CLI is not defined for vendor %s", dut.Vendor())
+	}
+	return aoCLI{}
+}
+
+// side is the configuration of one of the DUTs.
+type side struct {
+	dut          *ondatra.DUTDevice
+	port         *ondatra.Port
+	local, peer  attrs.Attributes
+	as, peerAS   uint32
+	transitions0 uint64
+}
+
+// sides returns the configuration of DUT1 and DUT2.
+func sides(d *topology.DUTDUT) map[*ondatra.DUTDevice]*side {
+	l := d.Links[0]
+	return map[*ondatra.DUTDevice]*side{
+		d.DUT1: {dut: d.DUT1, port: l.DUT1, local: l.DUT1Attrs, peer: l.DUT2Attrs, as: 65001, peerAS: 65002},
+		d.DUT2: {dut: d.DUT2, port: l.DUT2, local: l.DUT2Attrs, peer: l.DUT1Attrs, as: 65002, peerAS: 65001},
+	}
+}
+
+func (s *side) bgp() *netinstbgp.NetworkInstance_Protocol_BgpPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(s.dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp()
+}
+
+// configure configures the port, the keychain with key 1, and the BGP
+// session to the other DUT using the keychain.
+func (s *side) configure(t testing.TB) {
+	t.Helper()
+	dut := s.dut
+	gnmi.Replace(t, dut, gnmi.OC().Interface(s.port.Name()).Config(), s.local.NewOCInterface(s.port.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, s.port)
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	ni := deviations.DefaultNetworkInstance(dut)
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, s.port.Name(), ni, 0)
+	}
+
+	kc := &oc.Keychain{Name: ygot.String(keychainName)}
+	key := kc.GetOrCreateKey(oc.UnionUint64(1))
+	key.SecretKey = ygot.String(secret1)
+	key.CryptoAlgorithm = cryptoAlgo
+	gnmi.Replace(t, dut, gnmi.OC().Keychain(keychainName).Config(), kc)
+
+	niConf := &oc.NetworkInstance{Name: ygot.String(ni)}
+	bgp := niConf.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).GetOrCreateBgp()
+	global := bgp.GetOrCreateGlobal()
+	global.As = ygot.Uint32(s.as)
+	global.RouterId = ygot.String(s.local.IPv4)
+	global.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	nbr := bgp.GetOrCreateNeighbor(s.peer.IPv4)
+	nbr.PeerAs = ygot.Uint32(s.peerAS)
+	nbr.Enabled = ygot.Bool(true)
+	nbr.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	timers := nbr.GetOrCreateTimers()
+	timers.HoldTime = ygot.Uint16(holdTime)
+	timers.KeepaliveInterval = ygot.Uint16(keepaliveInterval)
+	gnmi.Update(t, dut, gnmi.OC().NetworkInstance(ni).Config(), niConf)
+
+	helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(aoCLIs(t, dut).set, s.as, s.peer.IPv4))
+}
+
+// awaitSession waits for the session to the other DUT to be established,
+// or not, as set by established.
+func (s *side) awaitSession(t testing.TB, established bool, timeout time.Duration) {
+	t.Helper()
+	_, ok := gnmi.Watch(t, s.dut, s.bgp().Neighbor(s.peer.IPv4).SessionState().State(), timeout, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		state, present := val.Val()
+		return present && (state == oc.Bgp_Neighbor_SessionState_ESTABLISHED) == established
+	}).Await(t)
+	if !ok {
+		want := "established"
+		if !established {
+			want = "down"
+		}
+		t.Fatalf("BGP session to %s was not %s within %v", s.peer.IPv4, want, timeout)
+	}
+}
+
+// verifySession checks that the session to the other DUT stays established,
+// or not, as set by established, for d.
+func (s *side) verifySession(t testing.TB, established bool, d time.Duration) {
+	t.Helper()
+	got, changed := gnmi.Watch(t, s.dut, s.bgp().Neighbor(s.peer.IPv4).SessionState().State(), d, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		state, present := val.Val()
+		return (present && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED) != established
+	}).Await(t)
+	if changed {
+		t.Errorf("BGP session to %s left the established=%v state within %v: %v", s.peer.IPv4, established, d, got)
+	}
+}
+
+// transitions returns the established-transitions of the session to the
+// other DUT.
+func (s *side) transitions(t testing.TB) uint64 {
+	t.Helper()
+	return gnmi.Get(t, s.dut, s.bgp().Neighbor(s.peer.IPv4).EstablishedTransitions().State())
+}
+
+// rotateKeys adds key 2, sent from rollover, and limits key 1 to be sent
+// until rollover and accepted until receiveOverlap after it.
+func (s *side) rotateKeys(t testing.TB, now, rollover time.Time) {
+	t.Helper()
+	kc := &oc.Keychain{Name: ygot.String(keychainName)}
+	key1 := kc.GetOrCreateKey(oc.UnionUint64(1))
+	key1.SecretKey = ygot.String(secret1)
+	key1.CryptoAlgorithm = cryptoAlgo
+	key1.GetOrCreateSendLifetime().StartTime = ygot.Uint64(uint64(now.UnixNano()))
+	key1.GetOrCreateSendLifetime().EndTime = ygot.Uint64(uint64(rollover.UnixNano()))
+	key1.GetOrCreateReceiveLifetime().StartTime = ygot.Uint64(uint64(now.UnixNano()))
+	key1.GetOrCreateReceiveLifetime().EndTime = ygot.Uint64(uint64(rollover.Add(receiveOverlap).UnixNano()))
+
+	key2 := kc.GetOrCreateKey(oc.UnionUint64(2))
+	key2.SecretKey = ygot.String(secret2)
+	key2.CryptoAlgorithm = cryptoAlgo
+	key2.GetOrCreateSendLifetime().StartTime = ygot.Uint64(uint64(rollover.UnixNano()))
+	key2.GetOrCreateReceiveLifetime().StartTime = ygot.Uint64(uint64(now.UnixNano()))
+	gnmi.Update(t, s.dut, gnmi.OC().Keychain(keychainName).Config(), kc)
+}
+
+func TestTCPAO(t *testing.T) {
+	d := topology.NewDUTDUT(t, 1, 1)
+	for _, dut := range []*ondatra.DUTDevice{d.DUT1, d.DUT2} {
+		if !deviations.BGPTCPAOOCUnsupported(dut) {
+			t.Skipf("TCP-AO for BGP is not modelled in OpenConfig; set the bgp_tcp_ao_oc_unsupported deviation of %s to configure it through CLI", dut.ID())
+		}
+	}
+	s := sides(d)
+	d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+		s[dut].configure(t)
+	})
+
+	t.Run("Matching keys", func(t *testing.T) {
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].awaitSession(t, true, 2*time.Minute)
+		})
+	})
+
+	t.Run("Mismatched key", func(t *testing.T) {
+		secret := gnmi.OC().Keychain(keychainName).Key(oc.UnionUint64(1)).SecretKey().Config()
+		gnmi.Replace(t, d.DUT2, secret, mismatch)
+		s1 := s[d.DUT1]
+		s1.awaitSession(t, false, (holdTime+10)*time.Second)
+		s1.verifySession(t, false, 2*holdTime*time.Second)
+
+		gnmi.Replace(t, d.DUT2, secret, secret1)
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].awaitSession(t, true, 2*time.Minute)
+		})
+	})
+
+	t.Run("Key rotation", func(t *testing.T) {
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].transitions0 = s[dut].transitions(t)
+		})
+		now := time.Now()
+		rollover := now.Add(rolloverDelay)
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].rotateKeys(t, now, rollover)
+		})
+		t.Logf("Key 2 is sent from %v", rollover)
+
+		// The session must stay established until key 1 is no longer
+		// accepted, and after key 1 is removed.
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].verifySession(t, true, time.Until(rollover.Add(receiveOverlap))+2*holdTime*time.Second)
+		})
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			gnmi.Delete(t, dut, gnmi.OC().Keychain(keychainName).Key(oc.UnionUint64(1)).Config())
+		})
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].verifySession(t, true, 2*holdTime*time.Second)
+		})
+
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			if got, want := s[dut].transitions(t), s[dut].transitions0; got != want {
+				t.Errorf("BGP session to %s established-transitions: got %d, want %d", s[dut].peer.IPv4, got, want)
+			}
+		})
+	})
+}
@@ -0,0 +1,318 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package community_scale_test
+
+import (
+	"flag"
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var (
+	routesPerGroup      = flag.Int("routes_per_group", 250, "The number of /24 routes in each route group advertised by the ATE, at most 256.")
+	communitiesPerRoute = flag.Int("communities_per_route", 400, "The number of communities carried by each route advertised by the ATE.")
+	convergenceBudget   = flag.Duration("convergence_budget", 2*time.Minute, "The maximum time from starting the ATE session until the DUT installs the routes accepted by the regex policy.")
+)
+
+const (
+	regexPolicy    = "COMMUNITY-REGEX"
+	regexCommunity = "REGEX-COMMUNITIES"
+	// fillerAS is the AS of the communities that fill each route up to
+	// --communities_per_route.  No member of REGEX-COMMUNITIES matches them.
+	fillerAS = 65000
+	// maxCommunities keeps the communities of a route within a 4096 byte
+	// BGP UPDATE.
+	maxCommunities = 900
+	prefixLen      = 24
+	sessionTimeout = 2 * time.Minute
+	// convergenceTimeout is the time to wait for the DUT to install the
+	// routes, longer than --convergence_budget so the time it took can be
+	// reported.
+	convergenceTimeout = 10 * time.Minute
+)
+
+// regexMembers are the members of REGEX-COMMUNITIES.  Only the first one
+// matches the communities of the routes; the others make the DUT evaluate
+// every member against every community of the routes that do not match.
+var regexMembers = []string{
+	"^10[0-9]:1$",
+	"^20[0-9]:2$",
+	"^3[0-9]{2}:[0-9]+$",
+	"^6451[0-9]:.*$",
+}
+
+// routeGroup is a range of routes advertised by the ATE with the same
+// communities.
+type routeGroup struct {
+	name  string
+	start string
+	// marker is the community that decides whether the routes match
+	// REGEX-COMMUNITIES, if any.
+	marker [2]uint32
+	// markerFirst puts marker before the filler communities instead of after
+	// them.
+	markerFirst bool
+	accept      bool
+}
+
+var routeGroups = []routeGroup{
+	{name: "match-first", start: "100.64.0.0", marker: [2]uint32{101, 1}, markerFirst: true, accept: true},
+	{name: "match-last", start: "100.65.0.0", marker: [2]uint32{109, 1}, accept: true},
+	{name: "no-match", start: "100.66.0.0", marker: [2]uint32{201, 1}},
+	{name: "filler-only", start: "100.67.0.0"},
+}
+
+// lastPrefix returns the last prefix of the routes of g.
+func (g routeGroup) lastPrefix() string {
+	a := netip.MustParseAddr(g.start).As4()
+	a[2] += byte(*routesPerGroup - 1)
+	return fmt.Sprintf("%s/%d", netip.AddrFrom4(a), prefixLen)
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test steps:
+//   - Establish an eBGP session between DUT port-1 and ATE port-1.  The ATE
+//     advertises four groups of --routes_per_group /24 routes, each route
+//     carrying --communities_per_route communities:
+//     1. match-first: 101:1 followed by filler communities.
+//     2. match-last: filler communities followed by 109:1.
+//     3. no-match: filler communities followed by 201:1.
+//     4. filler-only: filler communities.
+//     The filler communities are 65000:1, 65000:2 and so on.
+//   - Permit all: with the import policy PERMIT-ALL, measure the time from
+//     starting the ATE session until the DUT installs all the routes.
+//   - Regex policy: stop the ATE session, and change the import policy to
+//     COMMUNITY-REGEX, which accepts the routes matching any member of the
+//     community-set REGEX-COMMUNITIES and rejects all others.  Start the ATE
+//     session again, and verify the DUT installs the routes of match-first
+//     and match-last, and only those, within --convergence_budget.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut
+//
+// Test notes:
+//   - The convergence time includes pushing the ATE config, ARP and the BGP
+//     session setup, which are the same with both policies, so the time of
+//     Permit all is the baseline of Regex policy.
+
+func atePeer(bs *cfgplugins.BGPSession) gosnappi.BgpV4Peer {
+	return bs.ATEIntfs[0].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+}
+
+func bgpPath(dut *ondatra.DUTDevice) *netinstbgp.NetworkInstance_Protocol_BgpPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp()
+}
+
+// configureDUTPolicy adds REGEX-COMMUNITIES and COMMUNITY-REGEX, which is
+// not applied until the Regex policy case.
+func configureDUTPolicy(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	rp := bs.DUTConf.GetOrCreateRoutingPolicy()
+	if deviations.CommunityMemberRegexUnsupported(dut) {
+		switch dut.Vendor() {
+		case ondatra.CISCO:
+			var b strings.Builder
+			fmt.Fprintf(&b, "community-set %s\n", regexCommunity)
+			for i, m := range regexMembers {
+				sep := ","
+				if i == len(regexMembers)-1 {
+					sep = ""
+				}
+				fmt.Fprintf(&b, " ios-regex '%s'%s\n", m, sep)
+			}
+			b.WriteString("end-set\n")
+			helpers.GnmiCLIConfig(t, dut, b.String())
+		default:
+			t.Fatalf("Unsupported vendor %s for deviation 'CommunityMemberRegexUnsupported'", dut.Vendor())
+		}
+	} else {
+		cs := rp.GetOrCreateDefinedSets().GetOrCreateBgpDefinedSets().GetOrCreateCommunitySet(regexCommunity)
+		var members []oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet_CommunityMember_Union
+		for _, m := range regexMembers {
+			members = append(members, oc.UnionString(m))
+		}
+		cs.SetCommunityMember(members)
+		if !deviations.SkipSetRpMatchSetOptions(dut) {
+			cs.SetMatchSetOptions(oc.BgpPolicy_MatchSetOptionsType_ANY)
+		}
+	}
+
+	pdef := rp.GetOrCreatePolicyDefinition(regexPolicy)
+	match, err := pdef.AppendNewStatement("10")
+	if err != nil {
+		t.Fatalf("AppendNewStatement(%q) failed: %v", "10", err)
+	}
+	if deviations.BGPConditionsMatchCommunitySetUnsupported(dut) {
+		match.GetOrCreateConditions().GetOrCreateBgpConditions().SetCommunitySet(regexCommunity)
+	} else {
+		match.GetOrCreateConditions().GetOrCreateBgpConditions().GetOrCreateMatchCommunitySet().SetCommunitySet(regexCommunity)
+	}
+	match.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+
+	reject, err := pdef.AppendNewStatement("20")
+	if err != nil {
+		t.Fatalf("AppendNewStatement(%q) failed: %v", "20", err)
+	}
+	reject.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE
+}
+
+// applyImportPolicy replaces the import policy of the peer group of ATE
+// port-1 with policy.
+func applyImportPolicy(t *testing.T, dut *ondatra.DUTDevice, policy string) {
+	t.Helper()
+	pg := bgpPath(dut).PeerGroup(cfgplugins.BGPPeerGroup1)
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		gnmi.Replace(t, dut, pg.ApplyPolicy().ImportPolicy().Config(), []string{policy})
+		return
+	}
+	gnmi.Replace(t, dut, pg.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).ApplyPolicy().ImportPolicy().Config(), []string{policy})
+}
+
+// configureATE adds the route groups to ATE port-1.
+func configureATE(bs *cfgplugins.BGPSession) {
+	for _, g := range routeGroups {
+		r := atePeer(bs).V4Routes().Add().SetName(fmt.Sprintf("%s-%s", bs.ATEPorts[0].Name, g.name))
+		r.SetNextHopIpv4Address(bs.ATEPorts[0].IPv4).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		r.Addresses().Add().SetAddress(g.start).SetPrefix(prefixLen).SetCount(uint32(*routesPerGroup)).SetStep(1)
+
+		var comms [][2]uint32
+		for i := 1; len(comms) < *communitiesPerRoute-1; i++ {
+			comms = append(comms, [2]uint32{fillerAS, uint32(i)})
+		}
+		switch {
+		case g.marker[0] == 0:
+			comms = append(comms, [2]uint32{fillerAS, uint32(len(comms) + 1)})
+		case g.markerFirst:
+			comms = append([][2]uint32{g.marker}, comms...)
+		default:
+			comms = append(comms, g.marker)
+		}
+		for _, comm := range comms {
+			c := r.Communities().Add()
+			c.SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER)
+			c.SetAsNumber(comm[0])
+			c.SetAsCustom(comm[1])
+		}
+	}
+}
+
+// converge pushes the config to the ATE and starts its session, and returns
+// the time until the DUT installs want prefixes from it.
+func converge(t *testing.T, bs *cfgplugins.BGPSession, want uint32) time.Duration {
+	t.Helper()
+	dut := bs.DUT
+	start := time.Now()
+	bs.PushAndStartATE(t)
+	programming.Await(t, dut, sessionTimeout, programming.BGPNeighbor(deviations.DefaultNetworkInstance(dut), "BGP", bs.ATEPorts[0].IPv4))
+	installed := bgpPath(dut).Neighbor(bs.ATEPorts[0].IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Installed().State()
+	got, ok := gnmi.Watch(t, dut, installed, convergenceTimeout, func(v *ygnmi.Value[uint32]) bool {
+		n, present := v.Val()
+		return present && n == want
+	}).Await(t)
+	if !ok {
+		t.Fatalf("Prefixes installed from %s: got %v, want %d", bs.ATEPorts[0].IPv4, got, want)
+	}
+	return time.Since(start)
+}
+
+// verifyGroups verifies the first and last prefix of each route group is in
+// the DUT AFT if and only if the group is accepted.
+func verifyGroups(t *testing.T, dut *ondatra.DUTDevice, accepted func(routeGroup) bool) {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts()
+	for _, g := range routeGroups {
+		want := accepted(g)
+		for _, prefix := range []string{fmt.Sprintf("%s/%d", g.start, prefixLen), g.lastPrefix()} {
+			_, ok := gnmi.Watch(t, dut, afts.Ipv4Entry(prefix).State(), time.Minute, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+				return v.IsPresent() == want
+			}).Await(t)
+			if !ok {
+				t.Errorf("AFT entry %s of %s present: got %t, want %t", prefix, g.name, !want, want)
+			}
+		}
+	}
+}
+
+func TestCommunityScale(t *testing.T) {
+	if *routesPerGroup < 1 || *routesPerGroup > 256 {
+		t.Fatalf("--routes_per_group is %d, want between 1 and 256", *routesPerGroup)
+	}
+	if *communitiesPerRoute < 2 || *communitiesPerRoute > maxCommunities {
+		t.Fatalf("--communities_per_route is %d, want between 2 and %d", *communitiesPerRoute, maxCommunities)
+	}
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port1"}, true, true)
+	configureDUTPolicy(t, bs)
+	configureATE(bs)
+	if err := bs.PushDUT(t); err != nil {
+		t.Fatalf("Failed to push DUT config: %v", err)
+	}
+	dut := bs.DUT
+	installed := bgpPath(dut).Neighbor(bs.ATEPorts[0].IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Installed().State()
+
+	var baseline time.Duration
+	t.Run("Permit all", func(t *testing.T) {
+		baseline = converge(t, bs, uint32(len(routeGroups)**routesPerGroup))
+		t.Logf("DUT installed %d routes with %d communities each with %s in %v", len(routeGroups)**routesPerGroup, *communitiesPerRoute, cfgplugins.RPLPermitAll, baseline)
+		verifyGroups(t, dut, func(routeGroup) bool { return true })
+	})
+
+	t.Run("Regex policy", func(t *testing.T) {
+		bs.ATE.OTG().StopProtocols(t)
+		_, ok := gnmi.Watch(t, dut, installed, sessionTimeout, func(v *ygnmi.Value[uint32]) bool {
+			n, present := v.Val()
+			return !present || n == 0
+		}).Await(t)
+		if !ok {
+			t.Fatalf("DUT did not withdraw the routes from %s after the ATE session stopped", bs.ATEPorts[0].IPv4)
+		}
+		applyImportPolicy(t, dut, regexPolicy)
+
+		var want int
+		for _, g := range routeGroups {
+			if g.accept {
+				want += *routesPerGroup
+			}
+		}
+		elapsed := converge(t, bs, uint32(want))
+		t.Logf("DUT installed %d of %d routes with %d communities each with %s in %v, %v with %s", want, len(routeGroups)**routesPerGroup, *communitiesPerRoute, regexPolicy, elapsed, baseline, cfgplugins.RPLPermitAll)
+		if elapsed > *convergenceBudget {
+			t.Errorf("Convergence with %s: got %v, want at most %v", regexPolicy, elapsed, *convergenceBudget)
+		}
+		verifyGroups(t, dut, func(g routeGroup) bool { return g.accept })
+	})
+}
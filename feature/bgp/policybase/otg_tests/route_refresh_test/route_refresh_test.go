@@ -0,0 +1,301 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route_refresh_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	importPolicy   = "IMPORT-POLICY"
+	importPrefixes = "IMPORT-PREFIXES"
+	routesA        = "routes-a"
+	routesB        = "routes-b"
+	flowPPS        = 1000
+	trafficWarmup  = 10 * time.Second
+	sessionTimeout = 2 * time.Minute
+	// refreshTimeout is the time for the DUT to apply a changed import
+	// policy to the routes it received.
+	refreshTimeout = 2 * time.Minute
+)
+
+// routes are the route ranges advertised by ATE port-2, keyed by name.
+var routes = map[string]struct {
+	address string
+	prefix  uint32
+}{
+	routesA: {"198.51.100.0", 24},
+	routesB: {"203.0.113.0", 24},
+}
+
+func cidr(name string) string {
+	return fmt.Sprintf("%s/%d", routes[name].address, routes[name].prefix)
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Route refresh: ATE port-2 advertises the route refresh capability.
+//  2. Soft reconfiguration: ATE port-2 does not advertise the route refresh
+//     capability, so the DUT must apply the changed policy to the routes it
+//     retained before policy.
+//
+// For each case:
+//   - Establish an eBGP session between the DUT and ATE port-2, which
+//     advertises route ranges A and B.  The DUT import policy accepts the
+//     prefixes of prefix-set IMPORT-PREFIXES, which only has A.
+//   - Verify A is installed and B is not.
+//   - Start traffic from ATE port-1 to A and B.
+//   - Add B to IMPORT-PREFIXES, without resetting the session.
+//   - Verify B is installed, the session did not flap, and traffic to A had
+//     no loss and traffic to B was received.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - OpenConfig has no leaf for soft reconfiguration inbound, so in case 2
+//     the DUT must retain the routes received from a peer without route
+//     refresh by default.
+//   - In case 1 the DUT may either send a ROUTE-REFRESH or apply the policy
+//     to retained routes.  Which one it did is logged from the UPDATE
+//     messages sent by ATE port-2.
+
+func atePeer(bs *cfgplugins.BGPSession) gosnappi.BgpV4Peer {
+	return bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+}
+
+func bgpNeighbor(bs *cfgplugins.BGPSession) *netinstbgp.NetworkInstance_Protocol_Bgp_NeighborPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(bs.ATEPorts[1].IPv4)
+}
+
+// prefixSet returns IMPORT-PREFIXES with the route ranges names.
+func prefixSet(dut *ondatra.DUTDevice, names ...string) *oc.RoutingPolicy_DefinedSets_PrefixSet {
+	ps := &oc.RoutingPolicy_DefinedSets_PrefixSet{Name: ygot.String(importPrefixes)}
+	if !deviations.SkipPrefixSetMode(dut) {
+		ps.SetMode(oc.PrefixSet_Mode_IPV4)
+	}
+	for _, name := range names {
+		ps.GetOrCreatePrefix(cidr(name), "exact")
+	}
+	return ps
+}
+
+// configureDUTPolicy adds the import policy, which accepts the prefixes of
+// IMPORT-PREFIXES and rejects all others, to the peer group of ATE port-2.
+func configureDUTPolicy(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	rp := bs.DUTConf.GetOrCreateRoutingPolicy()
+	rp.GetOrCreateDefinedSets().PrefixSet = map[string]*oc.RoutingPolicy_DefinedSets_PrefixSet{
+		importPrefixes: prefixSet(dut, routesA),
+	}
+	pdef := rp.GetOrCreatePolicyDefinition(importPolicy)
+	accept, err := pdef.AppendNewStatement("10")
+	if err != nil {
+		t.Fatalf("AppendNewStatement(%q) failed: %v", "10", err)
+	}
+	match := accept.GetOrCreateConditions().GetOrCreateMatchPrefixSet()
+	match.SetPrefixSet(importPrefixes)
+	if !deviations.SkipSetRpMatchSetOptions(dut) {
+		match.SetMatchSetOptions(oc.RoutingPolicy_MatchSetOptionsRestrictedType_ANY)
+	}
+	accept.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+	reject, err := pdef.AppendNewStatement("20")
+	if err != nil {
+		t.Fatalf("AppendNewStatement(%q) failed: %v", "20", err)
+	}
+	reject.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE
+
+	pg := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").GetOrCreateBgp().GetOrCreatePeerGroup(cfgplugins.BGPPeerGroup2)
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		pg.GetOrCreateApplyPolicy().SetImportPolicy([]string{importPolicy})
+	} else {
+		pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateApplyPolicy().SetImportPolicy([]string{importPolicy})
+	}
+}
+
+// configureATE adds the route ranges to ATE port-2, and a flow from ATE
+// port-1 to each of them.
+func configureATE(bs *cfgplugins.BGPSession) {
+	for _, name := range []string{routesA, routesB} {
+		r := atePeer(bs).V4Routes().Add().SetName(name)
+		r.SetNextHopIpv4Address(bs.ATEPorts[1].IPv4).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		r.Addresses().Add().SetAddress(routes[name].address).SetPrefix(routes[name].prefix)
+
+		flow := bs.ATETop.Flows().Add().SetName("to-" + name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().
+			SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+			SetRxNames([]string{name})
+		flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+		v4.Dst().Increment().SetStart(routes[name].address).SetCount(100)
+		flow.Rate().SetPps(flowPPS)
+	}
+}
+
+// awaitInstalled waits for the DUT to report want prefixes installed from
+// ATE port-2.
+func awaitInstalled(t *testing.T, bs *cfgplugins.BGPSession, want uint32) {
+	t.Helper()
+	installed := bgpNeighbor(bs).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Installed().State()
+	got, ok := gnmi.Watch(t, bs.DUT, installed, refreshTimeout, func(v *ygnmi.Value[uint32]) bool {
+		n, present := v.Val()
+		return present && n == want
+	}).Await(t)
+	if !ok {
+		t.Errorf("Prefixes installed from %s: got %v, want %d", bs.ATEPorts[1].IPv4, got, want)
+	}
+}
+
+// awaitAFT waits for the IPv4 AFT entry of the route range name to be
+// present, if present is true, or absent.
+func awaitAFT(t *testing.T, dut *ondatra.DUTDevice, name string, present bool) {
+	t.Helper()
+	entry := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts().Ipv4Entry(cidr(name))
+	_, ok := gnmi.Watch(t, dut, entry.State(), refreshTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+		return v.IsPresent() == present
+	}).Await(t)
+	if !ok {
+		t.Errorf("AFT entry %s for %s present: got %t, want %t", cidr(name), name, !present, present)
+	}
+}
+
+// sessionCounters are the counters that tell whether the session of ATE
+// port-2 was reset, and whether the DUT requested a route refresh.
+type sessionCounters struct {
+	transitions uint64
+	flaps       uint64
+	outUpdates  uint64
+}
+
+func getSessionCounters(t *testing.T, bs *cfgplugins.BGPSession) sessionCounters {
+	t.Helper()
+	peer := gnmi.OTG().BgpPeer(atePeer(bs).Name()).Counters()
+	return sessionCounters{
+		transitions: gnmi.Get(t, bs.DUT, bgpNeighbor(bs).EstablishedTransitions().State()),
+		flaps:       gnmi.Get(t, bs.ATE.OTG(), peer.Flaps().State()),
+		outUpdates:  gnmi.Get(t, bs.ATE.OTG(), peer.OutUpdates().State()),
+	}
+}
+
+func verifyTraffic(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	otg := bs.ATE.OTG()
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+	if loss := otgutils.GetFlowLossPct(t, otg, "to-"+routesA, 10*time.Second); loss > 0 {
+		t.Errorf("Flow to %s: got %.2f%% loss across the policy change, want 0", routesA, loss)
+	}
+	if rx := gnmi.Get(t, otg, gnmi.OTG().Flow("to-"+routesB).Counters().InPkts().State()); rx == 0 {
+		t.Errorf("Flow to %s: got no packets received after the policy change, want some", routesB)
+	}
+}
+
+func TestRouteRefresh(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port2"}, false, false)
+	configureDUTPolicy(t, bs)
+	configureATE(bs)
+	if err := bs.PushDUT(t); err != nil {
+		t.Fatalf("Failed to push DUT config: %v", err)
+	}
+	dut := bs.DUT
+	ni := deviations.DefaultNetworkInstance(dut)
+	prefixSetPath := gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(importPrefixes).Config()
+
+	cases := []struct {
+		desc         string
+		routeRefresh bool
+	}{
+		{"Route refresh", true},
+		{"Soft reconfiguration", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gnmi.Replace(t, dut, prefixSetPath, prefixSet(dut, routesA))
+			atePeer(bs).Capability().SetRouteRefresh(tc.routeRefresh)
+			bs.PushAndStartATE(t)
+			programming.Await(t, dut, sessionTimeout,
+				programming.BGPNeighbor(ni, "BGP", bs.ATEPorts[1].IPv4),
+				programming.IPv4Entry(ni, cidr(routesA)),
+			)
+			awaitInstalled(t, bs, 1)
+			awaitAFT(t, dut, routesB, false)
+
+			caps := gnmi.Get(t, dut, bgpNeighbor(bs).SupportedCapabilities().State())
+			t.Logf("Capabilities of session with %s: %v", bs.ATEPorts[1].IPv4, caps)
+			if !tc.routeRefresh && !deviations.MissingPrePolicyReceivedRoutes(dut) {
+				prePolicy := bgpNeighbor(bs).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().ReceivedPrePolicy().State()
+				if got, want := gnmi.Get(t, dut, prePolicy), uint32(len(routes)); got != want {
+					t.Errorf("Prefixes received pre-policy from %s without route refresh: got %d, want %d", bs.ATEPorts[1].IPv4, got, want)
+				}
+			}
+
+			otg := bs.ATE.OTG()
+			before := getSessionCounters(t, bs)
+			otg.StartTraffic(t)
+			time.Sleep(trafficWarmup)
+
+			t.Logf("Adding %s to prefix-set %s", cidr(routesB), importPrefixes)
+			gnmi.Replace(t, dut, prefixSetPath, prefixSet(dut, routesA, routesB))
+			awaitInstalled(t, bs, uint32(len(routes)))
+			awaitAFT(t, dut, routesB, true)
+
+			time.Sleep(trafficWarmup)
+			otg.StopTraffic(t)
+
+			after := getSessionCounters(t, bs)
+			if got := after.transitions - before.transitions; got != 0 {
+				t.Errorf("Established transitions of session with %s across the policy change: got %d, want 0", bs.ATEPorts[1].IPv4, got)
+			}
+			if got := after.flaps - before.flaps; got != 0 {
+				t.Errorf("Flaps of ATE session with %s across the policy change: got %d, want 0", bs.DUTPorts[1].IPv4, got)
+			}
+			switch updates := after.outUpdates - before.outUpdates; {
+			case updates > 0 && !tc.routeRefresh:
+				t.Errorf("ATE sent %d UPDATE messages across the policy change without route refresh, want 0", updates)
+			case updates > 0:
+				t.Logf("DUT requested a route refresh: ATE sent %d UPDATE messages", updates)
+			default:
+				t.Log("DUT applied the policy to retained routes")
+			}
+			verifyTraffic(t, bs)
+		})
+	}
+}
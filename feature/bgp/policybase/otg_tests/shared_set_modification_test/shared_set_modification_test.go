@@ -0,0 +1,365 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared_set_modification_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	sharedPrefixes    = "SHARED-PREFIXES"
+	sharedCommunities = "SHARED-COMMUNITIES"
+	routesA           = "routes-a"
+	routesB           = "routes-b"
+	routesC           = "routes-c"
+	routesD           = "routes-d"
+	community1        = "65000:1"
+	community2        = "65000:2"
+	sessionTimeout    = 2 * time.Minute
+	// updateTimeout is the time for the DUT to apply a changed defined set
+	// to the routes it received.
+	updateTimeout = 2 * time.Minute
+)
+
+// routes are the route ranges advertised by ATE port-1 and port-2, keyed by
+// name.
+var routes = map[string]struct {
+	address   string
+	prefix    uint32
+	community [2]uint32
+}{
+	routesA: {address: "198.51.100.0", prefix: 24},
+	routesB: {address: "203.0.113.0", prefix: 24},
+	routesC: {address: "192.0.2.0", prefix: 24, community: [2]uint32{65000, 1}},
+	routesD: {address: "100.64.0.0", prefix: 24, community: [2]uint32{65000, 2}},
+}
+
+// importPolicies are the import policies of the DUT, keyed by the index of
+// the ATE port of the peer group they are applied to.
+var importPolicies = map[int]struct {
+	name      string
+	peerGroup string
+}{
+	0: {"IMPORT-POLICY-1", cfgplugins.BGPPeerGroup1},
+	1: {"IMPORT-POLICY-2", cfgplugins.BGPPeerGroup2},
+}
+
+func cidr(name string) string {
+	return fmt.Sprintf("%s/%d", routes[name].address, routes[name].prefix)
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test steps:
+//   - Establish eBGP sessions between the DUT and ATE port-1 and port-2, which
+//     both advertise route ranges A, B, C and D.  C has community 65000:1 and
+//     D has community 65000:2.
+//   - The DUT has a different import policy on the peer group of each ATE
+//     port.  Both policies accept the prefixes of prefix-set SHARED-PREFIXES,
+//     which has A, and the routes with a community of community-set
+//     SHARED-COMMUNITIES, which has 65000:1, and reject all others.
+//   - Verify A and C are installed from both ATE ports.
+//   - Modify the shared sets in place, one entry at a time:
+//     1. Add B to SHARED-PREFIXES.
+//     2. Delete A from SHARED-PREFIXES.
+//     3. Add 65000:2 to SHARED-COMMUNITIES.
+//     4. Delete 65000:1 from SHARED-COMMUNITIES.
+//   - After each step, verify the state of the set has the change, the routes
+//     installed from both ATE ports follow it, and neither session was reset.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+
+func atePeer(bs *cfgplugins.BGPSession, port int) gosnappi.BgpV4Peer {
+	return bs.ATEIntfs[port].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+}
+
+func bgpNeighbor(bs *cfgplugins.BGPSession, port int) *netinstbgp.NetworkInstance_Protocol_Bgp_NeighborPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(bs.ATEPorts[port].IPv4)
+}
+
+// configureDUTPolicy adds the shared sets, and an import policy that
+// references both of them to the peer group of each ATE port.
+func configureDUTPolicy(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	rp := bs.DUTConf.GetOrCreateRoutingPolicy()
+	ps := rp.GetOrCreateDefinedSets().GetOrCreatePrefixSet(sharedPrefixes)
+	if !deviations.SkipPrefixSetMode(dut) {
+		ps.SetMode(oc.PrefixSet_Mode_IPV4)
+	}
+	ps.GetOrCreatePrefix(cidr(routesA), "exact")
+	cs := rp.GetOrCreateDefinedSets().GetOrCreateBgpDefinedSets().GetOrCreateCommunitySet(sharedCommunities)
+	cs.SetCommunityMember([]oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet_CommunityMember_Union{
+		oc.UnionString(community1),
+	})
+	if !deviations.SkipSetRpMatchSetOptions(dut) {
+		cs.SetMatchSetOptions(oc.BgpPolicy_MatchSetOptionsType_ANY)
+	}
+
+	bgp := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").GetOrCreateBgp()
+	for _, ip := range importPolicies {
+		pdef := rp.GetOrCreatePolicyDefinition(ip.name)
+		matchPrefixes, err := pdef.AppendNewStatement("10")
+		if err != nil {
+			t.Fatalf("AppendNewStatement(%q) failed: %v", "10", err)
+		}
+		match := matchPrefixes.GetOrCreateConditions().GetOrCreateMatchPrefixSet()
+		match.SetPrefixSet(sharedPrefixes)
+		if !deviations.SkipSetRpMatchSetOptions(dut) {
+			match.SetMatchSetOptions(oc.RoutingPolicy_MatchSetOptionsRestrictedType_ANY)
+		}
+		matchPrefixes.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+
+		matchCommunities, err := pdef.AppendNewStatement("20")
+		if err != nil {
+			t.Fatalf("AppendNewStatement(%q) failed: %v", "20", err)
+		}
+		if deviations.BGPConditionsMatchCommunitySetUnsupported(dut) {
+			matchCommunities.GetOrCreateConditions().GetOrCreateBgpConditions().SetCommunitySet(sharedCommunities)
+		} else {
+			matchCommunities.GetOrCreateConditions().GetOrCreateBgpConditions().GetOrCreateMatchCommunitySet().SetCommunitySet(sharedCommunities)
+		}
+		matchCommunities.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+
+		reject, err := pdef.AppendNewStatement("30")
+		if err != nil {
+			t.Fatalf("AppendNewStatement(%q) failed: %v", "30", err)
+		}
+		reject.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE
+
+		pg := bgp.GetOrCreatePeerGroup(ip.peerGroup)
+		if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+			pg.GetOrCreateApplyPolicy().SetImportPolicy([]string{ip.name})
+		} else {
+			pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateApplyPolicy().SetImportPolicy([]string{ip.name})
+		}
+	}
+}
+
+// configureATE adds the route ranges to ATE port-1 and port-2.
+func configureATE(bs *cfgplugins.BGPSession) {
+	for port := range importPolicies {
+		for _, name := range []string{routesA, routesB, routesC, routesD} {
+			r := atePeer(bs, port).V4Routes().Add().SetName(fmt.Sprintf("%s-%s", bs.ATEPorts[port].Name, name))
+			r.SetNextHopIpv4Address(bs.ATEPorts[port].IPv4).
+				SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+				SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+			r.Addresses().Add().SetAddress(routes[name].address).SetPrefix(routes[name].prefix)
+			if comm := routes[name].community; comm[0] != 0 {
+				c := r.Communities().Add()
+				c.SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER)
+				c.SetAsNumber(comm[0])
+				c.SetAsCustom(comm[1])
+			}
+		}
+	}
+}
+
+// verifyInstalled waits for the DUT to install the route ranges want, and
+// only those, from both ATE ports.
+func verifyInstalled(t *testing.T, bs *cfgplugins.BGPSession, want ...string) {
+	t.Helper()
+	dut := bs.DUT
+	for port := range importPolicies {
+		installed := bgpNeighbor(bs, port).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Installed().State()
+		got, ok := gnmi.Watch(t, dut, installed, updateTimeout, func(v *ygnmi.Value[uint32]) bool {
+			n, present := v.Val()
+			return present && n == uint32(len(want))
+		}).Await(t)
+		if !ok {
+			t.Errorf("Prefixes installed from %s: got %v, want %d", bs.ATEPorts[port].IPv4, got, len(want))
+		}
+	}
+	for name := range routes {
+		present := false
+		for _, w := range want {
+			present = present || w == name
+		}
+		entry := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts().Ipv4Entry(cidr(name))
+		_, ok := gnmi.Watch(t, dut, entry.State(), updateTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			return v.IsPresent() == present
+		}).Await(t)
+		if !ok {
+			t.Errorf("AFT entry %s for %s present: got %t, want %t", cidr(name), name, !present, present)
+		}
+	}
+}
+
+// verifyPrefixSet verifies the state of SHARED-PREFIXES has the route ranges
+// want.
+func verifyPrefixSet(t *testing.T, dut *ondatra.DUTDevice, want ...string) {
+	t.Helper()
+	var wantPrefixes []string
+	for _, name := range want {
+		wantPrefixes = append(wantPrefixes, cidr(name))
+	}
+	sort.Strings(wantPrefixes)
+	var gotPrefixes []string
+	for _, p := range gnmi.Get(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(sharedPrefixes).State()).Prefix {
+		gotPrefixes = append(gotPrefixes, p.GetIpPrefix())
+	}
+	sort.Strings(gotPrefixes)
+	if diff := cmp.Diff(wantPrefixes, gotPrefixes); diff != "" {
+		t.Errorf("Prefixes of prefix-set %s: (-want +got):\n%s", sharedPrefixes, diff)
+	}
+}
+
+// verifyCommunitySet verifies the state of SHARED-COMMUNITIES has the
+// communities want.
+func verifyCommunitySet(t *testing.T, dut *ondatra.DUTDevice, want ...string) {
+	t.Helper()
+	var got []string
+	members := gnmi.OC().RoutingPolicy().DefinedSets().BgpDefinedSets().CommunitySet(sharedCommunities).CommunityMember().State()
+	for _, m := range gnmi.Get(t, dut, members) {
+		got = append(got, fmt.Sprint(m))
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Members of community-set %s: (-want +got):\n%s", sharedCommunities, diff)
+	}
+}
+
+// sessionCounters are the counters that tell whether the session of an ATE
+// port was reset.
+type sessionCounters struct {
+	transitions uint64
+	flaps       uint64
+}
+
+func getSessionCounters(t *testing.T, bs *cfgplugins.BGPSession) map[int]sessionCounters {
+	t.Helper()
+	counters := make(map[int]sessionCounters)
+	for port := range importPolicies {
+		counters[port] = sessionCounters{
+			transitions: gnmi.Get(t, bs.DUT, bgpNeighbor(bs, port).EstablishedTransitions().State()),
+			flaps:       gnmi.Get(t, bs.ATE.OTG(), gnmi.OTG().BgpPeer(atePeer(bs, port).Name()).Counters().Flaps().State()),
+		}
+	}
+	return counters
+}
+
+func verifyNoReset(t *testing.T, bs *cfgplugins.BGPSession, before, after map[int]sessionCounters) {
+	t.Helper()
+	for port := range importPolicies {
+		if got := after[port].transitions - before[port].transitions; got != 0 {
+			t.Errorf("Established transitions of session with %s across the set change: got %d, want 0", bs.ATEPorts[port].IPv4, got)
+		}
+		if got := after[port].flaps - before[port].flaps; got != 0 {
+			t.Errorf("Flaps of ATE session with %s across the set change: got %d, want 0", bs.DUTPorts[port].IPv4, got)
+		}
+	}
+}
+
+func TestSharedSetModification(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port1", "port2"}, false, false)
+	configureDUTPolicy(t, bs)
+	configureATE(bs)
+	if err := bs.PushDUT(t); err != nil {
+		t.Fatalf("Failed to push DUT config: %v", err)
+	}
+	bs.PushAndStartATE(t)
+
+	dut := bs.DUT
+	ni := deviations.DefaultNetworkInstance(dut)
+	programming.Await(t, dut, sessionTimeout,
+		programming.BGPNeighbor(ni, "BGP", bs.ATEPorts[0].IPv4),
+		programming.BGPNeighbor(ni, "BGP", bs.ATEPorts[1].IPv4),
+	)
+	verifyPrefixSet(t, dut, routesA)
+	verifyCommunitySet(t, dut, community1)
+	verifyInstalled(t, bs, routesA, routesC)
+
+	prefixSet := gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(sharedPrefixes)
+	members := gnmi.OC().RoutingPolicy().DefinedSets().BgpDefinedSets().CommunitySet(sharedCommunities).CommunityMember()
+	setMembers := func(t *testing.T, comms ...string) {
+		var cm []oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet_CommunityMember_Union
+		for _, c := range comms {
+			cm = append(cm, oc.UnionString(c))
+		}
+		gnmi.Replace(t, dut, members.Config(), cm)
+	}
+
+	cases := []struct {
+		desc        string
+		modify      func(t *testing.T)
+		prefixes    []string
+		communities []string
+		installed   []string
+	}{{
+		desc: "Add prefix to prefix-set",
+		modify: func(t *testing.T) {
+			gnmi.Replace(t, dut, prefixSet.Prefix(cidr(routesB), "exact").Config(), &oc.RoutingPolicy_DefinedSets_PrefixSet_Prefix{
+				IpPrefix:        ygot.String(cidr(routesB)),
+				MasklengthRange: ygot.String("exact"),
+			})
+		},
+		prefixes:    []string{routesA, routesB},
+		communities: []string{community1},
+		installed:   []string{routesA, routesB, routesC},
+	}, {
+		desc: "Delete prefix from prefix-set",
+		modify: func(t *testing.T) {
+			gnmi.Delete(t, dut, prefixSet.Prefix(cidr(routesA), "exact").Config())
+		},
+		prefixes:    []string{routesB},
+		communities: []string{community1},
+		installed:   []string{routesB, routesC},
+	}, {
+		desc:        "Add community to community-set",
+		modify:      func(t *testing.T) { setMembers(t, community1, community2) },
+		prefixes:    []string{routesB},
+		communities: []string{community1, community2},
+		installed:   []string{routesB, routesC, routesD},
+	}, {
+		desc:        "Delete community from community-set",
+		modify:      func(t *testing.T) { setMembers(t, community2) },
+		prefixes:    []string{routesB},
+		communities: []string{community2},
+		installed:   []string{routesB, routesD},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			before := getSessionCounters(t, bs)
+			tc.modify(t)
+			verifyPrefixSet(t, dut, tc.prefixes...)
+			verifyCommunitySet(t, dut, tc.communities...)
+			verifyInstalled(t, bs, tc.installed...)
+			verifyNoReset(t, bs, before, getSessionCounters(t, bs))
+		})
+	}
+}
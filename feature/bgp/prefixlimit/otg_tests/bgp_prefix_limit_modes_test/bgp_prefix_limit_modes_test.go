@@ -0,0 +1,361 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_prefix_limit_modes_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var restartTimer = flag.Duration("restart_timer", 0, "Prefix-limit restart timer the DUT is configured with out of band.  The restart check is skipped when 0.")
+
+const (
+	prefixLimit = 100
+	warnPct     = 75
+	// baseCount routes are above the warning threshold but within the limit,
+	// and baseCount+excessCount routes exceed it.
+	baseCount      = 80
+	excessCount    = 30
+	baseStart      = "198.51.100.0"
+	excessStart    = "198.51.100.80"
+	baseRoutes     = "base"
+	excessRoutes   = "excess"
+	flowName       = "to-base"
+	flowPkts       = 1000
+	pps            = 100
+	lossTol        = 1.0
+	stableWindow   = 30 * time.Second
+	sessionTimeout = 2 * time.Minute
+	// restartSlack is how long after --restart_timer the session may take to
+	// re-establish.
+	restartSlack = time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. WarningOnly: configure max-prefixes 100, warning-threshold-pct 75 and
+//     prevent-teardown on the IPv4 session with ATE port-2.
+//     a. Advertise 80 routes from ATE port-2.  Verify the prefix-limit
+//        config is reflected in state, prefix-limit-exceeded is false, and
+//        traffic from ATE port-1 to the routes is forwarded.
+//     b. Advertise 30 more routes.  Verify prefix-limit-exceeded is true, the
+//        session stays ESTABLISHED without any new established transition,
+//        and traffic to the first 80 routes is still forwarded.
+//     c. Withdraw the 30 routes, and verify prefix-limit-exceeded is false.
+//  2. Teardown: configure the same limit without prevent-teardown.
+//     a. Advertise 80 routes from ATE port-2, and verify the session is
+//        ESTABLISHED and prefix-limit-exceeded is false.
+//     b. Advertise 30 more routes.  Verify the session leaves ESTABLISHED,
+//        prefix-limit-exceeded is true, the DUT sent a CEASE notification
+//        with subcode MAX_NUM_PREFIXES_REACHED, a syslog message about the
+//        neighbor is streamed on /system/messages, and traffic to the first
+//        80 routes is dropped.
+//     c. Withdraw the 30 routes.  With --restart_timer set, verify the session
+//        does not re-establish before the restart timer, re-establishes
+//        within the restart timer plus one minute, and traffic is forwarded
+//        again.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The OpenConfig models used here have no prefix-limit restart-timer
+//     leaf, so the restart timer is configured on the DUT out of band and
+//     passed to the test with --restart_timer.
+//   - ATE port-2 advertises the routes within the limit before the excess
+//     routes, so the routes received before the limit is exceeded are known
+//     regardless of the order the DUT processes updates in.
+
+func neighborPath(bs *cfgplugins.BGPSession) *netinstbgp.NetworkInstance_Protocol_Bgp_NeighborPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(bs.ATEPorts[1].IPv4)
+}
+
+// configurePrefixLimit replaces the IPv4 unicast prefix-limit of the ATE
+// port-2 session.
+func configurePrefixLimit(t *testing.T, bs *cfgplugins.BGPSession, preventTeardown bool) {
+	t.Helper()
+	t.Logf("Configuring max-prefixes %d, warning-threshold-pct %d, prevent-teardown %v", prefixLimit, warnPct, preventTeardown)
+	v4 := neighborPath(bs).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast()
+	if deviations.BGPExplicitPrefixLimitReceived(bs.DUT) {
+		gnmi.Replace(t, bs.DUT, v4.PrefixLimitReceived().Config(), &oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi_Ipv4Unicast_PrefixLimitReceived{
+			MaxPrefixes:         ygot.Uint32(prefixLimit),
+			WarningThresholdPct: ygot.Uint8(warnPct),
+			PreventTeardown:     ygot.Bool(preventTeardown),
+		})
+		return
+	}
+	gnmi.Replace(t, bs.DUT, v4.PrefixLimit().Config(), &oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi_Ipv4Unicast_PrefixLimit{
+		MaxPrefixes:         ygot.Uint32(prefixLimit),
+		WarningThresholdPct: ygot.Uint8(warnPct),
+		PreventTeardown:     ygot.Bool(preventTeardown),
+	})
+}
+
+// limitState is the prefix-limit state of a session.
+type limitState struct {
+	maxPrefixes     uint32
+	warnPct         uint8
+	preventTeardown bool
+	exceeded        bool
+}
+
+func prefixLimitState(dut *ondatra.DUTDevice, v4 *oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi_Ipv4Unicast) limitState {
+	if deviations.BGPExplicitPrefixLimitReceived(dut) {
+		p := v4.GetPrefixLimitReceived()
+		return limitState{p.GetMaxPrefixes(), p.GetWarningThresholdPct(), p.GetPreventTeardown(), p.GetPrefixLimitExceeded()}
+	}
+	p := v4.GetPrefixLimit()
+	return limitState{p.GetMaxPrefixes(), p.GetWarningThresholdPct(), p.GetPreventTeardown(), p.GetPrefixLimitExceeded()}
+}
+
+// verifyPrefixLimit verifies the prefix-limit state of the ATE port-2
+// session reflects its config, and waits for prefix-limit-exceeded to be
+// wantExceeded.
+func verifyPrefixLimit(t *testing.T, bs *cfgplugins.BGPSession, preventTeardown, wantExceeded bool) {
+	t.Helper()
+	want := limitState{prefixLimit, warnPct, preventTeardown, wantExceeded}
+	ignoreExceeded := deviations.PrefixLimitExceededTelemetryUnsupported(bs.DUT)
+	var got limitState
+	_, ok := gnmi.Watch(t, bs.DUT, neighborPath(bs).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Ipv4Unicast().State(), sessionTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Protocol_Bgp_Neighbor_AfiSafi_Ipv4Unicast]) bool {
+		v4, present := v.Val()
+		if !present {
+			return false
+		}
+		got = prefixLimitState(bs.DUT, v4)
+		if ignoreExceeded {
+			got.exceeded = want.exceeded
+		}
+		return got == want
+	}).Await(t)
+	if !ok {
+		t.Errorf("Prefix-limit state: got %+v, want %+v", got, want)
+	}
+}
+
+func isEstablished(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+	s, ok := v.Val()
+	return ok && s == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+}
+
+// awaitSession waits for the ATE port-2 session to be ESTABLISHED, if
+// established is true, or to leave ESTABLISHED.  It returns the time the
+// state was observed.
+func awaitSession(t *testing.T, bs *cfgplugins.BGPSession, established bool, timeout time.Duration) (time.Time, bool) {
+	t.Helper()
+	v, ok := gnmi.Watch(t, bs.DUT, neighborPath(bs).SessionState().State(), timeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		return v.IsPresent() && isEstablished(v) == established
+	}).Await(t)
+	if !ok {
+		return time.Time{}, false
+	}
+	s, _ := v.Val()
+	t.Logf("Session with %s is %v", bs.ATEPorts[1].IPv4, s)
+	return time.Now(), true
+}
+
+// verifyStable verifies the ATE port-2 session stays ESTABLISHED for
+// stableWindow without a new established transition.
+func verifyStable(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	nbr := neighborPath(bs)
+	before := gnmi.Get(t, bs.DUT, nbr.EstablishedTransitions().State())
+	states := gnmi.Collect(t, bs.DUT, nbr.SessionState().State(), stableWindow).Await(t)
+	for _, s := range states {
+		if s.IsPresent() && !isEstablished(s) {
+			got, _ := s.Val()
+			t.Errorf("Session with %s went to %v at %v, want it to stay ESTABLISHED", bs.ATEPorts[1].IPv4, got, s.Timestamp)
+		}
+	}
+	if after := gnmi.Get(t, bs.DUT, nbr.EstablishedTransitions().State()); after != before {
+		t.Errorf("Established transitions of the session with %s: got %d, want %d", bs.ATEPorts[1].IPv4, after, before)
+	}
+}
+
+// verifyNotification verifies the DUT sent the ATE port-2 session a CEASE
+// notification for exceeding the prefix limit.
+func verifyNotification(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	sent := neighborPath(bs).Messages().Sent()
+	if got, want := gnmi.Get(t, bs.DUT, sent.LastNotificationErrorCode().State()), oc.BgpTypes_BGP_ERROR_CODE_CEASE; got != want {
+		t.Errorf("Last sent notification error code: got %v, want %v", got, want)
+	}
+	if got, want := gnmi.Get(t, bs.DUT, sent.LastNotificationErrorSubcode().State()), oc.BgpTypes_BGP_ERROR_SUBCODE_MAX_NUM_PREFIXES_REACHED; got != want {
+		t.Errorf("Last sent notification error subcode: got %v, want %v", got, want)
+	}
+}
+
+// verifyLogged verifies a syslog message about the ATE port-2 neighbor was
+// collected.
+func verifyLogged(t *testing.T, bs *cfgplugins.BGPSession, msgs []*ygnmi.Value[*oc.System_Messages_Message]) {
+	t.Helper()
+	for _, v := range msgs {
+		m, ok := v.Val()
+		if ok && strings.Contains(m.GetMsg(), bs.ATEPorts[1].IPv4) {
+			t.Logf("Syslog message: %s", m.GetMsg())
+			return
+		}
+	}
+	t.Errorf("No syslog message about neighbor %s among %d messages received while the prefix limit was exceeded", bs.ATEPorts[1].IPv4, len(msgs))
+}
+
+// configureATE adds the base and excess route ranges to ATE port-2, and a
+// flow from ATE port-1 to the base routes.
+func configureATE(bs *cfgplugins.BGPSession) {
+	peer := bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+	for _, r := range []struct {
+		name, start string
+		count       uint32
+	}{
+		{baseRoutes, baseStart, baseCount},
+		{excessRoutes, excessStart, excessCount},
+	} {
+		routes := peer.V4Routes().Add().SetName(r.name)
+		routes.SetNextHopIpv4Address(bs.ATEPorts[1].IPv4).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		routes.Addresses().Add().SetAddress(r.start).SetPrefix(32).SetCount(r.count)
+	}
+
+	flow := bs.ATETop.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+		SetRxNames([]string{baseRoutes})
+	flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+	v4.Dst().Increment().SetStart(baseStart).SetCount(baseCount)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPkts)
+}
+
+func setRouteState(t *testing.T, bs *cfgplugins.BGPSession, state gosnappi.StateProtocolRouteStateEnum, names ...string) {
+	t.Helper()
+	t.Logf("Setting routes %v to %v", names, state)
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames(names).SetState(state)
+	bs.ATE.OTG().SetControlState(t, cs)
+}
+
+// verifyTraffic sends the flow to the base routes, and verifies it is
+// forwarded if wantForwarded is true, or dropped.
+func verifyTraffic(t *testing.T, bs *cfgplugins.BGPSession, wantForwarded bool) {
+	t.Helper()
+	otg := bs.ATE.OTG()
+	otg.StartTraffic(t)
+	time.Sleep(flowPkts/pps*time.Second + 5*time.Second)
+	otg.StopTraffic(t)
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+
+	loss := otgutils.GetFlowLossPct(t, otg, flowName, 10*time.Second)
+	if wantForwarded && loss > lossTol {
+		t.Errorf("Flow %s: got %.2f%% loss, want <= %.2f%%", flowName, loss, lossTol)
+	}
+	if !wantForwarded && loss < 100-lossTol {
+		t.Errorf("Flow %s: got %.2f%% loss, want >= %.2f%%", flowName, loss, 100-lossTol)
+	}
+}
+
+func TestPrefixLimitModes(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port1", "port2"}, false, false)
+	configureATE(bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	cfgplugins.VerifyDUTBGPEstablished(t, bs.DUT)
+	cfgplugins.VerifyOTGBGPEstablished(t, bs.ATE)
+	setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, baseRoutes, excessRoutes)
+
+	t.Run("WarningOnly", func(t *testing.T) {
+		configurePrefixLimit(t, bs, true)
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE, baseRoutes)
+		defer setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, baseRoutes, excessRoutes)
+		if _, ok := awaitSession(t, bs, true, sessionTimeout); !ok {
+			t.Fatalf("Session with %s is not ESTABLISHED", bs.ATEPorts[1].IPv4)
+		}
+		verifyPrefixLimit(t, bs, true, false)
+		verifyTraffic(t, bs, true)
+
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE, excessRoutes)
+		verifyPrefixLimit(t, bs, true, true)
+		verifyStable(t, bs)
+		verifyTraffic(t, bs, true)
+
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, excessRoutes)
+		verifyPrefixLimit(t, bs, true, false)
+	})
+
+	t.Run("Teardown", func(t *testing.T) {
+		configurePrefixLimit(t, bs, false)
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE, baseRoutes)
+		defer setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, baseRoutes, excessRoutes)
+		if _, ok := awaitSession(t, bs, true, sessionTimeout); !ok {
+			t.Fatalf("Session with %s is not ESTABLISHED", bs.ATEPorts[1].IPv4)
+		}
+		verifyPrefixLimit(t, bs, false, false)
+
+		var msgs *gnmi.Collector[*oc.System_Messages_Message]
+		if !deviations.SystemMessagesUnsupported(bs.DUT) {
+			msgs = gnmi.Collect(t, bs.DUT, gnmi.OC().System().Messages().Message().State(), sessionTimeout)
+		}
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE, excessRoutes)
+		down, ok := awaitSession(t, bs, false, sessionTimeout)
+		if !ok {
+			t.Fatalf("Session with %s is still ESTABLISHED after exceeding the prefix limit", bs.ATEPorts[1].IPv4)
+		}
+		verifyPrefixLimit(t, bs, false, true)
+		verifyNotification(t, bs)
+		if msgs != nil {
+			verifyLogged(t, bs, msgs.Await(t))
+		}
+		verifyTraffic(t, bs, false)
+
+		setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, excessRoutes)
+		if *restartTimer == 0 {
+			t.Skip("Skipping the restart timer check without --restart_timer")
+		}
+		// The session must not come back before the restart timer expires.
+		if at, ok := awaitSession(t, bs, true, *restartTimer-time.Since(down)); ok {
+			t.Errorf("Session with %s re-established %v after teardown, want no earlier than the restart timer %v", bs.ATEPorts[1].IPv4, at.Sub(down), *restartTimer)
+		}
+		up, ok := awaitSession(t, bs, true, *restartTimer+restartSlack-time.Since(down))
+		if !ok {
+			t.Fatalf("Session with %s did not re-establish within %v after teardown", bs.ATEPorts[1].IPv4, *restartTimer+restartSlack)
+		}
+		t.Logf("Session with %s re-established %v after teardown", bs.ATEPorts[1].IPv4, up.Sub(down))
+		verifyPrefixLimit(t, bs, false, false)
+		verifyTraffic(t, bs, true)
+	})
+}
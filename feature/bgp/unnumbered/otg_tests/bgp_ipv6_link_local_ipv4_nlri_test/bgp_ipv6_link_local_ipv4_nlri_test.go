@@ -0,0 +1,302 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_ipv6_link_local_ipv4_nlri_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	dutAS       = 65501
+	ateAS       = 65502
+	rplName     = "ALLOW"
+	bgpName     = "BGP"
+	routeName   = "ateDst.v4routes"
+	routePrefix = "198.51.100.0"
+	routeLen    = 24
+	routeCount  = 10
+	flowName    = "v4-over-v6-ll"
+	pps         = 1000
+	flowPackets = 10000
+	lossTol     = 1.0
+	bgpTimeout  = 2 * time.Minute
+	// ateRouterID is the router ID of ATE port-2, which has no IPv4 address.
+	ateRouterID = "192.0.2.6"
+)
+
+var (
+	dutSrc = attrs.Attributes{
+		Desc:    "dutsrc",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	ateSrc = attrs.Attributes{
+		Name:    "atesrc",
+		MAC:     "02:11:01:00:00:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	// dutDst and ateDst only have IPv6 link-local addresses.
+	dutDst = attrs.Attributes{
+		Desc:    "dutdst",
+		IPv6:    "fe80::1",
+		IPv6Len: 64,
+	}
+	ateDst = attrs.Attributes{
+		Name:    "atedst",
+		MAC:     "02:12:01:00:00:01",
+		IPv6:    "fe80::2",
+		IPv6Len: 64,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish eBGP between DUT port-2 and ATE port-2 using only IPv6
+//     link-local addresses, with the IPv4 unicast AFI-SAFI enabled and
+//     extended next-hop encoding.
+//  2. Verify the DUT receives the IPv4 prefixes advertised by the ATE and
+//     installs them with the IPv6 link-local next-hop out of port-2.
+//  3. Verify IPv4 traffic from ATE port-1 is forwarded over the link-local
+//     next-hop to ATE port-2.
+//  4. Flap DUT port-2 and verify the session and IPv4 forwarding recover.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The link-local neighbor is bound to DUT port-2 using the transport
+//     local-address, as the same link-local address may exist on any link.
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutSrc.NewOCInterface(p1.Name(), dut))
+
+	p2 := dut.Port(t, "port2")
+	i2 := dutDst.NewOCInterface(p2.Name(), dut)
+	s6 := i2.GetOrCreateSubinterface(0).GetOrCreateIpv6()
+	s6.Enabled = ygot.Bool(true)
+	s6.GetOrCreateAddress(dutDst.IPv6).SetType(oc.IfIp_Ipv6AddressType_LINK_LOCAL_UNICAST)
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), i2)
+
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+
+	rp := &oc.RoutingPolicy{}
+	st, err := rp.GetOrCreatePolicyDefinition(rplName).AppendNewStatement("id-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+	gnmi.Replace(t, dut, gnmi.OC().RoutingPolicy().Config(), rp)
+
+	bgpPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName)
+	gnmi.Replace(t, dut, bgpPath.Config(), bgpConfig(dut, p2.Name()))
+}
+
+// bgpConfig returns the BGP config with a single neighbor on the link-local
+// address of ATE port-2, reached through interface intf.
+func bgpConfig(dut *ondatra.DUTDevice, intf string) *oc.NetworkInstance_Protocol {
+	proto := &oc.NetworkInstance_Protocol{
+		Identifier: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP,
+		Name:       ygot.String(bgpName),
+	}
+	bgp := proto.GetOrCreateBgp()
+	g := bgp.GetOrCreateGlobal()
+	g.As = ygot.Uint32(dutAS)
+	g.RouterId = ygot.String(dutSrc.IPv4)
+	g.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	g.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled = ygot.Bool(true)
+
+	n := bgp.GetOrCreateNeighbor(ateDst.IPv6)
+	n.PeerAs = ygot.Uint32(ateAS)
+	n.Enabled = ygot.Bool(true)
+	n.GetOrCreateTransport().LocalAddress = ygot.String(intf)
+	af4 := n.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+	af4.Enabled = ygot.Bool(true)
+	af4.GetOrCreateIpv4Unicast().ExtendedNextHopEncoding = ygot.Bool(true)
+	n.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		rpl := n.GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	} else {
+		rpl := af4.GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	}
+	return proto
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	src := ateSrc.AddToOTG(top, ate.Port(t, "port1"), &dutSrc)
+	dst := ateDst.AddToOTG(top, ate.Port(t, "port2"), &dutDst)
+
+	dstIPv6 := dst.Ethernets().Items()[0].Ipv6Addresses().Items()[0]
+	peer := dst.Bgp().SetRouterId(ateRouterID).Ipv6Interfaces().Add().SetIpv6Name(dstIPv6.Name()).
+		Peers().Add().SetName(ateDst.Name + ".BGP6.peer")
+	peer.SetPeerAddress(dutDst.IPv6).SetAsNumber(ateAS).SetAsType(gosnappi.BgpV6PeerAsType.EBGP)
+	peer.Capability().SetExtendedNextHopEncoding(true)
+	routes := peer.V4Routes().Add().SetName(routeName)
+	routes.SetNextHopIpv6Address(ateDst.IPv6).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+	routes.Addresses().Add().SetAddress(routePrefix).SetPrefix(routeLen).SetCount(routeCount)
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{src.Name() + ".IPv4"}).SetRxNames([]string{routeName})
+	flow.Packet().Add().Ethernet().Src().SetValue(ateSrc.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(ateSrc.IPv4)
+	v4.Dst().Increment().SetStart("198.51.100.1").SetStep("0.0.1.0").SetCount(routeCount)
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPackets)
+	return top
+}
+
+// awaitSession waits for the link-local neighbor session state to be want.
+func awaitSession(t *testing.T, dut *ondatra.DUTDevice, want oc.E_Bgp_Neighbor_SessionState) {
+	t.Helper()
+	nbr := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Neighbor(ateDst.IPv6)
+	_, ok := gnmi.Watch(t, dut, nbr.SessionState().State(), bgpTimeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		s, ok := v.Val()
+		return ok && s == want
+	}).Await(t)
+	if !ok {
+		fptest.LogQuery(t, "BGP neighbor", nbr.State(), gnmi.Get(t, dut, nbr.State()))
+		t.Fatalf("BGP neighbor %s session state: did not reach %v within %v", ateDst.IPv6, want, bgpTimeout)
+	}
+}
+
+// verifyNextHops checks that every advertised prefix is installed in the
+// AFT with the link-local next-hop of ATE port-2 out of DUT port-2.
+func verifyNextHops(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts()
+	p2 := dut.Port(t, "port2").Name()
+	for i := 0; i < routeCount; i++ {
+		prefix := fmt.Sprintf("198.51.%d.0/%d", 100+i, routeLen)
+		entry, ok := gnmi.Watch(t, dut, afts.Ipv4Entry(prefix).State(), time.Minute, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			e, ok := v.Val()
+			return ok && e.NextHopGroup != nil
+		}).Await(t)
+		if !ok {
+			t.Errorf("AFT entry for %s not installed", prefix)
+			continue
+		}
+		e, _ := entry.Val()
+		nhg := gnmi.Get(t, dut, afts.NextHopGroup(e.GetNextHopGroup()).State())
+		for idx := range nhg.NextHop {
+			nh := gnmi.Get(t, dut, afts.NextHop(idx).State())
+			if got := nh.GetIpAddress(); got != ateDst.IPv6 {
+				t.Errorf("%s next-hop %d ip-address: got %q, want %q", prefix, idx, got, ateDst.IPv6)
+			}
+			if got := nh.GetInterfaceRef().GetInterface(); got != p2 {
+				t.Errorf("%s next-hop %d interface: got %q, want %q", prefix, idx, got, p2)
+			}
+		}
+	}
+}
+
+// verifyTraffic sends the IPv4 flow and checks its loss against wantLoss.
+func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, wantLoss bool) {
+	t.Helper()
+	ate.OTG().StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 10*time.Second)
+	switch {
+	case wantLoss && loss < 100-lossTol:
+		t.Errorf("Flow %s loss: got %.2f%%, want 100%%", flowName, loss)
+	case !wantLoss && loss > lossTol:
+		t.Errorf("Flow %s loss: got %.2f%%, want <= %.2f%%", flowName, loss, lossTol)
+	}
+}
+
+func TestIPv6LinkLocalIPv4NLRI(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	nbr := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).Bgp().Neighbor(ateDst.IPv6)
+
+	t.Run("Session", func(t *testing.T) {
+		awaitSession(t, dut, oc.Bgp_Neighbor_SessionState_ESTABLISHED)
+		if got := gnmi.Get(t, dut, nbr.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Active().State()); !got {
+			t.Errorf("IPv4 unicast AFI-SAFI active: got %v, want true", got)
+		}
+	})
+
+	t.Run("NextHopEncoding", func(t *testing.T) {
+		gnmi.Await(t, dut, nbr.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Received().State(), time.Minute, routeCount)
+		verifyNextHops(t, dut)
+	})
+
+	t.Run("Forwarding", func(t *testing.T) {
+		verifyTraffic(t, ate, top, false)
+	})
+
+	t.Run("InterfaceFlap", func(t *testing.T) {
+		p2 := dut.Port(t, "port2").Name()
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p2).Enabled().Config(), false)
+		gnmi.Await(t, dut, gnmi.OC().Interface(p2).OperStatus().State(), time.Minute, oc.Interface_OperStatus_DOWN)
+		gnmi.Watch(t, dut, nbr.SessionState().State(), bgpTimeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+			s, ok := v.Val()
+			return ok && s != oc.Bgp_Neighbor_SessionState_ESTABLISHED
+		}).Await(t)
+		verifyTraffic(t, ate, top, true)
+
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p2).Enabled().Config(), true)
+		gnmi.Await(t, dut, gnmi.OC().Interface(p2).OperStatus().State(), time.Minute, oc.Interface_OperStatus_UP)
+		awaitSession(t, dut, oc.Bgp_Neighbor_SessionState_ESTABLISHED)
+		gnmi.Await(t, dut, nbr.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Received().State(), time.Minute, routeCount)
+		verifyNextHops(t, dut)
+		verifyTraffic(t, ate, top, false)
+	})
+}
@@ -0,0 +1,427 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_slow_peer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/networkinstance"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	dutAS         = 65501
+	srcAS         = 65502
+	rcvAS         = 65503
+	rplName       = "ALLOW"
+	bgpName       = "BGP"
+	peerGroupName = "RECEIVERS"
+	routeName     = "atesrc.v4routes"
+	// routeCount /25 prefixes from routePrefix fit in 100.64.0.0/10.
+	routePrefix = "100.64.0.0"
+	routeLen    = 25
+	routeCount  = 20000
+	// congestionPct is the line rate percentage of each of the two
+	// congestion flows towards the slow peer, oversubscribing its link.
+	congestionPct = 60
+	bgpTimeout    = 2 * time.Minute
+	// propagationTimeout bounds how long the fast peer may take to receive
+	// all routes.
+	propagationTimeout = 5 * time.Minute
+	// catchUpTimeout bounds how long the slow peer may take to receive all
+	// routes once it is no longer slow.
+	catchUpTimeout = 10 * time.Minute
+	// latencyTolerance is how much slower propagation to the fast peer may
+	// be while another member of its update group is slow.
+	latencyTolerance = 10 * time.Second
+	pollInterval     = time.Second
+)
+
+var (
+	dutSrc = attrs.Attributes{
+		Desc:    "dutsrc",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	ateSrc = attrs.Attributes{
+		Name:    "atesrc",
+		MAC:     "02:11:01:00:00:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutFast = attrs.Attributes{
+		Desc:    "dutfast",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	ateFast = attrs.Attributes{
+		Name:    "atefast",
+		MAC:     "02:12:01:00:00:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+	dutSlow = attrs.Attributes{
+		Desc:    "dutslow",
+		IPv4:    "192.0.2.9",
+		IPv4Len: 30,
+	}
+	ateSlow = attrs.Attributes{
+		Name:    "ateslow",
+		MAC:     "02:13:01:00:00:01",
+		IPv4:    "192.0.2.10",
+		IPv4Len: 30,
+	}
+	dutLoad = attrs.Attributes{
+		Desc:    "dutload",
+		IPv4:    "192.0.2.13",
+		IPv4Len: 30,
+	}
+	ateLoad = attrs.Attributes{
+		Name:    "ateload",
+		MAC:     "02:14:01:00:00:01",
+		IPv4:    "192.0.2.14",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish eBGP from the DUT to a route source on ATE port-1, and to a
+//     fast and a slow receiver on ATE port-2 and port-3.  Both receivers are
+//     in the same peer-group with the same export policy, so they share an
+//     update group.
+//  2. Baseline: with no congestion, advertise the routes from the source and
+//     measure the time until each receiver has received all of them.
+//  3. SlowPeer: make the port-3 receiver slow, advertise the routes again,
+//     and verify the fast receiver gets all routes within the baseline time
+//     plus a tolerance, without the slow peer session going down.  Log the
+//     BGP output queue and sent prefixes of each neighbor.
+//  4. Recovery: remove the congestion and verify the slow receiver catches
+//     up with all routes without its session flapping.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2 (fast receiver)
+//	ate:port4 <--> port4:dut:port3 <--> ate:port3 (slow receiver)
+//
+// Test notes:
+//   - OTG cannot shrink the TCP receive window or delay ACKs of its BGP
+//     peers, so the slow receiver is emulated by oversubscribing the DUT
+//     egress link to ATE port-3 with traffic from ATE port-1 and port-4.
+//     BGP messages to the slow receiver are then delayed or dropped and
+//     retransmitted, and the DUT must not hold back the rest of the update
+//     group while it waits.
+
+// configureDUT configures the interfaces, an accept-all policy and BGP.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	ports := []struct {
+		id string
+		a  attrs.Attributes
+	}{
+		{"port1", dutSrc},
+		{"port2", dutFast},
+		{"port3", dutSlow},
+		{"port4", dutLoad},
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, p := range ports {
+		dp := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+
+	rp := &oc.RoutingPolicy{}
+	st, err := rp.GetOrCreatePolicyDefinition(rplName).AppendNewStatement("id-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+	gnmi.Replace(t, dut, gnmi.OC().RoutingPolicy().Config(), rp)
+
+	gnmi.Replace(t, dut, bgpPath(dut).Config(), bgpConfig(dut))
+}
+
+func bgpPath(dut *ondatra.DUTDevice) *networkinstance.NetworkInstance_ProtocolPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName)
+}
+
+// bgpConfig returns the BGP config with the route source as a neighbor and the
+// two receivers as members of the same peer-group.
+func bgpConfig(dut *ondatra.DUTDevice) *oc.NetworkInstance_Protocol {
+	proto := &oc.NetworkInstance_Protocol{
+		Identifier: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP,
+		Name:       ygot.String(bgpName),
+	}
+	bgp := proto.GetOrCreateBgp()
+	g := bgp.GetOrCreateGlobal()
+	g.As = ygot.Uint32(dutAS)
+	g.RouterId = ygot.String(dutSrc.IPv4)
+	g.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+
+	pg := bgp.GetOrCreatePeerGroup(peerGroupName)
+	pg.PeerGroupName = ygot.String(peerGroupName)
+	pg.PeerAs = ygot.Uint32(rcvAS)
+	pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		rpl := pg.GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	} else {
+		rpl := pg.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	}
+
+	src := bgp.GetOrCreateNeighbor(ateSrc.IPv4)
+	src.PeerAs = ygot.Uint32(srcAS)
+	src.Enabled = ygot.Bool(true)
+	af := src.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST)
+	af.Enabled = ygot.Bool(true)
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		rpl := src.GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	} else {
+		rpl := af.GetOrCreateApplyPolicy()
+		rpl.ImportPolicy = []string{rplName}
+		rpl.ExportPolicy = []string{rplName}
+	}
+
+	for _, a := range []attrs.Attributes{ateFast, ateSlow} {
+		n := bgp.GetOrCreateNeighbor(a.IPv4)
+		n.PeerAs = ygot.Uint32(rcvAS)
+		n.PeerGroup = ygot.String(peerGroupName)
+		n.Enabled = ygot.Bool(true)
+		n.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	}
+	return proto
+}
+
+// addPeer adds an eBGP peer to the DUT on dev.
+func addPeer(dev gosnappi.Device, a, dutAttrs attrs.Attributes, as uint32) gosnappi.BgpV4Peer {
+	ip := dev.Ethernets().Items()[0].Ipv4Addresses().Items()[0]
+	peer := dev.Bgp().SetRouterId(a.IPv4).Ipv4Interfaces().Add().SetIpv4Name(ip.Name()).
+		Peers().Add().SetName(a.Name + ".BGP4.peer")
+	peer.SetPeerAddress(dutAttrs.IPv4).SetAsNumber(as).SetAsType(gosnappi.BgpV4PeerAsType.EBGP)
+	return peer
+}
+
+// configureATE configures the route source, the two receivers and the flows
+// which congest the link to the slow receiver.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	src := ateSrc.AddToOTG(top, ate.Port(t, "port1"), &dutSrc)
+	fast := ateFast.AddToOTG(top, ate.Port(t, "port2"), &dutFast)
+	slow := ateSlow.AddToOTG(top, ate.Port(t, "port3"), &dutSlow)
+	load := ateLoad.AddToOTG(top, ate.Port(t, "port4"), &dutLoad)
+
+	routes := addPeer(src, ateSrc, dutSrc, srcAS).V4Routes().Add().SetName(routeName)
+	routes.SetNextHopIpv4Address(ateSrc.IPv4).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+	routes.Addresses().Add().SetAddress(routePrefix).SetPrefix(routeLen).SetCount(routeCount)
+	addPeer(fast, ateFast, dutFast, rcvAS)
+	addPeer(slow, ateSlow, dutSlow, rcvAS)
+
+	for _, d := range []struct {
+		dev gosnappi.Device
+		a   attrs.Attributes
+	}{{src, ateSrc}, {load, ateLoad}} {
+		flow := top.Flows().Add().SetName(d.a.Name + "-to-" + ateSlow.Name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{d.dev.Name() + ".IPv4"}).SetRxNames([]string{slow.Name() + ".IPv4"})
+		flow.Packet().Add().Ethernet().Src().SetValue(d.a.MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(d.a.IPv4)
+		v4.Dst().SetValue(ateSlow.IPv4)
+		flow.Size().SetFixed(1500)
+		flow.Rate().SetPercentage(congestionPct)
+	}
+	return top
+}
+
+func setRouteState(t *testing.T, ate *ondatra.ATEDevice, state gosnappi.StateProtocolRouteStateEnum) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames([]string{routeName}).SetState(state)
+	ate.OTG().SetControlState(t, cs)
+}
+
+// awaitSessions waits for every neighbor session to be established.
+func awaitSessions(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	bgp := bgpPath(dut).Bgp()
+	for _, a := range []attrs.Attributes{ateSrc, ateFast, ateSlow} {
+		nbr := bgp.Neighbor(a.IPv4)
+		_, ok := gnmi.Watch(t, dut, nbr.SessionState().State(), bgpTimeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+			s, ok := v.Val()
+			return ok && s == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+		}).Await(t)
+		if !ok {
+			fptest.LogQuery(t, "BGP neighbor", nbr.State(), gnmi.Get(t, dut, nbr.State()))
+			t.Fatalf("BGP neighbor %s session state: not ESTABLISHED within %v", a.IPv4, bgpTimeout)
+		}
+	}
+}
+
+// awaitWithdrawn withdraws the routes from the source and waits for the DUT
+// to stop advertising them to both receivers.
+func awaitWithdrawn(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice) {
+	t.Helper()
+	setRouteState(t, ate, gosnappi.StateProtocolRouteState.WITHDRAW)
+	bgp := bgpPath(dut).Bgp()
+	for _, a := range []attrs.Attributes{ateFast, ateSlow} {
+		sent := bgp.Neighbor(a.IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Sent().State()
+		gnmi.Await(t, dut, sent, catchUpTimeout, 0)
+	}
+}
+
+// inRoutes returns the number of routes received so far by the ATE peer.
+func inRoutes(t *testing.T, ate *ondatra.ATEDevice, a attrs.Attributes) uint64 {
+	t.Helper()
+	return gnmi.Get(t, ate.OTG(), gnmi.OTG().BgpPeer(a.Name+".BGP4.peer").Counters().InRoutes().State())
+}
+
+// awaitRoutes waits until the ATE peer has received want routes in total and
+// returns the time since start, or false if that took longer than timeout.
+func awaitRoutes(t *testing.T, ate *ondatra.ATEDevice, a attrs.Attributes, want uint64, start time.Time, timeout time.Duration) (time.Duration, bool) {
+	t.Helper()
+	for time.Since(start) < timeout {
+		if inRoutes(t, ate, a) >= want {
+			return time.Since(start), true
+		}
+		time.Sleep(pollInterval)
+	}
+	return 0, false
+}
+
+// logNeighbors logs the output queue and sent prefixes of each receiver.
+func logNeighbors(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	bgp := bgpPath(dut).Bgp()
+	for _, a := range []attrs.Attributes{ateFast, ateSlow} {
+		nbr := bgp.Neighbor(a.IPv4)
+		queue, _ := gnmi.Lookup(t, dut, nbr.Queues().Output().State()).Val()
+		sent, _ := gnmi.Lookup(t, dut, nbr.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Sent().State()).Val()
+		t.Logf("BGP neighbor %s (%s): output queue %d, prefixes sent %d", a.IPv4, a.Name, queue, sent)
+	}
+}
+
+// propagation advertises the routes and returns the time taken for each
+// receiver to get all of them.  The slow receiver is not waited for if
+// waitSlow is false.
+func propagation(t *testing.T, ate *ondatra.ATEDevice, waitSlow bool) (fast, slow time.Duration) {
+	t.Helper()
+	fastWant := inRoutes(t, ate, ateFast) + routeCount
+	slowWant := inRoutes(t, ate, ateSlow) + routeCount
+	start := time.Now()
+	setRouteState(t, ate, gosnappi.StateProtocolRouteState.ADVERTISE)
+	fast, ok := awaitRoutes(t, ate, ateFast, fastWant, start, propagationTimeout)
+	if !ok {
+		t.Fatalf("Fast peer %s: did not receive %d routes within %v", ateFast.IPv4, routeCount, propagationTimeout)
+	}
+	t.Logf("Fast peer %s received %d routes in %v", ateFast.IPv4, routeCount, fast)
+	if !waitSlow {
+		return fast, 0
+	}
+	slow, ok = awaitRoutes(t, ate, ateSlow, slowWant, start, catchUpTimeout)
+	if !ok {
+		t.Fatalf("Slow peer %s: did not receive %d routes within %v", ateSlow.IPv4, routeCount, catchUpTimeout)
+	}
+	t.Logf("Slow peer %s received %d routes in %v", ateSlow.IPv4, routeCount, slow)
+	return fast, slow
+}
+
+func TestSlowPeer(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+	awaitSessions(t, dut)
+
+	bgp := bgpPath(dut).Bgp()
+	for _, a := range []attrs.Attributes{ateFast, ateSlow} {
+		if got := gnmi.Get(t, dut, bgp.Neighbor(a.IPv4).PeerGroup().State()); got != peerGroupName {
+			t.Errorf("BGP neighbor %s peer-group: got %q, want %q", a.IPv4, got, peerGroupName)
+		}
+	}
+	slowTransitions := gnmi.Get(t, dut, bgp.Neighbor(ateSlow.IPv4).EstablishedTransitions().State())
+	awaitWithdrawn(t, dut, ate)
+
+	var baseline time.Duration
+	t.Run("Baseline", func(t *testing.T) {
+		baseline, _ = propagation(t, ate, true)
+		logNeighbors(t, dut)
+	})
+	if baseline == 0 {
+		t.Fatalf("No baseline propagation time")
+	}
+	awaitWithdrawn(t, dut, ate)
+
+	slowWant := inRoutes(t, ate, ateSlow) + routeCount
+	t.Run("SlowPeer", func(t *testing.T) {
+		ate.OTG().StartTraffic(t)
+		defer ate.OTG().StopTraffic(t)
+		// Let the congestion build before the update burst.
+		time.Sleep(10 * time.Second)
+
+		fast, _ := propagation(t, ate, false)
+		logNeighbors(t, dut)
+		if limit := baseline + latencyTolerance; fast > limit {
+			t.Errorf("Fast peer %s propagation time with a slow peer: got %v, want <= %v (baseline %v)", ateFast.IPv4, fast, limit, baseline)
+		}
+		if got := gnmi.Get(t, dut, bgp.Neighbor(ateSlow.IPv4).SessionState().State()); got != oc.Bgp_Neighbor_SessionState_ESTABLISHED {
+			t.Errorf("Slow peer %s session state under congestion: got %v, want ESTABLISHED", ateSlow.IPv4, got)
+		}
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		sent := bgp.Neighbor(ateSlow.IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Sent().State()
+		gnmi.Await(t, dut, sent, catchUpTimeout, routeCount)
+		if d, ok := awaitRoutes(t, ate, ateSlow, slowWant, time.Now(), catchUpTimeout); ok {
+			t.Logf("Slow peer %s caught up %v after the congestion was removed", ateSlow.IPv4, d)
+		} else {
+			t.Errorf("Slow peer %s: did not receive all %d routes within %v", ateSlow.IPv4, routeCount, catchUpTimeout)
+		}
+		logNeighbors(t, dut)
+		if got := gnmi.Get(t, dut, bgp.Neighbor(ateSlow.IPv4).EstablishedTransitions().State()); got != slowTransitions {
+			t.Errorf("Slow peer %s established-transitions: got %d, want %d", ateSlow.IPv4, got, slowTransitions)
+		}
+	})
+}
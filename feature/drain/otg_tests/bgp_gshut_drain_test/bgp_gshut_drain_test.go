@@ -0,0 +1,286 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_gshut_drain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	// gshutAS and gshutValue make up the GRACEFUL_SHUTDOWN well-known
+	// community from RFC 8326.
+	gshutAS    = 65535
+	gshutValue = 0
+	gshutComm  = "65535:0"
+	gshutSet   = "GSHUT"
+	importPol  = "GSHUT-IN"
+	exportPol  = "GSHUT-OUT"
+	netPrefix  = "198.51.100.0"
+	netPlen    = 24
+	netIP      = "198.51.100.1"
+	flowName   = "to-network"
+	flowPkts   = 1000
+	minShare   = 0.9
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish eBGP sessions between the DUT and ATE port-1, port-2 and
+//     port-3.  ATE port-2 and port-3 both advertise 198.51.100.0/24, with a
+//     longer AS path from port-3 so that the DUT prefers port-2.
+//  2. Receive GSHUT: apply an import policy on the port-2 and port-3 sessions
+//     that sets local preference 0 for routes with the GRACEFUL_SHUTDOWN
+//     community 65535:0.
+//     a. Verify traffic from ATE port-1 to 198.51.100.0/24 egresses port-2.
+//     b. Advertise the route from ATE port-2 with 65535:0, and verify the
+//        traffic moves to port-3.
+//     c. Advertise the route without the community again, and verify the
+//        traffic moves back to port-2.
+//  3. Send GSHUT: drain the DUT towards ATE port-1 with an export policy that
+//     adds 65535:0.
+//     a. Verify ATE port-1 receives 198.51.100.0/24 with 65535:0.
+//     b. Remove the export policy, and verify the community is no longer
+//        received.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                    dut:port3 <--> ate:port3
+//
+// Test notes:
+//   - The ATE does not run best path selection, so when the DUT sends GSHUT
+//     the route shift is not observable on ATE port-1; the test verifies the
+//     community the shift depends on is received instead.
+//   - OpenConfig has no graceful shutdown leaf for BGP in the models used
+//     here, so GSHUT is sent and acted upon with routing policy.
+
+func configurePolicies(dut *ondatra.DUTDevice, root *oc.Root) error {
+	rp := root.GetOrCreateRoutingPolicy()
+	rp.GetOrCreateDefinedSets().GetOrCreateBgpDefinedSets().GetOrCreateCommunitySet(gshutSet).
+		SetCommunityMember([]oc.RoutingPolicy_DefinedSets_BgpDefinedSets_CommunitySet_CommunityMember_Union{oc.UnionString(gshutComm)})
+
+	in := rp.GetOrCreatePolicyDefinition(importPol)
+	stmt, err := in.AppendNewStatement("gshut")
+	if err != nil {
+		return err
+	}
+	if deviations.BGPConditionsMatchCommunitySetUnsupported(dut) {
+		stmt.GetOrCreateConditions().GetOrCreateBgpConditions().SetCommunitySet(gshutSet)
+	} else {
+		cs := stmt.GetOrCreateConditions().GetOrCreateBgpConditions().GetOrCreateMatchCommunitySet()
+		cs.SetCommunitySet(gshutSet)
+		cs.SetMatchSetOptions(oc.RoutingPolicy_MatchSetOptionsType_ANY)
+	}
+	stmt.GetOrCreateActions().GetOrCreateBgpActions().SetSetLocalPref(0)
+	stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+	if stmt, err = in.AppendNewStatement("accept"); err != nil {
+		return err
+	}
+	stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+
+	out := rp.GetOrCreatePolicyDefinition(exportPol)
+	if stmt, err = out.AppendNewStatement("gshut"); err != nil {
+		return err
+	}
+	sc := stmt.GetOrCreateActions().GetOrCreateBgpActions().GetOrCreateSetCommunity()
+	sc.GetOrCreateReference().SetCommunitySetRef(gshutSet)
+	sc.SetOptions(oc.BgpPolicy_BgpSetCommunityOptionType_ADD)
+	if !deviations.BgpActionsSetCommunityMethodUnsupported(dut) {
+		sc.SetMethod(oc.SetCommunity_Method_REFERENCE)
+	}
+	stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_NEXT_STATEMENT)
+	if stmt, err = out.AppendNewStatement("accept"); err != nil {
+		return err
+	}
+	stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+	return nil
+}
+
+// applyPolicy replaces the import and export policies of peer group pg.
+func applyPolicy(t *testing.T, dut *ondatra.DUTDevice, pg, importPolicy, exportPolicy string) {
+	t.Helper()
+	t.Logf("Applying import policy %s and export policy %s to %s", importPolicy, exportPolicy, pg)
+	pgPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp().PeerGroup(pg)
+	b := &gnmi.SetBatch{}
+	if deviations.RoutePolicyUnderAFIUnsupported(dut) {
+		gnmi.BatchReplace(b, pgPath.ApplyPolicy().ImportPolicy().Config(), []string{importPolicy})
+		gnmi.BatchReplace(b, pgPath.ApplyPolicy().ExportPolicy().Config(), []string{exportPolicy})
+	} else {
+		ap := pgPath.AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).ApplyPolicy()
+		gnmi.BatchReplace(b, ap.ImportPolicy().Config(), []string{importPolicy})
+		gnmi.BatchReplace(b, ap.ExportPolicy().Config(), []string{exportPolicy})
+	}
+	b.Set(t, dut)
+}
+
+// configureRoutes advertises the network from ATE port-2 and port-3, with
+// GSHUT from port-2 if gshut is true.
+func configureRoutes(bs *cfgplugins.BGPSession, gshut bool) {
+	for i, asPath := range [][]uint32{
+		1: {cfgplugins.AteAS2},
+		2: {cfgplugins.AteAS3, cfgplugins.AteAS3, cfgplugins.AteAS3},
+	} {
+		if asPath == nil {
+			continue
+		}
+		peer := bs.ATEIntfs[i].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+		peer.V4Routes().Clear()
+		r := peer.V4Routes().Add().SetName(bs.ATEPorts[i].Name + ".network")
+		r.SetNextHopIpv4Address(bs.ATEPorts[i].IPv4).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		r.Addresses().Add().SetAddress(netPrefix).SetPrefix(netPlen)
+		r.AsPath().SetAsSetMode(gosnappi.BgpAsPathAsSetMode.DO_NOT_INCLUDE_LOCAL_AS).
+			Segments().Add().SetType(gosnappi.BgpAsPathSegmentType.AS_SEQ).SetAsNumbers(asPath)
+		if gshut && i == 1 {
+			r.Communities().Add().SetType(gosnappi.BgpCommunityType.MANUAL_AS_NUMBER).SetAsNumber(gshutAS).SetAsCustom(gshutValue)
+		}
+	}
+}
+
+func configureFlow(bs *cfgplugins.BGPSession) {
+	bs.ATETop.Flows().Clear()
+	flow := bs.ATETop.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+		SetRxNames([]string{bs.ATEPorts[1].Name + ".network", bs.ATEPorts[2].Name + ".network"})
+	flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+	v4.Dst().Increment().SetStart(netIP).SetCount(50)
+	flow.Rate().SetPps(100)
+	flow.Duration().FixedPackets().SetPackets(flowPkts)
+}
+
+// restartATE pushes the ATE config with or without GSHUT from ATE port-2 and
+// waits for the sessions to come back.
+func restartATE(t *testing.T, bs *cfgplugins.BGPSession, gshut bool) {
+	t.Helper()
+	configureRoutes(bs, gshut)
+	bs.PushAndStartATE(t)
+	cfgplugins.VerifyDUTBGPEstablished(t, bs.DUT)
+	cfgplugins.VerifyOTGBGPEstablished(t, bs.ATE)
+	// Allow the DUT to select the best path and program the FIB.
+	time.Sleep(30 * time.Second)
+}
+
+// verifyEgress sends traffic to the network and verifies at least minShare
+// of it is received on want.
+func verifyEgress(t *testing.T, bs *cfgplugins.BGPSession, want *ondatra.Port) {
+	t.Helper()
+	otg := bs.ATE.OTG()
+	inFrames := gnmi.OTG().Port(want.ID()).Counters().InFrames().State()
+	before := gnmi.Get(t, otg, inFrames)
+	otg.StartTraffic(t)
+	time.Sleep(flowPkts/100*time.Second + 5*time.Second)
+	otg.StopTraffic(t)
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+	otgutils.LogPortMetrics(t, otg, bs.ATETop)
+
+	tx, _ := otgutils.GetFlowStats(t, otg, flowName, 10*time.Second)
+	got := gnmi.Get(t, otg, inFrames) - before
+	if tx == 0 {
+		t.Fatalf("Flow %s sent no packets", flowName)
+	}
+	if share := float64(got) / float64(tx); share < minShare {
+		t.Errorf("Port %s received %d of %d packets sent to %s/%d, want at least %.0f%%", want.ID(), got, tx, netPrefix, netPlen, minShare*100)
+	}
+}
+
+// awaitGSHUT waits until ATE port-1 receives the network with the GSHUT
+// community, if want is true, or without it.
+func awaitGSHUT(t *testing.T, bs *cfgplugins.BGPSession, want bool) {
+	t.Helper()
+	peer := bs.ATEPorts[0].Name + ".BGP4.peer"
+	prefix := gnmi.OTG().BgpPeer(peer).UnicastIpv4Prefix(netPrefix, netPlen, otgtelemetry.UnicastIpv4Prefix_Origin_IGP, 0)
+	_, ok := gnmi.Watch(t, bs.ATE.OTG(), prefix.State(), time.Minute, func(v *ygnmi.Value[*otgtelemetry.BgpPeer_UnicastIpv4Prefix]) bool {
+		p, present := v.Val()
+		if !present {
+			return false
+		}
+		var got bool
+		for _, c := range p.Community {
+			if c.GetCustomAsNumber() == gshutAS && c.GetCustomAsValue() == gshutValue {
+				got = true
+			}
+		}
+		return got == want
+	}).Await(t)
+	if !ok {
+		t.Errorf("%s: received %s/%d with GSHUT community %s: got %v, want %v", peer, netPrefix, netPlen, gshutComm, !want, want)
+	}
+}
+
+func TestBGPGracefulShutdownDrain(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount4, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port1", "port2", "port3"}, false, false)
+	if err := configurePolicies(bs.DUT, bs.DUTConf); err != nil {
+		t.Fatalf("Failed to configure routing policy: %v", err)
+	}
+	configureRoutes(bs, false)
+	configureFlow(bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	cfgplugins.VerifyDUTBGPEstablished(t, bs.DUT)
+	cfgplugins.VerifyOTGBGPEstablished(t, bs.ATE)
+
+	t.Run("ReceiveGSHUT", func(t *testing.T) {
+		for _, pg := range []string{cfgplugins.BGPPeerGroup2, cfgplugins.BGPPeerGroup3} {
+			applyPolicy(t, bs.DUT, pg, importPol, cfgplugins.RPLPermitAll)
+		}
+		defer func() {
+			for _, pg := range []string{cfgplugins.BGPPeerGroup2, cfgplugins.BGPPeerGroup3} {
+				applyPolicy(t, bs.DUT, pg, cfgplugins.RPLPermitAll, cfgplugins.RPLPermitAll)
+			}
+		}()
+
+		restartATE(t, bs, false)
+		t.Log("Verifying traffic egresses port2 without GSHUT")
+		verifyEgress(t, bs, bs.OndatraATEPorts[1])
+
+		restartATE(t, bs, true)
+		t.Log("Verifying traffic egresses port3 with GSHUT from port2")
+		verifyEgress(t, bs, bs.OndatraATEPorts[2])
+
+		restartATE(t, bs, false)
+		t.Log("Verifying traffic egresses port2 after GSHUT is withdrawn")
+		verifyEgress(t, bs, bs.OndatraATEPorts[1])
+	})
+
+	t.Run("SendGSHUT", func(t *testing.T) {
+		applyPolicy(t, bs.DUT, cfgplugins.BGPPeerGroup1, cfgplugins.RPLPermitAll, exportPol)
+		awaitGSHUT(t, bs, true)
+
+		applyPolicy(t, bs.DUT, cfgplugins.BGPPeerGroup1, cfgplugins.RPLPermitAll, cfgplugins.RPLPermitAll)
+		awaitGSHUT(t, bs, false)
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isis_overload_drain_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	isisInstance = "DEFAULT"
+	areaAddress  = "49.0001"
+	sysID        = "1920.0000.2001"
+	ate1SysID    = "640000000001"
+	ate2SysID    = "640000000002"
+	v4Route      = "203.0.113.0"
+	v4RoutePlen  = 24
+	v4IP         = "203.0.113.1"
+	flowName     = "to-isis-route"
+	lossTol      = 1.0
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "port2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish IS-IS level 2 adjacencies between the DUT and ATE port-1 and
+//     port-2.  ATE port-2 advertises 203.0.113.0/24.
+//  2. Verify the DUT LSP received by ATE port-1 does not have the overload
+//     bit, and traffic from ATE port-1 to 203.0.113.0/24 is received on ATE
+//     port-2.
+//  3. Drain the DUT by setting the overload bit with
+//     /network-instances/network-instance/protocols/protocol/isis/global/lsp-bit/overload-bit/config/set-bit.
+//  4. Verify the DUT reports the overload bit set, and the DUT LSP received by
+//     ATE port-1 has the overload flag.
+//  5. Undrain the DUT and verify the overload flag is removed from the LSP.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The ATE does not run SPF, so traffic is not moved away from the drained
+//     DUT.  The overload flag in the LSP is the signal other routers use to
+//     route around it, and traffic that is still sent to the DUT while it is
+//     drained is expected to be forwarded.
+//   - The ATE routers never set the overload bit in their own LSPs, so any LSP
+//     with the overload flag in the ATE port-1 database is from the DUT.
+
+func isisIntf(dut *ondatra.DUTDevice, p *ondatra.Port) string {
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		return p.Name() + ".0"
+	}
+	return p.Name()
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	var intfs []string
+	for i, a := range []*attrs.Attributes{&dutPort1, &dutPort2} {
+		p := dut.Port(t, fmt.Sprintf("port%d", i+1))
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+		intfs = append(intfs, isisIntf(dut, p))
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	configureISIS(t, dut, intfs)
+}
+
+func configureISIS(t *testing.T, dut *ondatra.DUTDevice, intfs []string) {
+	t.Helper()
+	d := &oc.Root{}
+	prot := d.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisInstance)
+	prot.Enabled = ygot.Bool(true)
+	isis := prot.GetOrCreateIsis()
+
+	global := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		global.Instance = ygot.String(isisInstance)
+	}
+	global.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	global.Net = []string{fmt.Sprintf("%v.%v.00", areaAddress, sysID)}
+	global.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	global.GetOrCreateLspBit().GetOrCreateOverloadBit().SetBit = ygot.Bool(false)
+
+	level := isis.GetOrCreateLevel(2)
+	level.MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+	if deviations.ISISLevelEnabled(dut) {
+		level.Enabled = ygot.Bool(true)
+	}
+	for _, name := range intfs {
+		intf := isis.GetOrCreateInterface(name)
+		intf.GetOrCreateInterfaceRef().Interface = ygot.String(name)
+		intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+		if deviations.InterfaceRefConfigUnsupported(dut) {
+			intf.InterfaceRef = nil
+		}
+		intf.Enabled = ygot.Bool(true)
+		intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+		intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+		if deviations.ISISInterfaceAfiUnsupported(dut) {
+			intf.Af = nil
+		}
+		intfLevel := intf.GetOrCreateLevel(2)
+		intfLevel.Enabled = ygot.Bool(true)
+		af := intfLevel.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST)
+		af.Metric = ygot.Uint32(10)
+		af.Enabled = ygot.Bool(true)
+		if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+			af.Enabled = nil
+		}
+	}
+	gnmi.Update(t, dut, gnmi.OC().Config(), d)
+}
+
+// setOverload sets or clears the IS-IS overload bit of the DUT.
+func setOverload(t *testing.T, dut *ondatra.DUTDevice, set bool) {
+	t.Helper()
+	t.Logf("Setting the IS-IS overload bit to %v", set)
+	path := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisInstance).Isis().Global().LspBit().OverloadBit().SetBit()
+	gnmi.Replace(t, dut, path.Config(), set)
+	_, ok := gnmi.Watch(t, dut, path.State(), time.Minute, func(v *ygnmi.Value[bool]) bool {
+		got, present := v.Val()
+		// An unset bit is reported as missing by platforms that do not send
+		// default values.
+		return got == set || (!set && !present)
+	}).Await(t)
+	if !ok {
+		t.Fatalf("IS-IS overload bit state is not %v", set)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for _, p := range []struct {
+		ate, dut *attrs.Attributes
+		sysID    string
+	}{
+		{&atePort1, &dutPort1, ate1SysID},
+		{&atePort2, &dutPort2, ate2SysID},
+	} {
+		dev := p.ate.AddToOTG(top, ate.Port(t, p.ate.Name), p.dut)
+		isis := dev.Isis().SetSystemId(p.sysID).SetName(p.ate.Name + ".ISIS")
+		isis.Basic().SetHostname(isis.Name())
+		isis.Advanced().SetAreaAddresses([]string{strings.ReplaceAll(areaAddress, ".", "")})
+		isis.Interfaces().Add().
+			SetEthName(dev.Ethernets().Items()[0].Name()).SetName(p.ate.Name + ".ISISIntf").
+			SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+			SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+			SetMetric(10)
+	}
+	net := top.Devices().Items()[1].Isis().V4Routes().Add().SetName("port2.ISISNet").SetLinkMetric(10)
+	net.Addresses().Add().SetAddress(v4Route).SetPrefix(v4RoutePlen)
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{net.Name()})
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().Increment().SetStart(v4IP).SetCount(50)
+	flow.Rate().SetPps(100)
+	flow.Duration().FixedPackets().SetPackets(1000)
+	return top
+}
+
+func awaitAdjacencies(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	isis := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisInstance).Isis()
+	for _, pn := range []string{"port1", "port2"} {
+		intf := isisIntf(dut, dut.Port(t, pn))
+		_, ok := gnmi.WatchAll(t, dut, isis.Interface(intf).Level(2).AdjacencyAny().AdjacencyState().State(), 2*time.Minute, func(v *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+			state, present := v.Val()
+			return present && state == oc.Isis_IsisInterfaceAdjState_UP
+		}).Await(t)
+		if !ok {
+			t.Fatalf("IS-IS adjacency on %s is not up", intf)
+		}
+	}
+}
+
+// hasOverload reports whether flags contains the overload flag.
+func hasOverload(flags []otgtelemetry.E_Lsps_Flags) bool {
+	for _, f := range flags {
+		if f == otgtelemetry.Lsps_Flags_OVERLOAD {
+			return true
+		}
+	}
+	return false
+}
+
+// awaitOverloadFlag waits until an LSP in the ATE port-1 database has the
+// overload flag, if want is true, or no LSP has it.
+func awaitOverloadFlag(t *testing.T, ate *ondatra.ATEDevice, want bool) {
+	t.Helper()
+	flags := gnmi.OTG().IsisRouter(atePort1.Name + ".ISIS").LinkStateDatabase().LspsAny().Flags().State()
+	if want {
+		_, ok := gnmi.WatchAll(t, ate.OTG(), flags, time.Minute, func(v *ygnmi.Value[[]otgtelemetry.E_Lsps_Flags]) bool {
+			f, present := v.Val()
+			return present && hasOverload(f)
+		}).Await(t)
+		if !ok {
+			t.Fatalf("No LSP received by %s has the overload flag", atePort1.Name)
+		}
+		return
+	}
+	deadline := time.Now().Add(time.Minute)
+	for {
+		var set bool
+		for _, f := range gnmi.LookupAll(t, ate.OTG(), flags) {
+			if v, ok := f.Val(); ok && hasOverload(v) {
+				set = true
+			}
+		}
+		if !set {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("LSP received by %s still has the overload flag", atePort1.Name)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) {
+	t.Helper()
+	ate.OTG().StartTraffic(t)
+	time.Sleep(15 * time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	if loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 10*time.Second); loss > lossTol {
+		t.Errorf("Flow %s: got %.2f%% loss, want <= %.2f%%", flowName, loss, lossTol)
+	}
+}
+
+func TestISISOverloadDrain(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+	awaitAdjacencies(t, dut)
+
+	t.Run("Undrained", func(t *testing.T) {
+		awaitOverloadFlag(t, ate, false)
+		verifyTraffic(t, ate, top)
+	})
+
+	t.Run("Drained", func(t *testing.T) {
+		setOverload(t, dut, true)
+		awaitOverloadFlag(t, ate, true)
+		verifyTraffic(t, ate, top)
+	})
+
+	t.Run("Undrain", func(t *testing.T) {
+		setOverload(t, dut, false)
+		awaitOverloadFlag(t, ate, false)
+		verifyTraffic(t, ate, top)
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dutdut_test is an example of a test between two DUTs.  It
+// bundles the links between the DUTs in a LACP LAG, runs IS-IS and iBGP over
+// the LAG, and sends traffic from the ATE port of one DUT to the ATE port of
+// the other.  Both DUTs are configured concurrently, each with its own
+// deviations.
+package dutdut_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/featureprofiles/internal/topology"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	isisName  = "DEFAULT"
+	areaAddr  = "49.0001"
+	bgpName   = "BGP"
+	bgpAS     = 65000
+	flowName  = "edge1-to-edge2"
+	flowPPS   = 1000
+	lossPct   = 0
+	readyTime = 3 * time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// side is the configuration of one of the DUTs.
+type side struct {
+	dut *ondatra.DUTDevice
+	// edge is the port pair of the DUT with the ATE, and remote the port
+	// pair of the other DUT with the ATE.
+	edge, remote topology.PortPair
+	// aggID is the LAG over members, with the attributes lag, and peer the
+	// attributes of the LAG of the other DUT.
+	aggID     string
+	members   []*ondatra.Port
+	lag, peer attrs.Attributes
+	sysID     string
+}
+
+// sides returns the configuration of DUT1 and DUT2.  The LAGs use the
+// addresses of the first link.
+func sides(t *testing.T, d *topology.DUTDUT) map[*ondatra.DUTDevice]*side {
+	s1 := &side{
+		dut:    d.DUT1,
+		edge:   d.Edge1,
+		remote: d.Edge2,
+		aggID:  netutil.NextAggregateInterface(t, d.DUT1),
+		lag:    d.Links[0].DUT1Attrs,
+		peer:   d.Links[0].DUT2Attrs,
+		sysID:  "1920.0000.2001",
+	}
+	s2 := &side{
+		dut:    d.DUT2,
+		edge:   d.Edge2,
+		remote: d.Edge1,
+		aggID:  netutil.NextAggregateInterface(t, d.DUT2),
+		lag:    d.Links[0].DUT2Attrs,
+		peer:   d.Links[0].DUT1Attrs,
+		sysID:  "1920.0000.2002",
+	}
+	for _, l := range d.Links {
+		s1.members = append(s1.members, l.DUT1)
+		s2.members = append(s2.members, l.DUT2)
+	}
+	return map[*ondatra.DUTDevice]*side{d.DUT1: s1, d.DUT2: s2}
+}
+
+// configureInterfaces configures the edge port, and the LACP LAG over the
+// members in a single update.
+func (s *side) configureInterfaces(t testing.TB) {
+	t.Helper()
+	dut := s.dut
+	agg := s.lag.NewOCInterface(s.aggID, dut)
+	agg.Type = oc.IETFInterfaces_InterfaceType_ieee8023adLag
+	agg.GetOrCreateAggregation().LagType = oc.IfAggregate_AggregationType_LACP
+	root := &oc.Root{}
+	for _, i := range []*oc.Interface{s.edge.DUTAttrs.NewOCInterface(s.edge.DUT.Name(), dut), agg} {
+		if err := root.AppendInterface(i); err != nil {
+			t.Fatalf("Cannot add interface %s: %v", i.GetName(), err)
+		}
+	}
+	root.GetOrCreateLacp().GetOrCreateInterface(s.aggID).LacpMode = oc.Lacp_LacpActivityType_ACTIVE
+
+	for _, p := range s.members {
+		i := root.GetOrCreateInterface(p.Name())
+		i.Description = ygot.String(p.String())
+		i.Type = oc.IETFInterfaces_InterfaceType_ethernetCsmacd
+		if deviations.InterfaceEnabled(dut) {
+			i.Enabled = ygot.Bool(true)
+		}
+		i.GetOrCreateEthernet().AggregateId = ygot.String(s.aggID)
+	}
+	gnmi.Update(t, dut, gnmi.OC().Config(), root)
+
+	for _, p := range append([]*ondatra.Port{s.edge.DUT}, s.members...) {
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, s.edge.DUT.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, s.aggID, deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+// isisInterface returns the name of interface name in the IS-IS
+// configuration of the DUT.
+func (s *side) isisInterface(name string) string {
+	if deviations.ExplicitInterfaceInDefaultVRF(s.dut) {
+		return name + ".0"
+	}
+	return name
+}
+
+// configureProtocols configures IS-IS on the LAG, with the edge port
+// passive so that the other DUT learns its subnet, and an iBGP session
+// between the LAG addresses.
+func (s *side) configureProtocols(t testing.TB) {
+	t.Helper()
+	dut := s.dut
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	ni := deviations.DefaultNetworkInstance(dut)
+	root := &oc.Root{}
+	niConf := root.GetOrCreateNetworkInstance(ni)
+
+	isisProto := niConf.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName)
+	isisProto.Enabled = ygot.Bool(true)
+	isis := isisProto.GetOrCreateIsis()
+	g := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		g.Instance = ygot.String(isisName)
+	}
+	g.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	g.Net = []string{fmt.Sprintf("%s.%s.00", areaAddr, s.sysID)}
+	g.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	isis.GetOrCreateLevel(2).MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+	for _, name := range []string{s.aggID, s.edge.DUT.Name()} {
+		intf := isis.GetOrCreateInterface(s.isisInterface(name))
+		intf.Enabled = ygot.Bool(true)
+		if name == s.aggID {
+			intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+		} else {
+			intf.Passive = ygot.Bool(true)
+		}
+		intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+		if deviations.ISISInterfaceAfiUnsupported(dut) {
+			intf.Af = nil
+		}
+		af := intf.GetOrCreateLevel(2).GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST)
+		af.Metric = ygot.Uint32(10)
+		af.Enabled = ygot.Bool(true)
+		if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+			af.Enabled = nil
+		}
+	}
+
+	bgp := niConf.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, bgpName).GetOrCreateBgp()
+	global := bgp.GetOrCreateGlobal()
+	global.As = ygot.Uint32(bgpAS)
+	global.RouterId = ygot.String(s.lag.IPv4)
+	global.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+	nbr := bgp.GetOrCreateNeighbor(s.peer.IPv4)
+	nbr.PeerAs = ygot.Uint32(bgpAS)
+	nbr.Enabled = ygot.Bool(true)
+	nbr.GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Enabled = ygot.Bool(true)
+
+	gnmi.Update(t, dut, gnmi.OC().NetworkInstance(ni).Config(), niConf)
+}
+
+// remotePrefix returns the IPv4 subnet of the edge of the other DUT.
+func (s *side) remotePrefix(t testing.TB) string {
+	_, n, err := net.ParseCIDR(fmt.Sprintf("%s/%d", s.remote.DUTAttrs.IPv4, s.remote.DUTAttrs.IPv4Len))
+	if err != nil {
+		t.Fatalf("Cannot parse edge address: %v", err)
+	}
+	return n.String()
+}
+
+// await waits for the LAG members to be distributing, and for the IS-IS
+// adjacency, the BGP session and the route to the edge of the other DUT.
+func (s *side) await(t testing.TB) {
+	t.Helper()
+	for _, p := range s.members {
+		gnmi.Await(t, s.dut, gnmi.OC().Lacp().Interface(s.aggID).Member(p.Name()).Distributing().State(), readyTime, true)
+	}
+	ni := deviations.DefaultNetworkInstance(s.dut)
+	programming.Await(t, s.dut, readyTime,
+		programming.ISISAdjacency(ni, isisName, s.isisInterface(s.aggID)),
+		programming.BGPNeighbor(ni, bgpName, s.peer.IPv4),
+		programming.IPv4Entry(ni, s.remotePrefix(t)),
+	)
+}
+
+func configureATE(t *testing.T, d *topology.DUTDUT) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for _, e := range []topology.PortPair{d.Edge1, d.Edge2} {
+		e.ATEAttrs.AddToOTG(top, e.ATE, &e.DUTAttrs)
+	}
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{d.Edge1.ATEAttrs.Name + ".IPv4"}).
+		SetRxNames([]string{d.Edge2.ATEAttrs.Name + ".IPv4"})
+	flow.Packet().Add().Ethernet().Src().SetValue(d.Edge1.ATEAttrs.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(d.Edge1.ATEAttrs.IPv4)
+	v4.Dst().SetValue(d.Edge2.ATEAttrs.IPv4)
+	flow.Rate().SetPps(flowPPS)
+	flow.Duration().FixedPackets().SetPackets(10 * flowPPS)
+	return top
+}
+
+func TestDUTDUT(t *testing.T) {
+	d := topology.NewDUTDUT(t, 2, 2)
+	if d.ATE == nil {
+		t.Fatalf("Testbed has no ATE, want topologies/atedutdutate_4.testbed")
+	}
+	s := sides(t, d)
+
+	d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+		s[dut].configureInterfaces(t)
+		s[dut].configureProtocols(t)
+	})
+
+	otg := d.ATE.OTG()
+	top := configureATE(t, d)
+	otg.PushConfig(t, top)
+	otg.StartProtocols(t)
+
+	t.Run("Protocols", func(t *testing.T) {
+		d.ForEachDUT(t, func(t testing.TB, dut *ondatra.DUTDevice) {
+			s[dut].await(t)
+		})
+	})
+
+	t.Run("Traffic", func(t *testing.T) {
+		otgutils.AwaitNeighborResolution(t, otg, top, 2*time.Minute)
+		otg.StartTraffic(t)
+		time.Sleep(15 * time.Second)
+		otg.StopTraffic(t)
+		otgutils.LogFlowMetrics(t, otg, top)
+		if got := otgutils.GetFlowLossPct(t, otg, flowName, 10*time.Second); got > lossPct {
+			t.Errorf("Flow %s loss: got %.2f%%, want %d%%", flowName, got, lossPct)
+		}
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	"github.com/openconfig/featureprofiles/internal/deviations"
@@ -35,6 +36,7 @@ import (
 	"github.com/openconfig/ondatra/gnoi"
 	"github.com/openconfig/ygnmi/ygnmi"
 	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestMain(m *testing.M) {
@@ -60,6 +62,10 @@ const (
 	nhIndex          = 1
 	nhgIndex         = 42
 	flowName         = "Flow"
+
+	// restartTimeout is the time for the gRIBI daemon to restart after it
+	// is killed.
+	restartTimeout = 5 * time.Minute
 )
 
 var (
@@ -249,6 +255,54 @@ func verifyGRIBIGet(ctx context.Context, t *testing.T, clientA *gribi.Client, du
 	}
 }
 
+// getAllEntries returns the gRIBI Get response with all AFT entries of the
+// default network instance.
+func getAllEntries(t *testing.T, clientA *gribi.Client, dut *ondatra.DUTDevice) *grps.GetResponse {
+	t.Helper()
+	getResponse, err := clientA.Fluent(t).Get().WithNetworkInstance(deviations.DefaultNetworkInstance(dut)).WithAFT(fluent.AllAFTs).Send()
+	if err != nil {
+		t.Fatalf("Cannot Get: %v", err)
+	}
+	return getResponse
+}
+
+// verifyGRIBIGetUnchanged verifies that the gRIBI Get RPC returns the same
+// entries as before the daemon restart.  The RIB and FIB status of the
+// entries are ignored.
+func verifyGRIBIGetUnchanged(t *testing.T, clientA *gribi.Client, dut *ondatra.DUTDevice, before *grps.GetResponse) {
+	t.Helper()
+	if before == nil {
+		t.Fatal("No gRIBI Get response from before the daemon restart")
+	}
+	after := getAllEntries(t, clientA, dut)
+	if diff := cmp.Diff(before, after,
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&grps.AFTEntry{}, "rib_status", "fib_status"),
+		protocmp.SortRepeated(func(a, b *grps.AFTEntry) bool { return a.String() < b.String() }),
+	); diff != "" {
+		t.Errorf("gRIBI Get entries changed across the daemon restart (-before +after):\n%s", diff)
+	}
+}
+
+// awaitProcessRestart waits for a process named pName with a PID other than
+// oldPID, and returns its PID.
+func awaitProcessRestart(t *testing.T, dut *ondatra.DUTDevice, pName string, oldPID uint64) uint64 {
+	t.Helper()
+	var pID uint64
+	_, ok := gnmi.WatchAll(t, dut, gnmi.OC().System().ProcessAny().State(), restartTimeout, func(val *ygnmi.Value[*oc.System_Process]) bool {
+		proc, present := val.Val()
+		if !present || proc.GetName() != pName || proc.GetPid() == oldPID {
+			return false
+		}
+		pID = proc.GetPid()
+		return true
+	}).Await(t)
+	if !ok {
+		t.Fatalf("gRIBI daemon '%s' did not restart within %v", pName, restartTimeout)
+	}
+	return pID
+}
+
 // gNOIKillProcess kills a daemon on the DUT, given its name and pid.
 func gNOIKillProcess(ctx context.Context, t *testing.T, args *testArgs, pName string, pID uint32) {
 	killResponse := gnoi.Execute(t, args.dut, system.NewKillProcessOperation().Name(pName).PID(pID).Signal(gnps.KillProcessRequest_SIGNAL_TERM).Restart(true))
@@ -299,6 +353,9 @@ func TestDUTDaemonFailure(t *testing.T) {
 		top: top,
 	}
 
+	// getBefore is the gRIBI Get response before the daemon restart.
+	var getBefore *grps.GetResponse
+
 	t.Run("SetupGRIBIConnection", func(t *testing.T) {
 
 		// Set parameters for gRIBI client clientA.
@@ -327,6 +384,7 @@ func TestDUTDaemonFailure(t *testing.T) {
 				verifyTraffic(ctx, t, args)
 			})
 
+			getBefore = getAllEntries(t, clientA, dut)
 		})
 
 		t.Logf("Time check: %s", time.Since(start))
@@ -363,9 +421,8 @@ func TestDUTDaemonFailure(t *testing.T) {
 			// Until the models are brought in line, typecasting the uint64 to uint32.
 			gNOIKillProcess(ctx, t, args, pName, uint32(pId))
 
-			// Wait for a bit for gRIBI daemon on the DUT to restart.
-			time.Sleep(30 * time.Second)
-
+			newPID := awaitProcessRestart(t, dut, pName, pId)
+			t.Logf("gRIBI daemon '%s' restarted with pid '%d'", pName, newPID)
 		})
 
 		t.Logf("Time check: %s", time.Since(start))
@@ -400,6 +457,7 @@ func TestDUTDaemonFailure(t *testing.T) {
 
 		t.Run("VerifyGRIBIGet", func(t *testing.T) {
 			verifyGRIBIGet(ctx, t, clientA, dut)
+			verifyGRIBIGetUnchanged(t, clientA, dut, getBefore)
 		})
 	})
 
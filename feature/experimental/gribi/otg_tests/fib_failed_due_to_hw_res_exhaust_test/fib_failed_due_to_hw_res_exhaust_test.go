@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/bgputils"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/gribi"
@@ -33,7 +34,6 @@ import (
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
 	"github.com/openconfig/ondatra/otg"
-	"github.com/openconfig/ygnmi/ygnmi"
 	"github.com/openconfig/ygot/ygot"
 
 	aftspb "github.com/openconfig/gribi/v1/proto/service"
@@ -373,27 +373,8 @@ func verifyTraffic(t *testing.T, args *testArgs, flowName string, wantLoss bool)
 
 func verifyBgpTelemetry(t *testing.T, dut *ondatra.DUTDevice) {
 	t.Helper()
-	var nbrIP = []string{atePort1.IPv6}
 	t.Logf("Verifying BGP state.")
-	bgpPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP").Bgp()
-
-	for _, nbr := range nbrIP {
-		nbrPath := bgpPath.Neighbor(nbr)
-		// Get BGP adjacency state.
-		t.Logf("Waiting for BGP neighbor to establish...")
-		status, ok := gnmi.Watch(t, dut, nbrPath.SessionState().State(), time.Minute, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
-			state, ok := val.Val()
-			return ok && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
-		}).Await(t)
-		if !ok {
-			t.Fatal("No BGP neighbor formed")
-		}
-		state, _ := status.Val()
-		t.Logf("BGP adjacency for %s: %v", nbr, state)
-		if want := oc.Bgp_Neighbor_SessionState_ESTABLISHED; state != want {
-			t.Errorf("BGP peer %s status got %d, want %d", nbr, state, want)
-		}
-	}
+	bgputils.AwaitSessionState(t, dut, atePort1.IPv6, oc.Bgp_Neighbor_SessionState_ESTABLISHED, time.Minute)
 }
 
 // configureDUT configures port1-2 on the DUT.
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	fpargs "github.com/openconfig/featureprofiles/internal/args"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	cmp "github.com/openconfig/featureprofiles/internal/components"
 	"github.com/openconfig/featureprofiles/internal/deviations"
@@ -33,18 +34,18 @@ import (
 	"github.com/openconfig/featureprofiles/internal/gribi"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
 	"github.com/openconfig/gnoigo"
-	"github.com/openconfig/gnoigo/system"
 	"github.com/openconfig/gribigo/chk"
 	"github.com/openconfig/gribigo/constants"
 	"github.com/openconfig/gribigo/fluent"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
-	"github.com/openconfig/ondatra/gnoi"
 	"github.com/openconfig/testt"
 	"github.com/openconfig/ygot/ygot"
 
 	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
 	aftspb "github.com/openconfig/gribi/v1/proto/service"
 )
 
@@ -72,7 +73,6 @@ const (
 	controllerCardType  = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
 	primaryController   = oc.Platform_ComponentRedundantRole_PRIMARY
 	secondaryController = oc.Platform_ComponentRedundantRole_SECONDARY
-	maxSwitchoverTime   = 900
 	configNhg           = true
 	switchover          = true
 )
@@ -482,7 +482,7 @@ func validateSwitchoverStatus(t *testing.T, dut *ondatra.DUTDevice, secondaryBef
 	for {
 		var currentTime string
 		t.Logf("Time elapsed %.2f seconds since switchover started.", time.Since(startSwitchover).Seconds())
-		time.Sleep(30 * time.Second)
+		time.Sleep(fpargs.PollInterval())
 		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
 			currentTime = gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
 		}); errMsg != nil {
@@ -491,8 +491,8 @@ func validateSwitchoverStatus(t *testing.T, dut *ondatra.DUTDevice, secondaryBef
 			t.Logf("Controller switchover has completed successfully with received time: %v", currentTime)
 			break
 		}
-		if uint64(time.Since(startSwitchover).Seconds()) > maxSwitchoverTime {
-			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", time.Since(startSwitchover), maxSwitchoverTime)
+		if got, want := time.Since(startSwitchover), fpargs.SwitchoverTimeout(); got >= want {
+			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", got, want)
 		}
 	}
 	t.Logf("Controller switchover time: %.2f seconds", time.Since(startSwitchover).Seconds())
@@ -644,7 +644,16 @@ func TestRouteAdditionDuringFailover(t *testing.T) {
 
 	go func() {
 		defer wg.Done()
-		switchoverResponse := gnoi.Execute(t, dut, system.NewSwitchControlProcessorOperation().Path(cmp.GetSubcomponentPath(secondaryBeforeSwitch, deviations.GNOISubcomponentPath(dut))))
+		gnoiClient := dut.RawAPIs().GNOI(t)
+		var switchoverResponse *spb.SwitchControlProcessorResponse
+		err := cmp.WithSubcomponentPath(dut, secondaryBeforeSwitch, func(p *tpb.Path) error {
+			var err error
+			switchoverResponse, err = gnoiClient.System().SwitchControlProcessor(context.Background(), &spb.SwitchControlProcessorRequest{ControlProcessor: p})
+			return err
+		})
+		if err != nil {
+			t.Errorf("Failed to perform control processor switchover with unexpected err: %v", err)
+		}
 		t.Logf("swtichover process response: %v", switchoverResponse)
 	}()
 	wg.Wait()
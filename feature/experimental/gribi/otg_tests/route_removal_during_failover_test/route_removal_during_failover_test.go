@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	fpargs "github.com/openconfig/featureprofiles/internal/args"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	cmp "github.com/openconfig/featureprofiles/internal/components"
 	"github.com/openconfig/featureprofiles/internal/deviations"
@@ -33,7 +34,6 @@ import (
 	"github.com/openconfig/featureprofiles/internal/gribi"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
 	"github.com/openconfig/gnoigo"
-	"github.com/openconfig/gnoigo/system"
 	gpb "github.com/openconfig/gribi/v1/proto/service"
 	"github.com/openconfig/gribigo/chk"
 	"github.com/openconfig/gribigo/constants"
@@ -41,11 +41,12 @@ import (
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
-	"github.com/openconfig/ondatra/gnoi"
 	"github.com/openconfig/testt"
 	"github.com/openconfig/ygot/ygot"
 
 	fpb "github.com/openconfig/gnoi/file"
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
 )
 
 func TestMain(m *testing.M) {
@@ -74,7 +75,6 @@ const (
 	primaryController   = oc.Platform_ComponentRedundantRole_PRIMARY
 	secondaryController = oc.Platform_ComponentRedundantRole_SECONDARY
 	switchTrigger       = oc.PlatformTypes_ComponentRedundantRoleSwitchoverReasonTrigger_SYSTEM_INITIATED
-	maxSwitchoverTime   = 900
 )
 
 var (
@@ -436,7 +436,7 @@ func validateSwitchoverStatus(t *testing.T, dut *ondatra.DUTDevice, secondaryBef
 	for {
 		var currentTime string
 		t.Logf("Time elapsed %.2f seconds since switchover started.", time.Since(startSwitchover).Seconds())
-		time.Sleep(30 * time.Second)
+		time.Sleep(fpargs.PollInterval())
 		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
 			currentTime = gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
 		}); errMsg != nil {
@@ -445,8 +445,8 @@ func validateSwitchoverStatus(t *testing.T, dut *ondatra.DUTDevice, secondaryBef
 			t.Logf("Controller switchover has completed successfully with received time: %v", currentTime)
 			break
 		}
-		if uint64(time.Since(startSwitchover).Seconds()) > maxSwitchoverTime {
-			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", time.Since(startSwitchover), maxSwitchoverTime)
+		if got, want := time.Since(startSwitchover), fpargs.SwitchoverTimeout(); got >= want {
+			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", got, want)
 		}
 	}
 	t.Logf("Controller switchover time: %.2f seconds", time.Since(startSwitchover).Seconds())
@@ -602,7 +602,16 @@ func TestRouteRemovalDuringFailover(t *testing.T) {
 	}("gRIBi Flush")
 
 	go func(msg string) {
-		switchoverResponse := gnoi.Execute(t, dut, system.NewSwitchControlProcessorOperation().Path(cmp.GetSubcomponentPath(secondaryBeforeSwitch, deviations.GNOISubcomponentPath(dut))))
+		gnoiClient := dut.RawAPIs().GNOI(t)
+		var switchoverResponse *spb.SwitchControlProcessorResponse
+		err := cmp.WithSubcomponentPath(dut, secondaryBeforeSwitch, func(p *tpb.Path) error {
+			var err error
+			switchoverResponse, err = gnoiClient.System().SwitchControlProcessor(context.Background(), &spb.SwitchControlProcessorRequest{ControlProcessor: p})
+			return err
+		})
+		if err != nil {
+			t.Errorf("Failed to perform control processor switchover with unexpected err: %v", err)
+		}
 		t.Logf("gnoiClient.System().SwitchControlProcessor() response: %v", switchoverResponse)
 	}("Master Switchover")
 
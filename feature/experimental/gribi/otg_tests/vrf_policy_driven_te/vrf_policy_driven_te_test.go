@@ -28,6 +28,7 @@ import (
 	"github.com/google/gopacket/pcap"
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/bgputils"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/gribi"
@@ -875,25 +876,7 @@ func createFlow(flowValues *flowArgs) gosnappi.Flow {
 func verifyBgpTelemetry(t *testing.T, dut *ondatra.DUTDevice) {
 	t.Helper()
 	t.Logf("Verifying BGP state.")
-	bgpPath := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP").Bgp()
-
-	nbrPath := bgpPath.Neighbor(otgIsisPort8LoopV4)
-	// Get BGP adjacency state.
-	t.Logf("Waiting for BGP neighbor to establish...")
-	var status *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]
-	status, ok := gnmi.Watch(t, dut, nbrPath.SessionState().State(), time.Minute, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
-		state, ok := val.Val()
-		return ok && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
-	}).Await(t)
-	if !ok {
-		fptest.LogQuery(t, "BGP reported state", nbrPath.State(), gnmi.Get(t, dut, nbrPath.State()))
-		t.Fatal("No BGP neighbor formed")
-	}
-	state, _ := status.Val()
-	t.Logf("BGP adjacency for %s: %v", otgIsisPort8LoopV4, state)
-	if want := oc.Bgp_Neighbor_SessionState_ESTABLISHED; state != want {
-		t.Errorf("BGP peer %s status got %d, want %d", otgIsisPort8LoopV4, state, want)
-	}
+	bgputils.AwaitSessionState(t, dut, otgIsisPort8LoopV4, oc.Bgp_Neighbor_SessionState_ESTABLISHED, time.Minute)
 }
 
 func programAftWithMagicIp(t *testing.T, dut *ondatra.DUTDevice, args *testArgs) {
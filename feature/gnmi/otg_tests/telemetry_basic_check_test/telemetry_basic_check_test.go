@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/check"
 	"github.com/openconfig/featureprofiles/internal/components"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
@@ -100,10 +101,13 @@ func TestEthernetPortSpeed(t *testing.T) {
 		fptest.SetPortSpeed(t, dp)
 	}
 	want := portSpeed[dp.Speed()]
-	got := gnmi.Get(t, dut, gnmi.OC().Interface(dp.Name()).Ethernet().PortSpeed().State())
-	t.Logf("Got %s PortSpeed from telmetry: %v, expected: %v", dp.Name(), got, want)
-	if got != want {
-		t.Errorf("Get(DUT port1 PortSpeed): got %v, want %v", got, want)
+	client, err := ygnmi.NewClient(dut.RawAPIs().GNMI(t), ygnmi.WithTarget(dut.ID()))
+	if err != nil {
+		t.Fatalf("Failed to create ygnmi client: %v", err)
+	}
+	speed := gnmi.OC().Interface(dp.Name()).Ethernet().PortSpeed().State()
+	if err := check.EqualNormalized(speed, want, deviations.Normalizer[oc.E_IfEthernet_ETHERNET_SPEED](dut)).Check(client); err != nil {
+		t.Errorf("Get(DUT port1 PortSpeed): %v", err)
 	}
 }
 
@@ -0,0 +1,243 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi_heartbeat_suppress_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	heartbeatInterval = flag.Duration("heartbeat_interval", 10*time.Second, "Heartbeat interval of the subscriptions.")
+	sampleInterval    = flag.Duration("sample_interval", 5*time.Second, "SAMPLE interval of the suppress_redundant subscriptions.")
+	tolerance         = flag.Duration("tolerance", 2*time.Second, "Allowed difference between the expected and the actual time of an update.")
+)
+
+const (
+	// heartbeats is the number of heartbeat intervals subscribed for.
+	heartbeats = 6
+	// initialDesc is the description when subscribing, and newDesc the
+	// description set while subscribed.
+	initialDesc = "gNMI heartbeat test"
+	newDesc     = "gNMI heartbeat test - updated"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Heartbeat: subscribe with ON_CHANGE mode and heartbeat_interval to the
+//     description of an interface that does not change, and verify the DUT
+//     sends the value every heartbeat interval.
+//  2. Suppress redundant: subscribe with SAMPLE mode and suppress_redundant,
+//     without heartbeat_interval, change the description once while
+//     subscribed, and verify the DUT sends only the changed value, within a
+//     sample interval of the change.
+//  3. Suppress redundant with heartbeat: subscribe with SAMPLE mode,
+//     suppress_redundant and a heartbeat_interval longer than the sample
+//     interval, and verify the DUT sends the unchanged value every heartbeat
+//     interval instead of every sample interval.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - Per the gNMI specification, suppress_redundant applies to SAMPLE
+//     subscriptions, and heartbeat_interval to ON_CHANGE subscriptions and to
+//     SAMPLE subscriptions with suppress_redundant.
+//   - Update times are the times the notifications are received by the test,
+//     so the tolerance includes the latency from the DUT.
+
+// update is the local time an update after the initial sync was received.
+type update struct {
+	recv time.Time
+	val  string
+}
+
+// subscribe sends a STREAM subscription with sub.
+func subscribe(ctx context.Context, t *testing.T, c gpb.GNMIClient, sub *gpb.Subscription) gpb.GNMI_SubscribeClient {
+	t.Helper()
+	stream, err := c.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("gNMI Subscribe failed: %v", err)
+	}
+	if err := stream.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:         gpb.SubscriptionList_STREAM,
+				Encoding:     gpb.Encoding_PROTO,
+				Subscription: []*gpb.Subscription{sub},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Sending gNMI SubscribeRequest failed: %v", err)
+	}
+	return stream
+}
+
+// receive returns the updates received on stream after the initial sync
+// until ctx is done.
+func receive(ctx context.Context, stream gpb.GNMI_SubscribeClient) ([]update, error) {
+	var got []update
+	synced := false
+	for {
+		resp, err := stream.Recv()
+		recv := time.Now()
+		if err != nil {
+			if ctx.Err() != nil {
+				return got, nil
+			}
+			return got, fmt.Errorf("gNMI Subscribe ended with error: %w", err)
+		}
+		switch r := resp.GetResponse().(type) {
+		case *gpb.SubscribeResponse_SyncResponse:
+			synced = true
+		case *gpb.SubscribeResponse_Update:
+			if !synced {
+				continue
+			}
+			for _, u := range r.Update.GetUpdate() {
+				got = append(got, update{recv: recv, val: u.GetVal().GetStringVal()})
+			}
+		}
+	}
+}
+
+// subscribeUpdates subscribes with sub and returns the updates received
+// after the initial sync until ctx is done.
+func subscribeUpdates(ctx context.Context, t *testing.T, c gpb.GNMIClient, sub *gpb.Subscription) []update {
+	t.Helper()
+	got, err := receive(ctx, subscribe(ctx, t, c, sub))
+	if err != nil {
+		t.Error(err)
+	}
+	return got
+}
+
+// verifyIntervals checks that there are at least min updates in got, and that
+// consecutive updates are want apart within --tolerance.
+func verifyIntervals(t *testing.T, got []update, want time.Duration, min int) {
+	t.Helper()
+	if len(got) < min {
+		t.Errorf("Got %d updates, want at least %d", len(got), min)
+	}
+	for i := 1; i < len(got); i++ {
+		d := got[i].recv.Sub(got[i-1].recv)
+		t.Logf("Update %d received %v after the previous one", i, d)
+		if d < want-*tolerance || d > want+*tolerance {
+			t.Errorf("Update %d received %v after the previous one, want %v within %v", i, d, want, *tolerance)
+		}
+	}
+}
+
+func TestHeartbeatSuppressRedundant(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNMI(t)
+	p1 := dut.Port(t, "port1")
+	descPath := gnmi.OC().Interface(p1.Name()).Description()
+	if origDesc, ok := gnmi.Lookup(t, dut, descPath.Config()).Val(); ok {
+		defer gnmi.Replace(t, dut, descPath.Config(), origDesc)
+	} else {
+		defer gnmi.Delete(t, dut, descPath.Config())
+	}
+	// The heartbeats need a value to send.
+	gnmi.Replace(t, dut, descPath.Config(), initialDesc)
+	gnmi.Await(t, dut, descPath.State(), time.Minute, initialDesc)
+
+	path, err := ygot.StringToStructuredPath(fmt.Sprintf("/interfaces/interface[name=%s]/state/description", p1.Name()))
+	if err != nil {
+		t.Fatalf("Cannot parse the description path of %s: %v", p1.Name(), err)
+	}
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), heartbeats*(*heartbeatInterval)+*tolerance)
+		defer cancel()
+		got := subscribeUpdates(ctx, t, c, &gpb.Subscription{
+			Path:              path,
+			Mode:              gpb.SubscriptionMode_ON_CHANGE,
+			HeartbeatInterval: uint64(heartbeatInterval.Nanoseconds()),
+		})
+		verifyIntervals(t, got, *heartbeatInterval, heartbeats-1)
+	})
+
+	t.Run("Suppress redundant", func(t *testing.T) {
+		// The description is changed after this many sample intervals with
+		// no change, and the subscription lasts as long again.
+		const quiet = 4
+		ctx, cancel := context.WithTimeout(context.Background(), 2*quiet*(*sampleInterval))
+		defer cancel()
+		stream := subscribe(ctx, t, c, &gpb.Subscription{
+			Path:              path,
+			Mode:              gpb.SubscriptionMode_SAMPLE,
+			SampleInterval:    uint64(sampleInterval.Nanoseconds()),
+			SuppressRedundant: true,
+		})
+		type result struct {
+			got []update
+			err error
+		}
+		done := make(chan result, 1)
+		go func() {
+			got, err := receive(ctx, stream)
+			done <- result{got, err}
+		}()
+		time.Sleep(quiet * (*sampleInterval))
+		changed := time.Now()
+		gnmi.Replace(t, dut, descPath.Config(), newDesc)
+		res := <-done
+		if res.err != nil {
+			t.Error(res.err)
+		}
+		got := res.got
+		if len(got) != 1 {
+			t.Fatalf("Got %d updates %v, want 1 update with the changed description", len(got), got)
+		}
+		if got[0].val != newDesc {
+			t.Errorf("Got update with description %q, want %q", got[0].val, newDesc)
+		}
+		if d := got[0].recv.Sub(changed); d > *sampleInterval+*tolerance {
+			t.Errorf("Update received %v after the description changed, want within %v", d, *sampleInterval+*tolerance)
+		}
+	})
+
+	t.Run("Suppress redundant with heartbeat", func(t *testing.T) {
+		hb := *heartbeatInterval
+		if hb <= *sampleInterval {
+			t.Fatalf("--heartbeat_interval %v must be longer than --sample_interval %v", hb, *sampleInterval)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), heartbeats*hb+*tolerance)
+		defer cancel()
+		got := subscribeUpdates(ctx, t, c, &gpb.Subscription{
+			Path:              path,
+			Mode:              gpb.SubscriptionMode_SAMPLE,
+			SampleInterval:    uint64(sampleInterval.Nanoseconds()),
+			SuppressRedundant: true,
+			HeartbeatInterval: uint64(hb.Nanoseconds()),
+		})
+		verifyIntervals(t, got, hb, heartbeats-1)
+	})
+}
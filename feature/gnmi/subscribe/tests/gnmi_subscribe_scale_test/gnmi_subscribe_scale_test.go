@@ -0,0 +1,364 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi_subscribe_scale_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	subscriptionCount  = flag.Int("subscriptions", 100, "Number of concurrent SAMPLE subscriptions.")
+	clientCount        = flag.Int("clients", 10, "Number of gNMI connections the subscriptions are spread across.")
+	runDuration        = flag.Duration("run_duration", 15*time.Minute, "How long the subscriptions are kept open.")
+	sampleInterval     = flag.Duration("sample_interval", 10*time.Second, "SAMPLE interval requested by each subscription.")
+	maxSampleGapFactor = flag.Float64("max_sample_gap_factor", 2, "Maximum gap between updates on a subscription, as a multiple of --sample_interval.")
+	maxCPUUtilization  = flag.Uint("max_cpu_utilization", 80, "Maximum average CPU utilization in percent of any CPU component while the subscriptions are open.")
+	maxMemUtilization  = flag.Float64("max_memory_utilization", 80, "Maximum memory utilization in percent of any controller card while the subscriptions are open.")
+)
+
+const (
+	// resourcePollInterval is how often CPU and memory utilization is read
+	// while the subscriptions are open.
+	resourcePollInterval = time.Minute
+	staticPrefix         = "198.51.100.0/24"
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		IPv4: "192.0.2.2",
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure DUT port-1 and a static route so that the interfaces,
+//     components and protocol subtrees are populated.
+//  2. Open --subscriptions SAMPLE subscriptions spread round robin across the
+//     subtrees and across --clients gNMI connections, each served by its own
+//     goroutine.
+//  3. Keep the subscriptions open for --run_duration, reading the CPU and
+//     memory utilization of the DUT every minute.
+//  4. Verify every subscription stayed open, only received updates under its
+//     subscribed path and never went longer than --max_sample_gap_factor
+//     sample intervals without an update.
+//  5. Verify CPU and memory utilization stayed within the thresholds.
+//
+// Topology:
+//
+//	dut:port1 <--> ate:port1
+//
+// Test notes:
+//   - Subscriptions use raw gNMI connections dialed through the binding so
+//     that each client is a separate gRPC connection to the DUT.
+
+// subscription is a gNMI SAMPLE subscription to one subtree, recording the
+// arrival time of each update and any update outside the subtree.
+type subscription struct {
+	path *gpb.Path
+
+	mu         sync.Mutex
+	arrivals   []time.Time
+	mismatches int
+	mismatch   *gpb.Path
+	err        error
+}
+
+// run subscribes to s.path and records updates until ctx is done.
+func (s *subscription) run(ctx context.Context, c gpb.GNMIClient) {
+	err := s.subscribe(ctx, c)
+	if status.Code(err) == codes.Canceled || err == io.EOF {
+		err = nil
+	}
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+func (s *subscription) subscribe(ctx context.Context, c gpb.GNMIClient) error {
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sub.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:     gpb.SubscriptionList_STREAM,
+				Encoding: gpb.Encoding_PROTO,
+				Subscription: []*gpb.Subscription{{
+					Path:           s.path,
+					Mode:           gpb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(sampleInterval.Nanoseconds()),
+				}},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	for {
+		resp, err := sub.Recv()
+		if err != nil {
+			return err
+		}
+		n := resp.GetUpdate()
+		if len(n.GetUpdate()) == 0 {
+			continue
+		}
+		s.mu.Lock()
+		s.arrivals = append(s.arrivals, time.Now())
+		for _, u := range n.GetUpdate() {
+			p := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)}
+			if !hasPrefix(p, s.path) {
+				s.mismatches++
+				s.mismatch = p
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// maxGap returns the longest time between updates within [start, end],
+// counting the window edges.
+func (s *subscription) maxGap(start, end time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var gap time.Duration
+	last := start
+	for _, a := range s.arrivals {
+		if a.Before(start) {
+			continue
+		}
+		if a.After(end) {
+			break
+		}
+		if d := a.Sub(last); d > gap {
+			gap = d
+		}
+		last = a
+	}
+	if d := end.Sub(last); d > gap {
+		gap = d
+	}
+	return gap
+}
+
+// hasPrefix reports whether the elements of p start with the elements of
+// prefix, including their keys.
+func hasPrefix(p, prefix *gpb.Path) bool {
+	if len(p.GetElem()) < len(prefix.GetElem()) {
+		return false
+	}
+	for i, want := range prefix.GetElem() {
+		got := p.GetElem()[i]
+		if got.GetName() != want.GetName() {
+			return false
+		}
+		for k, v := range want.GetKey() {
+			if got.GetKey()[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// subtrees returns the paths the subscriptions are spread across.
+func subtrees(t *testing.T, dut *ondatra.DUTDevice) []*gpb.Path {
+	t.Helper()
+	var paths []string
+	for _, p := range dut.Ports() {
+		paths = append(paths, fmt.Sprintf("/interfaces/interface[name=%s]/state", p.Name()))
+	}
+	for _, c := range components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD) {
+		paths = append(paths, fmt.Sprintf("/components/component[name=%s]/state", c))
+	}
+	paths = append(paths, fmt.Sprintf("/network-instances/network-instance[name=%s]/protocols/protocol[identifier=STATIC][name=%s]",
+		deviations.DefaultNetworkInstance(dut), deviations.StaticProtocolName(dut)))
+
+	var gps []*gpb.Path
+	for _, s := range paths {
+		p, err := ygot.StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("Cannot parse path %q: %v", s, err)
+		}
+		p.Origin = "openconfig"
+		gps = append(gps, p)
+	}
+	return gps
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	dp := dut.Port(t, "port1")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), dutPort1.NewOCInterface(dp.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, dp)
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+
+	b := &gnmi.SetBatch{}
+	if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+		NetworkInstance: deviations.DefaultNetworkInstance(dut),
+		Prefix:          staticPrefix,
+		NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+			"0": oc.UnionString(atePort1.IPv4),
+		},
+	}, dut); err != nil {
+		t.Fatalf("Failed to configure static route: %v", err)
+	}
+	b.Set(t, dut)
+}
+
+// resourceUsage tracks the highest CPU and memory utilization seen per
+// component.
+type resourceUsage struct {
+	cpu map[string]uint8
+	mem map[string]float64
+}
+
+// poll reads the current CPU utilization of every CPU component and the
+// memory utilization of every controller card.
+func (r *resourceUsage) poll(t *testing.T, dut *ondatra.DUTDevice, cpus, cards []string) {
+	t.Helper()
+	for _, c := range cpus {
+		if avg, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(c).Cpu().Utilization().Avg().State()).Val(); ok && avg > r.cpu[c] {
+			r.cpu[c] = avg
+		}
+	}
+	for _, c := range cards {
+		mem, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(c).Memory().State()).Val()
+		if !ok || mem.GetAvailable()+mem.GetUtilized() == 0 {
+			continue
+		}
+		if pct := 100 * float64(mem.GetUtilized()) / float64(mem.GetAvailable()+mem.GetUtilized()); pct > r.mem[c] {
+			r.mem[c] = pct
+		}
+	}
+}
+
+func TestSubscribeScale(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	configureDUT(t, dut)
+
+	paths := subtrees(t, dut)
+	cpus := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CPU)
+	cards := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var clients []gpb.GNMIClient
+	for i := 0; i < *clientCount; i++ {
+		c, err := dut.RawAPIs().BindingDUT().DialGNMI(ctx)
+		if err != nil {
+			t.Fatalf("Failed to dial gNMI client %d: %v", i, err)
+		}
+		clients = append(clients, c)
+	}
+
+	var wg sync.WaitGroup
+	subs := make([]*subscription, *subscriptionCount)
+	for i := range subs {
+		subs[i] = &subscription{path: paths[i%len(paths)]}
+		wg.Add(1)
+		go func(s *subscription, c gpb.GNMIClient) {
+			defer wg.Done()
+			s.run(ctx, c)
+		}(subs[i], clients[i%len(clients)])
+	}
+	t.Logf("Opened %d SAMPLE subscriptions over %d clients across %d subtrees", len(subs), len(clients), len(paths))
+
+	// Let every subscription deliver its initial sample before measuring.
+	time.Sleep(*sampleInterval)
+	start := time.Now()
+	usage := &resourceUsage{cpu: map[string]uint8{}, mem: map[string]float64{}}
+	for time.Since(start) < *runDuration {
+		usage.poll(t, dut, cpus, cards)
+		time.Sleep(resourcePollInterval)
+	}
+	end := time.Now()
+	usage.poll(t, dut, cpus, cards)
+	cancel()
+	wg.Wait()
+
+	t.Run("Subscriptions", func(t *testing.T) {
+		maxGap := time.Duration(*maxSampleGapFactor * float64(*sampleInterval))
+		for i, s := range subs {
+			p, err := ygot.PathToString(s.path)
+			if err != nil {
+				t.Fatalf("Cannot format path %v: %v", s.path, err)
+			}
+			if s.err != nil {
+				t.Errorf("Subscription %d to %s failed: %v", i, p, s.err)
+			}
+			if gap := s.maxGap(start, end); gap > maxGap {
+				t.Errorf("Subscription %d to %s: longest gap between updates got %v, want <= %v", i, p, gap, maxGap)
+			}
+			if s.mismatches > 0 {
+				t.Errorf("Subscription %d to %s: got %d updates outside the subscribed path, e.g. %v", i, p, s.mismatches, s.mismatch)
+			}
+		}
+	})
+
+	t.Run("Resources", func(t *testing.T) {
+		if len(usage.cpu) == 0 {
+			t.Errorf("No CPU utilization reported for CPU components %v", cpus)
+		}
+		for c, got := range usage.cpu {
+			t.Logf("%s peak average CPU utilization: %d%%", c, got)
+			if uint(got) > *maxCPUUtilization {
+				t.Errorf("%s CPU utilization: got %d%%, want <= %d%%", c, got, *maxCPUUtilization)
+			}
+		}
+		if len(usage.mem) == 0 {
+			t.Errorf("No memory utilization reported for controller cards %v", cards)
+		}
+		for c, got := range usage.mem {
+			t.Logf("%s peak memory utilization: %.1f%%", c, got)
+			if got > *maxMemUtilization {
+				t.Errorf("%s memory utilization: got %.1f%%, want <= %.1f%%", c, got, *maxMemUtilization)
+			}
+		}
+	})
+}
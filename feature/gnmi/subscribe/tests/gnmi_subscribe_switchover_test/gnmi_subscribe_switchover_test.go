@@ -0,0 +1,332 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi_subscribe_switchover_test
+
+import (
+	"context"
+	"flag"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/testt"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+var (
+	subscriptionCount = flag.Int("subscriptions", 40, "Number of concurrent SAMPLE subscriptions.")
+	clientCount       = flag.Int("clients", 4, "Number of gNMI connections the subscriptions are spread across.")
+	sampleInterval    = flag.Duration("sample_interval", 10*time.Second, "SAMPLE interval requested by each subscription.")
+	gapBudget         = flag.Duration("gap_budget", 3*time.Minute, "Maximum gap between updates on a subscription across the switchover.")
+	maxClockSkew      = flag.Duration("max_clock_skew", 5*time.Second, "Maximum amount a notification timestamp may be ahead of its arrival time.")
+)
+
+const (
+	// redialInterval is how often a subscription whose stream failed tries
+	// to reconnect.
+	redialInterval = time.Second
+	// settleSamples is the number of sample intervals the subscriptions are
+	// kept open before the switchover and after the DUT recovers.
+	settleSamples = 3
+)
+
+// subscribedPaths are the subtrees the subscriptions are spread across.
+var subscribedPaths = []string{
+	"/interfaces/interface[name=*]/state/counters",
+	"/components/component[name=*]/state",
+	"/system/state",
+	"/system/processes/process[pid=*]/state",
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Open --subscriptions SAMPLE subscriptions spread round robin across
+//     subscribedPaths and across --clients gNMI connections.  A subscription
+//     whose stream fails redials and resubscribes until the test ends.
+//  2. Once every subscription has received its initial updates, switch over
+//     to the standby controller card using gNOI SwitchControlProcessor, and
+//     wait for the DUT to respond to gNMI again.
+//  3. Verify that every subscription received updates after the
+//     switchover, and that its longest gap between updates from the
+//     switchover to the end of the test is within --gap_budget.
+//  4. Verify that notification timestamps are sane on every subscription:
+//     the timestamp of each leaf never goes backwards, including across the
+//     switchover, and no timestamp is more than --max_clock_skew ahead of
+//     its arrival time.  Every stream, including those opened after the
+//     switchover, must send a sync response.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The test is skipped on DUTs with fewer than 2 controller cards.
+//   - Subscriptions use raw gNMI connections dialed through the binding so
+//     that each client is a separate gRPC connection to the DUT, and so that
+//     they can be redialed after the switchover.
+
+// subscription is a gNMI SAMPLE subscription to one subtree that survives
+// stream failures, recording the arrival time of each update and any
+// violation of notification timestamp ordering.
+type subscription struct {
+	path *gpb.Path
+
+	mu       sync.Mutex
+	arrivals []time.Time
+	// last is the latest timestamp received per leaf path.
+	last       map[string]int64
+	streams    int
+	syncs      int
+	regressed  int
+	regression string
+	future     int
+	errs       []error
+}
+
+// run subscribes to s.path, resubscribing on a new connection whenever the
+// stream fails, until ctx is done.
+func (s *subscription) run(ctx context.Context, dut *ondatra.DUTDevice, c gpb.GNMIClient) {
+	for {
+		err := s.subscribe(ctx, c)
+		if ctx.Err() != nil {
+			return
+		}
+		s.mu.Lock()
+		s.errs = append(s.errs, err)
+		s.mu.Unlock()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(redialInterval):
+			}
+			if c, err = dut.RawAPIs().BindingDUT().DialGNMI(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+func (s *subscription) subscribe(ctx context.Context, c gpb.GNMIClient) error {
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sub.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:     gpb.SubscriptionList_STREAM,
+				Encoding: gpb.Encoding_PROTO,
+				Subscription: []*gpb.Subscription{{
+					Path:           s.path,
+					Mode:           gpb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(sampleInterval.Nanoseconds()),
+				}},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.streams++
+	s.mu.Unlock()
+	for {
+		resp, err := sub.Recv()
+		if err != nil {
+			return err
+		}
+		if resp.GetSyncResponse() {
+			s.mu.Lock()
+			s.syncs++
+			s.mu.Unlock()
+			continue
+		}
+		s.record(resp.GetUpdate(), time.Now())
+	}
+}
+
+// record records the arrival of notification n.
+func (s *subscription) record(n *gpb.Notification, arrival time.Time) {
+	if len(n.GetUpdate()) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.arrivals = append(s.arrivals, arrival)
+	ts := n.GetTimestamp()
+	if time.Unix(0, ts).After(arrival.Add(*maxClockSkew)) {
+		s.future++
+	}
+	for _, u := range n.GetUpdate() {
+		p := &gpb.Path{Elem: append(append([]*gpb.PathElem{}, n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)}
+		leaf, err := ygot.PathToString(p)
+		if err != nil {
+			continue
+		}
+		if prev, ok := s.last[leaf]; ok && ts < prev {
+			s.regressed++
+			s.regression = leaf
+			continue
+		}
+		s.last[leaf] = ts
+	}
+}
+
+// maxGap returns the longest time between updates within [start, end],
+// counting the window edges, and whether there were any updates in it.
+func (s *subscription) maxGap(start, end time.Time) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var gap time.Duration
+	last := start
+	updated := false
+	for _, a := range s.arrivals {
+		if a.Before(start) {
+			continue
+		}
+		if a.After(end) {
+			break
+		}
+		if d := a.Sub(last); d > gap {
+			gap = d
+		}
+		last = a
+		updated = true
+	}
+	if d := end.Sub(last); d > gap {
+		gap = d
+	}
+	return gap, updated
+}
+
+// switchover switches over to the standby controller card and waits for the
+// DUT to respond to gNMI, returning the time the switchover was requested.
+func switchover(t *testing.T, dut *ondatra.DUTDevice, cards []string) time.Time {
+	t.Helper()
+	standby, active := components.FindStandbyRP(t, dut, cards)
+	t.Logf("Detected standby RP: %v, active RP: %v", standby, active)
+	gnmi.Await(t, dut, gnmi.OC().Component(active).SwitchoverReady().State(), 30*time.Minute, true)
+
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	start := time.Now()
+	err := components.WithSubcomponentPath(dut, standby, func(p *tpb.Path) error {
+		_, err := gnoiClient.System().SwitchControlProcessor(context.Background(), &spb.SwitchControlProcessorRequest{ControlProcessor: p})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to perform control processor switchover: %v", err)
+	}
+
+	for {
+		time.Sleep(args.PollInterval())
+		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
+			gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
+		}); errMsg == nil {
+			break
+		}
+		if got, want := time.Since(start), args.SwitchoverTimeout(); got >= want {
+			t.Fatalf("time.Since(start): got %v, want < %v", got, want)
+		}
+	}
+	t.Logf("RP switchover time: %.2f seconds", time.Since(start).Seconds())
+	if _, got := components.FindStandbyRP(t, dut, cards); got != standby {
+		t.Errorf("Active RP after switchover: got %v, want %v", got, standby)
+	}
+	return start
+}
+
+func TestSubscribeSwitchover(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	cards := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD)
+	if got, want := len(cards), 2; got < want {
+		t.Skipf("Not enough controller cards for the test on %v: got %v, want at least %v", dut.Model(), got, want)
+	}
+
+	var paths []*gpb.Path
+	for _, s := range subscribedPaths {
+		p, err := ygot.StringToStructuredPath(s)
+		if err != nil {
+			t.Fatalf("Cannot parse path %q: %v", s, err)
+		}
+		p.Origin = "openconfig"
+		paths = append(paths, p)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var clients []gpb.GNMIClient
+	for i := 0; i < *clientCount; i++ {
+		c, err := dut.RawAPIs().BindingDUT().DialGNMI(ctx)
+		if err != nil {
+			t.Fatalf("Failed to dial gNMI client %d: %v", i, err)
+		}
+		clients = append(clients, c)
+	}
+
+	var wg sync.WaitGroup
+	subs := make([]*subscription, *subscriptionCount)
+	for i := range subs {
+		subs[i] = &subscription{path: paths[i%len(paths)], last: map[string]int64{}}
+		wg.Add(1)
+		go func(s *subscription, c gpb.GNMIClient) {
+			defer wg.Done()
+			s.run(ctx, dut, c)
+		}(subs[i], clients[i%len(clients)])
+	}
+	t.Logf("Opened %d SAMPLE subscriptions over %d clients across %d subtrees", len(subs), len(clients), len(paths))
+
+	time.Sleep(settleSamples * *sampleInterval)
+	start := switchover(t, dut, cards)
+	time.Sleep(settleSamples * *sampleInterval)
+	end := time.Now()
+	cancel()
+	wg.Wait()
+
+	for i, s := range subs {
+		p, err := ygot.PathToString(s.path)
+		if err != nil {
+			t.Fatalf("Cannot format path %v: %v", s.path, err)
+		}
+		gap, updated := s.maxGap(start, end)
+		t.Logf("Subscription %d to %s: %d streams, stream errors %v, longest gap after switchover %v", i, p, s.streams, s.errs, gap)
+		if !updated {
+			t.Errorf("Subscription %d to %s: got no updates after the switchover", i, p)
+		} else if gap > *gapBudget {
+			t.Errorf("Subscription %d to %s: longest gap between updates across the switchover got %v, want <= %v", i, p, gap, *gapBudget)
+		}
+		if s.syncs < s.streams {
+			t.Errorf("Subscription %d to %s: got %d sync responses over %d streams, want one per stream", i, p, s.syncs, s.streams)
+		}
+		if s.regressed > 0 {
+			t.Errorf("Subscription %d to %s: got %d updates with a timestamp older than the previous update of the leaf, e.g. %s", i, p, s.regressed, s.regression)
+		}
+		if s.future > 0 {
+			t.Errorf("Subscription %d to %s: got %d notifications timestamped more than %v after their arrival", i, p, s.future, *maxClockSkew)
+		}
+	}
+}
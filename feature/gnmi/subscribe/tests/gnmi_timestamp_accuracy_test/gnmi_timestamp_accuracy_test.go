@@ -0,0 +1,215 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi_timestamp_accuracy_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+)
+
+var (
+	maxSkew        = flag.Duration("max_skew", 2*time.Second, "Maximum difference between a notification timestamp and the DUT clock when the notification is received.")
+	sampleInterval = flag.Duration("sample_interval", 5*time.Second, "SAMPLE interval of the subscription.")
+	samples        = flag.Int("samples", 20, "Number of sample intervals to receive notifications for.")
+)
+
+const (
+	// timeProbes is the number of gNOI System.Time requests used to estimate
+	// the DUT clock offset.  The one with the shortest round trip is used.
+	timeProbes = 10
+	// subscribePath is sampled because its values change every interval on
+	// every platform.
+	subscribePath = "/components/component/cpu/utilization/state"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Estimate the offset of the DUT clock from the test host clock with
+//     gNOI System.Time.
+//  2. Subscribe with SAMPLE mode to the CPU utilization of every component
+//     for --samples intervals, recording the time each notification is
+//     received.
+//  3. Verify the timestamp of every notification is within --max_skew of the
+//     DUT clock when it was received, estimated from the offset.
+//  4. Verify the timestamps of the updates to each path strictly increase.
+//  5. Estimate the offset again and verify it changed by no more than
+//     --max_skew.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The DUT clock at the time a notification is received is estimated as
+//     the receive time on the test host plus the offset, so the skew includes
+//     the network latency from the DUT to the test host.
+//   - The offset is taken from the System.Time response with the shortest
+//     round trip, assuming the DUT read its clock halfway through it.
+
+// clockOffset returns the estimated offset of the DUT clock from the local
+// clock, and the round trip time of the System.Time request it was estimated
+// from.
+func clockOffset(ctx context.Context, t *testing.T, c spb.SystemClient) (time.Duration, time.Duration) {
+	t.Helper()
+	var offset time.Duration
+	rtt := time.Duration(-1)
+	for i := 0; i < timeProbes; i++ {
+		start := time.Now()
+		resp, err := c.Time(ctx, &spb.TimeRequest{})
+		end := time.Now()
+		if err != nil {
+			t.Fatalf("gNOI System.Time failed: %v", err)
+		}
+		if r := end.Sub(start); rtt < 0 || r < rtt {
+			rtt = r
+			mid := start.Add(r / 2)
+			offset = time.Unix(0, int64(resp.GetTime())).Sub(mid)
+		}
+	}
+	return offset, rtt
+}
+
+// notification is a notification received from the DUT and the local time it
+// was received.
+type notification struct {
+	n    *gpb.Notification
+	recv time.Time
+}
+
+// subscribe receives the notifications of a SAMPLE subscription to path
+// until ctx is done, excluding the initial sync.
+func subscribe(ctx context.Context, t *testing.T, c gpb.GNMIClient, path *gpb.Path) []notification {
+	t.Helper()
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("gNMI Subscribe failed: %v", err)
+	}
+	if err := sub.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:     gpb.SubscriptionList_STREAM,
+				Encoding: gpb.Encoding_PROTO,
+				Subscription: []*gpb.Subscription{{
+					Path:           path,
+					Mode:           gpb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(sampleInterval.Nanoseconds()),
+				}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Sending gNMI SubscribeRequest failed: %v", err)
+	}
+
+	var got []notification
+	synced := false
+	for {
+		resp, err := sub.Recv()
+		recv := time.Now()
+		if err != nil {
+			if ctx.Err() == nil {
+				t.Errorf("gNMI Subscribe ended with error: %v", err)
+			}
+			return got
+		}
+		switch r := resp.GetResponse().(type) {
+		case *gpb.SubscribeResponse_SyncResponse:
+			synced = true
+		case *gpb.SubscribeResponse_Update:
+			if synced {
+				got = append(got, notification{n: r.Update, recv: recv})
+			}
+		}
+	}
+}
+
+func TestTimestampAccuracy(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	sys := dut.RawAPIs().GNOI(t).System()
+	gnmiClient := dut.RawAPIs().GNMI(t)
+
+	ctx := context.Background()
+	offset, rtt := clockOffset(ctx, t, sys)
+	t.Logf("DUT clock offset: %v, System.Time round trip: %v", offset, rtt)
+
+	path, err := ygot.StringToStructuredPath(subscribePath)
+	if err != nil {
+		t.Fatalf("Cannot parse %s: %v", subscribePath, err)
+	}
+	subCtx, cancel := context.WithTimeout(ctx, time.Duration(*samples)*(*sampleInterval))
+	defer cancel()
+	notifs := subscribe(subCtx, t, gnmiClient, path)
+	if len(notifs) == 0 {
+		t.Fatalf("No notifications received for %s after the initial sync", subscribePath)
+	}
+	t.Logf("Received %d notifications", len(notifs))
+
+	t.Run("Skew", func(t *testing.T) {
+		var minSkew, maxSeen time.Duration
+		for i, n := range notifs {
+			ts := time.Unix(0, n.n.GetTimestamp())
+			skew := n.recv.Add(offset).Sub(ts)
+			if i == 0 || skew < minSkew {
+				minSkew = skew
+			}
+			if i == 0 || skew > maxSeen {
+				maxSeen = skew
+			}
+			if skew > *maxSkew || skew < -*maxSkew {
+				t.Errorf("Notification with timestamp %v received at DUT time %v: skew %v, want within %v", ts, n.recv.Add(offset), skew, *maxSkew)
+			}
+		}
+		t.Logf("Skew from DUT clock: min %v, max %v", minSkew, maxSeen)
+	})
+
+	t.Run("Monotonicity", func(t *testing.T) {
+		last := make(map[string]int64)
+		for _, n := range notifs {
+			ts := n.n.GetTimestamp()
+			for _, u := range n.n.GetUpdate() {
+				p, err := ygot.PathToString(&gpb.Path{Elem: append(append([]*gpb.PathElem{}, n.n.GetPrefix().GetElem()...), u.GetPath().GetElem()...)})
+				if err != nil {
+					t.Fatalf("Cannot format path of update %v: %v", u, err)
+				}
+				if prev, ok := last[p]; ok && ts <= prev {
+					t.Errorf("Update to %s has timestamp %d, want greater than the previous %d", p, ts, prev)
+				}
+				last[p] = ts
+			}
+		}
+		if len(last) == 0 {
+			t.Errorf("No updates received for %s", subscribePath)
+		}
+	})
+
+	t.Run("Drift", func(t *testing.T) {
+		end, rtt := clockOffset(ctx, t, sys)
+		t.Logf("DUT clock offset: %v, System.Time round trip: %v", end, rtt)
+		if d := end - offset; d > *maxSkew || d < -*maxSkew {
+			t.Errorf("DUT clock offset changed by %v during the subscription, want within %v", d, *maxSkew)
+		}
+	})
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_load_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/testt"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	fpb "github.com/openconfig/gnoi/file"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+var (
+	staticRoutes = flag.Int("static_routes", 10000, "Number of static routes added to the running configuration to make the staged configuration large.")
+	loadCLI      = flag.String("config_load_cli", "", "CLI that activates a staged configuration file, with a %s verb for its path. Required with the config_load_from_file_cli deviation.")
+)
+
+const (
+	largeFile   = "fp-config-load-large.json"
+	invalidFile = "fp-config-load-invalid.json"
+	// chunkSize is the size of each Put contents message, as recommended by
+	// the gNOI file service.
+	chunkSize = 64 << 10
+	// permissions is the octal UNIX mode of the files on the DUT.
+	permissions = 644
+)
+
+var (
+	// vendorFileDir is the directory on the DUT the files are written to.
+	vendorFileDir = map[ondatra.Vendor]string{
+		ondatra.ARISTA:  "/mnt/flash/",
+		ondatra.CISCO:   "/misc/disk1/",
+		ondatra.JUNIPER: "/var/tmp/",
+		ondatra.NOKIA:   "/tmp/",
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Stage the running configuration with -static_routes static routes added
+//     to it as an RFC 7951 JSON file on the DUT with gNOI File.Put, and verify
+//     it with File.Stat and File.Get.
+//  2. Activate the staged file, measure the activation time, and verify the
+//     running configuration has the static routes.
+//  3. Stage a truncated copy of the file, and verify its activation fails and
+//     the running configuration is unchanged.
+//  4. Restore the running configuration from before the test.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The staged file is activated with a gNMI Set that replaces the root
+//     with the contents of the file, read back from the DUT with File.Get.
+//     Devices with the config_load_from_file_cli deviation activate it with
+//     -config_load_cli instead.
+//   - The activation time is checked against -arg_full_config_replace_time
+//     when it is set.
+
+// put writes content to remote on the DUT.
+func put(t *testing.T, c fpb.FileClient, remote string, content []byte) {
+	t.Helper()
+	stream, err := c.Put(context.Background())
+	if err != nil {
+		t.Fatalf("Put(%q) failed: %v", remote, err)
+	}
+	if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Open{Open: &fpb.PutRequest_Details{
+		RemoteFile:  remote,
+		Permissions: permissions,
+	}}}); err != nil {
+		t.Fatalf("Put(%q) open failed: %v", remote, err)
+	}
+	for i := 0; i < len(content); i += chunkSize {
+		chunk := content[i:min(i+chunkSize, len(content))]
+		if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Contents{Contents: chunk}}); err != nil {
+			t.Fatalf("Put(%q) failed after %d bytes: %v", remote, i, err)
+		}
+	}
+	sum := sha256.Sum256(content)
+	if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Hash{Hash: &tpb.HashType{
+		Method: tpb.HashType_SHA256,
+		Hash:   sum[:],
+	}}}); err != nil {
+		t.Fatalf("Put(%q) hash failed: %v", remote, err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("Put(%q) close failed: %v", remote, err)
+	}
+	t.Cleanup(func() {
+		if _, err := c.Remove(context.Background(), &fpb.RemoveRequest{RemoteFile: remote}); err != nil {
+			t.Errorf("Remove(%q) failed: %v", remote, err)
+		}
+	})
+}
+
+// get returns the contents of remote on the DUT.
+func get(t *testing.T, c fpb.FileClient, remote string) []byte {
+	t.Helper()
+	stream, err := c.Get(context.Background(), &fpb.GetRequest{RemoteFile: remote})
+	if err != nil {
+		t.Fatalf("Get(%q) failed: %v", remote, err)
+	}
+	var content bytes.Buffer
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Get(%q) failed after %d bytes: %v", remote, content.Len(), err)
+		}
+		content.Write(resp.GetContents())
+	}
+	return content.Bytes()
+}
+
+// stage writes content to remote on the DUT, and verifies the DUT stored it.
+func stage(t *testing.T, c fpb.FileClient, remote string, content []byte) {
+	t.Helper()
+	put(t, c, remote, content)
+	resp, err := c.Stat(context.Background(), &fpb.StatRequest{Path: remote})
+	if err != nil {
+		t.Fatalf("Stat(%q) failed: %v", remote, err)
+	}
+	if len(resp.GetStats()) != 1 {
+		t.Fatalf("Stat(%q) returned %d entries, want 1: %v", remote, len(resp.GetStats()), resp)
+	}
+	if got, want := resp.GetStats()[0].GetSize(), uint64(len(content)); got != want {
+		t.Errorf("Stat(%q) size: got %d, want %d", remote, got, want)
+	}
+	if got := get(t, c, remote); !bytes.Equal(got, content) {
+		t.Errorf("Get(%q) returned %d bytes that differ from the %d bytes staged", remote, len(got), len(content))
+	}
+	t.Logf("Staged %d bytes in %s", len(content), remote)
+}
+
+// activate activates the configuration staged in remote, and returns how long
+// it took.
+func activate(t *testing.T, dut *ondatra.DUTDevice, remote string) (time.Duration, error) {
+	t.Helper()
+	if deviations.ConfigLoadFromFileCLI(dut) {
+		if *loadCLI == "" {
+			t.Fatalf("-config_load_cli is required to activate a staged configuration on %s", dut.ID())
+		}
+		start := time.Now()
+		var err error
+		if msg := testt.CaptureFatal(t, func(t testing.TB) {
+			helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(*loadCLI, remote))
+		}); msg != nil {
+			err = errors.New(*msg)
+		}
+		return time.Since(start), err
+	}
+
+	content := get(t, dut.RawAPIs().GNOI(t).File(), remote)
+	req := &gpb.SetRequest{
+		Replace: []*gpb.Update{{
+			Path: &gpb.Path{Origin: "openconfig"},
+			Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: content}},
+		}},
+	}
+	start := time.Now()
+	_, err := dut.RawAPIs().GNMI(t).Set(context.Background(), req)
+	return time.Since(start), err
+}
+
+// marshal returns config as RFC 7951 JSON.
+func marshal(t *testing.T, config *oc.Root) []byte {
+	t.Helper()
+	j, err := ygot.EmitJSON(config, &ygot.EmitJSONConfig{
+		Format:         ygot.RFC7951,
+		Indent:         "  ",
+		RFC7951Config:  &ygot.RFC7951JSONConfig{AppendModuleName: true},
+		SkipValidation: true,
+	})
+	if err != nil {
+		t.Fatalf("Cannot marshal configuration: %v", err)
+	}
+	return []byte(j)
+}
+
+// addStaticRoutes adds n static routes with a DROP next hop to config.
+func addStaticRoutes(dut *ondatra.DUTDevice, config *oc.Root, n int) {
+	static := config.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.StaticProtocolName(dut))
+	for i := 0; i < n; i++ {
+		// Routes are /32s in 198.18.0.0/15.
+		prefix := fmt.Sprintf("198.%d.%d.%d/32", 18+i>>16, i>>8&0xff, i&0xff)
+		s := static.GetOrCreateStatic(prefix)
+		s.GetOrCreateNextHop("0").NextHop = oc.LocalRouting_LOCAL_DEFINED_NEXT_HOP_DROP
+	}
+}
+
+// countStaticRoutes returns the number of static routes in the running
+// configuration.
+func countStaticRoutes(t *testing.T, dut *ondatra.DUTDevice) int {
+	t.Helper()
+	p := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.StaticProtocolName(dut))
+	v := gnmi.Lookup(t, dut, p.Config())
+	static, ok := v.Val()
+	if !ok {
+		return 0
+	}
+	return len(static.Static)
+}
+
+// countStaticRoutesIn returns the number of static routes in config.
+func countStaticRoutesIn(dut *ondatra.DUTDevice, config *oc.Root) int {
+	static := config.GetNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.StaticProtocolName(dut))
+	if static == nil {
+		return 0
+	}
+	return len(static.Static)
+}
+
+func TestConfigLoadFromFile(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNOI(t).File()
+	dir, ok := vendorFileDir[dut.Vendor()]
+	if !ok {
+		t.Fatalf("No file directory defined for vendor %s", dut.Vendor())
+	}
+
+	baseline := gnmi.Get(t, dut, gnmi.OC().Config())
+	baselineRoutes := countStaticRoutes(t, dut)
+	large, err := ygot.DeepCopy(baseline)
+	if err != nil {
+		t.Fatalf("Cannot copy running configuration: %v", err)
+	}
+	addStaticRoutes(dut, large.(*oc.Root), *staticRoutes)
+	content := marshal(t, large.(*oc.Root))
+	wantRoutes := countStaticRoutesIn(dut, large.(*oc.Root))
+
+	defer func() {
+		t.Log("Restoring the running configuration from before the test")
+		gnmi.Replace(t, dut, gnmi.OC().Config(), baseline)
+		if got := countStaticRoutes(t, dut); got != baselineRoutes {
+			t.Errorf("Static routes after restoring the configuration: got %d, want %d", got, baselineRoutes)
+		}
+	}()
+
+	t.Run("Large configuration", func(t *testing.T) {
+		remote := path.Join(dir, largeFile)
+		stage(t, c, remote, content)
+		elapsed, err := activate(t, dut, remote)
+		if err != nil {
+			t.Fatalf("Activating staged configuration %s failed: %v", remote, err)
+		}
+		t.Logf("Activated %d byte configuration in %v", len(content), elapsed)
+		if *args.FullConfigReplaceTime > 0 && elapsed > *args.FullConfigReplaceTime {
+			t.Errorf("Activation time of staged configuration: got %v, want <= %v", elapsed, *args.FullConfigReplaceTime)
+		}
+		if got := countStaticRoutes(t, dut); got != wantRoutes {
+			t.Errorf("Static routes after activating staged configuration: got %d, want %d", got, wantRoutes)
+		}
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		before := countStaticRoutes(t, dut)
+		remote := path.Join(dir, invalidFile)
+		stage(t, c, remote, content[:len(content)/2])
+		if _, err := activate(t, dut, remote); err == nil {
+			t.Errorf("Activating truncated configuration %s succeeded, want an error", remote)
+		} else {
+			t.Logf("Activating truncated configuration %s failed as expected: %v", remote, err)
+		}
+		if got := countStaticRoutes(t, dut); got != before {
+			t.Errorf("Static routes after failed activation: got %d, want %d from before", got, before)
+		}
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_transfer_benchmark_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+
+	fpb "github.com/openconfig/gnoi/file"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+const (
+	// fileSize is the size of the transferred file.
+	fileSize = 1 << 30
+	// chunkSize is the size of each Put contents message, as recommended by
+	// the gNOI file service.
+	chunkSize = 64 << 10
+	fileName  = "fp-file-transfer-benchmark.bin"
+	// permissions is the octal UNIX mode of the file on the DUT.
+	permissions     = 644
+	transferTimeout = 30 * time.Minute
+	seed            = 1
+)
+
+var (
+	// vendorFileDir is the directory on the DUT the file is written to.
+	vendorFileDir = map[ondatra.Vendor]string{
+		ondatra.ARISTA:  "/mnt/flash/",
+		ondatra.CISCO:   "/misc/disk1/",
+		ondatra.JUNIPER: "/var/tmp/",
+		ondatra.NOKIA:   "/tmp/",
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Put a 1 GiB file to the DUT in 64 KiB chunks followed by its SHA256
+//     hash, and measure the throughput.
+//  2. Stat the file and verify its size.
+//  3. Get the file back, verify the received bytes match the size and hash of
+//     the file that was sent and the hash sent by the DUT, and measure the
+//     throughput.
+//  4. Remove the file.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The file content is generated from a fixed seed as it is sent, and the
+//     received content is hashed as it arrives, so the file is never held in
+//     memory.
+//   - The throughput of each transfer is logged and written as a JSON
+//     artifact to the test outputs directory.
+
+// result is the outcome of one transfer, written to the test outputs.
+type result struct {
+	Direction string        `json:"direction"`
+	Bytes     int64         `json:"bytes"`
+	Duration  time.Duration `json:"duration_ns"`
+	MBps      float64       `json:"mbps"`
+}
+
+func newResult(direction string, n int64, d time.Duration) result {
+	return result{
+		Direction: direction,
+		Bytes:     n,
+		Duration:  d,
+		MBps:      float64(n) / d.Seconds() / 1e6,
+	}
+}
+
+// put sends fileSize bytes of generated content to remote and returns the
+// SHA256 hash of the content.
+func put(ctx context.Context, t *testing.T, c fpb.FileClient, remote string) ([]byte, result) {
+	t.Helper()
+	stream, err := c.Put(ctx)
+	if err != nil {
+		t.Fatalf("Put(%q) failed: %v", remote, err)
+	}
+	if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Open{Open: &fpb.PutRequest_Details{
+		RemoteFile:  remote,
+		Permissions: permissions,
+	}}}); err != nil {
+		t.Fatalf("Put(%q) open failed: %v", remote, err)
+	}
+
+	h := sha256.New()
+	r := rand.New(rand.NewSource(seed))
+	buf := make([]byte, chunkSize)
+	start := time.Now()
+	for sent := 0; sent < fileSize; sent += chunkSize {
+		r.Read(buf)
+		h.Write(buf)
+		if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Contents{Contents: buf}}); err != nil {
+			t.Fatalf("Put(%q) failed after %d bytes: %v", remote, sent, err)
+		}
+	}
+	sum := h.Sum(nil)
+	if err := stream.Send(&fpb.PutRequest{Request: &fpb.PutRequest_Hash{Hash: &tpb.HashType{
+		Method: tpb.HashType_SHA256,
+		Hash:   sum,
+	}}}); err != nil {
+		t.Fatalf("Put(%q) hash failed: %v", remote, err)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		t.Fatalf("Put(%q) close failed: %v", remote, err)
+	}
+	return sum, newResult("put", fileSize, time.Since(start))
+}
+
+// get receives remote and verifies its size and hash against wantHash and
+// the hash sent by the DUT.
+func get(ctx context.Context, t *testing.T, c fpb.FileClient, remote string, wantHash []byte) result {
+	t.Helper()
+	start := time.Now()
+	stream, err := c.Get(ctx, &fpb.GetRequest{RemoteFile: remote})
+	if err != nil {
+		t.Fatalf("Get(%q) failed: %v", remote, err)
+	}
+	h := sha256.New()
+	var n int64
+	var dutHash *tpb.HashType
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Get(%q) failed after %d bytes: %v", remote, n, err)
+		}
+		switch r := resp.GetResponse().(type) {
+		case *fpb.GetResponse_Contents:
+			if dutHash != nil {
+				t.Fatalf("Get(%q) sent contents after the hash", remote)
+			}
+			h.Write(r.Contents)
+			n += int64(len(r.Contents))
+		case *fpb.GetResponse_Hash:
+			dutHash = r.Hash
+		}
+	}
+	res := newResult("get", n, time.Since(start))
+
+	if n != fileSize {
+		t.Errorf("Get(%q) size: got %d, want %d", remote, n, fileSize)
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, wantHash) {
+		t.Errorf("Get(%q) content SHA256: got %x, want %x", remote, got, wantHash)
+	}
+	switch {
+	case dutHash == nil:
+		t.Errorf("Get(%q) did not send a hash", remote)
+	case dutHash.GetMethod() == tpb.HashType_SHA256 && !bytes.Equal(dutHash.GetHash(), wantHash):
+		t.Errorf("Get(%q) hash sent by the DUT: got %x, want %x", remote, dutHash.GetHash(), wantHash)
+	default:
+		t.Logf("Get(%q) hash sent by the DUT: %v %x", remote, dutHash.GetMethod(), dutHash.GetHash())
+	}
+	return res
+}
+
+func writeResults(t *testing.T, results []result) {
+	t.Helper()
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		t.Fatalf("Cannot marshal results: %v", err)
+	}
+	if _, err := fptest.WriteOutput("file_transfer_benchmark", ".json", string(b)); err != nil {
+		t.Errorf("Cannot write results: %v", err)
+	}
+}
+
+func TestFileTransferBenchmark(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNOI(t).File()
+	dir, ok := vendorFileDir[dut.Vendor()]
+	if !ok {
+		t.Fatalf("No file directory defined for vendor %s", dut.Vendor())
+	}
+	remote := path.Join(dir, fileName)
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	var results []result
+	defer func() {
+		writeResults(t, results)
+	}()
+
+	var sum []byte
+	t.Run("Put", func(t *testing.T) {
+		var res result
+		sum, res = put(ctx, t, c, remote)
+		results = append(results, res)
+		t.Logf("Put %d bytes in %v: %.2f MB/s", res.Bytes, res.Duration, res.MBps)
+	})
+	if sum == nil {
+		t.Fatalf("File %s was not transferred to the DUT", remote)
+	}
+	defer func() {
+		if _, err := c.Remove(context.Background(), &fpb.RemoveRequest{RemoteFile: remote}); err != nil {
+			t.Errorf("Remove(%q) failed: %v", remote, err)
+		}
+	}()
+
+	t.Run("Stat", func(t *testing.T) {
+		resp, err := c.Stat(ctx, &fpb.StatRequest{Path: remote})
+		if err != nil {
+			t.Fatalf("Stat(%q) failed: %v", remote, err)
+		}
+		if len(resp.GetStats()) != 1 {
+			t.Fatalf("Stat(%q) returned %d entries, want 1: %v", remote, len(resp.GetStats()), resp)
+		}
+		if got := resp.GetStats()[0].GetSize(); got != fileSize {
+			t.Errorf("Stat(%q) size: got %d, want %d", remote, got, fileSize)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		res := get(ctx, t, c, remote, sum)
+		results = append(results, res)
+		t.Logf("Get %d bytes in %v: %.2f MB/s", res.Bytes, res.Duration, res.MBps)
+	})
+}
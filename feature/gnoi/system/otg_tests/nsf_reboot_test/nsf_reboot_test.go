@@ -0,0 +1,396 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsf_reboot_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/gnoigo/system"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnoi"
+	"github.com/openconfig/testt"
+	"github.com/openconfig/ygot/ygot"
+
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+const (
+	isisName     = "DEFAULT"
+	dutAreaAddr  = "49.0001"
+	dutSysID     = "1920.0000.2001"
+	ateAreaAddr  = "490001"
+	ateSysID     = "640000000001"
+	grRestart    = 300
+	grStaleRoute = 600
+
+	bgpRoutes      = "bgp-routes"
+	isisRoutes     = "isis-routes"
+	flowPPS        = 1000
+	controlcard    = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
+	trafficWarmup  = 10 * time.Second
+	sessionTimeout = 5 * time.Minute
+)
+
+// routes are the route ranges advertised by ATE port-2, keyed by name.
+var routes = map[string]struct {
+	address, address6 string
+	prefix, prefix6   uint32
+}{
+	bgpRoutes:  {"198.51.100.0", "2001:db8:100::", 24, 64},
+	isisRoutes: {"203.0.113.0", "2001:db8:113::", 24, 64},
+}
+
+// address returns the first address and the prefix length of the route
+// range name, of the address family of the underlay.
+func address(name string) (string, uint32) {
+	if *args.IPv6Only {
+		return routes[name].address6, routes[name].prefix6
+	}
+	return routes[name].address, routes[name].prefix
+}
+
+func cidr(name string) string {
+	a, p := address(name)
+	return fmt.Sprintf("%s/%d", a, p)
+}
+
+// neighbor returns the address of ATE port-2, which is the BGP neighbor of
+// the DUT.
+func neighbor(bs *cfgplugins.BGPSession) string {
+	if *args.IPv6Only {
+		return bs.ATEPorts[1].IPv6
+	}
+	return bs.ATEPorts[1].IPv4
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish an eBGP session and an IS-IS adjacency with graceful restart
+//     between the DUT and ATE port-2.  ATE port-2 advertises a route range
+//     with each protocol.
+//  2. Start traffic from ATE port-1 to both route ranges.
+//  3. Reboot the active controller card with gNOI System.Reboot method NSF,
+//     or the chassis if the DUT has a single controller card.
+//  4. Wait for the DUT to answer gNMI again, and verify the controller card
+//     rebooted, or the boot-time of the chassis changed.
+//  5. Verify the BGP session and the IS-IS adjacency are up again and both
+//     route ranges are in the DUT AFT.  Log whether the BGP session was kept
+//     by NSR or restarted by graceful restart.
+//  6. Stop traffic, and verify no packet was lost throughout the reboot.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The test is skipped for DUTs with the gnoi_reboot_nsf_unsupported
+//     deviation.
+//   - OpenConfig has no NSR leaf, so the test relies on graceful restart,
+//     and on NSR where the DUT enables it by default.
+//   - With --arg_ipv6_only the interfaces only have IPv6 addresses, and the
+//     BGP session, IS-IS, the route ranges and the flows are IPv6.
+
+// configureISIS adds IS-IS on DUT port-2 and ATE port-2, with the IS-IS
+// route range on ATE port-2.
+func configureISIS(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	afi := oc.IsisTypes_AFI_TYPE_IPV4
+	if *args.IPv6Only {
+		afi = oc.IsisTypes_AFI_TYPE_IPV6
+	}
+	isis := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).GetOrCreateIsis()
+	g := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		g.Instance = ygot.String(isisName)
+	}
+	g.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	g.Net = []string{fmt.Sprintf("%s.%s.00", dutAreaAddr, dutSysID)}
+	g.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	gr := g.GetOrCreateGracefulRestart()
+	gr.Enabled = ygot.Bool(true)
+	gr.RestartTime = ygot.Uint16(grRestart)
+	isis.GetOrCreateLevel(2).MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+
+	intf := isis.GetOrCreateInterface(isisInterface(t, bs))
+	intf.Enabled = ygot.Bool(true)
+	intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+	intf.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.ISISInterfaceAfiUnsupported(dut) {
+		intf.Af = nil
+	}
+	lvl := intf.GetOrCreateLevel(2)
+	lvl.Enabled = ygot.Bool(true)
+	af := lvl.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST)
+	af.Metric = ygot.Uint32(10)
+	af.Enabled = ygot.Bool(true)
+	if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+		af.Enabled = nil
+	}
+
+	dev := bs.ATEIntfs[1]
+	ateISIS := dev.Isis().SetSystemId(ateSysID).SetName(dev.Name() + ".ISIS")
+	ateISIS.Basic().SetHostname(ateISIS.Name()).SetLearnedLspFilter(true)
+	ateISIS.Advanced().SetAreaAddresses([]string{ateAreaAddr})
+	ateIntf := ateISIS.Interfaces().Add().
+		SetEthName(dev.Ethernets().Items()[0].Name()).SetName(dev.Name() + ".ISISIntf").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+		SetMetric(10)
+	ateIntf.Advanced().SetAutoAdjustMtu(true).SetAutoAdjustArea(true).SetAutoAdjustSupportedProtocols(true)
+	a, p := address(isisRoutes)
+	if *args.IPv6Only {
+		ateISIS.V6Routes().Add().SetName(isisRoutes).SetLinkMetric(10).
+			Addresses().Add().SetAddress(a).SetPrefix(p)
+	} else {
+		ateISIS.V4Routes().Add().SetName(isisRoutes).SetLinkMetric(10).
+			Addresses().Add().SetAddress(a).SetPrefix(p)
+	}
+}
+
+func isisInterface(t *testing.T, bs *cfgplugins.BGPSession) string {
+	name := bs.DUT.Port(t, "port2").Name()
+	if deviations.ExplicitInterfaceInDefaultVRF(bs.DUT) {
+		name += ".0"
+	}
+	return name
+}
+
+// atePeerName returns the name of the BGP peer of ATE port-2.
+func atePeerName(bs *cfgplugins.BGPSession) string {
+	if *args.IPv6Only {
+		return bs.ATEIntfs[1].Bgp().Ipv6Interfaces().Items()[0].Peers().Items()[0].Name()
+	}
+	return bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0].Name()
+}
+
+// configureBGP enables graceful restart on the DUT and ATE port-2, and adds
+// the BGP route range to ATE port-2.
+func configureBGP(bs *cfgplugins.BGPSession) {
+	gr := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").GetOrCreateBgp().GetOrCreateGlobal().GetOrCreateGracefulRestart()
+	gr.Enabled = ygot.Bool(true)
+	gr.RestartTime = ygot.Uint16(grRestart)
+	gr.StaleRoutesTime = ygot.Uint16(grStaleRoute)
+
+	a, p := address(bgpRoutes)
+	if *args.IPv6Only {
+		peer := bs.ATEIntfs[1].Bgp().Ipv6Interfaces().Items()[0].Peers().Items()[0]
+		peer.GracefulRestart().SetEnableGr(true).SetRestartTime(grRestart)
+		r := peer.V6Routes().Add().SetName(bgpRoutes)
+		r.SetNextHopIpv6Address(bs.ATEPorts[1].IPv6).
+			SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+			SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+		r.Addresses().Add().SetAddress(a).SetPrefix(p)
+		return
+	}
+	peer := bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+	peer.GracefulRestart().SetEnableGr(true).SetRestartTime(grRestart)
+	r := peer.V4Routes().Add().SetName(bgpRoutes)
+	r.SetNextHopIpv4Address(bs.ATEPorts[1].IPv4).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+	r.Addresses().Add().SetAddress(a).SetPrefix(p)
+}
+
+// configureFlows adds a flow from ATE port-1 to each route range.
+func configureFlows(bs *cfgplugins.BGPSession) {
+	for _, name := range []string{bgpRoutes, isisRoutes} {
+		a, _ := address(name)
+		flow := bs.ATETop.Flows().Add().SetName("to-" + name)
+		flow.Metrics().SetEnable(true)
+		flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+		if *args.IPv6Only {
+			flow.TxRx().Device().
+				SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv6"}).
+				SetRxNames([]string{name})
+			v6 := flow.Packet().Add().Ipv6()
+			v6.Src().SetValue(bs.ATEPorts[0].IPv6)
+			v6.Dst().Increment().SetStart(a).SetCount(100)
+		} else {
+			flow.TxRx().Device().
+				SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+				SetRxNames([]string{name})
+			v4 := flow.Packet().Add().Ipv4()
+			v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+			v4.Dst().Increment().SetStart(a).SetCount(100)
+		}
+		flow.Rate().SetPps(flowPPS)
+	}
+}
+
+// readySignals are the signals of the DUT that the sessions with ATE port-2
+// are up and the route ranges are programmed.
+func readySignals(t *testing.T, bs *cfgplugins.BGPSession) []programming.Signal {
+	ni := deviations.DefaultNetworkInstance(bs.DUT)
+	entry := programming.IPv4Entry
+	if *args.IPv6Only {
+		entry = programming.IPv6Entry
+	}
+	return []programming.Signal{
+		programming.BGPNeighbor(ni, "BGP", neighbor(bs)),
+		programming.ISISAdjacency(ni, isisName, isisInterface(t, bs)),
+		entry(ni, cidr(bgpRoutes)),
+		entry(ni, cidr(isisRoutes)),
+	}
+}
+
+// activeController returns the active controller card of the DUT, or "" if
+// it has fewer than two controller cards.
+func activeController(t *testing.T, dut *ondatra.DUTDevice) string {
+	t.Helper()
+	cards := components.FindComponentsByType(t, dut, controlcard)
+	t.Logf("Found controller cards: %v", cards)
+	if len(cards) < 2 {
+		return ""
+	}
+	_, active := components.FindStandbyRP(t, dut, cards)
+	return active
+}
+
+// awaitRebooted polls the DUT until it answers gNMI and rebooted returns
+// true, or fails the test after the chassis reboot timeout.
+func awaitRebooted(t *testing.T, dut *ondatra.DUTDevice, rebooted func(t testing.TB) bool) {
+	t.Helper()
+	start := time.Now()
+	for {
+		time.Sleep(args.PollInterval())
+		done := false
+		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
+			done = rebooted(t)
+		}); errMsg != nil {
+			t.Logf("DUT not reachable %.0f seconds after the reboot request: %s", time.Since(start).Seconds(), *errMsg)
+		} else if done {
+			t.Logf("DUT control plane restarted in %.0f seconds", time.Since(start).Seconds())
+			return
+		}
+		if got, want := time.Since(start), args.ChassisRebootTimeout(); got >= want {
+			t.Fatalf("DUT control plane did not restart within %v", want)
+		}
+	}
+}
+
+// nsfReboot issues an NSF reboot of the controller card, or of the chassis
+// if controller is empty.  The subcomponent path form accepted by the DUT is
+// detected by components.WithSubcomponentPath.
+func nsfReboot(t *testing.T, dut *ondatra.DUTDevice, controller string) {
+	t.Helper()
+	if controller == "" {
+		t.Log("Rebooting chassis with a single controller card with method NSF")
+		op := system.NewRebootOperation().RebootMethod(spb.RebootMethod_NSF).Message("NSF reboot")
+		t.Logf("Got reboot response: %v", gnoi.Execute(t, dut, op))
+		return
+	}
+	t.Logf("Rebooting active controller card %s with method NSF", controller)
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	err := components.WithSubcomponentPath(dut, controller, func(p *tpb.Path) error {
+		req := &spb.RebootRequest{
+			Method:        spb.RebootMethod_NSF,
+			Message:       "NSF reboot",
+			Subcomponents: []*tpb.Path{p},
+		}
+		resp, err := gnoiClient.System().Reboot(context.Background(), req)
+		t.Logf("Got reboot response: %v, err: %v", resp, err)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to reboot controller card %s: %v", controller, err)
+	}
+}
+
+func TestNSFReboot(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	dut := bs.DUT
+	if deviations.GNOIRebootNSFUnsupported(dut) {
+		t.Skipf("gNOI System.Reboot method NSF is not supported by %v %v", dut.Vendor(), dut.Model())
+	}
+	afiSafi := oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST
+	if *args.IPv6Only {
+		bs.WithIPv6Only(t)
+		afiSafi = oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST
+	}
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{afiSafi}, []string{"port2"}, false, false)
+	configureBGP(bs)
+	configureISIS(t, bs)
+	configureFlows(bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	programming.Await(t, dut, sessionTimeout, readySignals(t, bs)...)
+
+	otg := bs.ATE.OTG()
+	peerFlaps := gnmi.OTG().BgpPeer(atePeerName(bs)).Counters().Flaps().State()
+	flapsBefore := gnmi.Get(t, otg, peerFlaps)
+	controller := activeController(t, dut)
+	bootTime := gnmi.Get(t, dut, gnmi.OC().System().BootTime().State())
+
+	otg.StartTraffic(t)
+	time.Sleep(trafficWarmup)
+
+	start := time.Now()
+	nsfReboot(t, dut, controller)
+
+	if controller != "" {
+		awaitRebooted(t, dut, func(t testing.TB) bool {
+			c := gnmi.Get(t, dut, gnmi.OC().Component(controller).State())
+			return c.GetLastRebootTime() > uint64(start.UnixNano()) || c.GetRedundantRole() != oc.Platform_ComponentRedundantRole_PRIMARY
+		})
+	} else {
+		awaitRebooted(t, dut, func(t testing.TB) bool {
+			return gnmi.Get(t, dut, gnmi.OC().System().BootTime().State()) > bootTime
+		})
+	}
+
+	t.Run("Sessions", func(t *testing.T) {
+		programming.Await(t, dut, sessionTimeout, readySignals(t, bs)...)
+		if flaps := gnmi.Get(t, otg, peerFlaps) - flapsBefore; flaps == 0 {
+			t.Logf("BGP session with %s was kept by NSR", neighbor(bs))
+		} else {
+			t.Logf("BGP session with %s flapped %d times and was re-synced by graceful restart", neighbor(bs), flaps)
+		}
+	})
+
+	time.Sleep(trafficWarmup)
+	otg.StopTraffic(t)
+	t.Run("Traffic", func(t *testing.T) {
+		otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+		for _, f := range bs.ATETop.Flows().Items() {
+			tx, rx := otgutils.GetFlowStats(t, otg, f.Name(), 10*time.Second)
+			if tx == 0 {
+				t.Errorf("Flow %s sent no packets", f.Name())
+			} else if rx != tx {
+				t.Errorf("Flow %s: lost %d of %d packets across the NSF reboot, want 0", f.Name(), tx-rx, tx)
+			}
+		}
+	})
+}
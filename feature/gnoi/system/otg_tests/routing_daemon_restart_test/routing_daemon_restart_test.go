@@ -0,0 +1,447 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing_daemon_restart_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/availability"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	gnps "github.com/openconfig/gnoi/system"
+	"github.com/openconfig/gnoigo/system"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnoi"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	isisName     = "DEFAULT"
+	dutAreaAddr  = "49.0001"
+	dutSysID     = "1920.0000.2001"
+	ateAreaAddr  = "490001"
+	ateSysID     = "640000000001"
+	grRestart    = 120
+	grStaleRoute = 300
+
+	bgpStable      = "bgp-stable"
+	bgpTransient   = "bgp-transient"
+	isisStable     = "isis-stable"
+	isisTransient  = "isis-transient"
+	flowPPS        = 1000
+	lossTol        = 1.0
+	trafficWarmup  = 10 * time.Second
+	restartTimeout = 5 * time.Minute
+	sessionTimeout = 5 * time.Minute
+	// staleTimeout is how long the DUT may keep the routes withdrawn during
+	// the restart, allowing for the stale routes timer to expire.
+	staleTimeout = (grStaleRoute + 60) * time.Second
+)
+
+// routes are the route ranges advertised by ATE port-2, keyed by name.
+var routes = map[string]struct {
+	address, address6 string
+	prefix, prefix6   uint32
+}{
+	bgpStable:     {"198.51.100.0", "2001:db8:100::", 25, 64},
+	bgpTransient:  {"198.51.100.128", "2001:db8:100:1::", 25, 64},
+	isisStable:    {"203.0.113.0", "2001:db8:113::", 25, 64},
+	isisTransient: {"203.0.113.128", "2001:db8:113:1::", 25, 64},
+}
+
+// address returns the first address and the prefix length of the route
+// range name, of the address family of the underlay.
+func address(name string) (string, uint32) {
+	if *args.IPv6Only {
+		return routes[name].address6, routes[name].prefix6
+	}
+	return routes[name].address, routes[name].prefix
+}
+
+func cidr(name string) string {
+	a, p := address(name)
+	return fmt.Sprintf("%s/%d", a, p)
+}
+
+// neighbor returns the address of ATE port-2, which is the BGP neighbor of
+// the DUT.
+func neighbor(bs *cfgplugins.BGPSession) string {
+	if *args.IPv6Only {
+		return bs.ATEPorts[1].IPv6
+	}
+	return bs.ATEPorts[1].IPv4
+}
+
+// daemon is a routing daemon to restart.
+type daemon struct {
+	name string
+	// processes are the names of the daemon process for each vendor.
+	processes map[ondatra.Vendor]string
+	// transient is the route range withdrawn while the daemon restarts.
+	transient    string
+	awaitSession func(t *testing.T, bs *cfgplugins.BGPSession) bool
+}
+
+var daemons = []daemon{{
+	name: "BGP",
+	processes: map[ondatra.Vendor]string{
+		ondatra.ARISTA:  "Bgp-main",
+		ondatra.CISCO:   "bgp",
+		ondatra.JUNIPER: "rpd",
+		ondatra.NOKIA:   "sr_bgp_mgr",
+	},
+	transient:    bgpTransient,
+	awaitSession: awaitBGP,
+}, {
+	name: "ISIS",
+	processes: map[ondatra.Vendor]string{
+		ondatra.ARISTA:  "Isis",
+		ondatra.CISCO:   "isis",
+		ondatra.JUNIPER: "rpd",
+		ondatra.NOKIA:   "sr_isis_mgr",
+	},
+	transient:    isisTransient,
+	awaitSession: awaitISIS,
+}}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Establish an eBGP session and an IS-IS adjacency with graceful restart
+//     between the DUT and ATE port-2.  ATE port-2 advertises a stable and a
+//     transient route range with each protocol.
+//  2. For each of the BGP and IS-IS daemons:
+//     a. Advertise all route ranges, and verify they are in the DUT AFT.
+//     b. Start traffic from ATE port-1 to the stable route ranges of both
+//        protocols.
+//     c. Kill the daemon with gNOI System.KillProcess with restart set, and
+//        withdraw the transient route range of the protocol while it
+//        restarts.
+//     d. Verify the daemon restarts with a new PID, and the BGP session or
+//        IS-IS adjacency recovers.
+//     e. Verify the transient route range is removed from the DUT AFT, and
+//        the stable route ranges of both protocols are still present.
+//     f. Stop traffic, and verify it was forwarded throughout the restart.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - OpenConfig has no NSR leaf, so the test relies on graceful restart,
+//     and on NSR where the DUT enables it by default.
+//   - The daemon is skipped for vendors without a process name in daemons.
+//   - With --arg_ipv6_only the interfaces only have IPv6 addresses, and the
+//     BGP session, IS-IS, the route ranges and the flows are IPv6.
+
+// configureISIS adds IS-IS on DUT port-2 and ATE port-2, with the IS-IS
+// route ranges on ATE port-2.
+func configureISIS(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	afi := oc.IsisTypes_AFI_TYPE_IPV4
+	if *args.IPv6Only {
+		afi = oc.IsisTypes_AFI_TYPE_IPV6
+	}
+	isis := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).GetOrCreateIsis()
+	g := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		g.Instance = ygot.String(isisName)
+	}
+	g.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	g.Net = []string{fmt.Sprintf("%s.%s.00", dutAreaAddr, dutSysID)}
+	g.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	gr := g.GetOrCreateGracefulRestart()
+	gr.Enabled = ygot.Bool(true)
+	gr.RestartTime = ygot.Uint16(grRestart)
+	isis.GetOrCreateLevel(2).MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+
+	intf := isis.GetOrCreateInterface(isisInterface(t, bs))
+	intf.Enabled = ygot.Bool(true)
+	intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+	intf.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.ISISInterfaceAfiUnsupported(dut) {
+		intf.Af = nil
+	}
+	lvl := intf.GetOrCreateLevel(2)
+	lvl.Enabled = ygot.Bool(true)
+	af := lvl.GetOrCreateAf(afi, oc.IsisTypes_SAFI_TYPE_UNICAST)
+	af.Metric = ygot.Uint32(10)
+	af.Enabled = ygot.Bool(true)
+	if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+		af.Enabled = nil
+	}
+
+	dev := bs.ATEIntfs[1]
+	ateISIS := dev.Isis().SetSystemId(ateSysID).SetName(dev.Name() + ".ISIS")
+	ateISIS.Basic().SetHostname(ateISIS.Name()).SetLearnedLspFilter(true)
+	ateISIS.Advanced().SetAreaAddresses([]string{ateAreaAddr})
+	ateIntf := ateISIS.Interfaces().Add().
+		SetEthName(dev.Ethernets().Items()[0].Name()).SetName(dev.Name() + ".ISISIntf").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+		SetMetric(10)
+	ateIntf.Advanced().SetAutoAdjustMtu(true).SetAutoAdjustArea(true).SetAutoAdjustSupportedProtocols(true)
+	for _, name := range []string{isisStable, isisTransient} {
+		a, p := address(name)
+		if *args.IPv6Only {
+			ateISIS.V6Routes().Add().SetName(name).SetLinkMetric(10).
+				Addresses().Add().SetAddress(a).SetPrefix(p)
+		} else {
+			ateISIS.V4Routes().Add().SetName(name).SetLinkMetric(10).
+				Addresses().Add().SetAddress(a).SetPrefix(p)
+		}
+	}
+}
+
+func isisInterface(t *testing.T, bs *cfgplugins.BGPSession) string {
+	name := bs.DUT.Port(t, "port2").Name()
+	if deviations.ExplicitInterfaceInDefaultVRF(bs.DUT) {
+		name += ".0"
+	}
+	return name
+}
+
+// configureBGP enables graceful restart on the DUT and ATE port-2, and adds
+// the BGP route ranges to ATE port-2.
+func configureBGP(bs *cfgplugins.BGPSession) {
+	gr := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").GetOrCreateBgp().GetOrCreateGlobal().GetOrCreateGracefulRestart()
+	gr.Enabled = ygot.Bool(true)
+	gr.RestartTime = ygot.Uint16(grRestart)
+	gr.StaleRoutesTime = ygot.Uint16(grStaleRoute)
+
+	if *args.IPv6Only {
+		peer := bs.ATEIntfs[1].Bgp().Ipv6Interfaces().Items()[0].Peers().Items()[0]
+		peer.GracefulRestart().SetEnableGr(true).SetRestartTime(grRestart)
+		for _, name := range []string{bgpStable, bgpTransient} {
+			a, p := address(name)
+			r := peer.V6Routes().Add().SetName(name)
+			r.SetNextHopIpv6Address(bs.ATEPorts[1].IPv6).
+				SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+				SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+			r.Addresses().Add().SetAddress(a).SetPrefix(p)
+		}
+		return
+	}
+	peer := bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+	peer.GracefulRestart().SetEnableGr(true).SetRestartTime(grRestart)
+	for _, name := range []string{bgpStable, bgpTransient} {
+		a, p := address(name)
+		r := peer.V4Routes().Add().SetName(name)
+		r.SetNextHopIpv4Address(bs.ATEPorts[1].IPv4).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		r.Addresses().Add().SetAddress(a).SetPrefix(p)
+	}
+}
+
+// configureFlows adds a flow from ATE port-1 to each stable route range.
+func configureFlows(bs *cfgplugins.BGPSession) {
+	for _, name := range []string{bgpStable, isisStable} {
+		a, _ := address(name)
+		flow := bs.ATETop.Flows().Add().SetName("to-" + name)
+		flow.Metrics().SetEnable(true)
+		flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+		if *args.IPv6Only {
+			flow.TxRx().Device().
+				SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv6"}).
+				SetRxNames([]string{name})
+			v6 := flow.Packet().Add().Ipv6()
+			v6.Src().SetValue(bs.ATEPorts[0].IPv6)
+			v6.Dst().Increment().SetStart(a).SetCount(100)
+		} else {
+			flow.TxRx().Device().
+				SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+				SetRxNames([]string{name})
+			v4 := flow.Packet().Add().Ipv4()
+			v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+			v4.Dst().Increment().SetStart(a).SetCount(100)
+		}
+		flow.Rate().SetPps(flowPPS)
+	}
+}
+
+func awaitBGP(t *testing.T, bs *cfgplugins.BGPSession) bool {
+	t.Helper()
+	nbr := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(neighbor(bs))
+	_, ok := gnmi.Watch(t, bs.DUT, nbr.SessionState().State(), sessionTimeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		s, present := v.Val()
+		return present && s == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+	}).Await(t)
+	return ok
+}
+
+func awaitISIS(t *testing.T, bs *cfgplugins.BGPSession) bool {
+	t.Helper()
+	intf := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).Isis().Interface(isisInterface(t, bs))
+	_, ok := gnmi.WatchAll(t, bs.DUT, intf.Level(2).AdjacencyAny().AdjacencyState().State(), sessionTimeout, func(v *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+		s, present := v.Val()
+		return present && s == oc.Isis_IsisInterfaceAdjState_UP
+	}).Await(t)
+	return ok
+}
+
+// awaitAFT waits for the AFT entry of the route range name to be present, if
+// present is true, or absent.
+func awaitAFT(t *testing.T, dut *ondatra.DUTDevice, name string, present bool, timeout time.Duration) {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts()
+	var ok bool
+	if *args.IPv6Only {
+		_, ok = gnmi.Watch(t, dut, afts.Ipv6Entry(cidr(name)).State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv6Entry]) bool {
+			return v.IsPresent() == present
+		}).Await(t)
+	} else {
+		_, ok = gnmi.Watch(t, dut, afts.Ipv4Entry(cidr(name)).State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			return v.IsPresent() == present
+		}).Await(t)
+	}
+	switch {
+	case !ok && present:
+		t.Errorf("AFT entry %s for %s is not present after %v", cidr(name), name, timeout)
+	case !ok:
+		t.Errorf("AFT entry %s for %s is still present %v after it was withdrawn", cidr(name), name, timeout)
+	}
+}
+
+// findProcess returns the PID of the process name on the DUT, or 0 if there
+// is no such process.
+func findProcess(t *testing.T, dut *ondatra.DUTDevice, name string) uint64 {
+	t.Helper()
+	for _, p := range gnmi.GetAll(t, dut, gnmi.OC().System().ProcessAny().State()) {
+		if p.GetName() == name {
+			return p.GetPid()
+		}
+	}
+	return 0
+}
+
+// awaitRestart waits for the process name to be running with a PID other
+// than oldPID.
+func awaitRestart(t *testing.T, dut *ondatra.DUTDevice, name string, oldPID uint64) bool {
+	t.Helper()
+	v, ok := gnmi.WatchAll(t, dut, gnmi.OC().System().ProcessAny().State(), restartTimeout, func(v *ygnmi.Value[*oc.System_Process]) bool {
+		p, present := v.Val()
+		return present && p.GetName() == name && p.GetPid() != oldPID
+	}).Await(t)
+	if ok {
+		p, _ := v.Val()
+		t.Logf("Process %s restarted with PID %d", name, p.GetPid())
+	}
+	return ok
+}
+
+func setRouteState(t *testing.T, bs *cfgplugins.BGPSession, state gosnappi.StateProtocolRouteStateEnum, names ...string) {
+	t.Helper()
+	t.Logf("Setting routes %v to %v", names, state)
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames(names).SetState(state)
+	bs.ATE.OTG().SetControlState(t, cs)
+}
+
+func verifyTraffic(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	otg := bs.ATE.OTG()
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+	for _, f := range bs.ATETop.Flows().Items() {
+		if loss := otgutils.GetFlowLossPct(t, otg, f.Name(), 10*time.Second); loss > lossTol {
+			t.Errorf("Flow %s: got %.2f%% loss across the restart, want <= %.2f%%", f.Name(), loss, lossTol)
+		}
+	}
+}
+
+func TestRoutingDaemonRestart(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	afiSafi := oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST
+	if *args.IPv6Only {
+		bs.WithIPv6Only(t)
+		afiSafi = oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST
+	}
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{afiSafi}, []string{"port2"}, false, false)
+	configureBGP(bs)
+	configureISIS(t, bs)
+	configureFlows(bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	if !awaitBGP(t, bs) {
+		t.Fatalf("BGP session with %s is not ESTABLISHED", neighbor(bs))
+	}
+	if !awaitISIS(t, bs) {
+		t.Fatalf("IS-IS adjacency on %s is not UP", isisInterface(t, bs))
+	}
+
+	for _, d := range daemons {
+		t.Run(d.name, func(t *testing.T) {
+			pName, ok := d.processes[bs.DUT.Vendor()]
+			if !ok {
+				t.Skipf("No %s daemon process name for vendor %v", d.name, bs.DUT.Vendor())
+			}
+			setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE, bgpTransient, isisTransient)
+			for name := range routes {
+				awaitAFT(t, bs.DUT, name, true, sessionTimeout)
+			}
+			pid := findProcess(t, bs.DUT, pName)
+			if pid == 0 {
+				t.Fatalf("Couldn't find PID of %s daemon %q", d.name, pName)
+			}
+			t.Logf("PID of %s daemon %q is %d", d.name, pName, pid)
+
+			otg := bs.ATE.OTG()
+			otg.StartTraffic(t)
+			time.Sleep(trafficWarmup)
+
+			m := availability.Start(t, bs.DUT)
+			defer m.Stop(t)
+
+			// TODO - pid type is uint64 in oc-system model, but uint32 in gNOI Kill Request proto.
+			// Until the models are brought in line, typecasting the uint64 to uint32.
+			resp := gnoi.Execute(t, bs.DUT, system.NewKillProcessOperation().Name(pName).PID(uint32(pid)).Signal(gnps.KillProcessRequest_SIGNAL_TERM).Restart(true))
+			t.Logf("Got kill process response: %v", resp)
+			setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW, d.transient)
+
+			if !awaitRestart(t, bs.DUT, pName, pid) {
+				otg.StopTraffic(t)
+				t.Fatalf("%s daemon %q did not restart within %v", d.name, pName, restartTimeout)
+			}
+			if !d.awaitSession(t, bs) {
+				t.Errorf("%s did not recover within %v after the daemon restarted", d.name, sessionTimeout)
+			}
+			awaitAFT(t, bs.DUT, d.transient, false, staleTimeout)
+			for _, name := range []string{bgpStable, isisStable} {
+				awaitAFT(t, bs.DUT, name, true, sessionTimeout)
+			}
+
+			time.Sleep(trafficWarmup)
+			otg.StopTraffic(t)
+			verifyTraffic(t, bs)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	spb "github.com/openconfig/gnoi/system"
 	tpb "github.com/openconfig/gnoi/types"
+	"github.com/openconfig/gnoigo"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi/oc"
 )
@@ -43,12 +44,15 @@ func TestMain(m *testing.M) {
 //   - Check the reboot status after sending reboot request.
 //     - Verify the reboot status is active.
 //     - Verify the reason from reboot status response matches reboot message.
-//     - Verify the wait time from reboot status response matches reboot delay.
+//     - Verify the wait time from reboot status response is set and does not
+//       exceed the reboot delay.
+//     - Verify the when and count fields are set.
 //  2) Cancel gNOI reboot request.
 //   - Cancel reboot request before the test
 //     - Verify that there is no response error returned.
 //   - Send reboot request with delay.
-//     - Verify the reboot status is active.
+//     - Verify the reboot status is active with the when, wait, reason and
+//       count fields set.
 //   - Send reboot cancel request.
 //     - Verify the reboot status is not active.
 //
@@ -87,10 +91,6 @@ func TestRebootStatus(t *testing.T) {
 		},
 	}
 
-	statusReq := &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{}}
-	if !deviations.GNOIStatusWithEmptySubcomponent(dut) {
-		statusReq.Subcomponents = append(statusReq.Subcomponents, getSubCompPath(t, dut))
-	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			if tc.rebootRequest != nil {
@@ -102,7 +102,7 @@ func TestRebootStatus(t *testing.T) {
 					t.Fatalf("Failed to request reboot with unexpected err: %v", err)
 				}
 			}
-			resp, err := gnoiClient.System().RebootStatus(context.Background(), statusReq)
+			resp, err := rebootStatus(t, dut, gnoiClient)
 			t.Logf("DUT rebootStatus: %v, err: %v", resp, err)
 			if err != nil {
 				t.Fatalf("Failed to get reboot status with unexpected err: %v", err)
@@ -112,15 +112,7 @@ func TestRebootStatus(t *testing.T) {
 			}
 
 			if tc.rebootRequest != nil {
-				if resp.GetReason() != tc.rebootRequest.GetMessage() {
-					t.Errorf("resp.GetReason(): got %v, want %v", resp.GetReason(), tc.rebootRequest.GetMessage())
-				}
-				if resp.GetWait() > tc.rebootRequest.GetDelay() {
-					t.Errorf("resp.GetWait(): got %v, want <= %v", resp.GetWait(), tc.rebootRequest.GetDelay())
-				}
-				if resp.GetWhen() == 0 {
-					t.Errorf("resp.GetWhen(): got %v, want > 0", resp.GetWhen())
-				}
+				verifyActiveRebootStatus(t, resp, tc.rebootRequest)
 			}
 		})
 
@@ -159,18 +151,12 @@ func TestCancelReboot(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to request reboot with unexpected err: %v", err)
 	}
-	statusReq := &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{}}
-	if !deviations.GNOIStatusWithEmptySubcomponent(dut) {
-		statusReq.Subcomponents = append(statusReq.Subcomponents, getSubCompPath(t, dut))
-	}
-	rebootStatus, err := gnoiClient.System().RebootStatus(context.Background(), statusReq)
-	t.Logf("DUT rebootStatus: %v, err: %v", rebootStatus, err)
+	status, err := rebootStatus(t, dut, gnoiClient)
+	t.Logf("DUT rebootStatus: %v, err: %v", status, err)
 	if err != nil {
 		t.Fatalf("Failed to get reboot status with unexpected err: %v", err)
 	}
-	if !rebootStatus.GetActive() {
-		t.Errorf("rebootStatus.GetActive(): got %v, want true", rebootStatus.GetActive())
-	}
+	verifyActiveRebootStatus(t, status, rebootRequest)
 
 	t.Logf("Cancel reboot request: %v", rebootRequest)
 	rebootCancel, err = gnoiClient.System().CancelReboot(context.Background(), &spb.CancelRebootRequest{})
@@ -179,17 +165,59 @@ func TestCancelReboot(t *testing.T) {
 		t.Fatalf("Failed to cancel reboot with unexpected err: %v", err)
 	}
 
-	rebootStatus, err = gnoiClient.System().RebootStatus(context.Background(), statusReq)
-	t.Logf("DUT rebootStatus: %v, err: %v", rebootStatus, err)
+	status, err = rebootStatus(t, dut, gnoiClient)
+	t.Logf("DUT rebootStatus: %v, err: %v", status, err)
 	if err != nil {
 		t.Fatalf("Failed to get reboot status with unexpected err: %v", err)
 	}
-	if rebootStatus.GetActive() {
-		t.Errorf("rebootStatus.GetActive(): got %v, want false", rebootStatus.GetActive())
+	if status.GetActive() {
+		t.Errorf("status.GetActive(): got %v, want false", status.GetActive())
+	}
+}
+
+// verifyActiveRebootStatus checks that resp reports the pending reboot
+// requested by req, with every field the gNOI spec defines for an active
+// reboot populated.
+func verifyActiveRebootStatus(t *testing.T, resp *spb.RebootStatusResponse, req *spb.RebootRequest) {
+	t.Helper()
+	if !resp.GetActive() {
+		t.Errorf("resp.GetActive(): got %v, want true", resp.GetActive())
+	}
+	if resp.GetReason() != req.GetMessage() {
+		t.Errorf("resp.GetReason(): got %q, want %q", resp.GetReason(), req.GetMessage())
+	}
+	if resp.GetWhen() == 0 {
+		t.Errorf("resp.GetWhen(): got %v, want > 0", resp.GetWhen())
+	}
+	if resp.GetCount() == 0 {
+		t.Errorf("resp.GetCount(): got %v, want > 0", resp.GetCount())
+	}
+	if req.GetDelay() > 0 {
+		if resp.GetWait() == 0 || resp.GetWait() > req.GetDelay() {
+			t.Errorf("resp.GetWait(): got %v, want > 0 and <= %v", resp.GetWait(), req.GetDelay())
+		}
+	}
+}
+
+// rebootStatus requests the reboot status of the active controller card, or
+// with no subcomponents if the DUT requires that.  The subcomponent path form
+// accepted by the DUT is detected by components.WithSubcomponentPath.
+func rebootStatus(t *testing.T, dut *ondatra.DUTDevice, gnoiClient gnoigo.Clients) (*spb.RebootStatusResponse, error) {
+	t.Helper()
+	if deviations.GNOIStatusWithEmptySubcomponent(dut) {
+		return gnoiClient.System().RebootStatus(context.Background(), &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{}})
 	}
+	var resp *spb.RebootStatusResponse
+	err := components.WithSubcomponentPath(dut, activeRP(t, dut), func(p *tpb.Path) error {
+		var err error
+		resp, err = gnoiClient.System().RebootStatus(context.Background(), &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{p}})
+		return err
+	})
+	return resp, err
 }
 
-func getSubCompPath(t *testing.T, dut *ondatra.DUTDevice) *tpb.Path {
+// activeRP returns the name of the active controller card.
+func activeRP(t *testing.T, dut *ondatra.DUTDevice) string {
 	t.Helper()
 	controllerCards := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD)
 	if len(controllerCards) == 0 {
@@ -199,6 +227,5 @@ func getSubCompPath(t *testing.T, dut *ondatra.DUTDevice) *tpb.Path {
 	if len(controllerCards) == 2 {
 		_, activeRP = components.FindStandbyRP(t, dut, controllerCards)
 	}
-	useNameOnly := deviations.GNOISubcomponentPath(dut)
-	return components.GetSubcomponentPath(activeRP, useNameOnly)
+	return activeRP
 }
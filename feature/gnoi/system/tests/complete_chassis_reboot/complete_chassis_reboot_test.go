@@ -16,11 +16,16 @@ package complete_chassis_reboot_test
 
 import (
 	"context"
+	"flag"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/availability"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	spb "github.com/openconfig/gnoi/system"
 	"github.com/openconfig/ondatra"
@@ -33,12 +38,12 @@ const (
 	oneMinuteInNanoSecond = 6e10
 	oneSecondInNanoSecond = 1e9
 	rebootDelay           = 120
-	// Maximum reboot time is 900 seconds (15 minutes).
-	maxRebootTime = 900
 	// Maximum wait time for all components to be in responsive state
 	maxCompWaitTime = 600
 )
 
+var powerCycled = flag.Bool("power_cycled", false, "Set when the DUT was power cycled, e.g. with the testbed PDU, before the test run. Enables the verification of last-reboot-reason after a power cycle.")
+
 func TestMain(m *testing.M) {
 	fptest.RunTests(m)
 }
@@ -52,6 +57,7 @@ func TestMain(m *testing.M) {
 //   - Verify the following items.
 //     - DUT remains reachable for N seconds by checking DUT current time is updated.
 //     - DUT boot time is updated after reboot.
+//     - DUT last-reboot-reason is REBOOT_USER_INITIATED after the reboot.
 //     - DUT software version is the same after the reboot.
 //  2) Send gNOI reboot request using the method COLD without delay.
 //     - method: Only the COLD method is required to be supported by all targets.
@@ -60,7 +66,10 @@ func TestMain(m *testing.M) {
 //     - force: Force reboot if basic checks fail. (ex. uncommitted configuration).
 //   - Verify the following items.
 //     - DUT boot time is updated after reboot.
+//     - DUT last-reboot-reason is REBOOT_USER_INITIATED after the reboot.
 //     - DUT software version is the same after the reboot.
+//  3) When --power_cycled is set, verify the last-reboot-reason is not
+//     REBOOT_USER_INITIATED before any gNOI reboot.
 //
 // Topology:
 //   dut:port1 <--> ate:port1
@@ -73,10 +82,48 @@ func TestMain(m *testing.M) {
 //    requested.  Only the COLD method is required to be supported by all
 //    targets.  Methods the target does not support should result in failure.
 //
+//  - last-reboot-reason is read from the chassis and the controller cards,
+//    since platforms report it on either.  Platforms that report it on
+//    neither set the component_last_reboot_reason_unsupported deviation.
+//
 //  - gnoi operation commands can be sent and tested using CLI command grpcurl.
 //    https://github.com/fullstorydev/grpcurl
 //
 
+// lastRebootReasons returns the last-reboot-reason of the chassis and
+// controller cards that report one.
+func lastRebootReasons(t *testing.T, dut *ondatra.DUTDevice) map[string]oc.E_PlatformTypes_COMPONENT_REBOOT_REASON {
+	t.Helper()
+	names := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CHASSIS)
+	names = append(names, components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD)...)
+	reasons := make(map[string]oc.E_PlatformTypes_COMPONENT_REBOOT_REASON)
+	for _, c := range names {
+		if r, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(c).LastRebootReason().State()).Val(); ok {
+			t.Logf("Component %s last-reboot-reason: %v", c, r)
+			reasons[c] = r
+		}
+	}
+	if len(reasons) == 0 {
+		t.Errorf("No chassis or controller card in %v reports last-reboot-reason", names)
+	}
+	return reasons
+}
+
+func TestLastRebootReasonAfterPowerCycle(t *testing.T) {
+	if !*powerCycled {
+		t.Skip("The DUT was not power cycled before the test run, set --power_cycled after power cycling it")
+	}
+	dut := ondatra.DUT(t, "dut")
+	if deviations.ComponentLastRebootReasonUnsupported(dut) {
+		t.Skip("last-reboot-reason is not supported by the DUT")
+	}
+	for c, r := range lastRebootReasons(t, dut) {
+		if r == oc.PlatformTypes_COMPONENT_REBOOT_REASON_REBOOT_USER_INITIATED {
+			t.Errorf("Component %s last-reboot-reason after power cycle: got %v, want other than %v", c, r, oc.PlatformTypes_COMPONENT_REBOOT_REASON_REBOOT_USER_INITIATED)
+		}
+	}
+}
+
 func TestChassisReboot(t *testing.T) {
 	dut := ondatra.DUT(t, "dut")
 
@@ -130,6 +177,8 @@ func TestChassisReboot(t *testing.T) {
 				t.Fatalf("Failed parsing current-datetime: %s", err)
 			}
 			start := time.Now()
+			m := availability.Start(t, dut)
+			defer m.Stop(t)
 
 			t.Logf("Send reboot request: %v", tc.rebootRequest)
 			rebootResponse, err := gnoiClient.System().Reboot(context.Background(), tc.rebootRequest)
@@ -164,7 +213,7 @@ func TestChassisReboot(t *testing.T) {
 			for {
 				var currentTime string
 				t.Logf("Time elapsed %.2f seconds since reboot started.", time.Since(startReboot).Seconds())
-				time.Sleep(30 * time.Second)
+				time.Sleep(args.PollInterval())
 				if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
 					currentTime = gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
 				}); errMsg != nil {
@@ -174,8 +223,8 @@ func TestChassisReboot(t *testing.T) {
 					break
 				}
 
-				if uint64(time.Since(startReboot).Seconds()) > maxRebootTime {
-					t.Errorf("Check boot time: got %v, want < %v", time.Since(startReboot), maxRebootTime)
+				if got, want := time.Since(startReboot), args.ChassisRebootTimeout(); got >= want {
+					t.Errorf("Check boot time: got %v, want < %v", got, want)
 				}
 			}
 			t.Logf("Device boot time: %.2f seconds", time.Since(startReboot).Seconds())
@@ -186,6 +235,16 @@ func TestChassisReboot(t *testing.T) {
 				t.Errorf("Get boot time: got %v, want > %v", bootTimeAfterReboot, bootTimeBeforeReboot)
 			}
 
+			if deviations.ComponentLastRebootReasonUnsupported(dut) {
+				fptest.Waive(t, "component_last_reboot_reason_unsupported", "not checking last-reboot-reason of components after gNOI Reboot")
+			} else {
+				for c, r := range lastRebootReasons(t, dut) {
+					if r != oc.PlatformTypes_COMPONENT_REBOOT_REASON_REBOOT_USER_INITIATED {
+						t.Errorf("Component %s last-reboot-reason after gNOI Reboot: got %v, want %v", c, r, oc.PlatformTypes_COMPONENT_REBOOT_REASON_REBOOT_USER_INITIATED)
+					}
+				}
+			}
+
 			startComp := time.Now()
 			t.Logf("Wait for all the components on DUT to come up")
 
@@ -16,14 +16,21 @@ package per_component_reboot_test
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/featureprofiles/internal/args"
 	"github.com/openconfig/featureprofiles/internal/components"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/metrics"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/gnoigo"
 	"github.com/openconfig/ondatra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -33,6 +40,8 @@ import (
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
 	"github.com/openconfig/ygnmi/ygnmi"
+
+	hpb "github.com/openconfig/gnoi/healthz"
 )
 
 const (
@@ -41,6 +50,15 @@ const (
 	fabricType        = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_FABRIC
 	activeController  = oc.Platform_ComponentRedundantRole_PRIMARY
 	standbyController = oc.Platform_ComponentRedundantRole_SECONDARY
+
+	// rebootStatusPollInterval is the interval between RebootStatus requests.
+	rebootStatusPollInterval = 10 * time.Second
+)
+
+var (
+	healthzRemediation  = flag.Bool("healthz_remediation", false, "Set when the DUT restarts a component on which gNOI Healthz Check is invoked. Enables the comparison of linecard recovery after a Healthz Check and after a gNOI Reboot.")
+	maxStatusComponents = flag.Int("max_reboot_status_components", 16, "Maximum number of linecards and fabrics rebooted together to verify RebootStatus per subcomponent.")
+	cancelRebootDelay   = flag.Duration("cancel_reboot_delay", 2*time.Minute, "Delay of the subcomponent reboot that is cancelled with CancelReboot.")
 )
 
 func TestMain(m *testing.M) {
@@ -50,13 +68,30 @@ func TestMain(m *testing.M) {
 // Test cases:
 //  1) Issue gnoi.system Reboot to chassis with
 //     - Delay: Not set.
-//     - message: Not set.
+//     - message: Set to identify the rebooted subcomponent.
 //     - method: Only the COLD method is required to be supported by all targets.
 //     - subcomponents: Standby RP/supervisor or linecard name.
 //  2) Set the subcomponent to a standby RP (supervisor).
 //     - Verify that the standby RP has rebooted and the uptime has been reset.
 //  3) Set the subcomponent to a a field-removable linecard in the system.
 //     - Verify that the line card has rebooted and the uptime has been reset.
+//  4) While a linecard or fabric reboot is active, verify that RebootStatus
+//     reports the when, reason and count fields and no remaining wait time.
+//  5) On DUTs that restart a component on Healthz Check, restart a linecard
+//     with gnoi.healthz Check and with gnoi.system Reboot.
+//     - Verify both leave the linecard and the interfaces in the same state,
+//       and record the recovery time of each.
+//  6) Reboot up to 16 removable linecards and fabrics together, and poll
+//     RebootStatus for each of them in parallel.
+//     - Verify the status of each subcomponent reports its own reboot only,
+//       and that each subcomponent becomes active again.
+//  7) Send CancelReboot for a removable linecard or fabric with no pending
+//     reboot, and verify it succeeds and RebootStatus is not active.
+//  8) Reboot the same subcomponent with a delay, verify RebootStatus reports
+//     the pending reboot, and send CancelReboot for it.
+//     - Verify RebootStatus is no longer active, and that the subcomponent
+//       stays ACTIVE without a new last-reboot-time until well after the
+//       delay.
 //
 // Topology:
 //   DUT
@@ -77,10 +112,85 @@ func TestMain(m *testing.M) {
 //
 //  - TODO: Check the uptime has been reset after the reboot.
 //
+//  - The gNOI spec defines no error for a CancelReboot with no pending
+//    reboot, as it cancels "any pending reboot request".  Like gNOI-3.4 for
+//    the chassis, the test expects it to succeed without changing the state
+//    of the DUT.
+//
 //  - gnoi operation commands can be sent and tested using CLI command grpcurl.
 //    https://github.com/fullstorydev/grpcurl
 //
 
+// rebootSubcomponent issues a cold reboot of the named subcomponent after
+// delay, or immediately if delay is 0, and returns the accepted request.  The
+// subcomponent path form accepted by the DUT is detected by
+// components.WithSubcomponentPath.
+func rebootSubcomponent(t *testing.T, gnoiClient gnoigo.Clients, dut *ondatra.DUTDevice, name string, delay time.Duration) (*spb.RebootRequest, error) {
+	t.Helper()
+	var req *spb.RebootRequest
+	err := components.WithSubcomponentPath(dut, name, func(p *tpb.Path) error {
+		req = &spb.RebootRequest{
+			Method:        spb.RebootMethod_COLD,
+			Delay:         uint64(delay.Nanoseconds()),
+			Message:       fmt.Sprintf("Reboot %s", name),
+			Subcomponents: []*tpb.Path{p},
+		}
+		t.Logf("rebootSubComponentRequest: %v", req)
+		rebootResponse, err := gnoiClient.System().Reboot(context.Background(), req)
+		t.Logf("gnoiClient.System().Reboot() response: %v, err: %v", rebootResponse, err)
+		return err
+	})
+	return req, err
+}
+
+// verifyActiveRebootStatus checks that resp reports the subcomponent reboot
+// requested by req with the fields the gNOI spec defines for an active reboot
+// populated.
+func verifyActiveRebootStatus(t *testing.T, resp *spb.RebootStatusResponse, req *spb.RebootRequest) {
+	t.Helper()
+	if resp.GetReason() != req.GetMessage() {
+		t.Errorf("resp.GetReason(): got %q, want %q", resp.GetReason(), req.GetMessage())
+	}
+	if resp.GetWhen() == 0 {
+		t.Errorf("resp.GetWhen(): got %v, want > 0", resp.GetWhen())
+	}
+	if resp.GetCount() == 0 {
+		t.Errorf("resp.GetCount(): got %v, want > 0", resp.GetCount())
+	}
+	switch {
+	case req.GetDelay() == 0 && resp.GetWait() != 0:
+		t.Errorf("resp.GetWait(): got %v, want 0 for a reboot without delay", resp.GetWait())
+	case req.GetDelay() > 0 && (resp.GetWait() == 0 || resp.GetWait() > req.GetDelay()):
+		t.Errorf("resp.GetWait(): got %v, want > 0 and <= %v", resp.GetWait(), req.GetDelay())
+	}
+}
+
+// pollRebootStatus polls RebootStatus with req every
+// rebootStatusPollInterval until the reboot is no longer active or timeout
+// has passed, and calls onActive with each response of an active reboot.
+// Errors other than Unimplemented are retried, as the DUT may not answer
+// while a subcomponent restarts.  pollRebootStatus does not use the test, so
+// that it can be called from several goroutines.
+func pollRebootStatus(gnoiClient gnoigo.Clients, req *spb.RebootStatusRequest, timeout time.Duration, onActive func(*spb.RebootStatusResponse)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		time.Sleep(rebootStatusPollInterval)
+		if time.Now().After(deadline) {
+			return nil
+		}
+		resp, err := gnoiClient.System().RebootStatus(context.Background(), req)
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			return fmt.Errorf("unimplemented RebootStatus() is not fully compliant with the Reboot spec: %v", err)
+		case err != nil:
+			continue
+		case !resp.GetActive():
+			return nil
+		}
+		onActive(resp)
+	}
+}
+
 func TestStandbyControllerCardReboot(t *testing.T) {
 	dut := ondatra.DUT(t, "dut")
 
@@ -99,40 +209,22 @@ func TestStandbyControllerCardReboot(t *testing.T) {
 	t.Logf("Detected rpStandby: %v, rpActive: %v", rpStandby, rpActive)
 
 	gnoiClient := dut.RawAPIs().GNOI(t)
-	useNameOnly := deviations.GNOISubcomponentPath(dut)
-	rebootSubComponentRequest := &spb.RebootRequest{
-		Method: spb.RebootMethod_COLD,
-		Subcomponents: []*tpb.Path{
-			components.GetSubcomponentPath(rpStandby, useNameOnly),
-		},
-	}
-
-	t.Logf("rebootSubComponentRequest: %v", rebootSubComponentRequest)
 	startReboot := time.Now()
-	rebootResponse, err := gnoiClient.System().Reboot(context.Background(), rebootSubComponentRequest)
-	if err != nil {
+	if _, err := rebootSubcomponent(t, gnoiClient, dut, rpStandby, 0); err != nil {
 		t.Fatalf("Failed to perform component reboot with unexpected err: %v", err)
 	}
-	t.Logf("gnoiClient.System().Reboot() response: %v, err: %v", rebootResponse, err)
 
-	t.Logf("Wait for a minute to allow the sub component's reboot process to start")
-	time.Sleep(1 * time.Minute)
-
-	watch := gnmi.Watch(t, dut, gnmi.OC().Component(rpStandby).RedundantRole().State(), 10*time.Minute, func(val *ygnmi.Value[oc.E_Platform_ComponentRedundantRole]) bool {
-		return val.IsPresent()
-	})
-	if val, ok := watch.Await(t); !ok {
-		t.Fatalf("DUT did not reach target state within %v: got %v", 10*time.Minute, val)
-	}
+	programming.Await(t, dut, 10*time.Minute, programming.ControllerReady(rpStandby, startReboot))
 	t.Logf("Standby controller boot time: %.2f seconds", time.Since(startReboot).Seconds())
+	metrics.RecordDuration(t, "standby_controller_boot", time.Since(startReboot))
 
 	// TODO: Check the standby RP uptime has been reset.
 }
 
-func TestLinecardReboot(t *testing.T) {
-	const linecardBoottime = 10 * time.Minute
-	dut := ondatra.DUT(t, "dut")
-
+// findRemovableLinecard returns a removable linecard of the DUT, or "" if
+// there is none.
+func findRemovableLinecard(t *testing.T, dut *ondatra.DUTDevice) string {
+	t.Helper()
 	lcs := components.FindComponentsByType(t, dut, linecardType)
 	t.Logf("Found linecard list: %v", lcs)
 
@@ -168,6 +260,14 @@ func TestLinecardReboot(t *testing.T) {
 			removableLinecard = lc
 		}
 	}
+	return removableLinecard
+}
+
+func TestLinecardReboot(t *testing.T) {
+	linecardBoottime := args.LinecardBootTimeout()
+	dut := ondatra.DUT(t, "dut")
+
+	removableLinecard := findRemovableLinecard(t, dut)
 	if removableLinecard == "" {
 		if *args.NumLinecards > 0 {
 			t.Fatalf("No removable line card found for the testing on a modular device")
@@ -177,22 +277,12 @@ func TestLinecardReboot(t *testing.T) {
 	}
 
 	gnoiClient := dut.RawAPIs().GNOI(t)
-	useNameOnly := deviations.GNOISubcomponentPath(dut)
-	rebootSubComponentRequest := &spb.RebootRequest{
-		Method: spb.RebootMethod_COLD,
-		Subcomponents: []*tpb.Path{
-			components.GetSubcomponentPath(removableLinecard, useNameOnly),
-		},
-	}
-
 	intfsOperStatusUPBeforeReboot := helpers.FetchOperStatusUPIntfs(t, dut, *args.CheckInterfacesInBinding)
 	t.Logf("OperStatusUP interfaces before reboot: %v", intfsOperStatusUPBeforeReboot)
-	t.Logf("rebootSubComponentRequest: %v", rebootSubComponentRequest)
-	rebootResponse, err := gnoiClient.System().Reboot(context.Background(), rebootSubComponentRequest)
+	rebootSubComponentRequest, err := rebootSubcomponent(t, gnoiClient, dut, removableLinecard, 0)
 	if err != nil {
 		t.Fatalf("Failed to perform line card reboot with unexpected err: %v", err)
 	}
-	t.Logf("gnoiClient.System().Reboot() response: %v, err: %v", rebootResponse, err)
 
 	t.Logf("Wait for 10s to allow the sub component's reboot process to start")
 	time.Sleep(10 * time.Second)
@@ -204,23 +294,14 @@ func TestLinecardReboot(t *testing.T) {
 	if deviations.GNOISubcomponentRebootStatusUnsupported(dut) {
 		req.Subcomponents = nil
 	}
-	rebootDeadline := time.Now().Add(linecardBoottime)
-	for retry := true; retry; {
-		t.Log("Waiting for 10 seconds before checking.")
-		time.Sleep(10 * time.Second)
-		if time.Now().After(rebootDeadline) {
-			retry = false
-			break
-		}
-		resp, err := gnoiClient.System().RebootStatus(context.Background(), req)
-		switch {
-		case status.Code(err) == codes.Unimplemented:
-			t.Fatalf("Unimplemented RebootStatus() is not fully compliant with the Reboot spec.")
-		case err == nil:
-			retry = resp.GetActive()
-		default:
-			// any other error just sleep.
+	var statusVerified bool
+	if err := pollRebootStatus(gnoiClient, req, linecardBoottime, func(resp *spb.RebootStatusResponse) {
+		if !statusVerified {
+			verifyActiveRebootStatus(t, resp, rebootSubComponentRequest)
+			statusVerified = true
 		}
+	}); err != nil {
+		t.Fatal(err)
 	}
 
 	t.Logf("Validate removable linecard %v status", removableLinecard)
@@ -230,6 +311,88 @@ func TestLinecardReboot(t *testing.T) {
 	// TODO: Check the line card uptime has been reset.
 }
 
+// recoveryState is the state of a restarted component, and of the DUT
+// interfaces, once the component has recovered.
+type recoveryState struct {
+	operStatus      oc.E_PlatformTypes_COMPONENT_OPER_STATUS
+	softwareVersion string
+	upIntfs         []string
+}
+
+// restartAndRecover restarts component name with restart and waits for it to
+// go down and become active again, and for upIntfs to be up.  It returns the
+// time from the restart request until the interfaces are up, and the state
+// after recovery.
+func restartAndRecover(t *testing.T, dut *ondatra.DUTDevice, name string, upIntfs []string, restart func() error) (time.Duration, *recoveryState) {
+	t.Helper()
+	timeout := args.LinecardBootTimeout()
+	operStatus := gnmi.OC().Component(name).OperStatus().State()
+	start := time.Now()
+	if err := restart(); err != nil {
+		t.Fatalf("Failed to restart %s: %v", name, err)
+	}
+	_, ok := gnmi.Watch(t, dut, operStatus, timeout, func(val *ygnmi.Value[oc.E_PlatformTypes_COMPONENT_OPER_STATUS]) bool {
+		v, present := val.Val()
+		return !present || v != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE
+	}).Await(t)
+	if !ok {
+		t.Fatalf("%s did not go down within %v of the restart request", name, timeout)
+	}
+	t.Logf("%s went down after %.2f seconds", name, time.Since(start).Seconds())
+	gnmi.Await(t, dut, operStatus, timeout, oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE)
+	helpers.ValidateOperStatusUPIntfs(t, dut, upIntfs, 10*time.Minute)
+	d := time.Since(start)
+
+	version, _ := gnmi.Lookup(t, dut, gnmi.OC().Component(name).SoftwareVersion().State()).Val()
+	return d, &recoveryState{
+		operStatus:      gnmi.Get(t, dut, operStatus),
+		softwareVersion: version,
+		upIntfs:         helpers.FetchOperStatusUPIntfs(t, dut, *args.CheckInterfacesInBinding),
+	}
+}
+
+func TestHealthzRemediationLinecardRestart(t *testing.T) {
+	if !*healthzRemediation {
+		t.Skip("Healthz remediation is not enabled with --healthz_remediation")
+	}
+	dut := ondatra.DUT(t, "dut")
+	lc := findRemovableLinecard(t, dut)
+	if lc == "" {
+		t.Skipf("No removable line card found for the testing")
+	}
+
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	upIntfs := helpers.FetchOperStatusUPIntfs(t, dut, *args.CheckInterfacesInBinding)
+	t.Logf("OperStatusUP interfaces before restart: %v", upIntfs)
+
+	rebootTime, rebootState := restartAndRecover(t, dut, lc, upIntfs, func() error {
+		_, err := rebootSubcomponent(t, gnoiClient, dut, lc, 0)
+		return err
+	})
+	t.Logf("%s recovery time after gNOI Reboot: %.2f seconds", lc, rebootTime.Seconds())
+	metrics.RecordDuration(t, "linecard_recovery", rebootTime, "restart", "reboot")
+
+	healthzTime, healthzState := restartAndRecover(t, dut, lc, upIntfs, func() error {
+		return components.WithSubcomponentPath(dut, lc, func(p *tpb.Path) error {
+			resp, err := gnoiClient.Healthz().Check(context.Background(), &hpb.CheckRequest{Path: p})
+			t.Logf("gnoiClient.Healthz().Check() response: %v, err: %v", resp, err)
+			return err
+		})
+	})
+	t.Logf("%s recovery time after Healthz Check: %.2f seconds", lc, healthzTime.Seconds())
+	metrics.RecordDuration(t, "linecard_recovery", healthzTime, "restart", "healthz")
+
+	if healthzState.operStatus != rebootState.operStatus {
+		t.Errorf("%s oper-status after Healthz Check: got %v, want %v as after gNOI Reboot", lc, healthzState.operStatus, rebootState.operStatus)
+	}
+	if healthzState.softwareVersion != rebootState.softwareVersion {
+		t.Errorf("%s software-version after Healthz Check: got %s, want %s as after gNOI Reboot", lc, healthzState.softwareVersion, rebootState.softwareVersion)
+	}
+	if diff := cmp.Diff(rebootState.upIntfs, healthzState.upIntfs); diff != "" {
+		t.Errorf("OperStatusUP interfaces after Healthz Check differ from after gNOI Reboot (-reboot,+healthz):\n%s", diff)
+	}
+}
+
 // Reboot the fabric component on the DUT.
 func TestFabricReboot(t *testing.T) {
 	dut := ondatra.DUT(t, "dut")
@@ -237,7 +400,7 @@ func TestFabricReboot(t *testing.T) {
 		t.Skipf("Skipping test due to deviation deviation_gnoi_fabric_component_reboot_unsupported")
 	}
 
-	const fabricBootTime = 10 * time.Minute
+	fabricBootTime := args.FabricBootTimeout()
 	fabrics := components.FindComponentsByType(t, dut, fabricType)
 	t.Logf("Found fabric components: %v", fabrics)
 
@@ -270,20 +433,10 @@ func TestFabricReboot(t *testing.T) {
 
 	// Fetch a new gnoi client.
 	gnoiClient := dut.RawAPIs().GNOI(t)
-	useNameOnly := deviations.GNOISubcomponentPath(dut)
-	rebootSubComponentRequest := &spb.RebootRequest{
-		Method: spb.RebootMethod_COLD,
-		Subcomponents: []*tpb.Path{
-			components.GetSubcomponentPath(removableFabric, useNameOnly),
-		},
-	}
-
-	t.Logf("rebootSubComponentRequest: %v", rebootSubComponentRequest)
-	rebootResponse, err := gnoiClient.System().Reboot(context.Background(), rebootSubComponentRequest)
+	rebootSubComponentRequest, err := rebootSubcomponent(t, gnoiClient, dut, removableFabric, 0)
 	if err != nil {
 		t.Fatalf("Failed to perform fabric component reboot with unexpected err: %v", err)
 	}
-	t.Logf("gnoiClient.System().Reboot() response: %v, err: %v", rebootResponse, err)
 
 	req := &spb.RebootStatusRequest{
 		Subcomponents: rebootSubComponentRequest.GetSubcomponents(),
@@ -292,20 +445,14 @@ func TestFabricReboot(t *testing.T) {
 	if deviations.GNOISubcomponentRebootStatusUnsupported(dut) {
 		req.Subcomponents = nil
 	}
-	rebootDeadline := time.Now().Add(fabricBootTime)
-	for {
-		t.Log("Waiting for 10 seconds before checking.")
-		time.Sleep(10 * time.Second)
-		if time.Now().After(rebootDeadline) {
-			break
-		}
-		resp, err := gnoiClient.System().RebootStatus(context.Background(), req)
-		if status.Code(err) == codes.Unimplemented {
-			t.Fatalf("Unimplemented RebootStatus() is not fully compliant with the Reboot spec.")
-		}
-		if !resp.GetActive() {
-			break
+	var statusVerified bool
+	if err := pollRebootStatus(gnoiClient, req, fabricBootTime, func(resp *spb.RebootStatusResponse) {
+		if !statusVerified {
+			verifyActiveRebootStatus(t, resp, rebootSubComponentRequest)
+			statusVerified = true
 		}
+	}); err != nil {
+		t.Fatal(err)
 	}
 
 	// Wait for the fabric component to come back up.
@@ -315,3 +462,212 @@ func TestFabricReboot(t *testing.T) {
 	helpers.ValidateOperStatusUPIntfs(t, dut, intfsOperStatusUPBeforeReboot, 5*time.Minute)
 	// TODO: Check the fabric component uptime has been reset.
 }
+
+// findRemovableComponents returns the removable, non-empty components of the
+// given types, up to limit components.
+func findRemovableComponents(t *testing.T, dut *ondatra.DUTDevice, limit int, types ...oc.E_PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT) []string {
+	t.Helper()
+	var names []string
+	for _, typ := range types {
+		for _, name := range components.FindComponentsByType(t, dut, typ) {
+			if len(names) == limit {
+				return names
+			}
+			if empty, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(name).Empty().State()).Val(); ok && empty {
+				continue
+			}
+			if removable, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(name).Removable().State()).Val(); ok && removable {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// subcomponentStatus is the result of polling RebootStatus for the reboot
+// of one subcomponent.
+type subcomponentStatus struct {
+	name string
+	req  *spb.RebootRequest
+	// first is the first response of an active reboot, nil if there was
+	// none.
+	first *spb.RebootStatusResponse
+	// active is the number of responses of an active reboot, and reasons
+	// counts them by reason.
+	active  int
+	reasons map[string]int
+	err     error
+}
+
+func TestMultiComponentRebootStatus(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	if deviations.GNOISubcomponentRebootStatusUnsupported(dut) {
+		t.Skip("RebootStatus per subcomponent is not supported")
+	}
+	types := []oc.E_PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT{linecardType}
+	if !deviations.GNOIFabricComponentRebootUnsupported(dut) {
+		types = append(types, fabricType)
+	}
+	names := findRemovableComponents(t, dut, *maxStatusComponents, types...)
+	t.Logf("Found removable linecards and fabrics: %v", names)
+	if len(names) < 2 {
+		t.Skipf("Not enough removable linecards and fabrics for the test on %v: got %d, want at least 2", dut.Model(), len(names))
+	}
+
+	timeout := max(args.LinecardBootTimeout(), args.FabricBootTimeout())
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	upIntfs := helpers.FetchOperStatusUPIntfs(t, dut, *args.CheckInterfacesInBinding)
+	statuses := make([]*subcomponentStatus, len(names))
+	for i, name := range names {
+		req, err := rebootSubcomponent(t, gnoiClient, dut, name, 0)
+		if err != nil {
+			t.Fatalf("Failed to reboot %s: %v", name, err)
+		}
+		statuses[i] = &subcomponentStatus{name: name, req: req, reasons: map[string]int{}}
+	}
+
+	// Each subcomponent is polled from its own goroutine, so that the
+	// requests for different subcomponents are concurrent.
+	var wg sync.WaitGroup
+	for _, st := range statuses {
+		wg.Add(1)
+		go func(st *subcomponentStatus) {
+			defer wg.Done()
+			req := &spb.RebootStatusRequest{Subcomponents: st.req.GetSubcomponents()}
+			st.err = pollRebootStatus(gnoiClient, req, timeout, func(resp *spb.RebootStatusResponse) {
+				if st.first == nil {
+					st.first = resp
+				}
+				st.active++
+				st.reasons[resp.GetReason()]++
+			})
+		}(st)
+	}
+	wg.Wait()
+
+	for _, st := range statuses {
+		t.Run(st.name, func(t *testing.T) {
+			if st.err != nil {
+				t.Fatal(st.err)
+			}
+			t.Logf("RebootStatus reported an active reboot %d times, with reasons %v", st.active, st.reasons)
+			if st.first == nil {
+				t.Logf("RebootStatus reported no active reboot of %s, it may have restarted within %v", st.name, rebootStatusPollInterval)
+			} else {
+				verifyActiveRebootStatus(t, st.first, st.req)
+			}
+			for reason, n := range st.reasons {
+				if reason != st.req.GetMessage() {
+					t.Errorf("RebootStatus of %s reported reason %q %d times, want only %q", st.name, reason, n, st.req.GetMessage())
+				}
+			}
+			gnmi.Await(t, dut, gnmi.OC().Component(st.name).OperStatus().State(), timeout, oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE)
+		})
+	}
+	helpers.ValidateOperStatusUPIntfs(t, dut, upIntfs, 10*time.Minute)
+}
+
+// rebootStatus returns the RebootStatus of req, failing the test on error.
+func rebootStatus(t *testing.T, gnoiClient gnoigo.Clients, req *spb.RebootStatusRequest) *spb.RebootStatusResponse {
+	t.Helper()
+	resp, err := gnoiClient.System().RebootStatus(context.Background(), req)
+	t.Logf("gnoiClient.System().RebootStatus() response: %v, err: %v", resp, err)
+	if err != nil {
+		t.Fatalf("Failed to get reboot status with unexpected err: %v", err)
+	}
+	return resp
+}
+
+func TestSubcomponentCancelReboot(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	types := []oc.E_PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT{linecardType}
+	if !deviations.GNOIFabricComponentRebootUnsupported(dut) {
+		types = append(types, fabricType)
+	}
+	names := findRemovableComponents(t, dut, 1, types...)
+	if len(names) == 0 {
+		t.Skipf("No removable linecard or fabric found for the test on %v", dut.Model())
+	}
+	name := names[0]
+	t.Logf("Found removable component: %v", name)
+
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	component := gnmi.OC().Component(name)
+	lastReboot, _ := gnmi.Lookup(t, dut, component.LastRebootTime().State()).Val()
+
+	t.Run("Cancel without pending reboot", func(t *testing.T) {
+		var path *tpb.Path
+		err := components.WithSubcomponentPath(dut, name, func(p *tpb.Path) error {
+			req := &spb.CancelRebootRequest{
+				Message:       fmt.Sprintf("Cancel reboot of %s", name),
+				Subcomponents: []*tpb.Path{p},
+			}
+			resp, err := gnoiClient.System().CancelReboot(context.Background(), req)
+			t.Logf("gnoiClient.System().CancelReboot(%v) response: %v, err: %v", req, resp, err)
+			if err == nil {
+				path = p
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("CancelReboot of %s with no pending reboot: got err %v, want none", name, err)
+		}
+		statusReq := &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{path}}
+		if deviations.GNOISubcomponentRebootStatusUnsupported(dut) {
+			statusReq.Subcomponents = nil
+		}
+		if resp := rebootStatus(t, gnoiClient, statusReq); resp.GetActive() {
+			t.Errorf("RebootStatus of %s after CancelReboot with no pending reboot: got active, want not active", name)
+		}
+	})
+
+	t.Run("Cancel pending reboot", func(t *testing.T) {
+		start := time.Now()
+		rebootReq, err := rebootSubcomponent(t, gnoiClient, dut, name, *cancelRebootDelay)
+		if err != nil {
+			t.Fatalf("Failed to request reboot of %s with delay %v: %v", name, *cancelRebootDelay, err)
+		}
+		cancelReq := &spb.CancelRebootRequest{
+			Message:       fmt.Sprintf("Cancel reboot of %s", name),
+			Subcomponents: rebootReq.GetSubcomponents(),
+		}
+		defer gnoiClient.System().CancelReboot(context.Background(), cancelReq)
+
+		statusReq := &spb.RebootStatusRequest{Subcomponents: rebootReq.GetSubcomponents()}
+		if deviations.GNOISubcomponentRebootStatusUnsupported(dut) {
+			statusReq.Subcomponents = nil
+		}
+		resp := rebootStatus(t, gnoiClient, statusReq)
+		if !resp.GetActive() {
+			t.Errorf("RebootStatus of %s with a pending reboot: got not active, want active", name)
+		}
+		verifyActiveRebootStatus(t, resp, rebootReq)
+
+		cancelResp, err := gnoiClient.System().CancelReboot(context.Background(), cancelReq)
+		t.Logf("gnoiClient.System().CancelReboot(%v) response: %v, err: %v", cancelReq, cancelResp, err)
+		if err != nil {
+			t.Fatalf("Failed to cancel reboot of %s with unexpected err: %v", name, err)
+		}
+		if resp := rebootStatus(t, gnoiClient, statusReq); resp.GetActive() {
+			t.Errorf("RebootStatus of %s after CancelReboot: got active, want not active", name)
+		}
+
+		// Wait until well after the reboot would have started, so that a
+		// reboot that was not cancelled is seen.
+		wait := time.Until(start.Add(*cancelRebootDelay + time.Minute))
+		t.Logf("Verify %s does not reboot in the next %v", name, wait)
+		got, rebooted := gnmi.Watch(t, dut, component.OperStatus().State(), wait, func(v *ygnmi.Value[oc.E_PlatformTypes_COMPONENT_OPER_STATUS]) bool {
+			s, present := v.Val()
+			return !present || s != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE
+		}).Await(t)
+		if rebooted {
+			t.Errorf("%s oper-status after CancelReboot: got %v, want %v", name, got, oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE)
+		}
+		if got, _ := gnmi.Lookup(t, dut, component.LastRebootTime().State()).Val(); got != lastReboot {
+			t.Errorf("%s last-reboot-time after CancelReboot: got %v, want %v", name, got, lastReboot)
+		}
+		if resp := rebootStatus(t, gnoiClient, statusReq); resp.GetActive() {
+			t.Errorf("RebootStatus of %s after the cancelled delay: got active, want not active", name)
+		}
+	})
+}
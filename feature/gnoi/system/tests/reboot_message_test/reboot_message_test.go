@@ -0,0 +1,178 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reboot_message_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	spb "github.com/openconfig/gnoi/system"
+	acctzpb "github.com/openconfig/gnsi/acctz"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// rebootDelay is long enough for the test to collect the records of the
+	// reboot request and cancel it before the DUT reboots.
+	rebootDelay = 10 * time.Minute
+	// logTimeout is the time for the DUT to log the reboot request.
+	logTimeout = time.Minute
+	// rebootRPC is the name of the gNOI Reboot RPC in accounting records.
+	rebootRPC = "/gnoi.system.System/Reboot"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test steps:
+//   - Start collecting the syslog messages of the DUT from
+//     /system/messages/state/message.
+//   - Send a gNOI System.Reboot request with a delay and a message unique to
+//     the test run.
+//   - Verify a syslog message of the DUT has the reboot message.
+//   - Subscribe to the gNSI.acctz records since the reboot request, and
+//     verify the record of the Reboot RPC has the reboot message.
+//   - Cancel the reboot.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The reboot is cancelled, since the records of the reboot request are
+//     made when the request is received, and the DUT does not need to reboot
+//     for the test to verify them.
+//   - The DUT syslog is read through gNMI, so the test needs no syslog server
+//     reachable from the DUT.
+
+// verifyLogged verifies a syslog message has the reboot message.
+func verifyLogged(t *testing.T, msgs []*ygnmi.Value[*oc.System_Messages_Message], message string) {
+	t.Helper()
+	for _, v := range msgs {
+		m, ok := v.Val()
+		if ok && strings.Contains(m.GetMsg(), message) {
+			t.Logf("Syslog message: %s", m.GetMsg())
+			return
+		}
+	}
+	t.Errorf("No syslog message with reboot message %q among %d messages received after the reboot request", message, len(msgs))
+}
+
+// hasMessage returns whether the payload of a gRPC accounting record is a
+// RebootRequest with message, or a string that has it.
+func hasMessage(t *testing.T, gs *acctzpb.GrpcService, message string) bool {
+	t.Helper()
+	if s := gs.GetStringVal(); s != "" {
+		return strings.Contains(s, message)
+	}
+	payloads := gs.GetPayloads()
+	if pv := gs.GetProtoVal(); pv != nil {
+		payloads = append(payloads, pv)
+	}
+	for _, p := range payloads {
+		req := &spb.RebootRequest{}
+		if err := p.UnmarshalTo(req); err != nil {
+			t.Logf("Cannot unmarshal payload of %s accounting record as a RebootRequest: %v", rebootRPC, err)
+			continue
+		}
+		if req.GetMessage() == message {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyAccounted verifies an accounting record since start is of a Reboot
+// RPC with the reboot message.
+func verifyAccounted(t *testing.T, dut *ondatra.DUTDevice, start time.Time, message string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), logTimeout)
+	defer cancel()
+	stream, err := dut.RawAPIs().GNSI(t).Acctz().RecordSubscribe(ctx)
+	if err != nil {
+		t.Fatalf("Failed to subscribe to accounting records: %v", err)
+	}
+	if err := stream.Send(&acctzpb.RecordRequest{Timestamp: timestamppb.New(start)}); err != nil {
+		t.Fatalf("Failed to request accounting records since %v: %v", start, err)
+	}
+	var reboots int
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Errorf("No accounting record of %s with reboot message %q among %d %s records: %v", rebootRPC, message, reboots, rebootRPC, err)
+			return
+		}
+		gs := resp.GetGrpcService()
+		if gs.GetRpcName() != rebootRPC {
+			continue
+		}
+		reboots++
+		if hasMessage(t, gs, message) {
+			t.Logf("Accounting record of %s at %v for user %q", rebootRPC, resp.GetTimestamp().AsTime(), resp.GetSessionInfo().GetUser().GetIdentity())
+			return
+		}
+	}
+}
+
+func TestRebootMessage(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	start := time.Now()
+	req := &spb.RebootRequest{
+		Method:  spb.RebootMethod_COLD,
+		Delay:   uint64(rebootDelay.Nanoseconds()),
+		Message: fmt.Sprintf("featureprofiles reboot message test %d", start.UnixNano()),
+		Force:   true,
+	}
+
+	var msgs *gnmi.Collector[*oc.System_Messages_Message]
+	if !deviations.SystemMessagesUnsupported(dut) {
+		msgs = gnmi.Collect(t, dut, gnmi.OC().System().Messages().Message().State(), logTimeout)
+	}
+
+	t.Logf("Send reboot request: %v", req)
+	resp, err := gnoiClient.System().Reboot(context.Background(), req)
+	defer gnoiClient.System().CancelReboot(context.Background(), &spb.CancelRebootRequest{})
+	t.Logf("Got reboot response: %v, err: %v", resp, err)
+	if err != nil {
+		t.Fatalf("Failed to request reboot with unexpected err: %v", err)
+	}
+
+	t.Run("Syslog", func(t *testing.T) {
+		if msgs == nil {
+			t.Skip("The DUT does not stream syslog messages")
+		}
+		verifyLogged(t, msgs.Await(t), req.GetMessage())
+	})
+	t.Run("Accounting", func(t *testing.T) {
+		verifyAccounted(t, dut, start, req.GetMessage())
+	})
+
+	cancelResp, err := gnoiClient.System().CancelReboot(context.Background(), &spb.CancelRebootRequest{})
+	t.Logf("DUT CancelReboot response: %v, err: %v", cancelResp, err)
+	if err != nil {
+		t.Fatalf("Failed to cancel reboot with unexpected err: %v", err)
+	}
+}
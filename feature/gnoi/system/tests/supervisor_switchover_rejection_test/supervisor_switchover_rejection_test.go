@@ -0,0 +1,277 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supervisor_switchover_rejection_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/testt"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+const (
+	controlcardType = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
+	// nonexistentRP is the name of a controller card that no DUT has.
+	nonexistentRP = "NonexistentControllerCard"
+
+	// noopTime is the time allowed for a rejected or NOOP request to cause a
+	// switchover anyway.
+	noopTime = 30 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Switch to the active controller card: the request is a NOOP, and
+//     returns the active controller card without a switchover.
+//  2. Switch to a controller card that does not exist, and to a component
+//     that is not a controller card: the request is rejected with
+//     INVALID_ARGUMENT or NOT_FOUND, without a switchover.
+//  3. Switch to the standby controller card while it reboots: the request is
+//     rejected with FAILED_PRECONDITION or UNAVAILABLE, without a switchover,
+//     until the standby is ready.  Once it is ready, the switchover succeeds.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - SwitchControlProcessor will switch from the current route processor to
+//     the provided route processor. If the current route processor is the same
+//     as the one provided it is a NOOP. If the target does not exist an error
+//     is returned.
+//   - The spec does not name the error codes, so the codes of the gRPC status
+//     code definitions for an invalid target and for a target not ready are
+//     accepted.
+//   - Requests expected to be rejected are sent once, with the subcomponent
+//     path form detected by an earlier request, so that an INVALID_ARGUMENT
+//     is not retried with the other path form.
+//   - The time for the standby controller card to reboot and be ready again
+//     is the switchover timeout of the timing profile.
+
+// controllerCards returns the standby and active controller cards of the DUT,
+// skipping the test if it does not have two.
+func controllerCards(t *testing.T, dut *ondatra.DUTDevice) (string, string) {
+	t.Helper()
+	cards := components.FindComponentsByType(t, dut, controlcardType)
+	t.Logf("Found controller card list: %v", cards)
+	if got, want := len(cards), 2; got < want {
+		t.Skipf("Not enough controller cards for the test on %v: got %v, want at least %v", dut.Model(), got, want)
+	}
+	return components.FindStandbyRP(t, dut, cards)
+}
+
+// switchControlProcessor requests a switchover to component name, detecting
+// the subcomponent path form accepted by the DUT.
+func switchControlProcessor(t *testing.T, dut *ondatra.DUTDevice, name string) (*spb.SwitchControlProcessorResponse, error) {
+	t.Helper()
+	var resp *spb.SwitchControlProcessorResponse
+	err := components.WithSubcomponentPath(dut, name, func(p *tpb.Path) error {
+		var err error
+		resp, err = requestSwitchover(t, dut, p)
+		return err
+	})
+	return resp, err
+}
+
+// rejectedSwitchControlProcessor requests a switchover to component name
+// with a single request, using the subcomponent path form already detected
+// for the DUT.
+func rejectedSwitchControlProcessor(t *testing.T, dut *ondatra.DUTDevice, name string) error {
+	t.Helper()
+	_, err := requestSwitchover(t, dut, components.DetectedSubcomponentPath(dut, name))
+	return err
+}
+
+// requestSwitchover sends a SwitchControlProcessor request for the control
+// processor p.
+func requestSwitchover(t *testing.T, dut *ondatra.DUTDevice, p *tpb.Path) (*spb.SwitchControlProcessorResponse, error) {
+	t.Helper()
+	req := &spb.SwitchControlProcessorRequest{ControlProcessor: p}
+	t.Logf("switchoverRequest: %v", req)
+	resp, err := dut.RawAPIs().GNOI(t).System().SwitchControlProcessor(context.Background(), req)
+	t.Logf("SwitchControlProcessor response: %v, err: %v", resp, err)
+	return resp, err
+}
+
+// responseName returns the component name of the control processor in resp.
+func responseName(resp *spb.SwitchControlProcessorResponse) string {
+	switch elems := resp.GetControlProcessor().GetElem(); {
+	case len(elems) == 1:
+		return elems[0].GetName()
+	case len(elems) > 1:
+		return elems[1].GetKey()["name"]
+	}
+	return ""
+}
+
+// checkCode verifies that err has one of the codes in want.
+func checkCode(t *testing.T, desc string, err error, want ...codes.Code) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("SwitchControlProcessor to %s succeeded, want an error with code %v", desc, want)
+		return
+	}
+	for _, c := range want {
+		if status.Code(err) == c {
+			return
+		}
+	}
+	t.Errorf("SwitchControlProcessor to %s: got error code %v, want %v: %v", desc, status.Code(err), want, err)
+}
+
+// verifyNoSwitchover verifies that active is still the active controller card
+// noopTime after a request.
+func verifyNoSwitchover(t *testing.T, dut *ondatra.DUTDevice, active string) {
+	t.Helper()
+	got, switched := gnmi.Watch(t, dut, gnmi.OC().Component(active).RedundantRole().State(), noopTime, func(val *ygnmi.Value[oc.E_Platform_ComponentRedundantRole]) bool {
+		role, present := val.Val()
+		return present && role != oc.Platform_ComponentRedundantRole_PRIMARY
+	}).Await(t)
+	if switched {
+		t.Fatalf("Controller card %s is no longer active: %v", active, got)
+	}
+}
+
+// awaitSwitchover waits for the DUT to answer gNMI requests again after a
+// switchover.
+func awaitSwitchover(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	start := time.Now()
+	for {
+		time.Sleep(args.PollInterval())
+		errMsg := testt.CaptureFatal(t, func(t testing.TB) {
+			gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
+		})
+		if errMsg == nil {
+			break
+		}
+		t.Logf("Got testt.CaptureFatal errMsg: %s, keep polling ...", *errMsg)
+		if got, want := time.Since(start), args.SwitchoverTimeout(); got >= want {
+			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", got, want)
+		}
+	}
+	t.Logf("RP switchover time: %.2f seconds", time.Since(start).Seconds())
+}
+
+func TestSwitchToActive(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	_, active := controllerCards(t, dut)
+
+	resp, err := switchControlProcessor(t, dut, active)
+	if err != nil {
+		t.Fatalf("SwitchControlProcessor to active controller card %s failed, want a NOOP: %v", active, err)
+	}
+	if got := responseName(resp); got != active {
+		t.Errorf("SwitchControlProcessor to active controller card control processor: got %q, want %q", got, active)
+	}
+	verifyNoSwitchover(t, dut, active)
+}
+
+func TestSwitchToInvalidTarget(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	_, active := controllerCards(t, dut)
+	chassis := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CHASSIS)
+
+	// targets maps the description of each invalid target to its name.
+	targets := map[string]string{
+		"nonexistent controller card": nonexistentRP,
+	}
+	if len(chassis) > 0 {
+		targets["chassis "+chassis[0]] = chassis[0]
+	}
+	// Detect the subcomponent path form with a NOOP request to the active
+	// controller card.
+	if _, err := switchControlProcessor(t, dut, active); err != nil {
+		t.Fatalf("SwitchControlProcessor to active controller card %s failed, want a NOOP: %v", active, err)
+	}
+	for desc, target := range targets {
+		t.Run(desc, func(t *testing.T) {
+			err := rejectedSwitchControlProcessor(t, dut, target)
+			checkCode(t, desc, err, codes.InvalidArgument, codes.NotFound)
+			verifyNoSwitchover(t, dut, active)
+		})
+	}
+}
+
+func TestSwitchDuringStandbyReboot(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	standby, active := controllerCards(t, dut)
+	gnmi.Await(t, dut, gnmi.OC().Component(active).SwitchoverReady().State(), args.SwitchoverTimeout(), true)
+
+	startReboot := time.Now()
+	err := components.WithSubcomponentPath(dut, standby, func(p *tpb.Path) error {
+		req := &spb.RebootRequest{
+			Method:        spb.RebootMethod_COLD,
+			Message:       fmt.Sprintf("Reboot %s", standby),
+			Subcomponents: []*tpb.Path{p},
+		}
+		t.Logf("rebootSubComponentRequest: %v", req)
+		_, err := dut.RawAPIs().GNOI(t).System().Reboot(context.Background(), req)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to reboot standby controller card %s: %v", standby, err)
+	}
+
+	_, rebooting := gnmi.Watch(t, dut, gnmi.OC().Component(active).SwitchoverReady().State(), args.SwitchoverTimeout(), func(val *ygnmi.Value[bool]) bool {
+		ready, present := val.Val()
+		return present && !ready
+	}).Await(t)
+	if !rebooting {
+		t.Fatalf("Active controller card %s still reports switchover-ready %v after the reboot of %s", active, args.SwitchoverTimeout(), standby)
+	}
+
+	t.Run("Rejected", func(t *testing.T) {
+		err := rejectedSwitchControlProcessor(t, dut, standby)
+		checkCode(t, "rebooting standby controller card "+standby, err, codes.FailedPrecondition, codes.Unavailable)
+		verifyNoSwitchover(t, dut, active)
+	})
+
+	programming.Await(t, dut, args.SwitchoverTimeout(), programming.ControllerReady(standby, startReboot))
+	gnmi.Await(t, dut, gnmi.OC().Component(active).SwitchoverReady().State(), args.SwitchoverTimeout(), true)
+	t.Logf("Standby controller card ready %.2f seconds after reboot", time.Since(startReboot).Seconds())
+
+	t.Run("AcceptedWhenReady", func(t *testing.T) {
+		resp, err := switchControlProcessor(t, dut, standby)
+		if err != nil {
+			t.Fatalf("SwitchControlProcessor to ready standby controller card %s failed: %v", standby, err)
+		}
+		if got := responseName(resp); got != standby {
+			t.Errorf("SwitchControlProcessor control processor: got %q, want %q", got, standby)
+		}
+		awaitSwitchover(t, dut)
+		if gotStandby, gotActive := controllerCards(t, dut); gotActive != standby || gotStandby != active {
+			t.Errorf("Controller cards after switchover: got active %s and standby %s, want active %s and standby %s", gotActive, gotStandby, standby, active)
+		}
+	})
+}
@@ -19,22 +19,22 @@ import (
 	"time"
 
 	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/availability"
 	"github.com/openconfig/featureprofiles/internal/helpers"
 
 	"github.com/openconfig/featureprofiles/internal/components"
-	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/testt"
 
 	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
 	"github.com/openconfig/ygnmi/ygnmi"
 )
 
 const (
-	maxSwitchoverTime = 900
 	controlcardType   = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
 	activeController  = oc.Platform_ComponentRedundantRole_PRIMARY
 	standbyController = oc.Platform_ComponentRedundantRole_SECONDARY
@@ -101,13 +101,19 @@ func TestSupervisorSwitchover(t *testing.T) {
 		t.Errorf("Get the number of intfsOperStatusUP interfaces for %q: got %v, want > %v", dut.Name(), got, want)
 	}
 
+	m := availability.Start(t, dut)
+	defer m.Stop(t)
 	gnoiClient := dut.RawAPIs().GNOI(t)
-	useNameOnly := deviations.GNOISubcomponentPath(dut)
-	switchoverRequest := &spb.SwitchControlProcessorRequest{
-		ControlProcessor: components.GetSubcomponentPath(rpStandbyBeforeSwitch, useNameOnly),
-	}
-	t.Logf("switchoverRequest: %v", switchoverRequest)
-	switchoverResponse, err := gnoiClient.System().SwitchControlProcessor(context.Background(), switchoverRequest)
+	var switchoverResponse *spb.SwitchControlProcessorResponse
+	err := components.WithSubcomponentPath(dut, rpStandbyBeforeSwitch, func(p *tpb.Path) error {
+		switchoverRequest := &spb.SwitchControlProcessorRequest{
+			ControlProcessor: p,
+		}
+		t.Logf("switchoverRequest: %v", switchoverRequest)
+		var err error
+		switchoverResponse, err = gnoiClient.System().SwitchControlProcessor(context.Background(), switchoverRequest)
+		return err
+	})
 	if err != nil {
 		t.Fatalf("Failed to perform control processor switchover with unexpected err: %v", err)
 	}
@@ -115,10 +121,10 @@ func TestSupervisorSwitchover(t *testing.T) {
 
 	want := rpStandbyBeforeSwitch
 	got := ""
-	if deviations.GNOISubcomponentPath(dut) {
-		got = switchoverResponse.GetControlProcessor().GetElem()[0].GetName()
-	} else {
-		got = switchoverResponse.GetControlProcessor().GetElem()[1].GetKey()["name"]
+	if elems := switchoverResponse.GetControlProcessor().GetElem(); len(elems) == 1 {
+		got = elems[0].GetName()
+	} else if len(elems) > 1 {
+		got = elems[1].GetKey()["name"]
 	}
 	if got != want {
 		t.Fatalf("switchoverResponse.GetControlProcessor().GetElem()[0].GetName(): got %v, want %v", got, want)
@@ -135,7 +141,7 @@ func TestSupervisorSwitchover(t *testing.T) {
 	for {
 		var currentTime string
 		t.Logf("Time elapsed %.2f seconds since switchover started.", time.Since(startSwitchover).Seconds())
-		time.Sleep(30 * time.Second)
+		time.Sleep(args.PollInterval())
 		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
 			currentTime = gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
 		}); errMsg != nil {
@@ -144,7 +150,7 @@ func TestSupervisorSwitchover(t *testing.T) {
 			t.Logf("RP switchover has completed successfully with received time: %v", currentTime)
 			break
 		}
-		if got, want := uint64(time.Since(startSwitchover).Seconds()), uint64(maxSwitchoverTime); got >= want {
+		if got, want := time.Since(startSwitchover), args.SwitchoverTimeout(); got >= want {
 			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", got, want)
 		}
 	}
@@ -0,0 +1,392 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route_install_rate_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+var (
+	entryCount         = flag.Int("entries", 500000, "Number of gRIBI IPv4 entries to program.")
+	batchSize          = flag.Int("batch_size", 1000, "Number of IPv4 entries sent per ModifyRequest batch.")
+	minInstallRate     = flag.Float64("min_install_rate", 0, "Minimum sustained install rate in FIB-acknowledged entries per second. Not checked when 0.")
+	subSampleInterval  = flag.Duration("subscription_sample_interval", 10*time.Second, "SAMPLE interval of the concurrent gNMI subscriptions.")
+	maxSampleGapFactor = flag.Float64("max_sample_gap_factor", 2, "Maximum allowed gap between samples of a subscription, as a multiple of --subscription_sample_interval.")
+)
+
+const (
+	nhIndex    = 1
+	nhgIndex   = 1
+	prefixBase = uint32(100<<24 | 64<<16) // 100.64.0.0
+	// maxEntries is the number of /32 prefixes in 100.64.0.0/10, enough for
+	// the default of 500k entries.
+	maxEntries    = 1 << 22
+	trafficPPS    = 1000
+	flowName      = "sampledPrefixes"
+	installWait   = 30 * time.Minute
+	electionIDLow = 12
+)
+
+// subscriptionPaths are the heavy paths subscribed to while programming.
+var subscriptionPaths = []string{
+	"/interfaces/interface",
+	"/interfaces/interface/state/counters",
+	"/interfaces/interface/subinterfaces",
+	"/components/component",
+	"/components/component/state",
+	"/network-instances/network-instance/state",
+	"/network-instances/network-instance/protocols",
+	"/lldp",
+	"/system",
+	"/qos",
+}
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Start 10 gNMI subscriptions in SAMPLE mode on heavy paths.
+//  2. Program one next-hop towards ATE port-2, one next-hop-group and
+//     --entries IPv4 /32 entries in the default network instance, in batches
+//     of --batch_size, with FIB ACK.
+//  3. Report the sustained install rate from the first request to the last
+//     FIB_PROGRAMMED acknowledgement, and verify it is at least
+//     --min_install_rate.
+//  4. Verify all entries are FIB_PROGRAMMED.
+//  5. Verify no subscription went longer than --max_sample_gap_factor times
+//     --subscription_sample_interval without an update while programming.
+//  6. Send traffic to the first, middle and last prefix and verify there is
+//     no loss.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice, dsts []string) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{atePort1.Name + ".IPv4"}).
+		SetRxNames([]string{atePort2.Name + ".IPv4"})
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(trafficPPS)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValues(dsts)
+	return top
+}
+
+// prefixAddr returns the address of the i-th programmed /32 prefix.
+func prefixAddr(i int) string {
+	a := prefixBase + uint32(i)
+	return fmt.Sprintf("%d.%d.%d.%d", byte(a>>24), byte(a>>16), byte(a>>8), byte(a))
+}
+
+// sampleSubscription is a gNMI SAMPLE subscription recording the arrival time
+// of each update.
+type sampleSubscription struct {
+	path string
+
+	mu       sync.Mutex
+	arrivals []time.Time
+	err      error
+}
+
+// run subscribes to s.path and records update arrivals until ctx is done.
+func (s *sampleSubscription) run(ctx context.Context, c gpb.GNMIClient, interval time.Duration) {
+	err := s.subscribe(ctx, c, interval)
+	if status.Code(err) == codes.Canceled || err == io.EOF {
+		err = nil
+	}
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+func (s *sampleSubscription) subscribe(ctx context.Context, c gpb.GNMIClient, interval time.Duration) error {
+	p, err := ygot.StringToStructuredPath(s.path)
+	if err != nil {
+		return err
+	}
+	p.Origin = "openconfig"
+	sub, err := c.Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sub.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:     gpb.SubscriptionList_STREAM,
+				Encoding: gpb.Encoding_PROTO,
+				Subscription: []*gpb.Subscription{{
+					Path:           p,
+					Mode:           gpb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(interval.Nanoseconds()),
+				}},
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	for {
+		resp, err := sub.Recv()
+		if err != nil {
+			return err
+		}
+		if resp.GetUpdate() == nil {
+			continue
+		}
+		s.mu.Lock()
+		s.arrivals = append(s.arrivals, time.Now())
+		s.mu.Unlock()
+	}
+}
+
+// maxGap returns the longest time between updates within [start, end],
+// counting the window edges.
+func (s *sampleSubscription) maxGap(start, end time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var gap time.Duration
+	last := start
+	for _, a := range s.arrivals {
+		if a.Before(start) {
+			continue
+		}
+		if a.After(end) {
+			break
+		}
+		if d := a.Sub(last); d > gap {
+			gap = d
+		}
+		last = a
+	}
+	if d := end.Sub(last); d > gap {
+		gap = d
+	}
+	return gap
+}
+
+// programEntries sends the next-hop, next-hop-group and IPv4 entries and
+// waits for all of them to be acknowledged.  It returns the time the first
+// request was sent.
+func programEntries(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, client *fluent.GRIBIClient) time.Time {
+	t.Helper()
+	ni := deviations.DefaultNetworkInstance(dut)
+	start := time.Now()
+	client.Modify().AddEntry(t,
+		fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(nhIndex).WithIPAddress(atePort2.IPv4),
+		fluent.NextHopGroupEntry().WithNetworkInstance(ni).WithID(nhgIndex).AddNextHop(nhIndex, 1),
+	)
+	for i := 0; i < *entryCount; i += *batchSize {
+		var batch []fluent.GRIBIEntry
+		for j := i; j < i+*batchSize && j < *entryCount; j++ {
+			batch = append(batch, fluent.IPv4Entry().
+				WithNetworkInstance(ni).
+				WithPrefix(prefixAddr(j)+"/32").
+				WithNextHopGroup(nhgIndex).
+				WithNextHopGroupNetworkInstance(ni))
+		}
+		client.Modify().AddEntry(t, batch...)
+	}
+	awaitCtx, cancel := context.WithTimeout(ctx, installWait)
+	defer cancel()
+	if err := client.Await(awaitCtx, t); err != nil {
+		t.Fatalf("Await got error while programming %d entries: %v", *entryCount, err)
+	}
+	return start
+}
+
+// fibResults returns the number of FIB_PROGRAMMED and failed operations and
+// the time of the last FIB_PROGRAMMED acknowledgement.
+func fibResults(t *testing.T, client *fluent.GRIBIClient) (programmed, failed int, last time.Time) {
+	t.Helper()
+	for _, r := range client.Results(t) {
+		switch r.ProgrammingResult {
+		case spb.AFTResult_FIB_PROGRAMMED:
+			programmed++
+			if ts := time.Unix(0, r.Timestamp); ts.After(last) {
+				last = ts
+			}
+		case spb.AFTResult_FAILED, spb.AFTResult_FIB_FAILED:
+			failed++
+		}
+	}
+	return programmed, failed, last
+}
+
+func TestRouteInstallRate(t *testing.T) {
+	if *entryCount > maxEntries {
+		t.Fatalf("--entries is %d, want at most %d", *entryCount, maxEntries)
+	}
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	ctx := context.Background()
+
+	configureDUT(t, dut)
+	sampled := []string{prefixAddr(0), prefixAddr(*entryCount / 2), prefixAddr(*entryCount - 1)}
+	top := configureATE(t, ate, sampled)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	subCtx, cancelSubs := context.WithCancel(ctx)
+	defer cancelSubs()
+	gnmiClient := dut.RawAPIs().GNMI(t)
+	var subs []*sampleSubscription
+	var wg sync.WaitGroup
+	for _, p := range subscriptionPaths {
+		s := &sampleSubscription{path: p}
+		subs = append(subs, s)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.run(subCtx, gnmiClient, *subSampleInterval)
+		}()
+	}
+	// Let the subscriptions complete their initial sync before programming.
+	time.Sleep(2 * *subSampleInterval)
+
+	client := fluent.NewClient()
+	client.Connection().WithStub(dut.RawAPIs().GRIBI(t)).WithPersistence().WithInitialElectionID(electionIDLow, 0).
+		WithRedundancyMode(fluent.ElectedPrimaryClient).WithFIBACK()
+	client.Start(ctx, t)
+	defer client.Stop(t)
+	client.StartSending(ctx, t)
+	gribi.BecomeLeader(t, client)
+	defer func() {
+		if err := gribi.FlushAll(client); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Logf("Programming %d IPv4 entries in batches of %d with %d concurrent gNMI subscriptions", *entryCount, *batchSize, len(subs))
+	start := programEntries(ctx, t, dut, client)
+	end := time.Now()
+	cancelSubs()
+	wg.Wait()
+
+	t.Run("InstallRate", func(t *testing.T) {
+		programmed, failed, last := fibResults(t, client)
+		if want := *entryCount + 2; programmed != want || failed != 0 {
+			t.Errorf("FIB programmed entries: got %d programmed and %d failed, want %d programmed", programmed, failed, want)
+		}
+		if last.IsZero() {
+			t.Fatalf("No FIB_PROGRAMMED acknowledgement received")
+		}
+		elapsed := last.Sub(start)
+		rate := float64(programmed) / elapsed.Seconds()
+		t.Logf("Installed %d entries in %v: %.0f installs/sec", programmed, elapsed, rate)
+		if *minInstallRate > 0 && rate < *minInstallRate {
+			t.Errorf("Sustained install rate: got %.0f entries/sec, want >= %.0f", rate, *minInstallRate)
+		}
+	})
+
+	t.Run("TelemetryNotStarved", func(t *testing.T) {
+		maxGap := time.Duration(*maxSampleGapFactor * float64(*subSampleInterval))
+		for _, s := range subs {
+			if s.err != nil {
+				t.Errorf("Subscription to %s failed: %v", s.path, s.err)
+				continue
+			}
+			gap := s.maxGap(start, end)
+			t.Logf("Subscription to %s: longest gap between updates while programming %v", s.path, gap)
+			if gap > maxGap {
+				t.Errorf("Subscription to %s starved while programming: got gap %v, want <= %v", s.path, gap, maxGap)
+			}
+		}
+	})
+
+	t.Run("Traffic", func(t *testing.T) {
+		ate.OTG().StartTraffic(t)
+		time.Sleep(15 * time.Second)
+		ate.OTG().StopTraffic(t)
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+		if loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 20*time.Second); loss > 0 {
+			t.Errorf("Traffic loss to prefixes %s: got %.4f%%, want 0%%", strings.Join(sampled, ", "), loss)
+		}
+	})
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp_same_prefix_test
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	prefixAddress = "198.51.100.0"
+	prefixLen     = 24
+	prefix        = "198.51.100.0/24"
+	bgpRoute      = "bgp-route"
+	flowName      = "to-prefix"
+	nhIndex       = 1
+	nhgIndex      = 42
+	flowPPS       = 1000
+	trafficTime   = 15 * time.Second
+	// lossTol is the percentage of the packets of a step that may be lost,
+	// or received on the ATE port of the source that is not active.
+	lossTol        = 1.0
+	sessionTimeout = 2 * time.Minute
+	aftTimeout     = 2 * time.Minute
+)
+
+var preferredProtocol = flag.String("preferred_protocol", "",
+	"The source the DUT is expected to prefer while the prefix is both programmed by gRIBI and learned from BGP, GRIBI or BGP. If empty, the DUT may prefer either, as long as it does so consistently.")
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Both sources: ATE port-3 advertises 198.51.100.0/24 with BGP, and a
+//     gRIBI client programs the same prefix with a next hop of ATE port-2.
+//     Verify the AFT entry of the prefix is attributed to one source, its
+//     next hop is the ATE port of that source, and traffic from ATE port-1 is
+//     forwarded to that port.  This source is the preferred source.
+//  2. Withdraw preferred: withdraw the prefix from the preferred source, and
+//     verify the AFT entry and traffic move to the other source.
+//  3. Restore preferred: add the prefix back to the preferred source, and
+//     verify the AFT entry and traffic move back to it.
+//  4. Withdraw other: withdraw the prefix from the other source, and verify
+//     the AFT entry and traffic stay with the preferred source.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                     dut:port3 <--> ate:port3
+//	                     dut:port4 <--> ate:port4
+//
+// Test notes:
+//   - The gRIBI client uses RIB acks, since the gRIBI entry is not programmed
+//     in the FIB while the BGP route is preferred.
+//   - The gRIBI specification does not define the preference between gRIBI
+//     and other protocols, so --preferred_protocol sets the expected one.
+
+// source is a source of the prefix on the DUT.
+type source struct {
+	protocol oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE
+	// port is the index of the ATE port of the next hop.
+	port     int
+	add      func(t *testing.T)
+	withdraw func(t *testing.T)
+}
+
+func (s *source) String() string {
+	return s.protocol.String()
+}
+
+// configureATE adds the BGP route to ATE port-3, and a flow from ATE port-1
+// to the prefix.
+func configureATE(bs *cfgplugins.BGPSession) {
+	peer := bs.ATEIntfs[2].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+	r := peer.V4Routes().Add().SetName(bgpRoute)
+	r.SetNextHopIpv4Address(bs.ATEPorts[2].IPv4).
+		SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+		SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+	r.Addresses().Add().SetAddress(prefixAddress).SetPrefix(prefixLen)
+
+	flow := bs.ATETop.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Port().
+		SetTxName(bs.OndatraATEPorts[0].ID()).
+		SetRxNames([]string{bs.OndatraATEPorts[1].ID(), bs.OndatraATEPorts[2].ID()})
+	flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+	flow.Packet().Add().Ethernet()
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+	v4.Dst().Increment().SetStart(prefixAddress).SetCount(100)
+	flow.Rate().SetPps(flowPPS)
+}
+
+// awaitActive waits for the AFT entry of the prefix to be attributed to s,
+// and verifies its next hop is the ATE port of s.
+func awaitActive(t *testing.T, bs *cfgplugins.BGPSession, s *source) {
+	t.Helper()
+	dut := bs.DUT
+	afts := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts()
+	v, ok := gnmi.Watch(t, dut, afts.Ipv4Entry(prefix).State(), aftTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+		e, present := v.Val()
+		return present && e.GetOriginProtocol() == s.protocol
+	}).Await(t)
+	if !ok {
+		e, _ := v.Val()
+		t.Fatalf("AFT entry %s origin-protocol: got %v, want %v", prefix, e.GetOriginProtocol(), s.protocol)
+	}
+	e, _ := v.Val()
+	nhg := gnmi.Get(t, dut, afts.NextHopGroup(e.GetNextHopGroup()).State())
+	want := bs.ATEPorts[s.port].IPv4
+	for i := range nhg.NextHop {
+		if got := gnmi.Get(t, dut, afts.NextHop(i).State()).GetIpAddress(); got != want {
+			t.Errorf("Next hop %d of AFT entry %s from %v: got %s, want %s", i, prefix, s, got, want)
+		}
+	}
+}
+
+// verifyForwarding sends traffic to the prefix, and verifies it is received
+// on the ATE port of s.
+func verifyForwarding(t *testing.T, bs *cfgplugins.BGPSession, s *source) {
+	t.Helper()
+	otg := bs.ATE.OTG()
+	inFrames := func() []uint64 {
+		var frames []uint64
+		for _, p := range bs.OndatraATEPorts {
+			frames = append(frames, gnmi.Get(t, otg, gnmi.OTG().Port(p.ID()).Counters().InFrames().State()))
+		}
+		return frames
+	}
+	before := inFrames()
+	otg.StartTraffic(t)
+	time.Sleep(trafficTime)
+	otg.StopTraffic(t)
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+	after := inFrames()
+
+	tx := gnmi.Get(t, otg, gnmi.OTG().Flow(flowName).Counters().OutPkts().State())
+	if tx == 0 {
+		t.Fatalf("Flow %s sent no packets", flowName)
+	}
+	for _, port := range []int{1, 2} {
+		rx := after[port] - before[port]
+		pct := 100 * float64(rx) / float64(tx)
+		switch {
+		case port == s.port && pct < 100-lossTol:
+			t.Errorf("ATE port %s received %.2f%% of the packets to %s, want >= %.2f%% while it is the next hop from %v", bs.OndatraATEPorts[port].ID(), pct, prefix, 100-lossTol, s)
+		case port != s.port && pct > lossTol:
+			t.Errorf("ATE port %s received %.2f%% of the packets to %s, want <= %.2f%% while the next hop from %v is ATE port %s", bs.OndatraATEPorts[port].ID(), pct, prefix, lossTol, s, bs.OndatraATEPorts[s.port].ID())
+		}
+	}
+}
+
+func setRouteState(t *testing.T, bs *cfgplugins.BGPSession, state gosnappi.StateProtocolRouteStateEnum) {
+	t.Helper()
+	t.Logf("Setting route %s to %v", bgpRoute, state)
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames([]string{bgpRoute}).SetState(state)
+	bs.ATE.OTG().SetControlState(t, cs)
+}
+
+func TestBGPSamePrefix(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount4, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port3"}, false, false)
+	configureATE(bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	dut := bs.DUT
+	ni := deviations.DefaultNetworkInstance(dut)
+	programming.Await(t, dut, sessionTimeout,
+		programming.BGPNeighbor(ni, "BGP", bs.ATEPorts[2].IPv4),
+		programming.IPv4Entry(ni, prefix),
+	)
+
+	c := &gribi.Client{DUT: dut, Persistence: true}
+	if err := c.Start(t); err != nil {
+		t.Fatalf("Could not initialize gRIBI: %v", err)
+	}
+	defer c.Close(t)
+	c.BecomeLeader(t)
+	c.FlushAll(t)
+	defer c.FlushAll(t)
+
+	bgp := &source{
+		protocol: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP,
+		port:     2,
+		add: func(t *testing.T) {
+			setRouteState(t, bs, gosnappi.StateProtocolRouteState.ADVERTISE)
+		},
+		withdraw: func(t *testing.T) {
+			setRouteState(t, bs, gosnappi.StateProtocolRouteState.WITHDRAW)
+		},
+	}
+	grb := &source{
+		protocol: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_GRIBI,
+		port:     1,
+		add: func(t *testing.T) {
+			t.Logf("Adding gRIBI IPv4 entry %s with next hop %s", prefix, bs.ATEPorts[1].IPv4)
+			c.AddIPv4(t, prefix, nhgIndex, ni, ni, fluent.InstalledInRIB)
+		},
+		withdraw: func(t *testing.T) {
+			t.Logf("Deleting gRIBI IPv4 entry %s", prefix)
+			c.DeleteIPv4(t, prefix, ni, fluent.InstalledInRIB)
+		},
+	}
+	c.AddNH(t, nhIndex, bs.ATEPorts[1].IPv4, ni, fluent.InstalledInRIB)
+	c.AddNHG(t, nhgIndex, map[uint64]uint64{nhIndex: 1}, ni, fluent.InstalledInRIB)
+
+	// preferred and other are set by the first case, from the source of the
+	// AFT entry while the prefix has both sources.
+	var preferred, other *source
+	t.Run("Both sources", func(t *testing.T) {
+		grb.add(t)
+		// Wait for the gRIBI entry to be processed before reading the source
+		// of the AFT entry.
+		time.Sleep(10 * time.Second)
+		e := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(ni).Afts().Ipv4Entry(prefix).State())
+		switch e.GetOriginProtocol() {
+		case grb.protocol:
+			preferred, other = grb, bgp
+		case bgp.protocol:
+			preferred, other = bgp, grb
+		default:
+			t.Fatalf("AFT entry %s origin-protocol with both sources: got %v, want %v or %v", prefix, e.GetOriginProtocol(), grb, bgp)
+		}
+		t.Logf("DUT prefers %v over %v for %s", preferred, other, prefix)
+		if *preferredProtocol != "" && preferred.String() != *preferredProtocol {
+			t.Errorf("Preferred source of %s: got %v, want %s", prefix, preferred, *preferredProtocol)
+		}
+		awaitActive(t, bs, preferred)
+		verifyForwarding(t, bs, preferred)
+
+		received := gnmi.OC().NetworkInstance(ni).Protocol(cfgplugins.PTBGP, "BGP").Bgp().
+			Neighbor(bs.ATEPorts[2].IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Received().State()
+		if got := gnmi.Get(t, dut, received); got != 1 {
+			t.Errorf("Prefixes received from %s with both sources: got %d, want 1", bs.ATEPorts[2].IPv4, got)
+		}
+	})
+	if preferred == nil {
+		t.Fatal("No preferred source, skipping the withdrawal cases")
+	}
+
+	cases := []struct {
+		desc   string
+		change func(t *testing.T)
+		want   *source
+	}{{
+		desc:   "Withdraw preferred",
+		change: preferred.withdraw,
+		want:   other,
+	}, {
+		desc:   "Restore preferred",
+		change: preferred.add,
+		want:   preferred,
+	}, {
+		desc:   "Withdraw other",
+		change: other.withdraw,
+		want:   preferred,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.change(t)
+			awaitActive(t, bs, tc.want)
+			verifyForwarding(t, bs, tc.want)
+		})
+	}
+}
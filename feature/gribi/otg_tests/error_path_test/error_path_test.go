@@ -0,0 +1,397 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package error_path_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+
+	spb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+var (
+	implicitReplace  = flag.Bool("implicit_replace", false, "Set when the DUT treats an ADD of an existing entry as a REPLACE, rather than failing it.")
+	idempotentDelete = flag.Bool("idempotent_delete", false, "Set when the DUT treats a DELETE of an entry that does not exist as successful, rather than failing it.")
+)
+
+const (
+	// unresolvedNHIP is a next hop address with no route on the DUT.
+	unresolvedNHIP   = "198.18.0.1"
+	unresolvedNH     = 3
+	unresolvedNHG    = 3
+	unresolvedPrefix = "198.51.100.0/25"
+	// missingPrefix, missingNHG and missingNH are never added.
+	missingPrefix = "198.51.100.128/25"
+	missingNHG    = 99
+	missingNH     = 99
+
+	// The dst entries are added by the cases, and the check entries after
+	// each case to verify that the session is still usable.
+	dstNH       = 1
+	dstNHG      = 1
+	dstPrefix   = "203.0.113.0/25"
+	checkNH     = 2
+	checkNHG    = 2
+	checkPrefix = "203.0.113.128/25"
+
+	awaitDuration = 2 * time.Minute
+	// fibSettleTime is how long an entry with an unresolved next hop must
+	// not be reported FIB_PROGRAMMED.
+	fibSettleTime = 30 * time.Second
+	pollInterval  = time.Second
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. FIB ACK with an unresolved next hop: an IPv4Entry referencing a
+//     NextHopGroup whose NextHop has no route is RIB_PROGRAMMED, and is
+//     neither FIB_PROGRAMMED nor FAILED.
+//  2. Duplicate ADD: a second ADD of an installed NextHop, NextHopGroup and
+//     IPv4Entry is FAILED, or succeeds as an implicit REPLACE with
+//     --implicit_replace.  The installed entries are not affected.
+//  3. DELETE of entries that do not exist: a DELETE of an IPv4Entry,
+//     NextHopGroup and NextHop that were never added is FAILED, or succeeds
+//     with --idempotent_delete.
+//  4. NextHopGroup referencing a missing NextHop: the NextHopGroup is FAILED
+//     and is not in the AFT.
+//
+// Each case is run with its own client, and is followed by a valid
+// ModifyRequest which must be installed.  Failures must be reported in the
+// AFTResult of each operation: the Modify stream must not fail.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - Only ate:port1 is used, to resolve the next hop of the valid entries.
+//   - With FIB ACK, an entry that is never FIB_PROGRAMMED stays pending in the
+//     client, so results are awaited per operation rather than with Await.
+
+// testArgs holds the objects needed by a test case.
+type testArgs struct {
+	c   *fluent.GRIBIClient
+	dut *ondatra.DUTDevice
+	// wantInstalled is the status of an entry that is installed.
+	wantInstalled spb.AFTResult_Status
+	// nextID is the ID of the next operation sent by c.
+	nextID uint64
+}
+
+// op is an AFT operation that was sent, with its ID.
+type op struct {
+	id   uint64
+	desc string
+}
+
+// send sends entries in one ModifyRequest, adding them if add is true and
+// deleting them otherwise, and returns the operations.
+func send(t *testing.T, args *testArgs, add bool, entries ...entry) []op {
+	t.Helper()
+	var ops []op
+	var fes []fluent.GRIBIEntry
+	for _, e := range entries {
+		ops = append(ops, op{id: args.nextID, desc: e.desc})
+		fes = append(fes, e.entry)
+		args.nextID++
+	}
+	if add {
+		args.c.Modify().AddEntry(t, fes...)
+	} else {
+		args.c.Modify().DeleteEntry(t, fes...)
+	}
+	return ops
+}
+
+// entry is a gRIBI entry with a description for test output.
+type entry struct {
+	desc  string
+	entry fluent.GRIBIEntry
+}
+
+func nhEntry(dut *ondatra.DUTDevice, index uint64, ip string) entry {
+	return entry{
+		desc: "NextHop " + ip,
+		entry: fluent.NextHopEntry().
+			WithNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+			WithIndex(index).
+			WithIPAddress(ip),
+	}
+}
+
+func nhgEntry(dut *ondatra.DUTDevice, id, nh uint64) entry {
+	return entry{
+		desc: "NextHopGroup",
+		entry: fluent.NextHopGroupEntry().
+			WithNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+			WithID(id).
+			AddNextHop(nh, 1),
+	}
+}
+
+func ipv4Entry(dut *ondatra.DUTDevice, prefix string, nhg uint64) entry {
+	return entry{
+		desc: "IPv4Entry " + prefix,
+		entry: fluent.IPv4Entry().
+			WithNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+			WithPrefix(prefix).
+			WithNextHopGroup(nhg),
+	}
+}
+
+// statuses returns the AFTResult statuses received for operation id.  A
+// Modify stream error fails the test.
+func statuses(t *testing.T, args *testArgs, id uint64) map[spb.AFTResult_Status]bool {
+	t.Helper()
+	st := args.c.Status(t)
+	if len(st.SendErrs) > 0 || len(st.ReadErrs) > 0 {
+		t.Fatalf("Modify stream failed, want per-operation results: send errors %v, receive errors %v", st.SendErrs, st.ReadErrs)
+	}
+	got := make(map[spb.AFTResult_Status]bool)
+	for _, r := range st.Results {
+		if r.OperationID == id {
+			got[r.ProgrammingResult] = true
+		}
+	}
+	return got
+}
+
+// awaitStatus waits until the result of o has a status in want, and returns
+// the statuses received.
+func awaitStatus(t *testing.T, args *testArgs, o op, want ...spb.AFTResult_Status) map[spb.AFTResult_Status]bool {
+	t.Helper()
+	deadline := time.Now().Add(awaitDuration)
+	for {
+		got := statuses(t, args, o.id)
+		for _, s := range want {
+			if got[s] {
+				return got
+			}
+		}
+		if time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// checkResults verifies that each of ops gets status want, and no other
+// final status.
+func checkResults(t *testing.T, args *testArgs, ops []op, want spb.AFTResult_Status) {
+	t.Helper()
+	for _, o := range ops {
+		got := awaitStatus(t, args, o, spb.AFTResult_FAILED, spb.AFTResult_FIB_FAILED, args.wantInstalled)
+		// A successful operation may have both RIB_PROGRAMMED and
+		// FIB_PROGRAMMED, but a failed one only FAILED.
+		if !got[want] || (want == spb.AFTResult_FAILED && len(got) > 1) {
+			t.Errorf("Operation %d on %s: got results %v, want %v", o.id, o.desc, got, want)
+		}
+	}
+}
+
+// addEntries adds an IPv4Entry resolved through ate:port1, and verifies that
+// it is installed.
+func addEntries(t *testing.T, args *testArgs, nh, nhg uint64, prefix string) {
+	t.Helper()
+	ops := send(t, args, true,
+		nhEntry(args.dut, nh, atePort1.IPv4),
+		nhgEntry(args.dut, nhg, nh),
+		ipv4Entry(args.dut, prefix, nhg),
+	)
+	checkResults(t, args, ops, args.wantInstalled)
+}
+
+// wantResult returns the status of an operation that succeeds if ok, and
+// fails otherwise.
+func wantResult(args *testArgs, ok bool) spb.AFTResult_Status {
+	if ok {
+		return args.wantInstalled
+	}
+	return spb.AFTResult_FAILED
+}
+
+// testUnresolvedNextHop adds an IPv4Entry whose NextHop has no route.
+func testUnresolvedNextHop(t *testing.T, args *testArgs) {
+	if args.wantInstalled != spb.AFTResult_FIB_PROGRAMMED {
+		t.Skip("FIB ACK is not supported by the DUT")
+	}
+	ops := send(t, args, true,
+		nhEntry(args.dut, unresolvedNH, unresolvedNHIP),
+		nhgEntry(args.dut, unresolvedNHG, unresolvedNH),
+		ipv4Entry(args.dut, unresolvedPrefix, unresolvedNHG),
+	)
+	prefix := ops[2]
+	if got := awaitStatus(t, args, prefix, spb.AFTResult_RIB_PROGRAMMED); !got[spb.AFTResult_RIB_PROGRAMMED] {
+		t.Fatalf("Operation %d on %s: got results %v, want %v", prefix.id, prefix.desc, got, spb.AFTResult_RIB_PROGRAMMED)
+	}
+	time.Sleep(fibSettleTime)
+	got := statuses(t, args, prefix.id)
+	t.Logf("Results of %s with unresolved next hop: %v", prefix.desc, got)
+	for _, s := range []spb.AFTResult_Status{spb.AFTResult_FIB_PROGRAMMED, spb.AFTResult_FAILED} {
+		if got[s] {
+			t.Errorf("Operation %d on %s with unresolved next hop: got results %v, want no %v", prefix.id, prefix.desc, got, s)
+		}
+	}
+}
+
+// testDuplicateAdd adds the same entries twice.
+func testDuplicateAdd(t *testing.T, args *testArgs) {
+	addEntries(t, args, dstNH, dstNHG, dstPrefix)
+	ops := send(t, args, true,
+		nhEntry(args.dut, dstNH, atePort1.IPv4),
+		nhgEntry(args.dut, dstNHG, dstNH),
+		ipv4Entry(args.dut, dstPrefix, dstNHG),
+	)
+	checkResults(t, args, ops, wantResult(args, *implicitReplace))
+
+	ipv4Path := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(args.dut)).Afts().Ipv4Entry(dstPrefix)
+	if !gnmi.Lookup(t, args.dut, ipv4Path.State()).IsPresent() {
+		t.Errorf("IPv4Entry %s is not in the AFT after a duplicate ADD", dstPrefix)
+	}
+}
+
+// testDeleteMissing deletes entries that were never added.
+func testDeleteMissing(t *testing.T, args *testArgs) {
+	ops := send(t, args, false,
+		ipv4Entry(args.dut, missingPrefix, missingNHG),
+		nhgEntry(args.dut, missingNHG, missingNH),
+		nhEntry(args.dut, missingNH, unresolvedNHIP),
+	)
+	checkResults(t, args, ops, wantResult(args, *idempotentDelete))
+}
+
+// testNHGMissingNH adds a NextHopGroup referencing a NextHop that does not
+// exist.
+func testNHGMissingNH(t *testing.T, args *testArgs) {
+	ops := send(t, args, true, nhgEntry(args.dut, missingNHG, missingNH))
+	checkResults(t, args, ops, spb.AFTResult_FAILED)
+
+	for _, v := range gnmi.LookupAll(t, args.dut, gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(args.dut)).Afts().NextHopGroupAny().State()) {
+		if nhg, ok := v.Val(); ok && nhg.GetProgrammedId() == missingNHG {
+			t.Errorf("NextHopGroup %d referencing missing NextHop %d is in the AFT", missingNHG, missingNH)
+		}
+	}
+}
+
+var cases = []struct {
+	name string
+	desc string
+	fn   func(t *testing.T, args *testArgs)
+}{
+	{
+		name: "FIB ACK unresolved next hop",
+		desc: "An IPv4Entry referencing a NextHopGroup whose NextHop has no route is RIB_PROGRAMMED and not FIB_PROGRAMMED.",
+		fn:   testUnresolvedNextHop,
+	},
+	{
+		name: "Duplicate ADD",
+		desc: "A second ADD of installed entries is FAILED, or is an implicit REPLACE.",
+		fn:   testDuplicateAdd,
+	},
+	{
+		name: "DELETE missing entries",
+		desc: "A DELETE of entries that do not exist is FAILED, or is idempotent.",
+		fn:   testDeleteMissing,
+	},
+	{
+		name: "NHG referencing missing NH",
+		desc: "A NextHopGroup referencing a NextHop that does not exist is FAILED.",
+		fn:   testNHGMissingNH,
+	},
+}
+
+func TestErrorPath(t *testing.T) {
+	ctx := context.Background()
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+
+	p1 := dut.Port(t, "port1")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+
+	gribic := dut.RawAPIs().GRIBI(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Description: %s", tc.desc)
+
+			c := fluent.NewClient()
+			conn := c.Connection().
+				WithStub(gribic).WithPersistence().
+				WithRedundancyMode(fluent.ElectedPrimaryClient).
+				WithInitialElectionID(1 /* low */, 0 /* hi */)
+			args := &testArgs{c: c, dut: dut, wantInstalled: spb.AFTResult_RIB_PROGRAMMED, nextID: 1}
+			if !deviations.GRIBIRIBAckOnly(dut) {
+				conn.WithFIBACK()
+				args.wantInstalled = spb.AFTResult_FIB_PROGRAMMED
+			}
+			c.Start(ctx, t)
+			defer c.Stop(t)
+			c.StartSending(ctx, t)
+			subctx, cancel := context.WithTimeout(ctx, awaitDuration)
+			defer cancel()
+			if err := c.Await(subctx, t); err != nil {
+				t.Fatalf("Await got error during session negotiation: %v", err)
+			}
+			gribi.BecomeLeader(t, c)
+			defer func() {
+				if err := gribi.FlushAll(c); err != nil {
+					t.Errorf("Cannot flush: %v", err)
+				}
+			}()
+
+			tc.fn(t, args)
+			t.Run("Session usable", func(t *testing.T) {
+				addEntries(t, args, checkNH, checkNHG, checkPrefix)
+			})
+		})
+	}
+}
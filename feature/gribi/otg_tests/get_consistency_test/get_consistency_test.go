@@ -0,0 +1,394 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get_consistency_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+
+	spb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+var (
+	entryCount    = flag.Int("entries", 500000, "Number of gRIBI IPv4 entries programmed before the Get requests.")
+	batchSize     = flag.Int("batch_size", 1000, "Number of IPv4 entries sent per ModifyRequest batch.")
+	churnCount    = flag.Int("churn_entries", 1000, "Number of IPv4 entries added and deleted in each modification cycle while the Get requests stream.")
+	getIterations = flag.Int("get_iterations", 3, "Number of Get requests issued while the entries are modified.")
+	maxGetTime    = flag.Duration("max_get_time", 5*time.Minute, "Maximum time for a Get request to stream all entries.")
+)
+
+const (
+	baseNH     = 1
+	baseNHG    = 1
+	churnNH    = 2
+	churnNHG   = 2
+	churnBase  = uint32(198<<24 | 18<<16) // 198.18.0.0
+	prefixBase = uint32(100<<24 | 64<<16) // 100.64.0.0
+
+	installWait   = 30 * time.Minute
+	churnWait     = 5 * time.Minute
+	electionIDLow = 12
+)
+
+// basePrefixes is the block of the entries programmed before the Get
+// requests, which must be returned by every Get.
+var basePrefixes = netip.MustParsePrefix("100.64.0.0/11")
+
+// churnPrefixes is the block of the entries added and deleted while the Get
+// requests stream.
+var churnPrefixes = netip.MustParsePrefix("198.18.0.0/15")
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Program a next-hop towards ATE port-2, a next-hop-group and --entries
+//     IPv4 /32 entries in the default network instance, with FIB ACK.
+//  2. Start modifying the AFTs from the same client: in each cycle, add a
+//     next-hop, a next-hop-group referencing it and --churn_entries IPv4
+//     entries referencing the next-hop-group, then delete them in reverse
+//     order.
+//  3. While the modifications run, issue --get_iterations Get requests for
+//     all AFTs of all network instances from a second connection, and for
+//     each of them verify:
+//     - the response completes within --max_get_time,
+//     - no entry is returned twice across the response messages,
+//     - every IPv4 entry references a next-hop-group, and every
+//     next-hop-group references next-hops, that are in the same response,
+//     - all --entries programmed IPv4 entries are returned.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	return top
+}
+
+// prefixAddr returns the i-th address after base.
+func prefixAddr(base uint32, i int) string {
+	a := base + uint32(i)
+	return fmt.Sprintf("%d.%d.%d.%d", byte(a>>24), byte(a>>16), byte(a>>8), byte(a))
+}
+
+// ipv4Entries returns the IPv4 /32 entries for n addresses after base,
+// referencing next-hop-group nhg.
+func ipv4Entries(ni string, base uint32, first, n int, nhg uint64) []fluent.GRIBIEntry {
+	var entries []fluent.GRIBIEntry
+	for i := first; i < first+n; i++ {
+		entries = append(entries, fluent.IPv4Entry().
+			WithNetworkInstance(ni).
+			WithPrefix(prefixAddr(base, i)+"/32").
+			WithNextHopGroup(nhg).
+			WithNextHopGroupNetworkInstance(ni))
+	}
+	return entries
+}
+
+// programEntries sends the base next-hop, next-hop-group and IPv4 entries and
+// waits for all of them to be acknowledged.
+func programEntries(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, client *fluent.GRIBIClient) {
+	t.Helper()
+	ni := deviations.DefaultNetworkInstance(dut)
+	client.Modify().AddEntry(t,
+		fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(baseNH).WithIPAddress(atePort2.IPv4),
+		fluent.NextHopGroupEntry().WithNetworkInstance(ni).WithID(baseNHG).AddNextHop(baseNH, 1),
+	)
+	for i := 0; i < *entryCount; i += *batchSize {
+		client.Modify().AddEntry(t, ipv4Entries(ni, prefixBase, i, min(*batchSize, *entryCount-i), baseNHG)...)
+	}
+	awaitCtx, cancel := context.WithTimeout(ctx, installWait)
+	defer cancel()
+	if err := client.Await(awaitCtx, t); err != nil {
+		t.Fatalf("Await got error while programming %d entries: %v", *entryCount, err)
+	}
+}
+
+// churn adds and deletes the churn entries in cycles until ctx is done.  It
+// closes started once the first cycle completed, and returns the number of
+// completed cycles, or an error if a cycle was not acknowledged in time.
+func churn(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, client *fluent.GRIBIClient, started chan<- struct{}) (int, error) {
+	ni := deviations.DefaultNetworkInstance(dut)
+	nh := fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(churnNH).WithIPAddress(atePort2.IPv4)
+	nhg := fluent.NextHopGroupEntry().WithNetworkInstance(ni).WithID(churnNHG).AddNextHop(churnNH, 1)
+	entries := ipv4Entries(ni, churnBase, 0, *churnCount, churnNHG)
+	for cycles := 0; ; cycles++ {
+		if cycles == 1 {
+			close(started)
+		}
+		if ctx.Err() != nil {
+			return cycles, nil
+		}
+		client.Modify().AddEntry(t, nh, nhg)
+		client.Modify().AddEntry(t, entries...)
+		client.Modify().DeleteEntry(t, entries...)
+		client.Modify().DeleteEntry(t, nhg, nh)
+		awaitCtx, cancel := context.WithTimeout(context.Background(), churnWait)
+		err := client.Await(awaitCtx, t)
+		cancel()
+		if err != nil {
+			return cycles, fmt.Errorf("modification cycle %d not acknowledged: %v", cycles, err)
+		}
+	}
+}
+
+// aftKey identifies an entry returned by Get.
+type aftKey struct {
+	ni, kind, id string
+}
+
+// snapshot is the content of the AFTs returned by a Get.
+type snapshot struct {
+	messages int
+	entries  map[aftKey]*spb.AFTEntry
+	dups     []aftKey
+}
+
+// key returns the key of e.
+func key(e *spb.AFTEntry) aftKey {
+	ni := e.GetNetworkInstance()
+	switch {
+	case e.GetIpv4() != nil:
+		return aftKey{ni, "ipv4", e.GetIpv4().GetPrefix()}
+	case e.GetNextHopGroup() != nil:
+		return aftKey{ni, "nhg", fmt.Sprint(e.GetNextHopGroup().GetId())}
+	case e.GetNextHop() != nil:
+		return aftKey{ni, "nh", fmt.Sprint(e.GetNextHop().GetIndex())}
+	}
+	return aftKey{ni, "other", e.String()}
+}
+
+// get streams all AFT entries of all network instances with a single Get
+// request and returns them with the time it took.
+func get(ctx context.Context, c spb.GRIBIClient) (*snapshot, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, *maxGetTime)
+	defer cancel()
+	start := time.Now()
+	stream, err := c.Get(ctx, &spb.GetRequest{
+		NetworkInstance: &spb.GetRequest_All{All: &spb.Empty{}},
+		Aft:             spb.AFTType_ALL,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	s := &snapshot{entries: map[aftKey]*spb.AFTEntry{}}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return s, time.Since(start), nil
+		}
+		if err != nil {
+			return nil, time.Since(start), err
+		}
+		s.messages++
+		for _, e := range resp.GetEntry() {
+			k := key(e)
+			if _, ok := s.entries[k]; ok {
+				s.dups = append(s.dups, k)
+			}
+			s.entries[k] = e
+		}
+	}
+}
+
+// danglingRefs returns a description of every reference of an IPv4 entry to a
+// next-hop-group, or of a next-hop-group to a next-hop, that is not in s.
+func (s *snapshot) danglingRefs() []string {
+	var refs []string
+	for k, e := range s.entries {
+		switch k.kind {
+		case "ipv4":
+			v4 := e.GetIpv4().GetIpv4Entry()
+			if v4.GetNextHopGroup() == nil {
+				continue
+			}
+			ni := k.ni
+			if v := v4.GetNextHopGroupNetworkInstance(); v != nil {
+				ni = v.GetValue()
+			}
+			nhg := aftKey{ni, "nhg", fmt.Sprint(v4.GetNextHopGroup().GetValue())}
+			if _, ok := s.entries[nhg]; !ok {
+				refs = append(refs, fmt.Sprintf("IPv4 entry %s/%s references missing next-hop-group %s/%s", k.ni, k.id, nhg.ni, nhg.id))
+			}
+		case "nhg":
+			for _, nh := range e.GetNextHopGroup().GetNextHopGroup().GetNextHop() {
+				nhk := aftKey{k.ni, "nh", fmt.Sprint(nh.GetIndex())}
+				if _, ok := s.entries[nhk]; !ok {
+					refs = append(refs, fmt.Sprintf("next-hop-group %s/%s references missing next-hop %s/%s", k.ni, k.id, nhk.ni, nhk.id))
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// baseCount returns the number of IPv4 entries of s in basePrefixes.
+func (s *snapshot) baseCount() int {
+	n := 0
+	for k := range s.entries {
+		if k.kind != "ipv4" {
+			continue
+		}
+		if p, err := netip.ParsePrefix(k.id); err == nil && basePrefixes.Contains(p.Addr()) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestGetConsistency(t *testing.T) {
+	if size := 1 << (32 - basePrefixes.Bits()); *entryCount > size {
+		t.Fatalf("--entries is %d, want at most %d to fit in %v", *entryCount, size, basePrefixes)
+	}
+	if size := 1 << (32 - churnPrefixes.Bits()); *churnCount > size {
+		t.Fatalf("--churn_entries is %d, want at most %d to fit in %v", *churnCount, size, churnPrefixes)
+	}
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	ctx := context.Background()
+
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	client := fluent.NewClient()
+	client.Connection().WithStub(dut.RawAPIs().GRIBI(t)).WithPersistence().WithInitialElectionID(electionIDLow, 0).
+		WithRedundancyMode(fluent.ElectedPrimaryClient).WithFIBACK()
+	client.Start(ctx, t)
+	defer client.Stop(t)
+	client.StartSending(ctx, t)
+	gribi.BecomeLeader(t, client)
+	defer func() {
+		if err := gribi.FlushAll(client); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Logf("Programming %d IPv4 entries in batches of %d", *entryCount, *batchSize)
+	programEntries(ctx, t, dut, client)
+
+	churnCtx, stopChurn := context.WithCancel(ctx)
+	defer stopChurn()
+	started := make(chan struct{})
+	var (
+		wg       sync.WaitGroup
+		cycles   int
+		churnErr error
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cycles, churnErr = churn(churnCtx, t, dut, client, started)
+	}()
+	select {
+	case <-started:
+	case <-time.After(churnWait):
+		t.Fatalf("First modification cycle did not complete within %v", churnWait)
+	}
+
+	getClient := dut.RawAPIs().GRIBI(t)
+	for i := 1; i <= *getIterations; i++ {
+		t.Run(fmt.Sprintf("Get %d", i), func(t *testing.T) {
+			s, elapsed, err := get(ctx, getClient)
+			if err != nil {
+				t.Fatalf("Get failed after %v: %v", elapsed, err)
+			}
+			t.Logf("Get returned %d entries in %d messages in %v", len(s.entries), s.messages, elapsed)
+			if elapsed > *maxGetTime {
+				t.Errorf("Get took %v, want <= %v", elapsed, *maxGetTime)
+			}
+			if len(s.dups) > 0 {
+				t.Errorf("Get returned %d entries more than once, first %v", len(s.dups), s.dups[0])
+			}
+			if refs := s.danglingRefs(); len(refs) > 0 {
+				t.Errorf("Get returned inconsistent AFTs with %d dangling references, first: %s", len(refs), refs[0])
+			}
+			if got := s.baseCount(); got != *entryCount {
+				t.Errorf("Get returned %d IPv4 entries in %v, want %d", got, basePrefixes, *entryCount)
+			}
+		})
+	}
+
+	stopChurn()
+	wg.Wait()
+	t.Logf("Completed %d modification cycles of %d IPv4 entries during the Get requests", cycles, *churnCount)
+	if churnErr != nil {
+		t.Errorf("Modifications failed: %v", churnErr)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader_election_flap_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	nhIndex  = 1
+	nhgIndex = 42
+	// rounds is the number of times leadership changes between the clients.
+	rounds = 40
+	// reconnectEvery is how often, in rounds, the client losing leadership
+	// disconnects and reconnects.
+	reconnectEvery = 5
+	flowName       = "flow"
+	pps            = 1000
+	flowPackets    = 10000
+	lossTol        = 1.0
+	connTimeout    = 2 * time.Minute
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "port1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "port2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Connect gRIBI clients A and B in ELECTED_PRIMARY mode with
+//     persistence and FIB ACK.
+//  2. For every round, alternate leadership between the clients by having
+//     the next leader learn the current election ID and increase it.  The
+//     leader programs a /32 from its own prefix range, which must be
+//     installed in the FIB.  The other client then programs a /32 from a
+//     third range, which must fail.  Every few rounds, the client losing
+//     leadership disconnects and reconnects before trying to program.
+//  3. Verify with gRIBI Get and AFT telemetry that every prefix programmed by
+//     a leader is present exactly once, and no prefix programmed by a
+//     follower is present.
+//  4. Verify traffic to every programmed prefix is forwarded to ATE port-2.
+//  5. Disconnect both clients and verify the number of connections to the
+//     gRIBI server returns to the number before the test.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - Clients A and B program disjoint ranges, so a lost or duplicated entry
+//     can be attributed to the round and client that programmed it.
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	return top
+}
+
+// prefix returns the /32 programmed in round r from the range of client c,
+// where client 2 is the range for rejected operations.
+func prefix(c, r int) string {
+	return fmt.Sprintf("198.18.%d.%d/32", c, r+1)
+}
+
+// gribiConnections returns the number of connections to the gRPC servers
+// running the gRIBI service.
+func gribiConnections(t *testing.T, dut *ondatra.DUTDevice) int {
+	t.Helper()
+	n := 0
+	for _, s := range gnmi.GetAll(t, dut, gnmi.OC().System().GrpcServerAny().State()) {
+		for _, svc := range s.Services {
+			if svc == oc.SystemGrpc_GRPC_SERVICE_GRIBI {
+				n += len(s.Connection)
+				break
+			}
+		}
+	}
+	return n
+}
+
+func startClient(t *testing.T, dut *ondatra.DUTDevice) *gribi.Client {
+	t.Helper()
+	c := &gribi.Client{
+		DUT:         dut,
+		FIBACK:      true,
+		Persistence: true,
+	}
+	if err := c.Start(t); err != nil {
+		t.Fatalf("gRIBI connection could not be established: %v", err)
+	}
+	return c
+}
+
+// verifyEntries checks that the gRIBI RIB has every prefix in want exactly
+// once and no prefix in unwanted, and that every prefix in want is in the
+// AFT.
+func verifyEntries(t *testing.T, dut *ondatra.DUTDevice, c *gribi.Client, want, unwanted []string) {
+	t.Helper()
+	ni := deviations.DefaultNetworkInstance(dut)
+	resp, err := c.Fluent(t).Get().WithNetworkInstance(ni).WithAFT(fluent.IPv4).Send()
+	if err != nil {
+		t.Fatalf("gRIBI Get failed: %v", err)
+	}
+	got := make(map[string]int)
+	for _, e := range resp.GetEntry() {
+		got[e.GetIpv4().GetPrefix()]++
+	}
+	for _, p := range want {
+		if got[p] != 1 {
+			t.Errorf("gRIBI Get entries for %s: got %d, want 1", p, got[p])
+		}
+	}
+	for _, p := range unwanted {
+		if got[p] != 0 {
+			t.Errorf("gRIBI Get entries for %s programmed by a follower: got %d, want 0", p, got[p])
+		}
+	}
+
+	afts := gnmi.OC().NetworkInstance(ni).Afts()
+	for _, p := range want {
+		_, ok := gnmi.Watch(t, dut, afts.Ipv4Entry(p).State(), time.Minute, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			e, ok := v.Val()
+			return ok && e.GetNextHopGroup() != 0
+		}).Await(t)
+		if !ok {
+			t.Errorf("AFT entry for %s not found", p)
+		}
+	}
+	for _, p := range unwanted {
+		if _, ok := gnmi.Lookup(t, dut, afts.Ipv4Entry(p).State()).Val(); ok {
+			t.Errorf("AFT entry for %s programmed by a follower found", p)
+		}
+	}
+}
+
+// verifyTraffic sends traffic from ATE port-1 to every prefix in dsts and
+// checks it is received on ATE port-2.
+func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, dsts []string) {
+	t.Helper()
+	var addrs []string
+	for _, p := range dsts {
+		addrs = append(addrs, p[:len(p)-len("/32")])
+	}
+	top.Flows().Clear()
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4"})
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValues(addrs)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPackets)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	if loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 10*time.Second); loss > lossTol {
+		t.Errorf("Flow %s loss: got %.2f%%, want <= %.2f%%", flowName, loss, lossTol)
+	}
+}
+
+func TestLeaderElectionFlap(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+	ni := deviations.DefaultNetworkInstance(dut)
+
+	baseConns := gribiConnections(t, dut)
+	t.Logf("Connections to the gRIBI server before the test: %d", baseConns)
+
+	clients := []*gribi.Client{startClient(t, dut), startClient(t, dut)}
+	defer func() {
+		for _, c := range clients {
+			c.Close(t)
+		}
+	}()
+	clients[0].BecomeLeader(t)
+	clients[0].FlushAll(t)
+	clients[0].AddNH(t, nhIndex, atePort2.IPv4, ni, fluent.InstalledInFIB)
+	clients[0].AddNHG(t, nhgIndex, map[uint64]uint64{nhIndex: 1}, ni, fluent.InstalledInFIB)
+
+	var programmed, rejected []string
+	t.Run("Flap", func(t *testing.T) {
+		var last gribi.Uint128
+		for r := 0; r < rounds; r++ {
+			leader, follower := r%2, (r+1)%2
+			eid := clients[leader].BecomeLeader(t)
+			if last != (gribi.Uint128{}) && (eid.High < last.High || eid.High == last.High && eid.Low <= last.Low) {
+				t.Fatalf("Round %d: election ID %v is not higher than the previous %v", r, eid, last)
+			}
+			last = eid
+
+			p := prefix(leader, r)
+			clients[leader].AddIPv4(t, p, nhgIndex, ni, "", fluent.InstalledInFIB)
+			programmed = append(programmed, p)
+
+			if r%reconnectEvery == reconnectEvery-1 {
+				t.Logf("Round %d: reconnecting client %d", r, follower)
+				clients[follower].Close(t)
+				clients[follower] = startClient(t, dut)
+			}
+			p = prefix(2, r)
+			clients[follower].AddIPv4(t, p, nhgIndex, ni, "", fluent.ProgrammingFailed)
+			rejected = append(rejected, p)
+		}
+	})
+
+	t.Run("Entries", func(t *testing.T) {
+		verifyEntries(t, dut, clients[(rounds-1)%2], programmed, rejected)
+	})
+
+	t.Run("Traffic", func(t *testing.T) {
+		verifyTraffic(t, ate, top, programmed)
+	})
+
+	t.Run("ClientState", func(t *testing.T) {
+		clients[(rounds-1)%2].FlushAll(t)
+		for _, c := range clients {
+			c.Close(t)
+		}
+		var got int
+		for start := time.Now(); time.Since(start) < connTimeout; time.Sleep(10 * time.Second) {
+			if got = gribiConnections(t, dut); got <= baseConns {
+				return
+			}
+		}
+		t.Errorf("Connections to the gRIBI server after disconnecting: got %d, want <= %d", got, baseConns)
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lpm_correctness_test
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/lpmref"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var (
+	seed        = flag.Int64("seed", 0, "Seed for the random prefixes. A seed based on the current time is used when 0.")
+	prefixCount = flag.Int("prefixes", 32, "Number of random prefixes to program.")
+)
+
+const (
+	// coveringPrefix contains every random prefix, and is routed to port4 by
+	// a static route so that every destination has a match.
+	coveringPrefix = "198.18.0.0/15"
+	minLen         = 16
+	maxLen         = 32
+	pps            = 100
+	flowPackets    = 500
+	lossTol        = 1.0
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "port2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30}
+	atePort3 = attrs.Attributes{Name: "port3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30}
+	dutPort4 = attrs.Attributes{Desc: "dutPort4", IPv4: "192.0.2.13", IPv4Len: 30}
+	atePort4 = attrs.Attributes{Name: "port4", MAC: "02:00:04:01:01:01", IPv4: "192.0.2.14", IPv4Len: 30}
+
+	// egress are the ATE ports routes point to.  The gRIBI next hop and next
+	// hop group for egress[i] both have index i+1.
+	egress = []*attrs.Attributes{&atePort2, &atePort3, &atePort4}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Generate --prefixes random overlapping prefixes with lengths from /16
+//     to /32 inside 198.18.0.0/15, each pointing to a random one of ATE
+//     port-2, port-3 and port-4.  Add a static route for 198.18.0.0/15 to
+//     port-4.
+//  2. Program each random prefix with gRIBI or a static route, chosen at
+//     random.
+//  3. Add every route to a reference longest prefix match table, and
+//     generate the first and last address of every prefix and the addresses
+//     just outside it.
+//  4. For every address in 198.18.0.0/15, send a flow from ATE port-1 to the
+//     port of the longest matching route in the reference table, and verify
+//     it is received there.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                    dut:port3 <--> ate:port3
+//	                    dut:port4 <--> ate:port4
+//
+// Test notes:
+//   - The seed is logged so that a failure can be reproduced with --seed.
+//   - Each flow only receives on its expected port, so packets forwarded by a
+//     shorter or a different prefix are counted as loss on that flow.
+
+// route is a random prefix and the egress it points to.
+type route struct {
+	prefix netip.Prefix
+	egress int
+	gribi  bool
+}
+
+func randomRoutes(r *rand.Rand, n int) []route {
+	cover := netip.MustParsePrefix(coveringPrefix)
+	seen := map[netip.Prefix]bool{cover: true}
+	var routes []route
+	for len(routes) < n {
+		b := cover.Addr().As4()
+		b[1] |= byte(r.Intn(2))
+		b[2], b[3] = byte(r.Intn(256)), byte(r.Intn(256))
+		p := netip.PrefixFrom(netip.AddrFrom4(b), minLen+r.Intn(maxLen-minLen+1)).Masked()
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		routes = append(routes, route{prefix: p, egress: r.Intn(len(egress)), gribi: r.Intn(2) == 0})
+	}
+	return routes
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for i, a := range []*attrs.Attributes{&dutPort1, &dutPort2, &dutPort3, &dutPort4} {
+		p := dut.Port(t, fmt.Sprintf("port%d", i+1))
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+}
+
+func configureStatic(t *testing.T, dut *ondatra.DUTDevice, routes []route) {
+	t.Helper()
+	b := &gnmi.SetBatch{}
+	for _, r := range routes {
+		if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+			NetworkInstance: deviations.DefaultNetworkInstance(dut),
+			Prefix:          r.prefix.String(),
+			NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+				"0": oc.UnionString(egress[r.egress].IPv4),
+			},
+		}, dut); err != nil {
+			t.Fatalf("Failed to configure static route %v: %v", r.prefix, err)
+		}
+	}
+	b.Set(t, dut)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	atePort4.AddToOTG(top, ate.Port(t, "port4"), &dutPort4)
+	return top
+}
+
+func TestLongestPrefixMatch(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	t.Logf("Random seed: %d", *seed)
+	r := rand.New(rand.NewSource(*seed))
+
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	routes := append(randomRoutes(r, *prefixCount), route{
+		prefix: netip.MustParsePrefix(coveringPrefix),
+		egress: len(egress) - 1,
+	})
+	ref := &lpmref.Table[route]{}
+	var static, programmed []route
+	for _, rt := range routes {
+		ref.Insert(rt.prefix, rt)
+		if rt.gribi {
+			programmed = append(programmed, rt)
+		} else {
+			static = append(static, rt)
+		}
+		t.Logf("Route %v via %s (gRIBI: %v)", rt.prefix, egress[rt.egress].Name, rt.gribi)
+	}
+
+	configureStatic(t, dut, static)
+	ni := deviations.DefaultNetworkInstance(dut)
+	client := &gribi.Client{
+		DUT:         dut,
+		FIBACK:      true,
+		Persistence: true,
+	}
+	if err := client.Start(t); err != nil {
+		t.Fatalf("gRIBI connection could not be established: %v", err)
+	}
+	defer client.Close(t)
+	client.BecomeLeader(t)
+	client.FlushAll(t)
+	defer client.FlushAll(t)
+	for i, e := range egress {
+		client.AddNH(t, uint64(i+1), e.IPv4, ni, fluent.InstalledInFIB)
+		client.AddNHG(t, uint64(i+1), map[uint64]uint64{uint64(i + 1): 1}, ni, fluent.InstalledInFIB)
+	}
+	for _, rt := range programmed {
+		client.AddIPv4(t, rt.prefix.String(), uint64(rt.egress+1), ni, "", fluent.InstalledInFIB)
+	}
+
+	afts := gnmi.OC().NetworkInstance(ni).Afts()
+	for _, rt := range static {
+		_, ok := gnmi.Watch(t, dut, afts.Ipv4Entry(rt.prefix.String()).State(), time.Minute, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			return v.IsPresent()
+		}).Await(t)
+		if !ok {
+			t.Fatalf("AFT entry for static route %v not found", rt.prefix)
+		}
+	}
+
+	cover := netip.MustParsePrefix(coveringPrefix)
+	want := make(map[string]route)
+	top.Flows().Clear()
+	for _, a := range lpmref.Boundaries(ref.Prefixes()) {
+		if !cover.Contains(a) {
+			continue
+		}
+		_, rt, _ := ref.Lookup(a)
+		name := fmt.Sprintf("to-%s", a)
+		want[name] = rt
+		flow := top.Flows().Add().SetName(name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{egress[rt.egress].Name + ".IPv4"})
+		flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(atePort1.IPv4)
+		v4.Dst().SetValue(a.String())
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+	t.Logf("Sending %d flows", len(want))
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+	for _, f := range top.Flows().Items() {
+		rt := want[f.Name()]
+		if loss := otgutils.GetFlowLossPct(t, ate.OTG(), f.Name(), 10*time.Second); loss > lossTol {
+			t.Errorf("Flow %s: got %.2f%% loss, want <= %.2f%% on %s, the port of the longest match %v", f.Name(), loss, lossTol, egress[rt.egress].Name, rt.prefix)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reboot_during_programming_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gnoigo"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+	gpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+var (
+	entryCount      = flag.Int("entries", 10000, "Number of gRIBI IPv4 entries to program.")
+	batchSize       = flag.Int("batch_size", 100, "Number of IPv4 entries sent per ModifyRequest batch.")
+	rebootComponent = flag.String("reboot_component", "", "Name of the subcomponent to reboot while programming. If empty, the standby controller card is used, or a removable linecard if there is no standby controller card.")
+)
+
+const (
+	nhIndex    = 1
+	nhgIndex   = 1
+	prefixBase = uint32(198<<24 | 18<<16) // 198.18.0.0
+	// maxEntries is the number of /32 prefixes in 198.18.0.0/15.
+	maxEntries    = 1 << 17
+	trafficPPS    = 1000
+	flowName      = "ackedPrefixes"
+	installWait   = 30 * time.Minute
+	electionIDLow = 12
+	// rebootAfter is the fraction of the batches sent before the reboot is
+	// issued.
+	rebootAfter = 0.25
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Program one next-hop towards ATE port-2 and one next-hop-group with
+//     FIB ACK.
+//  2. Send --entries IPv4 /32 entries in batches of --batch_size, and after a
+//     quarter of the batches issue a gNOI System.Reboot of a subcomponent.
+//  3. Verify every IPv4 operation received exactly one of FIB_PROGRAMMED,
+//     FAILED or FIB_FAILED, so no operation was silently lost.
+//  4. Wait for the subcomponent to recover.
+//  5. Verify the gRIBI Get response of a new client contains exactly the
+//     acknowledged entries, as FIB programmed.
+//  6. Send traffic to the first and last acknowledged prefix and verify there
+//     is no loss.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	return top
+}
+
+// addFlow adds a flow from ATE port-1 to dsts, received on ATE port-2.
+func addFlow(top gosnappi.Config, dsts []string) {
+	top.Flows().Clear()
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{atePort1.Name + ".IPv4"}).
+		SetRxNames([]string{atePort2.Name + ".IPv4"})
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(trafficPPS)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValues(dsts)
+}
+
+// prefix returns the i-th programmed /32 prefix.
+func prefix(i int) string {
+	a := prefixBase + uint32(i)
+	return fmt.Sprintf("%d.%d.%d.%d/32", byte(a>>24), byte(a>>16), byte(a>>8), byte(a))
+}
+
+// selectRebootComponent returns the subcomponent to reboot and whether it is
+// a controller card.
+func selectRebootComponent(t *testing.T, dut *ondatra.DUTDevice) (string, bool) {
+	t.Helper()
+	if *rebootComponent != "" {
+		typ := gnmi.Lookup(t, dut, gnmi.OC().Component(*rebootComponent).Type().State())
+		v, _ := typ.Val()
+		return *rebootComponent, v == oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
+	}
+	controllers := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD)
+	if len(controllers) >= 2 {
+		standby, _ := components.FindStandbyRP(t, dut, controllers)
+		return standby, true
+	}
+	for _, lc := range components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_LINECARD) {
+		if empty, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(lc).Empty().State()).Val(); ok && empty {
+			continue
+		}
+		if removable, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(lc).Removable().State()).Val(); ok && removable {
+			return lc, false
+		}
+	}
+	t.Skipf("No standby controller card or removable linecard found on %v", dut.Model())
+	return "", false
+}
+
+// rebootSubcomponent issues a cold reboot of the named subcomponent.
+func rebootSubcomponent(gnoiClient gnoigo.Clients, dut *ondatra.DUTDevice, name string) error {
+	return components.WithSubcomponentPath(dut, name, func(p *tpb.Path) error {
+		req := &spb.RebootRequest{
+			Method:        spb.RebootMethod_COLD,
+			Message:       fmt.Sprintf("Reboot %s during gRIBI programming", name),
+			Subcomponents: []*tpb.Path{p},
+		}
+		_, err := gnoiClient.System().Reboot(context.Background(), req)
+		return err
+	})
+}
+
+// awaitRecovery waits for the rebooted subcomponent to come back.
+func awaitRecovery(t *testing.T, dut *ondatra.DUTDevice, name string, controller bool) {
+	t.Helper()
+	timeout := args.LinecardBootTimeout()
+	if controller {
+		watch := gnmi.Watch(t, dut, gnmi.OC().Component(name).RedundantRole().State(), timeout, func(val *ygnmi.Value[oc.E_Platform_ComponentRedundantRole]) bool {
+			return val.IsPresent()
+		})
+		if val, ok := watch.Await(t); !ok {
+			t.Fatalf("Controller card %s did not recover within %v: got %v", name, timeout, val)
+		}
+		return
+	}
+	gnmi.Await(t, dut, gnmi.OC().Component(name).OperStatus().State(), timeout, oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE)
+}
+
+// programWithReboot sends the IPv4 entries in batches and issues the reboot
+// of name after rebootAfter of the batches.  It returns the error of the
+// reboot RPC and of waiting for the acknowledgements.
+func programWithReboot(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, client *fluent.GRIBIClient, name string) (rebootErr, awaitErr error) {
+	t.Helper()
+	ni := deviations.DefaultNetworkInstance(dut)
+	rebootAt := int(float64(*entryCount)*rebootAfter) / *batchSize * *batchSize
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	rebootDone := make(chan error, 1)
+	for i := 0; i < *entryCount; i += *batchSize {
+		if i == rebootAt {
+			t.Logf("Rebooting %s after sending %d entries", name, i)
+			go func() { rebootDone <- rebootSubcomponent(gnoiClient, dut, name) }()
+		}
+		var batch []fluent.GRIBIEntry
+		for j := i; j < i+*batchSize && j < *entryCount; j++ {
+			batch = append(batch, fluent.IPv4Entry().
+				WithNetworkInstance(ni).
+				WithPrefix(prefix(j)).
+				WithNextHopGroup(nhgIndex).
+				WithNextHopGroupNetworkInstance(ni))
+		}
+		client.Modify().AddEntry(t, batch...)
+	}
+	awaitCtx, cancel := context.WithTimeout(ctx, installWait)
+	defer cancel()
+	awaitErr = client.Await(awaitCtx, t)
+	return <-rebootDone, awaitErr
+}
+
+// ipv4Results returns the terminal programming result of each IPv4 prefix
+// operation, and the prefixes with more than one terminal result.
+func ipv4Results(t *testing.T, client *fluent.GRIBIClient) (map[string]gpb.AFTResult_Status, []string) {
+	t.Helper()
+	results := map[string]gpb.AFTResult_Status{}
+	var dups []string
+	for _, r := range client.Results(t) {
+		if r.Details == nil || r.Details.IPv4Prefix == "" {
+			continue
+		}
+		switch r.ProgrammingResult {
+		case gpb.AFTResult_FIB_PROGRAMMED, gpb.AFTResult_FAILED, gpb.AFTResult_FIB_FAILED:
+			if _, ok := results[r.Details.IPv4Prefix]; ok {
+				dups = append(dups, r.Details.IPv4Prefix)
+			}
+			results[r.Details.IPv4Prefix] = r.ProgrammingResult
+		}
+	}
+	return results, dups
+}
+
+func TestRebootDuringProgramming(t *testing.T) {
+	if *entryCount > maxEntries {
+		t.Fatalf("--entries is %d, want at most %d", *entryCount, maxEntries)
+	}
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	ctx := context.Background()
+
+	name, controller := selectRebootComponent(t, dut)
+	t.Logf("Subcomponent to reboot: %s (controller card: %v)", name, controller)
+
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	client := fluent.NewClient()
+	client.Connection().WithStub(dut.RawAPIs().GRIBI(t)).WithPersistence().WithInitialElectionID(electionIDLow, 0).
+		WithRedundancyMode(fluent.ElectedPrimaryClient).WithFIBACK()
+	client.Start(ctx, t)
+	defer client.Stop(t)
+	client.StartSending(ctx, t)
+	gribi.BecomeLeader(t, client)
+
+	ni := deviations.DefaultNetworkInstance(dut)
+	client.Modify().AddEntry(t,
+		fluent.NextHopEntry().WithNetworkInstance(ni).WithIndex(nhIndex).WithIPAddress(atePort2.IPv4),
+		fluent.NextHopGroupEntry().WithNetworkInstance(ni).WithID(nhgIndex).AddNextHop(nhIndex, 1),
+	)
+	nhCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := client.Await(nhCtx, t); err != nil {
+		t.Fatalf("Could not program next-hop and next-hop-group: %v", err)
+	}
+
+	t.Logf("Programming %d IPv4 entries in batches of %d", *entryCount, *batchSize)
+	rebootErr, awaitErr := programWithReboot(ctx, t, dut, client, name)
+	if rebootErr != nil {
+		t.Fatalf("Reboot of %s failed: %v", name, rebootErr)
+	}
+	if awaitErr != nil {
+		t.Logf("Await got error while programming during reboot: %v", awaitErr)
+	}
+
+	var acked []string
+	t.Run("EveryOperationAcknowledged", func(t *testing.T) {
+		results, dups := ipv4Results(t, client)
+		if len(dups) > 0 {
+			t.Errorf("Prefixes with more than one terminal result: %v", dups)
+		}
+		var missing []string
+		var nacked int
+		for i := 0; i < *entryCount; i++ {
+			p := prefix(i)
+			switch res, ok := results[p]; {
+			case !ok:
+				missing = append(missing, p)
+			case res == gpb.AFTResult_FIB_PROGRAMMED:
+				acked = append(acked, p)
+			default:
+				nacked++
+			}
+		}
+		t.Logf("IPv4 entries: %d FIB_PROGRAMMED, %d FAILED or FIB_FAILED, %d without result", len(acked), nacked, len(missing))
+		if len(missing) > 0 {
+			t.Errorf("Operations silently lost: got no FIB_PROGRAMMED, FAILED or FIB_FAILED result for %d prefixes, first %s", len(missing), missing[0])
+		}
+	})
+
+	t.Logf("Waiting for %s to recover", name)
+	awaitRecovery(t, dut, name, controller)
+
+	// The session of the programming client may not survive the reboot, so
+	// a new client verifies and cleans up the entries.
+	c := &gribi.Client{DUT: dut, FIBACK: true, Persistence: true}
+	defer c.Close(t)
+	if err := c.Start(t); err != nil {
+		t.Fatalf("Could not start gRIBI client after recovery: %v", err)
+	}
+	c.BecomeLeader(t)
+	defer c.FlushAll(t)
+
+	t.Run("FIBMatchesAcknowledged", func(t *testing.T) {
+		gr, err := c.Fluent(t).Get().WithNetworkInstance(ni).WithAFT(fluent.IPv4).Send()
+		if err != nil {
+			t.Fatalf("gRIBI Get after recovery failed: %v", err)
+		}
+		installed := map[string]bool{}
+		for _, e := range gr.GetEntry() {
+			if p := e.GetIpv4().GetPrefix(); p != "" {
+				installed[p] = e.GetFibStatus() == gpb.AFTEntry_PROGRAMMED
+			}
+		}
+		for _, p := range acked {
+			programmed, ok := installed[p]
+			switch {
+			case !ok:
+				t.Errorf("Acknowledged prefix %s missing from gRIBI Get after recovery", p)
+			case !programmed:
+				t.Errorf("Acknowledged prefix %s not FIB programmed after recovery", p)
+			}
+			delete(installed, p)
+		}
+		var extra []string
+		for p := range installed {
+			if strings.HasPrefix(p, "11.") {
+				extra = append(extra, p)
+			}
+		}
+		sort.Strings(extra)
+		if len(extra) > 0 {
+			t.Errorf("Prefixes installed without FIB_PROGRAMMED acknowledgement: got %d, first %s", len(extra), extra[0])
+		}
+	})
+
+	t.Run("Traffic", func(t *testing.T) {
+		if len(acked) == 0 {
+			t.Skip("No acknowledged prefixes to send traffic to")
+		}
+		dsts := []string{strings.TrimSuffix(acked[0], "/32"), strings.TrimSuffix(acked[len(acked)-1], "/32")}
+		addFlow(top, dsts)
+		ate.OTG().PushConfig(t, top)
+		ate.OTG().StartProtocols(t)
+		otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+		ate.OTG().StartTraffic(t)
+		time.Sleep(15 * time.Second)
+		ate.OTG().StopTraffic(t)
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+		if loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 20*time.Second); loss > 0 {
+			t.Errorf("Traffic loss to acknowledged prefixes %v: got %.4f%%, want 0%%", dsts, loss)
+		}
+	})
+}
@@ -16,17 +16,20 @@ package supervisor_failure_test
 
 import (
 	"context"
+	"flag"
 	"testing"
 	"time"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
+	fpargs "github.com/openconfig/featureprofiles/internal/args"
 	"github.com/openconfig/featureprofiles/internal/attrs"
 	cmp "github.com/openconfig/featureprofiles/internal/components"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/gribi"
 	"github.com/openconfig/featureprofiles/internal/otgutils"
-	"github.com/openconfig/gnoigo/system"
+	"github.com/openconfig/featureprofiles/internal/programming"
+	"github.com/openconfig/featureprofiles/internal/topology"
 	"github.com/openconfig/gribigo/fluent"
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/testt"
@@ -34,8 +37,10 @@ import (
 
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
-	"github.com/openconfig/ondatra/gnoi"
 	"github.com/openconfig/ygnmi/ygnmi"
+
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
 )
 
 func TestMain(m *testing.M) {
@@ -62,8 +67,15 @@ const (
 	primaryController   = oc.Platform_ComponentRedundantRole_PRIMARY
 	secondaryController = oc.Platform_ComponentRedundantRole_SECONDARY
 	switchTrigger       = oc.PlatformTypes_ComponentRedundantRoleSwitchoverReasonTrigger_USER_INITIATED
-	maxSwitchoverTime   = 900
 	flowName            = "Flow"
+	// readyTimeout is how long the DUT may take to program the route to
+	// ATE port-2 before traffic is sent.
+	readyTimeout = 2 * time.Minute
+)
+
+var (
+	soak            = flag.Bool("soak", false, "Replace the single flow with full-mesh IMIX flows at near line rate between all ATE ports.")
+	soakLineRatePct = flag.Float64("soak_line_rate_pct", 90, "Percentage of line rate sent from each ATE port when -soak is set.")
 )
 
 var (
@@ -113,8 +125,17 @@ func configInterfaceDUT(i *oc.Interface, a *attrs.Attributes, dut *ondatra.DUTDe
 	return i
 }
 
-// configureDUT configures port1 and port2 on the DUT.
-func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+// soakPairs returns the port pairs other than port1 and port2 that the soak
+// flows are spread over, which are all the other port pairs in the testbed.
+func soakPairs(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice) []topology.PortPair {
+	if !*soak {
+		return nil
+	}
+	return topology.PortPairs(t, dut, ate, 2, topology.MaxPortPairs)[2:]
+}
+
+// configureDUT configures port1, port2 and the extra port pairs on the DUT.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, extra []topology.PortPair) {
 	t.Helper()
 	d := gnmi.OC()
 
@@ -134,10 +155,22 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
 		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
 	}
+
+	for _, pp := range extra {
+		i := &oc.Interface{Name: ygot.String(pp.DUT.Name())}
+		gnmi.Replace(t, dut, d.Interface(pp.DUT.Name()).Config(), configInterfaceDUT(i, &pp.DUTAttrs, dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, pp.DUT)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, pp.DUT.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
 }
 
-// configureATE configures port1 and port2 on the ATE and adding a flow with port1 as the source and port2 as destination
-func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+// configureATE configures port1, port2 and the extra port pairs on the ATE and adding a flow with port1 as the source and port2 as destination.
+// With -soak, full-mesh IMIX flows between all ports are added instead.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice, extra []topology.PortPair) gosnappi.Config {
 	t.Helper()
 	top := gosnappi.NewConfig()
 
@@ -146,6 +179,15 @@ func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
 
 	atePort1.AddToOTG(top, p1, &dutPort1)
 	atePort2.AddToOTG(top, p2, &dutPort2)
+	for _, pp := range extra {
+		pp.ATEAttrs.AddToOTG(top, pp.ATE, &pp.DUTAttrs)
+	}
+
+	if *soak {
+		flows := otgutils.AddMeshFlows(top, float32(*soakLineRatePct))
+		t.Logf("Soak flows at %v%% of line rate: %v", *soakLineRatePct, flows)
+		return top
+	}
 
 	flow := top.Flows().Add().SetName(flowName)
 	flow.Metrics().SetEnable(true)
@@ -160,7 +202,11 @@ func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
 }
 
 // Function to verify traffic
-func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice) {
+func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) {
+	if *soak {
+		verifySoakTraffic(t, ate, top)
+		return
+	}
 	flowMetrics := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(flowName).Counters().State())
 	txPkts := flowMetrics.GetOutPkts()
 	rxPkts := flowMetrics.GetInPkts()
@@ -176,6 +222,20 @@ func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice) {
 	}
 }
 
+// verifySoakTraffic stops the soak flows and verifies there is no loss on
+// any ATE port.
+func verifySoakTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) {
+	ate.OTG().StopTraffic(t)
+	otgutils.LogPortMetrics(t, ate.OTG(), top)
+	for port, got := range otgutils.GetPortLossPct(t, ate.OTG(), top, 30*time.Second) {
+		if got > 0 {
+			t.Errorf("LossPct for traffic to port %s got %f, want 0", port, got)
+		} else {
+			t.Logf("Traffic flows fine to ATE %s", port)
+		}
+	}
+}
+
 // testArgs holds the objects needed by a test case.
 type testArgs struct {
 	ctx     context.Context
@@ -269,14 +329,15 @@ func switchoverReady(t *testing.T, dut *ondatra.DUTDevice, controller string) bo
 
 func TestSupFailure(t *testing.T) {
 	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
 	ctx := context.Background()
+	extra := soakPairs(t, dut, ate)
 
 	// Configure the DUT
-	configureDUT(t, dut)
+	configureDUT(t, dut, extra)
 
 	// Configure the ATE
-	ate := ondatra.ATE(t, "ate")
-	top := configureATE(t, ate)
+	top := configureATE(t, ate, extra)
 	ate.OTG().PushConfig(t, top)
 	ate.OTG().StartProtocols(t)
 
@@ -308,12 +369,21 @@ func TestSupFailure(t *testing.T) {
 	}
 	// Program a route and ensure AFT telemetry returns FIB_PROGRAMMED
 	routeInstall(ctx, t, args)
+	ready := []programming.Signal{
+		programming.IPv4Entry(deviations.DefaultNetworkInstance(dut), ateDstNetCIDR),
+		programming.IPv4Neighbor(dut.Port(t, "port2").Name(), atePort2.IPv4),
+	}
+	programming.Await(t, dut, readyTimeout, ready...)
 	// Verify that static route(203.0.113.0/24) to ATE port-2 is preferred by the traffic.`
 	t.Logf("Starting traffic")
 	ate.OTG().StartTraffic(t)
 	time.Sleep(15 * time.Second)
 	otgutils.LogFlowMetrics(t, ate.OTG(), top)
-	verifyTraffic(t, args.ate)
+	verifyTraffic(t, args.ate, top)
+	if *soak {
+		// Soak traffic is stopped to verify it, so restart it for the switchover.
+		ate.OTG().StartTraffic(t)
+	}
 
 	controllers := cmp.FindComponentsByType(t, dut, controlcardType)
 	t.Logf("Found controller list: %v", controllers)
@@ -328,7 +398,16 @@ func TestSupFailure(t *testing.T) {
 		t.Fatalf("Controller %q did not become switchover-ready before test.", primaryBeforeSwitch)
 	}
 
-	switchoverResponse := gnoi.Execute(t, dut, system.NewSwitchControlProcessorOperation().Path(cmp.GetSubcomponentPath(secondaryBeforeSwitch, deviations.GNOISubcomponentPath(dut))))
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	var switchoverResponse *spb.SwitchControlProcessorResponse
+	err := cmp.WithSubcomponentPath(dut, secondaryBeforeSwitch, func(p *tpb.Path) error {
+		var err error
+		switchoverResponse, err = gnoiClient.System().SwitchControlProcessor(context.Background(), &spb.SwitchControlProcessorRequest{ControlProcessor: p})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to perform control processor switchover with unexpected err: %v", err)
+	}
 	t.Logf("gnoiClient.System().SwitchControlProcessor() response: %v", switchoverResponse)
 
 	startSwitchover := time.Now()
@@ -336,7 +415,7 @@ func TestSupFailure(t *testing.T) {
 	for {
 		var currentTime string
 		t.Logf("Time elapsed %.2f seconds since switchover started.", time.Since(startSwitchover).Seconds())
-		time.Sleep(30 * time.Second)
+		time.Sleep(fpargs.PollInterval())
 		if errMsg := testt.CaptureFatal(t, func(t testing.TB) {
 			currentTime = gnmi.Get(t, dut, gnmi.OC().System().CurrentDatetime().State())
 		}); errMsg != nil {
@@ -345,8 +424,8 @@ func TestSupFailure(t *testing.T) {
 			t.Logf("Controller switchover has completed successfully with received time: %v", currentTime)
 			break
 		}
-		if uint64(time.Since(startSwitchover).Seconds()) > maxSwitchoverTime {
-			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", time.Since(startSwitchover), maxSwitchoverTime)
+		if got, want := time.Since(startSwitchover), fpargs.SwitchoverTimeout(); got >= want {
+			t.Fatalf("time.Since(startSwitchover): got %v, want < %v", got, want)
 		}
 	}
 	t.Logf("Controller switchover time: %.2f seconds", time.Since(startSwitchover).Seconds())
@@ -367,17 +446,11 @@ func TestSupFailure(t *testing.T) {
 
 	// Verify the entry for 203.0.113.0/24 is active through AFT Telemetry.
 	t.Logf("Verify the entry for %s is active through AFT Telemetry.", ateDstNetCIDR)
-	ipv4Path := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts().Ipv4Entry(ateDstNetCIDR)
-	if _, found := gnmi.Watch(t, args.dut, ipv4Path.State(), 2*time.Minute, func(val *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
-		value, present := val.Val()
-		return present && value.GetPrefix() == ateDstNetCIDR
-	}).Await(t); !found {
-		t.Fatalf("Could not find prefix %s in telemetry AFT", ateDstNetCIDR)
-	}
+	programming.Await(t, dut, readyTimeout, ready...)
 	t.Logf("ipv4-entry found for %s after controller switchover..", ateDstNetCIDR)
 
 	otgutils.LogFlowMetrics(t, ate.OTG(), top)
-	verifyTraffic(t, args.ate)
+	verifyTraffic(t, args.ate, top)
 	ate.OTG().StopTraffic(t)
 	args.ate.OTG().StopProtocols(t)
 }
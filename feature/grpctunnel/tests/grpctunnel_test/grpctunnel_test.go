@@ -0,0 +1,294 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpctunnel_test
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/args"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/tunnelserver"
+	"github.com/openconfig/grpctunnel/tunnel"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+var (
+	tunnelServerAddr    = flag.String("tunnel_server_addr", ":50052", "Local address the gRPC tunnel server listens on.")
+	tunnelServerDUTAddr = flag.String("tunnel_server_dut_addr", "", "Address (host:port) of the tunnel server as reachable from the DUT. The test is skipped when unset.")
+	tunnelTargetID      = flag.String("tunnel_target_id", "", "Target ID the DUT registers with the tunnel server. Defaults to the DUT name.")
+	tunnelUsername      = flag.String("tunnel_username", "admin", "Username sent as metadata on RPCs carried over the tunnel.")
+	tunnelPassword      = flag.String("tunnel_password", "", "Password sent as metadata on RPCs carried over the tunnel.")
+)
+
+const (
+	controlcardType = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CONTROLLER_CARD
+	registerTimeout = 2 * time.Minute
+	sampleInterval  = 5 * time.Second
+	maxClockSkew    = time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Start a tunnel server and configure the DUT to dial out to it.
+//  2. Verify the DUT registers its GNMI_GNOI target.
+//  3. Verify gNMI Capabilities, Get and Subscribe work over the tunnel.
+//  4. Verify gNOI System.Time works over the tunnel.
+//  5. Switch over the RP and verify the DUT re-registers and gNMI works again.
+//
+// Topology:
+//
+//	DUT
+//
+// Test notes:
+//   - The DUT must be able to reach the test host at --tunnel_server_dut_addr.
+//   - There is no OpenConfig model for the tunnel client yet, so the DUT is
+//     configured using CLI, which requires deviation
+//     'GRPCTunnelConfigOCUnsupported'.
+
+// configureTunnelClient makes the DUT dial out to the tunnel server at addr
+// and register target id, and removes the configuration when the test ends.
+func configureTunnelClient(t *testing.T, dut *ondatra.DUTDevice, addr, id string) {
+	t.Helper()
+	if !deviations.GRPCTunnelConfigOCUnsupported(dut) {
+		t.Skip("There is no OpenConfig model for the gRPC tunnel client; deviation 'GRPCTunnelConfigOCUnsupported' is required")
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("Invalid tunnel server address %q: %v", addr, err)
+	}
+	var config, unconfig string
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		config = fmt.Sprintf(`
+management api gnmi
+   transport grpc-tunnel featureprofiles
+      destination %s port %s
+      target %s
+`, host, port, id)
+		unconfig = `
+management api gnmi
+   no transport grpc-tunnel featureprofiles
+`
+	default:
+		t.Fatalf("Unsupported vendor %s for deviation 'GRPCTunnelConfigOCUnsupported'", dut.Vendor())
+	}
+	helpers.GnmiCLIConfig(t, dut, config)
+	t.Cleanup(func() {
+		helpers.GnmiCLIConfig(t, dut, unconfig)
+	})
+}
+
+// tunnelDialOpts returns the dial options used for RPCs over the tunnel.
+func tunnelDialOpts() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true, // NOLINT
+		})),
+		grpc.WithBlock(),
+	}
+}
+
+// withCreds adds the tunnel credentials to the outgoing context.
+func withCreds(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "username", *tunnelUsername, "password", *tunnelPassword)
+}
+
+func dialTarget(t *testing.T, ts *tunnelserver.Server, target tunnel.Target) *grpc.ClientConn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn, err := ts.Dial(ctx, target, tunnelDialOpts()...)
+	if err != nil {
+		t.Fatalf("Failed to dial %v over the tunnel: %v", target, err)
+	}
+	return conn
+}
+
+func verifyGNMIGet(t *testing.T, conn *grpc.ClientConn) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(withCreds(context.Background()), time.Minute)
+	defer cancel()
+	c := gpb.NewGNMIClient(conn)
+	if _, err := c.Capabilities(ctx, &gpb.CapabilityRequest{}); err != nil {
+		t.Errorf("gNMI Capabilities over tunnel failed: %v", err)
+	}
+	resp, err := c.Get(ctx, &gpb.GetRequest{
+		Path: []*gpb.Path{{
+			Origin: "openconfig",
+			Elem:   []*gpb.PathElem{{Name: "system"}, {Name: "state"}, {Name: "hostname"}},
+		}},
+		Type:     gpb.GetRequest_STATE,
+		Encoding: gpb.Encoding_JSON_IETF,
+	})
+	if err != nil {
+		t.Fatalf("gNMI Get over tunnel failed: %v", err)
+	}
+	if len(resp.GetNotification()) == 0 {
+		t.Errorf("gNMI Get over tunnel returned no notifications")
+	}
+}
+
+func verifyGNMISubscribe(t *testing.T, conn *grpc.ClientConn) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(withCreds(context.Background()), time.Minute)
+	defer cancel()
+	sub, err := gpb.NewGNMIClient(conn).Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("gNMI Subscribe over tunnel failed: %v", err)
+	}
+	if err := sub.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Prefix: &gpb.Path{Origin: "openconfig"},
+				Mode:   gpb.SubscriptionList_STREAM,
+				Subscription: []*gpb.Subscription{{
+					Path:           &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}, {Name: "state"}, {Name: "current-datetime"}}},
+					Mode:           gpb.SubscriptionMode_SAMPLE,
+					SampleInterval: uint64(sampleInterval.Nanoseconds()),
+				}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to send SubscribeRequest over tunnel: %v", err)
+	}
+
+	const wantUpdates = 3
+	var updates int
+	for updates < wantUpdates {
+		resp, err := sub.Recv()
+		if err != nil {
+			t.Fatalf("Subscribe over tunnel failed after %d updates: %v", updates, err)
+		}
+		if n := resp.GetUpdate(); n != nil {
+			t.Logf("Received over tunnel: %s", helpers.GNMINotifString(n))
+			updates += len(n.GetUpdate())
+		}
+	}
+}
+
+func TestGRPCTunnel(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	if *tunnelServerDUTAddr == "" {
+		t.Skip("Flag --tunnel_server_dut_addr is not set")
+	}
+
+	ts, err := tunnelserver.New(*tunnelServerAddr)
+	if err != nil {
+		t.Fatalf("Failed to start tunnel server: %v", err)
+	}
+	defer ts.Stop()
+	t.Logf("Tunnel server listening on %v", ts.Addr())
+
+	id := *tunnelTargetID
+	if id == "" {
+		id = dut.Name()
+	}
+	target := tunnel.Target{ID: id, Type: tunnelserver.TargetTypeGNMIGNOI}
+	configureTunnelClient(t, dut, *tunnelServerDUTAddr, id)
+
+	t.Run("Registration", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), registerTimeout)
+		defer cancel()
+		if err := ts.AwaitTarget(ctx, target); err != nil {
+			t.Fatalf("DUT did not register with the tunnel server: %v", err)
+		}
+		var gnmiTargets []tunnel.Target
+		for _, tt := range ts.Targets() {
+			if tt.Type == tunnelserver.TargetTypeGNMIGNOI {
+				gnmiTargets = append(gnmiTargets, tt)
+			}
+		}
+		if len(gnmiTargets) != 1 || gnmiTargets[0] != target {
+			t.Errorf("Registered %s targets: got %v, want [%v]", tunnelserver.TargetTypeGNMIGNOI, gnmiTargets, target)
+		}
+	})
+
+	conn := dialTarget(t, ts, target)
+	defer conn.Close()
+
+	t.Run("GNMIOverTunnel", func(t *testing.T) {
+		verifyGNMIGet(t, conn)
+		verifyGNMISubscribe(t, conn)
+	})
+
+	t.Run("GNOIOverTunnel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(withCreds(context.Background()), time.Minute)
+		defer cancel()
+		resp, err := spb.NewSystemClient(conn).Time(ctx, &spb.TimeRequest{})
+		if err != nil {
+			t.Fatalf("gNOI System.Time over tunnel failed: %v", err)
+		}
+		skew := time.Since(time.Unix(0, int64(resp.GetTime())))
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > maxClockSkew {
+			t.Errorf("gNOI System.Time over tunnel: got skew %v, want <= %v", skew, maxClockSkew)
+		}
+	})
+
+	t.Run("ReconnectAfterSwitchover", func(t *testing.T) {
+		controllerCards := components.FindComponentsByType(t, dut, controlcardType)
+		if got, want := len(controllerCards), 2; got < want {
+			t.Skipf("Not enough controller cards for the test on %v: got %v, want at least %v", dut.Model(), got, want)
+		}
+		rpStandby, rpActive := components.FindStandbyRP(t, dut, controllerCards)
+		t.Logf("Detected rpStandby: %v, rpActive: %v", rpStandby, rpActive)
+
+		registrations := ts.Registrations(target)
+		gnoiClient := dut.RawAPIs().GNOI(t)
+		err := components.WithSubcomponentPath(dut, rpStandby, func(p *tpb.Path) error {
+			switchoverRequest := &spb.SwitchControlProcessorRequest{ControlProcessor: p}
+			t.Logf("switchoverRequest: %v", switchoverRequest)
+			_, err := gnoiClient.System().SwitchControlProcessor(context.Background(), switchoverRequest)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Failed to perform control processor switchover: %v", err)
+		}
+
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), args.SwitchoverTimeout())
+		defer cancel()
+		if err := ts.AwaitRegistrations(ctx, target, registrations+1); err != nil {
+			t.Fatalf("DUT did not re-register with the tunnel server after switchover: %v", err)
+		}
+		t.Logf("DUT re-registered tunnel target %v after %.2f seconds", target, time.Since(start).Seconds())
+
+		conn := dialTarget(t, ts, target)
+		defer conn.Close()
+		verifyGNMIGet(t, conn)
+	})
+}
@@ -0,0 +1,432 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icmp_generation_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	// ingressMTU is the L3 MTU of DUT port-1, large enough to accept the
+	// oversized packets sent by the test.
+	ingressMTU = 9000
+	// egressMTU is the L3 MTU of DUT port-2.
+	egressMTU = 1500
+	// ethOverhead is the Ethernet header and FCS included in the OTG frame
+	// size.
+	ethOverhead = 18
+
+	// Each correctness case sends flowPackets packets at a rate low enough
+	// not to be rate limited.
+	pps         = 10
+	flowPackets = 50
+
+	// The rate limiting cases send burstPackets packets at burstPPS.
+	burstPPS     = 10000
+	burstPackets = 100000
+	// maxErrorRatio is the largest ratio of ICMP errors to packets sent in
+	// the rate limiting cases.
+	maxErrorRatio = 0.5
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30, IPv6: "2001:db8::1", IPv6Len: 126}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30, IPv6: "2001:db8::2", IPv6Len: 126, MTU: ingressMTU}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30, IPv6: "2001:db8::5", IPv6Len: 126}
+	atePort2 = attrs.Attributes{Name: "atePort2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30, IPv6: "2001:db8::6", IPv6Len: 126, MTU: ingressMTU}
+
+	// unroutableIPv4 and unroutableIPv6 have no route in the DUT.
+	unroutableIPv4 = "198.51.100.1"
+	unroutableIPv6 = "2001:db8:ffff::1"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. IPv4 packets with TTL 1 elicit ICMP Time Exceeded, and IPv6 packets
+//     with hop limit 1 elicit ICMPv6 Time Exceeded.
+//  2. Packets to a destination without a route elicit ICMP Destination
+//     Unreachable or ICMPv6 Destination Unreachable.
+//  3. IPv4 packets with DF set larger than the MTU of DUT port-2 elicit ICMP
+//     Fragmentation Needed, and IPv6 packets larger than the MTU elicit
+//     ICMPv6 Packet Too Big, both with the MTU of DUT port-2.
+//  4. Packets with TTL or hop limit 1 sent at a high rate elicit ICMP errors
+//     at a lower rate.
+//
+// For cases 1-3 the ICMP errors captured on ATE port-1 are verified to be
+// sourced from the address of DUT port-1, addressed to ATE port-1, and to
+// quote the header of the offending packet.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - OpenConfig has no ICMP rate limiting counters, so rate limiting is
+//     verified from the number of ICMP errors captured on ATE port-1.
+
+// configureInterface configures the port with L3 MTU mtu.
+func configureInterface(t *testing.T, dut *ondatra.DUTDevice, dp *ondatra.Port, a attrs.Attributes, mtu uint16) {
+	t.Helper()
+	i := a.NewOCInterface(dp.Name(), dut)
+	if !deviations.OmitL2MTU(dut) {
+		i.Mtu = ygot.Uint16(mtu + 14)
+	}
+	s := i.GetOrCreateSubinterface(0)
+	s.GetOrCreateIpv4().Mtu = ygot.Uint16(mtu)
+	s.GetOrCreateIpv6().Mtu = ygot.Uint32(uint32(mtu))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), i)
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+		mtu  uint16
+	}{
+		{"port1", dutPort1, ingressMTU},
+		{"port2", dutPort2, egressMTU},
+	} {
+		dp := dut.Port(t, p.port)
+		configureInterface(t, dut, dp, p.a, p.mtu)
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port1"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+	return top
+}
+
+// icmpCase is a flow from ATE port-1 that the DUT does not forward, and the
+// ICMP error it elicits.
+type icmpCase struct {
+	desc string
+	ipv6 bool
+	dst  string
+	// ttl is the TTL or hop limit of the packets, 0 for the OTG default.
+	ttl  uint32
+	df   bool
+	size uint16
+	// icmpType and icmpCodes are the ICMP or ICMPv6 type and the codes
+	// accepted in the error.
+	icmpType  uint8
+	icmpCodes []uint8
+	// mtu is the MTU expected in the error, 0 if the error carries no MTU.
+	mtu uint16
+	// burst sends the packets at burstPPS to verify rate limiting.
+	burst bool
+}
+
+func (c icmpCase) flow() gosnappi.Flow {
+	flow := gosnappi.NewFlow().SetName("flow")
+	flow.Metrics().SetEnable(true)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	if c.ipv6 {
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv6"}).SetRxNames([]string{atePort2.Name + ".IPv6"})
+		v6 := flow.Packet().Add().Ipv6()
+		v6.Src().SetValue(atePort1.IPv6)
+		v6.Dst().SetValue(c.dst)
+		if c.ttl != 0 {
+			v6.HopLimit().SetValue(c.ttl)
+		}
+	} else {
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4"})
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(atePort1.IPv4)
+		v4.Dst().SetValue(c.dst)
+		if c.ttl != 0 {
+			v4.TimeToLive().SetValue(c.ttl)
+		}
+		if c.df {
+			v4.DontFragment().SetValue(1)
+		}
+	}
+	if c.size != 0 {
+		flow.Size().SetFixed(uint32(c.size) + ethOverhead)
+	}
+	if c.burst {
+		flow.Rate().SetPps(burstPPS)
+		flow.Duration().FixedPackets().SetPackets(burstPackets)
+	} else {
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+	return flow
+}
+
+// duration is how long the flow of the case takes to send.
+func (c icmpCase) duration() time.Duration {
+	if c.burst {
+		return burstPackets / burstPPS * time.Second
+	}
+	return flowPackets / pps * time.Second
+}
+
+// icmpError is an ICMP or ICMPv6 error captured on ATE port-1.
+type icmpError struct {
+	src, dst       net.IP
+	icmpType, code uint8
+	// mtu is the MTU carried in Fragmentation Needed and Packet Too Big.
+	mtu uint16
+	// quoted is the network layer of the offending packet quoted in the
+	// error, nil if it could not be decoded.
+	quoted gopacket.NetworkLayer
+}
+
+func readCapture(t *testing.T, ate *ondatra.ATEDevice, port string, fn func(gopacket.Packet)) {
+	t.Helper()
+	b := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName(port))
+	r, err := pcapgo.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Failed to read %s capture: %v", port, err)
+	}
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from %s capture: %v", port, err)
+		}
+		fn(gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true}))
+	}
+}
+
+// quotedLayer decodes the offending packet quoted in an ICMP error.
+func quotedLayer(data []byte, lt gopacket.LayerType) gopacket.NetworkLayer {
+	return gopacket.NewPacket(data, lt, gopacket.DecodeOptions{Lazy: true, NoCopy: true}).NetworkLayer()
+}
+
+// icmpErrors returns the ICMP and ICMPv6 errors captured on ATE port-1.
+// Echo and neighbor discovery messages are ignored.
+func icmpErrors(t *testing.T, ate *ondatra.ATEDevice) []icmpError {
+	t.Helper()
+	var errs []icmpError
+	readCapture(t, ate, "port1", func(pkt gopacket.Packet) {
+		if icmp, ok := pkt.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4); ok {
+			typ := icmp.TypeCode.Type()
+			if typ != layers.ICMPv4TypeDestinationUnreachable && typ != layers.ICMPv4TypeTimeExceeded {
+				return
+			}
+			ip := pkt.NetworkLayer().(*layers.IPv4)
+			errs = append(errs, icmpError{
+				src:      ip.SrcIP,
+				dst:      ip.DstIP,
+				icmpType: typ,
+				code:     icmp.TypeCode.Code(),
+				// The next-hop MTU is carried in the low 16 bits of the
+				// rest-of-header, which gopacket decodes as Seq.
+				mtu:    icmp.Seq,
+				quoted: quotedLayer(icmp.Payload, layers.LayerTypeIPv4),
+			})
+		}
+		if icmp, ok := pkt.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6); ok && len(icmp.Payload) >= 4 {
+			typ := icmp.TypeCode.Type()
+			if typ != layers.ICMPv6TypeDestinationUnreachable && typ != layers.ICMPv6TypeTimeExceeded && typ != layers.ICMPv6TypePacketTooBig {
+				return
+			}
+			ip := pkt.NetworkLayer().(*layers.IPv6)
+			errs = append(errs, icmpError{
+				src:      ip.SrcIP,
+				dst:      ip.DstIP,
+				icmpType: typ,
+				code:     icmp.TypeCode.Code(),
+				mtu:      uint16(binary.BigEndian.Uint32(icmp.Payload[:4])),
+				quoted:   quotedLayer(icmp.Payload[4:], layers.LayerTypeIPv6),
+			})
+		}
+	})
+	return errs
+}
+
+// verifyError checks the contents of ICMP error e elicited by case c.
+func verifyError(t *testing.T, c icmpCase, e icmpError) {
+	t.Helper()
+	wantSrc, wantDst, wantQuotedSrc := dutPort1.IPv4, atePort1.IPv4, atePort1.IPv4
+	if c.ipv6 {
+		wantSrc, wantDst, wantQuotedSrc = dutPort1.IPv6, atePort1.IPv6, atePort1.IPv6
+	}
+	if e.icmpType != c.icmpType || !slices.Contains(c.icmpCodes, e.code) {
+		t.Errorf("ICMP error type/code: got %d/%d, want %d/%v", e.icmpType, e.code, c.icmpType, c.icmpCodes)
+	}
+	if !e.src.Equal(net.ParseIP(wantSrc)) {
+		t.Errorf("ICMP error source address: got %v, want %s (DUT port-1)", e.src, wantSrc)
+	}
+	if !e.dst.Equal(net.ParseIP(wantDst)) {
+		t.Errorf("ICMP error destination address: got %v, want %s", e.dst, wantDst)
+	}
+	if c.mtu != 0 && e.mtu != c.mtu {
+		t.Errorf("MTU in ICMP error: got %d, want %d", e.mtu, c.mtu)
+	}
+	if e.quoted == nil {
+		t.Errorf("ICMP error does not quote the offending packet header")
+		return
+	}
+	flow := e.quoted.NetworkFlow()
+	if got := net.IP(flow.Src().Raw()); !got.Equal(net.ParseIP(wantQuotedSrc)) {
+		t.Errorf("Source address of quoted packet: got %v, want %s", got, wantQuotedSrc)
+	}
+	if got := net.IP(flow.Dst().Raw()); !got.Equal(net.ParseIP(c.dst)) {
+		t.Errorf("Destination address of quoted packet: got %v, want %s", got, c.dst)
+	}
+}
+
+func TestICMPGeneration(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+
+	oversized := uint16(egressMTU + 100)
+	cases := []icmpCase{{
+		desc:      "IPv4 TTL exceeded",
+		dst:       atePort2.IPv4,
+		ttl:       1,
+		icmpType:  layers.ICMPv4TypeTimeExceeded,
+		icmpCodes: []uint8{layers.ICMPv4CodeTTLExceeded},
+	}, {
+		desc:      "IPv6 hop limit exceeded",
+		ipv6:      true,
+		dst:       atePort2.IPv6,
+		ttl:       1,
+		icmpType:  layers.ICMPv6TypeTimeExceeded,
+		icmpCodes: []uint8{layers.ICMPv6CodeHopLimitExceeded},
+	}, {
+		desc:      "IPv4 unreachable",
+		dst:       unroutableIPv4,
+		icmpType:  layers.ICMPv4TypeDestinationUnreachable,
+		icmpCodes: []uint8{layers.ICMPv4CodeNet, layers.ICMPv4CodeHost},
+	}, {
+		desc:      "IPv6 unreachable",
+		ipv6:      true,
+		dst:       unroutableIPv6,
+		icmpType:  layers.ICMPv6TypeDestinationUnreachable,
+		icmpCodes: []uint8{layers.ICMPv6CodeNoRouteToDst, layers.ICMPv6CodeAddressUnreachable},
+	}, {
+		desc:      "IPv4 fragmentation needed",
+		dst:       atePort2.IPv4,
+		df:        true,
+		size:      oversized,
+		icmpType:  layers.ICMPv4TypeDestinationUnreachable,
+		icmpCodes: []uint8{layers.ICMPv4CodeFragmentationNeeded},
+		mtu:       egressMTU,
+	}, {
+		desc:      "IPv6 packet too big",
+		ipv6:      true,
+		dst:       atePort2.IPv6,
+		size:      oversized,
+		icmpType:  layers.ICMPv6TypePacketTooBig,
+		icmpCodes: []uint8{0},
+		mtu:       egressMTU,
+	}, {
+		desc:      "IPv4 TTL exceeded rate limit",
+		dst:       atePort2.IPv4,
+		ttl:       1,
+		icmpType:  layers.ICMPv4TypeTimeExceeded,
+		icmpCodes: []uint8{layers.ICMPv4CodeTTLExceeded},
+		burst:     true,
+	}, {
+		desc:      "IPv6 hop limit exceeded rate limit",
+		ipv6:      true,
+		dst:       atePort2.IPv6,
+		ttl:       1,
+		icmpType:  layers.ICMPv6TypeTimeExceeded,
+		icmpCodes: []uint8{layers.ICMPv6CodeHopLimitExceeded},
+		burst:     true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			top.Flows().Clear().Append(tc.flow())
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+			cs := gosnappi.NewControlState()
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+			ate.OTG().SetControlState(t, cs)
+
+			ate.OTG().StartTraffic(t)
+			time.Sleep(tc.duration() + 5*time.Second)
+			ate.OTG().StopTraffic(t)
+
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+			ate.OTG().SetControlState(t, cs)
+			otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+			tx, rx := otgutils.GetFlowStats(t, ate.OTG(), "flow", 10*time.Second)
+			if tx == 0 {
+				t.Fatalf("Flow sent no packets")
+			}
+			if rx != 0 {
+				t.Errorf("Packets forwarded to ATE port-2: got %d, want 0", rx)
+			}
+			errs := icmpErrors(t, ate)
+			t.Logf("Sent %d packets, captured %d ICMP errors on port1", tx, len(errs))
+			if len(errs) == 0 {
+				t.Fatalf("No ICMP error captured on ATE port-1")
+			}
+
+			if tc.burst {
+				ratio := float64(len(errs)) / float64(tx)
+				t.Logf("ICMP error rate: %.0f/s for %d pps sent", float64(len(errs))/tc.duration().Seconds(), burstPPS)
+				if ratio > maxErrorRatio {
+					t.Errorf("ICMP errors per packet sent: got %.2f, want at most %.2f", ratio, maxErrorRatio)
+				}
+				return
+			}
+			for _, e := range errs {
+				verifyError(t, tc, e)
+				if t.Failed() {
+					// The errors of one case are alike, so stop at the
+					// first bad one.
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lag_failover_test
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	microBFD        = flag.Bool("micro_bfd", false, "Also run the failover cases with micro-BFD configured on the LAG members. The ATE must answer micro-BFD on its LAG members.")
+	maxFailoverTime = flag.Duration("max_failover_time", time.Second, "Maximum allowed traffic loss duration when a LAG member goes down.")
+)
+
+const (
+	trafficPPS     = 100000
+	flowName       = "lagFlow"
+	lagName        = "LAG"
+	bfdIntervalMs  = 50
+	bfdMultiplier  = 3
+	lagTimeout     = time.Minute
+	detectTimeout  = 30 * time.Second
+	settleDuration = 10 * time.Second
+	ethernetCsmacd = oc.IETFInterfaces_InterfaceType_ethernetCsmacd
+	ieee8023adLag  = oc.IETFInterfaces_InterfaceType_ieee8023adLag
+	lagTypeLACP    = oc.IfAggregate_AggregationType_LACP
+	lagTypeSTATIC  = oc.IfAggregate_AggregationType_STATIC
+)
+
+var (
+	dutSrc = attrs.Attributes{
+		Desc:    "dutsrc",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	ateSrc = attrs.Attributes{
+		Name:    "atesrc",
+		MAC:     "02:11:01:00:00:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutDst = attrs.Attributes{
+		Desc:    "dutdst",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	ateDst = attrs.Attributes{
+		Name:    "atedst",
+		MAC:     "02:12:01:00:00:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure DUT port-2 to port-4 as a static LAG, or a LACP LAG with the
+//     fast LACP rate, optionally with micro-BFD on the members.
+//  2. Send traffic from ATE port-1 to the LAG, hashed over the members.
+//  3. Bring down the first ATE LAG member and measure the time until the DUT
+//     removes it from the LAG (detection) and the traffic loss duration from
+//     the OTG flow metrics (rebalancing).
+//  4. Verify the traffic is rebalanced over the remaining members and the loss
+//     duration is within --max_failover_time.
+//  5. Restore the member and verify it rejoins the LAG.
+//  6. Log the detection and rebalancing times of all cases for comparison.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2..4 <==LAG==> ATE port-2..4
+//
+// Test notes:
+//   - There is no OpenConfig model for micro-BFD in the generated ondatra
+//     schema yet, so micro-BFD is configured using CLI and the micro-BFD cases
+//     only run with --micro_bfd.
+
+// failoverCase is one LAG flavor whose failover is measured.
+type failoverCase struct {
+	lagType  oc.E_IfAggregate_AggregationType
+	microBFD bool
+}
+
+func (fc failoverCase) String() string {
+	if fc.microBFD {
+		return fmt.Sprintf("%v+microBFD", fc.lagType)
+	}
+	return fc.lagType.String()
+}
+
+// failoverResult holds the measured failover times of one case.
+type failoverResult struct {
+	detection time.Duration
+	rebalance time.Duration
+}
+
+// microBFDConfig returns the vendor CLI that enables or disables micro-BFD on
+// the members of aggID.
+func microBFDConfig(t *testing.T, dut *ondatra.DUTDevice, aggID string, enable bool) string {
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		if !enable {
+			return fmt.Sprintf("interface %s\n   no bfd per-link\n   no bfd interval\n", aggID)
+		}
+		return fmt.Sprintf("interface %s\n   bfd per-link\n   bfd interval %d min-rx %d multiplier %d\n", aggID, bfdIntervalMs, bfdIntervalMs, bfdMultiplier)
+	default:
+		t.Skipf("Micro-BFD configuration is not defined for vendor %v", dut.Vendor())
+	}
+	return ""
+}
+
+// device is implemented by both *ondatra.DUTDevice and *ondatra.ATEDevice.
+type device interface {
+	Port(testing.TB, string) *ondatra.Port
+}
+
+// memberPorts returns the ports of dev that are members of the LAG.
+func memberPorts(t *testing.T, dev device) []*ondatra.Port {
+	return []*ondatra.Port{dev.Port(t, "port2"), dev.Port(t, "port3"), dev.Port(t, "port4")}
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, aggID string, fc failoverCase) {
+	t.Helper()
+	d := gnmi.OC()
+	members := memberPorts(t, dut)
+
+	if deviations.AggregateAtomicUpdate(dut) {
+		for _, p := range members {
+			gnmi.Delete(t, dut, d.Interface(p.Name()).Ethernet().AggregateId().Config())
+		}
+		root := &oc.Root{}
+		agg := root.GetOrCreateInterface(aggID)
+		agg.Type = ieee8023adLag
+		agg.GetOrCreateAggregation().LagType = fc.lagType
+		for _, p := range members {
+			i := root.GetOrCreateInterface(p.Name())
+			i.Type = ethernetCsmacd
+			i.GetOrCreateEthernet().AggregateId = ygot.String(aggID)
+		}
+		gnmi.Update(t, dut, d.Config(), root)
+	}
+
+	if fc.lagType == lagTypeLACP {
+		lacp := &oc.Lacp_Interface{
+			Name:     ygot.String(aggID),
+			LacpMode: oc.Lacp_LacpActivityType_ACTIVE,
+			Interval: oc.Lacp_LacpPeriodType_FAST,
+		}
+		gnmi.Replace(t, dut, d.Lacp().Interface(aggID).Config(), lacp)
+	} else {
+		gnmi.Delete(t, dut, d.Lacp().Interface(aggID).Config())
+	}
+
+	agg := dutDst.NewOCInterface(aggID, dut)
+	agg.Type = ieee8023adLag
+	agg.GetOrCreateAggregation().LagType = fc.lagType
+	gnmi.Replace(t, dut, d.Interface(aggID).Config(), agg)
+
+	p1 := dut.Port(t, "port1")
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutSrc.NewOCInterface(p1.Name(), dut))
+
+	for _, p := range members {
+		i := &oc.Interface{
+			Name:        ygot.String(p.Name()),
+			Description: ygot.String(p.String()),
+			Type:        ethernetCsmacd,
+		}
+		if deviations.InterfaceEnabled(dut) {
+			i.Enabled = ygot.Bool(true)
+		}
+		i.GetOrCreateEthernet().AggregateId = ygot.String(aggID)
+		gnmi.Replace(t, dut, d.Interface(p.Name()).Config(), i)
+	}
+
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		for _, p := range members {
+			fptest.SetPortSpeed(t, p)
+		}
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, aggID, deviations.DefaultNetworkInstance(dut), 0)
+	}
+
+	if fc.microBFD {
+		cfg := microBFDConfig(t, dut, aggID, true)
+		helpers.GnmiCLIConfig(t, dut, cfg)
+		t.Cleanup(func() { helpers.GnmiCLIConfig(t, dut, microBFDConfig(t, dut, aggID, false)) })
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice, aggID string, lagType oc.E_IfAggregate_AggregationType) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	ateSrc.AddToOTG(top, ate.Port(t, "port1"), &dutSrc)
+
+	agg := top.Lags().Add().SetName(lagName)
+	if lagType == lagTypeSTATIC {
+		lagID, _ := strconv.Atoi(aggID)
+		agg.Protocol().Static().SetLagId(uint32(lagID))
+	} else {
+		agg.Protocol().Lacp().SetActorKey(1).SetActorSystemPriority(1).SetActorSystemId(ateDst.MAC)
+	}
+	for i, p := range memberPorts(t, ate) {
+		top.Ports().Add().SetName(p.ID())
+		lagPort := agg.Ports().Add().SetPortName(p.ID())
+		lagPort.Ethernet().SetMac(fmt.Sprintf("02:12:01:00:01:%02x", i+1)).SetName("LAGRx-" + strconv.Itoa(i))
+		if lagType == lagTypeLACP {
+			// A LACPDU timeout of 0 selects the short timeout, matching the
+			// fast LACP rate on the DUT.
+			lagPort.Lacp().SetActorActivity("active").SetActorPortNumber(uint32(i) + 1).SetActorPortPriority(1).SetLacpduPeriodicTimeInterval(1).SetLacpduTimeout(0)
+		}
+	}
+
+	dstDev := top.Devices().Add().SetName(ateDst.Name)
+	dstEth := dstDev.Ethernets().Add().SetName(ateDst.Name + ".Eth").SetMac(ateDst.MAC)
+	dstEth.Connection().SetLagName(lagName)
+	dstEth.Ipv4Addresses().Add().SetName(ateDst.Name + ".IPv4").SetAddress(ateDst.IPv4).SetGateway(dutDst.IPv4).SetPrefix(uint32(ateDst.IPv4Len))
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{ateSrc.Name + ".IPv4"}).
+		SetRxNames([]string{ateDst.Name + ".IPv4"})
+	flow.Size().SetFixed(256)
+	flow.Rate().SetPps(trafficPPS)
+	flow.Packet().Add().Ethernet().Src().SetValue(ateSrc.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(ateSrc.IPv4)
+	v4.Dst().SetValue(ateDst.IPv4)
+	tcp := flow.Packet().Add().Tcp()
+	tcp.SrcPort().Increment().SetStart(10000).SetCount(1000)
+	tcp.DstPort().SetValue(80)
+	return top
+}
+
+// awaitLAGUp waits until the DUT aggregate and all its members are up.
+func awaitLAGUp(t *testing.T, dut *ondatra.DUTDevice, aggID string) {
+	t.Helper()
+	gnmi.Await(t, dut, gnmi.OC().Interface(aggID).OperStatus().State(), lagTimeout, oc.Interface_OperStatus_UP)
+	for _, p := range memberPorts(t, dut) {
+		gnmi.Await(t, dut, gnmi.OC().Interface(p.Name()).OperStatus().State(), lagTimeout, oc.Interface_OperStatus_UP)
+	}
+}
+
+// setATELink sets the link state of ATE port p.
+func setATELink(t *testing.T, ate *ondatra.ATEDevice, p *ondatra.Port, state gosnappi.StatePortLinkStateEnum) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().SetPortNames([]string{p.ID()}).SetState(state)
+	ate.OTG().SetControlState(t, cs)
+}
+
+// awaitMemberRemoved waits until the DUT stops forwarding over member dp and
+// returns false if it did not within detectTimeout.
+func awaitMemberRemoved(t *testing.T, dut *ondatra.DUTDevice, aggID string, dp *ondatra.Port, lagType oc.E_IfAggregate_AggregationType) bool {
+	t.Helper()
+	if lagType == lagTypeLACP {
+		_, ok := gnmi.Watch(t, dut, gnmi.OC().Lacp().Interface(aggID).Member(dp.Name()).Distributing().State(), detectTimeout, func(v *ygnmi.Value[bool]) bool {
+			dist, present := v.Val()
+			return present && !dist
+		}).Await(t)
+		return ok
+	}
+	_, ok := gnmi.Watch(t, dut, gnmi.OC().Interface(dp.Name()).OperStatus().State(), detectTimeout, func(v *ygnmi.Value[oc.E_Interface_OperStatus]) bool {
+		status, present := v.Val()
+		return present && status != oc.Interface_OperStatus_UP
+	}).Await(t)
+	return ok
+}
+
+// inFrames returns the frames received on each ATE port.
+func inFrames(t *testing.T, ate *ondatra.ATEDevice, ports []*ondatra.Port) []uint64 {
+	t.Helper()
+	var frames []uint64
+	for _, p := range ports {
+		frames = append(frames, gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(p.ID()).Counters().InFrames().State()))
+	}
+	return frames
+}
+
+// measureFailover brings down the first LAG member while traffic is running
+// and returns the measured detection and rebalancing times.
+func measureFailover(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, top gosnappi.Config, aggID string, lagType oc.E_IfAggregate_AggregationType) failoverResult {
+	t.Helper()
+	atePorts := memberPorts(t, ate)
+	dutPorts := memberPorts(t, dut)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(settleDuration)
+
+	t.Logf("Bringing down ATE LAG member %v", atePorts[0].ID())
+	start := time.Now()
+	setATELink(t, ate, atePorts[0], gosnappi.StatePortLinkState.DOWN)
+	defer func() {
+		setATELink(t, ate, atePorts[0], gosnappi.StatePortLinkState.UP)
+		awaitLAGUp(t, dut, aggID)
+	}()
+
+	var res failoverResult
+	if !awaitMemberRemoved(t, dut, aggID, dutPorts[0], lagType) {
+		t.Errorf("DUT did not remove %v from %s within %v", dutPorts[0].Name(), aggID, detectTimeout)
+	}
+	res.detection = time.Since(start)
+
+	before := inFrames(t, ate, atePorts[1:])
+	time.Sleep(settleDuration)
+	after := inFrames(t, ate, atePorts[1:])
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	otgutils.LogPortMetrics(t, ate.OTG(), top)
+
+	for i, p := range atePorts[1:] {
+		if after[i] <= before[i] {
+			t.Errorf("Remaining LAG member %v did not receive traffic after failover: in-frames %d -> %d", p.ID(), before[i], after[i])
+		}
+	}
+
+	tx, rx := otgutils.GetFlowStats(t, ate.OTG(), flowName, 20*time.Second)
+	if tx == 0 {
+		t.Fatalf("Flow %s did not transmit any packets", flowName)
+	}
+	if rx > tx {
+		rx = tx
+	}
+	res.rebalance = time.Duration(float64(tx-rx) / trafficPPS * float64(time.Second))
+	t.Logf("Failover of %v: detection %v, rebalancing %v (%d packets lost)", lagType, res.detection, res.rebalance, tx-rx)
+	return res
+}
+
+func TestLAGFailover(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	aggID := netutil.NextAggregateInterface(t, dut)
+
+	cases := []failoverCase{
+		{lagType: lagTypeSTATIC},
+		{lagType: lagTypeLACP},
+		{lagType: lagTypeSTATIC, microBFD: true},
+		{lagType: lagTypeLACP, microBFD: true},
+	}
+
+	results := make(map[string]failoverResult)
+	for _, fc := range cases {
+		t.Run(fc.String(), func(t *testing.T) {
+			if fc.microBFD && !*microBFD {
+				t.Skip("Flag --micro_bfd is not set")
+			}
+			// Clean the OTG with an empty config before changing the LAG type.
+			ate.OTG().PushConfig(t, gosnappi.NewConfig())
+			configureDUT(t, dut, aggID, fc)
+			top := configureATE(t, ate, aggID, fc.lagType)
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			awaitLAGUp(t, dut, aggID)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+			res := measureFailover(t, dut, ate, top, aggID, fc.lagType)
+			if res.rebalance > *maxFailoverTime {
+				t.Errorf("Traffic loss duration on member failure: got %v, want <= %v", res.rebalance, *maxFailoverTime)
+			}
+			results[fc.String()] = res
+		})
+	}
+
+	for _, fc := range cases {
+		if res, ok := results[fc.String()]; ok {
+			t.Logf("%-20s detection %-14v rebalancing %v", fc, res.detection, res.rebalance)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopback_counters_qos_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qoscfg"
+	"github.com/openconfig/featureprofiles/internal/topology"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	spb "github.com/openconfig/gnoi/system"
+)
+
+const (
+	dstPrefix = "198.51.100.0/24"
+	dstAddr   = "198.51.100.1"
+
+	className  = "LOOPBACK-REMARK"
+	groupName  = "LOOPBACK-AF4"
+	queueName  = "AF4"
+	remarkTerm = "remark"
+	markedTerm = "marked"
+	// remarkDSCP is AF41.
+	remarkDSCP = 34
+
+	flowName    = "loopback-flow"
+	flowPkts    = 1000
+	flowPPS     = 100
+	pingCount   = 20
+	flowTimeout = time.Minute
+	// counterWait is how long to wait for the DUT counters to catch up with
+	// the traffic.
+	counterWait = 30 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Traffic to 198.51.100.0/24 enters DUT port1 with DSCP 0 and is routed
+//     out of the egress port.  An input classifier on DUT port1 remarks it
+//     to DSCP 34 and assigns it to queue AF4.
+//  2. Verify the in-unicast-pkts of DUT port1, the out-unicast-pkts of the
+//     egress port, the matched-packets of the classifier and the
+//     transmit-pkts of queue AF4 of the egress port count the traffic.
+//  3. Verify the traffic is marked with DSCP 34: with an ATE, by egress
+//     tracking on ATE port2, and without, by the matched-packets of a
+//     classifier term matching DSCP 34 on the looped back traffic.
+//
+// Topology:
+//
+//	ATE port-1 <------> port-1 DUT
+//	DUT port-2 <------> port-2 ATE
+//
+// or, with -no_ate, DUT port-1 in TERMINAL loopback mode.
+//
+// Test notes:
+//   - With -no_ate, the egress port is DUT port1 itself and the traffic is
+//     sent from the DUT with gNOI System.Ping.  The static neighbor of
+//     port1 resolves to the MAC address of the DUT, so each packet is
+//     received back and forwarded again until its TTL expires, and the
+//     counters are only checked to count at least every packet sent.
+
+// configureDUT configures the DUT ports of pairs, in loopback mode with
+// -no_ate, and a static route to dstPrefix via the neighbor of out.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, pairs []topology.PortPair, out topology.PortPair) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, p := range pairs {
+		if topology.NoATE() {
+			topology.ConfigureLoopback(t, dut, p)
+		} else {
+			gnmi.Replace(t, dut, gnmi.OC().Interface(p.DUT.Name()).Config(), p.DUTAttrs.NewOCInterface(p.DUT.Name(), dut))
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.DUT.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+
+	b := &gnmi.SetBatch{}
+	if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+		NetworkInstance: deviations.DefaultNetworkInstance(dut),
+		Prefix:          dstPrefix,
+		NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+			"0": oc.UnionString(out.ATEAttrs.IPv4),
+		},
+	}, dut); err != nil {
+		t.Fatalf("Cannot configure static route to %s: %v", dstPrefix, err)
+	}
+	b.Set(t, dut)
+}
+
+// configureQoS configures an input classifier on port in that remarks the
+// traffic to dstPrefix with DSCP remarkDSCP and assigns it to queueName, and
+// counts the traffic already marked with remarkDSCP.
+func configureQoS(t *testing.T, dut *ondatra.DUTDevice, in string) {
+	t.Helper()
+	qos := &oc.Qos{}
+	qoscfg.SetForwardingGroup(t, dut, qos, groupName, queueName)
+
+	class := qos.GetOrCreateClassifier(className)
+	class.SetName(className)
+	class.SetType(oc.Qos_Classifier_Type_IPV4)
+
+	term := class.GetOrCreateTerm(remarkTerm)
+	term.SetId(remarkTerm)
+	term.GetOrCreateConditions().GetOrCreateIpv4().SetDestinationAddress(dstPrefix)
+	term.GetOrCreateConditions().GetOrCreateIpv4().SetDscp(0)
+	term.GetOrCreateActions().SetTargetGroup(groupName)
+	term.GetOrCreateActions().GetOrCreateRemark().SetSetDscp(remarkDSCP)
+
+	term = class.GetOrCreateTerm(markedTerm)
+	term.SetId(markedTerm)
+	term.GetOrCreateConditions().GetOrCreateIpv4().SetDscp(remarkDSCP)
+	term.GetOrCreateActions().SetTargetGroup(groupName)
+
+	qoscfg.SetInputClassifier(t, dut, qos, in, oc.Input_Classifier_Type_IPV4, className)
+	t.Cleanup(func() {
+		gnmi.Delete(t, dut, gnmi.OC().Qos().Config())
+	})
+}
+
+// counters are the DUT counters checked by the test.
+type counters struct {
+	inPkts, outPkts, remarked, marked, queuePkts uint64
+}
+
+// counterCase is a counter that must increase by at least the number of
+// packets sent.
+type counterCase struct {
+	desc          string
+	before, after uint64
+}
+
+func counter(t *testing.T, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[uint64]) uint64 {
+	t.Helper()
+	v, _ := gnmi.Lookup(t, dut, q).Val()
+	return v
+}
+
+func readCounters(t *testing.T, dut *ondatra.DUTDevice, in, out string) counters {
+	t.Helper()
+	classifier := gnmi.OC().Qos().Interface(in).Input().Classifier(oc.Input_Classifier_Type_IPV4)
+	return counters{
+		inPkts:    counter(t, dut, gnmi.OC().Interface(in).Counters().InUnicastPkts().State()),
+		outPkts:   counter(t, dut, gnmi.OC().Interface(out).Counters().OutUnicastPkts().State()),
+		remarked:  counter(t, dut, classifier.Term(remarkTerm).MatchedPackets().State()),
+		marked:    counter(t, dut, classifier.Term(markedTerm).MatchedPackets().State()),
+		queuePkts: counter(t, dut, gnmi.OC().Qos().Interface(out).Output().Queue(queueName).TransmitPkts().State()),
+	}
+}
+
+// sendPings sends pingCount pings to dstAddr from the DUT and returns the
+// number sent.  The pings are not answered, only looped back.
+func sendPings(t *testing.T, dut *ondatra.DUTDevice) uint64 {
+	t.Helper()
+	req := &spb.PingRequest{Destination: dstAddr, Count: pingCount}
+	t.Logf("Sending ping request: %v", req)
+	stream, err := dut.RawAPIs().GNOI(t).System().Ping(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Ping(%v) failed: %v", req, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Ping(%v) stream failed: %v", req, err)
+		}
+		t.Logf("Got ping response: %v", resp)
+	}
+	return pingCount
+}
+
+// sendFlow sends flowPkts packets from ATE port1 to dstAddr with DSCP 0, and
+// verifies they are received on ATE port2 with DSCP remarkDSCP.  It returns
+// the number of packets sent.
+func sendFlow(t *testing.T, ate *ondatra.ATEDevice, in, out topology.PortPair) uint64 {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	in.ATEAttrs.AddToOTG(top, in.ATE, &in.DUTAttrs)
+	out.ATEAttrs.AddToOTG(top, out.ATE, &out.DUTAttrs)
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{in.ATEAttrs.Name + ".IPv4"}).SetRxNames([]string{out.ATEAttrs.Name + ".IPv4"})
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(flowPPS)
+	flow.Duration().FixedPackets().SetPackets(flowPkts)
+	flow.Packet().Add().Ethernet().Src().SetValue(in.ATEAttrs.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(in.ATEAttrs.IPv4)
+	v4.Dst().SetValue(dstAddr)
+	v4.Priority().Dscp().Phb().SetValue(0)
+	flow.EgressPacket().Add().Ethernet()
+	flow.EgressPacket().Add().Ipv4().Priority().Dscp().Phb().MetricTags().Add().SetName("EgressDSCP").SetOffset(0).SetLength(6)
+
+	otg := ate.OTG()
+	otg.PushConfig(t, top)
+	otg.StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, otg, top, 2*time.Minute)
+	otg.StartTraffic(t)
+	txPkts, rxPkts := otgutils.GetFlowStats(t, otg, flowName, flowTimeout)
+	otg.StopTraffic(t)
+	otgutils.LogFlowMetrics(t, otg, top)
+
+	if txPkts == 0 {
+		t.Fatalf("Flow %s sent no packets", flowName)
+	}
+	if rxPkts != txPkts {
+		t.Errorf("Flow %s received %d packets, want %d", flowName, rxPkts, txPkts)
+	}
+	want := fmt.Sprintf("0x%x", remarkDSCP)
+	for _, m := range gnmi.GetAll(t, otg, gnmi.OTG().Flow(flowName).TaggedMetricAny().State()) {
+		for _, tag := range m.Tags {
+			if got := tag.GetTagValue().GetValueAsHex(); !strings.EqualFold(got, want) {
+				t.Errorf("Flow %s received %d packets with DSCP %s, want %s", flowName, m.GetCounters().GetInPkts(), got, want)
+			}
+		}
+	}
+	return txPkts
+}
+
+func TestLoopbackCountersQoS(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	var (
+		ate     *ondatra.ATEDevice
+		pairs   []topology.PortPair
+		in, out topology.PortPair
+	)
+	if topology.NoATE() {
+		pairs = topology.LoopbackPorts(t, dut, 1, 1)
+		in, out = pairs[0], pairs[0]
+	} else {
+		ate = ondatra.ATE(t, "ate")
+		pairs = topology.PortPairs(t, dut, ate, 2, 2)
+		in, out = pairs[0], pairs[1]
+	}
+	configureDUT(t, dut, pairs, out)
+	configureQoS(t, dut, in.DUT.Name())
+
+	before := readCounters(t, dut, in.DUT.Name(), out.DUT.Name())
+	var sent uint64
+	if topology.NoATE() {
+		sent = sendPings(t, dut)
+	} else {
+		sent = sendFlow(t, ate, in, out)
+	}
+	time.Sleep(counterWait)
+	after := readCounters(t, dut, in.DUT.Name(), out.DUT.Name())
+
+	cases := []counterCase{
+		{fmt.Sprintf("%s in-unicast-pkts", in.DUT.Name()), before.inPkts, after.inPkts},
+		{fmt.Sprintf("%s out-unicast-pkts", out.DUT.Name()), before.outPkts, after.outPkts},
+		{fmt.Sprintf("Classifier term %s matched-packets", remarkTerm), before.remarked, after.remarked},
+		{fmt.Sprintf("%s queue %s transmit-pkts", out.DUT.Name(), queueName), before.queuePkts, after.queuePkts},
+	}
+	if topology.NoATE() {
+		cases = append(cases, counterCase{fmt.Sprintf("Classifier term %s matched-packets", markedTerm), before.marked, after.marked})
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := c.after - c.before; got < sent {
+				t.Errorf("%s increased by %d, want at least %d", c.desc, got, sent)
+			} else {
+				t.Logf("%s increased by %d", c.desc, got)
+			}
+		})
+	}
+}
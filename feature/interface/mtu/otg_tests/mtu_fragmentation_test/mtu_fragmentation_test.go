@@ -0,0 +1,323 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtu_fragmentation_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	// ingressMTU is the L3 MTU of DUT port-1, large enough to accept every
+	// oversized packet sent by the test.
+	ingressMTU = 9000
+	// oversize is how much larger than the egress MTU oversized packets are.
+	// Oversized IPv4 packets fit in two fragments.
+	oversize = 100
+	// ethOverhead is the Ethernet header and FCS included in the OTG frame
+	// size.
+	ethOverhead = 18
+
+	pps         = 100
+	flowPackets = 1000
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30, IPv6: "2001:db8::1", IPv6Len: 126}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30, IPv6: "2001:db8::2", IPv6Len: 126, MTU: ingressMTU}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30, IPv6: "2001:db8::5", IPv6Len: 126}
+	atePort2 = attrs.Attributes{Name: "atePort2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30, IPv6: "2001:db8::6", IPv6Len: 126, MTU: ingressMTU}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases, for each egress MTU configured on DUT port-2:
+//  1. IPv4 packets of exactly the MTU with DF set are forwarded unfragmented.
+//  2. IPv4 packets larger than the MTU without DF are fragmented, and every
+//     fragment is no larger than the MTU.
+//  3. IPv4 packets larger than the MTU with DF set are dropped, and the DUT
+//     sends ICMP Destination Unreachable, Fragmentation Needed with the
+//     next-hop MTU set to the MTU.
+//  4. IPv6 packets of exactly the MTU are forwarded.
+//  5. IPv6 packets larger than the MTU are dropped, and the DUT sends ICMPv6
+//     Packet Too Big with the MTU set to the MTU.
+//
+// Each case also verifies the IPv4 or IPv6 forwarded packet counters of the
+// DUT port-2 subinterface.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The DUT may rate limit ICMP errors, so at least one ICMP error is
+//     expected rather than one per dropped packet.
+//   - Fragments carry no OTG flow instrumentation, so fragmented packets are
+//     counted from the ATE port-2 capture.
+
+// configureInterface configures the port with L3 MTU mtu.
+func configureInterface(t *testing.T, dut *ondatra.DUTDevice, dp *ondatra.Port, a attrs.Attributes, mtu uint16) {
+	t.Helper()
+	i := a.NewOCInterface(dp.Name(), dut)
+	if !deviations.OmitL2MTU(dut) {
+		i.Mtu = ygot.Uint16(mtu + 14)
+	}
+	s := i.GetOrCreateSubinterface(0)
+	s.GetOrCreateIpv4().Mtu = ygot.Uint16(mtu)
+	s.GetOrCreateIpv6().Mtu = ygot.Uint32(uint32(mtu))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), i)
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, egressMTU uint16) {
+	t.Helper()
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+		mtu  uint16
+	}{
+		{"port1", dutPort1, ingressMTU},
+		{"port2", dutPort2, egressMTU},
+	} {
+		dp := dut.Port(t, p.port)
+		configureInterface(t, dut, dp, p.a, p.mtu)
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port1", "port2"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+	return top
+}
+
+// mtuCase is a flow of IP packets of size bytes from ATE port-1 to ATE
+// port-2.
+type mtuCase struct {
+	desc string
+	ipv6 bool
+	df   bool
+	size uint16
+	// fragments is the number of packets expected on ATE port-2 per packet
+	// sent, 0 if the packets are dropped.
+	fragments int
+}
+
+func (c mtuCase) flow() gosnappi.Flow {
+	flow := gosnappi.NewFlow().SetName("flow")
+	flow.Metrics().SetEnable(true)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	if c.ipv6 {
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv6"}).SetRxNames([]string{atePort2.Name + ".IPv6"})
+		v6 := flow.Packet().Add().Ipv6()
+		v6.Src().SetValue(atePort1.IPv6)
+		v6.Dst().SetValue(atePort2.IPv6)
+	} else {
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4"})
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(atePort1.IPv4)
+		v4.Dst().SetValue(atePort2.IPv4)
+		if c.df {
+			v4.DontFragment().SetValue(1)
+		}
+	}
+	flow.Size().SetFixed(uint32(c.size) + ethOverhead)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPackets)
+	return flow
+}
+
+// captureResult summarizes the ATE capture of one case.
+type captureResult struct {
+	// received is the number of packets or fragments from ATE port-1 to ATE
+	// port-2 captured on ATE port-2, and oversized how many of them were
+	// larger than the egress MTU.
+	received, oversized int
+	// icmpMTUs are the MTUs reported in ICMP Fragmentation Needed or ICMPv6
+	// Packet Too Big messages captured on ATE port-1.
+	icmpMTUs []uint16
+}
+
+func readCapture(t *testing.T, ate *ondatra.ATEDevice, port string, fn func(gopacket.Packet)) {
+	t.Helper()
+	b := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName(port))
+	r, err := pcapgo.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Failed to read %s capture: %v", port, err)
+	}
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from %s capture: %v", port, err)
+		}
+		fn(gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true}))
+	}
+}
+
+func analyzeCapture(t *testing.T, ate *ondatra.ATEDevice, mtu uint16) captureResult {
+	t.Helper()
+	var res captureResult
+	readCapture(t, ate, "port2", func(pkt gopacket.Packet) {
+		switch ip := pkt.NetworkLayer().(type) {
+		case *layers.IPv4:
+			if ip.SrcIP.String() != atePort1.IPv4 || ip.DstIP.String() != atePort2.IPv4 {
+				return
+			}
+			res.received++
+			if ip.Length > mtu {
+				res.oversized++
+			}
+		case *layers.IPv6:
+			if ip.SrcIP.String() != atePort1.IPv6 || ip.DstIP.String() != atePort2.IPv6 {
+				return
+			}
+			res.received++
+			if ip.Length+40 > mtu {
+				res.oversized++
+			}
+		}
+	})
+	readCapture(t, ate, "port1", func(pkt gopacket.Packet) {
+		if icmp, ok := pkt.Layer(layers.LayerTypeICMPv4).(*layers.ICMPv4); ok &&
+			icmp.TypeCode == layers.CreateICMPv4TypeCode(layers.ICMPv4TypeDestinationUnreachable, layers.ICMPv4CodeFragmentationNeeded) {
+			// The next-hop MTU is carried in the low 16 bits of the
+			// rest-of-header, which gopacket decodes as Seq.
+			res.icmpMTUs = append(res.icmpMTUs, icmp.Seq)
+		}
+		if icmp, ok := pkt.Layer(layers.LayerTypeICMPv6).(*layers.ICMPv6); ok &&
+			icmp.TypeCode.Type() == layers.ICMPv6TypePacketTooBig && len(icmp.Payload) >= 4 {
+			res.icmpMTUs = append(res.icmpMTUs, uint16(binary.BigEndian.Uint32(icmp.Payload[:4])))
+		}
+	})
+	return res
+}
+
+// forwardedPkts returns the IPv4 or IPv6 out-forwarded-pkts counter of the
+// port subinterface.
+func forwardedPkts(t *testing.T, dut *ondatra.DUTDevice, port string, ipv6 bool) uint64 {
+	t.Helper()
+	sub := gnmi.OC().Interface(dut.Port(t, port).Name()).Subinterface(0)
+	var v uint64
+	if ipv6 {
+		v, _ = gnmi.Lookup(t, dut, sub.Ipv6().Counters().OutForwardedPkts().State()).Val()
+	} else {
+		v, _ = gnmi.Lookup(t, dut, sub.Ipv4().Counters().OutForwardedPkts().State()).Val()
+	}
+	return v
+}
+
+func TestMTUFragmentation(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	top := configureATE(t, ate)
+
+	for _, mtu := range []uint16{1500, 4000} {
+		t.Run(fmt.Sprintf("MTU=%d", mtu), func(t *testing.T) {
+			configureDUT(t, dut, mtu)
+
+			cases := []mtuCase{
+				{desc: "IPv4 DF exactly MTU", df: true, size: mtu, fragments: 1},
+				{desc: "IPv4 larger than MTU", size: mtu + oversize, fragments: 2},
+				{desc: "IPv4 DF larger than MTU", df: true, size: mtu + oversize},
+				{desc: "IPv6 exactly MTU", ipv6: true, size: mtu, fragments: 1},
+				{desc: "IPv6 larger than MTU", ipv6: true, size: mtu + oversize},
+			}
+			for _, tc := range cases {
+				t.Run(tc.desc, func(t *testing.T) {
+					top.Flows().Clear().Append(tc.flow())
+					ate.OTG().PushConfig(t, top)
+					ate.OTG().StartProtocols(t)
+					otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+					before := forwardedPkts(t, dut, "port2", tc.ipv6)
+					cs := gosnappi.NewControlState()
+					cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+					ate.OTG().SetControlState(t, cs)
+
+					ate.OTG().StartTraffic(t)
+					time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+					ate.OTG().StopTraffic(t)
+
+					cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+					ate.OTG().SetControlState(t, cs)
+					otgutils.LogFlowMetrics(t, ate.OTG(), top)
+					// Give the DUT time to update the counters.
+					time.Sleep(10 * time.Second)
+
+					tx, _ := otgutils.GetFlowStats(t, ate.OTG(), "flow", 10*time.Second)
+					if tx == 0 {
+						t.Fatalf("Flow sent no packets")
+					}
+					res := analyzeCapture(t, ate, mtu)
+					t.Logf("Sent %d packets, captured %d on port2 (%d larger than MTU) and %d ICMP errors on port1", tx, res.received, res.oversized, len(res.icmpMTUs))
+
+					if want := int(tx) * tc.fragments; res.received != want {
+						t.Errorf("Packets captured on ATE port-2: got %d, want %d", res.received, want)
+					}
+					if res.oversized != 0 {
+						t.Errorf("Packets larger than MTU %d captured on ATE port-2: got %d, want 0", mtu, res.oversized)
+					}
+
+					if tc.fragments == 0 && len(res.icmpMTUs) == 0 {
+						t.Errorf("No ICMP Fragmentation Needed or Packet Too Big captured on ATE port-1")
+					}
+					if tc.fragments != 0 && len(res.icmpMTUs) != 0 {
+						t.Errorf("ICMP errors captured on ATE port-1: got %d, want 0", len(res.icmpMTUs))
+					}
+					for _, got := range res.icmpMTUs {
+						if got != mtu {
+							t.Errorf("MTU in ICMP error: got %d, want %d", got, mtu)
+							break
+						}
+					}
+
+					got := forwardedPkts(t, dut, "port2", tc.ipv6) - before
+					if want := tx * uint64(tc.fragments); got < want || (want == 0 && got != 0) {
+						t.Errorf("DUT port-2 out-forwarded-pkts increase: got %d, want %d", got, want)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package port_speed_fec_matrix_test
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var linkTimeout = flag.Duration("link_timeout", 2*time.Minute, "Maximum time for the link to come up after a speed and FEC mode are applied.")
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+)
+
+// mode is a port speed and FEC mode combination.
+type mode struct {
+	speed    oc.E_IfEthernet_ETHERNET_SPEED
+	fec      oc.E_IfEthernet_INTERFACE_FEC
+	ateSpeed gosnappi.Layer1SpeedEnum
+}
+
+func (m mode) String() string {
+	return fmt.Sprintf("%v_%v", strings.TrimPrefix(m.speed.String(), "SPEED_"), m.fec)
+}
+
+// rsFEC returns whether the ATE port needs Reed-Solomon FEC for the mode.
+func (m mode) rsFEC() bool {
+	switch m.fec {
+	case oc.IfEthernet_INTERFACE_FEC_FEC_RS528, oc.IfEthernet_INTERFACE_FEC_FEC_RS544, oc.IfEthernet_INTERFACE_FEC_FEC_RS544_2X_INTERLEAVE:
+		return true
+	}
+	return false
+}
+
+var (
+	mode10G = []mode{
+		{oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB, oc.IfEthernet_INTERFACE_FEC_FEC_DISABLED, gosnappi.Layer1Speed.SPEED_10_GBPS},
+	}
+	mode100GNRZ = []mode{
+		{oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB, oc.IfEthernet_INTERFACE_FEC_FEC_RS528, gosnappi.Layer1Speed.SPEED_100_GBPS},
+		{oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB, oc.IfEthernet_INTERFACE_FEC_FEC_DISABLED, gosnappi.Layer1Speed.SPEED_100_GBPS},
+	}
+	mode400G = []mode{
+		{oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB, oc.IfEthernet_INTERFACE_FEC_FEC_RS544, gosnappi.Layer1Speed.SPEED_400_GBPS},
+	}
+
+	// capabilities are the speed and FEC mode combinations to test for each
+	// optic type.  Optic types that are not listed are skipped.
+	capabilities = map[ondatra.PMD][]mode{
+		ondatra.PMD10GBASESR:     mode10G,
+		ondatra.PMD10GBASELR:     mode10G,
+		ondatra.PMD10GBASEER:     mode10G,
+		ondatra.PMD100GBASESR4:   mode100GNRZ,
+		ondatra.PMD100GBASELR4:   mode100GNRZ,
+		ondatra.PMD100GBASECWDM4: mode100GNRZ,
+		ondatra.PMD100GBASECLR4:  mode100GNRZ,
+		ondatra.PMD100GBASEPSM4:  mode100GNRZ,
+		ondatra.PMD100GBASECR4:   mode100GNRZ,
+		ondatra.PMD100GBASEFR:    mode100GNRZ,
+		ondatra.PMD400GBASEDR4:   mode400G,
+		ondatra.PMD400GBASEFR4:   mode400G,
+		ondatra.PMD400GBASELR4:   mode400G,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Look up the speed and FEC mode combinations of the optic type of DUT
+//     port-1 in the capability table.
+//  2. For each combination:
+//     a. Configure the speed and FEC mode on ATE port-1, with Reed-Solomon
+//        FEC enabled for RS528 and RS544 modes.
+//     b. Configure port-speed and fec-mode on DUT port-1 with gNMI.
+//     c. Verify DUT port-1 and ATE port-1 come up within --link_timeout, and
+//        port-speed and fec-mode state match the config.
+//     d. Record the time from the gNMI SetRequest until both ports are up.
+//  3. Log the link-up times of every combination, and write them to
+//     --outputs_dir.
+//
+// Topology:
+//
+//	dut:port1 <--> ate:port1
+//
+// Test notes:
+//   - The optic type is the PMD of DUT port-1 in the testbed.
+//   - The original speed and FEC mode of DUT port-1 are restored at the end.
+
+// linkUp is the link-up time of a mode.
+type linkUp struct {
+	mode mode
+	time time.Duration
+	ok   bool
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice, m mode) {
+	t.Helper()
+	ap := ate.Port(t, "port1")
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ap, &dutPort1)
+	l1 := top.Layer1().Add().SetName("L1").SetPortNames([]string{ap.ID()})
+	l1.SetAutoNegotiate(false).SetIeeeMediaDefaults(false).SetSpeed(m.ateSpeed)
+	l1.AutoNegotiation().SetRsFec(m.rsFEC())
+	ate.OTG().PushConfig(t, top)
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, dp *ondatra.Port, m mode) {
+	t.Helper()
+	eth := gnmi.OC().Interface(dp.Name()).Ethernet()
+	b := &gnmi.SetBatch{}
+	gnmi.BatchReplace(b, eth.PortSpeed().Config(), m.speed)
+	gnmi.BatchReplace(b, eth.FecMode().Config(), m.fec)
+	b.Set(t, dut)
+}
+
+// awaitLinkUp waits for DUT port-1 and ATE port-1 to be up, and returns
+// whether both came up.
+func awaitLinkUp(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, dp, ap *ondatra.Port) bool {
+	t.Helper()
+	_, dutUp := gnmi.Watch(t, dut, gnmi.OC().Interface(dp.Name()).OperStatus().State(), *linkTimeout, func(v *ygnmi.Value[oc.E_Interface_OperStatus]) bool {
+		s, ok := v.Val()
+		return ok && s == oc.Interface_OperStatus_UP
+	}).Await(t)
+	if !dutUp {
+		t.Errorf("DUT port %s is not UP after %v", dp.Name(), *linkTimeout)
+	}
+	_, ateUp := gnmi.Watch(t, ate.OTG(), gnmi.OTG().Port(ap.ID()).Link().State(), *linkTimeout, func(v *ygnmi.Value[otgtelemetry.E_Port_Link]) bool {
+		s, ok := v.Val()
+		return ok && s == otgtelemetry.Port_Link_UP
+	}).Await(t)
+	if !ateUp {
+		t.Errorf("ATE port %s is not UP after %v", ap.ID(), *linkTimeout)
+	}
+	return dutUp && ateUp
+}
+
+func verifyState(t *testing.T, dut *ondatra.DUTDevice, dp *ondatra.Port, m mode) {
+	t.Helper()
+	eth := gnmi.OC().Interface(dp.Name()).Ethernet()
+	if got := gnmi.Get(t, dut, eth.PortSpeed().State()); got != m.speed {
+		t.Errorf("Port %s port-speed: got %v, want %v", dp.Name(), got, m.speed)
+	}
+	if got := gnmi.Get(t, dut, eth.FecMode().State()); got != m.fec {
+		t.Errorf("Port %s fec-mode: got %v, want %v", dp.Name(), got, m.fec)
+	}
+}
+
+func report(t *testing.T, pmd ondatra.PMD, results []linkUp) {
+	t.Helper()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Link-up times for %v:\n", pmd)
+	for _, r := range results {
+		if r.ok {
+			fmt.Fprintf(&b, "  %-24s %v\n", r.mode, r.time.Round(time.Millisecond))
+		} else {
+			fmt.Fprintf(&b, "  %-24s not up after %v\n", r.mode, *linkTimeout)
+		}
+	}
+	t.Log(b.String())
+	if _, err := fptest.WriteOutput(t.Name()+"_link_up_times", ".txt", b.String()); err != nil {
+		t.Errorf("Unable to write link-up times: %v", err)
+	}
+}
+
+func TestSpeedFECMatrix(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	dp := dut.Port(t, "port1")
+	ap := ate.Port(t, "port1")
+
+	modes, ok := capabilities[dp.PMD()]
+	if !ok {
+		t.Skipf("No speed and FEC modes in the capability table for optic type %v of port %s", dp.PMD(), dp.Name())
+	}
+
+	gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), dutPort1.NewOCInterface(dp.Name(), dut))
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+	eth := gnmi.OC().Interface(dp.Name()).Ethernet()
+	origSpeed := gnmi.Lookup(t, dut, eth.PortSpeed().Config())
+	origFEC := gnmi.Lookup(t, dut, eth.FecMode().Config())
+	defer func() {
+		if v, ok := origSpeed.Val(); ok {
+			gnmi.Replace(t, dut, eth.PortSpeed().Config(), v)
+		} else {
+			gnmi.Delete(t, dut, eth.PortSpeed().Config())
+		}
+		if v, ok := origFEC.Val(); ok {
+			gnmi.Replace(t, dut, eth.FecMode().Config(), v)
+		} else {
+			gnmi.Delete(t, dut, eth.FecMode().Config())
+		}
+	}()
+
+	var results []linkUp
+	for _, m := range modes {
+		t.Run(m.String(), func(t *testing.T) {
+			configureATE(t, ate, m)
+			start := time.Now()
+			configureDUT(t, dut, dp, m)
+			r := linkUp{mode: m, ok: awaitLinkUp(t, dut, ate, dp, ap)}
+			r.time = time.Since(start)
+			results = append(results, r)
+			if !r.ok {
+				return
+			}
+			t.Logf("Link up %v after setting %v", r.time, m)
+			verifyState(t, dut, dp, m)
+		})
+	}
+	report(t, dp.PMD(), results)
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_arp_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	// localTarget is an address without a host in the subnet of DUT port-1,
+	// and remoteTarget an address reached through DUT port-2.
+	localTarget  = "192.0.2.100"
+	remoteTarget = "198.51.100.2"
+
+	pps            = 10
+	requestPackets = 20
+)
+
+var (
+	ateSrc = attrs.Attributes{
+		Name:    "ateSrc",
+		MAC:     "02:11:01:00:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 24,
+	}
+	dutSrc = attrs.Attributes{
+		Desc:    "DUT to ATE source",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 24,
+	}
+	dutDst = attrs.Attributes{
+		Desc:    "DUT to ATE destination",
+		IPv4:    "198.51.100.1",
+		IPv4Len: 30,
+	}
+	ateDst = attrs.Attributes{
+		Name:    "ateDst",
+		MAC:     "02:12:01:00:02:01",
+		IPv4:    remoteTarget,
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases, for each proxy-ARP mode of DUT port-1:
+//   - DISABLE: the DUT does not reply to ARP requests for localTarget or
+//     remoteTarget.
+//   - REMOTE_ONLY: the DUT replies with its port-1 MAC to ARP requests for
+//     remoteTarget only.
+//   - ALL: the DUT replies with its port-1 MAC to ARP requests for both
+//     localTarget and remoteTarget.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> port2:ate
+//
+// Test notes:
+//   - The ARP requests are sent by ATE port-1 as raw packets, and the
+//     replies are read from a capture on ATE port-1.
+//   - The in-broadcast-pkts counter of DUT port-1 must count the ARP
+//     requests.
+
+// configureDUT configures the DUT ports.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		port *ondatra.Port
+		a    *attrs.Attributes
+	}{
+		{dut.Port(t, "port1"), &dutSrc},
+		{dut.Port(t, "port2"), &dutDst},
+	} {
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.port.Name()).Config(), p.a.NewOCInterface(p.port.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p.port)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.port.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configureATE returns the ATE config with an ARP request flow from port1
+// for localTarget and remoteTarget each, and a capture on port1.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	ateSrc.AddToOTG(top, ate.Port(t, "port1"), &dutSrc)
+	ateDst.AddToOTG(top, ate.Port(t, "port2"), &dutDst)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port1"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+
+	for _, target := range []string{localTarget, remoteTarget} {
+		flow := top.Flows().Add().SetName("arp-" + target)
+		flow.TxRx().Port().SetTxName("port1")
+		eth := flow.Packet().Add().Ethernet()
+		eth.Src().SetValue(ateSrc.MAC)
+		eth.Dst().SetValue("ff:ff:ff:ff:ff:ff")
+		arp := flow.Packet().Add().Arp()
+		arp.Operation().SetValue(1)
+		arp.SenderHardwareAddr().SetValue(ateSrc.MAC)
+		arp.SenderProtocolAddr().SetValue(ateSrc.IPv4)
+		arp.TargetHardwareAddr().SetValue("00:00:00:00:00:00")
+		arp.TargetProtocolAddr().SetValue(target)
+		flow.Size().SetFixed(64)
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(requestPackets)
+	}
+	return top
+}
+
+// sendRequests sends the ARP requests with the capture on port1 running,
+// and returns the number of ARP replies to ateSrc captured for each target,
+// by the MAC the target was resolved to.
+func sendRequests(t *testing.T, ate *ondatra.ATEDevice) map[string]map[string]int {
+	t.Helper()
+	otg := ate.OTG()
+	cs := gosnappi.NewControlState()
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+	otg.SetControlState(t, cs)
+	otg.StartTraffic(t)
+	time.Sleep(requestPackets/pps*time.Second + 5*time.Second)
+	otg.StopTraffic(t)
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+	otg.SetControlState(t, cs)
+
+	r, err := pcapgo.NewReader(bytes.NewReader(otg.GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port1"))))
+	if err != nil {
+		t.Fatalf("Failed to read port1 capture: %v", err)
+	}
+	replies := make(map[string]map[string]int)
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return replies
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port1 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		arp, ok := pkt.Layer(layers.LayerTypeARP).(*layers.ARP)
+		if !ok || arp.Operation != layers.ARPReply {
+			continue
+		}
+		target := net.IP(arp.SourceProtAddress).String()
+		if target != localTarget && target != remoteTarget {
+			continue
+		}
+		if replies[target] == nil {
+			replies[target] = make(map[string]int)
+		}
+		replies[target][net.HardwareAddr(arp.SourceHwAddress).String()]++
+	}
+}
+
+func TestProxyARP(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	p1 := dut.Port(t, "port1")
+	dutMAC := gnmi.Get(t, dut, gnmi.OC().Interface(p1.Name()).Ethernet().MacAddress().State())
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	cases := []struct {
+		mode oc.E_ProxyArp_Mode
+		// replied are the targets the DUT must reply for.
+		replied map[string]bool
+	}{
+		{oc.ProxyArp_Mode_DISABLE, map[string]bool{}},
+		{oc.ProxyArp_Mode_REMOTE_ONLY, map[string]bool{remoteTarget: true}},
+		{oc.ProxyArp_Mode_ALL, map[string]bool{localTarget: true, remoteTarget: true}},
+	}
+	proxyARP := gnmi.OC().Interface(p1.Name()).Subinterface(0).Ipv4().ProxyArp()
+	counters := gnmi.OC().Interface(p1.Name()).Counters()
+	for _, tc := range cases {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			gnmi.Replace(t, dut, proxyARP.Mode().Config(), tc.mode)
+			gnmi.Await(t, dut, proxyARP.Mode().State(), time.Minute, tc.mode)
+
+			inBroadcast := gnmi.Get(t, dut, counters.InBroadcastPkts().State())
+			replies := sendRequests(t, ate)
+			for _, target := range []string{localTarget, remoteTarget} {
+				got := replies[target]
+				if !tc.replied[target] {
+					if len(got) != 0 {
+						t.Errorf("Got ARP replies for %s: %v, want none", target, got)
+					}
+					continue
+				}
+				if len(got) == 0 {
+					t.Errorf("Got no ARP replies for %s, want replies with MAC %s", target, dutMAC)
+				}
+				for mac, n := range got {
+					if !strings.EqualFold(mac, dutMAC) {
+						t.Errorf("Got %d ARP replies for %s with MAC %s, want %s", n, target, mac, dutMAC)
+					}
+				}
+			}
+
+			// The counter is polled, so wait for it to count all requests.
+			want := inBroadcast + 2*requestPackets
+			_, ok := gnmi.Watch(t, dut, counters.InBroadcastPkts().State(), time.Minute, func(val *ygnmi.Value[uint64]) bool {
+				n, present := val.Val()
+				return present && n >= want
+			}).Await(t)
+			if !ok {
+				t.Errorf("DUT port-1 in-broadcast-pkts did not count the %d ARP requests", 2*requestPackets)
+			}
+		})
+	}
+	gnmi.Replace(t, dut, proxyARP.Mode().Config(), oc.ProxyArp_Mode_DISABLE)
+}
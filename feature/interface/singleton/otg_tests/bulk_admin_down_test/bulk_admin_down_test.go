@@ -0,0 +1,269 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk_admin_down_test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/topology"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	// loopbackBase is the index of the first loopback interface added by the
+	// test, chosen to avoid the loopbacks already configured on the DUT.
+	loopbackBase = 100
+	// statusTimeout is the time for the DUT to stream the oper-status of all
+	// the interfaces after a batch.
+	statusTimeout = 2 * time.Minute
+	// sessionTimeout is the time for the BGP sessions to follow the ports.
+	sessionTimeout = 2 * time.Minute
+)
+
+var interfaceCount = flag.Int("interface_count", 128, "The number of interfaces disabled by the batch, made up of the DUT ports connected to the ATE and loopback interfaces.")
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Disable: admin-disable the DUT ports connected to the ATE and enough
+//     loopback interfaces to make --interface_count interfaces, in a single
+//     SetRequest.  Verify the DUT streams oper-status DOWN for every one of
+//     them, and the BGP sessions over the DUT ports go down on the DUT and
+//     on the ATE.
+//  2. Enable: admin-enable the same interfaces in a single SetRequest.
+//     Verify the DUT streams oper-status UP for every one of them, and the
+//     BGP sessions are established again.
+//
+// Topology:
+//
+//	ATE port-1 <------> port-1 DUT
+//	ATE port-2 <------> port-2 DUT
+//	...
+//	ATE port-N <------> port-N DUT
+//
+// Test notes:
+//   - All the DUT-ATE port pairs of the testbed are used, up to
+//     topology.MaxPortPairs, so a larger testbed disables more ports and
+//     fewer loopbacks.
+//   - The oper-status of all interfaces is streamed from a single
+//     subscription started before each SetRequest, so an interface whose
+//     transition is coalesced away by the DUT is reported as missing.
+
+// loopbackAddress returns the address of the loopback with index i.
+func loopbackAddress(i int) string {
+	return fmt.Sprintf("198.18.%d.%d", i/250, i%250+1)
+}
+
+// configureDUT configures the DUT ports of pairs with an eBGP session to the
+// ATE port of each pair, and the loopbacks.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, pairs []topology.PortPair, loopbacks []string) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	root := &oc.Root{}
+	var neighbors []*cfgplugins.NeighborConfig
+	for _, pp := range pairs {
+		i := pp.DUTAttrs.ConfigOCInterface(root.GetOrCreateInterface(pp.DUT.Name()), dut)
+		i.SetEnabled(true)
+		neighbors = append(neighbors, &cfgplugins.NeighborConfig{
+			Name:         pp.DUT.ID(),
+			IPv4Neighbor: pp.ATEAttrs.IPv4,
+			PeerGroup:    cfgplugins.BGPPeerGroup1,
+			AS:           cfgplugins.AteAS1,
+		})
+	}
+	for n, lb := range loopbacks {
+		i := root.GetOrCreateInterface(lb)
+		i.SetType(oc.IETFInterfaces_InterfaceType_softwareLoopback)
+		i.SetEnabled(true)
+		i.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress(loopbackAddress(n)).SetPrefixLength(32)
+	}
+
+	ni := deviations.DefaultNetworkInstance(dut)
+	afis := []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}
+	root.GetOrCreateNetworkInstance(ni).GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").Bgp =
+		cfgplugins.BuildBGPOCConfig(t, dut, pairs[0].DUTAttrs.IPv4, afis, neighbors)
+	stmt, err := root.GetOrCreateRoutingPolicy().GetOrCreatePolicyDefinition(cfgplugins.RPLPermitAll).AppendNewStatement("20")
+	if err != nil {
+		t.Fatalf("Cannot append statement to %s: %v", cfgplugins.RPLPermitAll, err)
+	}
+	stmt.GetOrCreateActions().SetPolicyResult(oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE)
+
+	gnmi.Update(t, dut, gnmi.OC().Config(), root)
+	for _, pp := range pairs {
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, pp.DUT)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, pp.DUT.Name(), ni, 0)
+		}
+	}
+}
+
+// configureATE configures the ATE ports of pairs with an eBGP session to the
+// DUT port of each pair.
+func configureATE(pairs []topology.PortPair) gosnappi.Config {
+	top := gosnappi.NewConfig()
+	for _, pp := range pairs {
+		dev := pp.ATEAttrs.AddToOTG(top, pp.ATE, &pp.DUTAttrs)
+		ipv4 := dev.Ethernets().Items()[0].Ipv4Addresses().Items()[0]
+		peer := dev.Bgp().SetRouterId(pp.ATEAttrs.IPv4).
+			Ipv4Interfaces().Add().SetIpv4Name(ipv4.Name()).
+			Peers().Add().SetName(dev.Name() + ".BGP4.peer")
+		peer.SetPeerAddress(pp.DUTAttrs.IPv4).
+			SetAsNumber(cfgplugins.AteAS1).
+			SetAsType(gosnappi.BgpV4PeerAsType.EBGP)
+	}
+	return top
+}
+
+// setEnabled sets the enabled leaf of all the interfaces in a single
+// SetRequest, and verifies the DUT streams the oper-status that follows
+// for every interface.
+func setEnabled(t *testing.T, dut *ondatra.DUTDevice, intfs []string, enabled bool) {
+	t.Helper()
+	want := oc.Interface_OperStatus_DOWN
+	if enabled {
+		want = oc.Interface_OperStatus_UP
+	}
+	pending := map[string]bool{}
+	for _, name := range intfs {
+		pending[name] = true
+	}
+	updates := 0
+	watch := gnmi.WatchAll(t, dut, gnmi.OC().InterfaceAny().OperStatus().State(), statusTimeout, func(v *ygnmi.Value[oc.E_Interface_OperStatus]) bool {
+		status, ok := v.Val()
+		if !ok {
+			return false
+		}
+		name := v.Path.GetElem()[1].GetKey()["name"]
+		if !pending[name] {
+			return false
+		}
+		updates++
+		if status == want {
+			delete(pending, name)
+		}
+		return len(pending) == 0
+	})
+
+	b := &gnmi.SetBatch{}
+	for _, name := range intfs {
+		gnmi.BatchUpdate(b, gnmi.OC().Interface(name).Enabled().Config(), enabled)
+	}
+	t.Logf("Setting enabled to %v on %d interfaces", enabled, len(intfs))
+	start := time.Now()
+	b.Set(t, dut)
+
+	if _, ok := watch.Await(t); !ok {
+		var missing []string
+		for name := range pending {
+			missing = append(missing, name)
+		}
+		t.Fatalf("No oper-status %v streamed for %d of %d interfaces within %v after enabled was set to %v: %v", want, len(missing), len(intfs), statusTimeout, enabled, missing)
+	}
+	t.Logf("Oper-status %v streamed for all %d interfaces in %v, from %d updates", want, len(intfs), time.Since(start), updates)
+}
+
+// awaitSessions waits for the BGP sessions of pairs to be in the established
+// state, or out of it if established is false, on the DUT and on the ATE.
+func awaitSessions(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, pairs []topology.PortPair, established bool) {
+	t.Helper()
+	bgp := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(cfgplugins.PTBGP, "BGP").Bgp()
+	for _, pp := range pairs {
+		v, ok := gnmi.Watch(t, dut, bgp.Neighbor(pp.ATEAttrs.IPv4).SessionState().State(), sessionTimeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+			state, present := v.Val()
+			return present && (state == oc.Bgp_Neighbor_SessionState_ESTABLISHED) == established
+		}).Await(t)
+		if !ok {
+			state, _ := v.Val()
+			t.Errorf("DUT BGP session to %s: got %v, want established %v", pp.ATEAttrs.IPv4, state, established)
+		}
+		name := pp.ATEAttrs.Name + ".BGP4.peer"
+		pv, ok := gnmi.Watch(t, ate.OTG(), gnmi.OTG().BgpPeer(name).SessionState().State(), sessionTimeout, func(v *ygnmi.Value[otgtelemetry.E_BgpPeer_SessionState]) bool {
+			state, present := v.Val()
+			return present && (state == otgtelemetry.BgpPeer_SessionState_ESTABLISHED) == established
+		}).Await(t)
+		if !ok {
+			state, _ := pv.Val()
+			t.Errorf("ATE BGP peer %s: got %v, want established %v", name, state, established)
+		}
+	}
+}
+
+func TestBulkAdminDown(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	pairs := topology.PortPairs(t, dut, ate, 2, topology.MaxPortPairs)
+	if *interfaceCount < len(pairs) {
+		t.Fatalf("--interface_count %d is less than the %d DUT ports connected to the ATE", *interfaceCount, len(pairs))
+	}
+	var loopbacks []string
+	for i := 0; i < *interfaceCount-len(pairs); i++ {
+		loopbacks = append(loopbacks, netutil.LoopbackInterface(t, dut, loopbackBase+i))
+	}
+	intfs := append([]string{}, loopbacks...)
+	for _, pp := range pairs {
+		intfs = append(intfs, pp.DUT.Name())
+	}
+	t.Logf("Using %d DUT ports and %d loopbacks", len(pairs), len(loopbacks))
+
+	configureDUT(t, dut, pairs, loopbacks)
+	t.Cleanup(func() {
+		for _, lb := range loopbacks {
+			gnmi.Delete(t, dut, gnmi.OC().Interface(lb).Config())
+		}
+	})
+	top := configureATE(pairs)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+
+	for _, name := range intfs {
+		gnmi.Await(t, dut, gnmi.OC().Interface(name).OperStatus().State(), statusTimeout, oc.Interface_OperStatus_UP)
+	}
+	awaitSessions(t, dut, ate, pairs, true)
+
+	t.Run("Disable", func(t *testing.T) {
+		setEnabled(t, dut, intfs, false)
+		for _, name := range intfs {
+			if got := gnmi.Get(t, dut, gnmi.OC().Interface(name).AdminStatus().State()); got != oc.Interface_AdminStatus_DOWN {
+				t.Errorf("Interface %s admin-status: got %v, want %v", name, got, oc.Interface_AdminStatus_DOWN)
+			}
+		}
+		awaitSessions(t, dut, ate, pairs, false)
+	})
+	t.Run("Enable", func(t *testing.T) {
+		setEnabled(t, dut, intfs, true)
+		for _, name := range intfs {
+			if got := gnmi.Get(t, dut, gnmi.OC().Interface(name).AdminStatus().State()); got != oc.Interface_AdminStatus_UP {
+				t.Errorf("Interface %s admin-status: got %v, want %v", name, got, oc.Interface_AdminStatus_UP)
+			}
+		}
+		awaitSessions(t, dut, ate, pairs, true)
+	})
+}
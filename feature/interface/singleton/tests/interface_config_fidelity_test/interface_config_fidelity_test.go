@@ -0,0 +1,184 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interface_config_fidelity_test
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/interfaces"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var maxDescLen = flag.Int("max_description_length", 240, "Length of the longest description written.")
+
+const (
+	// loopbackIndex is the index of the loopback interface created by the
+	// test.
+	loopbackIndex = 100
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases, for each description below written to each of a physical,
+// loopback and aggregate interface, and to subinterface 0 of the physical
+// interface as its label:
+//   - The description read from the config path with gNMI Get is the one
+//     written.
+//   - The description read from the state path with gNMI Get, Subscribe ONCE
+//     and Subscribe STREAM is the one written.
+//
+// And for forwarding-viable false and true on the physical interface:
+//   - The value reads back the same way as the descriptions.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - OpenConfig has no interface label leaf, so the subinterface
+//     description is used as the label of a logical port.
+//   - The longest description has --max_description_length characters.
+
+// description is a description written to an interface.
+type description struct {
+	name string
+	desc string
+}
+
+// descriptions returns the descriptions written to each interface.
+func descriptions() []description {
+	var long strings.Builder
+	for i := 0; long.Len() < *maxDescLen; i++ {
+		long.WriteByte("abcdefghijklmnopqrstuvwxyz0123456789"[i%36])
+	}
+	return []description{
+		{"ASCII", "uplink to core-router-1 Ethernet1/1"},
+		{"Long", long.String()},
+		{"UTF-8", "Überlandleitung → 東京 データセンター ✓"},
+		{"Special characters", `to "core" <a&b> 'x' \path {1} [2]; #3 %s`},
+	}
+}
+
+// verifyRoundTrip verifies that want is read back from the config path of a
+// leaf with gNMI Get, and from its state path with gNMI Get, Subscribe ONCE
+// and Subscribe STREAM.
+func verifyRoundTrip[T comparable](t *testing.T, dut *ondatra.DUTDevice, leaf string, config, state ygnmi.SingletonQuery[T], want T) {
+	t.Helper()
+	getOpts := dut.GNMIOpts().WithYGNMIOpts(ygnmi.WithUseGet())
+
+	// STREAM first, so that the other reads see the value once it is
+	// in state.
+	got, ok := gnmi.Watch(t, dut, state, time.Minute, func(val *ygnmi.Value[T]) bool {
+		v, present := val.Val()
+		return present && v == want
+	}).Await(t)
+	if !ok {
+		t.Errorf("Subscribe STREAM of %s state: got %v, want %v", leaf, got, want)
+	}
+	if got := gnmi.Get(t, dut, state); got != want {
+		t.Errorf("Subscribe ONCE of %s state: got %v, want %v", leaf, got, want)
+	}
+	if got := gnmi.Get(t, getOpts, state); got != want {
+		t.Errorf("Get of %s state: got %v, want %v", leaf, got, want)
+	}
+	if got := gnmi.Get(t, getOpts, config); got != want {
+		t.Errorf("Get of %s config: got %v, want %v", leaf, got, want)
+	}
+}
+
+// newInterface returns an interface named name of type typ.
+func newInterface(dut *ondatra.DUTDevice, name string, typ oc.E_IETFInterfaces_InterfaceType) *oc.Interface {
+	i := &oc.Interface{
+		Name: ygot.String(name),
+		Type: typ,
+	}
+	if deviations.InterfaceEnabled(dut) {
+		i.Enabled = ygot.Bool(true)
+	}
+	if typ == oc.IETFInterfaces_InterfaceType_ieee8023adLag {
+		i.GetOrCreateAggregation().LagType = oc.IfAggregate_AggregationType_STATIC
+	}
+	return i
+}
+
+func TestInterfaceConfigFidelity(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	p1 := dut.Port(t, "port1").Name()
+	lb := netutil.LoopbackInterface(t, dut, loopbackIndex)
+	agg := netutil.NextAggregateInterface(t, dut)
+
+	gnmi.Replace(t, dut, gnmi.OC().Interface(lb).Config(), newInterface(dut, lb, oc.IETFInterfaces_InterfaceType_softwareLoopback))
+	defer gnmi.Delete(t, dut, gnmi.OC().Interface(lb).Config())
+	gnmi.Replace(t, dut, gnmi.OC().Interface(agg).Config(), newInterface(dut, agg, oc.IETFInterfaces_InterfaceType_ieee8023adLag))
+	defer gnmi.Delete(t, dut, gnmi.OC().Interface(agg).Config())
+
+	p1Desc := gnmi.OC().Interface(p1).Description()
+	if orig, ok := gnmi.Lookup(t, dut, p1Desc.Config()).Val(); ok {
+		defer gnmi.Replace(t, dut, p1Desc.Config(), orig)
+	} else {
+		defer gnmi.Delete(t, dut, p1Desc.Config())
+	}
+	subDesc := gnmi.OC().Interface(p1).Subinterface(0).Description()
+	if orig, ok := gnmi.Lookup(t, dut, subDesc.Config()).Val(); ok {
+		defer gnmi.Replace(t, dut, subDesc.Config(), orig)
+	} else {
+		defer gnmi.Delete(t, dut, subDesc.Config())
+	}
+
+	intfs := []struct {
+		name string
+		desc *interfaces.Interface_DescriptionPath
+	}{
+		{"Physical", p1Desc},
+		{"Loopback", gnmi.OC().Interface(lb).Description()},
+		{"Aggregate", gnmi.OC().Interface(agg).Description()},
+	}
+	for _, intf := range intfs {
+		for _, d := range descriptions() {
+			t.Run(fmt.Sprintf("%s description %s", intf.name, d.name), func(t *testing.T) {
+				gnmi.Replace(t, dut, intf.desc.Config(), d.desc)
+				verifyRoundTrip(t, dut, "description", intf.desc.Config(), intf.desc.State(), d.desc)
+			})
+		}
+	}
+	for _, d := range descriptions() {
+		t.Run("Subinterface label "+d.name, func(t *testing.T) {
+			gnmi.Replace(t, dut, subDesc.Config(), d.desc)
+			verifyRoundTrip(t, dut, "subinterface description", subDesc.Config(), subDesc.State(), d.desc)
+		})
+	}
+
+	fv := gnmi.OC().Interface(p1).ForwardingViable()
+	defer gnmi.Delete(t, dut, fv.Config())
+	for _, viable := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ForwardingViable=%t", viable), func(t *testing.T) {
+			gnmi.Replace(t, dut, fv.Config(), viable)
+			verifyRoundTrip(t, dut, "forwarding-viable", fv.Config(), fv.State(), viable)
+		})
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static_arp_override_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	// staticMAC is the static ARP and ND entry of ateDst on the DUT, in the
+	// form 02:1a+IPv4 of ateDst.  It differs from the MAC of ateDst, so the
+	// destination MAC of the packets forwarded to ATE port-2 tells which
+	// entry the DUT used.
+	staticMAC = "02:1a:c0:00:02:06"
+
+	pps         = 100
+	flowPackets = 1000
+	garpPackets = 100
+)
+
+var (
+	ateSrc = attrs.Attributes{
+		Name:    "ateSrc",
+		MAC:     "02:11:01:00:01:01",
+		IPv4:    "192.0.2.1",
+		IPv6:    "2001:db8::1",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	dutSrc = attrs.Attributes{
+		Desc:    "DUT to ATE source",
+		IPv4:    "192.0.2.2",
+		IPv6:    "2001:db8::2",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	dutDst = attrs.Attributes{
+		Desc:    "DUT to ATE destination",
+		IPv4:    "192.0.2.5",
+		IPv6:    "2001:db8::5",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	ateDst = attrs.Attributes{
+		Name:    "ateDst",
+		MAC:     "02:12:01:00:02:01",
+		IPv4:    "192.0.2.6",
+		IPv6:    "2001:db8::6",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Static: with static ARP and ND entries of ateDst on the DUT, ateDst
+//     resolves the DUT and sends gratuitous ARP.  The DUT entries stay
+//     static with staticMAC, and traffic to ateDst is sent to staticMAC.
+//  2. Dynamic: without the static entries, the DUT learns ateDst and
+//     traffic is sent to the MAC of ateDst.
+//  3. Static restored: the static entries replace the learned entries.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> port2:ate
+//
+// Test notes:
+//   - ATE ports are promiscuous, so the packets sent to staticMAC are
+//     received on ATE port-2 and their destination MAC is read from a
+//     capture.
+
+// configureDUT configures the DUT ports, with the static ARP and ND entries
+// of ateDst on port2 if static is set.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice, static bool) {
+	t.Helper()
+	for _, p := range []struct {
+		port *ondatra.Port
+		a    *attrs.Attributes
+	}{
+		{dut.Port(t, "port1"), &dutSrc},
+		{dut.Port(t, "port2"), &dutDst},
+	} {
+		i := p.a.NewOCInterface(p.port.Name(), dut)
+		if deviations.ExplicitPortSpeed(dut) {
+			i.GetOrCreateEthernet().PortSpeed = fptest.GetIfSpeed(t, p.port)
+		}
+		if static && p.a == &dutDst {
+			s := i.GetOrCreateSubinterface(0)
+			s.GetOrCreateIpv4().GetOrCreateNeighbor(ateDst.IPv4).LinkLayerAddress = ygot.String(staticMAC)
+			s.GetOrCreateIpv6().GetOrCreateNeighbor(ateDst.IPv6).LinkLayerAddress = ygot.String(staticMAC)
+		}
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.port.Name()).Config(), i)
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.port.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configureATE returns the ATE config with IPv4 and IPv6 flows from ateSrc
+// to ateDst, a gratuitous ARP flow from ateDst, and a capture on port2.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	ateSrc.AddToOTG(top, ate.Port(t, "port1"), &dutSrc)
+	ateDst.AddToOTG(top, ate.Port(t, "port2"), &dutDst)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port2"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+
+	for _, family := range []string{"IPv4", "IPv6"} {
+		flow := top.Flows().Add().SetName(family)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{ateSrc.Name + "." + family}).SetRxNames([]string{ateDst.Name + "." + family})
+		flow.Packet().Add().Ethernet().Src().SetValue(ateSrc.MAC)
+		if family == "IPv4" {
+			v4 := flow.Packet().Add().Ipv4()
+			v4.Src().SetValue(ateSrc.IPv4)
+			v4.Dst().SetValue(ateDst.IPv4)
+		} else {
+			v6 := flow.Packet().Add().Ipv6()
+			v6.Src().SetValue(ateSrc.IPv6)
+			v6.Dst().SetValue(ateDst.IPv6)
+		}
+		flow.Size().SetFixed(256)
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+
+	garp := top.Flows().Add().SetName("GARP")
+	garp.TxRx().Port().SetTxName("port2")
+	eth := garp.Packet().Add().Ethernet()
+	eth.Src().SetValue(ateDst.MAC)
+	eth.Dst().SetValue("ff:ff:ff:ff:ff:ff")
+	arp := garp.Packet().Add().Arp()
+	arp.Operation().SetValue(2)
+	arp.SenderHardwareAddr().SetValue(ateDst.MAC)
+	arp.SenderProtocolAddr().SetValue(ateDst.IPv4)
+	arp.TargetHardwareAddr().SetValue("ff:ff:ff:ff:ff:ff")
+	arp.TargetProtocolAddr().SetValue(ateDst.IPv4)
+	garp.Size().SetFixed(64)
+	garp.Rate().SetPps(pps)
+	garp.Duration().FixedPackets().SetPackets(garpPackets)
+	return top
+}
+
+// sendTraffic sends the flows of top with the capture on port2 running, and
+// returns the number of packets from ateSrc captured for each destination
+// MAC.
+func sendTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) map[string]int {
+	t.Helper()
+	otg := ate.OTG()
+	cs := gosnappi.NewControlState()
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+	otg.SetControlState(t, cs)
+	otg.StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	otg.StopTraffic(t)
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+	otg.SetControlState(t, cs)
+	otgutils.LogFlowMetrics(t, otg, top)
+
+	r, err := pcapgo.NewReader(bytes.NewReader(otg.GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port2"))))
+	if err != nil {
+		t.Fatalf("Failed to read port2 capture: %v", err)
+	}
+	macs := make(map[string]int)
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return macs
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port2 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		eth, ok := pkt.Layer(layers.LayerTypeEthernet).(*layers.Ethernet)
+		if !ok {
+			continue
+		}
+		if ip, ok := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4); ok && ip.SrcIP.String() == ateSrc.IPv4 {
+			macs[eth.DstMAC.String()]++
+		}
+		if ip, ok := pkt.Layer(layers.LayerTypeIPv6).(*layers.IPv6); ok && ip.SrcIP.String() == ateSrc.IPv6 {
+			macs[eth.DstMAC.String()]++
+		}
+	}
+}
+
+// verifyNoLoss checks that all packets of the IPv4 and IPv6 flows are
+// received.
+func verifyNoLoss(t *testing.T, ate *ondatra.ATEDevice) {
+	t.Helper()
+	for _, name := range []string{"IPv4", "IPv6"} {
+		tx, rx := otgutils.GetFlowStats(t, ate.OTG(), name, 10*time.Second)
+		if tx == 0 {
+			t.Fatalf("Flow %s sent no packets", name)
+		}
+		if rx != tx {
+			t.Errorf("Flow %s packets received: got %d, want %d", name, rx, tx)
+		}
+	}
+}
+
+// verifyDstMACs checks that all packets in macs were sent to want.
+func verifyDstMACs(t *testing.T, macs map[string]int, want string) {
+	t.Helper()
+	if len(macs) == 0 {
+		t.Fatal("No packets from ateSrc captured on ATE port-2")
+	}
+	for mac, n := range macs {
+		if !strings.EqualFold(mac, want) {
+			t.Errorf("Captured %d packets sent to %s, want %s", n, mac, want)
+		}
+	}
+}
+
+// awaitNeighbors waits for the IPv4 and IPv6 entries of ateDst on DUT port2
+// to have origin and mac.
+func awaitNeighbors(t *testing.T, dut *ondatra.DUTDevice, origin oc.E_IfIp_NeighborOrigin, mac string) {
+	t.Helper()
+	const timeout = time.Minute
+	sub := gnmi.OC().Interface(dut.Port(t, "port2").Name()).Subinterface(0)
+
+	got4, ok := gnmi.Watch(t, dut, sub.Ipv4().Neighbor(ateDst.IPv4).State(), timeout, func(val *ygnmi.Value[*oc.Interface_Subinterface_Ipv4_Neighbor]) bool {
+		n, present := val.Val()
+		return present && n.GetOrigin() == origin && strings.EqualFold(n.GetLinkLayerAddress(), mac)
+	}).Await(t)
+	if !ok {
+		n, _ := got4.Val()
+		t.Errorf("IPv4 neighbor %s: got origin %v and MAC %q, want %v and %q", ateDst.IPv4, n.GetOrigin(), n.GetLinkLayerAddress(), origin, mac)
+	}
+
+	got6, ok := gnmi.Watch(t, dut, sub.Ipv6().Neighbor(ateDst.IPv6).State(), timeout, func(val *ygnmi.Value[*oc.Interface_Subinterface_Ipv6_Neighbor]) bool {
+		n, present := val.Val()
+		return present && n.GetOrigin() == origin && strings.EqualFold(n.GetLinkLayerAddress(), mac)
+	}).Await(t)
+	if !ok {
+		n, _ := got6.Val()
+		t.Errorf("IPv6 neighbor %s: got origin %v and MAC %q, want %v and %q", ateDst.IPv6, n.GetOrigin(), n.GetLinkLayerAddress(), origin, mac)
+	}
+}
+
+func TestStaticARPOverride(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut, true)
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	t.Run("Static", func(t *testing.T) {
+		macs := sendTraffic(t, ate, top)
+		awaitNeighbors(t, dut, oc.IfIp_NeighborOrigin_STATIC, staticMAC)
+		verifyNoLoss(t, ate)
+		verifyDstMACs(t, macs, staticMAC)
+	})
+
+	t.Run("Dynamic", func(t *testing.T) {
+		configureDUT(t, dut, false)
+		// Packets sent while the DUT resolves ateDst may be dropped, so
+		// only the destination MAC of the received packets is checked.
+		macs := sendTraffic(t, ate, top)
+		awaitNeighbors(t, dut, oc.IfIp_NeighborOrigin_DYNAMIC, ateDst.MAC)
+		verifyDstMACs(t, macs, ateDst.MAC)
+	})
+
+	t.Run("Static restored", func(t *testing.T) {
+		configureDUT(t, dut, true)
+		awaitNeighbors(t, dut, oc.IfIp_NeighborOrigin_STATIC, staticMAC)
+		macs := sendTraffic(t, ate, top)
+		awaitNeighbors(t, dut, oc.IfIp_NeighborOrigin_STATIC, staticMAC)
+		verifyNoLoss(t, ate)
+		verifyDstMACs(t, macs, staticMAC)
+	})
+}
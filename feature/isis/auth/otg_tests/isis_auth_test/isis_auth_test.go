@@ -0,0 +1,282 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isis_auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/isissession"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure HMAC-MD5 hello and LSP authentication from a keychain on the
+//     DUT and matching MD5 authentication on the ATE. Verify the adjacency
+//     comes up and no authentication failures are counted.
+//  2. Configure a different secret on the ATE. Verify the adjacency does not
+//     come up and authentication failures are counted.
+//  3. Configure a keychain with two keys with different secrets whose
+//     lifetimes overlap. Switch the ATE to the second secret when the second
+//     key becomes valid, and verify the adjacency stays up while the DUT rolls
+//     over from the first key to the second.
+//  4. Configure an HMAC-SHA-256 keychain and verify the keychain and IS-IS
+//     authentication config is reflected in state.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+//
+// Test notes:
+//   - IS-IS MD5 authentication does not carry a key ID, so the ATE uses a
+//     single MD5 secret at a time and is switched from the first key's secret
+//     to the second's during the overlap.
+//   - The key lifetimes are computed from the DUT's current-datetime so that
+//     clock skew between the test host and the DUT does not shift them.
+//   - OTG does not support HMAC-SHA authentication for IS-IS, so the
+//     HMAC-SHA-256 case only checks config and state on the DUT.
+
+const (
+	keychainName = "ISIS-AUTH"
+	md5Secret    = "isis-md5-secret"
+	md5Secret2   = "isis-md5-secret-2"
+	wrongSecret  = "isis-wrong-secret"
+	sha256Secret = "isis-sha256-secret"
+
+	// rolloverOverlap is how long the send lifetimes of the two keys in the
+	// rollover keychain overlap, and rolloverHold is how long the first key
+	// remains the only key in use after it is pushed.
+	rolloverOverlap = time.Minute
+	rolloverHold    = time.Minute
+)
+
+// keySpec describes one key of a keychain.
+type keySpec struct {
+	id         uint64
+	secret     string
+	algorithm  oc.E_KeychainTypes_CRYPTO_TYPE
+	start, end time.Time // Zero values leave the lifetime unbounded.
+}
+
+// keychain returns the OC keychain named name holding keys.
+func keychain(name string, keys ...keySpec) *oc.Keychain {
+	kc := &oc.Keychain{Name: ygot.String(name)}
+	for _, k := range keys {
+		key := kc.GetOrCreateKey(oc.UnionUint64(k.id))
+		key.SecretKey = ygot.String(k.secret)
+		key.CryptoAlgorithm = k.algorithm
+		if k.start.IsZero() && k.end.IsZero() {
+			continue
+		}
+		send := key.GetOrCreateSendLifetime()
+		recv := key.GetOrCreateReceiveLifetime()
+		if !k.start.IsZero() {
+			send.StartTime = ygot.Uint64(uint64(k.start.Unix()))
+			recv.StartTime = ygot.Uint64(uint64(k.start.Unix()))
+		}
+		if !k.end.IsZero() {
+			send.EndTime = ygot.Uint64(uint64(k.end.Unix()))
+			recv.EndTime = ygot.Uint64(uint64(k.end.Unix()))
+		}
+	}
+	return kc
+}
+
+// isisIntfName returns the name of the DUT IS-IS interface.
+func isisIntfName(ts *isissession.TestSession) string {
+	if deviations.ExplicitInterfaceInDefaultVRF(ts.DUT) {
+		return ts.DUTPort1.Name() + ".0"
+	}
+	return ts.DUTPort1.Name()
+}
+
+// configureDUTAuth enables level 2 LSP and hello authentication on
+// ts.DUTConf using the keychain named keychainName.
+func configureDUTAuth(ts *isissession.TestSession) {
+	ts.ConfigISIS(func(isis *oc.NetworkInstance_Protocol_Isis) {
+		isis.GetOrCreateGlobal().AuthenticationCheck = ygot.Bool(true)
+
+		auth := isis.GetOrCreateLevel(2).GetOrCreateAuthentication()
+		auth.Enabled = ygot.Bool(true)
+		auth.AuthType = oc.KeychainTypes_AUTH_TYPE_KEYCHAIN
+		auth.Keychain = ygot.String(keychainName)
+
+		hello := isis.GetOrCreateInterface(isisIntfName(ts)).GetOrCreateLevel(2).GetOrCreateHelloAuthentication()
+		hello.Enabled = ygot.Bool(true)
+		hello.AuthType = oc.KeychainTypes_AUTH_TYPE_KEYCHAIN
+		hello.Keychain = ygot.String(keychainName)
+	})
+}
+
+// dutTime returns the current time reported by the DUT.
+func dutTime(t *testing.T, ts *isissession.TestSession) time.Time {
+	t.Helper()
+	datetime := gnmi.Get(t, ts.DUT, gnmi.OC().System().CurrentDatetime().State())
+	now, err := time.Parse(time.RFC3339, datetime)
+	if err != nil {
+		t.Fatalf("Unable to parse DUT current-datetime %q: %v", datetime, err)
+	}
+	return now
+}
+
+// configureATEAuth enables MD5 LSP and hello authentication on the ATE IS-IS
+// router using secret.
+func configureATEAuth(ts *isissession.TestSession, secret string) {
+	ts.ATEIntf1.Isis().RouterAuth().AreaAuth().SetAuthType("md5").SetMd5(secret)
+	ts.ATEIntf1.Isis().RouterAuth().DomainAuth().SetAuthType("md5").SetMd5(secret)
+	ts.ATEIntf1.Isis().Interfaces().Items()[0].Authentication().SetAuthType("md5").SetMd5(secret)
+}
+
+// setup pushes kc and the IS-IS config with authentication to the DUT, and
+// starts IS-IS on the ATE using ateSecret.
+func setup(t *testing.T, kc *oc.Keychain, ateSecret string) *isissession.TestSession {
+	t.Helper()
+	ts := isissession.MustNew(t).WithISIS()
+	pushAuth(t, ts, kc, ateSecret)
+	return ts
+}
+
+// pushAuth pushes kc and the IS-IS config with authentication of ts to the
+// DUT, and starts IS-IS on the ATE using ateSecret.
+func pushAuth(t *testing.T, ts *isissession.TestSession, kc *oc.Keychain, ateSecret string) {
+	t.Helper()
+	gnmi.Replace(t, ts.DUT, gnmi.OC().Keychain(kc.GetName()).Config(), kc)
+	configureDUTAuth(ts)
+	configureATEAuth(ts, ateSecret)
+	fptest.LogQuery(t, "Keychain", gnmi.OC().Keychain(kc.GetName()).Config(), kc)
+	if err := ts.PushAndStart(t); err != nil {
+		t.Fatalf("Unable to push config: %v", err)
+	}
+}
+
+// verifyNoAuthFails checks that the DUT has not counted any IS-IS
+// authentication failures.
+func verifyNoAuthFails(t *testing.T, ts *isissession.TestSession) {
+	t.Helper()
+	statePath := isissession.ISISPath(ts.DUT)
+	if got := gnmi.Get(t, ts.DUT, statePath.Level(2).SystemLevelCounters().AuthFails().State()); got != 0 {
+		t.Errorf("Level 2 auth-fails: got %d, want 0", got)
+	}
+	if got := gnmi.Get(t, ts.DUT, statePath.Interface(isisIntfName(ts)).CircuitCounters().AuthFails().State()); got != 0 {
+		t.Errorf("Interface %s auth-fails: got %d, want 0", isisIntfName(ts), got)
+	}
+}
+
+func TestMatchingKey(t *testing.T) {
+	kc := keychain(keychainName, keySpec{id: 1, secret: md5Secret, algorithm: oc.KeychainTypes_CRYPTO_TYPE_HMAC_MD5})
+	ts := setup(t, kc, md5Secret)
+	ts.MustAdjacency(t)
+
+	t.Run("Keychain state", func(t *testing.T) {
+		key := gnmi.OC().Keychain(keychainName).Key(oc.UnionUint64(1))
+		if got, want := gnmi.Get(t, ts.DUT, key.CryptoAlgorithm().State()), oc.KeychainTypes_CRYPTO_TYPE_HMAC_MD5; got != want {
+			t.Errorf("Key 1 crypto-algorithm: got %v, want %v", got, want)
+		}
+		levelAuth := isissession.ISISPath(ts.DUT).Level(2).Authentication()
+		if got, want := gnmi.Get(t, ts.DUT, levelAuth.Keychain().State()), keychainName; got != want {
+			t.Errorf("Level 2 authentication keychain: got %q, want %q", got, want)
+		}
+		helloAuth := isissession.ISISPath(ts.DUT).Interface(isisIntfName(ts)).Level(2).HelloAuthentication()
+		if got, want := gnmi.Get(t, ts.DUT, helloAuth.Keychain().State()), keychainName; got != want {
+			t.Errorf("Level 2 hello authentication keychain: got %q, want %q", got, want)
+		}
+	})
+	t.Run("Auth counters", func(t *testing.T) {
+		verifyNoAuthFails(t, ts)
+	})
+}
+
+func TestMismatchedKey(t *testing.T) {
+	kc := keychain(keychainName, keySpec{id: 1, secret: md5Secret, algorithm: oc.KeychainTypes_CRYPTO_TYPE_HMAC_MD5})
+	ts := setup(t, kc, wrongSecret)
+
+	if adj, err := ts.AwaitAdjacency(); err == nil {
+		t.Errorf("IS-IS adjacency %s came up with mismatched keys, want no adjacency", adj)
+	}
+
+	authFails := isissession.ISISPath(ts.DUT).Level(2).SystemLevelCounters().AuthFails().State()
+	_, ok := gnmi.Watch(t, ts.DUT, authFails, time.Minute, func(val *ygnmi.Value[uint32]) bool {
+		v, present := val.Val()
+		return present && v > 0
+	}).Await(t)
+	if !ok {
+		t.Errorf("Level 2 auth-fails did not increase with mismatched keys")
+	}
+}
+
+func TestKeyRollover(t *testing.T) {
+	ts := isissession.MustNew(t).WithISIS()
+	now := dutTime(t, ts)
+	rollover := now.Add(rolloverHold)
+	kc := keychain(keychainName,
+		keySpec{id: 1, secret: md5Secret, algorithm: oc.KeychainTypes_CRYPTO_TYPE_HMAC_MD5, start: now.Add(-time.Hour), end: rollover.Add(rolloverOverlap)},
+		keySpec{id: 2, secret: md5Secret2, algorithm: oc.KeychainTypes_CRYPTO_TYPE_HMAC_MD5, start: rollover},
+	)
+	pushAuth(t, ts, kc, md5Secret)
+	adjID := ts.MustAdjacency(t)
+
+	// Watch the adjacency until well after key 1 expires; it must not leave
+	// the UP state while the ATE and the DUT move to key 2.
+	adjState := isissession.ISISPath(ts.DUT).Interface(isisIntfName(ts)).Level(2).Adjacency(adjID).AdjacencyState().State()
+	watch := rollover.Add(2 * rolloverOverlap).Sub(dutTime(t, ts))
+	t.Logf("Watching adjacency %s for %v across key rollover", adjID, watch)
+	watcher := gnmi.Watch(t, ts.DUT, adjState, watch, func(val *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+		v, present := val.Val()
+		return !present || v != oc.Isis_IsisInterfaceAdjState_UP
+	})
+
+	wait := rollover.Sub(dutTime(t, ts))
+	t.Logf("Switching the ATE to the key 2 secret in %v", wait)
+	time.Sleep(wait)
+	configureATEAuth(ts, md5Secret2)
+	ts.PushAndStartATE(t)
+
+	if got, flapped := watcher.Await(t); flapped {
+		t.Errorf("IS-IS adjacency %s left UP state during key rollover: %v", adjID, got)
+	}
+	verifyNoAuthFails(t, ts)
+}
+
+func TestSHA256Keychain(t *testing.T) {
+	ts := isissession.MustNew(t).WithISIS()
+	kc := keychain(keychainName, keySpec{id: 1, secret: sha256Secret, algorithm: oc.KeychainTypes_CRYPTO_TYPE_HMAC_SHA_256})
+	gnmi.Replace(t, ts.DUT, gnmi.OC().Keychain(keychainName).Config(), kc)
+	configureDUTAuth(ts)
+	if err := ts.PushDUT(context.Background(), t); err != nil {
+		t.Fatalf("Unable to push DUT config: %v", err)
+	}
+
+	key := gnmi.OC().Keychain(keychainName).Key(oc.UnionUint64(1))
+	if got, want := gnmi.Get(t, ts.DUT, key.CryptoAlgorithm().State()), oc.KeychainTypes_CRYPTO_TYPE_HMAC_SHA_256; got != want {
+		t.Errorf("Key 1 crypto-algorithm: got %v, want %v", got, want)
+	}
+	levelAuth := isissession.ISISPath(ts.DUT).Level(2).Authentication()
+	if got, want := gnmi.Get(t, ts.DUT, levelAuth.AuthType().State()), oc.KeychainTypes_AUTH_TYPE_KEYCHAIN; got != want {
+		t.Errorf("Level 2 authentication auth-type: got %v, want %v", got, want)
+	}
+	if got, want := gnmi.Get(t, ts.DUT, levelAuth.Keychain().State()), keychainName; got != want {
+		t.Errorf("Level 2 authentication keychain: got %q, want %q", got, want)
+	}
+}
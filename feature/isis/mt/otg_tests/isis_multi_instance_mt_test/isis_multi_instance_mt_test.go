@@ -0,0 +1,436 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package isis_multi_instance_mt_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstisis"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	areaAddress = "49.0001"
+	// mtIPv6 is the IS-IS multi-topology ID of IPv6 unicast, RFC 5120.
+	mtIPv6 = 2
+
+	adjTimeout   = 2 * time.Minute
+	routeTimeout = time.Minute
+	// stableTime is how long the adjacency of one instance must stay up
+	// while the other instance is disabled.
+	stableTime = 30 * time.Second
+)
+
+// instance is an IS-IS instance of the DUT and the ATE IS-IS router it is
+// adjacent to.
+type instance struct {
+	name     string
+	dutSysID string
+	ateSysID string
+	mt       bool
+	dut, ate *attrs.Attributes
+	port     string
+	advV4    string
+	advV6    string
+	advV4Len uint32
+	advV6Len uint32
+	dutIntf  string
+	adjSysID string
+}
+
+var (
+	// instA runs IPv6 in its own topology, and instB in the same topology
+	// as IPv4.
+	instA = &instance{
+		name:     "ISIS-MT",
+		dutSysID: "1920.0000.2001",
+		ateSysID: "640000000001",
+		mt:       true,
+		dut: &attrs.Attributes{
+			Desc:    "DUT to ATE IS-IS multi-topology",
+			IPv4:    "192.0.2.1",
+			IPv6:    "2001:db8::1",
+			IPv4Len: 30,
+			IPv6Len: 126,
+		},
+		ate: &attrs.Attributes{
+			Name:    "ateMT",
+			MAC:     "02:11:01:00:00:01",
+			IPv4:    "192.0.2.2",
+			IPv6:    "2001:db8::2",
+			IPv4Len: 30,
+			IPv6Len: 126,
+		},
+		port:     "port1",
+		advV4:    "198.51.100.0",
+		advV4Len: 24,
+		advV6:    "2001:db8:100::",
+		advV6Len: 64,
+	}
+	instB = &instance{
+		name:     "ISIS-ST",
+		dutSysID: "1920.0000.2002",
+		ateSysID: "640000000002",
+		dut: &attrs.Attributes{
+			Desc:    "DUT to ATE IS-IS single topology",
+			IPv4:    "192.0.2.5",
+			IPv6:    "2001:db8::5",
+			IPv4Len: 30,
+			IPv6Len: 126,
+		},
+		ate: &attrs.Attributes{
+			Name:    "ateST",
+			MAC:     "02:12:01:00:00:01",
+			IPv4:    "192.0.2.6",
+			IPv6:    "2001:db8::6",
+			IPv4Len: 30,
+			IPv6Len: 126,
+		},
+		port:     "port2",
+		advV4:    "203.0.113.0",
+		advV4Len: 24,
+		advV6:    "2001:db8:200::",
+		advV6Len: 64,
+	}
+	instances = []*instance{instA, instB}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Adjacency: each DUT IS-IS instance forms a level 2 adjacency with the
+//     ATE router on its own port only. The adjacency of the multi-topology
+//     instance is multi-topology with the IPv4 and IPv6 unicast topologies.
+//  2. LSDB: the LSDB of each instance holds the LSP of its ATE router and not
+//     that of the other instance's ATE router. The DUT LSP of the
+//     multi-topology instance has a multi-topology TLV with MT ID 2 and MT
+//     IPv6 reachability, and that of the single topology instance has IPv6
+//     reachability and no multi-topology TLV.
+//  3. Routes: the IPv4 and IPv6 prefixes advertised to each instance are
+//     installed in the AFT with IS-IS as the origin protocol.
+//  4. Independence: with the single topology instance disabled, its prefixes
+//     are withdrawn, while the adjacency and prefixes of the multi-topology
+//     instance are not affected.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The ATE router of the multi-topology instance has its interface in
+//     MT ID 0 and 2.
+
+// lspID returns the ID of LSP 0 of system sysID, which is either in dotted
+// or plain notation.
+func lspID(sysID string) string {
+	s := strings.ReplaceAll(sysID, ".", "")
+	return fmt.Sprintf("%s.%s.%s.00-00", s[0:4], s[4:8], s[8:12])
+}
+
+func isisPath(dut *ondatra.DUTDevice, inst *instance) *netinstisis.NetworkInstance_Protocol_IsisPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, inst.name).Isis()
+}
+
+// isisProtocol returns the config of IS-IS instance inst of the DUT.
+func isisProtocol(dut *ondatra.DUTDevice, inst *instance) *oc.NetworkInstance_Protocol {
+	prot := &oc.NetworkInstance_Protocol{
+		Identifier: oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS,
+		Name:       ygot.String(inst.name),
+		Enabled:    ygot.Bool(true),
+	}
+	isis := prot.GetOrCreateIsis()
+	glob := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		glob.Instance = ygot.String(inst.name)
+	}
+	glob.Net = []string{fmt.Sprintf("%s.%s.00", areaAddress, inst.dutSysID)}
+	glob.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	glob.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	v6 := glob.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV6, oc.IsisTypes_SAFI_TYPE_UNICAST)
+	v6.Enabled = ygot.Bool(true)
+	if inst.mt {
+		// IPv6 unicast in its own topology rather than in that of IPv4.
+		mt := v6.GetOrCreateMultiTopology()
+		mt.AfiName = oc.IsisTypes_AFI_TYPE_IPV6
+		mt.SafiName = oc.IsisTypes_SAFI_TYPE_UNICAST
+	}
+	level := isis.GetOrCreateLevel(2)
+	level.MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+	if deviations.ISISLevelEnabled(dut) {
+		level.Enabled = ygot.Bool(true)
+	}
+
+	intf := isis.GetOrCreateInterface(inst.dutIntf)
+	intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+	intf.Enabled = ygot.Bool(true)
+	if deviations.ISISInterfaceLevel1DisableRequired(dut) {
+		intf.GetOrCreateLevel(1).Enabled = ygot.Bool(false)
+	} else {
+		intf.GetOrCreateLevel(2).Enabled = ygot.Bool(true)
+	}
+	intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV6, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.ISISInterfaceAfiUnsupported(dut) {
+		intf.Af = nil
+	}
+	return prot
+}
+
+// configureDUT configures the DUT ports and an IS-IS instance on each.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, inst := range instances {
+		p := dut.Port(t, inst.port)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), inst.dut.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+			inst.dutIntf = p.Name() + ".0"
+		} else {
+			inst.dutIntf = p.Name()
+		}
+	}
+	for _, inst := range instances {
+		prot := isisProtocol(dut, inst)
+		path := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, inst.name)
+		gnmi.Replace(t, dut, path.Config(), prot)
+		fptest.LogQuery(t, "IS-IS instance "+inst.name, path.Config(), prot)
+	}
+}
+
+// configureATE returns the ATE config with an IS-IS router on each port
+// advertising the prefixes of its instance.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for _, inst := range instances {
+		dev := inst.ate.AddToOTG(top, ate.Port(t, inst.port), inst.dut)
+		isis := dev.Isis().SetSystemId(inst.ateSysID).SetName(inst.ate.Name + ".ISIS")
+		isis.Basic().SetHostname(isis.Name()).SetLearnedLspFilter(true)
+		isis.Advanced().SetAreaAddresses([]string{strings.ReplaceAll(areaAddress, ".", "")})
+		intf := isis.Interfaces().Add().
+			SetEthName(dev.Ethernets().Items()[0].Name()).
+			SetName(inst.ate.Name + ".ISISIntf").
+			SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+			SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+			SetMetric(10)
+		if inst.mt {
+			intf.MultiTopologyIds().Add().SetMtId(0).SetLinkMetric(10)
+			intf.MultiTopologyIds().Add().SetMtId(mtIPv6).SetLinkMetric(10)
+		}
+		intf.Advanced().SetAutoAdjustMtu(true).SetAutoAdjustArea(true).SetAutoAdjustSupportedProtocols(true)
+
+		v4 := isis.V4Routes().Add().SetName(inst.ate.Name + ".ISISV4").SetLinkMetric(10)
+		v4.Addresses().Add().SetAddress(inst.advV4).SetPrefix(inst.advV4Len)
+		v6 := isis.V6Routes().Add().SetName(inst.ate.Name + ".ISISV6").SetLinkMetric(10)
+		v6.Addresses().Add().SetAddress(inst.advV6).SetPrefix(inst.advV6Len)
+	}
+	return top
+}
+
+// awaitAdjacency waits for an UP adjacency of inst on its interface, and
+// returns it.
+func awaitAdjacency(t *testing.T, dut *ondatra.DUTDevice, inst *instance) *oc.NetworkInstance_Protocol_Isis_Interface_Level_Adjacency {
+	t.Helper()
+	var adj *oc.NetworkInstance_Protocol_Isis_Interface_Level_Adjacency
+	_, ok := gnmi.WatchAll(t, dut, isisPath(dut, inst).Interface(inst.dutIntf).Level(2).AdjacencyAny().State(), adjTimeout, func(val *ygnmi.Value[*oc.NetworkInstance_Protocol_Isis_Interface_Level_Adjacency]) bool {
+		a, present := val.Val()
+		if !present || a.GetAdjacencyState() != oc.Isis_IsisInterfaceAdjState_UP {
+			return false
+		}
+		adj = a
+		return true
+	}).Await(t)
+	if !ok {
+		t.Fatalf("No UP adjacency on %s in IS-IS instance %s", inst.dutIntf, inst.name)
+	}
+	return adj
+}
+
+// lspIDs returns the level 2 LSP IDs in the LSDB of inst.
+func lspIDs(t *testing.T, dut *ondatra.DUTDevice, inst *instance) map[string]bool {
+	t.Helper()
+	ids := make(map[string]bool)
+	for _, v := range gnmi.LookupAll(t, dut, isisPath(dut, inst).Level(2).LspAny().LspId().State()) {
+		if id, ok := v.Val(); ok {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// aftEntry is an IPv4 or IPv6 AFT entry.
+type aftEntry interface {
+	GetOriginProtocol() oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE
+}
+
+// verifyRoute verifies that the AFT entry q of prefix of inst is installed by
+// IS-IS if installed is true, or is not installed otherwise.
+func verifyRoute[T aftEntry](t *testing.T, dut *ondatra.DUTDevice, inst *instance, prefix string, q ygnmi.SingletonQuery[T], installed bool) {
+	t.Helper()
+	_, ok := gnmi.Watch(t, dut, q, routeTimeout, func(val *ygnmi.Value[T]) bool {
+		e, present := val.Val()
+		if !installed {
+			return !present
+		}
+		// Some platforms do not report the origin protocol.
+		return present && (e.GetOriginProtocol() == oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS || e.GetOriginProtocol() == oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_UNSET)
+	}).Await(t)
+	switch {
+	case !ok && installed:
+		t.Errorf("Prefix %s of IS-IS instance %s is not installed by IS-IS", prefix, inst.name)
+	case !ok:
+		t.Errorf("Prefix %s of IS-IS instance %s is still installed", prefix, inst.name)
+	}
+}
+
+// verifyRoutes verifies that the prefixes of inst are installed by IS-IS if
+// installed is true, or are not installed otherwise.
+func verifyRoutes(t *testing.T, dut *ondatra.DUTDevice, inst *instance, installed bool) {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Afts()
+	v4 := fmt.Sprintf("%s/%d", inst.advV4, inst.advV4Len)
+	v6 := fmt.Sprintf("%s/%d", inst.advV6, inst.advV6Len)
+	verifyRoute(t, dut, inst, v4, afts.Ipv4Entry(v4).State(), installed)
+	verifyRoute(t, dut, inst, v6, afts.Ipv6Entry(v6).State(), installed)
+}
+
+func TestISISMultiInstanceMT(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+
+	t.Run("Adjacency", func(t *testing.T) {
+		for _, inst := range instances {
+			adj := awaitAdjacency(t, dut, inst)
+			inst.adjSysID = adj.GetSystemId()
+			t.Logf("IS-IS instance %s adjacency with %s: multi-topology %t, topologies %v", inst.name, adj.GetSystemId(), adj.GetMultiTopology(), adj.Topology)
+			if got, want := strings.ReplaceAll(adj.GetSystemId(), ".", ""), inst.ateSysID; got != want {
+				t.Errorf("IS-IS instance %s adjacency system-id: got %s, want %s", inst.name, got, want)
+			}
+			if got := adj.GetMultiTopology(); got != inst.mt {
+				t.Errorf("IS-IS instance %s adjacency multi-topology: got %t, want %t", inst.name, got, inst.mt)
+			}
+			if inst.mt {
+				topologies := make(map[oc.E_IsisTypes_AFI_SAFI_TYPE]bool)
+				for _, tp := range adj.Topology {
+					topologies[tp] = true
+				}
+				for _, want := range []oc.E_IsisTypes_AFI_SAFI_TYPE{oc.IsisTypes_AFI_SAFI_TYPE_IPV4_UNICAST, oc.IsisTypes_AFI_SAFI_TYPE_IPV6_UNICAST} {
+					if !topologies[want] {
+						t.Errorf("IS-IS instance %s adjacency topologies: got %v, want %v", inst.name, adj.Topology, want)
+					}
+				}
+			}
+		}
+		// Each instance has no adjacency on the port of the other.
+		for _, inst := range instances {
+			for _, other := range instances {
+				if other == inst {
+					continue
+				}
+				for _, v := range gnmi.LookupAll(t, dut, isisPath(dut, inst).Interface(other.dutIntf).LevelAny().AdjacencyAny().SystemId().State()) {
+					if id, ok := v.Val(); ok {
+						t.Errorf("IS-IS instance %s has an adjacency with %s on %s of instance %s", inst.name, id, other.dutIntf, other.name)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("LSDB", func(t *testing.T) {
+		for _, inst := range instances {
+			ate := lspID(inst.ateSysID)
+			_, ok := gnmi.Watch(t, dut, isisPath(dut, inst).Level(2).Lsp(ate).LspId().State(), adjTimeout, func(val *ygnmi.Value[string]) bool {
+				return val.IsPresent()
+			}).Await(t)
+			if !ok {
+				t.Errorf("LSDB of IS-IS instance %s has no LSP %s of its ATE router", inst.name, ate)
+			}
+			ids := lspIDs(t, dut, inst)
+			t.Logf("LSDB of IS-IS instance %s: %v", inst.name, ids)
+			for _, other := range instances {
+				if other != inst && ids[lspID(other.ateSysID)] {
+					t.Errorf("LSDB of IS-IS instance %s has LSP %s of the ATE router of instance %s", inst.name, lspID(other.ateSysID), other.name)
+				}
+			}
+
+			own := isisPath(dut, inst).Level(2).Lsp(lspID(inst.dutSysID))
+			mt, hasMT := gnmi.Lookup(t, dut, own.Tlv(oc.IsisLsdbTypes_ISIS_TLV_TYPE_MULTI_TOPOLOGY).State()).Val()
+			_, hasMTv6 := gnmi.Lookup(t, dut, own.Tlv(oc.IsisLsdbTypes_ISIS_TLV_TYPE_MT_IPV6_REACHABILITY).State()).Val()
+			_, hasV6 := gnmi.Lookup(t, dut, own.Tlv(oc.IsisLsdbTypes_ISIS_TLV_TYPE_IPV6_REACHABILITY).State()).Val()
+			if inst.mt {
+				if !hasMT || mt.GetMultiTopology().GetTopology(mtIPv6) == nil {
+					t.Errorf("DUT LSP of IS-IS instance %s has no multi-topology TLV with MT ID %d", inst.name, mtIPv6)
+				}
+				if !hasMTv6 {
+					t.Errorf("DUT LSP of IS-IS instance %s has no MT IPv6 reachability TLV", inst.name)
+				}
+				continue
+			}
+			if hasMT {
+				t.Errorf("DUT LSP of IS-IS instance %s has a multi-topology TLV, want none", inst.name)
+			}
+			if !hasV6 {
+				t.Errorf("DUT LSP of IS-IS instance %s has no IPv6 reachability TLV", inst.name)
+			}
+		}
+	})
+
+	t.Run("Routes", func(t *testing.T) {
+		for _, inst := range instances {
+			verifyRoutes(t, dut, inst, true)
+		}
+	})
+
+	t.Run("Independence", func(t *testing.T) {
+		enabled := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, instB.name).Enabled()
+		gnmi.Replace(t, dut, enabled.Config(), false)
+		defer func() {
+			gnmi.Replace(t, dut, enabled.Config(), true)
+			awaitAdjacency(t, dut, instB)
+		}()
+
+		verifyRoutes(t, dut, instB, false)
+		adjState := isisPath(dut, instA).Interface(instA.dutIntf).Level(2).Adjacency(instA.adjSysID).AdjacencyState().State()
+		got, flapped := gnmi.Watch(t, dut, adjState, stableTime, func(val *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+			v, present := val.Val()
+			return !present || v != oc.Isis_IsisInterfaceAdjState_UP
+		}).Await(t)
+		if flapped {
+			t.Errorf("Adjacency of IS-IS instance %s left UP with instance %s disabled: %v", instA.name, instB.name, got)
+		}
+		verifyRoutes(t, dut, instA, true)
+	})
+}
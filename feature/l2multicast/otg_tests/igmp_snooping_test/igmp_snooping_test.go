@@ -0,0 +1,359 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package igmp_snooping_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	vlanID = 100
+	group  = "239.1.1.1"
+	// groupMAC is the multicast MAC address of group.
+	groupMAC    = "01:00:5e:01:01:01"
+	allHosts    = "224.0.0.1"
+	allHostsMAC = "01:00:5e:00:00:01"
+	// dutQuerier is the querier address of the DUT.
+	dutQuerier = "192.0.2.254"
+	// ateQuerier is lower than dutQuerier, so it wins the querier election.
+	ateQuerier = "192.0.2.2"
+	// queryInterval is the query interval in seconds.  With the default
+	// robustness of 2 and query response interval of 10 seconds, the group
+	// membership interval is 30 seconds and the other querier present
+	// interval is 25 seconds.
+	queryInterval  = 10
+	membershipWait = 60 * time.Second
+	dataPPS        = 1000
+	dataPackets    = 10000
+	// maxLeakPct is the percentage of the data packets a port without
+	// members may receive, to allow for control traffic such as queries.
+	maxLeakPct = 1
+)
+
+// hosts are the source and the host on each ATE port.
+var hosts = map[string]struct{ mac, ip string }{
+	"port1": {"02:00:01:01:01:01", "192.0.2.10"},
+	"port2": {"02:00:02:01:01:01", "192.0.2.11"},
+	"port3": {"02:00:03:01:01:01", "192.0.2.12"},
+	"port4": {"02:00:04:01:01:01", "192.0.2.13"},
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. SnoopingTable: hosts on ATE port-2 and port-3 send IGMPv2 reports for
+//     the group.  Verify the DUT snooping table has the group.
+//  2. ConstrainedFlooding: ATE port-1 sends traffic to the group.  Verify it
+//     is received on ATE port-2 and port-3 and not flooded to ATE port-4.
+//  3. QuerierElection: verify the DUT is the querier, then send queries from
+//     a lower address on ATE port-4 and verify the ATE becomes the querier.
+//     Stop the queries and verify the DUT becomes the querier again.
+//  4. Aging: stop the reports and verify the group ages out of the snooping
+//     table after the group membership interval.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                    dut:port3 <--> ate:port3
+//	                    dut:port4 <--> ate:port4
+//
+// All DUT ports are access ports in the same VLAN.
+//
+// Test notes:
+//   - IGMP snooping is not modelled in OpenConfig, so it is configured
+//     through CLI, and the snooping table and querier are read with CLI show
+//     commands.
+//   - OTG does not emulate IGMP hosts, so reports and queries are sent as raw
+//     IGMPv2 flows.
+
+// snoopingCLI holds the vendor CLI to configure and inspect IGMP snooping.
+type snoopingCLI struct {
+	// config enables snooping and the querier on the VLAN.
+	config string
+	// remove removes the querier.
+	remove string
+	// groups lists the snooping table of the VLAN.
+	groups string
+	// querier shows the querier of the VLAN.
+	querier string
+}
+
+// snoopingCLIs returns the IGMP snooping CLI for dut.
+func snoopingCLIs(t *testing.T, dut *ondatra.DUTDevice) snoopingCLI {
+	t.Helper()
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		return snoopingCLI{
+			config: fmt.Sprintf(`
+ip igmp snooping
+ip igmp snooping vlan %[1]d
+ip igmp snooping vlan %[1]d querier
+ip igmp snooping vlan %[1]d querier address %[2]s
+ip igmp snooping vlan %[1]d querier query-interval %[3]d
+`, vlanID, dutQuerier, queryInterval),
+			remove: fmt.Sprintf(`
+no ip igmp snooping vlan %d querier
+`, vlanID),
+			groups:  fmt.Sprintf("show ip igmp snooping groups vlan %d", vlanID),
+			querier: fmt.Sprintf("show ip igmp snooping querier vlan %d", vlanID),
+		}
+	default:
+		t.Fatalf("IGMP snooping CLI is not defined for vendor %s", dut.Vendor())
+	}
+	return snoopingCLI{}
+}
+
+// configureDUT makes all DUT ports access ports in vlanID.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	ni := deviations.DefaultNetworkInstance(dut)
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(ni).Vlan(vlanID).Config(), &oc.NetworkInstance_Vlan{
+		VlanId: ygot.Uint16(vlanID),
+		Status: oc.Vlan_Status_ACTIVE,
+	})
+	for _, p := range dut.Ports() {
+		i := &oc.Interface{
+			Name: ygot.String(p.Name()),
+			Type: oc.IETFInterfaces_InterfaceType_ethernetCsmacd,
+		}
+		if deviations.InterfaceEnabled(dut) {
+			i.Enabled = ygot.Bool(true)
+		}
+		sv := i.GetOrCreateEthernet().GetOrCreateSwitchedVlan()
+		sv.InterfaceMode = oc.Vlan_VlanModeType_ACCESS
+		sv.AccessVlan = ygot.Uint16(vlanID)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), i)
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+	}
+}
+
+// igmpFlow adds a flow sending one IGMPv2 message per second from the host
+// on port.
+func igmpFlow(top gosnappi.Config, name, port, srcIP, dstMAC, dstIP string, typ, maxResp uint32, grp string) {
+	flow := top.Flows().Add().SetName(name)
+	flow.TxRx().Port().SetTxName(port)
+	flow.Rate().SetPps(1)
+	eth := flow.Packet().Add().Ethernet()
+	eth.Src().SetValue(hosts[port].mac)
+	eth.Dst().SetValue(dstMAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(srcIP)
+	v4.Dst().SetValue(dstIP)
+	v4.TimeToLive().SetValue(1)
+	v4.Protocol().SetValue(2)
+	igmp := flow.Packet().Add().Igmpv1()
+	// The IGMPv1 header has the layout of IGMPv2 messages, with the IGMPv2
+	// type split into the version and type nibbles.
+	igmp.Version().SetValue(1)
+	igmp.Type().SetValue(typ)
+	igmp.Unused().SetValue(maxResp)
+	igmp.GroupAddress().SetValue(grp)
+}
+
+// addReportFlows adds IGMPv2 membership report flows for group from the hosts
+// on ports.
+func addReportFlows(top gosnappi.Config, ports ...string) {
+	for _, p := range ports {
+		igmpFlow(top, "report-"+p, p, hosts[p].ip, groupMAC, group, 6, 0, group)
+	}
+}
+
+// addQueryFlow adds an IGMPv2 general query flow from ateQuerier on port.
+func addQueryFlow(top gosnappi.Config, port string) {
+	igmpFlow(top, "query-"+port, port, ateQuerier, allHostsMAC, allHosts, 1, 100, "0.0.0.0")
+}
+
+// addDataFlow adds a flow of dataPackets UDP packets from the source on
+// port-1 to group.
+func addDataFlow(top gosnappi.Config) {
+	flow := top.Flows().Add().SetName("data")
+	flow.TxRx().Port().SetTxName("port1")
+	flow.Rate().SetPps(dataPPS)
+	flow.Size().SetFixed(256)
+	flow.Duration().FixedPackets().SetPackets(dataPackets)
+	eth := flow.Packet().Add().Ethernet()
+	eth.Src().SetValue(hosts["port1"].mac)
+	eth.Dst().SetValue(groupMAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(hosts["port1"].ip)
+	v4.Dst().SetValue(group)
+	udp := flow.Packet().Add().Udp()
+	udp.SrcPort().SetValue(5000)
+	udp.DstPort().SetValue(5000)
+}
+
+// newATEConfig returns an ATE config with all ports and no flows.
+func newATEConfig(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for _, p := range ate.Ports() {
+		top.Ports().Add().SetName(p.ID())
+	}
+	return top
+}
+
+// runCLI runs a CLI show command and returns its output.
+func runCLI(t *testing.T, dut *ondatra.DUTDevice, cmd string) string {
+	t.Helper()
+	res, err := dut.RawAPIs().CLI(t).RunCommand(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("%q failed: %v", cmd, err)
+	}
+	return res.Output()
+}
+
+// awaitCLI polls the output of cmd until want reports true for it, and
+// returns whether it did before timeout.
+func awaitCLI(t *testing.T, dut *ondatra.DUTDevice, cmd string, timeout time.Duration, want func(string) bool) bool {
+	t.Helper()
+	var out string
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(5 * time.Second) {
+		if out = runCLI(t, dut, cmd); want(out) {
+			return true
+		}
+	}
+	t.Logf("%q output:\n%s", cmd, out)
+	return false
+}
+
+// inFrames returns the number of frames received on each ATE port.
+func inFrames(t *testing.T, ate *ondatra.ATEDevice) map[string]uint64 {
+	t.Helper()
+	frames := map[string]uint64{}
+	for _, p := range ate.Ports() {
+		frames[p.ID()] = gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(p.ID()).Counters().InFrames().State())
+	}
+	return frames
+}
+
+// startFlows starts transmitting the named flows.
+func startFlows(t *testing.T, ate *ondatra.ATEDevice, names ...string) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().SetState(gosnappi.StateTrafficFlowTransmitState.START).SetFlowNames(names)
+	ate.OTG().SetControlState(t, cs)
+}
+
+// sendData sends the data flow while the control flows run, and returns the
+// number of frames received on each ATE port while it was sent.
+func sendData(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) map[string]uint64 {
+	t.Helper()
+	before := inFrames(t, ate)
+	startFlows(t, ate, "data")
+	time.Sleep(time.Duration(dataPackets/dataPPS)*time.Second + 5*time.Second)
+	otgutils.LogPortMetrics(t, ate.OTG(), top)
+	after := inFrames(t, ate)
+	delta := map[string]uint64{}
+	for p, n := range after {
+		delta[p] = n - before[p]
+	}
+	return delta
+}
+
+// hasGroup reports whether a snooping table lists group.
+func hasGroup(out string) bool {
+	return strings.Contains(out, group)
+}
+
+func TestIGMPSnooping(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if deviations.L2SwitchingUnsupported(dut) {
+		t.Skip("IGMP snooping requires L2 switching, which is not supported by the device")
+	}
+	cli := snoopingCLIs(t, dut)
+	configureDUT(t, dut)
+	helpers.GnmiCLIConfig(t, dut, cli.config)
+	defer helpers.GnmiCLIConfig(t, dut, cli.remove)
+
+	top := newATEConfig(t, ate)
+	addReportFlows(top, "port2", "port3")
+	addDataFlow(top)
+	ate.OTG().PushConfig(t, top)
+	startFlows(t, ate, "report-port2", "report-port3")
+	defer ate.OTG().StopTraffic(t)
+
+	t.Run("SnoopingTable", func(t *testing.T) {
+		if !awaitCLI(t, dut, cli.groups, membershipWait, hasGroup) {
+			t.Errorf("Snooping table of VLAN %d: group %s not learned from reports on ATE port-2 and port-3", vlanID, group)
+		}
+	})
+
+	t.Run("ConstrainedFlooding", func(t *testing.T) {
+		rx := sendData(t, ate, top)
+		for _, p := range []string{"port2", "port3"} {
+			if rx[p] < dataPackets {
+				t.Errorf("Group traffic received on ATE %s: got %d frames, want at least %d", p, rx[p], dataPackets)
+			}
+		}
+		if limit := uint64(dataPackets * maxLeakPct / 100); rx["port4"] > limit {
+			t.Errorf("Group traffic flooded to ATE port4 without members: got %d frames, want at most %d", rx["port4"], limit)
+		}
+	})
+
+	t.Run("QuerierElection", func(t *testing.T) {
+		if !awaitCLI(t, dut, cli.querier, membershipWait, func(out string) bool { return strings.Contains(out, dutQuerier) }) {
+			t.Fatalf("DUT is not the querier of VLAN %d: want querier %s", vlanID, dutQuerier)
+		}
+
+		addQueryFlow(top, "port4")
+		ate.OTG().PushConfig(t, top)
+		startFlows(t, ate, "report-port2", "report-port3", "query-port4")
+		if !awaitCLI(t, dut, cli.querier, membershipWait, func(out string) bool { return strings.Contains(out, ateQuerier) }) {
+			t.Errorf("Querier of VLAN %d with queries from a lower address: want %s", vlanID, ateQuerier)
+		}
+
+		// Remove the query flow and restart the reports.
+		top.Flows().Clear()
+		addReportFlows(top, "port2", "port3")
+		addDataFlow(top)
+		ate.OTG().PushConfig(t, top)
+		startFlows(t, ate, "report-port2", "report-port3")
+		if !awaitCLI(t, dut, cli.querier, 2*membershipWait, func(out string) bool { return strings.Contains(out, dutQuerier) }) {
+			t.Errorf("Querier of VLAN %d after the ATE queries stopped: want %s", vlanID, dutQuerier)
+		}
+	})
+
+	t.Run("Aging", func(t *testing.T) {
+		if !awaitCLI(t, dut, cli.groups, membershipWait, hasGroup) {
+			t.Fatalf("Snooping table of VLAN %d: group %s not learned before aging", vlanID, group)
+		}
+		ate.OTG().StopTraffic(t)
+		start := time.Now()
+		if !awaitCLI(t, dut, cli.groups, 2*membershipWait, func(out string) bool { return !hasGroup(out) }) {
+			t.Errorf("Snooping table of VLAN %d: group %s did not age out within %v of the last report", vlanID, group, 2*membershipWait)
+		}
+		t.Logf("Group %s aged out after %v", group, time.Since(start))
+	})
+}
@@ -0,0 +1,413 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rsvp_frr_test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var maxFRRTime = flag.Duration("max_frr_time", 50*time.Millisecond, "Maximum traffic loss time when the protected link fails.")
+
+const (
+	isisName    = "DEFAULT"
+	dutAreaAddr = "49.0001"
+	dutSysID    = "1920.0000.2001"
+	ateAreaAddr = "49.0002"
+	ateSysID    = "640000000001"
+
+	// primaryMetric and bypassMetric are the IS-IS metrics of the links to
+	// ATE port-2 and ATE port-3, so that the tail is reached over ATE port-2
+	// unless its link fails.
+	primaryMetric = 10
+	bypassMetric  = 20
+
+	lspName      = "LSP_FRR"
+	primaryPath  = "PRIMARY"
+	explicitPath = "VIA_PORT2"
+
+	dutLoopbackIP  = "203.0.113.1"
+	tailLoopbackIP = "203.0.113.100"
+	dstPrefix      = "198.51.100.0"
+	dstPrefixLen   = 24
+	dstCount       = 250
+
+	flowName    = "to-tail"
+	pps         = 100000
+	lspTimeout  = 3 * time.Minute
+	lossTol     = 0.001
+	trafficTime = 10 * time.Second
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "ateTail", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30}
+	atePort3 = attrs.Attributes{Name: "ateTail.port3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30}
+
+	dutLoopback = attrs.Attributes{Desc: "dutLoopback", IPv4: dutLoopbackIP, IPv4Len: 32}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Bring up an RSVP-TE LSP from the DUT to the tail loopback over an
+//     explicit path through ATE port-2, requesting link protection.  Verify
+//     the LSP and its RSVP session are up, the session requests link
+//     protection, and a bypass LSP protecting the link to ATE port-2 is up.
+//  2. Send traffic from ATE port-1 to a prefix behind the tail, which the DUT
+//     forwards over the LSP using IS-IS shortcuts, and verify it is received
+//     on ATE port-2 without loss.
+//  3. Take down the ATE port-2 link, and verify the traffic is received on
+//     ATE port-3, the LSP stays up, and the traffic loss time is within
+//     --max_frr_time.
+//
+// Topology:
+//
+//	                                 |---> ate:port2 --|
+//	ate:port1 ---> port1:dut:port2 --|                 |-- tail
+//	                     dut:port3 --|---> ate:port3 --|
+//
+// Test notes:
+//   - ATE port-2 and ATE port-3 are interfaces of a single emulated router,
+//     the tail, which is the egress of the LSP and of the bypass LSP.  The
+//     bypass LSP is signalled by the DUT over the link to ATE port-3.
+//   - The traffic loss time is the number of packets lost divided by the
+//     packet rate.
+
+// interfaceID returns the name of the routed subinterface of port.
+func interfaceID(dut *ondatra.DUTDevice, name string) string {
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		return name + ".0"
+	}
+	return name
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+	}{
+		{"port1", dutPort1},
+		{"port2", dutPort2},
+		{"port3", dutPort3},
+	} {
+		dp := dut.Port(t, p.port)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+	lb := netutil.LoopbackInterface(t, dut, 0)
+	lo := dutLoopback.NewOCInterface(lb, dut)
+	lo.Type = oc.IETFInterfaces_InterfaceType_softwareLoopback
+	gnmi.Update(t, dut, gnmi.OC().Interface(lb).Config(), lo)
+
+	ni := &oc.NetworkInstance{Name: ygot.String(deviations.DefaultNetworkInstance(dut))}
+	configureISIS(t, dut, ni, lb)
+	configureMPLS(t, dut, ni)
+	gnmi.Update(t, dut, gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Config(), ni)
+}
+
+// configureISIS enables IS-IS on DUT port-2, DUT port-3 and the loopback, and
+// installs routes over RSVP-TE LSPs with IS-IS shortcuts.
+func configureISIS(t *testing.T, dut *ondatra.DUTDevice, ni *oc.NetworkInstance, lb string) {
+	t.Helper()
+	isis := ni.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).GetOrCreateIsis()
+	g := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		g.Instance = ygot.String(isisName)
+	}
+	g.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	g.Net = []string{fmt.Sprintf("%s.%s.00", dutAreaAddr, dutSysID)}
+	g.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	g.GetOrCreateAfi(oc.IsisTypes_AFI_TYPE_IPV4).NhType = []oc.E_MplsTypes_PATH_SETUP_PROTOCOL{oc.MplsTypes_PATH_SETUP_PROTOCOL_PATH_SETUP_RSVP}
+	isis.GetOrCreateLevel(2).MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+
+	for _, i := range []struct {
+		name    string
+		metric  uint32
+		passive bool
+	}{
+		{interfaceID(dut, dut.Port(t, "port2").Name()), primaryMetric, false},
+		{interfaceID(dut, dut.Port(t, "port3").Name()), bypassMetric, false},
+		{lb, 0, true},
+	} {
+		intf := isis.GetOrCreateInterface(i.name)
+		intf.Enabled = ygot.Bool(true)
+		if i.passive {
+			intf.Passive = ygot.Bool(true)
+		} else {
+			intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+		}
+		intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+		if deviations.ISISInterfaceAfiUnsupported(dut) {
+			intf.Af = nil
+		}
+		lvl := intf.GetOrCreateLevel(2)
+		lvl.Enabled = ygot.Bool(true)
+		af := lvl.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST)
+		af.Enabled = ygot.Bool(true)
+		if i.metric != 0 {
+			af.Metric = ygot.Uint32(i.metric)
+		}
+		if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+			af.Enabled = nil
+		}
+	}
+}
+
+// configureMPLS enables MPLS and RSVP-TE with link protection on DUT port-2
+// and DUT port-3, and configures the LSP to the tail over an explicit path
+// through ATE port-2.
+func configureMPLS(t *testing.T, dut *ondatra.DUTDevice, ni *oc.NetworkInstance) {
+	t.Helper()
+	mpls := ni.GetOrCreateMpls()
+	rsvp := mpls.GetOrCreateSignalingProtocols().GetOrCreateRsvpTe()
+	for _, port := range []string{"port2", "port3"} {
+		name := dut.Port(t, port).Name()
+		id := interfaceID(dut, name)
+		gi := mpls.GetOrCreateGlobal().GetOrCreateInterface(id)
+		gi.MplsEnabled = ygot.Bool(true)
+		gi.GetOrCreateInterfaceRef().Interface = ygot.String(name)
+		gi.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+		ti := mpls.GetOrCreateInterface(id)
+		ti.GetOrCreateInterfaceRef().Interface = ygot.String(name)
+		ti.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+		ri := rsvp.GetOrCreateInterface(id)
+		ri.GetOrCreateInterfaceRef().Interface = ygot.String(name)
+		ri.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+		ri.GetOrCreateProtection().LinkProtectionStyleRequested = oc.MplsTypes_PROTECTION_TYPE_LINK_PROTECTION_REQUIRED
+	}
+
+	cp := mpls.GetOrCreateLsps().GetOrCreateConstrainedPath()
+	ero := cp.GetOrCreateNamedExplicitPath(explicitPath).GetOrCreateExplicitRouteObject(1)
+	ero.Address = ygot.String(atePort2.IPv4)
+	ero.HopType = oc.Mpls_MplsHopType_STRICT
+
+	tunnel := cp.GetOrCreateTunnel(lspName)
+	tunnel.Type = oc.MplsTypes_TUNNEL_TYPE_P2P
+	tunnel.SignalingProtocol = oc.MplsTypes_PATH_SETUP_PROTOCOL_PATH_SETUP_RSVP
+	tunnel.AdminStatus = oc.MplsTypes_TUNNEL_ADMIN_STATUS_ADMIN_UP
+	tunnel.Source = ygot.String(dutLoopbackIP)
+	tunnel.ProtectionStyleRequested = oc.MplsTypes_PROTECTION_TYPE_LINK_PROTECTION_REQUIRED
+	tunnel.ShortcutEligible = ygot.Bool(true)
+	p2p := tunnel.GetOrCreateP2PTunnelAttributes()
+	p2p.Destination = ygot.String(tailLoopbackIP)
+	path := p2p.GetOrCreateP2PPrimaryPath(primaryPath)
+	path.PathComputationMethod = oc.MplsTypes_PATH_COMPUTATION_METHOD_EXPLICITLY_DEFINED
+	path.ExplicitPathName = ygot.String(explicitPath)
+}
+
+// configureATE configures ATE port-1 as the traffic source, and ATE port-2
+// and ATE port-3 as interfaces of the tail router, which runs IS-IS and is
+// the RSVP-TE egress for its loopback.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+
+	tail := atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	eth2 := tail.Ethernets().Items()[0]
+	ip2 := eth2.Ipv4Addresses().Items()[0]
+	p3 := ate.Port(t, "port3")
+	top.Ports().Add().SetName(p3.ID())
+	eth3 := tail.Ethernets().Add().SetName(atePort3.Name + ".Eth").SetMac(atePort3.MAC)
+	eth3.Connection().SetPortName(p3.ID())
+	ip3 := eth3.Ipv4Addresses().Add().SetName(atePort3.Name + ".IPv4").
+		SetAddress(atePort3.IPv4).SetGateway(dutPort3.IPv4).SetPrefix(uint32(atePort3.IPv4Len))
+	lo := tail.Ipv4Loopbacks().Add().SetName(tail.Name() + ".Loopback").SetEthName(eth2.Name()).SetAddress(tailLoopbackIP)
+
+	isis := tail.Isis().SetSystemId(ateSysID).SetName(tail.Name() + ".ISIS")
+	isis.Basic().SetHostname(isis.Name()).SetIpv4TeRouterId(tailLoopbackIP)
+	isis.Advanced().SetAreaAddresses([]string{ateAreaAddr})
+	for _, i := range []struct {
+		eth    gosnappi.DeviceEthernet
+		metric uint32
+	}{
+		{eth2, primaryMetric},
+		{eth3, bypassMetric},
+	} {
+		intf := isis.Interfaces().Add().SetEthName(i.eth.Name()).SetName(i.eth.Name() + ".ISISIntf").
+			SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+			SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+			SetMetric(i.metric)
+		intf.Advanced().SetAutoAdjustMtu(true).SetAutoAdjustArea(true).SetAutoAdjustSupportedProtocols(true)
+	}
+	isis.V4Routes().Add().SetName(tail.Name() + ".LoopbackRoute").SetLinkMetric(10).
+		Addresses().Add().SetAddress(tailLoopbackIP).SetPrefix(32)
+	isis.V4Routes().Add().SetName(tail.Name() + ".DstRoute").SetLinkMetric(10).
+		Addresses().Add().SetAddress(dstPrefix).SetPrefix(dstPrefixLen)
+
+	rsvp := tail.Rsvp().SetName(tail.Name() + ".RSVP")
+	for _, i := range []struct {
+		ip  gosnappi.DeviceIpv4
+		dut attrs.Attributes
+	}{
+		{ip2, dutPort2},
+		{ip3, dutPort3},
+	} {
+		rsvp.Ipv4Interfaces().Add().SetIpv4Name(i.ip.Name()).SetNeighborIp(i.dut.IPv4)
+	}
+	rsvp.LspIpv4Interfaces().Add().SetIpv4Name(lo.Name()).P2PEgressIpv4Lsps().SetName(tail.Name() + ".EgressLSP")
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{tail.Name() + ".DstRoute"})
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().Increment().SetStart(dstPrefix).SetCount(dstCount)
+	flow.Size().SetFixed(256)
+	flow.Rate().SetPps(pps)
+	return top
+}
+
+// verifyLSP verifies the LSP and its RSVP session are up with link protection
+// requested, and that a bypass LSP protects it.
+func verifyLSP(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	mpls := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Mpls()
+	tunnels := mpls.Lsps().ConstrainedPath()
+	_, ok := gnmi.Watch(t, dut, tunnels.Tunnel(lspName).OperStatus().State(), lspTimeout, func(v *ygnmi.Value[oc.E_MplsTypes_LSP_OPER_STATUS]) bool {
+		s, present := v.Val()
+		return present && s == oc.MplsTypes_LSP_OPER_STATUS_UP
+	}).Await(t)
+	if !ok {
+		t.Fatalf("LSP %s oper-status did not become UP within %v", lspName, lspTimeout)
+	}
+
+	var session *oc.NetworkInstance_Mpls_SignalingProtocols_RsvpTe_Session
+	for _, s := range gnmi.GetAll(t, dut, mpls.SignalingProtocols().RsvpTe().SessionAny().State()) {
+		if s.GetDestinationAddress() == tailLoopbackIP && s.GetType() == oc.MplsTypes_LSP_ROLE_INGRESS && s.GetProtectionRequested() != oc.MplsTypes_PROTECTION_TYPE_UNPROTECTED {
+			session = s
+		}
+	}
+	switch {
+	case session == nil:
+		t.Errorf("No protected ingress RSVP session to %s", tailLoopbackIP)
+	case session.GetStatus() != oc.Session_Status_UP:
+		t.Errorf("RSVP session %d to %s status: got %v, want %v", session.GetLocalIndex(), tailLoopbackIP, session.GetStatus(), oc.Session_Status_UP)
+	case session.GetProtectionRequested() != oc.MplsTypes_PROTECTION_TYPE_LINK_PROTECTION_REQUIRED:
+		t.Errorf("RSVP session %d to %s protection-requested: got %v, want %v", session.GetLocalIndex(), tailLoopbackIP, session.GetProtectionRequested(), oc.MplsTypes_PROTECTION_TYPE_LINK_PROTECTION_REQUIRED)
+	}
+
+	_, ok = gnmi.WatchAll(t, dut, tunnels.TunnelAny().State(), lspTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Mpls_Lsps_ConstrainedPath_Tunnel]) bool {
+		tun, present := v.Val()
+		return present && tun.GetAutoGenerated() && tun.GetOperStatus() == oc.MplsTypes_LSP_OPER_STATUS_UP
+	}).Await(t)
+	if !ok {
+		t.Errorf("No auto-generated bypass LSP became UP within %v", lspTimeout)
+	}
+}
+
+// rxFrames returns the in-frames counter of the named ATE port.
+func rxFrames(t *testing.T, ate *ondatra.ATEDevice, port string) uint64 {
+	t.Helper()
+	return gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(ate.Port(t, port).ID()).Counters().InFrames().State())
+}
+
+func setATEPortLink(t *testing.T, ate *ondatra.ATEDevice, port string, state gosnappi.StatePortLinkStateEnum) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().SetPortNames([]string{ate.Port(t, port).ID()}).SetState(state)
+	ate.OTG().SetControlState(t, cs)
+}
+
+func TestRSVPFRRLinkProtection(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+	defer setATEPortLink(t, ate, "port2", gosnappi.StatePortLinkState.UP)
+
+	t.Run("LSP", func(t *testing.T) {
+		verifyLSP(t, dut)
+	})
+
+	t.Run("Traffic on primary path", func(t *testing.T) {
+		port2, port3 := rxFrames(t, ate, "port2"), rxFrames(t, ate, "port3")
+		ate.OTG().StartTraffic(t)
+		time.Sleep(trafficTime)
+		ate.OTG().StopTraffic(t)
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+		tx, rx := otgutils.GetFlowStats(t, ate.OTG(), flowName, 10*time.Second)
+		if tx == 0 {
+			t.Fatalf("Flow %s sent no packets", flowName)
+		}
+		if float64(rx) < float64(tx)*(1-lossTol) {
+			t.Errorf("Flow %s: got %d of %d packets received, want no loss", flowName, rx, tx)
+		}
+		if got := rxFrames(t, ate, "port2") - port2; float64(got) < float64(tx)*(1-lossTol) {
+			t.Errorf("ATE port-2 received %d frames, want >= %d sent on the primary path", got, tx)
+		}
+		if got := rxFrames(t, ate, "port3") - port3; float64(got) > float64(tx)*lossTol {
+			t.Errorf("ATE port-3 received %d frames, want none before the failure", got)
+		}
+	})
+
+	t.Run("Link failure", func(t *testing.T) {
+		port3 := rxFrames(t, ate, "port3")
+		ate.OTG().StartTraffic(t)
+		time.Sleep(trafficTime / 2)
+		setATEPortLink(t, ate, "port2", gosnappi.StatePortLinkState.DOWN)
+		time.Sleep(trafficTime / 2)
+		ate.OTG().StopTraffic(t)
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+		frrTime, err := otgutils.GetFlowOutage(t, ate.OTG(), flowName, pps, 10*time.Second)
+		if err != nil {
+			t.Fatalf("Unable to measure traffic loss time: %v", err)
+		}
+		t.Logf("Traffic loss time on protected link failure: %v", frrTime)
+		if frrTime > *maxFRRTime {
+			t.Errorf("Traffic loss time on protected link failure: got %v, want <= %v", frrTime, *maxFRRTime)
+		}
+		if got := rxFrames(t, ate, "port3") - port3; got == 0 {
+			t.Errorf("ATE port-3 received no frames after the protected link failed")
+		}
+
+		tunnel := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Mpls().Lsps().ConstrainedPath().Tunnel(lspName)
+		if got := gnmi.Get(t, dut, tunnel.OperStatus().State()); got != oc.MplsTypes_LSP_OPER_STATUS_UP {
+			t.Errorf("LSP %s oper-status after the protected link failed: got %v, want %v", lspName, got, oc.MplsTypes_LSP_OPER_STATUS_UP)
+		}
+	})
+}
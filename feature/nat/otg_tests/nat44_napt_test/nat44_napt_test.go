@@ -0,0 +1,371 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nat44_napt_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+const (
+	// insideHosts is the number of inside hosts, starting at insideHostStart.
+	insideHosts     = 10
+	insideHostStart = "100.64.1.1"
+	// insideNet is the inside network translated by the DUT.
+	insideNet = "100.64.0.0/16"
+	// insidePrefix matches the inside hosts in the DUT translation table.
+	insidePrefix = "100.64."
+	natPoolStart = "203.0.113.1"
+	natPoolEnd   = "203.0.113.14"
+	srcPortStart = 10000
+	dstPort      = 4789
+	pps          = 1000
+	// flowRepeats is how many times each flow sends a packet from every
+	// source port.
+	flowRepeats = 2
+	// maxEntries is the translation table limit configured for the session
+	// scale case.
+	maxEntries = 1000
+)
+
+var (
+	dutInside = attrs.Attributes{
+		Desc:    "dutInside",
+		IPv4:    "100.64.0.1",
+		IPv4Len: 16,
+	}
+	ateInside = attrs.Attributes{
+		Name:    "ateInside",
+		MAC:     "02:11:01:00:00:01",
+		IPv4:    "100.64.0.2",
+		IPv4Len: 16,
+	}
+	dutOutside = attrs.Attributes{
+		Desc:    "dutOutside",
+		IPv4:    "198.51.100.1",
+		IPv4Len: 30,
+	}
+	ateOutside = attrs.Attributes{
+		Name:    "ateOutside",
+		MAC:     "02:12:01:00:00:01",
+		IPv4:    "198.51.100.2",
+		IPv4Len: 30,
+	}
+	// natPool is the pool of outside addresses for source NAT.
+	natPool = netip.MustParsePrefix("203.0.113.0/28")
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. SourceNAT: translate the source address of traffic from the inside
+//     hosts to addresses from a NAT pool.  Verify every packet captured on
+//     the outside port has a pool source address, each inside host maps to
+//     one pool address, and the DUT reports a translation per host.
+//  2. NAPT: translate the source address and port of traffic from the inside
+//     hosts to the outside interface address.  Verify every packet captured
+//     on the outside port has the interface source address, each inside
+//     address and port maps to a distinct outside port, and the DUT reports
+//     a translation per session.
+//  3. SessionScale: limit the translation table to maxEntries and send twice
+//     as many NAPT sessions.  Verify the DUT reports no more than maxEntries
+//     translations and no more than maxEntries sessions are forwarded.
+//
+// Topology:
+//
+//	ate:port1 (inside) <--> port1:dut:port2 <--> ate:port2 (outside)
+//
+// Test notes:
+//   - NAT is not modelled in OpenConfig, so it is configured through CLI on
+//     devices with the nat_oc_unsupported deviation, and the translation
+//     table is read with a CLI show command.
+
+// natCLI holds the vendor CLI to configure and inspect NAT.  Each config has
+// a %s verb for the outside interface name.
+type natCLI struct {
+	// pool translates inside source addresses to addresses in natPool.
+	pool string
+	// napt translates inside source addresses and ports to the outside
+	// interface address.
+	napt string
+	// limit sets the translation table limit to maxEntries.
+	limit string
+	// remove removes the source translation and the table limit.
+	remove string
+	// show lists the translation table.
+	show string
+	// clear clears the translation table.
+	clear string
+}
+
+// natCLIs returns the NAT CLI for dut.
+func natCLIs(t *testing.T, dut *ondatra.DUTDevice) natCLI {
+	t.Helper()
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		acl := fmt.Sprintf(`
+ip access-list standard NAT_INSIDE
+   10 permit %s
+`, insideNet)
+		return natCLI{
+			pool: acl + fmt.Sprintf(`
+ip nat pool NAT_POOL %s %s prefix-length %d
+interface %%s
+   ip nat source dynamic access-list NAT_INSIDE pool NAT_POOL
+`, natPoolStart, natPoolEnd, natPool.Bits()),
+			napt: acl + `
+interface %s
+   ip nat source dynamic access-list NAT_INSIDE overload
+`,
+			limit: fmt.Sprintf(`
+ip nat translation max-entries %d
+`, maxEntries),
+			remove: `
+no ip nat translation max-entries
+interface %s
+   no ip nat source dynamic access-list NAT_INSIDE pool NAT_POOL
+   no ip nat source dynamic access-list NAT_INSIDE overload
+`,
+			show:  "show ip nat translation",
+			clear: "clear ip nat flow translation",
+		}
+	default:
+		t.Fatalf("NAT CLI is not defined for vendor %s", dut.Vendor())
+	}
+	return natCLI{}
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutInside.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutOutside.NewOCInterface(p2.Name(), dut))
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	ateInside.AddToOTG(top, ate.Port(t, "port1"), &dutInside)
+	ateOutside.AddToOTG(top, ate.Port(t, "port2"), &dutOutside)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port2"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+	return top
+}
+
+// addFlows adds a UDP flow from each inside host to ATE port-2, each sending
+// from ports source ports.
+func addFlows(t *testing.T, top gosnappi.Config, hosts, ports int) {
+	t.Helper()
+	top.Flows().Clear()
+	host := netip.MustParseAddr(insideHostStart)
+	for i := 0; i < hosts; i++ {
+		flow := top.Flows().Add().SetName(fmt.Sprintf("host-%s", host))
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{ateInside.Name + ".IPv4"}).SetRxNames([]string{ateOutside.Name + ".IPv4"})
+		flow.Packet().Add().Ethernet().Src().SetValue(ateInside.MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(host.String())
+		v4.Dst().SetValue(ateOutside.IPv4)
+		udp := flow.Packet().Add().Udp()
+		udp.SrcPort().Increment().SetStart(srcPortStart).SetStep(1).SetCount(uint32(ports))
+		udp.DstPort().SetValue(dstPort)
+		flow.Size().SetFixed(256)
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(uint32(ports * flowRepeats))
+		host = host.Next()
+	}
+}
+
+// session is the source address and port of a captured packet.
+type session struct {
+	addr netip.Addr
+	port uint16
+}
+
+// captureSessions returns the number of UDP packets captured on ATE port-2
+// for each source address and port.
+func captureSessions(t *testing.T, ate *ondatra.ATEDevice) map[session]int {
+	t.Helper()
+	b := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port2"))
+	r, err := pcapgo.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Failed to read port2 capture: %v", err)
+	}
+	sessions := make(map[session]int)
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return sessions
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port2 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		ip, ok := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		if !ok {
+			continue
+		}
+		udp, ok := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		if !ok || udp.DstPort != dstPort {
+			continue
+		}
+		addr, _ := netip.AddrFromSlice(ip.SrcIP.To4())
+		sessions[session{addr, uint16(udp.SrcPort)}]++
+	}
+}
+
+// runTraffic clears the translation table, sends the flows with the capture
+// running and returns the captured sessions.
+func runTraffic(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, top gosnappi.Config, cli natCLI, ports int) map[session]int {
+	t.Helper()
+	if _, err := dut.RawAPIs().CLI(t).RunCommand(context.Background(), cli.clear); err != nil {
+		t.Fatalf("%q failed: %v", cli.clear, err)
+	}
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	cs := gosnappi.NewControlState()
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+	ate.OTG().SetControlState(t, cs)
+	ate.OTG().StartTraffic(t)
+	time.Sleep(time.Duration(ports*flowRepeats/pps)*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+	ate.OTG().SetControlState(t, cs)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	return captureSessions(t, ate)
+}
+
+// translations returns the number of entries for inside hosts in the DUT
+// translation table.
+func translations(t *testing.T, dut *ondatra.DUTDevice, cli natCLI) int {
+	t.Helper()
+	res, err := dut.RawAPIs().CLI(t).RunCommand(context.Background(), cli.show)
+	if err != nil {
+		t.Fatalf("%q failed: %v", cli.show, err)
+	}
+	n := 0
+	for _, line := range strings.Split(res.Output(), "\n") {
+		if strings.Contains(line, insidePrefix) {
+			n++
+		}
+	}
+	t.Logf("%q reported %d translations for inside hosts", cli.show, n)
+	return n
+}
+
+func TestNAT(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if !deviations.NatOCUnsupported(dut) {
+		t.Skip("NAT is not modelled in OpenConfig; set the nat_oc_unsupported deviation to configure it through CLI")
+	}
+	cli := natCLIs(t, dut)
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	outside := dut.Port(t, "port2").Name()
+	outsideAddr := netip.MustParseAddr(dutOutside.IPv4)
+
+	t.Run("SourceNAT", func(t *testing.T) {
+		helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.pool, outside))
+		defer helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.remove, outside))
+		addFlows(t, top, insideHosts, 1)
+		sessions := runTraffic(t, dut, ate, top, cli, 1)
+		if len(sessions) == 0 {
+			t.Fatalf("No packets captured on ATE port-2")
+		}
+		addrs := make(map[netip.Addr]bool)
+		for s := range sessions {
+			if !natPool.Contains(s.addr) {
+				t.Errorf("Captured source address %s: not in NAT pool %s", s.addr, natPool)
+			}
+			addrs[s.addr] = true
+		}
+		if len(addrs) != insideHosts {
+			t.Errorf("Translated source addresses: got %d, want %d", len(addrs), insideHosts)
+		}
+		if got := translations(t, dut, cli); got < insideHosts {
+			t.Errorf("DUT translations: got %d, want at least %d", got, insideHosts)
+		}
+	})
+
+	t.Run("NAPT", func(t *testing.T) {
+		const ports = 100
+		helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.napt, outside))
+		defer helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.remove, outside))
+		addFlows(t, top, insideHosts, ports)
+		sessions := runTraffic(t, dut, ate, top, cli, ports)
+		for s := range sessions {
+			if s.addr != outsideAddr {
+				t.Errorf("Captured source address %s: want outside interface address %s", s.addr, outsideAddr)
+			}
+		}
+		if want := insideHosts * ports; len(sessions) != want {
+			t.Errorf("Translated source address and port pairs: got %d, want %d", len(sessions), want)
+		}
+		if got, want := translations(t, dut, cli), insideHosts*ports; got < want {
+			t.Errorf("DUT translations: got %d, want at least %d", got, want)
+		}
+	})
+
+	t.Run("SessionScale", func(t *testing.T) {
+		ports := 2 * maxEntries / insideHosts
+		helpers.GnmiCLIConfig(t, dut, cli.limit+fmt.Sprintf(cli.napt, outside))
+		defer helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.remove, outside))
+		addFlows(t, top, insideHosts, ports)
+		sessions := runTraffic(t, dut, ate, top, cli, ports)
+		t.Logf("Sent %d sessions, %d were translated", insideHosts*ports, len(sessions))
+		if len(sessions) == 0 {
+			t.Errorf("No translated sessions captured on ATE port-2")
+		}
+		if len(sessions) > maxEntries {
+			t.Errorf("Translated sessions: got %d, want at most %d", len(sessions), maxEntries)
+		}
+		if got := translations(t, dut, cli); got > maxEntries {
+			t.Errorf("DUT translations: got %d, want at most %d", got, maxEntries)
+		}
+	})
+}
@@ -0,0 +1,351 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vrf_grt_route_leaking_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	vrfA = "VRF_A"
+	vrfB = "VRF_B"
+
+	rdA = "65501:1"
+	rdB = "65501:2"
+	// rtA is exported by VRF_A and imported by the default network instance,
+	// rtGRT is exported by the default network instance and imported by
+	// VRF_A.  rtB is only used by VRF_B.
+	rtA   = "65501:1"
+	rtB   = "65501:2"
+	rtGRT = "65501:100"
+
+	vrfALeak    = "198.51.100.0/26"
+	vrfAPrivate = "198.51.100.64/26"
+	vrfBPrivate = "198.51.100.128/26"
+	grtLeak     = "203.0.113.0/26"
+	grtPrivate  = "203.0.113.64/26"
+
+	vrfALeakSet = "VRF_A_LEAK"
+	grtLeakSet  = "GRT_LEAK"
+	acceptAll   = "ACCEPT_ALL"
+	vrfAExport  = acceptAll
+	vrfAImport  = acceptAll
+	grtExport   = "GRT_EXPORT"
+	grtImport   = "GRT_IMPORT"
+
+	aftTimeout  = time.Minute
+	pps         = 1000
+	flowPackets = 10000
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "atePort2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30}
+	atePort3 = attrs.Attributes{Name: "atePort3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. RIB placement: the VRF_A prefix selected for leaking is installed in
+//     the default network instance and the other VRF_A prefix is not.  The
+//     default network instance prefix selected for leaking is installed in
+//     VRF_A and the other one is not.  Nothing is leaked into or out of VRF_B.
+//  2. Forwarding: traffic from each ingress interface is forwarded to leaked
+//     prefixes in the other network instance and dropped for prefixes that
+//     are not leaked.
+//  3. Policy update: replacing the default network instance import policy to
+//     accept all VRF_A routes leaks the other VRF_A prefix as well.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut  (VRF_A)
+//	ate:port2 <--> port2:dut  (default)
+//	ate:port3 <--> port3:dut  (VRF_B)
+//
+// Test notes:
+//   - Prefixes are originated by static routes towards the ATE port in the
+//     same network instance.
+//   - VRF_A exports all of its routes and the default network instance import
+//     policy selects the VRF_A prefixes to leak.  The default network instance
+//     export policy selects the prefixes to leak and VRF_A imports all routes
+//     with the default network instance route-target.  Both the export and
+//     import policies are therefore exercised.
+
+// configureDUT configures the ports, the VRFs and their static routes.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, v := range []struct{ name, rd string }{{vrfA, rdA}, {vrfB, rdB}} {
+		ni := &oc.NetworkInstance{
+			Name:               ygot.String(v.name),
+			Type:               oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+			RouteDistinguisher: ygot.String(v.rd),
+		}
+		gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(v.name).Config(), ni)
+	}
+
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+		ni   string
+	}{
+		{"port1", dutPort1, vrfA},
+		{"port2", dutPort2, defaultNI},
+		{"port3", dutPort3, vrfB},
+	} {
+		dp := dut.Port(t, p.port)
+		if p.ni != defaultNI || deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), p.ni, 0)
+		}
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+	}
+
+	b := &gnmi.SetBatch{}
+	for _, r := range []struct {
+		ni, prefix, nh string
+	}{
+		{vrfA, vrfALeak, atePort1.IPv4},
+		{vrfA, vrfAPrivate, atePort1.IPv4},
+		{vrfB, vrfBPrivate, atePort3.IPv4},
+		{defaultNI, grtLeak, atePort2.IPv4},
+		{defaultNI, grtPrivate, atePort2.IPv4},
+	} {
+		if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+			NetworkInstance: r.ni,
+			Prefix:          r.prefix,
+			NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+				"0": oc.UnionString(r.nh),
+			},
+		}, dut); err != nil {
+			t.Fatalf("Failed to configure static route to %s in %s: %v", r.prefix, r.ni, err)
+		}
+	}
+	b.Set(t, dut)
+}
+
+// configureRoutingPolicy configures the prefix-sets and the leaking policies.
+// The policy named grtImport accepts the prefixes in importSet, or all routes
+// if importSet is empty.
+func configureRoutingPolicy(t *testing.T, dut *ondatra.DUTDevice, importSet string) {
+	t.Helper()
+	rp := &oc.RoutingPolicy{}
+	for name, prefix := range map[string]string{vrfALeakSet: vrfALeak, grtLeakSet: grtLeak} {
+		ps := rp.GetOrCreateDefinedSets().GetOrCreatePrefixSet(name)
+		ps.GetOrCreatePrefix(prefix, "exact")
+		if !deviations.SkipPrefixSetMode(dut) {
+			ps.SetMode(oc.PrefixSet_Mode_IPV4)
+		}
+	}
+
+	stmt, err := rp.GetOrCreatePolicyDefinition(acceptAll).AppendNewStatement("accept")
+	if err != nil {
+		t.Fatalf("AppendNewStatement(%s) failed: %v", acceptAll, err)
+	}
+	stmt.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+
+	for _, p := range []struct{ name, set string }{{grtExport, grtLeakSet}, {grtImport, importSet}} {
+		pdef := rp.GetOrCreatePolicyDefinition(p.name)
+		stmt, err := pdef.AppendNewStatement("leak")
+		if err != nil {
+			t.Fatalf("AppendNewStatement(%s) failed: %v", p.name, err)
+		}
+		stmt.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_ACCEPT_ROUTE
+		if p.set == "" {
+			continue
+		}
+		stmt.GetOrCreateConditions().GetOrCreateMatchPrefixSet().SetPrefixSet(p.set)
+		stmt, err = pdef.AppendNewStatement("reject")
+		if err != nil {
+			t.Fatalf("AppendNewStatement(%s) failed: %v", p.name, err)
+		}
+		stmt.GetOrCreateActions().PolicyResult = oc.RoutingPolicy_PolicyResultType_REJECT_ROUTE
+	}
+	gnmi.Replace(t, dut, gnmi.OC().RoutingPolicy().Config(), rp)
+}
+
+// configureLeaking configures the route-targets and leaking policies of each
+// network instance.
+func configureLeaking(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, l := range []struct {
+		ni                         string
+		exportRT, importRT         string
+		exportPolicy, importPolicy string
+	}{
+		{vrfA, rtA, rtGRT, vrfAExport, vrfAImport},
+		{deviations.DefaultNetworkInstance(dut), rtGRT, rtA, grtExport, grtImport},
+		{vrfB, rtB, rtB, "", ""},
+	} {
+		iip := &oc.NetworkInstance_InterInstancePolicies{}
+		iep := iip.GetOrCreateImportExportPolicy()
+		iep.SetExportRouteTarget([]oc.NetworkInstance_InterInstancePolicies_ImportExportPolicy_ExportRouteTarget_Union{oc.UnionString(l.exportRT)})
+		iep.SetImportRouteTarget([]oc.NetworkInstance_InterInstancePolicies_ImportExportPolicy_ImportRouteTarget_Union{oc.UnionString(l.importRT)})
+		if l.exportPolicy != "" {
+			ap := iip.GetOrCreateApplyPolicy()
+			ap.SetExportPolicy([]string{l.exportPolicy})
+			ap.SetImportPolicy([]string{l.importPolicy})
+			ap.SetDefaultExportPolicy(oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+			ap.SetDefaultImportPolicy(oc.RoutingPolicy_DefaultPolicyType_REJECT_ROUTE)
+		}
+		gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(l.ni).InterInstancePolicies().Config(), iip)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	return top
+}
+
+// flowCase is an OTG flow entering the DUT on the port of src to dstAddr,
+// expected to be received on dst unless wantLoss.
+type flowCase struct {
+	name     string
+	src, dst attrs.Attributes
+	dstAddr  string
+	wantLoss bool
+}
+
+var flowCases = []flowCase{
+	{name: "VRF_A-to-GRT-leak", src: atePort1, dst: atePort2, dstAddr: "203.0.113.1"},
+	{name: "VRF_A-to-GRT-private", src: atePort1, dst: atePort2, dstAddr: "203.0.113.65", wantLoss: true},
+	{name: "GRT-to-VRF_A-leak", src: atePort2, dst: atePort1, dstAddr: "198.51.100.1"},
+	{name: "GRT-to-VRF_A-private", src: atePort2, dst: atePort1, dstAddr: "198.51.100.65", wantLoss: true},
+	{name: "VRF_B-to-GRT", src: atePort3, dst: atePort2, dstAddr: "203.0.113.1", wantLoss: true},
+	{name: "GRT-to-VRF_B", src: atePort2, dst: atePort3, dstAddr: "198.51.100.129", wantLoss: true},
+}
+
+func (fc flowCase) flow() gosnappi.Flow {
+	flow := gosnappi.NewFlow().SetName(fc.name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{fc.src.Name + ".IPv4"}).SetRxNames([]string{fc.dst.Name + ".IPv4"})
+	flow.Packet().Add().Ethernet().Src().SetValue(fc.src.MAC)
+	ip := flow.Packet().Add().Ipv4()
+	ip.Src().SetValue(fc.src.IPv4)
+	ip.Dst().SetValue(fc.dstAddr)
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPackets)
+	return flow
+}
+
+// verifyAFT verifies prefix is installed in the AFT of ni if want, and is
+// absent otherwise.
+func verifyAFT(t *testing.T, dut *ondatra.DUTDevice, ni, prefix string, want bool) {
+	t.Helper()
+	q := gnmi.OC().NetworkInstance(ni).Afts().Ipv4Entry(prefix).State()
+	if want {
+		if _, ok := gnmi.Watch(t, dut, q, aftTimeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			return v.IsPresent()
+		}).Await(t); !ok {
+			t.Errorf("Prefix %s is not installed in the AFT of %s", prefix, ni)
+		}
+		return
+	}
+	if gnmi.Lookup(t, dut, q).IsPresent() {
+		t.Errorf("Prefix %s is installed in the AFT of %s, want absent", prefix, ni)
+	}
+}
+
+func verifyTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, cases []flowCase) {
+	t.Helper()
+	ate.OTG().StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	for _, fc := range cases {
+		tx, rx := otgutils.GetFlowStats(t, ate.OTG(), fc.name, 10*time.Second)
+		switch {
+		case tx == 0:
+			t.Errorf("Flow %s sent no packets", fc.name)
+		case fc.wantLoss && rx != 0:
+			t.Errorf("Flow %s received %d of %d packets on %s, want 0", fc.name, rx, tx, fc.dst.Name)
+		case !fc.wantLoss && rx != tx:
+			t.Errorf("Flow %s received %d of %d packets on %s, want all", fc.name, rx, tx, fc.dst.Name)
+		}
+	}
+}
+
+func TestVRFGRTRouteLeaking(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+
+	configureDUT(t, dut)
+	configureRoutingPolicy(t, dut, vrfALeakSet)
+	configureLeaking(t, dut)
+
+	top := configureATE(t, ate)
+	for _, fc := range flowCases {
+		top.Flows().Append(fc.flow())
+	}
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	t.Run("RIBPlacement", func(t *testing.T) {
+		verifyAFT(t, dut, defaultNI, vrfALeak, true)
+		verifyAFT(t, dut, defaultNI, vrfAPrivate, false)
+		verifyAFT(t, dut, defaultNI, vrfBPrivate, false)
+		verifyAFT(t, dut, vrfA, grtLeak, true)
+		verifyAFT(t, dut, vrfA, grtPrivate, false)
+		verifyAFT(t, dut, vrfB, grtLeak, false)
+		verifyAFT(t, dut, vrfB, vrfALeak, false)
+	})
+
+	t.Run("Forwarding", func(t *testing.T) {
+		verifyTraffic(t, ate, top, flowCases)
+	})
+
+	t.Run("PolicyUpdate", func(t *testing.T) {
+		configureRoutingPolicy(t, dut, "")
+		verifyAFT(t, dut, defaultNI, vrfALeak, true)
+		verifyAFT(t, dut, defaultNI, vrfAPrivate, true)
+		verifyAFT(t, dut, defaultNI, vrfBPrivate, false)
+
+		cases := []flowCase{
+			{name: "GRT-to-VRF_A-leak", src: atePort2, dst: atePort1, dstAddr: "198.51.100.1"},
+			{name: "GRT-to-VRF_A-private", src: atePort2, dst: atePort1, dstAddr: "198.51.100.65"},
+			{name: "GRT-to-VRF_B", src: atePort2, dst: atePort3, dstAddr: "198.51.100.129", wantLoss: true},
+		}
+		verifyTraffic(t, ate, top, cases)
+	})
+}
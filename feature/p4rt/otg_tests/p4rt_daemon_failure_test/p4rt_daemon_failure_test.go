@@ -358,15 +358,18 @@ func TestP4RTDaemonFailure(t *testing.T) {
 	t.Logf("Stop traffic")
 	ate.OTG().StopTraffic(t)
 
-	// Skip check for CISCO devices that use the same process for P4RT & gNMI.
-	if dut.Vendor() != ondatra.CISCO && dut.Vendor() != ondatra.NOKIA {
-		// Verify interfaceID did not change since the last time we read it.
-		changedID, notOk := watchID.Await(t)
-		if notOk {
-			t.Errorf("DUT changed /interfaces/interface/state/id during p4rt process restart.  want: %q got: %q", dutPort1.ID, changedID.String())
-		}
-		t.Logf("OK: no change detected in /interfaces/interface/state/id want:%q got:%q", dutPort1.ID, changedID.String())
-	}
+	// Devices that use the same process for P4RT & gNMI skip this check
+	// through the expected_failures in the test metadata.
+	t.Run("Interface ID unchanged", func(t *testing.T) {
+		fptest.ExpectFailure(t, dut, func(t testing.TB) {
+			// Verify interfaceID did not change since the last time we read it.
+			changedID, notOk := watchID.Await(t)
+			if notOk {
+				t.Errorf("DUT changed /interfaces/interface/state/id during p4rt process restart.  want: %q got: %q", dutPort1.ID, changedID.String())
+			}
+			t.Logf("OK: no change detected in /interfaces/interface/state/id want:%q got:%q", dutPort1.ID, changedID.String())
+		})
+	})
 
 	recvMetric := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(flow.Name()).State())
 	txPackets := float32(recvMetric.GetCounters().GetOutPkts())
@@ -0,0 +1,231 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pic_convergence_test
+
+import (
+	"flag"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	prefixesStart  = "100.64.0.0"
+	prefixLen      = 32
+	maxPaths       = 2
+	flowName       = "pic"
+	flowPPS        = 10000
+	trafficWarmup  = 10 * time.Second
+	trafficSettle  = 20 * time.Second
+	routeTimeout   = 10 * time.Minute
+	statsTimeout   = 30 * time.Second
+	backupPathASN1 = 65521
+	backupPathASN2 = 65522
+)
+
+var (
+	// scales are the numbers of prefixes sharing the failed next hop.
+	scales = []uint32{1000, 100000}
+
+	maxOutageDelta = flag.Duration("max_outage_delta", 100*time.Millisecond, "Maximum increase of the traffic outage between the smallest and largest number of prefixes.")
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Backup path: ATE port-2 and ATE port-3 advertise the same prefixes over
+//     eBGP, with a longer AS path from ATE port-3, so the DUT prefers ATE
+//     port-2.
+//  2. Multipath: ATE port-2 and ATE port-3 advertise the same prefixes over
+//     eBGP, and the DUT uses both paths.
+//
+// For each case, and for each of 1k and 100k prefixes:
+//   - Send traffic from ATE port-1 to all prefixes.
+//   - Bring the link of ATE port-2 down, and measure the traffic outage from
+//     the packets lost.
+//
+// Verify the outage with 100k prefixes is no more than -max_outage_delta
+// longer than with 1k prefixes.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                         port3 <--> ate:port3
+//
+// Test notes:
+//   - OpenConfig has no model to enable BGP PIC or backup path installation,
+//     so the DUT is expected to enable them by default.
+//   - The outage is computed from the packets lost at a fixed rate, so its
+//     resolution is 1/flowPPS seconds.
+
+// configureOTG adds the route ranges to ATE port-2 and ATE port-3, and a flow
+// from ATE port-1 to all the prefixes.
+func configureOTG(t *testing.T, bs *cfgplugins.BGPSession, prefixes uint32, multipath bool) {
+	t.Helper()
+	var rxNames []string
+	for i, d := range bs.ATEIntfs {
+		if i == 0 {
+			continue
+		}
+		ipv4 := d.Ethernets().Items()[0].Ipv4Addresses().Items()[0]
+		peer := d.Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0]
+		rr := peer.V4Routes().Add().SetName(bs.ATEPorts[i].Name + ".BGP4.peer.rr4")
+		rr.SetNextHopIpv4Address(ipv4.Address()).
+			SetNextHopAddressType(gosnappi.BgpV4RouteRangeNextHopAddressType.IPV4).
+			SetNextHopMode(gosnappi.BgpV4RouteRangeNextHopMode.MANUAL)
+		rr.Addresses().Add().SetAddress(prefixesStart).SetPrefix(prefixLen).SetCount(prefixes)
+		if !multipath && i == 2 {
+			rr.AsPath().Segments().Add().SetAsNumbers([]uint32{backupPathASN1, backupPathASN2})
+		}
+		rxNames = append(rxNames, rr.Name())
+	}
+
+	flow := bs.ATETop.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{bs.ATEPorts[0].Name + ".IPv4"}).
+		SetRxNames(rxNames)
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(flowPPS)
+	flow.Packet().Add().Ethernet().Src().SetValue(bs.ATEPorts[0].MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(bs.ATEPorts[0].IPv4)
+	v4.Dst().Increment().SetStart(prefixesStart).SetStep("0.0.0.1").SetCount(prefixes)
+}
+
+// lastPrefix returns the last of the prefixes advertised.
+func lastPrefix(t *testing.T, prefixes uint32) string {
+	t.Helper()
+	start, err := netip.ParseAddr(prefixesStart)
+	if err != nil {
+		t.Fatalf("Cannot parse %s: %v", prefixesStart, err)
+	}
+	b := start.As4()
+	n := (uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])) + prefixes - 1
+	return fmt.Sprintf("%s/%d", netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}), prefixLen)
+}
+
+// awaitRoutes waits for the DUT to receive all prefixes from ATE port-2 and
+// ATE port-3, and to install the last of them in its AFT.
+func awaitRoutes(t *testing.T, bs *cfgplugins.BGPSession, prefixes uint32) {
+	t.Helper()
+	dni := deviations.DefaultNetworkInstance(bs.DUT)
+	bgpPath := gnmi.OC().NetworkInstance(dni).Protocol(cfgplugins.PTBGP, "BGP").Bgp()
+	for _, ap := range bs.ATEPorts[1:] {
+		received := bgpPath.Neighbor(ap.IPv4).AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).Prefixes().Received().State()
+		if got, ok := gnmi.Watch(t, bs.DUT, received, routeTimeout, func(val *ygnmi.Value[uint32]) bool {
+			n, present := val.Val()
+			return present && n == prefixes
+		}).Await(t); !ok {
+			t.Fatalf("Prefixes received from %s: got %v, want %d", ap.IPv4, got, prefixes)
+		}
+	}
+	last := lastPrefix(t, prefixes)
+	if _, ok := gnmi.Watch(t, bs.DUT, gnmi.OC().NetworkInstance(dni).Afts().Ipv4Entry(last).State(), routeTimeout, func(val *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+		return val.IsPresent()
+	}).Await(t); !ok {
+		t.Fatalf("Prefix %s not found in the DUT AFT", last)
+	}
+}
+
+// setLink sets the link state of ATE port-2.
+func setLink(t *testing.T, bs *cfgplugins.BGPSession, state gosnappi.StatePortLinkStateEnum) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().SetPortNames([]string{bs.OndatraATEPorts[1].ID()}).SetState(state)
+	bs.ATE.OTG().SetControlState(t, cs)
+}
+
+// measureOutage advertises the prefixes from ATE port-2 and ATE port-3, brings
+// the link of ATE port-2 down while sending traffic to them, and returns the
+// traffic outage.
+func measureOutage(t *testing.T, prefixes uint32, multipath bool) time.Duration {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount4, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port2", "port3"}, true, true)
+	if multipath {
+		dni := deviations.DefaultNetworkInstance(bs.DUT)
+		bgp := bs.DUTConf.GetOrCreateNetworkInstance(dni).GetOrCreateProtocol(cfgplugins.PTBGP, "BGP").GetOrCreateBgp()
+		bgp.GetOrCreateGlobal().GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateUseMultiplePaths().GetOrCreateEbgp().MaximumPaths = ygot.Uint32(maxPaths)
+		bgp.GetOrCreatePeerGroup(cfgplugins.BGPPeerGroup1).GetOrCreateAfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST).GetOrCreateUseMultiplePaths().Enabled = ygot.Bool(true)
+	}
+	configureOTG(t, bs, prefixes, multipath)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Cannot push the configuration: %v", err)
+	}
+	cfgplugins.VerifyDUTBGPEstablished(t, bs.DUT)
+	cfgplugins.VerifyOTGBGPEstablished(t, bs.ATE)
+	awaitRoutes(t, bs, prefixes)
+
+	otg := bs.ATE.OTG()
+	defer setLink(t, bs, gosnappi.StatePortLinkState.UP)
+	otg.StartTraffic(t)
+	time.Sleep(trafficWarmup)
+	t.Logf("Bringing down the link of ATE %s", bs.OndatraATEPorts[1].ID())
+	setLink(t, bs, gosnappi.StatePortLinkState.DOWN)
+	time.Sleep(trafficSettle)
+	otg.StopTraffic(t)
+
+	otgutils.LogFlowMetrics(t, otg, bs.ATETop)
+	otgutils.LogPortMetrics(t, otg, bs.ATETop)
+	outage, err := otgutils.GetFlowOutage(t, otg, flowName, flowPPS, statsTimeout)
+	if err != nil {
+		t.Fatalf("Unable to measure traffic outage: %v", err)
+	}
+	if outage >= trafficSettle {
+		t.Errorf("Traffic did not converge within %v after the link failure", trafficSettle)
+	}
+	t.Logf("Traffic outage with %d prefixes: %v", prefixes, outage)
+	return outage
+}
+
+func TestPIC(t *testing.T) {
+	cases := []struct {
+		desc      string
+		multipath bool
+	}{
+		{desc: "Backup path", multipath: false},
+		{desc: "Multipath", multipath: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			outages := make([]time.Duration, len(scales))
+			for i, prefixes := range scales {
+				t.Run(fmt.Sprintf("%d prefixes", prefixes), func(t *testing.T) {
+					outages[i] = measureOutage(t, prefixes, tc.multipath)
+				})
+			}
+			if t.Failed() {
+				return
+			}
+			small, large := outages[0], outages[len(outages)-1]
+			if large-small > *maxOutageDelta {
+				t.Errorf("Traffic outage with %d prefixes is %v longer than with %d prefixes, want at most %v", scales[len(scales)-1], large-small, scales[0], *maxOutageDelta)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system_alarms_test
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	fanRemoveCmd  = flag.String("fan_remove_cmd", "", "CLI command that simulates the removal of a fan on the DUT. The fan removal case is skipped when unset.")
+	fanRestoreCmd = flag.String("fan_restore_cmd", "", "CLI command that restores the fan removed by -fan_remove_cmd.")
+)
+
+const (
+	raiseTimeout = 2 * time.Minute
+	clearTimeout = 2 * time.Minute
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "port1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "port2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Enumerate /system/alarms and verify every alarm has an id matching its
+//     key, a resource, a text, a creation time and a known severity.
+//  2. Disable the laser of ATE port-2 and verify an alarm is raised for DUT
+//     port-2 or its transceiver.  Enable the laser and verify the alarm is
+//     cleared.
+//  3. When -fan_remove_cmd is set, run it to simulate the removal of a fan and
+//     verify an alarm is raised for a fan component.  Run -fan_restore_cmd and
+//     verify the alarm is cleared.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - Alarms are observed with ON_CHANGE subscriptions, so creation and
+//     clearing are verified as notifications rather than by polling.
+//   - An alarm is cleared when it is deleted from /system/alarms.
+//   - Fan removal can only be simulated with a vendor specific command, which
+//     is supplied by the flags rather than hard coded.
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	return top
+}
+
+func onChange(dut *ondatra.DUTDevice) *gnmi.Opts {
+	return dut.GNMIOpts().WithYGNMIOpts(ygnmi.WithSubscriptionMode(gpb.SubscriptionMode_ON_CHANGE))
+}
+
+func alarmIDs(t *testing.T, dut *ondatra.DUTDevice) map[string]bool {
+	t.Helper()
+	ids := make(map[string]bool)
+	for _, a := range gnmi.GetAll(t, dut, gnmi.OC().System().AlarmAny().State()) {
+		ids[a.GetId()] = true
+	}
+	return ids
+}
+
+// awaitAlarm waits for an alarm not in existing whose resource satisfies
+// match, and returns it.
+func awaitAlarm(t *testing.T, dut *ondatra.DUTDevice, existing map[string]bool, match func(resource string) bool) *oc.System_Alarm {
+	t.Helper()
+	var alarm *oc.System_Alarm
+	_, ok := gnmi.WatchAll(t, onChange(dut), gnmi.OC().System().AlarmAny().State(), raiseTimeout, func(v *ygnmi.Value[*oc.System_Alarm]) bool {
+		a, present := v.Val()
+		if !present || existing[a.GetId()] || !match(a.GetResource()) {
+			return false
+		}
+		alarm = a
+		return true
+	}).Await(t)
+	if !ok {
+		t.Fatalf("No alarm was raised within %v", raiseTimeout)
+	}
+	t.Logf("Alarm raised: id %q, resource %q, severity %v, text %q", alarm.GetId(), alarm.GetResource(), alarm.GetSeverity(), alarm.GetText())
+	return alarm
+}
+
+// awaitCleared waits for the alarm with id to be deleted.
+func awaitCleared(t *testing.T, dut *ondatra.DUTDevice, id string) {
+	t.Helper()
+	_, ok := gnmi.Watch(t, onChange(dut), gnmi.OC().System().Alarm(id).State(), clearTimeout, func(v *ygnmi.Value[*oc.System_Alarm]) bool {
+		return !v.IsPresent()
+	}).Await(t)
+	if !ok {
+		t.Errorf("Alarm %q was not cleared within %v", id, clearTimeout)
+	}
+}
+
+// verifyAlarm checks the leaves every alarm is expected to have.
+func verifyAlarm(t *testing.T, key string, a *oc.System_Alarm) {
+	t.Helper()
+	if a.GetId() != key {
+		t.Errorf("Alarm %q id: got %q, want %q", key, a.GetId(), key)
+	}
+	if a.GetResource() == "" {
+		t.Errorf("Alarm %q resource is empty", key)
+	}
+	if a.GetText() == "" {
+		t.Errorf("Alarm %q text is empty", key)
+	}
+	if a.GetTimeCreated() == 0 {
+		t.Errorf("Alarm %q time-created is not set", key)
+	}
+	if a.GetTypeId() == nil {
+		t.Errorf("Alarm %q type-id is not set", key)
+	}
+	switch a.GetSeverity() {
+	case oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET, oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNKNOWN:
+		t.Errorf("Alarm %q severity: got %v, want CRITICAL, MAJOR, MINOR or WARNING", key, a.GetSeverity())
+	}
+}
+
+func TestAlarmEnumeration(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	alarms := gnmi.GetAll(t, dut, gnmi.OC().System().AlarmAny().State())
+	t.Logf("Found %d alarms", len(alarms))
+	for _, a := range alarms {
+		t.Logf("Alarm: id %q, resource %q, severity %v, text %q", a.GetId(), a.GetResource(), a.GetSeverity(), a.GetText())
+		verifyAlarm(t, a.GetId(), a)
+	}
+}
+
+func TestLinkDownAlarm(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+
+	p2 := dut.Port(t, "port2")
+	gnmi.Await(t, dut, gnmi.OC().Interface(p2.Name()).OperStatus().State(), time.Minute, oc.Interface_OperStatus_UP)
+	resources := map[string]bool{p2.Name(): true}
+	if tr, ok := gnmi.Lookup(t, dut, gnmi.OC().Interface(p2.Name()).Transceiver().State()).Val(); ok {
+		resources[tr] = true
+	}
+	t.Logf("Expecting an alarm for one of %v", resources)
+	match := func(r string) bool {
+		return resources[r] || strings.Contains(r, p2.Name())
+	}
+
+	existing := alarmIDs(t, dut)
+	cs := gosnappi.NewControlState()
+	cs.Port().Link().SetPortNames([]string{atePort2.Name}).SetState(gosnappi.StatePortLinkState.DOWN)
+	ate.OTG().SetControlState(t, cs)
+	defer func() {
+		cs.Port().Link().SetState(gosnappi.StatePortLinkState.UP)
+		ate.OTG().SetControlState(t, cs)
+	}()
+
+	alarm := awaitAlarm(t, dut, existing, match)
+	verifyAlarm(t, alarm.GetId(), alarm)
+
+	cs.Port().Link().SetState(gosnappi.StatePortLinkState.UP)
+	ate.OTG().SetControlState(t, cs)
+	gnmi.Await(t, dut, gnmi.OC().Interface(p2.Name()).OperStatus().State(), time.Minute, oc.Interface_OperStatus_UP)
+	awaitCleared(t, dut, alarm.GetId())
+}
+
+func TestFanRemovalAlarm(t *testing.T) {
+	if *fanRemoveCmd == "" || *fanRestoreCmd == "" {
+		t.Skip("Fan removal simulation commands are not set with -fan_remove_cmd and -fan_restore_cmd")
+	}
+	dut := ondatra.DUT(t, "dut")
+	cli := dut.RawAPIs().CLI(t)
+	isFan := func(r string) bool {
+		typ, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(r).Type().State()).Val()
+		return ok && (typ == oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_FAN || typ == oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_FAN_TRAY)
+	}
+
+	existing := alarmIDs(t, dut)
+	if _, err := cli.RunCommand(context.Background(), *fanRemoveCmd); err != nil {
+		t.Fatalf("Running %q failed: %v", *fanRemoveCmd, err)
+	}
+	restored := false
+	restore := func() {
+		if restored {
+			return
+		}
+		restored = true
+		if _, err := cli.RunCommand(context.Background(), *fanRestoreCmd); err != nil {
+			t.Fatalf("Running %q failed: %v", *fanRestoreCmd, err)
+		}
+	}
+	defer restore()
+
+	alarm := awaitAlarm(t, dut, existing, isFan)
+	verifyAlarm(t, alarm.GetId(), alarm)
+
+	restore()
+	awaitCleared(t, dut, alarm.GetId())
+}
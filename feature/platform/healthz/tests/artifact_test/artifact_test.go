@@ -0,0 +1,188 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/healthz"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi/oc"
+
+	hpb "github.com/openconfig/gnoi/healthz"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+const (
+	// maxChunkSize is the default gRPC maximum message size.
+	maxChunkSize = 4 << 20
+	// interruptChunks is the number of chunks received before the first
+	// Artifact RPC is cancelled.
+	interruptChunks = 3
+	fetchTimeout    = 30 * time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Call Healthz Check on the chassis component, then Healthz Get, and
+//     select the largest file artifact reported.
+//  2. Retrieve the artifact with the Artifact RPC.  Verify every chunk is
+//     non-empty and within the gRPC message size, the bytes received match
+//     the size and hash in the artifact header, and the stream ends with a
+//     trailer.
+//  3. Simulate a transient connection loss by cancelling an Artifact RPC
+//     after a few chunks, then retrieve the artifact again and verify it has
+//     the same header and content.
+//  4. Verify the artifact is stored in the test artifact directory.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - The Artifact RPC has no offset, so an interrupted retrieval is resumed
+//     by retrieving the artifact again from the start.
+//   - Artifacts may be several hundred megabytes, so they are written to disk
+//     as they are received rather than held in memory.
+
+// checkChassis calls Healthz Check on the chassis component and returns its
+// path, in the form accepted by the DUT as detected by
+// components.WithSubcomponentPath.
+func checkChassis(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, c hpb.HealthzClient) *tpb.Path {
+	t.Helper()
+	chassis := components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_CHASSIS)
+	if len(chassis) == 0 {
+		t.Fatalf("No chassis component found on %s", dut.Name())
+	}
+	var path *tpb.Path
+	err := components.WithSubcomponentPath(dut, chassis[0], func(p *tpb.Path) error {
+		path = p
+		_, err := c.Check(ctx, &hpb.CheckRequest{Path: p})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Healthz Check(%v) failed: %v", path, err)
+	}
+	return path
+}
+
+// largestFileArtifact returns the largest file artifact reported for path.
+func largestFileArtifact(ctx context.Context, t *testing.T, c hpb.HealthzClient, path *tpb.Path) *hpb.ArtifactHeader {
+	t.Helper()
+	resp, err := c.Get(ctx, &hpb.GetRequest{Path: path})
+	if err != nil {
+		t.Fatalf("Healthz Get(%v) failed: %v", path, err)
+	}
+	var largest *hpb.ArtifactHeader
+	for _, a := range resp.GetComponent().GetArtifacts() {
+		if a.GetFile() == nil {
+			continue
+		}
+		if largest == nil || a.GetFile().GetSize() > largest.GetFile().GetSize() {
+			largest = a
+		}
+	}
+	if largest == nil {
+		t.Fatalf("Healthz Get(%v) reported no file artifacts: %v", path, resp)
+	}
+	return largest
+}
+
+// interrupt starts an Artifact RPC for id and cancels it after n chunks.
+func interrupt(ctx context.Context, t *testing.T, c hpb.HealthzClient, id string, n int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Artifact(ctx, &hpb.ArtifactRequest{Id: id})
+	if err != nil {
+		t.Fatalf("Artifact(%q) failed: %v", id, err)
+	}
+	for chunks := 0; chunks < n; {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Artifact(%q) Recv failed: %v", id, err)
+		}
+		if resp.GetTrailer() != nil {
+			t.Logf("Artifact %q completed in %d chunks before the interruption", id, chunks)
+			return
+		}
+		if resp.GetBytes() != nil {
+			chunks++
+		}
+	}
+	t.Logf("Cancelling Artifact(%q) after %d chunks", id, n)
+}
+
+func fetch(ctx context.Context, t *testing.T, c hpb.HealthzClient, id, dir string) *healthz.Artifact {
+	t.Helper()
+	start := time.Now()
+	a, err := healthz.Fetch(ctx, c, id, dir, &healthz.Options{MaxChunkSize: maxChunkSize})
+	if err != nil {
+		t.Fatalf("Fetch(%q) failed: %v", id, err)
+	}
+	t.Logf("Artifact %q: %d bytes in %d chunks (largest %d bytes) in %v, stored at %s", id, a.Size, a.Chunks, a.MaxChunk, time.Since(start), a.Path)
+	return a
+}
+
+func TestArtifact(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNOI(t).Healthz()
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	dir := fptest.ArtifactDir(t)
+
+	header := largestFileArtifact(ctx, t, c, checkChassis(ctx, t, dut, c))
+	id := header.GetId()
+	t.Logf("Selected artifact %q: %v", id, header.GetFile())
+
+	var first *healthz.Artifact
+	t.Run("Retrieve", func(t *testing.T) {
+		first = fetch(ctx, t, c, id, dir)
+	})
+	if first == nil {
+		t.Fatalf("Artifact %q was not retrieved", id)
+	}
+
+	t.Run("ResumeAfterConnectionLoss", func(t *testing.T) {
+		interrupt(ctx, t, c, id, interruptChunks)
+		second := fetch(ctx, t, c, id, t.TempDir())
+		if second.Size != first.Size {
+			t.Errorf("Artifact %q size after interruption: got %d, want %d", id, second.Size, first.Size)
+		}
+		if got, want := second.Header.GetFile().GetHash().GetHash(), first.Header.GetFile().GetHash().GetHash(); !bytes.Equal(got, want) {
+			t.Errorf("Artifact %q hash after interruption: got %x, want %x", id, got, want)
+		}
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		fi, err := os.Stat(first.Path)
+		if err != nil {
+			t.Fatalf("Stat(%q) failed: %v", first.Path, err)
+		}
+		if fi.Size() != first.Size {
+			t.Errorf("Stored artifact %s size: got %d, want %d", first.Path, fi.Size(), first.Size)
+		}
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coherent_optics_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/samplestream"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+
+	spb "github.com/openconfig/gnoi/system"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+var (
+	berSampleInterval = flag.Duration("ber_sample_interval", time.Second, "SAMPLE interval used to stream pre/post-FEC BER.")
+	berStreamDuration = flag.Duration("ber_stream_duration", time.Minute, "How long to stream pre/post-FEC BER.")
+)
+
+const (
+	dp16QAM             = uint16(1)
+	samplingInterval    = 10 * time.Second
+	powerTolerance      = 1.0
+	minAllowedPreFECBER = 1e-9
+	maxAllowedPreFECBER = 1e-2
+	// minSampleRatio is the minimum fraction of expected BER samples that
+	// must be received while streaming.
+	minSampleRatio    = 0.8
+	linkTimeout       = 10 * time.Minute
+	linecardBoottime  = 20 * time.Minute
+	otnIndexBase      = uint32(4000)
+	ethernetIndexBase = uint32(40000)
+	defaultFrequency  = uint64(193100000)
+	defaultPower      = float64(-10)
+	linecardType      = oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_LINECARD
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Tune the coherent optics to a set of frequencies and target output
+//     powers and verify the optical channel state.
+//  2. Stream pre-FEC and post-FEC BER at --ber_sample_interval and verify
+//     samples arrive at the requested rate with valid values.
+//  3. Flap the interfaces and verify the optical channel comes back with the
+//     configured parameters and no post-FEC errors.
+//  4. Reboot the linecard hosting port-1 and verify the optical channel comes
+//     back with the configured parameters.
+//
+// Topology:
+//
+//	DUT port-1 <--> DUT port-2, both 400ZR.
+
+// channel holds the components and logical channels for one DUT port.
+type channel struct {
+	port        *ondatra.Port
+	transceiver string
+	och         string
+	otnIndex    uint32
+	ethIndex    uint32
+}
+
+// channels returns the channel for each DUT port, failing if any port is not
+// 400ZR.
+func channels(t *testing.T, dut *ondatra.DUTDevice) []*channel {
+	t.Helper()
+	var chs []*channel
+	for i, p := range dut.Ports() {
+		if p.PMD() != ondatra.PMD400GBASEZR {
+			t.Fatalf("%s PMD is %v, not 400ZR", p.Name(), p.PMD())
+		}
+		chs = append(chs, &channel{
+			port:        p,
+			transceiver: gnmi.Get(t, dut, gnmi.OC().Interface(p.Name()).Transceiver().State()),
+			och:         components.OpticalChannelComponentFromPort(t, dut, p),
+			otnIndex:    otnIndexBase + uint32(i),
+			ethIndex:    ethernetIndexBase + uint32(i),
+		})
+	}
+	return chs
+}
+
+// configure applies the optical channel and logical channel config to every
+// channel and waits for the interfaces to come up.
+func configure(t *testing.T, dut *ondatra.DUTDevice, chs []*channel, frequency uint64, power float64) {
+	t.Helper()
+	for _, ch := range chs {
+		cfgplugins.ConfigOpticalChannel(t, dut, ch.och, frequency, power, dp16QAM)
+		cfgplugins.ConfigOTNChannel(t, dut, ch.och, ch.otnIndex, ch.ethIndex)
+		cfgplugins.ConfigETHChannel(t, dut, ch.port.Name(), ch.transceiver, ch.otnIndex, ch.ethIndex)
+		cfgplugins.ToggleInterface(t, dut, ch.port.Name(), true)
+	}
+	awaitOperStatus(t, dut, chs, oc.Interface_OperStatus_UP, linkTimeout)
+}
+
+func awaitOperStatus(t *testing.T, dut *ondatra.DUTDevice, chs []*channel, want oc.E_Interface_OperStatus, timeout time.Duration) {
+	t.Helper()
+	for _, ch := range chs {
+		gnmi.Await(t, dut, gnmi.OC().Interface(ch.port.Name()).OperStatus().State(), timeout, want)
+	}
+}
+
+// verifyOpticalChannel checks the optical channel state against the
+// configured frequency and target output power.
+func verifyOpticalChannel(t *testing.T, dut *ondatra.DUTDevice, chs []*channel, frequency uint64, power float64) {
+	t.Helper()
+	for _, ch := range chs {
+		s := samplestream.New(t, dut, gnmi.OC().Component(ch.och).OpticalChannel().State(), samplingInterval)
+		v, ok := s.Next().Val()
+		s.Close()
+		if !ok {
+			t.Errorf("%s: optical channel %s telemetry not received", ch.port.Name(), ch.och)
+			continue
+		}
+		if got := v.GetFrequency(); got != frequency {
+			t.Errorf("%s: frequency: got %v, want %v", ch.och, got, frequency)
+		}
+		if got := v.GetTargetOutputPower(); got != power {
+			t.Errorf("%s: target-output-power: got %v, want %v", ch.och, got, power)
+		}
+		if got := v.GetOperationalMode(); got != dp16QAM {
+			t.Errorf("%s: operational-mode: got %v, want %v", ch.och, got, dp16QAM)
+		}
+		if got := v.GetOutputPower().GetInstant(); got < power-powerTolerance || got > power+powerTolerance {
+			t.Errorf("%s: output-power instant: got %v, want %v +/- %v", ch.och, got, power, powerTolerance)
+		}
+	}
+}
+
+// verifyNoPostFECErrors checks that no post-FEC bit errors are reported.
+func verifyNoPostFECErrors(t *testing.T, dut *ondatra.DUTDevice, chs []*channel) {
+	t.Helper()
+	for _, ch := range chs {
+		if got := gnmi.Get(t, dut, gnmi.OC().TerminalDevice().Channel(ch.otnIndex).Otn().PostFecBer().Instant().State()); got != 0 {
+			t.Errorf("%s: post-FEC BER instant: got %v, want 0", ch.port.Name(), got)
+		}
+	}
+}
+
+// linecardOf walks up the component tree from name and returns the linecard
+// containing it, or "" if there is none.
+func linecardOf(t *testing.T, dut *ondatra.DUTDevice, name string) string {
+	t.Helper()
+	for name != "" {
+		if typ, ok := gnmi.Lookup(t, dut, gnmi.OC().Component(name).Type().State()).Val(); ok && typ == linecardType {
+			return name
+		}
+		name, _ = gnmi.Lookup(t, dut, gnmi.OC().Component(name).Parent().State()).Val()
+	}
+	return ""
+}
+
+func TestTuning(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	chs := channels(t, dut)
+
+	for _, frequency := range []uint64{191400000, 193100000, 196100000} {
+		for _, power := range []float64{-13, -10, -9} {
+			t.Run(fmt.Sprintf("Freq %v Power %v", frequency, power), func(t *testing.T) {
+				configure(t, dut, chs, frequency, power)
+				verifyOpticalChannel(t, dut, chs, frequency, power)
+			})
+		}
+	}
+}
+
+func TestFECBERStreaming(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	chs := channels(t, dut)
+	configure(t, dut, chs, defaultFrequency, defaultPower)
+
+	streams := make(map[string]*samplestream.SampleStream[*oc.TerminalDevice_Channel_Otn])
+	for _, ch := range chs {
+		s := samplestream.New(t, dut, gnmi.OC().TerminalDevice().Channel(ch.otnIndex).Otn().State(), *berSampleInterval)
+		defer s.Close()
+		streams[ch.port.Name()] = s
+	}
+	time.Sleep(*berStreamDuration)
+
+	wantSamples := int(minSampleRatio * float64(*berStreamDuration) / float64(*berSampleInterval))
+	for _, ch := range chs {
+		t.Run(ch.port.Name(), func(t *testing.T) {
+			samples := streams[ch.port.Name()].All()
+			if len(samples) < wantSamples {
+				t.Errorf("Received %d BER samples in %v at %v interval, want at least %d", len(samples), *berStreamDuration, *berSampleInterval, wantSamples)
+			}
+			for _, sample := range samples {
+				otn, ok := sample.Val()
+				if !ok {
+					continue
+				}
+				if b := otn.GetPreFecBer().GetInstant(); b < minAllowedPreFECBER || b > maxAllowedPreFECBER {
+					t.Errorf("Pre-FEC BER at %v: got %v, want in [%v, %v]", sample.Timestamp, b, minAllowedPreFECBER, maxAllowedPreFECBER)
+				}
+				if b := otn.GetPostFecBer().GetInstant(); b != 0 {
+					t.Errorf("Post-FEC BER at %v: got %v, want 0", sample.Timestamp, b)
+				}
+			}
+		})
+	}
+}
+
+func TestInterfaceFlap(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	chs := channels(t, dut)
+	configure(t, dut, chs, defaultFrequency, defaultPower)
+
+	for _, ch := range chs {
+		cfgplugins.ToggleInterface(t, dut, ch.port.Name(), false)
+	}
+	awaitOperStatus(t, dut, chs, oc.Interface_OperStatus_DOWN, linkTimeout)
+	for _, ch := range chs {
+		cfgplugins.ToggleInterface(t, dut, ch.port.Name(), true)
+	}
+	awaitOperStatus(t, dut, chs, oc.Interface_OperStatus_UP, linkTimeout)
+
+	verifyOpticalChannel(t, dut, chs, defaultFrequency, defaultPower)
+	verifyNoPostFECErrors(t, dut, chs)
+}
+
+func TestLinecardReboot(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	chs := channels(t, dut)
+	configure(t, dut, chs, defaultFrequency, defaultPower)
+
+	lc := linecardOf(t, dut, chs[0].transceiver)
+	if lc == "" {
+		t.Skipf("No linecard found for transceiver %s", chs[0].transceiver)
+	}
+	t.Logf("Rebooting linecard %s hosting %s", lc, chs[0].port.Name())
+
+	gnoiClient := dut.RawAPIs().GNOI(t)
+	err := components.WithSubcomponentPath(dut, lc, func(p *tpb.Path) error {
+		_, err := gnoiClient.System().Reboot(context.Background(), &spb.RebootRequest{
+			Method:        spb.RebootMethod_COLD,
+			Subcomponents: []*tpb.Path{p},
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to reboot linecard %s: %v", lc, err)
+	}
+	start := time.Now()
+	gnmi.Await(t, dut, gnmi.OC().Interface(chs[0].port.Name()).OperStatus().State(), linecardBoottime, oc.Interface_OperStatus_DOWN)
+	awaitOperStatus(t, dut, chs, oc.Interface_OperStatus_UP, linecardBoottime)
+	t.Logf("Interfaces came back up %.2f seconds after linecard reboot", time.Since(start).Seconds())
+
+	verifyOpticalChannel(t, dut, chs, defaultFrequency, defaultPower)
+	verifyNoPostFECErrors(t, dut, chs)
+}
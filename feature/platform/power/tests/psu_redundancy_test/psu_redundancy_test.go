@@ -0,0 +1,309 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package psu_redundancy_test
+
+import (
+	"encoding/binary"
+	"flag"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/components"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var (
+	redundancy = flag.String("redundancy", "n+1", `Power supply redundancy of the DUT: "n+1", "n+n" or "none".`)
+	disablePSU = flag.Bool("disable_psu", false, "Disable a power supply to validate that the DUT stays powered by the others.")
+)
+
+const (
+	// psuTimeout is the time for a power supply to be disabled or enabled.
+	psuTimeout = 2 * time.Minute
+	// settleTime is the time for the other power supplies to take over the
+	// load after a power supply is disabled.
+	settleTime = 30 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Inventory: the DUT reports its power supplies, each removable and not
+//     empty power supply with an oper-status.
+//  2. Telemetry: each active power supply reports a capacity, input voltage
+//     and current, and output voltage, current and power within its
+//     capacity.
+//  3. Redundancy: the output power of all active power supplies can be
+//     supplied by them with the largest one (N+1) or the larger half (N+N)
+//     removed.
+//  4. Fault alarms: each present power supply that is not active has an
+//     alarm raised for it.
+//  5. Disable (with --disable_psu): with the largest active power supply
+//     disabled, the others supply the DUT, and the power supply is active
+//     again once re-enabled.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - OpenConfig does not model the power supply redundancy mode, so it is
+//     given by --redundancy, and validated from the capacity and output power
+//     telemetry.
+//   - Platforms without removable power supplies are not modular, and are
+//     skipped.
+
+// psu is the state of a power supply.
+type psu struct {
+	name         string
+	capacity     float32
+	outputPower  float32
+	hasTelemetry bool
+}
+
+// ieeeFloat32 decodes an IEEE 754 float32 value of a power supply leaf.
+func ieeeFloat32(b oc.Binary) (float32, bool) {
+	if len(b) != 4 {
+		return 0, false
+	}
+	return math.Float32frombits(binary.BigEndian.Uint32(b)), true
+}
+
+// powerSupplies returns the removable power supplies of the DUT that are not
+// empty, skipping the test if there are none.
+func powerSupplies(t *testing.T, dut *ondatra.DUTDevice) []*oc.Component {
+	t.Helper()
+	var psus []*oc.Component
+	for _, name := range components.FindComponentsByType(t, dut, oc.PlatformTypes_OPENCONFIG_HARDWARE_COMPONENT_POWER_SUPPLY) {
+		c := gnmi.Get(t, dut, gnmi.OC().Component(name).State())
+		if !c.GetRemovable() {
+			t.Logf("Power supply %s is not removable", name)
+			continue
+		}
+		if c.GetEmpty() {
+			t.Logf("Power supply slot %s is empty", name)
+			continue
+		}
+		psus = append(psus, c)
+	}
+	if len(psus) == 0 {
+		t.Skip("No removable power supplies, the DUT is not a modular platform")
+	}
+	return psus
+}
+
+// activePSUs returns the power supplies in psus that are active, with their
+// capacity and output power.
+func activePSUs(t *testing.T, psus []*oc.Component) []psu {
+	t.Helper()
+	var active []psu
+	for _, c := range psus {
+		if c.GetOperStatus() != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE {
+			continue
+		}
+		p := psu{name: c.GetName()}
+		capacity, okCap := ieeeFloat32(c.GetPowerSupply().Capacity)
+		output, okOut := ieeeFloat32(c.GetPowerSupply().OutputPower)
+		p.capacity, p.outputPower, p.hasTelemetry = capacity, output, okCap && okOut
+		active = append(active, p)
+	}
+	return active
+}
+
+// spare returns the number of the largest power supplies whose capacity must
+// be spare with n power supplies active.
+func spare(t *testing.T, n int) int {
+	t.Helper()
+	switch *redundancy {
+	case "none":
+		return 0
+	case "n+1":
+		return 1
+	case "n+n":
+		return n / 2
+	}
+	t.Fatalf("Invalid --redundancy %q", *redundancy)
+	return 0
+}
+
+// checkRedundancy verifies that the output power of active can be supplied
+// with the spare largest power supplies removed.
+func checkRedundancy(t *testing.T, active []psu) {
+	t.Helper()
+	s := spare(t, len(active))
+	if len(active) <= s {
+		t.Fatalf("Got %d active power supplies, want more than %d for %s redundancy", len(active), s, *redundancy)
+	}
+	var load float32
+	var capacities []float32
+	for _, p := range active {
+		if !p.hasTelemetry {
+			t.Fatalf("Power supply %s reports no capacity or output power", p.name)
+		}
+		load += p.outputPower
+		capacities = append(capacities, p.capacity)
+	}
+	sort.Slice(capacities, func(i, j int) bool { return capacities[i] < capacities[j] })
+	var remaining float32
+	for _, c := range capacities[:len(capacities)-s] {
+		remaining += c
+	}
+	t.Logf("Output power %.1f W, capacity without the %d largest power supplies %.1f W", load, s, remaining)
+	if load > remaining {
+		t.Errorf("Output power %.1f W exceeds the capacity %.1f W left with the %d largest of %d power supplies removed, want %s redundancy", load, remaining, s, len(active), *redundancy)
+	}
+}
+
+func TestPSURedundancy(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	psus := powerSupplies(t, dut)
+
+	t.Run("Inventory", func(t *testing.T) {
+		for _, c := range psus {
+			t.Logf("Power supply %s: oper-status %v, enabled %t", c.GetName(), c.GetOperStatus(), c.GetPowerSupply().GetEnabled())
+			if c.GetOperStatus() == oc.PlatformTypes_COMPONENT_OPER_STATUS_UNSET {
+				t.Errorf("Power supply %s has no oper-status", c.GetName())
+			}
+		}
+	})
+
+	active := activePSUs(t, psus)
+	t.Run("Telemetry", func(t *testing.T) {
+		if len(active) == 0 {
+			t.Fatal("No active power supplies")
+		}
+		for _, c := range psus {
+			if c.GetOperStatus() != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE {
+				continue
+			}
+			ps := c.GetPowerSupply()
+			vals := make(map[string]float32)
+			for _, leaf := range []struct {
+				name string
+				val  oc.Binary
+			}{
+				{"capacity", ps.Capacity},
+				{"input-voltage", ps.InputVoltage},
+				{"input-current", ps.InputCurrent},
+				{"output-voltage", ps.OutputVoltage},
+				{"output-current", ps.OutputCurrent},
+				{"output-power", ps.OutputPower},
+			} {
+				v, ok := ieeeFloat32(leaf.val)
+				if !ok {
+					t.Errorf("Power supply %s %s: got %x, want an IEEE float32", c.GetName(), leaf.name, leaf.val)
+					continue
+				}
+				if v < 0 || math.IsNaN(float64(v)) {
+					t.Errorf("Power supply %s %s: got %v, want a non-negative value", c.GetName(), leaf.name, v)
+				}
+				vals[leaf.name] = v
+			}
+			t.Logf("Power supply %s: %v", c.GetName(), vals)
+			for _, leaf := range []string{"capacity", "input-voltage", "output-voltage"} {
+				if v, ok := vals[leaf]; ok && v == 0 {
+					t.Errorf("Power supply %s %s: got 0, want a positive value for an active power supply", c.GetName(), leaf)
+				}
+			}
+			if vals["output-power"] > vals["capacity"] {
+				t.Errorf("Power supply %s output-power %.1f W exceeds its capacity %.1f W", c.GetName(), vals["output-power"], vals["capacity"])
+			}
+		}
+	})
+
+	t.Run("Redundancy", func(t *testing.T) {
+		checkRedundancy(t, active)
+	})
+
+	t.Run("Fault alarms", func(t *testing.T) {
+		alarms := make(map[string][]string)
+		for _, v := range gnmi.LookupAll(t, dut, gnmi.OC().System().AlarmAny().State()) {
+			if a, ok := v.Val(); ok {
+				alarms[a.GetResource()] = append(alarms[a.GetResource()], a.GetText())
+			}
+		}
+		faulty := 0
+		for _, c := range psus {
+			if c.GetOperStatus() == oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE {
+				continue
+			}
+			faulty++
+			if len(alarms[c.GetName()]) == 0 {
+				t.Errorf("Power supply %s is %v, want an alarm raised for it", c.GetName(), c.GetOperStatus())
+				continue
+			}
+			t.Logf("Power supply %s is %v with alarms %q", c.GetName(), c.GetOperStatus(), alarms[c.GetName()])
+		}
+		if faulty == 0 {
+			t.Skip("All power supplies are active")
+		}
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		if !*disablePSU {
+			t.Skip("Disabling a power supply requires --disable_psu")
+		}
+		if len(active) < 2 || spare(t, len(active)) == 0 {
+			t.Skipf("Got %d active power supplies with %s redundancy, need a spare power supply to disable", len(active), *redundancy)
+		}
+		largest := active[0]
+		for _, p := range active[1:] {
+			if p.capacity > largest.capacity {
+				largest = p
+			}
+		}
+		t.Logf("Disabling power supply %s", largest.name)
+		ps := gnmi.OC().Component(largest.name).PowerSupply()
+		gnmi.Replace(t, dut, ps.Enabled().Config(), false)
+		defer func() {
+			gnmi.Replace(t, dut, ps.Enabled().Config(), true)
+			gnmi.Await(t, dut, gnmi.OC().Component(largest.name).OperStatus().State(), psuTimeout, oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE)
+		}()
+		_, ok := gnmi.Watch(t, dut, gnmi.OC().Component(largest.name).OperStatus().State(), psuTimeout, func(val *ygnmi.Value[oc.E_PlatformTypes_COMPONENT_OPER_STATUS]) bool {
+			oper, present := val.Val()
+			return present && oper != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE
+		}).Await(t)
+		if !ok {
+			t.Fatalf("Power supply %s is still active after being disabled", largest.name)
+		}
+		time.Sleep(settleTime)
+
+		var others []psu
+		for _, p := range activePSUs(t, powerSupplies(t, dut)) {
+			if p.name != largest.name {
+				others = append(others, p)
+			}
+		}
+		if len(others) != len(active)-1 {
+			t.Errorf("Got %d active power supplies with %s disabled, want %d", len(others), largest.name, len(active)-1)
+		}
+		var load float32
+		for _, p := range others {
+			load += p.outputPower
+		}
+		if load == 0 {
+			t.Errorf("Power supplies %v report no output power with %s disabled", others, largest.name)
+		}
+		t.Logf("Output power with %s disabled: %.1f W", largest.name, load)
+	})
+}
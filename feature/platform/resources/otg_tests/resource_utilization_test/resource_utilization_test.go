@@ -0,0 +1,399 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_utilization_test
+
+import (
+	"flag"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	bgpRoutesStart  = "2001:db8:1000::"
+	bgpRouteName    = "scale-v6"
+	gribiStart      = "198.18.0.0"
+	labelStart      = 100000
+	aclName         = "SCALE-ACL"
+	aclSourceStart  = "198.19.0.0"
+	nhIndex         = 1
+	nhgIndex        = 1
+	scaleTimeout    = 10 * time.Minute
+	resourceTimeout = 5 * time.Minute
+)
+
+var (
+	fibV4Resource = flag.String("fib_v4_resource", "", "Name of the IPv4 FIB hardware resource. Defaults to the name for the DUT vendor.")
+	fibV6Resource = flag.String("fib_v6_resource", "", "Name of the IPv6 FIB hardware resource. Defaults to the name for the DUT vendor.")
+	labelResource = flag.String("label_resource", "", "Name of the MPLS label hardware resource. Defaults to the name for the DUT vendor.")
+	tcamResource  = flag.String("tcam_resource", "", "Name of the ACL TCAM hardware resource. Defaults to the name for the DUT vendor.")
+
+	bgpRoutes     = flag.Int("bgp_routes", 250000, "Number of IPv6 routes advertised over BGP.")
+	gribiEntries  = flag.Int("gribi_entries", 10000, "Number of IPv4 entries programmed over gRIBI.")
+	labelEntries  = flag.Int("label_entries", 10000, "Number of MPLS label entries programmed over gRIBI.")
+	aclEntries    = flag.Int("acl_entries", 1000, "Number of entries in the ingress ACL.")
+	thresholdStep = flag.Uint("threshold_step", 1, "Percentage above the initial utilization at which used-threshold-upper is set.")
+)
+
+// defaultResources are the hardware resource names for each vendor, keyed by
+// the flag overriding them.
+var defaultResources = map[*string]map[ondatra.Vendor]string{
+	fibV6Resource: {
+		ondatra.ARISTA: "Routing/Resource6",
+	},
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. IPv6 FIB: advertise IPv6 routes from ATE port-1 over BGP, then
+//     withdraw them.
+//  2. IPv4 FIB: program IPv4 entries pointing to ATE port-2 over gRIBI, then
+//     flush them.
+//  3. MPLS label: program MPLS label entries pointing to ATE port-2 over
+//     gRIBI, then flush them.
+//  4. ACL TCAM: apply an ingress ACL to DUT port-1, then delete it.
+//
+// For each case:
+//   - Get the utilization of the resource on each component it is active on.
+//   - Configure used-threshold-upper -threshold_step percent above the
+//     highest utilization, and used-threshold-upper-clear at it.
+//   - Add the scale, and verify used increases on the components, and
+//     used-threshold-upper-exceeded becomes true on the components whose
+//     utilization crosses used-threshold-upper.
+//   - Remove the scale, and verify used decreases, and
+//     used-threshold-upper-exceeded becomes false again.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - Hardware resource names are not standardized.  A case is skipped when
+//     there is no resource name for the DUT vendor and none is given by flag.
+
+type utilization struct {
+	used uint64
+	free uint64
+}
+
+func (u utilization) percent() uint64 {
+	if u.used == 0 && u.free == 0 {
+		return 0
+	}
+	return u.used * 100 / (u.used + u.free)
+}
+
+// resourceName returns the name of the resource in flag f for the DUT.
+func resourceName(dut *ondatra.DUTDevice, f *string) string {
+	if *f != "" {
+		return *f
+	}
+	return defaultResources[f][dut.Vendor()]
+}
+
+// componentResource returns the name of res in the components.
+func componentResource(dut *ondatra.DUTDevice, res string) string {
+	if deviations.MismatchedHardwareResourceNameInComponent(dut) {
+		return res + "/-"
+	}
+	return res
+}
+
+// activeComponents returns the components res is active on.
+func activeComponents(t *testing.T, dut *ondatra.DUTDevice, res string) []string {
+	t.Helper()
+	val, ok := gnmi.Watch(t, dut, gnmi.OC().System().Utilization().Resource(res).ActiveComponentList().State(), time.Minute, func(v *ygnmi.Value[[]string]) bool {
+		cs, present := v.Val()
+		return present && len(cs) > 0
+	}).Await(t)
+	if !ok {
+		t.Fatalf("Resource %s is not active in any component", res)
+	}
+	comps, _ := val.Val()
+	return comps
+}
+
+// utilizations returns the utilization of res on each of comps.
+func utilizations(t *testing.T, dut *ondatra.DUTDevice, res string, comps []string) map[string]utilization {
+	t.Helper()
+	utzs := make(map[string]utilization)
+	for _, c := range comps {
+		r := gnmi.Get(t, dut, gnmi.OC().Component(c).IntegratedCircuit().Utilization().Resource(componentResource(dut, res)).State())
+		utzs[c] = utilization{used: r.GetUsed(), free: r.GetFree()}
+	}
+	return utzs
+}
+
+// configureThresholds sets the used thresholds of res at the system level.
+func configureThresholds(t *testing.T, dut *ondatra.DUTDevice, res string, upper, upperClear uint8) {
+	t.Helper()
+	gnmi.Replace(t, dut, gnmi.OC().System().Utilization().Resource(res).Config(), &oc.System_Utilization_Resource{
+		Name:                    ygot.String(res),
+		UsedThresholdUpper:      ygot.Uint8(upper),
+		UsedThresholdUpperClear: ygot.Uint8(upperClear),
+	})
+}
+
+// awaitUsed waits for used of res on component c to satisfy pred.
+func awaitUsed(t *testing.T, dut *ondatra.DUTDevice, c, res string, pred func(uint64) bool) bool {
+	t.Helper()
+	_, ok := gnmi.Watch(t, dut, gnmi.OC().Component(c).IntegratedCircuit().Utilization().Resource(componentResource(dut, res)).Used().State(), resourceTimeout, func(v *ygnmi.Value[uint64]) bool {
+		used, present := v.Val()
+		return present && pred(used)
+	}).Await(t)
+	return ok
+}
+
+// awaitExceeded waits for used-threshold-upper-exceeded of res on component
+// c to be want.
+func awaitExceeded(t *testing.T, dut *ondatra.DUTDevice, c, res string, want bool) {
+	t.Helper()
+	if _, ok := gnmi.Watch(t, dut, gnmi.OC().Component(c).IntegratedCircuit().Utilization().Resource(componentResource(dut, res)).UsedThresholdUpperExceeded().State(), resourceTimeout, func(v *ygnmi.Value[bool]) bool {
+		exceeded, present := v.Val()
+		return present && exceeded == want
+	}).Await(t); !ok {
+		t.Errorf("Component %s resource %s: used-threshold-upper-exceeded is not %v after %v", c, res, want, resourceTimeout)
+	}
+}
+
+// ipv4Addrs returns n consecutive IPv4 addresses from start.
+func ipv4Addrs(t *testing.T, start string, n int) []string {
+	t.Helper()
+	a, err := netip.ParseAddr(start)
+	if err != nil {
+		t.Fatalf("Cannot parse %s: %v", start, err)
+	}
+	addrs := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		addrs = append(addrs, a.String())
+		a = a.Next()
+	}
+	return addrs
+}
+
+// scaler adds and removes the scale of a test case.
+type scaler struct {
+	bs     *cfgplugins.BGPSession
+	client *gribi.Client
+}
+
+func (s *scaler) setBGPRoutes(t *testing.T, state gosnappi.StateProtocolRouteStateEnum) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Protocol().Route().SetNames([]string{bgpRouteName}).SetState(state)
+	s.bs.ATE.OTG().SetControlState(t, cs)
+}
+
+func (s *scaler) addBGPRoutes(t *testing.T) {
+	s.setBGPRoutes(t, gosnappi.StateProtocolRouteState.ADVERTISE)
+	received := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(s.bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(s.bs.ATEPorts[0].IPv6).
+		AfiSafi(oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST).Prefixes().Received().State()
+	if got, ok := gnmi.Watch(t, s.bs.DUT, received, scaleTimeout, func(v *ygnmi.Value[uint32]) bool {
+		n, present := v.Val()
+		return present && n == uint32(*bgpRoutes)
+	}).Await(t); !ok {
+		t.Fatalf("Prefixes received from %s: got %v, want %d", s.bs.ATEPorts[0].IPv6, got, *bgpRoutes)
+	}
+}
+
+func (s *scaler) removeBGPRoutes(t *testing.T) {
+	s.setBGPRoutes(t, gosnappi.StateProtocolRouteState.WITHDRAW)
+}
+
+// addNextHop programs the gRIBI next hop group to ATE port-2.
+func (s *scaler) addNextHop(t *testing.T) {
+	ni := deviations.DefaultNetworkInstance(s.bs.DUT)
+	s.client.AddNH(t, nhIndex, s.bs.ATEPorts[1].IPv4, ni, fluent.InstalledInRIB)
+	s.client.AddNHG(t, nhgIndex, map[uint64]uint64{nhIndex: 1}, ni, fluent.InstalledInRIB)
+}
+
+func (s *scaler) addGRIBIEntries(t *testing.T) {
+	s.addNextHop(t)
+	ni := deviations.DefaultNetworkInstance(s.bs.DUT)
+	var entries []fluent.GRIBIEntry
+	for _, a := range ipv4Addrs(t, gribiStart, *gribiEntries) {
+		entries = append(entries, fluent.IPv4Entry().WithPrefix(a+"/32").WithNetworkInstance(ni).WithNextHopGroup(nhgIndex))
+	}
+	s.client.AddEntries(t, entries, nil)
+}
+
+func (s *scaler) addLabelEntries(t *testing.T) {
+	s.addNextHop(t)
+	ni := deviations.DefaultNetworkInstance(s.bs.DUT)
+	var entries []fluent.GRIBIEntry
+	for i := 0; i < *labelEntries; i++ {
+		entries = append(entries, fluent.LabelEntry().WithLabel(uint32(labelStart+i)).WithNetworkInstance(ni).WithNextHopGroup(nhgIndex))
+	}
+	s.client.AddEntries(t, entries, nil)
+}
+
+func (s *scaler) flushGRIBI(t *testing.T) {
+	s.client.FlushAll(t)
+}
+
+func (s *scaler) aclInterface(t *testing.T) string {
+	id := s.bs.DUT.Port(t, "port1").Name()
+	if deviations.InterfaceRefInterfaceIDFormat(s.bs.DUT) {
+		id += ".0"
+	}
+	return id
+}
+
+func (s *scaler) addACL(t *testing.T) {
+	dut := s.bs.DUT
+	acl := (&oc.Root{}).GetOrCreateAcl()
+	set := acl.GetOrCreateAclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV4)
+	for i, a := range ipv4Addrs(t, aclSourceStart, *aclEntries) {
+		e := set.GetOrCreateAclEntry(uint32(10 * (i + 1)))
+		e.GetOrCreateIpv4().SourceAddress = ygot.String(a + "/32")
+		e.GetOrCreateActions().ForwardingAction = oc.Acl_FORWARDING_ACTION_DROP
+	}
+	set.GetOrCreateAclEntry(uint32(10 * (*aclEntries + 1))).GetOrCreateActions().ForwardingAction = oc.Acl_FORWARDING_ACTION_ACCEPT
+
+	intf := acl.GetOrCreateInterface(s.aclInterface(t))
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(dut.Port(t, "port1").Name())
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	intf.GetOrCreateIngressAclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV4)
+	gnmi.Update(t, dut, gnmi.OC().Acl().Config(), acl)
+}
+
+func (s *scaler) removeACL(t *testing.T) {
+	gnmi.Delete(t, s.bs.DUT, gnmi.OC().Acl().Interface(s.aclInterface(t)).Config())
+	gnmi.Delete(t, s.bs.DUT, gnmi.OC().Acl().AclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV4).Config())
+}
+
+// testResource verifies the utilization and threshold telemetry of res as
+// the scale is added and removed.
+func testResource(t *testing.T, dut *ondatra.DUTDevice, res string, add, remove func(t *testing.T)) {
+	if deviations.MissingHardwareResourceTelemetryBeforeConfig(dut) {
+		configureThresholds(t, dut, res, 100, 99)
+	}
+	defer gnmi.Delete(t, dut, gnmi.OC().System().Utilization().Resource(res).Config())
+	comps := activeComponents(t, dut, res)
+	before := utilizations(t, dut, res, comps)
+	var maxPct uint64
+	for c, u := range before {
+		t.Logf("Component %s resource %s before: used %d, free %d, %d%%", c, res, u.used, u.free, u.percent())
+		maxPct = max(maxPct, u.percent())
+	}
+	upper := maxPct + uint64(*thresholdStep)
+	if upper > 100 {
+		t.Fatalf("Resource %s is %d%% used, cannot set used-threshold-upper %d%% above it", res, maxPct, *thresholdStep)
+	}
+	configureThresholds(t, dut, res, uint8(upper), uint8(maxPct))
+
+	add(t)
+	after := make(map[string]utilization)
+	var crossed []string
+	for _, c := range comps {
+		if !awaitUsed(t, dut, c, res, func(used uint64) bool { return used > before[c].used }) {
+			t.Errorf("Component %s resource %s: used did not increase from %d after adding scale", c, res, before[c].used)
+			continue
+		}
+		after[c] = utilizations(t, dut, res, []string{c})[c]
+		t.Logf("Component %s resource %s after adding scale: used %d, free %d, %d%%", c, res, after[c].used, after[c].free, after[c].percent())
+		if after[c].percent() >= upper {
+			crossed = append(crossed, c)
+			awaitExceeded(t, dut, c, res, true)
+		}
+	}
+	if len(crossed) == 0 {
+		t.Errorf("Resource %s utilization did not cross used-threshold-upper %d%% on any component; increase the scale or lower -threshold_step", res, upper)
+	}
+
+	remove(t)
+	for c, u := range after {
+		if !awaitUsed(t, dut, c, res, func(used uint64) bool { return used < u.used }) {
+			t.Errorf("Component %s resource %s: used did not decrease from %d after removing scale", c, res, u.used)
+		}
+	}
+	for _, c := range crossed {
+		awaitExceeded(t, dut, c, res, false)
+	}
+}
+
+func TestResourceUtilization(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST}, []string{"port1"}, false, false)
+	peer := bs.ATEIntfs[0].Bgp().Ipv6Interfaces().Items()[0].Peers().Items()[0]
+	rr := peer.V6Routes().Add().SetName(bgpRouteName)
+	rr.SetNextHopIpv6Address(bs.ATEPorts[0].IPv6).
+		SetNextHopAddressType(gosnappi.BgpV6RouteRangeNextHopAddressType.IPV6).
+		SetNextHopMode(gosnappi.BgpV6RouteRangeNextHopMode.MANUAL)
+	rr.Addresses().Add().SetAddress(bgpRoutesStart).SetPrefix(128).SetCount(uint32(*bgpRoutes))
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	cfgplugins.VerifyDUTBGPEstablished(t, bs.DUT)
+
+	client := &gribi.Client{
+		DUT:         bs.DUT,
+		FIBACK:      false,
+		Persistence: true,
+	}
+	if err := client.Start(t); err != nil {
+		t.Fatalf("gRIBI Connection can not be established: %v", err)
+	}
+	defer client.Close(t)
+	client.BecomeLeader(t)
+	client.FlushAll(t)
+	defer client.FlushAll(t)
+
+	s := &scaler{bs: bs, client: client}
+	// The BGP routes are advertised when the protocols start, so withdraw them
+	// before measuring the initial utilization.
+	s.removeBGPRoutes(t)
+
+	cases := []struct {
+		desc     string
+		resource *string
+		flag     string
+		add      func(t *testing.T)
+		remove   func(t *testing.T)
+	}{
+		{"IPv6 FIB", fibV6Resource, "fib_v6_resource", s.addBGPRoutes, s.removeBGPRoutes},
+		{"IPv4 FIB", fibV4Resource, "fib_v4_resource", s.addGRIBIEntries, s.flushGRIBI},
+		{"MPLS label", labelResource, "label_resource", s.addLabelEntries, s.flushGRIBI},
+		{"ACL TCAM", tcamResource, "tcam_resource", s.addACL, s.removeACL},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res := resourceName(bs.DUT, tc.resource)
+			if res == "" {
+				t.Skipf("No %s resource name for %v, set -%s", tc.desc, bs.DUT.Vendor(), tc.flag)
+			}
+			testResource(t, bs.DUT, res, tc.add, tc.remove)
+		})
+	}
+}
@@ -127,7 +127,7 @@ func TestOpticsPowerBiasCurrent(t *testing.T) {
 			}
 
 			if deviations.TransceiverThresholdsUnsupported(dut) {
-				t.Logf("Skipping verification of transceiver threshold leaves due to deviation")
+				fptest.Waive(t, "transceiver_thresholds_unsupported", "not checking threshold leaves of transceiver %s", transceiver)
 			} else {
 				// TODO(ankursaikia): Validate the values for each leaf.
 				ths := gnmi.GetAll(t, dut, component.Transceiver().ThresholdAny().State())
@@ -262,7 +262,7 @@ func TestOpticsPowerUpdate(t *testing.T) {
 				}
 			}
 			if deviations.TransceiverThresholdsUnsupported(dut) {
-				t.Logf("Skipping verification of transceiver threshold leaves due to deviation")
+				fptest.Waive(t, "transceiver_thresholds_unsupported", "not checking threshold leaves of transceiver %s", transceiverName)
 			} else {
 				ths := gnmi.GetAll(t, dut, component.Transceiver().ThresholdAny().State())
 				for _, th := range ths {
@@ -0,0 +1,171 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transceiver_disable_enable_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+const (
+	linkTimeout  = 5 * time.Minute
+	cycleTimeout = 2*linkTimeout + time.Minute
+)
+
+var dutPort1 = attrs.Attributes{
+	Desc:    "dutPort1",
+	IPv4:    "192.0.2.1",
+	IPv4Len: 30,
+	IPv6:    "2001:db8::1",
+	IPv6Len: 126,
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure DUT port-1 with a description and IPv4/IPv6 addresses and
+//     record its config and the physical channels of its transceiver.
+//  2. Disable the transceiver and verify telemetry reports it disabled and
+//     the interface down.
+//  3. Re-enable the transceiver and verify telemetry reports it enabled and
+//     the interface up, with ON_CHANGE updates for both transitions.
+//  4. Verify the interface config is unchanged, the transceiver reports the
+//     same physical channels and the interface addresses are back in state.
+//
+// Topology:
+//
+//	dut:port1 <--> ate:port1
+//
+// Test notes:
+//   - Disabling the transceiver approximates removing it (OIR) without
+//     physical access to the testbed.
+
+// channelIndexes returns the sorted physical channel indexes of transceiver
+// tr.
+func channelIndexes(t *testing.T, dut *ondatra.DUTDevice, tr string) []uint16 {
+	t.Helper()
+	var idx []uint16
+	for _, v := range gnmi.LookupAll(t, dut, gnmi.OC().Component(tr).Transceiver().ChannelAny().Index().State()) {
+		if i, ok := v.Val(); ok {
+			idx = append(idx, i)
+		}
+	}
+	sort.Slice(idx, func(i, j int) bool { return idx[i] < idx[j] })
+	return idx
+}
+
+// watchCycle returns a watcher on q that completes once it has seen a value
+// other than want followed by want.
+func watchCycle[T comparable](t *testing.T, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[T], want T) *gnmi.Watcher[T] {
+	t.Helper()
+	var left bool
+	return gnmi.Watch(t, dut, q, cycleTimeout, func(v *ygnmi.Value[T]) bool {
+		if got, ok := v.Val(); !ok || got != want {
+			left = true
+			return false
+		}
+		return left
+	})
+}
+
+func setTransceiverEnabled(t *testing.T, dut *ondatra.DUTDevice, tr string, enabled bool) {
+	t.Helper()
+	gnmi.Replace(t, dut, gnmi.OC().Component(tr).Transceiver().Enabled().Config(), enabled)
+	gnmi.Await(t, dut, gnmi.OC().Component(tr).Transceiver().Enabled().State(), linkTimeout, enabled)
+}
+
+func TestTransceiverDisableEnable(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	dp := dut.Port(t, "port1")
+	intf := gnmi.OC().Interface(dp.Name())
+
+	gnmi.Replace(t, dut, intf.Config(), dutPort1.NewOCInterface(dp.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, dp)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+	gnmi.Await(t, dut, intf.OperStatus().State(), linkTimeout, oc.Interface_OperStatus_UP)
+
+	tr := gnmi.Get(t, dut, intf.Transceiver().State())
+	if tr == "" {
+		t.Fatalf("No transceiver reported for %s", dp.Name())
+	}
+	t.Logf("Port %s uses transceiver %s", dp.Name(), tr)
+	// Leave the transceiver enabled even if the test fails while disabled.
+	t.Cleanup(func() { gnmi.Replace(t, dut, gnmi.OC().Component(tr).Transceiver().Enabled().Config(), true) })
+	wantConfig := gnmi.Get(t, dut, intf.Config())
+	wantChannels := channelIndexes(t, dut, tr)
+
+	trWatch := watchCycle(t, dut, gnmi.OC().Component(tr).Transceiver().Enabled().State(), true)
+	operWatch := watchCycle(t, dut, intf.OperStatus().State(), oc.Interface_OperStatus_UP)
+
+	t.Run("Disable", func(t *testing.T) {
+		setTransceiverEnabled(t, dut, tr, false)
+		gnmi.Watch(t, dut, intf.OperStatus().State(), linkTimeout, func(v *ygnmi.Value[oc.E_Interface_OperStatus]) bool {
+			status, ok := v.Val()
+			return !ok || status != oc.Interface_OperStatus_UP
+		}).Await(t)
+		if present := gnmi.Get(t, dut, gnmi.OC().Component(tr).Transceiver().Present().State()); present != oc.Transceiver_Present_PRESENT {
+			t.Errorf("Disabled transceiver %s present: got %v, want %v", tr, present, oc.Transceiver_Present_PRESENT)
+		}
+	})
+
+	t.Run("Enable", func(t *testing.T) {
+		setTransceiverEnabled(t, dut, tr, true)
+		gnmi.Await(t, dut, intf.OperStatus().State(), linkTimeout, oc.Interface_OperStatus_UP)
+	})
+
+	t.Run("ChangeNotifications", func(t *testing.T) {
+		if _, ok := trWatch.Await(t); !ok {
+			t.Errorf("Did not receive transceiver %s enabled false then true updates", tr)
+		}
+		if _, ok := operWatch.Await(t); !ok {
+			t.Errorf("Did not receive interface %s oper-status down then up updates", dp.Name())
+		}
+	})
+
+	t.Run("NoStaleState", func(t *testing.T) {
+		if diff := cmp.Diff(wantConfig, gnmi.Get(t, dut, intf.Config())); diff != "" {
+			t.Errorf("Interface %s config changed after transceiver disable/enable (-want +got):\n%s", dp.Name(), diff)
+		}
+		if diff := cmp.Diff(wantChannels, channelIndexes(t, dut, tr)); diff != "" {
+			t.Errorf("Transceiver %s physical channels changed after disable/enable (-want +got):\n%s", tr, diff)
+		}
+		sub := intf.Subinterface(0)
+		if got := gnmi.Get(t, dut, sub.Ipv4().Address(dutPort1.IPv4).PrefixLength().State()); got != dutPort1.IPv4Len {
+			t.Errorf("%s IPv4 %s prefix-length: got %d, want %d", dp.Name(), dutPort1.IPv4, got, dutPort1.IPv4Len)
+		}
+		if got := gnmi.Get(t, dut, sub.Ipv6().Address(dutPort1.IPv6).PrefixLength().State()); got != dutPort1.IPv6Len {
+			t.Errorf("%s IPv6 %s prefix-length: got %d, want %d", dp.Name(), dutPort1.IPv6, got, dutPort1.IPv6Len)
+		}
+		if got := gnmi.Get(t, dut, intf.Transceiver().State()); got != tr {
+			t.Errorf("%s transceiver: got %q, want %q", dp.Name(), got, tr)
+		}
+	})
+}
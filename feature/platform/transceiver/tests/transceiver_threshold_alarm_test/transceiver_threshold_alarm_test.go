@@ -0,0 +1,295 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transceiver_threshold_alarm_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	linkTimeout  = 5 * time.Minute
+	raiseTimeout = 2 * time.Minute
+	clearTimeout = 2 * time.Minute
+)
+
+var (
+	dut1Port1 = attrs.Attributes{Desc: "dut1Port1", IPv4: "192.0.2.1", IPv4Len: 30}
+	dut2Port1 = attrs.Attributes{Desc: "dut2Port1", IPv4: "192.0.2.2", IPv4Len: 30}
+)
+
+// severityRank orders threshold severities from least to most severe.
+var severityRank = map[oc.E_AlarmTypes_OPENCONFIG_ALARM_SEVERITY]int{
+	oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_WARNING:  1,
+	oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MINOR:    2,
+	oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_MAJOR:    3,
+	oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_CRITICAL: 4,
+}
+
+// thresholdLeaves are the lower and upper bounds of each quantity with
+// transceiver thresholds.
+var thresholdLeaves = []struct {
+	name         string
+	lower, upper func(*oc.Component_Transceiver_Threshold) *float64
+}{
+	{"input-power", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.InputPowerLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.InputPowerUpper }},
+	{"output-power", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.OutputPowerLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.OutputPowerUpper }},
+	{"laser-bias-current", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.LaserBiasCurrentLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.LaserBiasCurrentUpper }},
+	{"laser-temperature", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.LaserTemperatureLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.LaserTemperatureUpper }},
+	{"module-temperature", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.ModuleTemperatureLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.ModuleTemperatureUpper }},
+	{"supply-voltage", func(th *oc.Component_Transceiver_Threshold) *float64 { return th.SupplyVoltageLower }, func(th *oc.Component_Transceiver_Threshold) *float64 { return th.SupplyVoltageUpper }},
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Verify the factory thresholds of the transceiver of DUT1 port-1: every
+//     lower bound is below its upper bound, and a more severe threshold is
+//     never inside a less severe one.  Verify the input power of every
+//     physical channel is within the input power thresholds while the link
+//     is up.
+//  2. Admin-disable DUT2 port-1, the far end of the link.  Verify the input
+//     power of the transceiver drops below the input-power-lower threshold,
+//     and that an alarm for the transceiver or DUT1 port-1 is raised with
+//     the severity of the most severe threshold crossed, as an ON_CHANGE
+//     notification.
+//  3. Admin-enable DUT2 port-1.  Verify the input power returns within the
+//     input power thresholds and the alarm is cleared, as an ON_CHANGE
+//     notification.
+//
+// Topology:
+//
+//	dut1:port1 <--> port1:dut2
+//
+// Test notes:
+//   - Transceiver thresholds are read only in OpenConfig, so the factory
+//     thresholds are verified instead of configured ones.
+//   - An alarm is cleared when it is deleted from /system/alarms.
+
+func configurePort(t *testing.T, dut *ondatra.DUTDevice, a attrs.Attributes) *ondatra.Port {
+	t.Helper()
+	p := dut.Port(t, "port1")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+	return p
+}
+
+func onChange(dut *ondatra.DUTDevice) *gnmi.Opts {
+	return dut.GNMIOpts().WithYGNMIOpts(ygnmi.WithSubscriptionMode(gpb.SubscriptionMode_ON_CHANGE))
+}
+
+// verifyThresholds checks the thresholds of transceiver tr are consistent
+// and returns them.
+func verifyThresholds(t *testing.T, dut *ondatra.DUTDevice, tr string) []*oc.Component_Transceiver_Threshold {
+	t.Helper()
+	ths := gnmi.GetAll(t, dut, gnmi.OC().Component(tr).Transceiver().ThresholdAny().State())
+	for _, th := range ths {
+		if _, ok := severityRank[th.GetSeverity()]; !ok {
+			t.Errorf("Transceiver %s threshold severity: got %v, want CRITICAL, MAJOR, MINOR or WARNING", tr, th.GetSeverity())
+		}
+		for _, l := range thresholdLeaves {
+			lower, upper := l.lower(th), l.upper(th)
+			if lower == nil || upper == nil {
+				continue
+			}
+			t.Logf("Transceiver %s %v %s thresholds: lower %v, upper %v", tr, th.GetSeverity(), l.name, *lower, *upper)
+			if *lower >= *upper {
+				t.Errorf("Transceiver %s %v %s thresholds: got lower %v >= upper %v, want lower < upper", tr, th.GetSeverity(), l.name, *lower, *upper)
+			}
+		}
+	}
+	for _, more := range ths {
+		for _, less := range ths {
+			if severityRank[more.GetSeverity()] <= severityRank[less.GetSeverity()] {
+				continue
+			}
+			for _, l := range thresholdLeaves {
+				if ml, ll := l.lower(more), l.lower(less); ml != nil && ll != nil && *ml > *ll {
+					t.Errorf("Transceiver %s %s-lower: got %v %v > %v %v, want more severe threshold <= less severe", tr, l.name, more.GetSeverity(), *ml, less.GetSeverity(), *ll)
+				}
+				if mu, lu := l.upper(more), l.upper(less); mu != nil && lu != nil && *mu < *lu {
+					t.Errorf("Transceiver %s %s-upper: got %v %v < %v %v, want more severe threshold >= less severe", tr, l.name, more.GetSeverity(), *mu, less.GetSeverity(), *lu)
+				}
+			}
+		}
+	}
+	return ths
+}
+
+// crossedSeverity returns the most severe threshold whose input-power-lower
+// is above power, or UNSET if power is above all of them.
+func crossedSeverity(ths []*oc.Component_Transceiver_Threshold, power float64) oc.E_AlarmTypes_OPENCONFIG_ALARM_SEVERITY {
+	sev := oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET
+	for _, th := range ths {
+		if th.InputPowerLower != nil && power < *th.InputPowerLower && severityRank[th.GetSeverity()] > severityRank[sev] {
+			sev = th.GetSeverity()
+		}
+	}
+	return sev
+}
+
+// inputPowers returns the instant input power of each physical channel of
+// transceiver tr.
+func inputPowers(t *testing.T, dut *ondatra.DUTDevice, tr string) map[uint16]float64 {
+	t.Helper()
+	powers := make(map[uint16]float64)
+	for _, ch := range gnmi.GetAll(t, dut, gnmi.OC().Component(tr).Transceiver().ChannelAny().State()) {
+		powers[ch.GetIndex()] = ch.GetInputPower().GetInstant()
+	}
+	return powers
+}
+
+func alarmIDs(t *testing.T, dut *ondatra.DUTDevice) map[string]bool {
+	t.Helper()
+	ids := make(map[string]bool)
+	for _, a := range gnmi.GetAll(t, dut, gnmi.OC().System().AlarmAny().State()) {
+		ids[a.GetId()] = true
+	}
+	return ids
+}
+
+func TestThresholdCrossingAlarm(t *testing.T) {
+	dut1 := ondatra.DUT(t, "dut1")
+	dut2 := ondatra.DUT(t, "dut2")
+	p1 := configurePort(t, dut1, dut1Port1)
+	p2 := configurePort(t, dut2, dut2Port1)
+	gnmi.Await(t, dut1, gnmi.OC().Interface(p1.Name()).OperStatus().State(), linkTimeout, oc.Interface_OperStatus_UP)
+
+	tr := gnmi.Get(t, dut1, gnmi.OC().Interface(p1.Name()).Transceiver().State())
+	if tr == "" {
+		t.Fatalf("No transceiver reported for %s", p1.Name())
+	}
+	t.Logf("Port %s uses transceiver %s", p1.Name(), tr)
+	resources := map[string]bool{tr: true, p1.Name(): true}
+	isPort := func(r string) bool {
+		return resources[r] || strings.Contains(r, p1.Name())
+	}
+
+	var ths []*oc.Component_Transceiver_Threshold
+	t.Run("Factory thresholds", func(t *testing.T) {
+		if deviations.TransceiverThresholdsUnsupported(dut1) {
+			fptest.Waive(t, "transceiver_thresholds_unsupported", "not checking thresholds of transceiver %s", tr)
+			return
+		}
+		ths = verifyThresholds(t, dut1, tr)
+		for ch, power := range inputPowers(t, dut1, tr) {
+			t.Logf("Transceiver %s channel %d input power: %v dBm", tr, ch, power)
+			if sev := crossedSeverity(ths, power); sev != oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET {
+				t.Errorf("Transceiver %s channel %d input power with link up: got %v dBm, below %v input-power-lower threshold", tr, ch, power, sev)
+			}
+		}
+	})
+	if !deviations.TransceiverThresholdsUnsupported(dut1) && len(ths) == 0 {
+		t.Fatalf("Transceiver %s has no thresholds", tr)
+	}
+
+	existing := alarmIDs(t, dut1)
+	for id := range existing {
+		a := gnmi.Get(t, dut1, gnmi.OC().System().Alarm(id).State())
+		if isPort(a.GetResource()) {
+			t.Fatalf("Alarm %q for %s is already raised before the threshold crossing: %s", id, a.GetResource(), a.GetText())
+		}
+	}
+
+	farEnd := gnmi.OC().Interface(p2.Name()).Enabled().Config()
+	gnmi.Replace(t, dut2, farEnd, false)
+	restored := false
+	defer func() {
+		if !restored {
+			gnmi.Replace(t, dut2, farEnd, true)
+		}
+	}()
+
+	var alarm *oc.System_Alarm
+	t.Run("Crossing", func(t *testing.T) {
+		wantSev := oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET
+		if len(ths) > 0 {
+			var power float64
+			_, ok := gnmi.WatchAll(t, dut1, gnmi.OC().Component(tr).Transceiver().ChannelAny().InputPower().Instant().State(), linkTimeout, func(v *ygnmi.Value[float64]) bool {
+				p, present := v.Val()
+				if !present || crossedSeverity(ths, p) == oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET {
+					return false
+				}
+				power = p
+				return true
+			}).Await(t)
+			if !ok {
+				t.Fatalf("Transceiver %s input power did not drop below an input-power-lower threshold within %v of disabling the far end", tr, linkTimeout)
+			}
+			wantSev = crossedSeverity(ths, power)
+			t.Logf("Transceiver %s input power %v dBm crossed the %v input-power-lower threshold", tr, power, wantSev)
+		}
+
+		_, ok := gnmi.WatchAll(t, onChange(dut1), gnmi.OC().System().AlarmAny().State(), raiseTimeout, func(v *ygnmi.Value[*oc.System_Alarm]) bool {
+			a, present := v.Val()
+			if !present || existing[a.GetId()] || !isPort(a.GetResource()) {
+				return false
+			}
+			if wantSev != oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET && a.GetSeverity() != wantSev {
+				t.Logf("Alarm %q for %s has severity %v, waiting for %v: %s", a.GetId(), a.GetResource(), a.GetSeverity(), wantSev, a.GetText())
+				return false
+			}
+			alarm = a
+			return true
+		}).Await(t)
+		if !ok {
+			t.Fatalf("No alarm with severity %v for %v was raised within %v", wantSev, resources, raiseTimeout)
+		}
+		t.Logf("Alarm raised: id %q, resource %q, severity %v, text %q", alarm.GetId(), alarm.GetResource(), alarm.GetSeverity(), alarm.GetText())
+		if alarm.GetTimeCreated() == 0 {
+			t.Errorf("Alarm %q time-created is not set", alarm.GetId())
+		}
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		gnmi.Replace(t, dut2, farEnd, true)
+		restored = true
+		gnmi.Await(t, dut1, gnmi.OC().Interface(p1.Name()).OperStatus().State(), linkTimeout, oc.Interface_OperStatus_UP)
+		if alarm != nil {
+			_, ok := gnmi.Watch(t, onChange(dut1), gnmi.OC().System().Alarm(alarm.GetId()).State(), clearTimeout, func(v *ygnmi.Value[*oc.System_Alarm]) bool {
+				return !v.IsPresent()
+			}).Await(t)
+			if !ok {
+				t.Errorf("Alarm %q was not cleared within %v of enabling the far end", alarm.GetId(), clearTimeout)
+			}
+		}
+		if len(ths) == 0 {
+			return
+		}
+		for ch, power := range inputPowers(t, dut1, tr) {
+			if sev := crossedSeverity(ths, power); sev != oc.AlarmTypes_OPENCONFIG_ALARM_SEVERITY_UNSET {
+				t.Errorf("Transceiver %s channel %d input power after recovery: got %v dBm, below %v input-power-lower threshold", tr, ch, power, sev)
+			}
+		}
+	})
+}
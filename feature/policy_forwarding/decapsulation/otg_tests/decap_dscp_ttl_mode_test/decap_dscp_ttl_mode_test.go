@@ -0,0 +1,378 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decap_dscp_ttl_mode_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	policyName = "decap-mode-policy"
+
+	// decapAddr is the outer destination of the GRE packets decapsulated by
+	// the DUT.
+	decapAddr    = "203.0.113.1"
+	innerSrc     = "198.51.100.1"
+	innerDst     = "198.51.100.100"
+	innerPrefix  = "198.51.100.0/24"
+	innerSrcV6   = "2001:db8:2::1"
+	innerDstV6   = "2001:db8:2::100"
+	innerPrefix6 = "2001:db8:2::/64"
+
+	// The outer and inner headers have distinct DSCP and TTL, so that the
+	// captured packets show which header the DUT kept.
+	outerDSCP = 46
+	innerDSCP = 10
+	outerTTL  = 32
+	innerTTL  = 64
+
+	// catchAllSeq is the sequence-id of the rule forwarding all other traffic
+	// in the default network instance.
+	catchAllSeq = 100
+
+	pps         = 1000
+	flowPackets = 5000
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv6: "2001:db8::1", IPv4Len: 30, IPv6Len: 126}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv6: "2001:db8::2", IPv4Len: 30, IPv6Len: 126}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv6: "2001:db8::5", IPv4Len: 30, IPv6Len: 126}
+	atePort2 = attrs.Attributes{Name: "atePort2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv6: "2001:db8::6", IPv4Len: 30, IPv6Len: 126}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases, each with IPv4 and IPv6 packets in GRE over IPv4:
+//  1. TTL pipe, DSCP pipe: the decapsulated packets keep the inner DSCP, and
+//     the inner TTL decremented by one.
+//  2. TTL uniform, DSCP uniform: the decapsulated packets get the outer DSCP,
+//     and the outer TTL decremented by one.
+//  3. TTL pipe, DSCP uniform.
+//  4. TTL uniform, DSCP pipe.
+//
+// Each case verifies there is no loss and that every packet captured on ATE
+// port-2 is decapsulated, with the DSCP and TTL of its mode.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - The GRE packets are decapsulated by a policy-forwarding rule with the
+//     decapsulate-gre action on DUT port-1.
+//   - The DSCP and TTL modes of decapsulation are not modelled in
+//     OpenConfig, so they are configured through CLI on devices with the
+//     tunnel_decap_mode_oc_unsupported deviation.
+
+// mode is the DSCP or TTL mode of decapsulation.
+type mode string
+
+const (
+	// pipe keeps the field of the inner header.
+	pipe mode = "pipe"
+	// uniform copies the field of the outer header to the inner header.
+	uniform mode = "uniform"
+)
+
+// decapCLI holds the vendor CLI to configure the decapsulation modes.
+type decapCLI struct {
+	// mode sets the modes, with a %s verb for the TTL mode and one for the
+	// DSCP mode.
+	mode string
+	// remove restores the default modes.
+	remove string
+}
+
+// decapCLIs returns the decapsulation mode CLI for dut.
+func decapCLIs(t *testing.T, dut *ondatra.DUTDevice) decapCLI {
+	t.Helper()
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		return decapCLI{
+			mode: `
+ip tunnel termination model ttl %s dscp %s
+`,
+			remove: `
+no ip tunnel termination model
+`,
+		}
+	default:
+		t.Fatalf("Decapsulation mode CLI is not defined for vendor %s", dut.Vendor())
+	}
+	return decapCLI{}
+}
+
+// configureDUT configures the ports and the static routes to the inner
+// destinations.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+	}{
+		{"port1", dutPort1},
+		{"port2", dutPort2},
+	} {
+		dp := dut.Port(t, p.port)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+
+	b := &gnmi.SetBatch{}
+	for _, r := range []struct {
+		prefix, nh string
+	}{
+		{innerPrefix, atePort2.IPv4},
+		{innerPrefix6, atePort2.IPv6},
+	} {
+		if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+			NetworkInstance: deviations.DefaultNetworkInstance(dut),
+			Prefix:          r.prefix,
+			NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+				"0": oc.UnionString(r.nh),
+			},
+		}, dut); err != nil {
+			t.Fatalf("Failed to configure static route to %s: %v", r.prefix, err)
+		}
+	}
+	b.Set(t, dut)
+}
+
+// configurePolicy applies a policy decapsulating GRE to decapAddr to DUT
+// port-1.
+func configurePolicy(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	pf := (&oc.NetworkInstance{Name: ygot.String(defaultNI)}).GetOrCreatePolicyForwarding()
+	p := pf.GetOrCreatePolicy(policyName)
+	p.SetType(oc.Policy_Type_VRF_SELECTION_POLICY)
+
+	rule := p.GetOrCreateRule(1)
+	ipv4 := rule.GetOrCreateIpv4()
+	ipv4.Protocol = oc.PacketMatchTypes_IP_PROTOCOL_IP_GRE
+	ipv4.DestinationAddress = ygot.String(decapAddr + "/32")
+	a := rule.GetOrCreateAction()
+	a.DecapsulateGre = ygot.Bool(true)
+	a.NetworkInstance = ygot.String(defaultNI)
+
+	catchAll := p.GetOrCreateRule(catchAllSeq)
+	if deviations.PfRequireMatchDefaultRule(dut) {
+		catchAll.GetOrCreateL2().SetEthertype(oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV4)
+	}
+	catchAll.GetOrCreateAction().NetworkInstance = ygot.String(defaultNI)
+
+	p1 := dut.Port(t, "port1")
+	interfaceID := p1.Name()
+	if deviations.InterfaceRefInterfaceIDFormat(dut) {
+		interfaceID = interfaceID + ".0"
+	}
+	intf := pf.GetOrCreateInterface(interfaceID)
+	intf.ApplyVrfSelectionPolicy = ygot.String(policyName)
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(p1.Name())
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(defaultNI).PolicyForwarding().Config(), pf)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port2"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+
+	for _, v6 := range []bool{false, true} {
+		name, rx := "gre-ipv4", atePort2.Name+".IPv4"
+		if v6 {
+			name, rx = "gre-ipv6", atePort2.Name+".IPv6"
+		}
+		flow := top.Flows().Add().SetName(name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{rx})
+		flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+		outer := flow.Packet().Add().Ipv4()
+		outer.Src().SetValue(atePort1.IPv4)
+		outer.Dst().SetValue(decapAddr)
+		outer.Priority().Dscp().Phb().SetValue(outerDSCP)
+		outer.TimeToLive().SetValue(outerTTL)
+		flow.Packet().Add().Gre()
+		if v6 {
+			inner := flow.Packet().Add().Ipv6()
+			inner.Src().SetValue(innerSrcV6)
+			inner.Dst().SetValue(innerDstV6)
+			inner.TrafficClass().SetValue(innerDSCP << 2)
+			inner.HopLimit().SetValue(innerTTL)
+		} else {
+			inner := flow.Packet().Add().Ipv4()
+			inner.Src().SetValue(innerSrc)
+			inner.Dst().SetValue(innerDst)
+			inner.Priority().Dscp().Phb().SetValue(innerDSCP)
+			inner.TimeToLive().SetValue(innerTTL)
+		}
+		flow.Size().SetFixed(512)
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+	return top
+}
+
+// header is the DSCP and TTL, or hop limit, of a captured packet.
+type header struct {
+	dscp, ttl uint8
+}
+
+// captureHeaders returns the number of packets from the inner sources
+// captured on ATE port-2 for each header, by IP version, and the number of
+// packets still encapsulated in GRE.
+func captureHeaders(t *testing.T, ate *ondatra.ATEDevice) (v4, v6 map[header]int, encapped int) {
+	t.Helper()
+	b := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port2"))
+	r, err := pcapgo.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Failed to read port2 capture: %v", err)
+	}
+	v4, v6 = make(map[header]int), make(map[header]int)
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return v4, v6, encapped
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port2 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		if pkt.Layer(layers.LayerTypeGRE) != nil {
+			encapped++
+			continue
+		}
+		if ip, ok := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4); ok && ip.SrcIP.String() == innerSrc {
+			v4[header{ip.TOS >> 2, ip.TTL}]++
+		}
+		if ip, ok := pkt.Layer(layers.LayerTypeIPv6).(*layers.IPv6); ok && ip.SrcIP.String() == innerSrcV6 {
+			v6[header{ip.TrafficClass >> 2, ip.HopLimit}]++
+		}
+	}
+}
+
+// verifyHeaders checks that all packets of headers have header want.
+func verifyHeaders(t *testing.T, family string, headers map[header]int, want header) {
+	t.Helper()
+	if len(headers) == 0 {
+		t.Errorf("No decapsulated %s packets captured on ATE port-2", family)
+		return
+	}
+	for h, n := range headers {
+		if h != want {
+			t.Errorf("Captured %d decapsulated %s packets with DSCP %d and TTL %d, want DSCP %d and TTL %d", n, family, h.dscp, h.ttl, want.dscp, want.ttl)
+		}
+	}
+}
+
+func TestDecapDSCPTTLMode(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if !deviations.TunnelDecapModeOCUnsupported(dut) {
+		t.Skip("Decapsulation DSCP and TTL modes are not modelled in OpenConfig; set the tunnel_decap_mode_oc_unsupported deviation to configure them through CLI")
+	}
+	cli := decapCLIs(t, dut)
+	configureDUT(t, dut)
+	configurePolicy(t, dut)
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	cases := []struct {
+		ttl, dscp mode
+	}{
+		{pipe, pipe},
+		{uniform, uniform},
+		{pipe, uniform},
+		{uniform, pipe},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("TTL %s DSCP %s", tc.ttl, tc.dscp), func(t *testing.T) {
+			helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.mode, tc.ttl, tc.dscp))
+			defer helpers.GnmiCLIConfig(t, dut, cli.remove)
+
+			want := header{dscp: innerDSCP, ttl: innerTTL - 1}
+			if tc.dscp == uniform {
+				want.dscp = outerDSCP
+			}
+			if tc.ttl == uniform {
+				want.ttl = outerTTL - 1
+			}
+
+			cs := gosnappi.NewControlState()
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+			ate.OTG().SetControlState(t, cs)
+			ate.OTG().StartTraffic(t)
+			time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+			ate.OTG().StopTraffic(t)
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+			ate.OTG().SetControlState(t, cs)
+			otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+			for _, flow := range top.Flows().Items() {
+				tx, rx := otgutils.GetFlowStats(t, ate.OTG(), flow.Name(), 10*time.Second)
+				if tx == 0 {
+					t.Fatalf("Flow %s sent no packets", flow.Name())
+				}
+				if rx != tx {
+					t.Errorf("Flow %s packets received: got %d, want %d", flow.Name(), rx, tx)
+				}
+			}
+
+			v4, v6, encapped := captureHeaders(t, ate)
+			if encapped != 0 {
+				t.Errorf("Captured %d packets still encapsulated in GRE, want 0", encapped)
+			}
+			verifyHeaders(t, "IPv4", v4, want)
+			verifyHeaders(t, "IPv6", v6, want)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dscp_decap_vrf_selection_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	policyName = "decap-dscp-policy"
+	vrfA       = "VRF_A"
+	vrfB       = "VRF_B"
+	decapVRF   = "DECAP_VRF"
+
+	dscpA         = 10
+	dscpB         = 20
+	dscpUnmatched = 30
+
+	// decapAddr is the outer destination decapsulated by the DUT, programmed
+	// in decapVRF.  missAddr is an outer destination with no decap entry.
+	decapAddr   = "203.0.113.1"
+	missAddr    = "203.0.113.2"
+	outerPrefix = "203.0.113.0/24"
+	innerSrc    = "198.51.100.1"
+	innerDst    = "198.51.100.100"
+	innerPrefix = "198.51.100.0/24"
+
+	nhIndex  = 1
+	nhgIndex = 1
+
+	// catchAllSeq is the sequence-id of the rule sending all other traffic to
+	// the default network instance.
+	catchAllSeq = 100
+
+	pps         = 1000
+	flowPackets = 10000
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "atePort1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "atePort2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30}
+	atePort3 = attrs.Attributes{Name: "atePort3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30}
+	dutPort4 = attrs.Attributes{Desc: "dutPort4", IPv4: "192.0.2.13", IPv4Len: 30}
+	atePort4 = attrs.Attributes{Name: "atePort4", MAC: "02:00:04:01:01:01", IPv4: "192.0.2.14", IPv4Len: 30}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. IPinIP with DSCP A to the decap address is decapsulated and the inner
+//     packet is forwarded in VRF_A out of port-2.
+//  2. IPinIP with DSCP B is decapsulated and forwarded in VRF_B out of port-3.
+//  3. GRE with DSCP A is decapsulated and forwarded in VRF_A out of port-2.
+//  4. GRE with DSCP B is decapsulated and forwarded in VRF_B out of port-3.
+//  5. IPinIP with an unmatched DSCP is forwarded still encapsulated in the
+//     default network instance out of port-4.
+//  6. IPinIP with DSCP A to an address with no decap entry falls back to the
+//     default network instance and is forwarded out of port-4.
+//
+// Each case also verifies the matched-pkts counter of the policy rule and,
+// for IPinIP decapsulation, the packets-forwarded counter of the decap AFT
+// entry.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2  (VRF_A)
+//	                     dut:port3 <--> ate:port3  (VRF_B)
+//	                     dut:port4 <--> ate:port4  (default)
+//
+// Test notes:
+//   - VRF_A and VRF_B only have a route to the inner destination, and the
+//     default network instance only has a route to the outer destination, so
+//     the egress port shows whether the packet was decapsulated.
+//   - IPinIP decapsulation uses decap-network-instance with a decap entry
+//     programmed by gRIBI.  GRE decapsulation uses decapsulate-gre, as gRIBI
+//     only programs IPinIP decapsulation.
+
+// configureDUT configures the ports, the VRFs and their static routes.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	for _, vrf := range []string{vrfA, vrfB, decapVRF} {
+		ni := &oc.NetworkInstance{
+			Name: ygot.String(vrf),
+			Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+		}
+		gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrf).Config(), ni)
+	}
+
+	for _, p := range []struct {
+		port string
+		a    attrs.Attributes
+		ni   string
+	}{
+		{"port1", dutPort1, deviations.DefaultNetworkInstance(dut)},
+		{"port2", dutPort2, vrfA},
+		{"port3", dutPort3, vrfB},
+		{"port4", dutPort4, deviations.DefaultNetworkInstance(dut)},
+	} {
+		dp := dut.Port(t, p.port)
+		if p.ni != deviations.DefaultNetworkInstance(dut) || deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), p.ni, 0)
+		}
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+	}
+
+	b := &gnmi.SetBatch{}
+	for _, r := range []struct {
+		ni, prefix, nh string
+	}{
+		{vrfA, innerPrefix, atePort2.IPv4},
+		{vrfB, innerPrefix, atePort3.IPv4},
+		{deviations.DefaultNetworkInstance(dut), outerPrefix, atePort4.IPv4},
+	} {
+		if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+			NetworkInstance: r.ni,
+			Prefix:          r.prefix,
+			NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+				"0": oc.UnionString(r.nh),
+			},
+		}, dut); err != nil {
+			t.Fatalf("Failed to configure static route to %s in %s: %v", r.prefix, r.ni, err)
+		}
+	}
+	b.Set(t, dut)
+}
+
+// configurePolicy applies the DSCP based decapsulation policy to DUT port-1.
+func configurePolicy(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	pf := (&oc.NetworkInstance{Name: ygot.String(defaultNI)}).GetOrCreatePolicyForwarding()
+	p := pf.GetOrCreatePolicy(policyName)
+	p.SetType(oc.Policy_Type_VRF_SELECTION_POLICY)
+
+	for _, r := range []struct {
+		seq      uint32
+		protocol oc.NetworkInstance_PolicyForwarding_Policy_Rule_Ipv4_Protocol_Union
+		dscp     uint8
+		vrf      string
+	}{
+		{1, oc.PacketMatchTypes_IP_PROTOCOL_IP_IN_IP, dscpA, vrfA},
+		{2, oc.PacketMatchTypes_IP_PROTOCOL_IP_IN_IP, dscpB, vrfB},
+		{3, oc.PacketMatchTypes_IP_PROTOCOL_IP_GRE, dscpA, vrfA},
+		{4, oc.PacketMatchTypes_IP_PROTOCOL_IP_GRE, dscpB, vrfB},
+	} {
+		rule := p.GetOrCreateRule(r.seq)
+		ipv4 := rule.GetOrCreateIpv4()
+		ipv4.Protocol = r.protocol
+		ipv4.DscpSet = []uint8{r.dscp}
+		a := rule.GetOrCreateAction()
+		if r.protocol == oc.PacketMatchTypes_IP_PROTOCOL_IP_GRE {
+			a.DecapsulateGre = ygot.Bool(true)
+			a.NetworkInstance = ygot.String(r.vrf)
+			continue
+		}
+		a.DecapNetworkInstance = ygot.String(decapVRF)
+		a.PostDecapNetworkInstance = ygot.String(r.vrf)
+		a.DecapFallbackNetworkInstance = ygot.String(defaultNI)
+	}
+	if deviations.PfRequireMatchDefaultRule(dut) {
+		rule := p.GetOrCreateRule(catchAllSeq)
+		rule.GetOrCreateL2().SetEthertype(oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV4)
+		rule.GetOrCreateAction().NetworkInstance = ygot.String(defaultNI)
+	} else {
+		p.GetOrCreateRule(catchAllSeq).GetOrCreateAction().NetworkInstance = ygot.String(defaultNI)
+	}
+
+	p1 := dut.Port(t, "port1")
+	interfaceID := p1.Name()
+	if deviations.InterfaceRefInterfaceIDFormat(dut) {
+		interfaceID = interfaceID + ".0"
+	}
+	intf := pf.GetOrCreateInterface(interfaceID)
+	intf.ApplyVrfSelectionPolicy = ygot.String(policyName)
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(p1.Name())
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(defaultNI).PolicyForwarding().Config(), pf)
+}
+
+// programDecapEntry programs decapAddr in decapVRF to decapsulate IPinIP.
+func programDecapEntry(t *testing.T, dut *ondatra.DUTDevice, c *gribi.Client) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	c.AddNH(t, nhIndex, "Decap", defaultNI, fluent.InstalledInFIB)
+	c.AddNHG(t, nhgIndex, map[uint64]uint64{nhIndex: 1}, defaultNI, fluent.InstalledInFIB)
+	c.AddIPv4(t, decapAddr+"/32", nhgIndex, decapVRF, defaultNI, fluent.InstalledInFIB)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	atePort4.AddToOTG(top, ate.Port(t, "port4"), &dutPort4)
+	return top
+}
+
+// encapFlow returns a flow from ATE port-1 to dst carrying an IPv4 packet in
+// an IPv4 (IPinIP) or GRE header with the given outer destination and DSCP.
+func encapFlow(name string, gre bool, outerDst string, dscp uint32, dst attrs.Attributes) gosnappi.Flow {
+	flow := gosnappi.NewFlow().SetName(name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{dst.Name + ".IPv4"})
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	outer := flow.Packet().Add().Ipv4()
+	outer.Src().SetValue(atePort1.IPv4)
+	outer.Dst().SetValue(outerDst)
+	outer.Priority().Dscp().Phb().SetValue(dscp)
+	if gre {
+		flow.Packet().Add().Gre()
+	}
+	inner := flow.Packet().Add().Ipv4()
+	inner.Src().SetValue(innerSrc)
+	inner.Dst().SetValue(innerDst)
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(pps)
+	flow.Duration().FixedPackets().SetPackets(flowPackets)
+	return flow
+}
+
+// counter returns the value of q, or 0 if it is not present.
+func counter(t *testing.T, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[uint64]) uint64 {
+	t.Helper()
+	v, _ := gnmi.Lookup(t, dut, q).Val()
+	return v
+}
+
+// aftPacketsForwarded returns the packets-forwarded counter of the decap AFT
+// entry, or 0 if it is not present.
+func aftPacketsForwarded(t *testing.T, dut *ondatra.DUTDevice) uint64 {
+	t.Helper()
+	e, _ := gnmi.Lookup(t, dut, gnmi.OC().NetworkInstance(decapVRF).Afts().Ipv4Entry(decapAddr+"/32").State()).Val()
+	return e.GetCounters().GetPacketsForwarded()
+}
+
+func TestDSCPDecapVRFSelection(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+
+	c := &gribi.Client{DUT: dut, FIBACK: true, Persistence: true}
+	defer c.Close(t)
+	if err := c.Start(t); err != nil {
+		t.Fatalf("gRIBI connection could not be established: %v", err)
+	}
+	c.BecomeLeader(t)
+	defer c.FlushAll(t)
+	programDecapEntry(t, dut, c)
+	configurePolicy(t, dut)
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	rules := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).PolicyForwarding().Policy(policyName)
+
+	cases := []struct {
+		desc     string
+		gre      bool
+		outerDst string
+		dscp     uint32
+		dst      attrs.Attributes
+		seq      uint32
+		// decap is true if the packets are decapsulated using the decap
+		// AFT entry.
+		decap bool
+	}{{
+		desc:     "IPinIP DSCP A to VRF_A",
+		outerDst: decapAddr,
+		dscp:     dscpA,
+		dst:      atePort2,
+		seq:      1,
+		decap:    true,
+	}, {
+		desc:     "IPinIP DSCP B to VRF_B",
+		outerDst: decapAddr,
+		dscp:     dscpB,
+		dst:      atePort3,
+		seq:      2,
+		decap:    true,
+	}, {
+		desc:     "GRE DSCP A to VRF_A",
+		gre:      true,
+		outerDst: decapAddr,
+		dscp:     dscpA,
+		dst:      atePort2,
+		seq:      3,
+	}, {
+		desc:     "GRE DSCP B to VRF_B",
+		gre:      true,
+		outerDst: decapAddr,
+		dscp:     dscpB,
+		dst:      atePort3,
+		seq:      4,
+	}, {
+		desc:     "IPinIP unmatched DSCP to default",
+		outerDst: decapAddr,
+		dscp:     dscpUnmatched,
+		dst:      atePort4,
+		seq:      catchAllSeq,
+	}, {
+		desc:     "IPinIP decap miss falls back to default",
+		outerDst: missAddr,
+		dscp:     dscpA,
+		dst:      atePort4,
+		seq:      1,
+	}}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flow := encapFlow("flow", tc.gre, tc.outerDst, tc.dscp, tc.dst)
+			top.Flows().Clear().Append(flow)
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+			ruleBefore := counter(t, dut, rules.Rule(tc.seq).MatchedPkts().State())
+			aftBefore := aftPacketsForwarded(t, dut)
+
+			ate.OTG().StartTraffic(t)
+			time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+			ate.OTG().StopTraffic(t)
+			otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+			tx, rx := otgutils.GetFlowStats(t, ate.OTG(), flow.Name(), 10*time.Second)
+			if tx == 0 {
+				t.Fatalf("Flow %s sent no packets", flow.Name())
+			}
+			if rx != tx {
+				t.Errorf("Packets received on %s: got %d, want %d", tc.dst.Name, rx, tx)
+			}
+
+			if got := counter(t, dut, rules.Rule(tc.seq).MatchedPkts().State()) - ruleBefore; got < tx {
+				t.Errorf("Policy %s rule %d matched-pkts increase: got %d, want >= %d", policyName, tc.seq, got, tx)
+			}
+			got := aftPacketsForwarded(t, dut) - aftBefore
+			switch {
+			case tc.decap && got < tx:
+				t.Errorf("%s decap entry %s packets-forwarded increase: got %d, want >= %d", decapVRF, decapAddr, got, tx)
+			case !tc.decap && got != 0:
+				t.Errorf("%s decap entry %s packets-forwarded increase: got %d, want 0", decapVRF, decapAddr, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipv6_flow_label_traffic_class_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	plenIPv6    = 126
+	dstPrefix   = "2001:db8:100::/64"
+	dstAddr     = "2001:db8:100::1"
+	aclName     = "ACL-IPV6-FLOW-LABEL-TC"
+	policyName  = "PBR-IPV6-FLOW-LABEL-TC"
+	flowPkts    = 10000
+	flowPPS     = 1000
+	hashLabels  = 1024
+	lossTol     = 0.01
+	hashTol     = 0.10
+	flowTimeout = time.Minute
+
+	// labelMatch and tcMatch are matched by the ACL and the PBR policy, and
+	// labelOther and tcOther are not.
+	labelMatch = 0xabcde
+	labelOther = 0x12345
+	// tcMatch is traffic class 184, DSCP 46 in its upper 6 bits.
+	tcMatch = 46 << 2
+	tcOther = 0
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv6: "2001:db8::192:0:2:1", IPv6Len: plenIPv6}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv6: "2001:db8::192:0:2:5", IPv6Len: plenIPv6}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv6: "2001:db8::192:0:2:9", IPv6Len: plenIPv6}
+	dutPort4 = attrs.Attributes{Desc: "dutPort4", IPv6: "2001:db8::192:0:2:d", IPv6Len: plenIPv6}
+
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv6: "2001:db8::192:0:2:2", IPv6Len: plenIPv6}
+	atePort2 = attrs.Attributes{Name: "port2", MAC: "02:00:02:01:01:01", IPv6: "2001:db8::192:0:2:6", IPv6Len: plenIPv6}
+	atePort3 = attrs.Attributes{Name: "port3", MAC: "02:00:03:01:01:01", IPv6: "2001:db8::192:0:2:a", IPv6Len: plenIPv6}
+	atePort4 = attrs.Attributes{Name: "port4", MAC: "02:00:04:01:01:01", IPv6: "2001:db8::192:0:2:e", IPv6Len: plenIPv6}
+
+	dutPorts = []*attrs.Attributes{&dutPort1, &dutPort2, &dutPort3, &dutPort4}
+	atePorts = []*attrs.Attributes{&atePort1, &atePort2, &atePort3, &atePort4}
+
+	// hashCLI enables hashing on the IPv6 flow label.  OpenConfig has no model
+	// for hash fields, so vendors without an entry are expected to hash on the
+	// flow label by default.
+	hashCLI = map[ondatra.Vendor]string{
+		ondatra.CISCO: "cef load-balancing fields ipv6 flow-label\n",
+	}
+)
+
+// flow is an IPv6 flow from ATE port-1 to dstAddr.
+type flow struct {
+	name string
+	// labels are the flow labels of the flow, one per packet in turn.
+	labels []uint32
+	tc     uint32
+}
+
+var (
+	fixedLabel   = flow{name: "fixed-label", labels: []uint32{labelOther}, tc: tcOther}
+	varyingLabel = flow{name: "varying-label", tc: tcOther}
+	matchLabel   = flow{name: "match-label", labels: []uint32{labelMatch}, tc: tcOther}
+	matchTC      = flow{name: "match-tc", labels: []uint32{labelOther}, tc: tcMatch}
+	noMatch      = flow{name: "no-match", labels: []uint32{labelOther}, tc: tcOther}
+)
+
+func init() {
+	for i := uint32(0); i < hashLabels; i++ {
+		varyingLabel.labels = append(varyingLabel.labels, i+1)
+	}
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Hashing: with an ECMP static route to ATE port-2 and port-3, enable
+//     hashing on the IPv6 flow label.
+//     a. Send a flow with a single flow label, and verify it egresses one
+//        port only.
+//     b. Send a flow varying only the flow label, and verify it is evenly
+//        distributed across both ports.
+//  2. ACL: apply an ingress IPv6 ACL to DUT port-1 that drops a flow label
+//     and a traffic class, and accepts the rest.
+//     a. Send a flow with the flow label, one with the traffic class and one
+//        with neither.  Verify only the latter is forwarded.
+//     b. Verify the matched-packets counter of each ACL entry accounts for
+//        the flow it matches.
+//  3. PBR: apply a forwarding policy to DUT port-1 that redirects a flow
+//     label and a traffic class to ATE port-4.
+//     a. Send the same flows as for the ACL.  Verify the flows with the flow
+//        label and the traffic class egress ATE port-4, and the other one
+//        egresses ATE port-2 or port-3.
+//     b. Verify the matched-pkts counter of each rule accounts for the flow
+//        it matches.
+//
+// Topology:
+//
+//	                      |---> ate:port2
+//	ate:port1 ---> dut ---|---> ate:port3
+//	                      |---> ate:port4
+//
+// Test notes:
+//   - The traffic class is matched by the DSCP in its upper 6 bits.
+//   - Flows are sent one at a time, and the ATE port each flow egresses is
+//     determined from the ATE port receive counters.
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for i, a := range dutPorts {
+		p := dut.Port(t, atePorts[i].Name)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+	b := &gnmi.SetBatch{}
+	if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+		NetworkInstance: deviations.DefaultNetworkInstance(dut),
+		Prefix:          dstPrefix,
+		NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+			"0": oc.UnionString(atePort2.IPv6),
+			"1": oc.UnionString(atePort3.IPv6),
+		},
+	}, dut); err != nil {
+		t.Fatalf("Failed to configure static route to %s: %v", dstPrefix, err)
+	}
+	b.Set(t, dut)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for i, a := range atePorts {
+		a.AddToOTG(top, ate.Port(t, a.Name), dutPorts[i])
+	}
+	for _, f := range []flow{fixedLabel, varyingLabel, matchLabel, matchTC, noMatch} {
+		of := top.Flows().Add().SetName(f.name)
+		of.Metrics().SetEnable(true)
+		of.TxRx().Device().
+			SetTxNames([]string{atePort1.Name + ".IPv6"}).
+			SetRxNames([]string{atePort2.Name + ".IPv6", atePort3.Name + ".IPv6", atePort4.Name + ".IPv6"})
+		of.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+		v6 := of.Packet().Add().Ipv6()
+		v6.Src().SetValue(atePort1.IPv6)
+		v6.Dst().SetValue(dstAddr)
+		v6.TrafficClass().SetValue(f.tc)
+		if len(f.labels) == 1 {
+			v6.FlowLabel().SetValue(f.labels[0])
+		} else {
+			v6.FlowLabel().SetValues(f.labels)
+		}
+		udp := of.Packet().Add().Udp()
+		udp.SrcPort().SetValue(49152)
+		udp.DstPort().SetValue(49153)
+		of.Rate().SetPps(flowPPS)
+		of.Duration().FixedPackets().SetPackets(flowPkts)
+	}
+	return top
+}
+
+// rxFrames returns the in-frames counter of ATE port-2, port-3 and port-4.
+func rxFrames(t *testing.T, ate *ondatra.ATEDevice) map[string]uint64 {
+	t.Helper()
+	rx := make(map[string]uint64)
+	for _, a := range atePorts[1:] {
+		rx[a.Name] = gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(ate.Port(t, a.Name).ID()).Counters().InFrames().State())
+	}
+	return rx
+}
+
+// send sends flow f alone, and returns the number of packets it sent and the
+// number of frames received by each egress ATE port meanwhile.
+func send(t *testing.T, ate *ondatra.ATEDevice, f flow) (uint64, map[string]uint64) {
+	t.Helper()
+	before := rxFrames(t, ate)
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().SetState(gosnappi.StateTrafficFlowTransmitState.START).SetFlowNames([]string{f.name})
+	ate.OTG().SetControlState(t, cs)
+	gnmi.Await(t, ate.OTG(), gnmi.OTG().Flow(f.name).Transmit().State(), flowTimeout, false)
+	// Give the last packets time to arrive.
+	time.Sleep(2 * time.Second)
+	after := rxFrames(t, ate)
+	rx := make(map[string]uint64)
+	for port, a := range after {
+		if b := before[port]; a >= b {
+			rx[port] = a - b
+		}
+	}
+	tx := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(f.name).Counters().OutPkts().State())
+	t.Logf("Flow %s sent %d packets, received by ATE ports: %v", f.name, tx, rx)
+	return tx, rx
+}
+
+// verifyEgress verifies flow f egresses one of ports only, if ports is not
+// empty, or is dropped.
+func verifyEgress(t *testing.T, ate *ondatra.ATEDevice, f flow, ports ...string) {
+	t.Helper()
+	tx, rx := send(t, ate, f)
+	if tx == 0 {
+		t.Fatalf("Flow %s sent no packets", f.name)
+	}
+	var got uint64
+	for _, p := range ports {
+		got += rx[p]
+	}
+	if len(ports) == 0 {
+		for _, n := range rx {
+			got += n
+		}
+		if float64(got) > float64(tx)*lossTol {
+			t.Errorf("Flow %s: got %d of %d packets forwarded, want it dropped", f.name, got, tx)
+		}
+		return
+	}
+	if float64(got) < float64(tx)*(1-lossTol) {
+		t.Errorf("Flow %s: got %d of %d packets on ATE ports %v, want all of them", f.name, got, tx, ports)
+	}
+}
+
+func counter(t *testing.T, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[uint64]) uint64 {
+	t.Helper()
+	v, _ := gnmi.Lookup(t, dut, q).Val()
+	return v
+}
+
+func testHashing(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice) {
+	if cli, ok := hashCLI[dut.Vendor()]; ok {
+		helpers.GnmiCLIConfig(t, dut, cli)
+	} else {
+		t.Logf("No hash configuration for vendor %v, relying on the default hash fields", dut.Vendor())
+	}
+
+	t.Run("FixedLabel", func(t *testing.T) {
+		tx, rx := send(t, ate, fixedLabel)
+		if n := max(rx[atePort2.Name], rx[atePort3.Name]); float64(n) < float64(tx)*(1-lossTol) {
+			t.Errorf("Flow %s: got %d packets on ATE port-2 and %d on port-3, want all %d on one of them", fixedLabel.name, rx[atePort2.Name], rx[atePort3.Name], tx)
+		}
+	})
+	t.Run("VaryingLabel", func(t *testing.T) {
+		tx, rx := send(t, ate, varyingLabel)
+		for _, p := range []string{atePort2.Name, atePort3.Name} {
+			share := float64(rx[p]) / float64(tx)
+			if share < 0.5-hashTol || share > 0.5+hashTol {
+				t.Errorf("Flow %s: got %.1f%% of packets on ATE %s, want %.0f%% +/- %.0f%%", varyingLabel.name, share*100, p, 50.0, hashTol*100)
+			}
+		}
+	})
+}
+
+func interfaceID(t *testing.T, dut *ondatra.DUTDevice) string {
+	id := dut.Port(t, "port1").Name()
+	if deviations.InterfaceRefInterfaceIDFormat(dut) {
+		id += ".0"
+	}
+	return id
+}
+
+func configureACL(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	acl := (&oc.Root{}).GetOrCreateAcl()
+	set := acl.GetOrCreateAclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV6)
+	e := set.GetOrCreateAclEntry(10)
+	e.GetOrCreateIpv6().SourceFlowLabel = ygot.Uint32(labelMatch)
+	e.GetOrCreateActions().ForwardingAction = oc.Acl_FORWARDING_ACTION_DROP
+	e = set.GetOrCreateAclEntry(20)
+	e.GetOrCreateIpv6().Dscp = ygot.Uint8(tcMatch >> 2)
+	e.GetOrCreateActions().ForwardingAction = oc.Acl_FORWARDING_ACTION_DROP
+	set.GetOrCreateAclEntry(30).GetOrCreateActions().ForwardingAction = oc.Acl_FORWARDING_ACTION_ACCEPT
+
+	p1 := dut.Port(t, "port1").Name()
+	intf := acl.GetOrCreateInterface(interfaceID(t, dut))
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(p1)
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	intf.GetOrCreateIngressAclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV6)
+	gnmi.Update(t, dut, gnmi.OC().Acl().Config(), acl)
+}
+
+func testACL(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice) {
+	configureACL(t, dut)
+	defer func() {
+		gnmi.Delete(t, dut, gnmi.OC().Acl().Interface(interfaceID(t, dut)).Config())
+		gnmi.Delete(t, dut, gnmi.OC().Acl().AclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV6).Config())
+	}()
+	entries := gnmi.OC().Acl().Interface(interfaceID(t, dut)).IngressAclSet(aclName, oc.Acl_ACL_TYPE_ACL_IPV6)
+
+	for _, tc := range []struct {
+		flow    flow
+		seq     uint32
+		forward bool
+	}{
+		{matchLabel, 10, false},
+		{matchTC, 20, false},
+		{noMatch, 30, true},
+	} {
+		t.Run(tc.flow.name, func(t *testing.T) {
+			before := counter(t, dut, entries.AclEntry(tc.seq).MatchedPackets().State())
+			if tc.forward {
+				verifyEgress(t, ate, tc.flow, atePort2.Name, atePort3.Name)
+			} else {
+				verifyEgress(t, ate, tc.flow)
+			}
+			tx := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(tc.flow.name).Counters().OutPkts().State())
+			if got := counter(t, dut, entries.AclEntry(tc.seq).MatchedPackets().State()) - before; got < tx {
+				t.Errorf("ACL entry %d matched-packets increased by %d, want at least %d", tc.seq, got, tx)
+			}
+		})
+	}
+}
+
+func configurePBR(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	ni := deviations.DefaultNetworkInstance(dut)
+	pf := (&oc.NetworkInstance{Name: ygot.String(ni)}).GetOrCreatePolicyForwarding()
+	p := pf.GetOrCreatePolicy(policyName)
+	p.SetType(oc.Policy_Type_PBR_POLICY)
+	r := p.GetOrCreateRule(10)
+	r.GetOrCreateIpv6().SourceFlowLabel = ygot.Uint32(labelMatch)
+	r.GetOrCreateAction().NextHop = ygot.String(atePort4.IPv6)
+	r = p.GetOrCreateRule(20)
+	r.GetOrCreateIpv6().Dscp = ygot.Uint8(tcMatch >> 2)
+	r.GetOrCreateAction().NextHop = ygot.String(atePort4.IPv6)
+	if deviations.PfRequireMatchDefaultRule(dut) {
+		r = p.GetOrCreateRule(30)
+		r.GetOrCreateL2().SetEthertype(oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV6)
+		r.GetOrCreateAction().NetworkInstance = ygot.String(ni)
+	}
+
+	intf := pf.GetOrCreateInterface(interfaceID(t, dut))
+	intf.ApplyForwardingPolicy = ygot.String(policyName)
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(dut.Port(t, "port1").Name())
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(ni).PolicyForwarding().Config(), pf)
+}
+
+func testPBR(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice) {
+	configurePBR(t, dut)
+	pf := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).PolicyForwarding()
+	defer gnmi.Delete(t, dut, pf.Config())
+
+	for _, tc := range []struct {
+		flow  flow
+		seq   uint32
+		ports []string
+	}{
+		{matchLabel, 10, []string{atePort4.Name}},
+		{matchTC, 20, []string{atePort4.Name}},
+		{noMatch, 0, []string{atePort2.Name, atePort3.Name}},
+	} {
+		t.Run(tc.flow.name, func(t *testing.T) {
+			var before uint64
+			if tc.seq != 0 {
+				before = counter(t, dut, pf.Policy(policyName).Rule(tc.seq).MatchedPkts().State())
+			}
+			verifyEgress(t, ate, tc.flow, tc.ports...)
+			if tc.seq == 0 {
+				return
+			}
+			tx := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(tc.flow.name).Counters().OutPkts().State())
+			if got := counter(t, dut, pf.Policy(policyName).Rule(tc.seq).MatchedPkts().State()) - before; got < tx {
+				t.Errorf("Rule %d matched-pkts increased by %d, want at least %d", tc.seq, got, tx)
+			}
+		})
+	}
+}
+
+func TestIPv6FlowLabelTrafficClass(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if deviations.ATEIPv6FlowLabelUnsupported(ate) {
+		t.Skip("ATE does not support setting the IPv6 flow label")
+	}
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	t.Run("Hashing", func(t *testing.T) { testHashing(t, dut, ate) })
+	t.Run("ACL", func(t *testing.T) { testACL(t, dut, ate) })
+	t.Run("PBR", func(t *testing.T) { testPBR(t, dut, ate) })
+}
@@ -0,0 +1,376 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weighted_nhg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/flowpath"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/gribigo/fluent"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	policyName = "wcmp-dscp-policy"
+	wcmpVRF    = "VRF_WCMP"
+
+	dscpWCMP      = 10
+	dscpUnmatched = 0
+
+	dstPrefix = "203.0.113.0/24"
+	dstAddr   = "203.0.113.1"
+	dstCount  = 250
+
+	nhgIndex = 1
+
+	// catchAllSeq is the sequence-id of the rule sending all other traffic to
+	// the default network instance.
+	catchAllSeq = 100
+
+	// srcPorts is the number of UDP source ports each flow varies over, so
+	// that it hashes over the members of the next-hop group.
+	srcPorts    = 4096
+	pps         = 10000
+	flowPackets = 200000
+	flowTimeout = 2 * time.Minute
+	lossTol     = 0.01
+
+	// tolerance is the allowed difference, in percentage points, between the
+	// share of traffic of each next hop and its share of the total weight.
+	tolerance = 3
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30}
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30}
+	atePort2 = attrs.Attributes{Name: "port2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30}
+	atePort3 = attrs.Attributes{Name: "port3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30}
+	dutPort4 = attrs.Attributes{Desc: "dutPort4", IPv4: "192.0.2.13", IPv4Len: 30}
+	atePort4 = attrs.Attributes{Name: "port4", MAC: "02:00:04:01:01:01", IPv4: "192.0.2.14", IPv4Len: 30}
+
+	dutPorts = []*attrs.Attributes{&dutPort1, &dutPort2, &dutPort3, &dutPort4}
+	atePorts = []*attrs.Attributes{&atePort1, &atePort2, &atePort3, &atePort4}
+
+	// nextHops are the gRIBI next-hop indices of the next-hop group members,
+	// keyed by the ATE port they resolve to.
+	nextHops = map[string]uint64{
+		atePort2.Name: 2,
+		atePort3.Name: 3,
+		atePort4.Name: 4,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Program a next-hop group with equal weights over ATE port-2, port-3 and
+//     port-4, and verify traffic matching the policy is split equally.
+//  2. Reprogram the next-hop group with unequal weights, and verify traffic
+//     matching the policy is split in proportion to the weights.
+//  3. Verify traffic not matching the policy is not forwarded by the next-hop
+//     group.
+//
+// For each set of weights, the weights reported in the AFT for the next-hop
+// group are also verified.
+//
+// Topology:
+//
+//	                      |---> ate:port2
+//	ate:port1 ---> dut ---|---> ate:port3
+//	                      |---> ate:port4
+//
+// Test notes:
+//   - The next-hop group is programmed by gRIBI.  OpenConfig does not model
+//     weights for next hops of policy-forwarding rules, so WCMP is only
+//     tested through gRIBI next-hop group weights.
+//   - The default network instance has no route to dstPrefix, so traffic
+//     not matching the policy is dropped.
+
+// configureDUT configures the ports and the WCMP VRF.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+	ni := &oc.NetworkInstance{
+		Name: ygot.String(wcmpVRF),
+		Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(wcmpVRF).Config(), ni)
+
+	for i, a := range dutPorts {
+		p := dut.Port(t, atePorts[i].Name)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configurePolicy applies a policy to DUT port-1 selecting wcmpVRF for
+// traffic with DSCP dscpWCMP.
+func configurePolicy(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	pf := (&oc.NetworkInstance{Name: ygot.String(defaultNI)}).GetOrCreatePolicyForwarding()
+	p := pf.GetOrCreatePolicy(policyName)
+	p.SetType(oc.Policy_Type_VRF_SELECTION_POLICY)
+
+	rule := p.GetOrCreateRule(1)
+	rule.GetOrCreateIpv4().DscpSet = []uint8{dscpWCMP}
+	rule.GetOrCreateAction().NetworkInstance = ygot.String(wcmpVRF)
+	if deviations.PfRequireMatchDefaultRule(dut) {
+		rule := p.GetOrCreateRule(catchAllSeq)
+		rule.GetOrCreateL2().SetEthertype(oc.PacketMatchTypes_ETHERTYPE_ETHERTYPE_IPV4)
+		rule.GetOrCreateAction().NetworkInstance = ygot.String(defaultNI)
+	} else {
+		p.GetOrCreateRule(catchAllSeq).GetOrCreateAction().NetworkInstance = ygot.String(defaultNI)
+	}
+
+	p1 := dut.Port(t, "port1")
+	interfaceID := p1.Name()
+	if deviations.InterfaceRefInterfaceIDFormat(dut) {
+		interfaceID = interfaceID + ".0"
+	}
+	intf := pf.GetOrCreateInterface(interfaceID)
+	intf.ApplyVrfSelectionPolicy = ygot.String(policyName)
+	intf.GetOrCreateInterfaceRef().Interface = ygot.String(p1.Name())
+	intf.GetOrCreateInterfaceRef().Subinterface = ygot.Uint32(0)
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		intf.InterfaceRef = nil
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(defaultNI).PolicyForwarding().Config(), pf)
+}
+
+// programNHG programs the next-hop group with the given weights, keyed by
+// ATE port, and dstPrefix in wcmpVRF pointing to it.  The next-hop group is
+// replaced if it already exists.
+func programNHG(t *testing.T, dut *ondatra.DUTDevice, c *gribi.Client, weights map[string]uint64) {
+	t.Helper()
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	nhWeights := make(map[uint64]uint64)
+	for port, w := range weights {
+		nhWeights[nextHops[port]] = w
+	}
+	c.AddNHG(t, nhgIndex, nhWeights, defaultNI, fluent.InstalledInFIB)
+	c.AddIPv4(t, dstPrefix, nhgIndex, wcmpVRF, defaultNI, fluent.InstalledInFIB)
+}
+
+// aftWeights returns the weights of the next-hop group dstPrefix resolves to
+// in the AFT of wcmpVRF, keyed by the ATE port each next hop resolves to.
+func aftWeights(t *testing.T, dut *ondatra.DUTDevice) map[string]uint64 {
+	t.Helper()
+	e := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(wcmpVRF).Afts().Ipv4Entry(dstPrefix).State())
+	nhgNI := e.GetNextHopGroupNetworkInstance()
+	if nhgNI == "" {
+		nhgNI = deviations.DefaultNetworkInstance(dut)
+	}
+	afts := gnmi.OC().NetworkInstance(nhgNI).Afts()
+	nhg := gnmi.Get(t, dut, afts.NextHopGroup(e.GetNextHopGroup()).State())
+	ports := make(map[string]string)
+	for _, a := range atePorts[1:] {
+		ports[a.IPv4] = a.Name
+	}
+	weights := make(map[string]uint64)
+	for idx, nh := range nhg.NextHop {
+		addr := gnmi.Get(t, dut, afts.NextHop(idx).State()).GetIpAddress()
+		port, ok := ports[addr]
+		if !ok {
+			t.Errorf("AFT next hop %d of next-hop group %d has address %s, want one of the ATE ports", idx, e.GetNextHopGroup(), addr)
+			continue
+		}
+		weights[port] += nh.GetWeight()
+	}
+	return weights
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	for i, a := range atePorts {
+		a.AddToOTG(top, ate.Port(t, a.Name), dutPorts[i])
+	}
+	for _, f := range []struct {
+		name string
+		dscp uint32
+	}{
+		{"wcmp", dscpWCMP},
+		{"unmatched", dscpUnmatched},
+	} {
+		flow := top.Flows().Add().SetName(f.name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().
+			SetTxNames([]string{atePort1.Name + ".IPv4"}).
+			SetRxNames([]string{atePort2.Name + ".IPv4", atePort3.Name + ".IPv4", atePort4.Name + ".IPv4"})
+		flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(atePort1.IPv4)
+		v4.Dst().Increment().SetStart(dstAddr).SetCount(dstCount)
+		v4.Priority().Dscp().Phb().SetValue(f.dscp)
+		udp := flow.Packet().Add().Udp()
+		udp.SrcPort().Increment().SetStart(1024).SetCount(srcPorts)
+		udp.DstPort().SetValue(49152)
+		flow.Size().SetFixed(256)
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+	return top
+}
+
+// rxFrames returns the in-frames counter of ATE port-2, port-3 and port-4.
+func rxFrames(t *testing.T, ate *ondatra.ATEDevice) map[string]uint64 {
+	t.Helper()
+	rx := make(map[string]uint64)
+	for _, a := range atePorts[1:] {
+		rx[a.Name] = gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(ate.Port(t, a.Name).ID()).Counters().InFrames().State())
+	}
+	return rx
+}
+
+// send sends the named flow alone, and returns the number of packets it sent
+// and the number of frames received by each egress ATE port meanwhile.
+func send(t *testing.T, ate *ondatra.ATEDevice, name string) (uint64, map[string]uint64) {
+	t.Helper()
+	before := rxFrames(t, ate)
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().SetState(gosnappi.StateTrafficFlowTransmitState.START).SetFlowNames([]string{name})
+	ate.OTG().SetControlState(t, cs)
+	gnmi.Await(t, ate.OTG(), gnmi.OTG().Flow(name).Transmit().State(), flowTimeout, false)
+	// Give the last packets time to arrive.
+	time.Sleep(2 * time.Second)
+	after := rxFrames(t, ate)
+	rx := make(map[string]uint64)
+	for port, a := range after {
+		if b := before[port]; a >= b {
+			rx[port] = a - b
+		}
+	}
+	tx := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(name).Counters().OutPkts().State())
+	t.Logf("Flow %s sent %d packets, received by ATE ports: %v", name, tx, rx)
+	return tx, rx
+}
+
+func counter(t *testing.T, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[uint64]) uint64 {
+	t.Helper()
+	v, _ := gnmi.Lookup(t, dut, q).Val()
+	return v
+}
+
+func TestWeightedNHG(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+
+	c := &gribi.Client{DUT: dut, FIBACK: true, Persistence: true}
+	defer c.Close(t)
+	if err := c.Start(t); err != nil {
+		t.Fatalf("gRIBI connection could not be established: %v", err)
+	}
+	c.BecomeLeader(t)
+	defer c.FlushAll(t)
+	defaultNI := deviations.DefaultNetworkInstance(dut)
+	for _, a := range atePorts[1:] {
+		c.AddNH(t, nextHops[a.Name], a.IPv4, defaultNI, fluent.InstalledInFIB)
+	}
+	configurePolicy(t, dut)
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	rule := gnmi.OC().NetworkInstance(defaultNI).PolicyForwarding().Policy(policyName).Rule(1)
+
+	cases := []struct {
+		desc    string
+		weights map[string]uint64
+	}{{
+		desc:    "Equal weights",
+		weights: map[string]uint64{atePort2.Name: 1, atePort3.Name: 1, atePort4.Name: 1},
+	}, {
+		desc:    "Weights 1:2:4",
+		weights: map[string]uint64{atePort2.Name: 1, atePort3.Name: 2, atePort4.Name: 4},
+	}, {
+		desc:    "Weights 10:30:60",
+		weights: map[string]uint64{atePort2.Name: 10, atePort3.Name: 30, atePort4.Name: 60},
+	}, {
+		desc:    "Weights 1:1:8",
+		weights: map[string]uint64{atePort2.Name: 1, atePort3.Name: 1, atePort4.Name: 8},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			programNHG(t, dut, c, tc.weights)
+
+			t.Run("AFT", func(t *testing.T) {
+				got := aftWeights(t, dut)
+				// The DUT may normalize the weights, so compare the shares.
+				if err := flowpath.CheckDistribution(got, tc.weights, 1); err != nil {
+					t.Errorf("AFT next-hop group weights %v: %v", got, err)
+				}
+			})
+
+			t.Run("Traffic", func(t *testing.T) {
+				ruleBefore := counter(t, dut, rule.MatchedPkts().State())
+				tx, rx := send(t, ate, "wcmp")
+				if tx == 0 {
+					t.Fatalf("Flow wcmp sent no packets")
+				}
+				var got uint64
+				for _, n := range rx {
+					got += n
+				}
+				if float64(got) < float64(tx)*(1-lossTol) {
+					t.Errorf("Flow wcmp: got %d of %d packets forwarded, want all of them", got, tx)
+				}
+				if err := flowpath.CheckDistribution(rx, tc.weights, tolerance); err != nil {
+					t.Errorf("Flow wcmp: %v", err)
+				}
+				if got := counter(t, dut, rule.MatchedPkts().State()) - ruleBefore; got < tx {
+					t.Errorf("Policy %s rule 1 matched-pkts increase: got %d, want >= %d", policyName, got, tx)
+				}
+			})
+		})
+	}
+
+	t.Run("Unmatched traffic", func(t *testing.T) {
+		tx, rx := send(t, ate, "unmatched")
+		if tx == 0 {
+			t.Fatalf("Flow unmatched sent no packets")
+		}
+		var got uint64
+		for _, n := range rx {
+			got += n
+		}
+		if float64(got) > float64(tx)*lossTol {
+			t.Errorf("Flow unmatched: got %d of %d packets forwarded, want it dropped", got, tx)
+		}
+	})
+}
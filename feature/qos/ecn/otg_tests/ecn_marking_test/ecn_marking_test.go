@@ -0,0 +1,344 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecn_marking_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qoscfg"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	minCERatio      = flag.Float64("min_ce_ratio", 0.01, "Minimum fraction of captured ECN-capable packets that must be CE marked when the egress port is congested.")
+	trafficDuration = flag.Duration("traffic_duration", 30*time.Second, "How long to send traffic for each test case.")
+)
+
+const (
+	ecnProfile   = "ECNProfile"
+	schedPolicy  = "scheduler"
+	classifierV4 = "dscp_based_classifier_ipv4"
+	groupAF1     = "target-group-AF1"
+	groupBE1     = "target-group-BE1"
+	dscpAF1      = 10
+	dscpBE1      = 0
+	frameSize    = 1000
+
+	// WRED/ECN profile applied to the AF1 queue of the bottleneck port.
+	minThreshold       = uint64(80000)
+	maxThreshold       = uint64(math.MaxUint64)
+	maxDropProbability = uint8(1)
+
+	// ECN codepoints from RFC 3168.
+	ecnNotECT = 0
+	ecnECT1   = 1
+	ecnECT0   = 2
+	ecnCE     = 3
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "198.51.100.0", IPv4Len: 31}
+	atePort1 = attrs.Attributes{Name: "ate1", MAC: "02:00:01:01:01:01", IPv4: "198.51.100.1", IPv4Len: 31}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "198.51.100.2", IPv4Len: 31}
+	atePort2 = attrs.Attributes{Name: "ate2", MAC: "02:00:01:02:01:01", IPv4: "198.51.100.3", IPv4Len: 31}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "198.51.100.4", IPv4Len: 31}
+	atePort3 = attrs.Attributes{Name: "ate3", MAC: "02:00:01:03:01:01", IPv4: "198.51.100.5", IPv4Len: 31}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Send ECT(0) traffic from ATE port-1 and port-2 at 60% line rate each
+//     to ATE port-3, congesting DUT port-3.  Verify at least --min_ce_ratio
+//     of the packets captured on ATE port-3 are CE marked and the AF1 queue
+//     ecn-marked-pkts counter increments.
+//  2. Repeat with ECT(1) traffic.
+//  3. Repeat with Not-ECT traffic.  Verify no packet is CE marked and the
+//     queue drops packets instead.
+//  4. Send ECT(0) traffic at 40% line rate each.  Verify no packet is CE
+//     marked and nothing is dropped.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-3 <--> ATE port-3
+//	ATE port-2 <--> port-2 DUT
+//
+// Test notes:
+//   - Packets are captured on ATE port-3 for the whole test case.  The
+//     capture buffer may not hold every packet, so the CE ratio from the
+//     capture is compared with --min_ce_ratio rather than with the DUT
+//     counters.
+
+func configureDUTIntf(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		id string
+		a  attrs.Attributes
+	}{{"port1", dutPort1}, {"port2", dutPort2}, {"port3", dutPort3}} {
+		dp := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configureQoS classifies DSCP 10 into AF1 and DSCP 0 into BE1 on the
+// ingress ports and applies the ECN profile to the AF1 queue of port-3.
+func configureQoS(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	dp1 := dut.Port(t, "port1")
+	dp2 := dut.Port(t, "port2")
+	dp3 := dut.Port(t, "port3")
+	queues := netutil.CommonTrafficQueues(t, dut)
+	d := &oc.Root{}
+	q := d.GetOrCreateQos()
+
+	if deviations.QOSQueueRequiresID(dut) {
+		for i, queue := range []string{queues.AF1, queues.BE1} {
+			q1 := q.GetOrCreateQueue(queue)
+			q1.Name = ygot.String(queue)
+			q1.QueueId = ygot.Uint8(uint8(2 - i))
+		}
+	}
+	qoscfg.SetForwardingGroup(t, dut, q, groupAF1, queues.AF1)
+	qoscfg.SetForwardingGroup(t, dut, q, groupBE1, queues.BE1)
+
+	uniform := q.GetOrCreateQueueManagementProfile(ecnProfile).GetOrCreateWred().GetOrCreateUniform()
+	uniform.SetEnableEcn(true)
+	uniform.SetDrop(false)
+	uniform.SetMinThreshold(minThreshold)
+	uniform.SetMaxThreshold(maxThreshold)
+	uniform.SetMaxDropProbabilityPercent(maxDropProbability)
+
+	classifier := q.GetOrCreateClassifier(classifierV4)
+	classifier.SetType(oc.Qos_Classifier_Type_IPV4)
+	for i, c := range []struct {
+		group string
+		dscp  uint8
+	}{{groupAF1, dscpAF1}, {groupBE1, dscpBE1}} {
+		term, err := classifier.NewTerm(strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("Failed to create classifier.NewTerm(): %v", err)
+		}
+		term.GetOrCreateActions().SetTargetGroup(c.group)
+		term.GetOrCreateConditions().GetOrCreateIpv4().SetDscpSet([]uint8{c.dscp})
+	}
+	for _, dp := range []*ondatra.Port{dp1, dp2} {
+		qoscfg.SetInputClassifier(t, dut, q, dp.Name(), oc.Input_Classifier_Type_IPV4, classifierV4)
+	}
+
+	s := q.GetOrCreateSchedulerPolicy(schedPolicy).GetOrCreateScheduler(1)
+	s.SetSequence(1)
+	for _, queue := range []string{queues.AF1, queues.BE1} {
+		input := s.GetOrCreateInput(queue)
+		input.SetInputType(oc.Input_InputType_QUEUE)
+		input.SetQueue(queue)
+		input.SetWeight(1)
+	}
+
+	i := q.GetOrCreateInterface(dp3.Name())
+	i.GetOrCreateInterfaceRef().Interface = ygot.String(dp3.Name())
+	output := i.GetOrCreateOutput()
+	output.GetOrCreateSchedulerPolicy().SetName(schedPolicy)
+	output.GetOrCreateQueue(queues.AF1).SetQueueManagementProfile(ecnProfile)
+	output.GetOrCreateQueue(queues.BE1)
+	gnmi.Replace(t, dut, gnmi.OC().Qos().Config(), q)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	top.Captures().Add().SetName("port3").SetPortNames([]string{"port3"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+	return top
+}
+
+// addFlows replaces the flows in top with one flow from each of ATE port-1
+// and port-2 to ATE port-3 at pct percent of line rate with the given ECN
+// codepoint.
+func addFlows(top gosnappi.Config, pct float32, ecn uint32) []string {
+	top.Flows().Clear()
+	var names []string
+	for _, src := range []attrs.Attributes{atePort1, atePort2} {
+		flow := top.Flows().Add().SetName(src.Name + "-to-" + atePort3.Name)
+		flow.Metrics().SetEnable(true)
+		flow.TxRx().Device().SetTxNames([]string{src.Name + ".IPv4"}).SetRxNames([]string{atePort3.Name + ".IPv4"})
+		flow.Size().SetFixed(frameSize)
+		flow.Rate().SetPercentage(pct)
+		flow.Packet().Add().Ethernet().Src().SetValue(src.MAC)
+		v4 := flow.Packet().Add().Ipv4()
+		v4.Src().SetValue(src.IPv4)
+		v4.Dst().SetValue(atePort3.IPv4)
+		v4.Priority().Dscp().Phb().SetValue(dscpAF1)
+		v4.Priority().Dscp().Ecn().SetValue(ecn)
+		names = append(names, flow.Name())
+	}
+	return names
+}
+
+type queueCounters struct {
+	transmit, dropped, ecnMarked uint64
+}
+
+func getQueueCounters(t *testing.T, dut *ondatra.DUTDevice, intf, queue string) queueCounters {
+	t.Helper()
+	q := gnmi.Get(t, dut, gnmi.OC().Qos().Interface(intf).Output().Queue(queue).State())
+	return queueCounters{transmit: q.GetTransmitPkts(), dropped: q.GetDroppedPkts(), ecnMarked: q.GetEcnMarkedPkts()}
+}
+
+// captureECN returns the number of IPv4 packets to ATE port-3 in the port-3
+// capture and how many of them are CE marked.
+func captureECN(t *testing.T, ate *ondatra.ATEDevice) (total, ce int) {
+	t.Helper()
+	b := ate.OTG().GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port3"))
+	r, err := pcapgo.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Failed to read port3 capture: %v", err)
+	}
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port3 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		v4, ok := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		if !ok || v4.DstIP.String() != atePort3.IPv4 {
+			continue
+		}
+		total++
+		if v4.TOS&0x3 == ecnCE {
+			ce++
+		}
+	}
+	return total, ce
+}
+
+func TestECNMarking(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUTIntf(t, dut)
+	configureQoS(t, dut)
+	dp3 := dut.Port(t, "port3")
+	queue := netutil.CommonTrafficQueues(t, dut).AF1
+
+	top := configureATE(t, ate)
+
+	cases := []struct {
+		desc       string
+		pct        float32
+		ecn        uint32
+		wantMarked bool
+		wantDrops  bool
+	}{
+		{desc: "ECT(0) congested", pct: 60, ecn: ecnECT0, wantMarked: true, wantDrops: true},
+		{desc: "ECT(1) congested", pct: 60, ecn: ecnECT1, wantMarked: true, wantDrops: true},
+		{desc: "Not-ECT congested", pct: 60, ecn: ecnNotECT, wantDrops: true},
+		{desc: "ECT(0) not congested", pct: 40, ecn: ecnECT0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flows := addFlows(top, tc.pct, tc.ecn)
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+			before := getQueueCounters(t, dut, dp3.Name(), queue)
+			cs := gosnappi.NewControlState()
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+			ate.OTG().SetControlState(t, cs)
+
+			ate.OTG().StartTraffic(t)
+			time.Sleep(*trafficDuration)
+			ate.OTG().StopTraffic(t)
+
+			cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+			ate.OTG().SetControlState(t, cs)
+			otgutils.LogFlowMetrics(t, ate.OTG(), top)
+			// Give the DUT time to update the queue counters.
+			time.Sleep(10 * time.Second)
+			after := getQueueCounters(t, dut, dp3.Name(), queue)
+
+			var txPkts, rxPkts uint64
+			for _, f := range flows {
+				m := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(f).Counters().State())
+				txPkts += m.GetOutPkts()
+				rxPkts += m.GetInPkts()
+			}
+			total, ce := captureECN(t, ate)
+			if total == 0 {
+				t.Fatalf("No packets to %s captured on port3", atePort3.IPv4)
+			}
+			ratio := float64(ce) / float64(total)
+			marked := after.ecnMarked - before.ecnMarked
+			dropped := after.dropped - before.dropped
+			t.Logf("Captured %d packets, %d CE marked (%.4f); queue %s transmit-pkts +%d, dropped-pkts +%d, ecn-marked-pkts +%d; ATE tx %d rx %d",
+				total, ce, ratio, queue, after.transmit-before.transmit, dropped, marked, txPkts, rxPkts)
+
+			if tc.wantMarked {
+				if ratio < *minCERatio {
+					t.Errorf("CE marked ratio: got %.4f, want >= %.4f", ratio, *minCERatio)
+				}
+				if marked == 0 {
+					t.Errorf("Queue %s ecn-marked-pkts: got no increase, want increase", queue)
+				}
+			} else {
+				if ce != 0 {
+					t.Errorf("CE marked packets: got %d, want 0", ce)
+				}
+				if marked != 0 {
+					t.Errorf("Queue %s ecn-marked-pkts: got increase of %d, want 0", queue, marked)
+				}
+			}
+			if tc.wantDrops {
+				if dropped == 0 {
+					t.Errorf("Queue %s dropped-pkts: got no increase, want increase", queue)
+				}
+			} else if rxPkts != txPkts {
+				t.Errorf("ATE packets: got tx %d rx %d, want no loss", txPkts, rxPkts)
+			}
+			ate.OTG().StopProtocols(t)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package microburst_test
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qoscfg"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	smallBurst      = flag.Uint("small_burst", 100, "Packets per burst that fit in the queue below the WRED minimum threshold.")
+	mediumBurst     = flag.Uint("medium_burst", 2000, "Packets per burst that exceed the WRED maximum threshold but fit in the queue buffer.")
+	largeBurst      = flag.Uint("large_burst", 50000, "Packets per burst that exceed the queue buffer.")
+	burstGap        = flag.Duration("burst_gap", 10*time.Millisecond, "Idle time between bursts, long enough for the queue to drain.")
+	wredMin         = flag.Uint64("wred_min_threshold", 1000000, "WRED minimum threshold in bytes.")
+	wredMax         = flag.Uint64("wred_max_threshold", 1500000, "WRED maximum threshold in bytes.")
+	trafficDuration = flag.Duration("traffic_duration", 30*time.Second, "How long to send traffic for each test case.")
+)
+
+const (
+	wredProfile    = "WREDProfile"
+	schedPolicy    = "scheduler"
+	classifierV4   = "dscp_based_classifier_ipv4"
+	groupAF1       = "target-group-AF1"
+	groupAF2       = "target-group-AF2"
+	dscpAF1        = 10
+	dscpAF2        = 18
+	frameSize      = 1000
+	steadyRatePct  = 10
+	maxDropPercent = uint8(100)
+	steadyFlowName = "steady"
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "198.51.100.0", IPv4Len: 31}
+	atePort1 = attrs.Attributes{Name: "ate1", MAC: "02:00:01:01:01:01", IPv4: "198.51.100.1", IPv4Len: 31}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "198.51.100.2", IPv4Len: 31}
+	atePort2 = attrs.Attributes{Name: "ate2", MAC: "02:00:01:02:01:01", IPv4: "198.51.100.3", IPv4Len: 31}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "198.51.100.4", IPv4Len: 31}
+	atePort3 = attrs.Attributes{Name: "ate3", MAC: "02:00:01:03:01:01", IPv4: "198.51.100.5", IPv4Len: 31}
+	dutPort4 = attrs.Attributes{Desc: "dutPort4", IPv4: "198.51.100.6", IPv4Len: 31}
+	atePort4 = attrs.Attributes{Name: "ate4", MAC: "02:00:01:04:01:01", IPv4: "198.51.100.7", IPv4Len: 31}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Tail drop, small bursts: verify no drops.
+//  2. Tail drop, medium bursts: verify no drops.
+//  3. Tail drop, large bursts: verify the AF1 queue drops packets.
+//  4. WRED, medium bursts: verify the AF1 queue drops packets.
+//
+// In each case, ATE port-1 and port-2 send synchronized AF1 bursts at line
+// rate to ATE port-3, and ATE port-4 sends steady AF2 traffic at 10% of line
+// rate to ATE port-3.  Verify the max-queue-len of the AF1 queue of DUT
+// port-3 is non-zero, and the AF2 queue drops nothing.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-3 <--> ATE port-3
+//	ATE port-2 <--> port-2 DUT
+//	ATE port-4 <--> port-4 DUT
+//
+// Test notes:
+//   - Buffer sizes differ between platforms, so the burst sizes and WRED
+//     thresholds are set by flags.  With two ports bursting into one, the
+//     queue grows by about one burst of packets per burst.
+
+func configureDUTIntf(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		id string
+		a  attrs.Attributes
+	}{{"port1", dutPort1}, {"port2", dutPort2}, {"port3", dutPort3}, {"port4", dutPort4}} {
+		dp := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configureQoS classifies DSCP 10 into AF1 and DSCP 18 into AF2 on the
+// ingress ports, and schedules both queues of port-3 with equal weights.  If
+// wred is true, the WRED profile is applied to the AF1 queue of port-3, and
+// otherwise it tail drops.
+func configureQoS(t *testing.T, dut *ondatra.DUTDevice, wred bool) {
+	t.Helper()
+	dp3 := dut.Port(t, "port3")
+	queues := netutil.CommonTrafficQueues(t, dut)
+	d := &oc.Root{}
+	q := d.GetOrCreateQos()
+
+	if deviations.QOSQueueRequiresID(dut) {
+		for i, queue := range []string{queues.AF2, queues.AF1} {
+			q1 := q.GetOrCreateQueue(queue)
+			q1.Name = ygot.String(queue)
+			q1.QueueId = ygot.Uint8(uint8(3 - i))
+		}
+	}
+	qoscfg.SetForwardingGroup(t, dut, q, groupAF1, queues.AF1)
+	qoscfg.SetForwardingGroup(t, dut, q, groupAF2, queues.AF2)
+
+	classifier := q.GetOrCreateClassifier(classifierV4)
+	classifier.SetType(oc.Qos_Classifier_Type_IPV4)
+	for i, c := range []struct {
+		group string
+		dscp  uint8
+	}{{groupAF1, dscpAF1}, {groupAF2, dscpAF2}} {
+		term, err := classifier.NewTerm(strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("Failed to create classifier.NewTerm(): %v", err)
+		}
+		term.GetOrCreateActions().SetTargetGroup(c.group)
+		term.GetOrCreateConditions().GetOrCreateIpv4().SetDscpSet([]uint8{c.dscp})
+	}
+	for _, id := range []string{"port1", "port2", "port4"} {
+		qoscfg.SetInputClassifier(t, dut, q, dut.Port(t, id).Name(), oc.Input_Classifier_Type_IPV4, classifierV4)
+	}
+
+	s := q.GetOrCreateSchedulerPolicy(schedPolicy).GetOrCreateScheduler(1)
+	s.SetSequence(1)
+	for _, queue := range []string{queues.AF1, queues.AF2} {
+		input := s.GetOrCreateInput(queue)
+		input.SetInputType(oc.Input_InputType_QUEUE)
+		input.SetQueue(queue)
+		input.SetWeight(1)
+	}
+
+	i := q.GetOrCreateInterface(dp3.Name())
+	i.GetOrCreateInterfaceRef().Interface = ygot.String(dp3.Name())
+	output := i.GetOrCreateOutput()
+	output.GetOrCreateSchedulerPolicy().SetName(schedPolicy)
+	output.GetOrCreateQueue(queues.AF1)
+	output.GetOrCreateQueue(queues.AF2)
+	if wred {
+		uniform := q.GetOrCreateQueueManagementProfile(wredProfile).GetOrCreateWred().GetOrCreateUniform()
+		uniform.SetEnableEcn(false)
+		uniform.SetDrop(true)
+		uniform.SetMinThreshold(*wredMin)
+		uniform.SetMaxThreshold(*wredMax)
+		uniform.SetMaxDropProbabilityPercent(maxDropPercent)
+		output.GetQueue(queues.AF1).SetQueueManagementProfile(wredProfile)
+	}
+	gnmi.Replace(t, dut, gnmi.OC().Qos().Config(), q)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	atePort4.AddToOTG(top, ate.Port(t, "port4"), &dutPort4)
+	return top
+}
+
+// addFlow adds a flow from src to ATE port-3 with the given DSCP.
+func addFlow(top gosnappi.Config, name string, src attrs.Attributes, dscp uint32) gosnappi.Flow {
+	flow := top.Flows().Add().SetName(name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{src.Name + ".IPv4"}).SetRxNames([]string{atePort3.Name + ".IPv4"})
+	flow.Size().SetFixed(frameSize)
+	flow.Packet().Add().Ethernet().Src().SetValue(src.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(src.IPv4)
+	v4.Dst().SetValue(atePort3.IPv4)
+	v4.Priority().Dscp().Phb().SetValue(dscp)
+	return flow
+}
+
+// addFlows replaces the flows in top with AF1 bursts of the given size from
+// ATE port-1 and port-2, and steady AF2 traffic from ATE port-4.
+func addFlows(top gosnappi.Config, packets uint32) {
+	top.Flows().Clear()
+	burst := otgutils.BurstProfile{Packets: packets, Gap: *burstGap}
+	for _, src := range []attrs.Attributes{atePort1, atePort2} {
+		burst.Apply(addFlow(top, src.Name+"-burst", src, dscpAF1))
+	}
+	addFlow(top, steadyFlowName, atePort4, dscpAF2).Rate().SetPercentage(steadyRatePct)
+}
+
+type queueCounters struct {
+	transmit, dropped, maxLen, avgLen uint64
+}
+
+func getQueueCounters(t *testing.T, dut *ondatra.DUTDevice, intf, queue string) queueCounters {
+	t.Helper()
+	q := gnmi.Get(t, dut, gnmi.OC().Qos().Interface(intf).Output().Queue(queue).State())
+	return queueCounters{transmit: q.GetTransmitPkts(), dropped: q.GetDroppedPkts(), maxLen: q.GetMaxQueueLen(), avgLen: q.GetAvgQueueLen()}
+}
+
+func TestMicroburst(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUTIntf(t, dut)
+	dp3 := dut.Port(t, "port3")
+	queues := netutil.CommonTrafficQueues(t, dut)
+	top := configureATE(t, ate)
+
+	cases := []struct {
+		desc      string
+		wred      bool
+		packets   uint
+		wantDrops bool
+	}{
+		{desc: "Tail drop small bursts", packets: *smallBurst},
+		{desc: "Tail drop medium bursts", packets: *mediumBurst},
+		{desc: "Tail drop large bursts", packets: *largeBurst, wantDrops: true},
+		{desc: "WRED medium bursts", wred: true, packets: *mediumBurst, wantDrops: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			configureQoS(t, dut, tc.wred)
+			addFlows(top, uint32(tc.packets))
+			ate.OTG().PushConfig(t, top)
+			ate.OTG().StartProtocols(t)
+			otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+			beforeAF1 := getQueueCounters(t, dut, dp3.Name(), queues.AF1)
+			beforeAF2 := getQueueCounters(t, dut, dp3.Name(), queues.AF2)
+			ate.OTG().StartTraffic(t)
+			time.Sleep(*trafficDuration)
+			ate.OTG().StopTraffic(t)
+			otgutils.LogFlowMetrics(t, ate.OTG(), top)
+			// Give the DUT time to update the queue counters.
+			time.Sleep(10 * time.Second)
+			afterAF1 := getQueueCounters(t, dut, dp3.Name(), queues.AF1)
+			afterAF2 := getQueueCounters(t, dut, dp3.Name(), queues.AF2)
+
+			dropped := afterAF1.dropped - beforeAF1.dropped
+			t.Logf("Queue %s: transmit-pkts +%d, dropped-pkts +%d, max-queue-len %d, avg-queue-len %d",
+				queues.AF1, afterAF1.transmit-beforeAF1.transmit, dropped, afterAF1.maxLen, afterAF1.avgLen)
+			if afterAF1.maxLen == 0 {
+				t.Errorf("Queue %s max-queue-len: got 0, want > 0", queues.AF1)
+			}
+			switch {
+			case tc.wantDrops && dropped == 0:
+				t.Errorf("Queue %s dropped-pkts: got no increase, want increase", queues.AF1)
+			case !tc.wantDrops && dropped != 0:
+				t.Errorf("Queue %s dropped-pkts: got increase of %d, want 0", queues.AF1, dropped)
+			}
+
+			if got := afterAF2.dropped - beforeAF2.dropped; got != 0 {
+				t.Errorf("Queue %s dropped-pkts: got increase of %d, want 0", queues.AF2, got)
+			}
+			if loss := otgutils.GetFlowLossPct(t, ate.OTG(), steadyFlowName, 10*time.Second); loss > 0 {
+				t.Errorf("Flow %s loss: got %.2f%%, want 0", steadyFlowName, loss)
+			}
+			ate.OTG().StopProtocols(t)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sp_latency_test
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qoscfg"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	maxSPLatency    = flag.Duration("max_sp_latency", 100*time.Microsecond, "Maximum latency of strict priority traffic under congestion.")
+	maxSPJitter     = flag.Duration("max_sp_jitter", 50*time.Microsecond, "Maximum jitter, the difference of the maximum and minimum latency, of strict priority traffic under congestion.")
+	trafficDuration = flag.Duration("traffic_duration", 30*time.Second, "How long to send traffic for each test case.")
+)
+
+const (
+	schedPolicy  = "scheduler"
+	classifierV4 = "dscp_based_classifier_ipv4"
+	groupNC1     = "target-group-NC1"
+	groupBE1     = "target-group-BE1"
+	dscpNC1      = 48
+	dscpBE1      = 0
+	frameSize    = 512
+	// spRatePct is the rate of strict priority traffic from ATE port-1.
+	spRatePct = 10
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "198.51.100.0", IPv4Len: 31}
+	atePort1 = attrs.Attributes{Name: "ate1", MAC: "02:00:01:01:01:01", IPv4: "198.51.100.1", IPv4Len: 31}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "198.51.100.2", IPv4Len: 31}
+	atePort2 = attrs.Attributes{Name: "ate2", MAC: "02:00:01:02:01:01", IPv4: "198.51.100.3", IPv4Len: 31}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "198.51.100.4", IPv4Len: 31}
+	atePort3 = attrs.Attributes{Name: "ate3", MAC: "02:00:01:03:01:01", IPv4: "198.51.100.5", IPv4Len: 31}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Uncongested: ATE port-1 sends NC1 traffic at 10% of line rate, and ATE
+//     port-1 and port-2 send BE1 traffic at 20% each to ATE port-3.  Record
+//     the latency of each flow.
+//  2. Congested: ATE port-1 sends NC1 traffic at 10% of line rate, and ATE
+//     port-1 and port-2 send BE1 traffic at 60% each to ATE port-3.
+//     - Verify the NC1 flow has no loss, a maximum latency below
+//       --max_sp_latency and a jitter below --max_sp_jitter.
+//     - Verify the BE1 flows have loss, and an average latency above that of
+//       the NC1 flow and above their own uncongested average latency.
+//
+// NC1 is scheduled with strict priority and BE1 by WRR on DUT port-3.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-3 <--> ATE port-3
+//	ATE port-2 <--> port-2 DUT
+//
+// Test notes:
+//   - The ATE measures latency in cut-through mode from its own timestamps,
+//     and jitter as the difference of the maximum and minimum latency.
+//   - The latency bounds depend on the platform buffer and port speed, so they
+//     are set by flags.
+
+func configureDUTIntf(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		id string
+		a  attrs.Attributes
+	}{{"port1", dutPort1}, {"port2", dutPort2}, {"port3", dutPort3}} {
+		dp := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// configureQoS classifies DSCP 48 into NC1 and DSCP 0 into BE1 on the ingress
+// ports, and schedules NC1 with strict priority and BE1 by WRR on port-3.
+func configureQoS(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	dp3 := dut.Port(t, "port3")
+	queues := netutil.CommonTrafficQueues(t, dut)
+	d := &oc.Root{}
+	q := d.GetOrCreateQos()
+
+	if deviations.QOSQueueRequiresID(dut) {
+		for i, queue := range []string{queues.NC1, queues.BE1} {
+			q1 := q.GetOrCreateQueue(queue)
+			q1.Name = ygot.String(queue)
+			q1.QueueId = ygot.Uint8(uint8(7 - i))
+		}
+	}
+	qoscfg.SetForwardingGroup(t, dut, q, groupNC1, queues.NC1)
+	qoscfg.SetForwardingGroup(t, dut, q, groupBE1, queues.BE1)
+
+	classifier := q.GetOrCreateClassifier(classifierV4)
+	classifier.SetType(oc.Qos_Classifier_Type_IPV4)
+	for i, c := range []struct {
+		group string
+		dscp  uint8
+	}{{groupNC1, dscpNC1}, {groupBE1, dscpBE1}} {
+		term, err := classifier.NewTerm(strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("Failed to create classifier.NewTerm(): %v", err)
+		}
+		term.GetOrCreateActions().SetTargetGroup(c.group)
+		term.GetOrCreateConditions().GetOrCreateIpv4().SetDscpSet([]uint8{c.dscp})
+	}
+	for _, id := range []string{"port1", "port2"} {
+		qoscfg.SetInputClassifier(t, dut, q, dut.Port(t, id).Name(), oc.Input_Classifier_Type_IPV4, classifierV4)
+	}
+
+	policy := q.GetOrCreateSchedulerPolicy(schedPolicy)
+	for _, s := range []struct {
+		sequence uint32
+		priority oc.E_Scheduler_Priority
+		queue    string
+	}{
+		{0, oc.Scheduler_Priority_STRICT, queues.NC1},
+		{1, oc.Scheduler_Priority_UNSET, queues.BE1},
+	} {
+		sched := policy.GetOrCreateScheduler(s.sequence)
+		sched.SetSequence(s.sequence)
+		sched.SetPriority(s.priority)
+		input := sched.GetOrCreateInput(s.queue)
+		input.SetInputType(oc.Input_InputType_QUEUE)
+		input.SetQueue(s.queue)
+		input.SetWeight(1)
+	}
+
+	i := q.GetOrCreateInterface(dp3.Name())
+	i.GetOrCreateInterfaceRef().Interface = ygot.String(dp3.Name())
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		i.InterfaceRef = nil
+	}
+	output := i.GetOrCreateOutput()
+	output.GetOrCreateSchedulerPolicy().SetName(schedPolicy)
+	output.GetOrCreateQueue(queues.NC1)
+	output.GetOrCreateQueue(queues.BE1)
+	gnmi.Replace(t, dut, gnmi.OC().Qos().Config(), q)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	return top
+}
+
+// addFlow adds a flow from src to ATE port-3 with the given DSCP and rate,
+// which measures latency.
+func addFlow(top gosnappi.Config, name string, src attrs.Attributes, dscp uint32, ratePct float32) {
+	flow := top.Flows().Add().SetName(name)
+	otgutils.EnableLatency(flow)
+	flow.TxRx().Device().SetTxNames([]string{src.Name + ".IPv4"}).SetRxNames([]string{atePort3.Name + ".IPv4"})
+	flow.Size().SetFixed(frameSize)
+	flow.Rate().SetPercentage(ratePct)
+	flow.Packet().Add().Ethernet().Src().SetValue(src.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(src.IPv4)
+	v4.Dst().SetValue(atePort3.IPv4)
+	v4.Priority().Dscp().Phb().SetValue(dscp)
+}
+
+const spFlow = "NC1"
+
+// beFlows are the names of the BE1 flows.
+var beFlows = []string{atePort1.Name + "-BE1", atePort2.Name + "-BE1"}
+
+// runTraffic sends NC1 traffic from ATE port-1 and BE1 traffic at beRatePct
+// from ATE port-1 and port-2, and returns the latency and loss of each flow.
+func runTraffic(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, beRatePct float32) (map[string]otgutils.FlowLatency, map[string]float64) {
+	t.Helper()
+	top.Flows().Clear()
+	addFlow(top, spFlow, atePort1, dscpNC1, spRatePct)
+	for i, src := range []attrs.Attributes{atePort1, atePort2} {
+		addFlow(top, beFlows[i], src, dscpBE1, beRatePct)
+	}
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	defer ate.OTG().StopProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(*trafficDuration)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	otgutils.LogFlowLatency(t, ate.OTG(), top)
+
+	latency := make(map[string]otgutils.FlowLatency)
+	loss := make(map[string]float64)
+	for _, f := range top.Flows().Items() {
+		loss[f.Name()] = otgutils.GetFlowLossPct(t, ate.OTG(), f.Name(), 10*time.Second)
+		latency[f.Name()] = otgutils.GetFlowLatency(t, ate.OTG(), f.Name())
+	}
+	return latency, loss
+}
+
+func TestStrictPriorityLatency(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUTIntf(t, dut)
+	configureQoS(t, dut)
+	top := configureATE(t, ate)
+
+	var uncongested map[string]otgutils.FlowLatency
+	t.Run("Uncongested", func(t *testing.T) {
+		var loss map[string]float64
+		uncongested, loss = runTraffic(t, ate, top, 20)
+		for name, l := range loss {
+			if l > 0 {
+				t.Errorf("Flow %s loss: got %.2f%%, want 0", name, l)
+			}
+		}
+	})
+	if uncongested == nil {
+		t.Fatal("No uncongested latency to compare to")
+	}
+
+	t.Run("Congested", func(t *testing.T) {
+		latency, loss := runTraffic(t, ate, top, 60)
+		sp := latency[spFlow]
+		t.Logf("Flow %s latency: uncongested %v, congested %v", spFlow, uncongested[spFlow], sp)
+		if l := loss[spFlow]; l > 0 {
+			t.Errorf("Flow %s loss: got %.2f%%, want 0", spFlow, l)
+		}
+		if sp.Max > *maxSPLatency {
+			t.Errorf("Flow %s maximum latency: got %v, want <= %v", spFlow, sp.Max, *maxSPLatency)
+		}
+		if sp.Jitter() > *maxSPJitter {
+			t.Errorf("Flow %s jitter: got %v, want <= %v", spFlow, sp.Jitter(), *maxSPJitter)
+		}
+
+		for _, name := range beFlows {
+			be := latency[name]
+			t.Logf("Flow %s latency: uncongested %v, congested %v", name, uncongested[name], be)
+			if loss[name] == 0 {
+				t.Errorf("Flow %s loss: got 0, want > 0 under congestion", name)
+			}
+			if be.Avg <= sp.Avg {
+				t.Errorf("Flow %s average latency: got %v, want > %v of flow %s", name, be.Avg, sp.Avg, spFlow)
+			}
+			if be.Avg <= uncongested[name].Avg {
+				t.Errorf("Flow %s average latency: got %v, want > %v uncongested", name, be.Avg, uncongested[name].Avg)
+			}
+		}
+	})
+}
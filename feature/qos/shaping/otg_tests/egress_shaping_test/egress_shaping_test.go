@@ -0,0 +1,348 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egress_shaping_test
+
+import (
+	"flag"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/featureprofiles/internal/qoscfg"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/netutil"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	portShapeRate   = flag.Uint64("port_shape_rate", 1_000_000_000, "The rate in bits per second of the port shaper.")
+	queueShapeRate  = flag.Uint64("queue_shape_rate", 400_000_000, "The rate in bits per second of the queue shaper.")
+	shapeBurst      = flag.Uint("shape_burst", 64000, "The committed burst size in bytes of the shapers.")
+	rateTolerance   = flag.Float64("rate_tolerance_pct", 5, "The allowed difference in percent between the received rate of shaped traffic and the shaper rate.")
+	trafficDuration = flag.Duration("traffic_duration", 30*time.Second, "How long to send traffic for each rate test case.")
+)
+
+const (
+	schedPolicy  = "shaper"
+	classifierV4 = "dscp_based_classifier_ipv4"
+	groupAF4     = "target-group-AF4"
+	groupBE1     = "target-group-BE1"
+	dscpAF4      = 32
+	dscpBE1      = 0
+	frameSize    = 1000
+	flowAF4      = "AF4"
+	flowBE1      = "BE1"
+	// bigBurst is the size of the burst exceeding the burst size, as a
+	// multiple of it.
+	bigBurst = 10
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "198.51.100.0", IPv4Len: 31}
+	atePort1 = attrs.Attributes{Name: "ate1", MAC: "02:00:01:01:01:01", IPv4: "198.51.100.1", IPv4Len: 31}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "198.51.100.2", IPv4Len: 31}
+	atePort2 = attrs.Attributes{Name: "ate2", MAC: "02:00:01:02:01:01", IPv4: "198.51.100.3", IPv4Len: 31}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Port shaper: a single scheduler on DUT port-2 serves the AF4 and BE1
+//     queues, shaped to --port_shape_rate.  ATE port-1 sends AF4 and BE1
+//     traffic at 80% of the shaper rate each.  Verify the total rate
+//     received on ATE port-2 is the shaper rate within tolerance.
+//  2. Queue shaper: the AF4 queue is shaped to --queue_shape_rate, and the
+//     BE1 queue is not shaped.  ATE port-1 sends AF4 traffic at twice the
+//     shaper rate and BE1 traffic at half of it.  Verify the AF4 rate
+//     received on ATE port-2 is the shaper rate within tolerance, and BE1
+//     has no loss.
+//  3. Burst: with the queue shaper, ATE port-1 sends a burst of AF4 traffic
+//     at line rate of half the burst size, then one of 10 times the burst
+//     size.  Verify the small burst has no loss, and the big burst is
+//     either dropped in part or delayed by at least half of the time the
+//     shaper needs to send the bytes over the burst size.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+//
+// Test notes:
+//   - The shapers are one-rate two-color schedulers with the SHAPE queuing
+//     behavior, so traffic over the rate is queued before it is dropped.
+//   - The rates are measured from the octets received by the ATE, which
+//     count the Ethernet frames without preamble and inter-frame gap.
+
+func configureDUTIntf(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		id string
+		a  attrs.Attributes
+	}{{"port1", dutPort1}, {"port2", dutPort2}} {
+		dp := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(dp.Name()).Config(), p.a.NewOCInterface(dp.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, dp)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+}
+
+// scheduler is a scheduler of the policy on DUT port-2.  It is shaped if
+// cir is not 0.
+type scheduler struct {
+	queues []string
+	cir    uint64
+}
+
+// configureQoS classifies DSCP 32 into AF4 and DSCP 0 into BE1 on DUT
+// port-1, and applies a scheduler policy with scheds to DUT port-2.
+func configureQoS(t *testing.T, dut *ondatra.DUTDevice, scheds []scheduler) {
+	t.Helper()
+	dp2 := dut.Port(t, "port2")
+	queues := netutil.CommonTrafficQueues(t, dut)
+	d := &oc.Root{}
+	q := d.GetOrCreateQos()
+
+	if deviations.QOSQueueRequiresID(dut) {
+		for i, queue := range []string{queues.AF4, queues.BE1} {
+			q1 := q.GetOrCreateQueue(queue)
+			q1.Name = ygot.String(queue)
+			q1.QueueId = ygot.Uint8(uint8(7 - i))
+		}
+	}
+	qoscfg.SetForwardingGroup(t, dut, q, groupAF4, queues.AF4)
+	qoscfg.SetForwardingGroup(t, dut, q, groupBE1, queues.BE1)
+
+	classifier := q.GetOrCreateClassifier(classifierV4)
+	classifier.SetType(oc.Qos_Classifier_Type_IPV4)
+	for i, c := range []struct {
+		group string
+		dscp  uint8
+	}{{groupAF4, dscpAF4}, {groupBE1, dscpBE1}} {
+		term, err := classifier.NewTerm(strconv.Itoa(i))
+		if err != nil {
+			t.Fatalf("Failed to create classifier.NewTerm(): %v", err)
+		}
+		term.GetOrCreateActions().SetTargetGroup(c.group)
+		term.GetOrCreateConditions().GetOrCreateIpv4().SetDscpSet([]uint8{c.dscp})
+	}
+	qoscfg.SetInputClassifier(t, dut, q, dut.Port(t, "port1").Name(), oc.Input_Classifier_Type_IPV4, classifierV4)
+
+	policy := q.GetOrCreateSchedulerPolicy(schedPolicy)
+	for seq, s := range scheds {
+		sched := policy.GetOrCreateScheduler(uint32(seq))
+		sched.SetSequence(uint32(seq))
+		sched.SetPriority(oc.Scheduler_Priority_UNSET)
+		for _, queue := range s.queues {
+			input := sched.GetOrCreateInput(queue)
+			input.SetInputType(oc.Input_InputType_QUEUE)
+			input.SetQueue(queue)
+			input.SetWeight(1)
+		}
+		if s.cir == 0 {
+			continue
+		}
+		sched.SetType(oc.QosTypes_QOS_SCHEDULER_TYPE_ONE_RATE_TWO_COLOR)
+		ortc := sched.GetOrCreateOneRateTwoColor()
+		ortc.SetCir(s.cir)
+		ortc.SetBc(uint32(*shapeBurst))
+		ortc.SetQueuingBehavior(oc.Qos_QueueBehavior_SHAPE)
+		ortc.GetOrCreateExceedAction().SetDrop(true)
+	}
+
+	i := q.GetOrCreateInterface(dp2.Name())
+	i.GetOrCreateInterfaceRef().Interface = ygot.String(dp2.Name())
+	if deviations.InterfaceRefConfigUnsupported(dut) {
+		i.InterfaceRef = nil
+	}
+	output := i.GetOrCreateOutput()
+	output.GetOrCreateSchedulerPolicy().SetName(schedPolicy)
+	output.GetOrCreateQueue(queues.AF4)
+	output.GetOrCreateQueue(queues.BE1)
+	gnmi.Replace(t, dut, gnmi.OC().Qos().Config(), q)
+
+	for seq, s := range scheds {
+		if s.cir == 0 {
+			continue
+		}
+		cir := gnmi.OC().Qos().SchedulerPolicy(schedPolicy).Scheduler(uint32(seq)).OneRateTwoColor().Cir().State()
+		if got := gnmi.Get(t, dut, cir); got != s.cir {
+			t.Errorf("Scheduler %d cir: got %d, want %d", seq, got, s.cir)
+		}
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	return top
+}
+
+// addFlow adds a flow from ATE port-1 to ATE port-2 with the given DSCP.
+func addFlow(top gosnappi.Config, name string, dscp uint32) gosnappi.Flow {
+	flow := top.Flows().Add().SetName(name)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4"})
+	flow.Size().SetFixed(frameSize)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValue(atePort2.IPv4)
+	v4.Priority().Dscp().Phb().SetValue(dscp)
+	return flow
+}
+
+// pushAndStart pushes top to the ATE and waits for ARP.
+func pushAndStart(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config) {
+	t.Helper()
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+}
+
+// runRates sends a flow at each of the rates in bits per second for
+// --traffic_duration, and returns the rate each was received at, and its
+// loss.
+func runRates(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, rates map[string]uint64) (map[string]float64, map[string]float64) {
+	t.Helper()
+	top.Flows().Clear()
+	for _, f := range []struct {
+		name string
+		dscp uint32
+	}{{flowAF4, dscpAF4}, {flowBE1, dscpBE1}} {
+		if r, ok := rates[f.name]; ok {
+			addFlow(top, f.name, f.dscp).Rate().SetBps(r)
+		}
+	}
+	pushAndStart(t, ate, top)
+	defer ate.OTG().StopProtocols(t)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(*trafficDuration)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+	received := make(map[string]float64)
+	loss := make(map[string]float64)
+	for name := range rates {
+		octets := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(name).Counters().InOctets().State())
+		received[name] = float64(octets) * 8 / trafficDuration.Seconds()
+		loss[name] = otgutils.GetFlowLossPct(t, ate.OTG(), name, 10*time.Second)
+	}
+	return received, loss
+}
+
+// verifyRate verifies the received rate in bits per second is the shaper
+// rate within tolerance.
+func verifyRate(t *testing.T, desc string, got float64, want uint64) {
+	t.Helper()
+	diff := 100 * math.Abs(got-float64(want)) / float64(want)
+	t.Logf("%s received rate: %.0f bps, shaper rate %d bps, difference %.2f%%", desc, got, want, diff)
+	if diff > *rateTolerance {
+		t.Errorf("%s received rate: got %.0f bps, want %d bps within %.2f%%", desc, got, want, *rateTolerance)
+	}
+}
+
+// runBurst sends a single AF4 burst of size bytes at line rate, and returns
+// its loss and latency.
+func runBurst(t *testing.T, ate *ondatra.ATEDevice, top gosnappi.Config, size uint64) (float64, otgutils.FlowLatency) {
+	t.Helper()
+	top.Flows().Clear()
+	flow := addFlow(top, flowAF4, dscpAF4)
+	otgutils.EnableLatency(flow)
+	flow.Rate().SetPercentage(100)
+	flow.Duration().FixedPackets().SetPackets(uint32(size / frameSize))
+	pushAndStart(t, ate, top)
+	defer ate.OTG().StopProtocols(t)
+
+	ate.OTG().StartTraffic(t)
+	// The burst is sent within milliseconds, and the shaper drains it
+	// within a second at the rates of the test.
+	time.Sleep(5 * time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+	otgutils.LogFlowLatency(t, ate.OTG(), top)
+	return otgutils.GetFlowLossPct(t, ate.OTG(), flowAF4, 10*time.Second), otgutils.GetFlowLatency(t, ate.OTG(), flowAF4)
+}
+
+func TestEgressShaping(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUTIntf(t, dut)
+	top := configureATE(t, ate)
+	queues := netutil.CommonTrafficQueues(t, dut)
+	t.Cleanup(func() {
+		gnmi.Delete(t, dut, gnmi.OC().Qos().Config())
+	})
+
+	t.Run("Port shaper", func(t *testing.T) {
+		configureQoS(t, dut, []scheduler{{queues: []string{queues.AF4, queues.BE1}, cir: *portShapeRate}})
+		offered := *portShapeRate * 8 / 10
+		received, _ := runRates(t, ate, top, map[string]uint64{flowAF4: offered, flowBE1: offered})
+		verifyRate(t, "Port", received[flowAF4]+received[flowBE1], *portShapeRate)
+	})
+
+	t.Run("Queue shaper", func(t *testing.T) {
+		configureQoS(t, dut, []scheduler{
+			{queues: []string{queues.AF4}, cir: *queueShapeRate},
+			{queues: []string{queues.BE1}},
+		})
+		received, loss := runRates(t, ate, top, map[string]uint64{flowAF4: 2 * *queueShapeRate, flowBE1: *queueShapeRate / 2})
+		verifyRate(t, "Queue "+queues.AF4, received[flowAF4], *queueShapeRate)
+		if loss[flowBE1] > 0 {
+			t.Errorf("Flow %s in the unshaped queue loss: got %.2f%%, want 0", flowBE1, loss[flowBE1])
+		}
+	})
+
+	t.Run("Burst", func(t *testing.T) {
+		configureQoS(t, dut, []scheduler{
+			{queues: []string{queues.AF4}, cir: *queueShapeRate},
+			{queues: []string{queues.BE1}},
+		})
+		bc := uint64(*shapeBurst)
+		// delay is the time the shaper needs to send the bytes of the big
+		// burst over the burst size.
+		delay := time.Duration(float64((bigBurst-1)*bc*8) / float64(*queueShapeRate) * float64(time.Second))
+
+		loss, small := runBurst(t, ate, top, bc/2)
+		t.Logf("Burst of %d bytes: loss %.2f%%, latency %v", bc/2, loss, small)
+		if loss > 0 {
+			t.Errorf("Burst of %d bytes within burst size %d loss: got %.2f%%, want 0", bc/2, bc, loss)
+		}
+		if small.Max >= delay/2 {
+			t.Errorf("Burst of %d bytes within burst size %d maximum latency: got %v, want < %v", bc/2, bc, small.Max, delay/2)
+		}
+
+		loss, big := runBurst(t, ate, top, bigBurst*bc)
+		t.Logf("Burst of %d bytes: loss %.2f%%, latency %v, shaping delay %v", bigBurst*bc, loss, big, delay)
+		if loss == 0 && big.Max < delay/2 {
+			t.Errorf("Burst of %d bytes over burst size %d: got no loss and maximum latency %v, want loss or maximum latency >= %v", bigBurst*bc, bc, big.Max, delay/2)
+		}
+	})
+}
@@ -0,0 +1,308 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package urpf_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+const (
+	pps         = 100
+	flowPackets = 1000
+	lossTol     = 1.0
+	// minDiscards is the fraction of the dropped packets the DUT must count
+	// as input discards on port-1.
+	minDiscards = 0.9
+)
+
+var (
+	dutPort1 = attrs.Attributes{Desc: "dutPort1", IPv4: "192.0.2.1", IPv4Len: 30, IPv6: "2001:db8::1", IPv6Len: 126}
+	atePort1 = attrs.Attributes{Name: "port1", MAC: "02:00:01:01:01:01", IPv4: "192.0.2.2", IPv4Len: 30, IPv6: "2001:db8::2", IPv6Len: 126}
+	dutPort2 = attrs.Attributes{Desc: "dutPort2", IPv4: "192.0.2.5", IPv4Len: 30, IPv6: "2001:db8::5", IPv6Len: 126}
+	atePort2 = attrs.Attributes{Name: "port2", MAC: "02:00:02:01:01:01", IPv4: "192.0.2.6", IPv4Len: 30, IPv6: "2001:db8::6", IPv6Len: 126}
+	dutPort3 = attrs.Attributes{Desc: "dutPort3", IPv4: "192.0.2.9", IPv4Len: 30, IPv6: "2001:db8::9", IPv6Len: 126}
+	atePort3 = attrs.Attributes{Name: "port3", MAC: "02:00:03:01:01:01", IPv4: "192.0.2.10", IPv4Len: 30, IPv6: "2001:db8::a", IPv6Len: 126}
+)
+
+// source is a range of source addresses sent from ATE port-1.
+type source struct {
+	name   string
+	v6     bool
+	prefix string
+	// start is the first source address of the flow.
+	start string
+	// via is the ATE port the DUT routes prefix to, or nil if it has no
+	// route for it.
+	via *attrs.Attributes
+}
+
+var sources = []source{
+	{name: "v4-legit", prefix: "198.51.100.0/24", start: "198.51.100.1", via: &atePort1},
+	{name: "v4-asymmetric", prefix: "198.18.0.0/24", start: "198.18.0.1", via: &atePort3},
+	{name: "v4-unroutable", start: "100.64.0.1"},
+	{name: "v6-legit", v6: true, prefix: "2001:db8:100::/64", start: "2001:db8:100::1", via: &atePort1},
+	{name: "v6-asymmetric", v6: true, prefix: "2001:db8:18::/64", start: "2001:db8:18::1", via: &atePort3},
+	{name: "v6-unroutable", v6: true, start: "2001:db8:ffff::1"},
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Disabled: without uRPF, verify traffic from every source is forwarded.
+//  2. Strict: enable strict uRPF on DUT port-1 for IPv4 and IPv6.  Verify
+//     traffic from sources routed to port-1 is forwarded, and traffic from
+//     sources routed to port-3 or without a route is dropped and counted.
+//  3. Loose: enable loose uRPF on DUT port-1.  Verify traffic from sources
+//     routed to port-1 or port-3 is forwarded, and traffic from sources without
+//     a route is dropped and counted.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//	                    dut:port3 <--> ate:port3
+//
+// Test notes:
+//   - All traffic is sent from ATE port-1 to ATE port-2 with spoofed source
+//     addresses.  Sources routed to port-3 model asymmetric routing, where the
+//     return path is through a different interface than the one traffic is
+//     received on.
+//   - uRPF is not modelled in OpenConfig, so it is configured through CLI on
+//     devices with the urpf_oc_unsupported deviation.
+//   - Dropped packets are expected to be counted in the input discards of DUT
+//     port-1.
+
+// urpfCLI holds the vendor CLI to configure uRPF on an interface.  Each
+// config has a %s verb for the interface name.
+type urpfCLI struct {
+	strict string
+	loose  string
+	remove string
+}
+
+// urpfCLIs returns the uRPF CLI for dut.
+func urpfCLIs(t *testing.T, dut *ondatra.DUTDevice) urpfCLI {
+	t.Helper()
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		return urpfCLI{
+			strict: `
+interface %s
+   ip verify unicast source reachable-via rx
+   ipv6 verify unicast source reachable-via rx
+`,
+			loose: `
+interface %s
+   ip verify unicast source reachable-via any
+   ipv6 verify unicast source reachable-via any
+`,
+			remove: `
+interface %s
+   no ip verify unicast
+   no ipv6 verify unicast
+`,
+		}
+	case ondatra.CISCO:
+		return urpfCLI{
+			strict: `
+interface %s
+ ipv4 verify unicast source reachable-via rx
+ ipv6 verify unicast source reachable-via rx
+`,
+			loose: `
+interface %s
+ ipv4 verify unicast source reachable-via any
+ ipv6 verify unicast source reachable-via any
+`,
+			remove: `
+interface %s
+ no ipv4 verify unicast source reachable-via
+ no ipv6 verify unicast source reachable-via
+`,
+		}
+	default:
+		t.Fatalf("uRPF CLI is not defined for vendor %s", dut.Vendor())
+	}
+	return urpfCLI{}
+}
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for i, a := range []*attrs.Attributes{&dutPort1, &dutPort2, &dutPort3} {
+		p := dut.Port(t, fmt.Sprintf("port%d", i+1))
+		gnmi.Replace(t, dut, gnmi.OC().Interface(p.Name()).Config(), a.NewOCInterface(p.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, p)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, p.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+	fptest.ConfigureDefaultNetworkInstance(t, dut)
+
+	b := &gnmi.SetBatch{}
+	for _, s := range sources {
+		if s.via == nil {
+			continue
+		}
+		nh := s.via.IPv4
+		if s.v6 {
+			nh = s.via.IPv6
+		}
+		if _, err := cfgplugins.NewStaticRouteCfg(b, &cfgplugins.StaticRouteCfg{
+			NetworkInstance: deviations.DefaultNetworkInstance(dut),
+			Prefix:          s.prefix,
+			NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+				"0": oc.UnionString(nh),
+			},
+		}, dut); err != nil {
+			t.Fatalf("Failed to configure static route %s: %v", s.prefix, err)
+		}
+	}
+	b.Set(t, dut)
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+
+	for _, s := range sources {
+		flow := top.Flows().Add().SetName(s.name)
+		flow.Metrics().SetEnable(true)
+		flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+		if s.v6 {
+			flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv6"}).SetRxNames([]string{atePort2.Name + ".IPv6"})
+			v6 := flow.Packet().Add().Ipv6()
+			v6.Src().Increment().SetStart(s.start).SetCount(10)
+			v6.Dst().SetValue(atePort2.IPv6)
+		} else {
+			flow.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4"})
+			v4 := flow.Packet().Add().Ipv4()
+			v4.Src().Increment().SetStart(s.start).SetCount(10)
+			v4.Dst().SetValue(atePort2.IPv4)
+		}
+		flow.Rate().SetPps(pps)
+		flow.Duration().FixedPackets().SetPackets(flowPackets)
+	}
+	return top
+}
+
+// verifyTraffic sends every flow and verifies the flows in forward are
+// received, the other flows are dropped, and DUT port-1 counts the drops as
+// input discards.
+func verifyTraffic(t *testing.T, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, top gosnappi.Config, forward map[string]bool) {
+	t.Helper()
+	discards := gnmi.OC().Interface(dut.Port(t, "port1").Name()).Counters().InDiscards().State()
+	before := gnmi.Get(t, dut, discards)
+
+	ate.OTG().StartTraffic(t)
+	time.Sleep(flowPackets/pps*time.Second + 5*time.Second)
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+	var dropped uint64
+	for _, s := range sources {
+		tx, rx := otgutils.GetFlowStats(t, ate.OTG(), s.name, 10*time.Second)
+		if tx == 0 {
+			t.Errorf("Flow %s sent no packets", s.name)
+			continue
+		}
+		loss := float64(tx-rx) * 100 / float64(tx)
+		switch {
+		case forward[s.name] && loss > lossTol:
+			t.Errorf("Flow %s: got %.2f%% loss, want <= %.2f%%", s.name, loss, lossTol)
+		case !forward[s.name] && rx != 0:
+			t.Errorf("Flow %s: got %d packets received, want 0", s.name, rx)
+		case !forward[s.name]:
+			dropped += tx
+		}
+	}
+	if dropped == 0 {
+		return
+	}
+	got := gnmi.Get(t, dut, discards) - before
+	t.Logf("DUT port1 in-discards increased by %d, %d packets dropped", got, dropped)
+	if float64(got) < float64(dropped)*minDiscards {
+		t.Errorf("DUT port1 in-discards increased by %d, want at least %.0f%% of the %d dropped packets", got, minDiscards*100, dropped)
+	}
+}
+
+func TestURPF(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	if !deviations.URPFOCUnsupported(dut) {
+		t.Skip("uRPF is not modelled in OpenConfig; set the urpf_oc_unsupported deviation to configure it through CLI")
+	}
+	cli := urpfCLIs(t, dut)
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+	port1 := dut.Port(t, "port1").Name()
+
+	t.Run("Disabled", func(t *testing.T) {
+		forward := make(map[string]bool)
+		for _, s := range sources {
+			forward[s.name] = true
+		}
+		verifyTraffic(t, dut, ate, top, forward)
+	})
+
+	for _, tc := range []struct {
+		desc    string
+		config  string
+		forward map[string]bool
+	}{{
+		desc:   "Strict",
+		config: cli.strict,
+		forward: map[string]bool{
+			"v4-legit": true,
+			"v6-legit": true,
+		},
+	}, {
+		desc:   "Loose",
+		config: cli.loose,
+		forward: map[string]bool{
+			"v4-legit":      true,
+			"v4-asymmetric": true,
+			"v6-legit":      true,
+			"v6-asymmetric": true,
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(tc.config, port1))
+			defer helpers.GnmiCLIConfig(t, dut, fmt.Sprintf(cli.remove, port1))
+			verifyTraffic(t, dut, ate, top, tc.forward)
+		})
+	}
+}
@@ -0,0 +1,382 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static_route_bfd_frr_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/metrics"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var maxSwitchover = flag.Duration("max_switchover_time", time.Second, "Maximum allowed traffic loss duration when the BFD session of the primary next hop goes down.")
+
+const (
+	staticPrefix = "203.0.113.0/24"
+	trafficDst   = "203.0.113.1"
+	dataFlow     = "data"
+	bfdFlow      = "bfd"
+
+	trafficPPS     = 100000
+	settleDuration = 10 * time.Second
+
+	// The DUT detects the loss of the BFD session after bfdMultiplier
+	// intervals of bfdIntervalUs.  The ATE sends its BFD packets at
+	// bfdPPS, faster than the interval it announces.
+	bfdIntervalUs = 50000
+	bfdMultiplier = 3
+	bfdPPS        = 50
+
+	// ateDiscriminator is the BFD discriminator of the ATE session.
+	ateDiscriminator = 0x0a0b0c0d
+
+	// backupPreference is the preference, or metric with the
+	// set_metric_as_preference deviation, of the backup next hop.
+	backupPreference = 200
+)
+
+// BFD control packet fields, RFC 5880 section 4.1.
+const (
+	bfdVersion   = 1
+	bfdStateInit = 2
+	bfdLength    = 24
+	bfdPort      = 3784
+	bfdSrcPort   = 49152
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv4Len: 30,
+	}
+	dutPort3 = attrs.Attributes{
+		Desc:    "dutPort3",
+		IPv4:    "192.0.2.9",
+		IPv4Len: 30,
+	}
+	atePort3 = attrs.Attributes{
+		Name:    "atePort3",
+		MAC:     "02:00:03:01:01:01",
+		IPv4:    "192.0.2.10",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Configure a static route with a primary next hop on ATE port-2, with
+//     BFD, and a backup next hop on ATE port-3 with a higher preference.
+//  2. Bring up the BFD session of the primary next hop and verify traffic to
+//     the static route is forwarded to ATE port-2.
+//  3. Stop the BFD packets of the ATE and verify traffic is forwarded to ATE
+//     port-3, with a loss duration within --max_switchover_time.
+//  4. Send the BFD packets of the ATE again and verify traffic is forwarded to
+//     ATE port-2 again.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2 (primary)
+//	                       DUT port-3 <--> ATE port-3 (backup)
+//
+// Test notes:
+//   - The ATE does not run BFD, so the ATE side of the session is a flow of
+//     BFD control packets in the Init state, which keeps the DUT session Up
+//     once it has the discriminator of the DUT.  The discriminator is read
+//     from the BFD packets of the DUT captured on ATE port-2, and stopping
+//     the flow is the ATE failing to respond.
+//   - The switchover time is the loss duration of the data flow, which is
+//     also recorded as the static_bfd_switchover_seconds KPI.
+
+// configureDUT configures the DUT ports and the static route.
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	for _, p := range []struct {
+		id string
+		a  *attrs.Attributes
+	}{
+		{"port1", &dutPort1},
+		{"port2", &dutPort2},
+		{"port3", &dutPort3},
+	} {
+		port := dut.Port(t, p.id)
+		gnmi.Replace(t, dut, gnmi.OC().Interface(port.Name()).Config(), p.a.NewOCInterface(port.Name(), dut))
+		if deviations.ExplicitPortSpeed(dut) {
+			fptest.SetPortSpeed(t, port)
+		}
+		if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+			fptest.AssignToNetworkInstance(t, dut, port.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		}
+	}
+
+	b := &gnmi.SetBatch{}
+	cfg := &cfgplugins.StaticRouteCfg{
+		NetworkInstance: deviations.DefaultNetworkInstance(dut),
+		Prefix:          staticPrefix,
+		NextHops: map[string]oc.NetworkInstance_Protocol_Static_NextHop_NextHop_Union{
+			"primary": oc.UnionString(atePort2.IPv4),
+			"backup":  oc.UnionString(atePort3.IPv4),
+		},
+	}
+	if _, err := cfgplugins.NewStaticRouteCfg(b, cfg, dut); err != nil {
+		t.Fatalf("Failed to configure static route: %v", err)
+	}
+	static := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.StaticProtocolName(dut)).Static(staticPrefix)
+	gnmi.BatchReplace(b, static.NextHop("primary").EnableBfd().Config(), &oc.NetworkInstance_Protocol_Static_NextHop_EnableBfd{
+		Enabled:                  ygot.Bool(true),
+		DesiredMinimumTxInterval: ygot.Uint32(bfdIntervalUs),
+		RequiredMinimumReceive:   ygot.Uint32(bfdIntervalUs),
+		DetectionMultiplier:      ygot.Uint8(bfdMultiplier),
+	})
+	if deviations.SetMetricAsPreference(dut) {
+		gnmi.BatchReplace(b, static.NextHop("backup").Metric().Config(), backupPreference)
+	} else {
+		gnmi.BatchReplace(b, static.NextHop("backup").Preference().Config(), backupPreference)
+	}
+	b.Set(t, dut)
+}
+
+// configureATE returns the ATE config with the ATE ports and a capture on
+// port2.
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+	atePort3.AddToOTG(top, ate.Port(t, "port3"), &dutPort3)
+	top.Captures().Add().SetName("capture").SetPortNames([]string{"port2"}).SetFormat(gosnappi.CaptureFormat.PCAP)
+	return top
+}
+
+// bfdControl returns the hex encoded BFD control packet of the ATE session
+// in the Init state, for the DUT session with discriminator yourDisc.
+func bfdControl(yourDisc uint32) string {
+	b := make([]byte, bfdLength)
+	b[0] = bfdVersion << 5
+	b[1] = bfdStateInit << 6
+	b[2] = bfdMultiplier
+	b[3] = bfdLength
+	binary.BigEndian.PutUint32(b[4:], ateDiscriminator)
+	binary.BigEndian.PutUint32(b[8:], yourDisc)
+	binary.BigEndian.PutUint32(b[12:], bfdIntervalUs)
+	binary.BigEndian.PutUint32(b[16:], bfdIntervalUs)
+	return hex.EncodeToString(b)
+}
+
+// addFlows adds the BFD flow of the ATE, sent to dutMAC for the DUT session
+// with discriminator dutDisc, and the data flow to the static route.
+func addFlows(top gosnappi.Config, dutMAC string, dutDisc uint32) {
+	bfd := top.Flows().Add().SetName(bfdFlow)
+	bfd.TxRx().Port().SetTxName("port2")
+	eth := bfd.Packet().Add().Ethernet()
+	eth.Src().SetValue(atePort2.MAC)
+	eth.Dst().SetValue(dutMAC)
+	ip := bfd.Packet().Add().Ipv4()
+	ip.Src().SetValue(atePort2.IPv4)
+	ip.Dst().SetValue(dutPort2.IPv4)
+	ip.TimeToLive().SetValue(255)
+	udp := bfd.Packet().Add().Udp()
+	udp.SrcPort().SetValue(bfdSrcPort)
+	udp.DstPort().SetValue(bfdPort)
+	bfd.Packet().Add().Custom().SetBytes(bfdControl(dutDisc))
+	bfd.Rate().SetPps(bfdPPS)
+	bfd.Duration().Continuous()
+
+	data := top.Flows().Add().SetName(dataFlow)
+	data.Metrics().SetEnable(true)
+	data.TxRx().Device().SetTxNames([]string{atePort1.Name + ".IPv4"}).SetRxNames([]string{atePort2.Name + ".IPv4", atePort3.Name + ".IPv4"})
+	data.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := data.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValue(trafficDst)
+	data.Size().SetFixed(256)
+	data.Rate().SetPps(trafficPPS)
+	data.Duration().Continuous()
+}
+
+// setFlows starts or stops the named flows.
+func setFlows(t *testing.T, ate *ondatra.ATEDevice, state gosnappi.StateTrafficFlowTransmitStateEnum, names ...string) {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().SetState(state).SetFlowNames(names)
+	ate.OTG().SetControlState(t, cs)
+}
+
+// captureDUTBFD captures on ATE port-2 for d and returns the last BFD
+// control packet of the DUT, or nil if there was none.
+func captureDUTBFD(t *testing.T, ate *ondatra.ATEDevice, d time.Duration) *layers.BFD {
+	t.Helper()
+	otg := ate.OTG()
+	cs := gosnappi.NewControlState()
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.START)
+	otg.SetControlState(t, cs)
+	time.Sleep(d)
+	cs.Port().Capture().SetState(gosnappi.StatePortCaptureState.STOP)
+	otg.SetControlState(t, cs)
+
+	r, err := pcapgo.NewReader(bytes.NewReader(otg.GetCapture(t, gosnappi.NewCaptureRequest().SetPortName("port2"))))
+	if err != nil {
+		t.Fatalf("Failed to read port2 capture: %v", err)
+	}
+	var last *layers.BFD
+	for {
+		data, _, err := r.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return last
+		}
+		if err != nil {
+			t.Fatalf("Failed to read packet from port2 capture: %v", err)
+		}
+		pkt := gopacket.NewPacket(data, r.LinkType(), gopacket.DecodeOptions{Lazy: true, NoCopy: true})
+		ip, ok := pkt.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		if !ok || ip.SrcIP.String() != dutPort2.IPv4 {
+			continue
+		}
+		if bfd, ok := pkt.Layer(layers.LayerTypeBFD).(*layers.BFD); ok {
+			last = bfd
+		}
+	}
+}
+
+// inFrames returns the frames received on ATE port.
+func inFrames(t *testing.T, ate *ondatra.ATEDevice, port string) uint64 {
+	t.Helper()
+	return gnmi.Get(t, ate.OTG(), gnmi.OTG().Port(ate.Port(t, port).ID()).Counters().InFrames().State())
+}
+
+// verifyForwarding checks that, over settleDuration, the data flow is
+// received on ATE port want and not on ATE port notWant.
+func verifyForwarding(t *testing.T, ate *ondatra.ATEDevice, want, notWant string) {
+	t.Helper()
+	wantBefore, notWantBefore := inFrames(t, ate, want), inFrames(t, ate, notWant)
+	time.Sleep(settleDuration)
+	wantAfter, notWantAfter := inFrames(t, ate, want), inFrames(t, ate, notWant)
+	if got := wantAfter - wantBefore; got < trafficPPS {
+		t.Errorf("ATE %s in-frames: got %d more, want traffic to the static route", want, got)
+	}
+	// Allow for the BFD packets of the DUT and other control traffic.
+	if got := notWantAfter - notWantBefore; got >= trafficPPS {
+		t.Errorf("ATE %s in-frames: got %d more, want no traffic to the static route", notWant, got)
+	}
+}
+
+func TestStaticRouteBFDSwitchover(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	bfd := captureDUTBFD(t, ate, 5*time.Second)
+	if bfd == nil {
+		t.Fatalf("DUT sent no BFD packets to %s", atePort2.IPv4)
+	}
+	dutDisc := uint32(bfd.MyDiscriminator)
+	t.Logf("DUT BFD session to %s has discriminator %#x", atePort2.IPv4, dutDisc)
+
+	dutMAC := gnmi.Get(t, dut, gnmi.OC().Interface(dut.Port(t, "port2").Name()).Ethernet().MacAddress().State())
+	addFlows(top, dutMAC, dutDisc)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.START, bfdFlow)
+	defer ate.OTG().StopTraffic(t)
+	bfd = captureDUTBFD(t, ate, 5*time.Second)
+	if bfd == nil || bfd.State != layers.BFDStateUp || uint32(bfd.YourDiscriminator) != ateDiscriminator {
+		t.Fatalf("DUT BFD session to %s is not up with the ATE session: last BFD packet %+v", atePort2.IPv4, bfd)
+	}
+
+	setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.START, dataFlow)
+	t.Run("Primary", func(t *testing.T) {
+		verifyForwarding(t, ate, "port2", "port3")
+	})
+
+	t.Run("Switchover", func(t *testing.T) {
+		t.Log("Stopping the BFD packets of the ATE")
+		setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.STOP, bfdFlow)
+		verifyForwarding(t, ate, "port3", "port2")
+
+		setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.STOP, dataFlow)
+		otgutils.LogFlowMetrics(t, ate.OTG(), top)
+		// The data flow is lossless on the primary next hop, so all its loss
+		// is from the switchover.
+		tx, rx := otgutils.GetFlowStats(t, ate.OTG(), dataFlow, 20*time.Second)
+		if tx == 0 {
+			t.Fatalf("Flow %s did not transmit any packets", dataFlow)
+		}
+		if rx > tx {
+			rx = tx
+		}
+		switchover := time.Duration(float64(tx-rx) / trafficPPS * float64(time.Second))
+		t.Logf("Switchover to the backup next hop: %v (%d packets lost)", switchover, tx-rx)
+		metrics.RecordDuration(t, "static_bfd_switchover", switchover)
+		if switchover > *maxSwitchover {
+			t.Errorf("Traffic loss duration on BFD failure: got %v, want <= %v", switchover, *maxSwitchover)
+		}
+	})
+
+	t.Run("Revert", func(t *testing.T) {
+		setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.START, bfdFlow)
+		bfd := captureDUTBFD(t, ate, 5*time.Second)
+		if bfd == nil || bfd.State != layers.BFDStateUp {
+			t.Fatalf("DUT BFD session to %s did not come back up: last BFD packet %+v", atePort2.IPv4, bfd)
+		}
+		setFlows(t, ate, gosnappi.StateTrafficFlowTransmitState.START, dataFlow)
+		verifyForwarding(t, ate, "port2", "port3")
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mixed_origin_set_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	stateTimeout = time.Minute
+	// invalidCLI is rejected by every CLI parser.
+	invalidCLI = "featureprofiles-invalid-command"
+	// invalidMTU is outside the range of the OpenConfig mtu leaf.
+	invalidMTU = 70000
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Disjoint: in one SetRequest, set the description of DUT port-1 using
+//     origin "cli" and the description of DUT port-2 using OpenConfig.
+//     Verify both descriptions in OpenConfig telemetry.
+//  2. Ordering: in one SetRequest, set the description of DUT port-1 using
+//     origin "cli" followed by OpenConfig, then the reverse.  Verify the
+//     update that comes last in the request wins.
+//  3. Atomicity: in one SetRequest, send a valid OpenConfig update together
+//     with invalid CLI, then valid CLI together with an invalid OpenConfig
+//     update.  Verify the Set fails and neither change is applied.
+//
+// Topology:
+//
+//	dut:port1 <--> ate:port1
+//	dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - Updates in a SetRequest are applied in the order given in the request,
+//     regardless of their origin, and the whole SetRequest is a single
+//     transaction.
+
+// cliDescription returns the vendor CLI that sets the description of intf.
+func cliDescription(t *testing.T, dut *ondatra.DUTDevice, intf, desc string) string {
+	switch dut.Vendor() {
+	case ondatra.ARISTA, ondatra.CISCO:
+		return fmt.Sprintf("interface %s\n  description %s\n", intf, desc)
+	case ondatra.JUNIPER:
+		return fmt.Sprintf("interfaces {\n  %s {\n    description %q;\n  }\n}\n", intf, desc)
+	case ondatra.NOKIA:
+		return fmt.Sprintf("/interface %s description %s\n", intf, desc)
+	default:
+		t.Skipf("CLI description config is not defined for vendor %v", dut.Vendor())
+	}
+	return ""
+}
+
+// cliUpdate returns an update with origin "cli" carrying config.
+func cliUpdate(config string) *gpb.Update {
+	return &gpb.Update{
+		Path: &gpb.Path{Origin: "cli"},
+		Val:  &gpb.TypedValue{Value: &gpb.TypedValue_AsciiVal{AsciiVal: config}},
+	}
+}
+
+// ocUpdate returns an OpenConfig update setting leaf of interface intf to the
+// JSON encoded value.
+func ocUpdate(intf, leaf, value string) *gpb.Update {
+	return &gpb.Update{
+		Path: &gpb.Path{
+			Origin: "openconfig",
+			Elem: []*gpb.PathElem{
+				{Name: "interfaces"},
+				{Name: "interface", Key: map[string]string{"name": intf}},
+				{Name: "config"},
+				{Name: leaf},
+			},
+		},
+		Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(value)}},
+	}
+}
+
+func ocDescription(intf, desc string) *gpb.Update {
+	return ocUpdate(intf, "description", fmt.Sprintf("%q", desc))
+}
+
+func set(t *testing.T, dut *ondatra.DUTDevice, updates ...*gpb.Update) error {
+	t.Helper()
+	req := &gpb.SetRequest{Update: updates}
+	t.Logf("SetRequest:\n%s", req)
+	_, err := dut.RawAPIs().GNMI(t).Set(context.Background(), req)
+	return err
+}
+
+func awaitDescription(t *testing.T, dut *ondatra.DUTDevice, intf, want string) {
+	t.Helper()
+	gnmi.Await(t, dut, gnmi.OC().Interface(intf).Description().State(), stateTimeout, want)
+}
+
+// restoreDescriptions restores the configured description of each interface
+// when the test ends.
+func restoreDescriptions(t *testing.T, dut *ondatra.DUTDevice, intfs ...string) {
+	t.Helper()
+	for _, intf := range intfs {
+		path := gnmi.OC().Interface(intf).Description().Config()
+		orig, ok := gnmi.Lookup(t, dut, path).Val()
+		t.Cleanup(func() {
+			if ok {
+				gnmi.Replace(t, dut, path, orig)
+			} else {
+				gnmi.Delete(t, dut, path)
+			}
+		})
+	}
+}
+
+func TestMixedOriginSet(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	p1 := dut.Port(t, "port1").Name()
+	p2 := dut.Port(t, "port2").Name()
+	restoreDescriptions(t, dut, p1, p2)
+
+	t.Run("Disjoint", func(t *testing.T) {
+		if err := set(t, dut,
+			cliUpdate(cliDescription(t, dut, p1, "cli-disjoint")),
+			ocDescription(p2, "oc-disjoint"),
+		); err != nil {
+			t.Fatalf("Mixed origin Set failed: %v", err)
+		}
+		awaitDescription(t, dut, p1, "cli-disjoint")
+		awaitDescription(t, dut, p2, "oc-disjoint")
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		cases := []struct {
+			desc    string
+			updates []*gpb.Update
+			want    string
+		}{{
+			desc: "CLI then OC",
+			updates: []*gpb.Update{
+				cliUpdate(cliDescription(t, dut, p1, "cli-first")),
+				ocDescription(p1, "oc-last"),
+			},
+			want: "oc-last",
+		}, {
+			desc: "OC then CLI",
+			updates: []*gpb.Update{
+				ocDescription(p1, "oc-first"),
+				cliUpdate(cliDescription(t, dut, p1, "cli-last")),
+			},
+			want: "cli-last",
+		}}
+		for _, tc := range cases {
+			t.Run(tc.desc, func(t *testing.T) {
+				if err := set(t, dut, tc.updates...); err != nil {
+					t.Fatalf("Mixed origin Set failed: %v", err)
+				}
+				awaitDescription(t, dut, p1, tc.want)
+			})
+		}
+	})
+
+	t.Run("Atomicity", func(t *testing.T) {
+		const before = "atomic-before"
+		if err := set(t, dut, ocDescription(p1, before), ocDescription(p2, before)); err != nil {
+			t.Fatalf("Set of initial descriptions failed: %v", err)
+		}
+		awaitDescription(t, dut, p1, before)
+		awaitDescription(t, dut, p2, before)
+
+		cases := []struct {
+			desc    string
+			updates []*gpb.Update
+		}{{
+			desc: "Valid OC with invalid CLI",
+			updates: []*gpb.Update{
+				ocDescription(p1, "atomic-oc"),
+				cliUpdate(invalidCLI),
+			},
+		}, {
+			desc: "Valid CLI with invalid OC",
+			updates: []*gpb.Update{
+				cliUpdate(cliDescription(t, dut, p1, "atomic-cli")),
+				ocUpdate(p2, "mtu", fmt.Sprint(invalidMTU)),
+			},
+		}}
+		for _, tc := range cases {
+			t.Run(tc.desc, func(t *testing.T) {
+				if err := set(t, dut, tc.updates...); err == nil {
+					t.Errorf("Mixed origin Set with an invalid update succeeded, want error")
+				} else {
+					t.Logf("Mixed origin Set failed as expected: %v", err)
+				}
+				// Give a non-atomic implementation time to expose the change.
+				time.Sleep(5 * time.Second)
+				for _, intf := range []string{p1, p2} {
+					if got := gnmi.Get(t, dut, gnmi.OC().Interface(intf).Description().State()); got != before {
+						t.Errorf("Interface %s description after failed Set: got %q, want %q", intf, got, before)
+					}
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,301 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encoding_conformance_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// decimalTolerance is the allowed difference between PROTO and JSON_IETF
+// values of a decimal64 leaf, which may be sampled at different times.
+const decimalTolerance = 5.0
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. For a sampled set of leaves, Get the leaf with PROTO encoding and
+//     verify the TypedValue type matches the YANG type: uint_val for
+//     unsigned integers, double_val for decimal64, string_val for enums,
+//     identityrefs and strings, bool_val for booleans.
+//  2. Get the same leaves with JSON_IETF encoding and verify the value is
+//     encoded per RFC 7951: 64-bit integers and decimal64 as JSON strings,
+//     smaller integers as JSON numbers, identityrefs with a module prefix.
+//  3. Verify the PROTO and JSON_IETF values are consistent.
+//
+// Topology:
+//
+//	DUT port-1
+//
+// Test notes:
+//   - Counters may increase between the two Get requests, so the JSON_IETF
+//     value of a counter must be greater than or equal to the PROTO value.
+//   - The PROTO cases are skipped if the DUT does not advertise PROTO
+//     encoding in its capabilities.
+
+type kind int
+
+const (
+	kindUint64 kind = iota
+	kindUint
+	kindDecimal
+	kindCounter
+	kindEnum
+	kindIdentity
+	kindBool
+	kindString
+)
+
+func (k kind) String() string {
+	return [...]string{"uint64", "uint", "decimal64", "counter64", "enumeration", "identityref", "boolean", "string"}[k]
+}
+
+type leaf struct {
+	path *gpb.Path
+	kind kind
+}
+
+func mustPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ygot.StringToStructuredPath(s)
+	if err != nil {
+		t.Fatalf("Invalid path %q: %v", s, err)
+	}
+	p.Origin = "openconfig"
+	return p
+}
+
+// sampledLeaves returns the leaves checked by the test.
+func sampledLeaves(t *testing.T, dut *ondatra.DUTDevice) []leaf {
+	t.Helper()
+	intf := dut.Port(t, "port1").Name()
+	leaves := []leaf{
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/counters/in-octets", intf)), kindCounter},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/counters/out-pkts", intf)), kindCounter},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/mtu", intf)), kindUint},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/ifindex", intf)), kindUint},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/oper-status", intf)), kindEnum},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/type", intf)), kindIdentity},
+		{mustPath(t, fmt.Sprintf("/interfaces/interface[name=%s]/state/enabled", intf)), kindBool},
+		{mustPath(t, "/system/state/hostname"), kindString},
+		{mustPath(t, "/system/state/boot-time"), kindUint64},
+	}
+	for _, v := range gnmi.LookupAll(t, dut, gnmi.OC().ComponentAny().Temperature().Instant().State()) {
+		if _, ok := v.Val(); ok {
+			leaves = append(leaves, leaf{v.Path, kindDecimal})
+			break
+		}
+	}
+	return leaves
+}
+
+func get(t *testing.T, c gpb.GNMIClient, p *gpb.Path, enc gpb.Encoding) *gpb.TypedValue {
+	t.Helper()
+	resp, err := c.Get(context.Background(), &gpb.GetRequest{
+		Path:     []*gpb.Path{p},
+		Type:     gpb.GetRequest_STATE,
+		Encoding: enc,
+	})
+	if err != nil {
+		t.Fatalf("Get with %v encoding failed: %v", enc, err)
+	}
+	for _, n := range resp.GetNotification() {
+		for _, u := range n.GetUpdate() {
+			return u.GetVal()
+		}
+	}
+	t.Fatalf("Get with %v encoding returned no update: %s", enc, prototext.Format(resp))
+	return nil
+}
+
+// protoValue checks tv has the TypedValue type expected for k and returns
+// the value.
+func protoValue(k kind, tv *gpb.TypedValue) (any, error) {
+	switch k {
+	case kindUint64, kindUint, kindCounter:
+		v, ok := tv.GetValue().(*gpb.TypedValue_UintVal)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want uint_val", tv.GetValue())
+		}
+		return v.UintVal, nil
+	case kindDecimal:
+		switch v := tv.GetValue().(type) {
+		case *gpb.TypedValue_DoubleVal:
+			return v.DoubleVal, nil
+		case *gpb.TypedValue_DecimalVal:
+			return nil, fmt.Errorf("got deprecated decimal_val %v, want double_val", v.DecimalVal)
+		}
+		return nil, fmt.Errorf("got %T, want double_val", tv.GetValue())
+	case kindEnum, kindIdentity, kindString:
+		v, ok := tv.GetValue().(*gpb.TypedValue_StringVal)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want string_val", tv.GetValue())
+		}
+		return v.StringVal, nil
+	case kindBool:
+		v, ok := tv.GetValue().(*gpb.TypedValue_BoolVal)
+		if !ok {
+			return nil, fmt.Errorf("got %T, want bool_val", tv.GetValue())
+		}
+		return v.BoolVal, nil
+	}
+	return nil, fmt.Errorf("unknown kind %v", k)
+}
+
+// jsonValue checks tv is JSON_IETF encoded as expected for k by RFC 7951 and
+// returns the value.  A value wrapped in a single-key object is unwrapped.
+func jsonValue(k kind, tv *gpb.TypedValue) (any, error) {
+	b := tv.GetJsonIetfVal()
+	if b == nil {
+		return nil, fmt.Errorf("got %T, want json_ietf_val", tv.GetValue())
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON %q: %v", b, err)
+	}
+	if m, ok := v.(map[string]any); ok && len(m) == 1 {
+		for _, inner := range m {
+			v = inner
+		}
+	}
+	switch k {
+	case kindUint64, kindCounter:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("got JSON %T %v, want string", v, v)
+		}
+		return strconv.ParseUint(s, 10, 64)
+	case kindDecimal:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("got JSON %T %v, want string", v, v)
+		}
+		return strconv.ParseFloat(s, 64)
+	case kindUint:
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("got JSON %T %v, want number", v, v)
+		}
+		return uint64(f), nil
+	case kindIdentity:
+		s, ok := v.(string)
+		if !ok || !strings.Contains(s, ":") {
+			return nil, fmt.Errorf("got JSON %T %v, want string with module prefix", v, v)
+		}
+		return s, nil
+	case kindEnum, kindString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("got JSON %T %v, want string", v, v)
+		}
+		return s, nil
+	case kindBool:
+		bv, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("got JSON %T %v, want boolean", v, v)
+		}
+		return bv, nil
+	}
+	return nil, fmt.Errorf("unknown kind %v", k)
+}
+
+// consistent returns an error if the PROTO value p and JSON_IETF value j of
+// a leaf of kind k disagree.
+func consistent(k kind, p, j any) error {
+	switch k {
+	case kindCounter:
+		if j.(uint64) < p.(uint64) {
+			return fmt.Errorf("JSON_IETF counter %v is less than earlier PROTO value %v", j, p)
+		}
+		return nil
+	case kindDecimal:
+		if math.Abs(j.(float64)-p.(float64)) > decimalTolerance {
+			return fmt.Errorf("JSON_IETF %v and PROTO %v differ by more than %v", j, p, decimalTolerance)
+		}
+		return nil
+	case kindIdentity:
+		// PROTO identityrefs may or may not carry the module prefix.
+		js, ps := j.(string), p.(string)
+		if js != ps && js[strings.Index(js, ":")+1:] != ps[strings.Index(ps, ":")+1:] {
+			return fmt.Errorf("JSON_IETF %q and PROTO %q differ", js, ps)
+		}
+		return nil
+	}
+	if p != j {
+		return fmt.Errorf("JSON_IETF %v and PROTO %v differ", j, p)
+	}
+	return nil
+}
+
+func supportsProto(t *testing.T, c gpb.GNMIClient) bool {
+	t.Helper()
+	resp, err := c.Capabilities(context.Background(), &gpb.CapabilityRequest{})
+	if err != nil {
+		t.Fatalf("gNMI Capabilities failed: %v", err)
+	}
+	for _, e := range resp.GetSupportedEncodings() {
+		if e == gpb.Encoding_PROTO {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEncodingConformance(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNMI(t)
+	proto := supportsProto(t, c)
+
+	for _, l := range sampledLeaves(t, dut) {
+		name, err := ygot.PathToString(l.path)
+		if err != nil {
+			t.Fatalf("Invalid path %v: %v", l.path, err)
+		}
+		t.Run(name, func(t *testing.T) {
+			var pv any
+			if proto {
+				var err error
+				if pv, err = protoValue(l.kind, get(t, c, l.path, gpb.Encoding_PROTO)); err != nil {
+					t.Errorf("PROTO encoding of %v leaf: %v", l.kind, err)
+				}
+			}
+			jv, err := jsonValue(l.kind, get(t, c, l.path, gpb.Encoding_JSON_IETF))
+			if err != nil {
+				t.Errorf("JSON_IETF encoding of %v leaf: %v", l.kind, err)
+			}
+			if pv == nil || jv == nil {
+				return
+			}
+			if err := consistent(l.kind, pv, jv); err != nil {
+				t.Errorf("%v leaf: %v", l.kind, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package large_get_response_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var maxMsgSize = flag.Int("max_msg_size", 256<<20, "The maximum size in bytes of a gNMI message the client accepts for the root Get requests.")
+
+const (
+	// defaultMsgSize is the default maximum size of a message received by a
+	// gRPC client.
+	defaultMsgSize = 4 << 20
+	// lowMsgSize is the maximum message size for the RESOURCE_EXHAUSTED
+	// cases, much smaller than the state of any DUT.
+	lowMsgSize = 16 << 10
+	rpcTimeout = 5 * time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Get the root with type CONFIG and type STATE, with the maximum
+//     message size of the client increased to --max_msg_size.  Verify each
+//     Get succeeds with a non-empty response, and report its size.
+//  2. Subscribe ONCE to the root with the default maximum message size of
+//     4 MiB.  Verify the DUT chunks the root over SubscribeResponses within
+//     the limit and sends sync_response.
+//  3. Get the root with type STATE with the maximum message size of the
+//     client lowered to 16 KiB, and verify the RPC fails with
+//     RESOURCE_EXHAUSTED.  Verify a Get of a small path succeeds on the same
+//     connection afterwards.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - A GetResponse cannot be split, so a DUT whose root is larger than the
+//     maximum message size of the client can only be read in full by Get
+//     with a larger limit, or chunked by Subscribe.
+//   - The maximum message sizes are set per call, so they override the
+//     defaults of the connection.
+
+// rootGet returns a Get request of the root with typ.
+func rootGet(typ gpb.GetRequest_DataType) *gpb.GetRequest {
+	return &gpb.GetRequest{
+		Prefix:   &gpb.Path{Origin: "openconfig"},
+		Path:     []*gpb.Path{{}},
+		Type:     typ,
+		Encoding: gpb.Encoding_JSON_IETF,
+	}
+}
+
+// countUpdates returns the number of updates in notifications.
+func countUpdates(notifications []*gpb.Notification) int {
+	n := 0
+	for _, notif := range notifications {
+		n += len(notif.GetUpdate())
+	}
+	return n
+}
+
+func TestLargeGetResponse(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := dut.RawAPIs().GNMI(t)
+
+	var stateSize int
+	for _, typ := range []gpb.GetRequest_DataType{gpb.GetRequest_CONFIG, gpb.GetRequest_STATE} {
+		t.Run("Get root "+typ.String(), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+			defer cancel()
+			start := time.Now()
+			resp, err := c.Get(ctx, rootGet(typ), grpc.MaxCallRecvMsgSize(*maxMsgSize))
+			if err != nil {
+				t.Fatalf("Get of root %v with maximum message size %d failed: %v", typ, *maxMsgSize, err)
+			}
+			size := proto.Size(resp)
+			t.Logf("Get of root %v returned %d bytes in %d notifications with %d updates in %v", typ, size, len(resp.GetNotification()), countUpdates(resp.GetNotification()), time.Since(start))
+			if countUpdates(resp.GetNotification()) == 0 {
+				t.Errorf("Get of root %v returned no updates", typ)
+			}
+			if size > defaultMsgSize {
+				t.Logf("Get of root %v is larger than the default maximum message size %d, and needs the increased limit", typ, defaultMsgSize)
+			}
+			if typ == gpb.GetRequest_STATE {
+				stateSize = size
+			}
+		})
+	}
+
+	t.Run("Subscribe ONCE root", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		defer cancel()
+		sub, err := c.Subscribe(ctx, grpc.MaxCallRecvMsgSize(defaultMsgSize))
+		if err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+		req := &gpb.SubscribeRequest{
+			Request: &gpb.SubscribeRequest_Subscribe{
+				Subscribe: &gpb.SubscriptionList{
+					Prefix:       &gpb.Path{Origin: "openconfig"},
+					Subscription: []*gpb.Subscription{{Path: &gpb.Path{}}},
+					Mode:         gpb.SubscriptionList_ONCE,
+					Encoding:     gpb.Encoding_PROTO,
+				},
+			},
+		}
+		if err := sub.Send(req); err != nil {
+			t.Fatalf("Send(%v) failed: %v", req, err)
+		}
+		var responses, updates, largest int
+		for {
+			resp, err := sub.Recv()
+			if err != nil {
+				t.Fatalf("Subscribe ONCE to root failed after %d responses with maximum message size %d: %v", responses, defaultMsgSize, err)
+			}
+			if resp.GetSyncResponse() {
+				break
+			}
+			responses++
+			updates += len(resp.GetUpdate().GetUpdate())
+			largest = max(largest, proto.Size(resp))
+		}
+		t.Logf("Subscribe ONCE to root returned %d updates in %d responses, the largest of %d bytes", updates, responses, largest)
+		if updates == 0 {
+			t.Error("Subscribe ONCE to root returned no updates")
+		}
+	})
+
+	t.Run("Get root STATE with low limit", func(t *testing.T) {
+		if stateSize != 0 && stateSize <= lowMsgSize {
+			t.Skipf("Root STATE of %d bytes is not larger than %d bytes", stateSize, lowMsgSize)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+		defer cancel()
+		_, err := c.Get(ctx, rootGet(gpb.GetRequest_STATE), grpc.MaxCallRecvMsgSize(lowMsgSize))
+		if got, want := status.Code(err), codes.ResourceExhausted; got != want {
+			t.Errorf("Get of root STATE with maximum message size %d: got code %v, want %v; error: %v", lowMsgSize, got, want, err)
+		}
+
+		small := &gpb.GetRequest{
+			Path: []*gpb.Path{{
+				Origin: "openconfig",
+				Elem:   []*gpb.PathElem{{Name: "system"}, {Name: "state"}, {Name: "hostname"}},
+			}},
+			Type:     gpb.GetRequest_STATE,
+			Encoding: gpb.Encoding_JSON_IETF,
+		}
+		if _, err := c.Get(ctx, small, grpc.MaxCallRecvMsgSize(lowMsgSize)); err != nil {
+			t.Errorf("Get of /system/state/hostname after RESOURCE_EXHAUSTED failed: %v", err)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp_parity_test
+
+import (
+	"flag"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/snmp"
+	"github.com/openconfig/ondatra"
+)
+
+var (
+	snmpAddr      = flag.String("snmp_addr", "", "The host:port of the SNMP agent of the DUT. If empty, port 161 of the DUT name is used.")
+	snmpCommunity = flag.String("snmp_community", "", "The SNMPv2c community of the DUT. The test is skipped if it is empty, since the SNMP agent is configured outside of the test.")
+	// upTimeTolerance allows for the SNMP agent of the DUT starting after
+	// the system.
+	upTimeTolerance = flag.Duration("snmp_uptime_tolerance", 5*time.Minute, "The allowed difference between the SNMP sysUpTime and the gNMI up-time of the DUT.")
+)
+
+// octetsTolerance is the allowed difference between the SNMP and gNMI
+// in-octets, for DUTs that update the counters of each protocol at
+// different intervals.  It is about 10 seconds of control plane traffic.
+const octetsTolerance = 100000
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Verify the SNMP sysUpTime of the DUT matches its gNMI
+//     /system/state/up-time.
+//  2. For DUT port-1 and port-2, verify the SNMP ifHCInOctets at the
+//     ifIndex of the port matches its gNMI in-octets.
+//
+// Topology:
+//
+//	DUT port-1 <------> port-1 ATE
+//	DUT port-2 <------> port-2 ATE
+//
+// Test notes:
+//   - Each SNMP value is read between two gNMI reads, and must be between
+//     them within a tolerance, since the values change while they are read.
+//   - OpenConfig has no SNMP model, so the SNMP agent of the DUT must be
+//     configured with an SNMPv2c community before the test.
+
+func TestSNMPParity(t *testing.T) {
+	if *snmpCommunity == "" {
+		t.Skip("No --snmp_community, so the SNMP agent of the DUT is not configured")
+	}
+	dut := ondatra.DUT(t, "dut")
+	addr := *snmpAddr
+	if addr == "" {
+		addr = net.JoinHostPort(dut.Name(), "161")
+	}
+	c := &snmp.Client{Addr: addr, Community: *snmpCommunity, Retries: 2}
+
+	t.Run("sysUpTime", func(t *testing.T) {
+		snmp.CompareUpTime(t, dut, c, *upTimeTolerance)
+	})
+	for _, id := range []string{"port1", "port2"} {
+		intf := dut.Port(t, id).Name()
+		t.Run("ifHCInOctets "+id, func(t *testing.T) {
+			snmp.CompareInOctets(t, dut, c, intf, octetsTolerance)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delete_semantics_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/cfgplugins"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+const (
+	isisName    = "DEFAULT"
+	dutAreaAddr = "49.0001"
+	dutSysID    = "1920.0000.2001"
+	ateAreaAddr = "490001"
+	ateSysID    = "640000000001"
+	vrfName     = "DELETE-VRF"
+	vrfRoute    = "198.51.100.0/24"
+	// stateTimeout is how long the DUT may take to reflect a delete or
+	// re-add in its state.
+	stateTimeout = 2 * time.Minute
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Subinterface: delete subinterface 0 of DUT port-2, and verify its IPv4
+//     address is removed, and the BGP session and IS-IS adjacency over it go
+//     down.
+//  2. BGP neighbor: delete the BGP neighbor ATE port-2, and verify its state
+//     is removed and the ATE session goes down.
+//  3. Network instance: delete a non-default network instance holding DUT
+//     port-1 and a static route, and verify its state and AFT are removed.
+//
+// After each delete, the deleted subtree is re-added, and the state is
+// verified to recover without a reboot.
+//
+// Topology:
+//
+//	ate:port1 <--> port1:dut:port2 <--> ate:port2
+//
+// Test notes:
+//   - DUT port-1 is in DELETE-VRF, and DUT port-2 in the default network
+//     instance with an eBGP session and a level 2 IS-IS adjacency to ATE
+//     port-2.
+
+// configureISIS adds IS-IS on DUT port-2 and ATE port-2.
+func configureISIS(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	dut := bs.DUT
+	isis := bs.DUTConf.GetOrCreateNetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).GetOrCreateIsis()
+	g := isis.GetOrCreateGlobal()
+	if deviations.ISISInstanceEnabledRequired(dut) {
+		g.Instance = ygot.String(isisName)
+	}
+	g.LevelCapability = oc.Isis_LevelType_LEVEL_2
+	g.Net = []string{fmt.Sprintf("%s.%s.00", dutAreaAddr, dutSysID)}
+	g.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	isis.GetOrCreateLevel(2).MetricStyle = oc.Isis_MetricStyle_WIDE_METRIC
+
+	intf := isis.GetOrCreateInterface(isisInterface(t, bs))
+	intf.Enabled = ygot.Bool(true)
+	intf.CircuitType = oc.Isis_CircuitType_POINT_TO_POINT
+	intf.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST).Enabled = ygot.Bool(true)
+	if deviations.ISISInterfaceAfiUnsupported(dut) {
+		intf.Af = nil
+	}
+	lvl := intf.GetOrCreateLevel(2)
+	lvl.Enabled = ygot.Bool(true)
+	af := lvl.GetOrCreateAf(oc.IsisTypes_AFI_TYPE_IPV4, oc.IsisTypes_SAFI_TYPE_UNICAST)
+	af.Metric = ygot.Uint32(10)
+	af.Enabled = ygot.Bool(true)
+	if deviations.MissingIsisInterfaceAfiSafiEnable(dut) {
+		af.Enabled = nil
+	}
+
+	dev := bs.ATEIntfs[1]
+	ateISIS := dev.Isis().SetSystemId(ateSysID).SetName(dev.Name() + ".ISIS")
+	ateISIS.Basic().SetHostname(ateISIS.Name())
+	ateISIS.Advanced().SetAreaAddresses([]string{ateAreaAddr})
+	ateIntf := ateISIS.Interfaces().Add().
+		SetEthName(dev.Ethernets().Items()[0].Name()).SetName(dev.Name() + ".ISISIntf").
+		SetNetworkType(gosnappi.IsisInterfaceNetworkType.POINT_TO_POINT).
+		SetLevelType(gosnappi.IsisInterfaceLevelType.LEVEL_2).
+		SetMetric(10)
+	ateIntf.Advanced().SetAutoAdjustMtu(true).SetAutoAdjustArea(true).SetAutoAdjustSupportedProtocols(true)
+}
+
+func isisInterface(t *testing.T, bs *cfgplugins.BGPSession) string {
+	name := bs.DUT.Port(t, "port2").Name()
+	if deviations.ExplicitInterfaceInDefaultVRF(bs.DUT) {
+		name += ".0"
+	}
+	return name
+}
+
+// vrf returns DELETE-VRF with DUT port-1 and a static route to ATE port-1.
+func vrf(t *testing.T, bs *cfgplugins.BGPSession) *oc.NetworkInstance {
+	t.Helper()
+	p1 := bs.DUT.Port(t, "port1").Name()
+	ni := &oc.NetworkInstance{
+		Name: ygot.String(vrfName),
+		Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+	}
+	ni.GetOrCreateInterface(p1).Interface = ygot.String(p1)
+	ni.GetInterface(p1).Subinterface = ygot.Uint32(0)
+	static := ni.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.StaticProtocolName(bs.DUT))
+	static.GetOrCreateStatic(vrfRoute).GetOrCreateNextHop("0").NextHop = oc.UnionString(bs.ATEPorts[0].IPv4)
+	return ni
+}
+
+// configureVRF moves DUT port-1 from the default network instance to
+// DELETE-VRF.
+func configureVRF(t *testing.T, bs *cfgplugins.BGPSession) {
+	t.Helper()
+	p1 := bs.DUT.Port(t, "port1").Name()
+	if deviations.ExplicitInterfaceInDefaultVRF(bs.DUT) {
+		gnmi.Delete(t, bs.DUT, gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).Interface(p1+".0").Config())
+	}
+	gnmi.Replace(t, bs.DUT, gnmi.OC().NetworkInstance(vrfName).Config(), vrf(t, bs))
+}
+
+// await waits for the state query q to satisfy pred, and reports an error
+// with desc if it does not.
+func await[T any](t *testing.T, bs *cfgplugins.BGPSession, q ygnmi.SingletonQuery[T], desc string, pred func(*ygnmi.Value[T]) bool) {
+	t.Helper()
+	if got, ok := gnmi.Watch(t, bs.DUT, q, stateTimeout, pred).Await(t); !ok {
+		t.Errorf("%s: got %v after %v", desc, got, stateTimeout)
+	}
+}
+
+func bgpNeighbor(bs *cfgplugins.BGPSession) *netinstbgp.NetworkInstance_Protocol_Bgp_NeighborPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(cfgplugins.PTBGP, "BGP").Bgp().Neighbor(bs.ATEPorts[1].IPv4)
+}
+
+// awaitBGP waits for the BGP session with ATE port-2 to be ESTABLISHED, if
+// up is true, or not.
+func awaitBGP(t *testing.T, bs *cfgplugins.BGPSession, up bool) {
+	t.Helper()
+	await(t, bs, bgpNeighbor(bs).SessionState().State(), fmt.Sprintf("BGP session with %s, want ESTABLISHED %v", bs.ATEPorts[1].IPv4, up), func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		s, present := v.Val()
+		return (present && s == oc.Bgp_Neighbor_SessionState_ESTABLISHED) == up
+	})
+}
+
+// awaitISIS waits for the IS-IS adjacency on DUT port-2 to be UP, if up is
+// true, or not.
+func awaitISIS(t *testing.T, bs *cfgplugins.BGPSession, up bool) {
+	t.Helper()
+	adj := gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(bs.DUT)).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, isisName).Isis().Interface(isisInterface(t, bs)).Level(2).AdjacencyAny()
+	if got, ok := gnmi.WatchAll(t, bs.DUT, adj.AdjacencyState().State(), stateTimeout, func(v *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+		s, present := v.Val()
+		return (present && s == oc.Isis_IsisInterfaceAdjState_UP) == up
+	}).Await(t); !ok {
+		t.Errorf("IS-IS adjacency on %s, want UP %v: got %v after %v", isisInterface(t, bs), up, got, stateTimeout)
+	}
+}
+
+// awaitATEBGP waits for the ATE port-2 BGP session to be ESTABLISHED, if up
+// is true, or not.
+func awaitATEBGP(t *testing.T, bs *cfgplugins.BGPSession, up bool) {
+	t.Helper()
+	peer := bs.ATEIntfs[1].Bgp().Ipv4Interfaces().Items()[0].Peers().Items()[0].Name()
+	if got, ok := gnmi.Watch(t, bs.ATE.OTG(), gnmi.OTG().BgpPeer(peer).SessionState().State(), stateTimeout, func(v *ygnmi.Value[otgtelemetry.E_BgpPeer_SessionState]) bool {
+		s, present := v.Val()
+		return (present && s == otgtelemetry.BgpPeer_SessionState_ESTABLISHED) == up
+	}).Await(t); !ok {
+		t.Errorf("ATE BGP peer %s, want ESTABLISHED %v: got %v after %v", peer, up, got, stateTimeout)
+	}
+}
+
+func TestDeleteSemantics(t *testing.T) {
+	bs := cfgplugins.NewBGPSession(t, cfgplugins.PortCount2, nil)
+	bs.WithEBGP(t, []oc.E_BgpTypes_AFI_SAFI_TYPE{oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST}, []string{"port2"}, false, false)
+	configureISIS(t, bs)
+	if err := bs.PushAndStart(t); err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	configureVRF(t, bs)
+	defer gnmi.Delete(t, bs.DUT, gnmi.OC().NetworkInstance(vrfName).Config())
+
+	dut := bs.DUT
+	p2 := dut.Port(t, "port2").Name()
+	dni := deviations.DefaultNetworkInstance(dut)
+	subintf := gnmi.OC().Interface(p2).Subinterface(0)
+	vrfAFT := gnmi.OC().NetworkInstance(vrfName).Afts().Ipv4Entry(vrfRoute)
+
+	// verifyUp verifies the state of all subtrees is present and up.
+	verifyUp := func(t *testing.T) {
+		t.Helper()
+		await(t, bs, subintf.Ipv4().Address(bs.DUTPorts[1].IPv4).Ip().State(), "Subinterface address, want present", func(v *ygnmi.Value[string]) bool {
+			return v.IsPresent()
+		})
+		awaitBGP(t, bs, true)
+		awaitATEBGP(t, bs, true)
+		awaitISIS(t, bs, true)
+		await(t, bs, gnmi.OC().NetworkInstance(vrfName).Type().State(), vrfName+" type, want present", func(v *ygnmi.Value[oc.E_NetworkInstanceTypes_NETWORK_INSTANCE_TYPE]) bool {
+			return v.IsPresent()
+		})
+		await(t, bs, vrfAFT.State(), vrfRoute+" in "+vrfName+" AFT, want present", func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+			return v.IsPresent()
+		})
+	}
+	verifyUp(t)
+	if t.Failed() {
+		t.Fatal("State is not up before the deletes")
+	}
+
+	cases := []struct {
+		desc string
+		// del deletes the subtree.
+		del func(t *testing.T)
+		// removed verifies the state of the subtree and the state depending
+		// on it is removed.
+		removed func(t *testing.T)
+		// restore re-adds the subtree.
+		restore func(t *testing.T)
+	}{{
+		desc: "Subinterface",
+		del: func(t *testing.T) {
+			gnmi.Delete(t, dut, subintf.Config())
+		},
+		removed: func(t *testing.T) {
+			await(t, bs, subintf.Ipv4().Address(bs.DUTPorts[1].IPv4).Ip().State(), "Subinterface address, want absent", func(v *ygnmi.Value[string]) bool {
+				return !v.IsPresent()
+			})
+			awaitBGP(t, bs, false)
+			awaitISIS(t, bs, false)
+		},
+		restore: func(t *testing.T) {
+			gnmi.Replace(t, dut, gnmi.OC().Interface(p2).Config(), bs.DUTConf.GetInterface(p2))
+			if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+				fptest.AssignToNetworkInstance(t, dut, p2, dni, 0)
+			}
+		},
+	}, {
+		desc: "BGP neighbor",
+		del: func(t *testing.T) {
+			gnmi.Delete(t, dut, bgpNeighbor(bs).Config())
+		},
+		removed: func(t *testing.T) {
+			await(t, bs, bgpNeighbor(bs).NeighborAddress().State(), "BGP neighbor, want absent", func(v *ygnmi.Value[string]) bool {
+				return !v.IsPresent()
+			})
+			awaitATEBGP(t, bs, false)
+		},
+		restore: func(t *testing.T) {
+			nbr := bs.DUTConf.GetNetworkInstance(dni).GetProtocol(cfgplugins.PTBGP, "BGP").GetBgp().GetNeighbor(bs.ATEPorts[1].IPv4)
+			gnmi.Replace(t, dut, bgpNeighbor(bs).Config(), nbr)
+		},
+	}, {
+		desc: "Network instance",
+		del: func(t *testing.T) {
+			gnmi.Delete(t, dut, gnmi.OC().NetworkInstance(vrfName).Config())
+		},
+		removed: func(t *testing.T) {
+			await(t, bs, gnmi.OC().NetworkInstance(vrfName).Type().State(), vrfName+" type, want absent", func(v *ygnmi.Value[oc.E_NetworkInstanceTypes_NETWORK_INSTANCE_TYPE]) bool {
+				return !v.IsPresent()
+			})
+			await(t, bs, vrfAFT.State(), vrfRoute+" in "+vrfName+" AFT, want absent", func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+				return !v.IsPresent()
+			})
+		},
+		restore: func(t *testing.T) {
+			gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrfName).Config(), vrf(t, bs))
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Logf("Deleting %s", tc.desc)
+			tc.del(t)
+			tc.removed(t)
+			t.Logf("Re-adding %s", tc.desc)
+			tc.restore(t)
+			verifyUp(t)
+		})
+	}
+}
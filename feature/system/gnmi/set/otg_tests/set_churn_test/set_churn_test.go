@@ -0,0 +1,241 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package set_churn_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	churnDuration   = flag.Duration("churn_duration", 30*time.Minute, "How long to keep applying config changes.")
+	churnInterval   = flag.Duration("churn_interval", time.Second, "Interval between gNMI Set requests.")
+	maxSetLatency   = flag.Duration("max_set_latency", 5*time.Second, "Maximum allowed 99th percentile gNMI Set latency.")
+	maxLatencyDrift = flag.Float64("max_set_latency_drift", 2.0, "Maximum allowed ratio of the median Set latency in the last window to the first window.")
+)
+
+const (
+	trafficPPS    = 10000
+	flowName      = "churnFlow"
+	prefixSetName = "CHURN-PREFIX-SET"
+	// driftWindow is the fraction of Set requests at each end of the run
+	// compared to check that commit latency does not drift.
+	driftWindow = 0.1
+)
+
+var (
+	dutPort1 = attrs.Attributes{
+		Desc:    "dutPort1",
+		IPv4:    "192.0.2.1",
+		IPv4Len: 30,
+	}
+	atePort1 = attrs.Attributes{
+		Name:    "atePort1",
+		IPv4:    "192.0.2.2",
+		MAC:     "02:00:01:01:01:01",
+		IPv4Len: 30,
+	}
+	dutPort2 = attrs.Attributes{
+		Desc:    "dutPort2",
+		IPv4:    "192.0.2.5",
+		IPv4Len: 30,
+	}
+	atePort2 = attrs.Attributes{
+		Name:    "atePort2",
+		IPv4:    "192.0.2.6",
+		MAC:     "02:00:02:01:01:01",
+		IPv4Len: 30,
+	}
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Start traffic from ATE port-1 to ATE port-2 through the DUT.
+//  2. Apply one small config change per --churn_interval for --churn_duration,
+//     alternating between interface descriptions and a routing-policy
+//     prefix-set that is not referenced by any policy.
+//  3. Verify no gNMI Set failed, the 99th percentile Set latency is within
+//     --max_set_latency and the median latency at the end of the run is
+//     within --max_set_latency_drift of the start of the run.
+//  4. Stop traffic and verify there is no packet loss.
+//
+// Topology:
+//
+//	ATE port-1 <--> port-1 DUT port-2 <--> ATE port-2
+
+func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	p1 := dut.Port(t, "port1")
+	p2 := dut.Port(t, "port2")
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, gnmi.OC().Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.ExplicitPortSpeed(dut) {
+		fptest.SetPortSpeed(t, p1)
+		fptest.SetPortSpeed(t, p2)
+	}
+	if deviations.ExplicitInterfaceInDefaultVRF(dut) {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.DefaultNetworkInstance(dut), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.DefaultNetworkInstance(dut), 0)
+	}
+}
+
+func configureATE(t *testing.T, ate *ondatra.ATEDevice) gosnappi.Config {
+	t.Helper()
+	top := gosnappi.NewConfig()
+	atePort1.AddToOTG(top, ate.Port(t, "port1"), &dutPort1)
+	atePort2.AddToOTG(top, ate.Port(t, "port2"), &dutPort2)
+
+	flow := top.Flows().Add().SetName(flowName)
+	flow.Metrics().SetEnable(true)
+	flow.TxRx().Device().
+		SetTxNames([]string{atePort1.Name + ".IPv4"}).
+		SetRxNames([]string{atePort2.Name + ".IPv4"})
+	flow.Size().SetFixed(512)
+	flow.Rate().SetPps(trafficPPS)
+	flow.Packet().Add().Ethernet().Src().SetValue(atePort1.MAC)
+	v4 := flow.Packet().Add().Ipv4()
+	v4.Src().SetValue(atePort1.IPv4)
+	v4.Dst().SetValue(atePort2.IPv4)
+	return top
+}
+
+// churnSet returns a function that applies the i-th config change using c.
+func churnSet(c *ygnmi.Client, ports []*ondatra.Port) func(ctx context.Context, i int) error {
+	return func(ctx context.Context, i int) error {
+		if i%2 == 0 {
+			p := ports[(i/2)%len(ports)]
+			_, err := ygnmi.Replace(ctx, c, gnmi.OC().Interface(p.Name()).Description().Config(), fmt.Sprintf("churn-%d", i))
+			return err
+		}
+		ps := &oc.RoutingPolicy_DefinedSets_PrefixSet{
+			Name: ygot.String(prefixSetName),
+			Mode: oc.PrefixSet_Mode_IPV4,
+		}
+		ps.GetOrCreatePrefix(fmt.Sprintf("198.18.%d.0/24", (i/2)%256), "exact")
+		_, err := ygnmi.Replace(ctx, c, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName).Config(), ps)
+		return err
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(p*float64(len(sorted)-1))]
+}
+
+// median returns the median of durations without modifying it.
+func median(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return percentile(sorted, 0.5)
+}
+
+func TestSetChurnDuringTraffic(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	ate := ondatra.ATE(t, "ate")
+	configureDUT(t, dut)
+	top := configureATE(t, ate)
+	ate.OTG().PushConfig(t, top)
+	ate.OTG().StartProtocols(t)
+	otgutils.AwaitNeighborResolution(t, ate.OTG(), top, 2*time.Minute)
+
+	c, err := ygnmi.NewClient(dut.RawAPIs().GNMI(t), ygnmi.WithTarget(dut.ID()))
+	if err != nil {
+		t.Fatalf("Unable to create ygnmi client: %v", err)
+	}
+	set := churnSet(c, []*ondatra.Port{dut.Port(t, "port1"), dut.Port(t, "port2")})
+	defer gnmi.Delete(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(prefixSetName).Config())
+
+	ate.OTG().StartTraffic(t)
+	t.Logf("Applying config changes every %v for %v", *churnInterval, *churnDuration)
+
+	var latencies []time.Duration
+	var setErrs int
+	ticker := time.NewTicker(*churnInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(*churnDuration)
+	for i := 0; time.Now().Before(deadline); i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), *maxSetLatency*2)
+		start := time.Now()
+		err := set(ctx, i)
+		cancel()
+		if err != nil {
+			setErrs++
+			t.Logf("gNMI Set %d failed: %v", i, err)
+		} else {
+			latencies = append(latencies, time.Since(start))
+		}
+		if i%600 == 0 {
+			t.Logf("Applied %d config changes, %d failed", i+1, setErrs)
+		}
+		<-ticker.C
+	}
+
+	ate.OTG().StopTraffic(t)
+	otgutils.LogFlowMetrics(t, ate.OTG(), top)
+
+	t.Run("Set errors", func(t *testing.T) {
+		if setErrs != 0 {
+			t.Errorf("gNMI Set failures: got %d, want 0", setErrs)
+		}
+	})
+	t.Run("Set latency", func(t *testing.T) {
+		if len(latencies) == 0 {
+			t.Fatalf("No successful gNMI Set requests")
+		}
+		sorted := append([]time.Duration(nil), latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		p50, p99 := percentile(sorted, 0.5), percentile(sorted, 0.99)
+		t.Logf("gNMI Set latency over %d requests: p50 %v, p99 %v, max %v", len(sorted), p50, p99, sorted[len(sorted)-1])
+		if p99 > *maxSetLatency {
+			t.Errorf("gNMI Set p99 latency: got %v, want <= %v", p99, *maxSetLatency)
+		}
+
+		n := int(float64(len(latencies)) * driftWindow)
+		if n == 0 {
+			t.Skipf("Not enough Set requests to check latency drift: got %d", len(latencies))
+		}
+		first, last := median(latencies[:n]), median(latencies[len(latencies)-n:])
+		t.Logf("Median gNMI Set latency: first %d requests %v, last %d requests %v", n, first, n, last)
+		if float64(last) > float64(first)**maxLatencyDrift {
+			t.Errorf("gNMI Set latency drifted: median got %v at end of run, want <= %.1f x %v", last, *maxLatencyDrift, first)
+		}
+	})
+	t.Run("Traffic loss", func(t *testing.T) {
+		if loss := otgutils.GetFlowLossPct(t, ate.OTG(), flowName, 20*time.Second); loss > 0 {
+			t.Errorf("Traffic loss for flow %s: got %.4f%%, want 0%%", flowName, loss)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnmi_set_concurrency_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	clientCount    = flag.Int("clients", 4, "Number of concurrent gNMI clients.")
+	setsPerClient  = flag.Int("sets_per_client", 25, "Number of SetRequests each client sends.")
+	largeSetSize   = flag.Int("large_set_prefixes", 5000, "Number of prefixes in the long-running SetRequest.")
+	sampleInterval = flag.Duration("sample_interval", time.Second, "SAMPLE interval of the subscription kept open during the long-running SetRequest.")
+	maxSampleGap   = flag.Duration("max_sample_gap", 5*time.Second, "Maximum gap between samples received during the long-running SetRequest.")
+)
+
+const (
+	prefixSetPrefix = "CONCURRENT-SET-"
+	sharedSet       = prefixSetPrefix + "SHARED"
+	largeSet        = prefixSetPrefix + "LARGE"
+	setTimeout      = 2 * time.Minute
+)
+
+// conflictCodes are the status codes a SetRequest may fail with when it
+// conflicts with a concurrent SetRequest.
+var conflictCodes = map[codes.Code]bool{
+	codes.Aborted:            true,
+	codes.Unavailable:        true,
+	codes.ResourceExhausted:  true,
+	codes.FailedPrecondition: true,
+}
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Disjoint: --clients gNMI clients each replace their own routing-policy
+//     prefix-set --sets_per_client times concurrently.  Verify every
+//     SetRequest succeeds, and every prefix-set has the content of the last
+//     SetRequest of its client.
+//  2. Overlapping: the clients replace the same prefix-set concurrently, each
+//     with prefixes that identify the client and request.  Verify every
+//     failed SetRequest has a conflict status code, at least one succeeds,
+//     and the prefix-set has exactly the content of one successful
+//     SetRequest.
+//  3. SubscribeDuringSet: keep a SAMPLE subscription to
+//     /system/state/current-datetime open while a single SetRequest replaces
+//     a prefix-set with --large_set_prefixes prefixes.  Verify no gap between
+//     samples exceeds --max_sample_gap.
+//
+// Topology:
+//
+//	dut
+//
+// Test notes:
+//   - Prefix-sets are used because they can be replaced with arbitrary
+//     content without affecting forwarding, and are not referenced by any
+//     policy.
+//   - A prefix-set mixing the prefixes of two SetRequests means the DUT did
+//     not apply them atomically.
+
+// prefixes returns n prefixes that identify client c and request r.
+func prefixes(c, r, n int) []string {
+	var ps []string
+	for i := 0; i < n; i++ {
+		ps = append(ps, fmt.Sprintf("10.%d.%d.%d/32", c, r, i))
+	}
+	return ps
+}
+
+// largePrefixes returns n distinct prefixes.
+func largePrefixes(n int) []string {
+	var ps []string
+	for i := 0; i < n; i++ {
+		ps = append(ps, fmt.Sprintf("198.18.%d.%d/32", i/256%256, i%256))
+	}
+	return ps
+}
+
+func prefixSet(dut *ondatra.DUTDevice, name string, ps []string) *oc.RoutingPolicy_DefinedSets_PrefixSet {
+	s := &oc.RoutingPolicy_DefinedSets_PrefixSet{Name: ygot.String(name)}
+	if !deviations.SkipPrefixSetMode(dut) {
+		s.Mode = oc.PrefixSet_Mode_IPV4
+	}
+	for _, p := range ps {
+		s.GetOrCreatePrefix(p, "exact")
+	}
+	return s
+}
+
+func replacePrefixSet(ctx context.Context, c *ygnmi.Client, dut *ondatra.DUTDevice, name string, ps []string) error {
+	ctx, cancel := context.WithTimeout(ctx, setTimeout)
+	defer cancel()
+	_, err := ygnmi.Replace(ctx, c, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(name).Config(), prefixSet(dut, name, ps))
+	return err
+}
+
+// configuredPrefixes returns the sorted prefixes of the prefix-set name.
+func configuredPrefixes(t *testing.T, dut *ondatra.DUTDevice, name string) []string {
+	t.Helper()
+	s := gnmi.Get(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(name).Config())
+	var ps []string
+	for k := range s.Prefix {
+		ps = append(ps, k.IpPrefix)
+	}
+	sort.Strings(ps)
+	return ps
+}
+
+// newClients dials n gNMI clients to dut, each with its own connection.
+func newClients(t *testing.T, dut *ondatra.DUTDevice, n int) []*ygnmi.Client {
+	t.Helper()
+	var cs []*ygnmi.Client
+	for i := 0; i < n; i++ {
+		g, err := dut.RawAPIs().BindingDUT().DialGNMI(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to dial gNMI client %d: %v", i, err)
+		}
+		c, err := ygnmi.NewClient(g, ygnmi.WithTarget(dut.ID()))
+		if err != nil {
+			t.Fatalf("Unable to create ygnmi client %d: %v", i, err)
+		}
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+// result is the outcome of SetRequest r of client c.
+type result struct {
+	c, r int
+	err  error
+}
+
+// setConcurrently runs *setsPerClient SetRequests on every client
+// concurrently, and returns the result of every request.
+func setConcurrently(clients []*ygnmi.Client, set func(ctx context.Context, cl *ygnmi.Client, c, r int) error) []result {
+	var (
+		mu      sync.Mutex
+		results []result
+		wg      sync.WaitGroup
+	)
+	for i, cl := range clients {
+		wg.Add(1)
+		go func(c int, cl *ygnmi.Client) {
+			defer wg.Done()
+			for r := 0; r < *setsPerClient; r++ {
+				err := set(context.Background(), cl, c, r)
+				mu.Lock()
+				results = append(results, result{c: c, r: r, err: err})
+				mu.Unlock()
+			}
+		}(i, cl)
+	}
+	wg.Wait()
+	return results
+}
+
+func TestConcurrentSet(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	clients := newClients(t, dut, *clientCount)
+	defer func() {
+		for i := range clients {
+			gnmi.Delete(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(fmt.Sprintf("%s%d", prefixSetPrefix, i)).Config())
+		}
+		gnmi.Delete(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(sharedSet).Config())
+	}()
+
+	t.Run("Disjoint", func(t *testing.T) {
+		results := setConcurrently(clients, func(ctx context.Context, cl *ygnmi.Client, c, r int) error {
+			return replacePrefixSet(ctx, cl, dut, fmt.Sprintf("%s%d", prefixSetPrefix, c), prefixes(c, r, 4))
+		})
+		for _, res := range results {
+			if res.err != nil {
+				t.Errorf("Client %d SetRequest %d to its own prefix-set failed: %v", res.c, res.r, res.err)
+			}
+		}
+		for c := range clients {
+			name := fmt.Sprintf("%s%d", prefixSetPrefix, c)
+			if diff := cmp.Diff(prefixes(c, *setsPerClient-1, 4), configuredPrefixes(t, dut, name)); diff != "" {
+				t.Errorf("Prefix-set %s is not the last one set by client %d, -want,+got:\n%s", name, c, diff)
+			}
+		}
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		results := setConcurrently(clients, func(ctx context.Context, cl *ygnmi.Client, c, r int) error {
+			return replacePrefixSet(ctx, cl, dut, sharedSet, prefixes(c, r, 4))
+		})
+		written := make(map[string]bool)
+		for _, res := range results {
+			if res.err == nil {
+				written[fmt.Sprint(prefixes(res.c, res.r, 4))] = true
+				continue
+			}
+			if code := status.Code(res.err); !conflictCodes[code] {
+				t.Errorf("Client %d SetRequest %d to the shared prefix-set failed with %v, want one of %v: %v", res.c, res.r, code, conflictCodes, res.err)
+			}
+		}
+		t.Logf("%d of %d SetRequests to the shared prefix-set succeeded", len(written), len(results))
+		if len(written) == 0 {
+			t.Fatalf("No SetRequest to the shared prefix-set succeeded")
+		}
+		if got := configuredPrefixes(t, dut, sharedSet); !written[fmt.Sprint(got)] {
+			t.Errorf("Prefix-set %s has %v, want the content of one successful SetRequest", sharedSet, got)
+		}
+	})
+}
+
+func TestSubscribeDuringSet(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	c := newClients(t, dut, 1)[0]
+	defer gnmi.Delete(t, dut, gnmi.OC().RoutingPolicy().DefinedSets().PrefixSet(largeSet).Config())
+
+	opts := dut.GNMIOpts().WithYGNMIOpts(ygnmi.WithSubscriptionMode(gpb.SubscriptionMode_SAMPLE), ygnmi.WithSampleInterval(*sampleInterval))
+	samples := gnmi.Collect(t, opts, gnmi.OC().System().CurrentDatetime().State(), setTimeout+2**maxSampleGap)
+	// Let the subscription deliver samples before the SetRequest starts.
+	time.Sleep(*maxSampleGap)
+
+	start := time.Now()
+	err := replacePrefixSet(context.Background(), c, dut, largeSet, largePrefixes(*largeSetSize))
+	end := time.Now()
+	if err != nil {
+		t.Fatalf("SetRequest with %d prefixes failed: %v", *largeSetSize, err)
+	}
+	t.Logf("SetRequest with %d prefixes took %v", *largeSetSize, end.Sub(start))
+	time.Sleep(*maxSampleGap)
+
+	var recv []time.Time
+	for _, v := range samples.Await(t) {
+		recv = append(recv, v.RecvTimestamp)
+	}
+	sort.Slice(recv, func(i, j int) bool { return recv[i].Before(recv[j]) })
+	// Check the gaps between samples received from before the SetRequest
+	// started until after it completed.
+	last := time.Time{}
+	var maxGap time.Duration
+	for _, r := range recv {
+		if r.Before(start.Add(-*maxSampleGap)) {
+			last = r
+			continue
+		}
+		if !last.IsZero() && r.Sub(last) > maxGap {
+			maxGap = r.Sub(last)
+		}
+		last = r
+		if r.After(end) {
+			break
+		}
+	}
+	t.Logf("Longest gap between samples during the SetRequest: %v", maxGap)
+	if last.Before(end) {
+		t.Errorf("No sample received after the SetRequest completed at %v, last sample at %v", end, last)
+	}
+	if maxGap > *maxSampleGap {
+		t.Errorf("Longest gap between samples during the SetRequest: got %v, want <= %v", maxGap, *maxSampleGap)
+	}
+}
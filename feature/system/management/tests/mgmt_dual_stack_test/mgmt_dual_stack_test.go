@@ -0,0 +1,231 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt_dual_stack_test
+
+import (
+	"context"
+	"crypto/x509"
+	"flag"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/mgmtvrf"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var (
+	mgmtInterface = flag.String("mgmt_interface", "", "DUT management interface with an IPv4 and an IPv6 address. The test is skipped when unset.")
+	mgmtIPv4Host  = flag.String("mgmt_ipv4_host", "", "IPv4 address of --mgmt_interface. The test is skipped when unset.")
+	mgmtIPv6Host  = flag.String("mgmt_ipv6_host", "", "IPv6 address of --mgmt_interface. The test is skipped when unset.")
+	mgmtHostname  = flag.String("mgmt_hostname", "", "DUT hostname resolving to both --mgmt_ipv4_host and --mgmt_ipv6_host, to check address family fallback. Skipped when unset.")
+	caCert        = flag.String("ca_cert", "", "PEM file of the CA that issued the gRPC server certificates of the DUT. Certificate checks are skipped when unset.")
+	removedFamily = flag.String("removed_family", "ipv6", "Address family, ipv4 or ipv6, whose address is removed from --mgmt_interface. The Ondatra binding must reach the DUT over the other family.")
+	grpcPort      = flag.Int("grpc_port", 9339, "Port the DUT serves gNMI and gNOI on.")
+	gribiPort     = flag.Int("gribi_port", 9340, "Port the DUT serves gRIBI on.")
+)
+
+const (
+	probeTimeout = 10 * time.Second
+	// settleTime is how long to wait after changing the management addresses
+	// before probing the services.
+	settleTime = 30 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Verify --mgmt_interface reports --mgmt_ipv4_host and --mgmt_ipv6_host
+//     as its addresses.
+//  2. Verify gNMI, gNOI and gRIBI answer on both addresses.
+//  3. With --ca_cert, verify the gNMI and gRIBI server certificates chain to
+//     the CA and are valid for both addresses, which requires an IP address
+//     SAN for each of them.
+//  4. Remove the --removed_family address from --mgmt_interface.  Verify the
+//     services stop answering on the removed address and keep answering on
+//     the address of the other family and, with --mgmt_hostname, on the
+//     hostname.  Restore the address and verify the services answer on it
+//     again.
+//
+// Topology:
+//
+//	DUT
+//
+// Test notes:
+//   - The test host needs IPv4 and IPv6 connectivity to the management
+//     interface of the DUT.
+//   - The Ondatra binding must not reach the DUT over --removed_family,
+//     otherwise the test loses its own gNMI connection when the address is
+//     removed.
+
+// addrs returns the address of each gRPC management service on host.
+func addrs(host string) map[mgmtvrf.Service]string {
+	grpcAddr := net.JoinHostPort(host, strconv.Itoa(*grpcPort))
+	return map[mgmtvrf.Service]string{
+		mgmtvrf.GNMI:  grpcAddr,
+		mgmtvrf.GNOI:  grpcAddr,
+		mgmtvrf.GRIBI: net.JoinHostPort(host, strconv.Itoa(*gribiPort)),
+	}
+}
+
+// mgmtAddress is an address of the management interface.
+type mgmtAddress struct {
+	family string
+	ip     string
+}
+
+// prefixLength returns the prefix length of a, which fails the test if a is
+// not an address of the management interface.
+func (a mgmtAddress) prefixLength(t *testing.T, dut *ondatra.DUTDevice) uint8 {
+	t.Helper()
+	sub := gnmi.OC().Interface(*mgmtInterface).Subinterface(0)
+	if a.family == "ipv4" {
+		return gnmi.Get(t, dut, sub.Ipv4().Address(a.ip).State()).GetPrefixLength()
+	}
+	return gnmi.Get(t, dut, sub.Ipv6().Address(a.ip).State()).GetPrefixLength()
+}
+
+// remove removes a from the management interface.
+func (a mgmtAddress) remove(t *testing.T, dut *ondatra.DUTDevice) {
+	t.Helper()
+	sub := gnmi.OC().Interface(*mgmtInterface).Subinterface(0)
+	if a.family == "ipv4" {
+		gnmi.Delete(t, dut, sub.Ipv4().Address(a.ip).Config())
+		return
+	}
+	gnmi.Delete(t, dut, sub.Ipv6().Address(a.ip).Config())
+}
+
+// restore configures a on the management interface with prefix length pl.
+func (a mgmtAddress) restore(t *testing.T, dut *ondatra.DUTDevice, pl uint8) {
+	t.Helper()
+	sub := gnmi.OC().Interface(*mgmtInterface).Subinterface(0)
+	if a.family == "ipv4" {
+		gnmi.Replace(t, dut, sub.Ipv4().Address(a.ip).Config(), &oc.Interface_Subinterface_Ipv4_Address{
+			Ip:           ygot.String(a.ip),
+			PrefixLength: ygot.Uint8(pl),
+		})
+		return
+	}
+	gnmi.Replace(t, dut, sub.Ipv6().Address(a.ip).Config(), &oc.Interface_Subinterface_Ipv6_Address{
+		Ip:           ygot.String(a.ip),
+		PrefixLength: ygot.Uint8(pl),
+	})
+}
+
+func loadCACert(t *testing.T) *x509.CertPool {
+	t.Helper()
+	b, err := os.ReadFile(*caCert)
+	if err != nil {
+		t.Fatalf("Cannot read --ca_cert: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(b) {
+		t.Fatalf("No PEM certificates in --ca_cert %s", *caCert)
+	}
+	return roots
+}
+
+func TestDualStackManagement(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	if *mgmtInterface == "" || *mgmtIPv4Host == "" || *mgmtIPv6Host == "" {
+		t.Skip("Flags --mgmt_interface, --mgmt_ipv4_host and --mgmt_ipv6_host must be set")
+	}
+	v4, v6 := mgmtAddress{"ipv4", *mgmtIPv4Host}, mgmtAddress{"ipv6", *mgmtIPv6Host}
+	if ip := net.ParseIP(v4.ip); ip == nil || ip.To4() == nil {
+		t.Fatalf("--mgmt_ipv4_host %q is not an IPv4 address", v4.ip)
+	}
+	if ip := net.ParseIP(v6.ip); ip == nil || ip.To4() != nil {
+		t.Fatalf("--mgmt_ipv6_host %q is not an IPv6 address", v6.ip)
+	}
+	removed, kept := v6, v4
+	switch *removedFamily {
+	case "ipv6":
+	case "ipv4":
+		removed, kept = v4, v6
+	default:
+		t.Fatalf("--removed_family %q, want ipv4 or ipv6", *removedFamily)
+	}
+
+	var pl uint8
+	t.Run("Management addresses", func(t *testing.T) {
+		for _, a := range []mgmtAddress{v4, v6} {
+			got := a.prefixLength(t, dut)
+			t.Logf("Interface %s %s address: %s/%d", *mgmtInterface, a.family, a.ip, got)
+			if a == removed {
+				pl = got
+			}
+		}
+	})
+	if pl == 0 {
+		t.Fatalf("Cannot find prefix length of %s address %s on %s", removed.family, removed.ip, *mgmtInterface)
+	}
+
+	for _, a := range []mgmtAddress{v4, v6} {
+		t.Run("Reachable over "+a.family, func(t *testing.T) {
+			mgmtvrf.VerifyReachable(t, addrs(a.ip), true, probeTimeout)
+		})
+	}
+
+	t.Run("Certificate SANs", func(t *testing.T) {
+		if *caCert == "" {
+			t.Skip("Flag --ca_cert must be set")
+		}
+		roots := loadCACert(t)
+		for _, a := range []mgmtAddress{v4, v6} {
+			for _, svc := range []mgmtvrf.Service{mgmtvrf.GNMI, mgmtvrf.GRIBI} {
+				addr := addrs(a.ip)[svc]
+				ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+				err := mgmtvrf.VerifyCertificate(ctx, addr, roots)
+				cancel()
+				if err != nil {
+					t.Errorf("%s at %s: %v", svc, addr, err)
+				}
+			}
+		}
+	})
+
+	t.Run("Fallback with "+removed.family+" address removed", func(t *testing.T) {
+		removed.remove(t, dut)
+		restored := false
+		defer func() {
+			if !restored {
+				removed.restore(t, dut, pl)
+			}
+		}()
+		time.Sleep(settleTime)
+
+		mgmtvrf.VerifyReachable(t, addrs(removed.ip), false, probeTimeout)
+		mgmtvrf.VerifyReachable(t, addrs(kept.ip), true, probeTimeout)
+		if *mgmtHostname != "" {
+			mgmtvrf.VerifyReachable(t, addrs(*mgmtHostname), true, probeTimeout)
+		} else {
+			t.Log("Flag --mgmt_hostname is not set, not checking hostname fallback")
+		}
+
+		removed.restore(t, dut, pl)
+		restored = true
+		time.Sleep(settleTime)
+		mgmtvrf.VerifyReachable(t, addrs(removed.ip), true, probeTimeout)
+	})
+}
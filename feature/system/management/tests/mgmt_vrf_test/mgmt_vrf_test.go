@@ -0,0 +1,119 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmt_vrf_test
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/helpers"
+	"github.com/openconfig/featureprofiles/internal/mgmtvrf"
+	"github.com/openconfig/ondatra"
+)
+
+var (
+	mgmtVRF        = flag.String("mgmt_vrf", "mgmt", "Name of the management VRF to create on the DUT.")
+	mgmtInterface  = flag.String("mgmt_interface", "", "DUT management interface to move into the management VRF. The test is skipped when unset.")
+	mgmtVRFHost    = flag.String("mgmt_vrf_host", "", "DUT address in the management VRF as reachable from the test host. The test is skipped when unset.")
+	defaultVRFHost = flag.String("default_vrf_host", "", "DUT address in the default VRF as reachable from the test host. The test is skipped when unset.")
+	grpcPort       = flag.Int("grpc_port", 9339, "Port the DUT serves gNMI and gNOI on.")
+	sshPort        = flag.Int("ssh_port", 22, "Port the DUT serves SSH on.")
+)
+
+const (
+	probeTimeout = 10 * time.Second
+	// settleTime is how long to wait after moving services into the
+	// management VRF before probing them.
+	settleTime = 30 * time.Second
+)
+
+func TestMain(m *testing.M) {
+	fptest.RunTests(m)
+}
+
+// Test cases:
+//  1. Move the management interface into --mgmt_vrf and bind the gRPC
+//     servers and SSH server to it.
+//  2. Verify the gRPC servers report --mgmt_vrf as their network instance.
+//  3. Verify gNMI, gNOI and SSH answer on --mgmt_vrf_host.
+//  4. Verify gNMI, gNOI and SSH do not answer on --default_vrf_host.
+//
+// Topology:
+//
+//	DUT
+//
+// Test notes:
+//   - The Ondatra binding must reach the DUT through --mgmt_vrf_host,
+//     otherwise the test loses its own gNMI connection once the gRPC servers
+//     move into the management VRF.
+//   - There is no OpenConfig leaf to bind the SSH server to a network
+//     instance yet, so SSH is configured using CLI.
+
+// sshVRFConfig returns the vendor CLI that binds the SSH server to vrf and
+// the CLI that removes the binding.
+func sshVRFConfig(t *testing.T, dut *ondatra.DUTDevice, vrf string) (string, string) {
+	switch dut.Vendor() {
+	case ondatra.ARISTA:
+		return fmt.Sprintf(`
+management ssh
+   vrf %s
+      no shutdown
+`, vrf), fmt.Sprintf(`
+management ssh
+   no vrf %s
+`, vrf)
+	default:
+		t.Skipf("SSH VRF configuration is not defined for vendor %v", dut.Vendor())
+	}
+	return "", ""
+}
+
+// addrs returns the address of each management service on host.
+func addrs(host string) map[mgmtvrf.Service]string {
+	grpcAddr := net.JoinHostPort(host, strconv.Itoa(*grpcPort))
+	return map[mgmtvrf.Service]string{
+		mgmtvrf.GNMI: grpcAddr,
+		mgmtvrf.GNOI: grpcAddr,
+		mgmtvrf.SSH:  net.JoinHostPort(host, strconv.Itoa(*sshPort)),
+	}
+}
+
+func TestManagementVRFIsolation(t *testing.T) {
+	dut := ondatra.DUT(t, "dut")
+	if *mgmtInterface == "" || *mgmtVRFHost == "" || *defaultVRFHost == "" {
+		t.Skip("Flags --mgmt_interface, --mgmt_vrf_host and --default_vrf_host must be set")
+	}
+
+	sshConfig, sshCleanup := sshVRFConfig(t, dut, *mgmtVRF)
+	mgmtvrf.Configure(t, dut, *mgmtVRF, *mgmtInterface)
+	helpers.GnmiCLIConfig(t, dut, sshConfig)
+	defer helpers.GnmiCLIConfig(t, dut, sshCleanup)
+	time.Sleep(settleTime)
+
+	t.Run("gRPC server state", func(t *testing.T) {
+		mgmtvrf.VerifyGRPCServers(t, dut, *mgmtVRF)
+	})
+	t.Run("Reachable in management VRF", func(t *testing.T) {
+		mgmtvrf.VerifyReachable(t, addrs(*mgmtVRFHost), true, probeTimeout)
+	})
+	t.Run("Unreachable in default VRF", func(t *testing.T) {
+		mgmtvrf.VerifyReachable(t, addrs(*defaultVRFHost), false, probeTimeout)
+	})
+}
@@ -55,4 +55,6 @@ var (
 	V4TunnelNHGSplitCount = flag.Int("arg_v4_tunnel_nhg_split_count", 2, "In gRIBI scaling tests, the number of next-hop per next-hop-group for the v4 tunnels.")
 	EgressNHGSplitCount   = flag.Int("arg_egress_nhg_split_count", 16, "In gRIBI scaling tests, the number of next-hop per next-hop-group for the egress traffic.")
 	V4ReEncapNHGCount     = flag.Int("arg_v4_re_encap_nhg_count", 256, "In gRIBI scaling tests, the number of next-hop-groups for re-encapping v4 tunnels.")
+
+	IPv6Only = flag.Bool("arg_ipv6_only", false, "Configure only IPv6 addresses on the DUT and ATE interfaces, and run the control plane and traffic over IPv6, in tests that support an IPv6-only underlay.")
 )
@@ -0,0 +1,137 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package args
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Timing holds the time allowed for disruptive operations to complete on the
+// device under test.
+type Timing struct {
+	// LinecardBoot is the time allowed for a linecard to boot after a reboot.
+	LinecardBoot time.Duration
+	// FabricBoot is the time allowed for a fabric card to boot after a reboot.
+	FabricBoot time.Duration
+	// Switchover is the time allowed for a controller card switchover to
+	// complete.
+	Switchover time.Duration
+	// ChassisReboot is the time allowed for the whole device to come back
+	// after a reboot.
+	ChassisReboot time.Duration
+	// PollInterval is the interval between polls while waiting for the device
+	// to recover.
+	PollInterval time.Duration
+}
+
+// timingProfiles are the named profiles selectable with -arg_timing_profile.
+var timingProfiles = map[string]Timing{
+	"fast": {
+		LinecardBoot:  5 * time.Minute,
+		FabricBoot:    5 * time.Minute,
+		Switchover:    5 * time.Minute,
+		ChassisReboot: 10 * time.Minute,
+		PollInterval:  10 * time.Second,
+	},
+	"default": {
+		LinecardBoot:  10 * time.Minute,
+		FabricBoot:    10 * time.Minute,
+		Switchover:    15 * time.Minute,
+		ChassisReboot: 15 * time.Minute,
+		PollInterval:  30 * time.Second,
+	},
+	"slow": {
+		LinecardBoot:  20 * time.Minute,
+		FabricBoot:    20 * time.Minute,
+		Switchover:    30 * time.Minute,
+		ChassisReboot: 30 * time.Minute,
+		PollInterval:  30 * time.Second,
+	},
+}
+
+// timingProfile is a flag.Value accepting only the names in timingProfiles.
+type timingProfile string
+
+func (p *timingProfile) String() string { return string(*p) }
+
+func (p *timingProfile) Set(s string) error {
+	if _, ok := timingProfiles[s]; !ok {
+		names := make([]string, 0, len(timingProfiles))
+		for name := range timingProfiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown timing profile %q, want one of %s", s, strings.Join(names, ", "))
+	}
+	*p = timingProfile(s)
+	return nil
+}
+
+// Timing flags.
+var (
+	profile = timingProfile("default")
+
+	linecardBootTimeout  = flag.Duration("arg_linecard_boot_timeout", 0, "Time allowed for a linecard to boot after a reboot. Overrides the value from -arg_timing_profile when non-zero.")
+	fabricBootTimeout    = flag.Duration("arg_fabric_boot_timeout", 0, "Time allowed for a fabric card to boot after a reboot. Overrides the value from -arg_timing_profile when non-zero.")
+	switchoverTimeout    = flag.Duration("arg_switchover_timeout", 0, "Time allowed for a controller card switchover to complete. Overrides the value from -arg_timing_profile when non-zero.")
+	chassisRebootTimeout = flag.Duration("arg_chassis_reboot_timeout", 0, "Time allowed for the device to come back after a chassis reboot. Overrides the value from -arg_timing_profile when non-zero.")
+	pollInterval         = flag.Duration("arg_poll_interval", 0, "Interval between polls while waiting for the device to recover from a disruptive operation. Overrides the value from -arg_timing_profile when non-zero.")
+)
+
+func init() {
+	flag.Var(&profile, "arg_timing_profile", "Timing profile for disruptive tests: fast, default or slow. Slow platforms should use slow to avoid flaky timeouts, fast platforms may use fast to fail sooner.")
+}
+
+// TimingProfile returns the timings of the selected profile with any explicit
+// timeout flags applied.
+func TimingProfile() Timing {
+	tm := timingProfiles[string(profile)]
+	for _, o := range []struct {
+		dst *time.Duration
+		val time.Duration
+	}{
+		{&tm.LinecardBoot, *linecardBootTimeout},
+		{&tm.FabricBoot, *fabricBootTimeout},
+		{&tm.Switchover, *switchoverTimeout},
+		{&tm.ChassisReboot, *chassisRebootTimeout},
+		{&tm.PollInterval, *pollInterval},
+	} {
+		if o.val > 0 {
+			*o.dst = o.val
+		}
+	}
+	return tm
+}
+
+// LinecardBootTimeout returns the time allowed for a linecard to boot.
+func LinecardBootTimeout() time.Duration { return TimingProfile().LinecardBoot }
+
+// FabricBootTimeout returns the time allowed for a fabric card to boot.
+func FabricBootTimeout() time.Duration { return TimingProfile().FabricBoot }
+
+// SwitchoverTimeout returns the time allowed for a controller card switchover.
+func SwitchoverTimeout() time.Duration { return TimingProfile().Switchover }
+
+// ChassisRebootTimeout returns the time allowed for the device to come back
+// after a chassis reboot.
+func ChassisRebootTimeout() time.Duration { return TimingProfile().ChassisReboot }
+
+// PollInterval returns the interval between polls while waiting for the device
+// to recover.
+func PollInterval() time.Duration { return TimingProfile().PollInterval }
@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package args
+
+import (
+	"flag"
+	"testing"
+	"time"
+)
+
+func setFlags(t *testing.T, kv map[string]string) {
+	t.Helper()
+	for k, v := range kv {
+		f := flag.Lookup(k)
+		orig := f.Value.String()
+		if err := f.Value.Set(v); err != nil {
+			t.Fatalf("Set(%q, %q) failed: %v", k, v, err)
+		}
+		t.Cleanup(func() { f.Value.Set(orig) })
+	}
+}
+
+func TestTimingProfile(t *testing.T) {
+	tests := []struct {
+		desc  string
+		flags map[string]string
+		want  Timing
+	}{{
+		desc: "default",
+		want: timingProfiles["default"],
+	}, {
+		desc:  "slow",
+		flags: map[string]string{"arg_timing_profile": "slow"},
+		want:  timingProfiles["slow"],
+	}, {
+		desc: "override",
+		flags: map[string]string{
+			"arg_timing_profile":        "fast",
+			"arg_linecard_boot_timeout": "7m",
+			"arg_switchover_timeout":    "12m",
+		},
+		want: Timing{
+			LinecardBoot:  7 * time.Minute,
+			FabricBoot:    timingProfiles["fast"].FabricBoot,
+			Switchover:    12 * time.Minute,
+			ChassisReboot: timingProfiles["fast"].ChassisReboot,
+			PollInterval:  timingProfiles["fast"].PollInterval,
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			setFlags(t, tc.flags)
+			if got := TimingProfile(); got != tc.want {
+				t.Errorf("TimingProfile() got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimingProfileUnknown(t *testing.T) {
+	if err := flag.Lookup("arg_timing_profile").Value.Set("glacial"); err == nil {
+		t.Errorf("Set(arg_timing_profile, glacial) succeeded, want error")
+	}
+	if got, want := string(profile), "default"; got != want {
+		t.Errorf("Timing profile after invalid Set: got %q, want %q", got, want)
+	}
+}
@@ -59,6 +59,15 @@ func (a *Attributes) IPv6CIDR() string {
 	return fmt.Sprintf("%s/%d", a.IPv6, a.IPv6Len)
 }
 
+// IPv6Only returns a copy of these attributes without the IPv4 addresses,
+// for an interface of an IPv6-only underlay.
+func (a *Attributes) IPv6Only() *Attributes {
+	c := *a
+	c.IPv4, c.IPv4Len = "", 0
+	c.IPv4Sec, c.IPv4LenSec = "", 0
+	return &c
+}
+
 // ConfigOCInterface configures an OpenConfig interface with these attributes.
 func (a *Attributes) ConfigOCInterface(intf *oc.Interface, dut *ondatra.DUTDevice) *oc.Interface {
 	if a.Desc != "" {
@@ -0,0 +1,219 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package availability measures the availability of the gNMI management
+// plane of a DUT while a disruptive operation, such as a reboot, a
+// switchover or a process restart, runs.
+//
+// A test starts a Monitor before the disruption and stops it once the DUT
+// has recovered:
+//
+//	m := availability.Start(t, dut)
+//	defer m.Stop(t)
+//
+// The Monitor sends a gNMI Get of /system/state/current-datetime every
+// --gnmi_availability_interval.  Stop logs the report of the requests, writes
+// it as gnmi_availability.json to the artifact directory of the test, and
+// records the availability as a KPI with the metrics package.  If
+// --gnmi_availability_slo is set, Stop fails the test if the availability is
+// below it.
+package availability
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/featureprofiles/internal/metrics"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+var (
+	interval = flag.Duration("gnmi_availability_interval", time.Second,
+		"interval between the gNMI requests that measure the availability of the DUT management plane")
+	timeout = flag.Duration("gnmi_availability_timeout", 5*time.Second,
+		"time after which a gNMI request that measures the availability of the DUT management plane fails")
+	slo = flag.Float64("gnmi_availability_slo", 0,
+		"minimum percentage of gNMI requests that must succeed while a Monitor runs, or 0 to only report the availability")
+)
+
+// reportFile is the name of the report in the artifact directory of a test.
+const reportFile = "gnmi_availability.json"
+
+// Sample is the result of a gNMI request of a Monitor.
+type Sample struct {
+	Start   time.Time
+	Latency time.Duration
+	Err     error
+}
+
+// Report summarizes the samples of a Monitor.
+type Report struct {
+	Target   string    `json:"target"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Requests int       `json:"requests"`
+	Failures int       `json:"failures"`
+	// Availability is the percentage of the requests that succeeded.
+	Availability float64 `json:"availability_percent"`
+	// The latency percentiles are of the requests that succeeded.
+	LatencyP50 time.Duration `json:"latency_p50_ns"`
+	LatencyP99 time.Duration `json:"latency_p99_ns"`
+	LatencyMax time.Duration `json:"latency_max_ns"`
+	// Outages is the number of runs of consecutive failed requests, and
+	// LongestOutage is the longest time from the first failed request of a
+	// run to the next request that succeeded.
+	Outages       int           `json:"outages"`
+	LongestOutage time.Duration `json:"longest_outage_ns"`
+}
+
+// Monitor sends gNMI requests to a DUT in the background.
+type Monitor struct {
+	target string
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	samples []Sample
+}
+
+// Start starts a Monitor of the gNMI availability of dut.
+func Start(t testing.TB, dut *ondatra.DUTDevice) *Monitor {
+	t.Helper()
+	c, err := ygnmi.NewClient(dut.RawAPIs().GNMI(t), ygnmi.WithTarget(dut.ID()))
+	if err != nil {
+		t.Fatalf("Unable to connect to gNMI on %s: %v", dut.ID(), err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Monitor{
+		target: dut.Name(),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go m.run(ctx, func(ctx context.Context) error {
+		_, err := ygnmi.Get(ctx, c, gnmi.OC().System().CurrentDatetime().State())
+		return err
+	})
+	t.Logf("Started gNMI availability monitor of %s", m.target)
+	return m
+}
+
+// run calls probe every interval until ctx is done.
+func (m *Monitor) run(ctx context.Context, probe func(context.Context) error) {
+	defer close(m.done)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		pctx, cancel := context.WithTimeout(ctx, *timeout)
+		err := probe(pctx)
+		cancel()
+		if ctx.Err() != nil {
+			// The request was cut short by Stop, so it is not a sample.
+			return
+		}
+		m.mu.Lock()
+		m.samples = append(m.samples, Sample{Start: start, Latency: time.Since(start), Err: err})
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops m, and reports the availability of the DUT since m started.
+func (m *Monitor) Stop(t testing.TB) *Report {
+	t.Helper()
+	m.cancel()
+	<-m.done
+	m.mu.Lock()
+	r := summarize(m.samples)
+	m.mu.Unlock()
+	r.Target = m.target
+
+	t.Logf("gNMI availability of %s: %.3f%% of %d requests succeeded, %d outages, longest outage %v, latency p50 %v, p99 %v, max %v",
+		r.Target, r.Availability, r.Requests, r.Outages, r.LongestOutage, r.LatencyP50, r.LatencyP99, r.LatencyMax)
+	if b, err := json.MarshalIndent(r, "", "  "); err != nil {
+		t.Errorf("Cannot marshal gNMI availability report: %v", err)
+	} else if err := os.WriteFile(filepath.Join(fptest.ArtifactDir(t), reportFile), b, 0o644); err != nil {
+		t.Errorf("Cannot write gNMI availability report: %v", err)
+	}
+	metrics.Record(t, "gnmi_availability_percent", r.Availability)
+	metrics.RecordDuration(t, "gnmi_longest_outage", r.LongestOutage)
+	if *slo > 0 && r.Availability < *slo {
+		t.Errorf("gNMI availability of %s: got %.3f%%, want >= %.3f%%", r.Target, r.Availability, *slo)
+	}
+	return r
+}
+
+// summarize returns the report of samples, which are in the order they were
+// taken.
+func summarize(samples []Sample) *Report {
+	r := &Report{Requests: len(samples)}
+	if len(samples) == 0 {
+		return r
+	}
+	r.Start = samples[0].Start
+	last := samples[len(samples)-1]
+	r.End = last.Start.Add(last.Latency)
+
+	var latencies []time.Duration
+	var outageStart time.Time
+	for _, s := range samples {
+		if s.Err != nil {
+			if outageStart.IsZero() {
+				outageStart = s.Start
+				r.Outages++
+			}
+			r.Failures++
+			continue
+		}
+		latencies = append(latencies, s.Latency)
+		if !outageStart.IsZero() {
+			r.LongestOutage = max(r.LongestOutage, s.Start.Sub(outageStart))
+			outageStart = time.Time{}
+		}
+	}
+	if !outageStart.IsZero() {
+		r.LongestOutage = max(r.LongestOutage, r.End.Sub(outageStart))
+	}
+	r.Availability = 100 * float64(r.Requests-r.Failures) / float64(r.Requests)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	r.LatencyP50 = percentile(latencies, 50)
+	r.LatencyP99 = percentile(latencies, 99)
+	r.LatencyMax = percentile(latencies, 100)
+	return r
+}
+
+// percentile returns the nearest-rank percentile p of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)]
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package availability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarize(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	errDown := errors.New("unavailable")
+	// sample returns the sample of a request sent i seconds after t0.
+	sample := func(i int, latency time.Duration, err error) Sample {
+		return Sample{Start: t0.Add(time.Duration(i) * time.Second), Latency: latency, Err: err}
+	}
+
+	tests := []struct {
+		desc    string
+		samples []Sample
+		want    *Report
+	}{{
+		desc: "no samples",
+		want: &Report{},
+	}, {
+		desc: "all succeeded",
+		samples: []Sample{
+			sample(0, 10*time.Millisecond, nil),
+			sample(1, 30*time.Millisecond, nil),
+			sample(2, 20*time.Millisecond, nil),
+		},
+		want: &Report{
+			Start:        t0,
+			End:          t0.Add(2*time.Second + 20*time.Millisecond),
+			Requests:     3,
+			Availability: 100,
+			LatencyP50:   20 * time.Millisecond,
+			LatencyP99:   30 * time.Millisecond,
+			LatencyMax:   30 * time.Millisecond,
+		},
+	}, {
+		desc: "recovered outages",
+		samples: []Sample{
+			sample(0, 10*time.Millisecond, nil),
+			sample(1, 5*time.Second, errDown),
+			sample(6, 5*time.Second, errDown),
+			sample(11, 10*time.Millisecond, nil),
+			sample(12, 5*time.Second, errDown),
+			sample(17, 10*time.Millisecond, nil),
+		},
+		want: &Report{
+			Start:         t0,
+			End:           t0.Add(17*time.Second + 10*time.Millisecond),
+			Requests:      6,
+			Failures:      3,
+			Availability:  50,
+			LatencyP50:    10 * time.Millisecond,
+			LatencyP99:    10 * time.Millisecond,
+			LatencyMax:    10 * time.Millisecond,
+			Outages:       2,
+			LongestOutage: 10 * time.Second,
+		},
+	}, {
+		desc: "ongoing outage",
+		samples: []Sample{
+			sample(0, 10*time.Millisecond, nil),
+			sample(1, 10*time.Millisecond, nil),
+			sample(2, 10*time.Millisecond, nil),
+			sample(3, 5*time.Second, errDown),
+		},
+		want: &Report{
+			Start:         t0,
+			End:           t0.Add(8 * time.Second),
+			Requests:      4,
+			Failures:      1,
+			Availability:  75,
+			LatencyP50:    10 * time.Millisecond,
+			LatencyP99:    10 * time.Millisecond,
+			LatencyMax:    10 * time.Millisecond,
+			Outages:       1,
+			LongestOutage: 5 * time.Second,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, summarize(tt.samples)); diff != "" {
+				t.Errorf("summarize() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	orig := *interval
+	*interval = time.Millisecond
+	t.Cleanup(func() { *interval = orig })
+
+	errDown := errors.New("unavailable")
+	calls := 0
+	probe := func(context.Context) error {
+		calls++
+		if calls%2 == 0 {
+			return errDown
+		}
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Monitor{cancel: cancel, done: make(chan struct{})}
+	go m.run(ctx, probe)
+	time.Sleep(50 * time.Millisecond)
+	r := m.Stop(t)
+
+	if r.Requests == 0 {
+		t.Fatalf("Stop() got no requests, want some")
+	}
+	if got, want := r.Failures, r.Requests/2; got != want {
+		t.Errorf("Stop() failures: got %d, want %d of %d requests", got, want, r.Requests)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgputils provides helpers to verify the state of BGP neighbors on a
+// DUT through telemetry.
+package bgputils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ondatra/gnmi/oc/netinstbgp"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// ProtocolName is the name of the BGP protocol instance the helpers query.
+const ProtocolName = "BGP"
+
+// NeighborPath returns the path of the BGP neighbor with address neighbor in
+// the default network instance of dut.
+func NeighborPath(dut *ondatra.DUTDevice, neighbor string) *netinstbgp.NetworkInstance_Protocol_Bgp_NeighborPath {
+	return gnmi.OC().NetworkInstance(deviations.DefaultNetworkInstance(dut)).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, ProtocolName).Bgp().Neighbor(neighbor)
+}
+
+// AwaitSessionState waits up to timeout for the session state of the BGP
+// neighbor to become state.  If it does not, it logs the state of the
+// neighbor and fails the test.
+func AwaitSessionState(t testing.TB, dut *ondatra.DUTDevice, neighbor string, state oc.E_Bgp_Neighbor_SessionState, timeout time.Duration) {
+	t.Helper()
+	nbrPath := NeighborPath(dut, neighbor)
+	t.Logf("Waiting for BGP neighbor %s to become %v...", neighbor, state)
+	got, ok := gnmi.Watch(t, dut, nbrPath.SessionState().State(), timeout, func(val *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+		s, present := val.Val()
+		return present && s == state
+	}).Await(t)
+	if !ok {
+		fptest.LogQuery(t, "BGP reported state", nbrPath.State(), gnmi.Get(t, dut, nbrPath.State()))
+		s, _ := got.Val()
+		t.Fatalf("BGP neighbor %s session state: got %v, want %v", neighbor, s, state)
+	}
+	t.Logf("BGP neighbor %s session state: %v", neighbor, state)
+}
+
+// awaitCount waits up to timeout for the value at q to become want, and
+// reports a test error if it does not.
+func awaitCount(t testing.TB, dut *ondatra.DUTDevice, q ygnmi.SingletonQuery[uint32], desc string, want uint32, timeout time.Duration) {
+	t.Helper()
+	got, ok := gnmi.Watch(t, dut, q, timeout, func(val *ygnmi.Value[uint32]) bool {
+		v, present := val.Val()
+		return present && v == want
+	}).Await(t)
+	if !ok {
+		v, _ := got.Val()
+		t.Errorf("%s: got %d, want %d", desc, v, want)
+	}
+}
+
+// AwaitReceivedPrefixes waits up to timeout for the number of prefixes
+// received from the BGP neighbor for afiSafi to become want, and reports a
+// test error if it does not.
+func AwaitReceivedPrefixes(t testing.TB, dut *ondatra.DUTDevice, neighbor string, afiSafi oc.E_BgpTypes_AFI_SAFI_TYPE, want uint32, timeout time.Duration) {
+	t.Helper()
+	q := NeighborPath(dut, neighbor).AfiSafi(afiSafi).Prefixes().Received().State()
+	awaitCount(t, dut, q, "BGP neighbor "+neighbor+" "+afiSafi.String()+" received prefixes", want, timeout)
+}
+
+// AwaitSentPrefixes waits up to timeout for the number of prefixes advertised
+// to the BGP neighbor for afiSafi to become want, and reports a test error if
+// it does not.
+func AwaitSentPrefixes(t testing.TB, dut *ondatra.DUTDevice, neighbor string, afiSafi oc.E_BgpTypes_AFI_SAFI_TYPE, want uint32, timeout time.Duration) {
+	t.Helper()
+	q := NeighborPath(dut, neighbor).AfiSafi(afiSafi).Prefixes().Sent().State()
+	awaitCount(t, dut, q, "BGP neighbor "+neighbor+" "+afiSafi.String()+" sent prefixes", want, timeout)
+}
+
+// VerifyCapabilities reports a test error for each of caps that is not a
+// supported capability of the BGP neighbor, and for each of afiSafis that is
+// not active on the neighbor, i.e. was not negotiated with it.
+func VerifyCapabilities(t testing.TB, dut *ondatra.DUTDevice, neighbor string, caps []oc.E_BgpTypes_BGP_CAPABILITY, afiSafis []oc.E_BgpTypes_AFI_SAFI_TYPE) {
+	t.Helper()
+	nbrPath := NeighborPath(dut, neighbor)
+	supported := map[oc.E_BgpTypes_BGP_CAPABILITY]bool{}
+	for _, c := range gnmi.Get(t, dut, nbrPath.SupportedCapabilities().State()) {
+		supported[c] = true
+	}
+	for _, c := range caps {
+		if !supported[c] {
+			t.Errorf("BGP neighbor %s capability %v is not supported", neighbor, c)
+		}
+	}
+	for _, a := range afiSafis {
+		if active, _ := gnmi.Lookup(t, dut, nbrPath.AfiSafi(a).Active().State()).Val(); !active {
+			t.Errorf("BGP neighbor %s AFI-SAFI %v is not active", neighbor, a)
+		}
+	}
+}
@@ -153,6 +153,7 @@ type BGPSession struct {
 	ATEPorts        []*attrs.Attributes
 	afiTypes        []oc.E_BgpTypes_AFI_SAFI_TYPE
 	networkInstance string
+	ipv6Only        bool
 }
 
 // NewBGPSession creates a new BGPSession using the default global config, and
@@ -198,12 +199,37 @@ func NewBGPSession(t *testing.T, pc PortCount, ni *string) *BGPSession {
 	return conf
 }
 
+// WithIPv6Only removes the IPv4 addresses from the interfaces of the DUT and
+// the ATE, for an IPv6-only underlay.  It must be called before WithEBGP,
+// which then only supports the IPv6 unicast AFI-SAFI.  The IPv4 addresses of
+// DUTPorts and ATEPorts are kept, since they are the BGP router IDs.
+func (bs *BGPSession) WithIPv6Only(t *testing.T) *BGPSession {
+	t.Helper()
+	if len(bs.afiTypes) > 0 {
+		t.Fatal("WithIPv6Only must be called before BGP is configured")
+	}
+	bs.ipv6Only = true
+	for i, p := range bs.OndatraDUTPorts {
+		bs.DUTConf.DeleteInterface(p.Name())
+		bs.DUTPorts[i].IPv6Only().ConfigOCInterface(bs.DUTConf.GetOrCreateInterface(p.Name()), bs.DUT)
+	}
+	for _, dev := range bs.ATEIntfs {
+		if dev != nil {
+			dev.Ethernets().Items()[0].Ipv4Addresses().Clear()
+		}
+	}
+	return bs
+}
+
 // WithEBGP adds eBGP specific config
 func (bs *BGPSession) WithEBGP(t *testing.T, afiTypes []oc.E_BgpTypes_AFI_SAFI_TYPE, bgpPorts []string, isSamePG, isSameAS bool) *BGPSession {
 	for _, afiType := range afiTypes {
 		if afiType != oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST && afiType != oc.BgpTypes_AFI_SAFI_TYPE_IPV6_UNICAST {
 			t.Fatalf("Unsupported AFI type: %v", afiType)
 		}
+		if afiType == oc.BgpTypes_AFI_SAFI_TYPE_IPV4_UNICAST && bs.ipv6Only {
+			t.Fatalf("AFI type %v is not supported with an IPv6-only underlay", afiType)
+		}
 	}
 	bs.afiTypes = afiTypes
 
@@ -64,6 +64,13 @@ check also provides a number of shorthands for common cases:
   - check.Predicate[T](query PathStruct[T], wantMsg string, predicate func(T)
     bool) checks that the value at query is present and satisfies the given
     predicate function; wantMsg is used in the resulting error if it fails.
+  - check.EqualNormalized(query, want, normalize) and
+    check.EqualOrNilNormalized(query, want, normalize) are Equal and
+    EqualOrNil applied to the query's value after normalize has translated
+    it, typically with deviations.Normalizer[T](dut) to accept vendor
+    specific encodings of the value.  A leaf value that does not comply
+    with the schema, such as an enum value the schema does not define, is
+    passed to normalize with its compliance errors instead of failing.
 
 These helpers all have prewritten validation functions that return sensible
 errors of the form "<path>: <got>, <want>", such as:
@@ -276,6 +283,10 @@ func isTimeout(err error) bool {
 type validation[T any] struct {
 	query        ygnmi.SingletonQuery[T]
 	validationFn func(*ygnmi.Value[T]) error
+	// noncompliant is set if validationFn is called with a leaf value that
+	// could not be unmarshalled, so that it can be normalized from the
+	// compliance errors.
+	noncompliant bool
 }
 
 var _ Validator = (*validation[any])(nil)
@@ -295,7 +306,7 @@ func (vd *validation[T]) RelPath(base ygnmi.PathStruct) string {
 // fails.
 func (vd *validation[T]) Check(client *ygnmi.Client) error {
 	lastVal, err := ygnmi.Lookup(context.Background(), client, vd.query)
-	if err != nil {
+	if err != nil && !(vd.noncompliant && lastVal != nil && lastVal.ComplianceErrors != nil) {
 		return &validationError[T]{
 			query:        vd.query,
 			failureCause: err}
@@ -369,7 +380,7 @@ func (vd *validation[T]) AwaitUntil(deadline time.Time, client *ygnmi.Client) er
 
 // Validate expects validationFn to return no error on the query's value.
 func Validate[T any, QT ygnmi.SingletonQuery[T]](query QT, validationFn func(*ygnmi.Value[T]) error) Validator {
-	return &validation[T]{query, validationFn}
+	return &validation[T]{query: query, validationFn: validationFn}
 }
 
 // Predicate expects that the query has a value and the given predicate returns
@@ -412,6 +423,32 @@ func EqualOrNil[T any, QT ygnmi.SingletonQuery[T]](query QT, want T) Validator {
 	})
 }
 
+// EqualNormalized expects the query's value to be want after it is translated
+// by normalize, such as deviations.Normalizer for the DUT.
+func EqualNormalized[T any, QT ygnmi.SingletonQuery[T]](query QT, want T, normalize func(*ygnmi.Value[T]) *ygnmi.Value[T]) Validator {
+	return &validation[T]{query: query, noncompliant: true, validationFn: func(vgot *ygnmi.Value[T]) error {
+		vgot = normalize(vgot)
+		got, present := vgot.Val()
+		if !present || !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("got %s, want %#v", FormatValue(vgot), want)
+		}
+		return nil
+	}}
+}
+
+// EqualOrNilNormalized expects the query to be unset or have value want after
+// it is translated by normalize, such as deviations.Normalizer for the DUT.
+func EqualOrNilNormalized[T any, QT ygnmi.SingletonQuery[T]](query QT, want T, normalize func(*ygnmi.Value[T]) *ygnmi.Value[T]) Validator {
+	return &validation[T]{query: query, noncompliant: true, validationFn: func(vgot *ygnmi.Value[T]) error {
+		vgot = normalize(vgot)
+		got, present := vgot.Val()
+		if present && !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("got %s, want %#v or no value", FormatValue(vgot), want)
+		}
+		return nil
+	}}
+}
+
 // Present expects the query to have any value.
 func Present[T any, QT ygnmi.SingletonQuery[T]](query QT) Validator {
 	return Predicate(query, "want any value", func(T) bool {
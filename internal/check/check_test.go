@@ -24,6 +24,7 @@ import (
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	"github.com/openconfig/gnmi/testing/fake/gnmi"
 	fpb "github.com/openconfig/gnmi/testing/fake/proto"
+	"github.com/openconfig/ygnmi/exampleoc"
 	"github.com/openconfig/ygnmi/exampleoc/exampleocpath"
 	"github.com/openconfig/ygnmi/ygnmi"
 	"google.golang.org/grpc"
@@ -150,6 +151,22 @@ func errContainsAll(got error, want []string) error {
 	}
 }
 
+// upper normalizes "CORRECT" to "correct".
+func upper(v *ygnmi.Value[string]) *ygnmi.Value[string] {
+	if got, _ := v.Val(); got == "CORRECT" {
+		return (&ygnmi.Value[string]{Path: v.Path}).SetVal("correct")
+	}
+	return v
+}
+
+// emptyAsUnset normalizes the empty string to no value.
+func emptyAsUnset(v *ygnmi.Value[string]) *ygnmi.Value[string] {
+	if got, _ := v.Val(); got == "" {
+		return &ygnmi.Value[string]{Path: v.Path}
+	}
+	return v
+}
+
 func TestCheck(t *testing.T) {
 	fakeGNMI, c := mustNewFakeGNMI(context.Background(), t)
 	defer fakeGNMI.Close()
@@ -195,6 +212,24 @@ func TestCheck(t *testing.T) {
 		validator:   check.EqualOrNil(query, "correct"),
 		value:       "wrong",
 		errIncludes: []string{childTwoStatePath, "correct", "wrong"},
+	}, {
+		desc:      "EqualNormalized/Correct",
+		validator: check.EqualNormalized(query, "correct", upper),
+		value:     "CORRECT",
+	}, {
+		desc:        "EqualNormalized/Incorrect",
+		validator:   check.EqualNormalized(query, "correct", upper),
+		value:       "wrong",
+		errIncludes: []string{childTwoStatePath, "correct", "wrong"},
+	}, {
+		desc:      "EqualOrNilNormalized/Unset",
+		validator: check.EqualOrNilNormalized(query, "correct", emptyAsUnset),
+		value:     "",
+	}, {
+		desc:        "EqualOrNilNormalized/Incorrect",
+		validator:   check.EqualOrNilNormalized(query, "correct", emptyAsUnset),
+		value:       "wrong",
+		errIncludes: []string{childTwoStatePath, "correct", "wrong"},
 	}, {
 		desc:      "Present/Correct",
 		value:     "correct",
@@ -227,6 +262,74 @@ func TestCheck(t *testing.T) {
 	}
 }
 
+// vendorOne normalizes the noncompliant enum value "VENDOR_ONE" to ONE.
+func vendorOne(v *ygnmi.Value[exampleoc.E_Child_Three]) *ygnmi.Value[exampleoc.E_Child_Three] {
+	if v.ComplianceErrors == nil {
+		return v
+	}
+	for _, e := range v.ComplianceErrors.TypeErrors {
+		if e.Value.GetStringVal() == "VENDOR_ONE" {
+			return (&ygnmi.Value[exampleoc.E_Child_Three]{Path: v.Path}).SetVal(exampleoc.Child_Three_ONE)
+		}
+	}
+	return v
+}
+
+func TestCheckNoncompliant(t *testing.T) {
+	fakeGNMI, c := mustNewFakeGNMI(context.Background(), t)
+	defer fakeGNMI.Close()
+	childThree := exampleocpath.Root().Parent().Child().Three()
+	query := childThree.State()
+	path, _, err := ygnmi.ResolvePath(query.PathStruct())
+	if err != nil {
+		t.Fatalf("Resolving OC path: %v", err)
+	}
+	fakeGNMI.gen.Reset()
+	fakeGNMI.gen.Responses = []*gpb.SubscribeResponse{{
+		Response: &gpb.SubscribeResponse_Update{
+			Update: &gpb.Notification{
+				Update: []*gpb.Update{{
+					Path: path,
+					Val:  &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "VENDOR_ONE"}},
+				}},
+			},
+		},
+	}, {
+		Response: &gpb.SubscribeResponse_SyncResponse{SyncResponse: true},
+	}}
+	testCases := []struct {
+		desc        string
+		validator   check.Validator
+		errIncludes []string
+	}{{
+		desc:        "Equal",
+		validator:   check.Equal(query, exampleoc.Child_Three_ONE),
+		errIncludes: []string{"noncompliant", "VENDOR_ONE"},
+	}, {
+		desc:      "EqualNormalized",
+		validator: check.EqualNormalized(query, exampleoc.Child_Three_ONE, vendorOne),
+	}, {
+		desc:      "EqualOrNilNormalized",
+		validator: check.EqualOrNilNormalized(query, exampleoc.Child_Three_ONE, vendorOne),
+	}, {
+		desc:        "EqualNormalized/Incorrect",
+		validator:   check.EqualNormalized(query, exampleoc.Child_Three_TWO, vendorOne),
+		errIncludes: []string{"got 1", "want 2"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotErr := tc.validator.Check(c)
+			if len(tc.errIncludes) > 0 {
+				if err := errContainsAll(gotErr, tc.errIncludes); err != nil {
+					t.Error(err)
+				}
+			} else if gotErr != nil {
+				t.Errorf("Unexpected error: %v", gotErr)
+			}
+		})
+	}
+}
+
 func TestAwait(t *testing.T) {
 	fakeGNMI, c := mustNewFakeGNMI(context.Background(), t)
 	defer fakeGNMI.Close()
@@ -19,9 +19,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sync"
 	"testing"
 	"time"
 
+	log "github.com/golang/glog"
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	tpb "github.com/openconfig/gnoi/types"
 	"github.com/openconfig/ondatra"
@@ -29,6 +31,8 @@ import (
 	"github.com/openconfig/ondatra/gnmi/oc"
 	"github.com/openconfig/ondatra/gnmi/oc/ocpath"
 	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -107,6 +111,57 @@ func GetSubcomponentPath(name string, useNameOnly bool) *tpb.Path {
 	}
 }
 
+var (
+	nameOnlyMu sync.Mutex
+	// nameOnly records, per DUT ID, whether the DUT accepted the name-only
+	// form of subcomponent paths instead of the full OpenConfig path.
+	nameOnly = make(map[string]bool)
+)
+
+// WithSubcomponentPath calls rpc with a subcomponent path for the component
+// name, detecting which path form the DUT accepts.  The full OpenConfig path
+// is tried first; if rpc returns an INVALID_ARGUMENT error, rpc is retried
+// with the name-only path.  The form that worked is recorded so that later
+// calls for the same DUT use it first.  It returns the error from the last
+// call to rpc.
+func WithSubcomponentPath(dut *ondatra.DUTDevice, name string, rpc func(*tpb.Path) error) error {
+	return withSubcomponentPath(dut.ID(), name, rpc)
+}
+
+func withSubcomponentPath(id, name string, rpc func(*tpb.Path) error) error {
+	nameOnlyMu.Lock()
+	useNameOnly := nameOnly[id]
+	nameOnlyMu.Unlock()
+
+	err := rpc(GetSubcomponentPath(name, useNameOnly))
+	if status.Code(err) == codes.InvalidArgument {
+		useNameOnly = !useNameOnly
+		log.Infof("Subcomponent path for %q rejected (%v), retrying with useNameOnly=%v", name, err, useNameOnly)
+		err = rpc(GetSubcomponentPath(name, useNameOnly))
+	}
+	if err == nil {
+		nameOnlyMu.Lock()
+		nameOnly[id] = useNameOnly
+		nameOnlyMu.Unlock()
+	}
+	return err
+}
+
+// DetectedSubcomponentPath returns the subcomponent path for the component
+// name in the form last accepted by the DUT in a call to
+// WithSubcomponentPath, or the full OpenConfig path if no form was accepted
+// yet.  It is meant for requests that are expected to fail, which
+// WithSubcomponentPath would send twice.
+func DetectedSubcomponentPath(dut *ondatra.DUTDevice, name string) *tpb.Path {
+	return detectedSubcomponentPath(dut.ID(), name)
+}
+
+func detectedSubcomponentPath(id, name string) *tpb.Path {
+	nameOnlyMu.Lock()
+	defer nameOnlyMu.Unlock()
+	return GetSubcomponentPath(name, nameOnly[id])
+}
+
 // Y provides the ygnmi based components helper.  A ygnmi.Client is tied to a specific
 // DUT.
 type Y struct {
@@ -19,6 +19,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	tpb "github.com/openconfig/gnoi/types"
 )
 
 func TestFindMatchingStrings(t *testing.T) {
@@ -41,3 +47,85 @@ func TestFindMatchingStrings(t *testing.T) {
 		t.Errorf("FindMatchingStrings(%s) returned unexpected diff (-want +got):\n%s", args, diff)
 	}
 }
+
+func TestWithSubcomponentPath(t *testing.T) {
+	fullPath := GetSubcomponentPath("RP1", false)
+	namePath := GetSubcomponentPath("RP1", true)
+
+	tests := []struct {
+		desc      string
+		accept    *tpb.Path // Path form accepted by the fake RPC; nil accepts none.
+		rpcErr    error     // Error returned for rejected paths.
+		wantCalls []*tpb.Path
+		wantErr   bool
+	}{{
+		desc:      "full path accepted",
+		accept:    fullPath,
+		rpcErr:    status.Error(codes.InvalidArgument, "bad path"),
+		wantCalls: []*tpb.Path{fullPath},
+	}, {
+		desc:      "fall back to name only",
+		accept:    namePath,
+		rpcErr:    status.Error(codes.InvalidArgument, "bad path"),
+		wantCalls: []*tpb.Path{fullPath, namePath},
+	}, {
+		desc:      "no fall back on other errors",
+		accept:    namePath,
+		rpcErr:    status.Error(codes.Unavailable, "down"),
+		wantCalls: []*tpb.Path{fullPath},
+		wantErr:   true,
+	}, {
+		desc:      "neither form accepted",
+		rpcErr:    status.Error(codes.InvalidArgument, "bad path"),
+		wantCalls: []*tpb.Path{fullPath, namePath},
+		wantErr:   true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var calls []*tpb.Path
+			rpc := func(p *tpb.Path) error {
+				calls = append(calls, p)
+				if tc.accept != nil && proto.Equal(p, tc.accept) {
+					return nil
+				}
+				return tc.rpcErr
+			}
+			err := withSubcomponentPath(tc.desc, "RP1", rpc)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("withSubcomponentPath() got err %v, want error %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantCalls, calls, protocmp.Transform()); diff != "" {
+				t.Errorf("withSubcomponentPath() paths tried returned diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithSubcomponentPathRecordsForm(t *testing.T) {
+	namePath := GetSubcomponentPath("RP1", true)
+	var calls []*tpb.Path
+	rpc := func(p *tpb.Path) error {
+		calls = append(calls, p)
+		if proto.Equal(p, namePath) {
+			return nil
+		}
+		return status.Error(codes.InvalidArgument, "bad path")
+	}
+	const id = "recorded-dut"
+	if err := withSubcomponentPath(id, "RP1", rpc); err != nil {
+		t.Fatalf("withSubcomponentPath() first call failed: %v", err)
+	}
+	calls = nil
+	if err := withSubcomponentPath(id, "RP1", rpc); err != nil {
+		t.Fatalf("withSubcomponentPath() second call failed: %v", err)
+	}
+	if diff := cmp.Diff([]*tpb.Path{namePath}, calls, protocmp.Transform()); diff != "" {
+		t.Errorf("withSubcomponentPath() second call paths tried returned diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(GetSubcomponentPath("RP2", true), detectedSubcomponentPath(id, "RP2"), protocmp.Transform()); diff != "" {
+		t.Errorf("detectedSubcomponentPath() returned diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(GetSubcomponentPath("RP2", false), detectedSubcomponentPath("undetected-dut", "RP2"), protocmp.Transform()); diff != "" {
+		t.Errorf("detectedSubcomponentPath() for undetected DUT returned diff (-want +got):\n%s", diff)
+	}
+}
@@ -1067,3 +1067,63 @@ func WecmpAutoUnsupported(dut *ondatra.DUTDevice) bool {
 func RoutingPolicyChainingUnsupported(dut *ondatra.DUTDevice) bool {
 	return lookupDUTDeviations(dut).GetRoutingPolicyChainingUnsupported()
 }
+
+// NatOCUnsupported returns true if NAT pools and source translation must be
+// configured through CLI.
+func NatOCUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetNatOcUnsupported()
+}
+
+// ComponentLastRebootReasonUnsupported returns true if the device does not
+// report last-reboot-reason for the chassis or its supervisors.
+func ComponentLastRebootReasonUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetComponentLastRebootReasonUnsupported()
+}
+
+// URPFOCUnsupported returns true if unicast reverse path forwarding must be
+// configured through CLI.
+func URPFOCUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetUrpfOcUnsupported()
+}
+
+// SystemMessagesUnsupported returns true if the device does not stream syslog
+// messages on /system/messages/state/message.
+func SystemMessagesUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetSystemMessagesUnsupported()
+}
+
+// L2SwitchingUnsupported returns true if the device does not support L2
+// switching, such as VLAN access ports and IGMP snooping.
+func L2SwitchingUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetL2SwitchingUnsupported()
+}
+
+// GNOIRebootNSFUnsupported returns true if the device does not support gNOI
+// System.Reboot with the NSF method.
+func GNOIRebootNSFUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetGnoiRebootNsfUnsupported()
+}
+
+// TunnelDecapModeOCUnsupported returns true if the pipe and uniform DSCP and
+// TTL modes of tunnel decapsulation must be configured through CLI.
+func TunnelDecapModeOCUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetTunnelDecapModeOcUnsupported()
+}
+
+// BGPTCPAOOCUnsupported returns true if TCP-AO for a BGP neighbor must be
+// configured through CLI, with the keychain configured through OpenConfig.
+func BGPTCPAOOCUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetBgpTcpAoOcUnsupported()
+}
+
+// ConfigLoadFromFileCLI returns true if a configuration staged with gNOI
+// File.Put must be activated through CLI instead of a gNMI root replace.
+func ConfigLoadFromFileCLI(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetConfigLoadFromFileCli()
+}
+
+// GRPCTunnelConfigOCUnsupported returns true if the gRPC tunnel client must be
+// configured through CLI.
+func GRPCTunnelConfigOCUnsupported(dut *ondatra.DUTDevice) bool {
+	return lookupDUTDeviations(dut).GetGrpcTunnelConfigOcUnsupported()
+}
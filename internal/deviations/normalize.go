@@ -0,0 +1,153 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"reflect"
+	"strings"
+
+	log "github.com/golang/glog"
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// NormalizeValue returns v with the value translated by the
+// value_normalizations deviation of the DUT matching the path of v.  The
+// value is returned unchanged if no normalization matches.  An enum value
+// that is not in the OpenConfig schema is translated from the compliance
+// errors of v, as it could not be unmarshalled.
+func NormalizeValue[T any](dut *ondatra.DUTDevice, v *ygnmi.Value[T]) *ygnmi.Value[T] {
+	return normalize(lookupDUTDeviations(dut).GetValueNormalizations(), v)
+}
+
+// Normalizer returns NormalizeValue for the DUT, for use with verification
+// helpers such as check.EqualNormalized.
+func Normalizer[T any](dut *ondatra.DUTDevice) func(*ygnmi.Value[T]) *ygnmi.Value[T] {
+	return func(v *ygnmi.Value[T]) *ygnmi.Value[T] {
+		return NormalizeValue(dut, v)
+	}
+}
+
+func normalize[T any](norms []*mpb.Metadata_ValueNormalization, v *ygnmi.Value[T]) *ygnmi.Value[T] {
+	if len(norms) == 0 || v == nil {
+		return v
+	}
+	path := schemaPath(v.Path)
+	var norm *mpb.Metadata_ValueNormalization
+	for _, n := range norms {
+		if n.GetPath() == path {
+			norm = n
+			break
+		}
+	}
+	if norm == nil {
+		return v
+	}
+
+	out := &ygnmi.Value[T]{
+		Path:             v.Path,
+		Timestamp:        v.Timestamp,
+		RecvTimestamp:    v.RecvTimestamp,
+		ComplianceErrors: v.ComplianceErrors,
+	}
+	val, present := v.Val()
+	if !present {
+		// A vendor specific enum value that is not in the OpenConfig schema
+		// fails to unmarshal, so the value is not present and the raw value
+		// is only found in the compliance errors.
+		raw, ok := noncompliantString(v, path)
+		if !ok {
+			return v
+		}
+		want, ok := norm.GetValues()[raw]
+		if !ok {
+			return v
+		}
+		e, ok := any(val).(ygot.GoEnum)
+		if !ok {
+			return v
+		}
+		n, ok := enumValue(e, want)
+		if !ok {
+			log.Errorf("Value normalization for %s: %q is not a value of %T", path, want, e)
+			return v
+		}
+		reflect.ValueOf(&val).Elem().SetInt(n)
+		return out.SetVal(val)
+	}
+	switch x := any(val).(type) {
+	case string:
+		if x == "" && norm.GetEmptyAsUnset() {
+			return out
+		}
+		if want, ok := norm.GetValues()[x]; ok {
+			return out.SetVal(any(want).(T))
+		}
+	case ygot.GoEnum:
+		want, ok := norm.GetValues()[x.String()]
+		if !ok {
+			break
+		}
+		n, ok := enumValue(x, want)
+		if !ok {
+			log.Errorf("Value normalization for %s: %q is not a value of %T", path, want, x)
+			break
+		}
+		reflect.ValueOf(&val).Elem().SetInt(n)
+		return out.SetVal(val)
+	}
+	return v
+}
+
+// noncompliantString returns the string value at path that v failed to
+// unmarshal, if any.
+func noncompliantString[T any](v *ygnmi.Value[T], path string) (string, bool) {
+	if v.ComplianceErrors == nil {
+		return "", false
+	}
+	for _, e := range v.ComplianceErrors.TypeErrors {
+		if schemaPath(e.Path) != path {
+			continue
+		}
+		if s, ok := e.Value.GetValue().(*gpb.TypedValue_StringVal); ok {
+			return s.StringVal, true
+		}
+	}
+	return "", false
+}
+
+// enumValue returns the value of the enum type of e named name.
+func enumValue(e ygot.GoEnum, name string) (int64, bool) {
+	for n, def := range e.ΛMap()[reflect.TypeOf(e).Name()] {
+		if def.Name == name {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// schemaPath returns p as a string without keys or origin.
+func schemaPath(p *gpb.Path) string {
+	var b strings.Builder
+	for _, e := range p.GetElem() {
+		b.WriteString("/")
+		b.WriteString(e.GetName())
+	}
+	return b.String()
+}
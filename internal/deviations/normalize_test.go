@@ -0,0 +1,158 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"errors"
+	"testing"
+
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	speedPath = &gpb.Path{Elem: []*gpb.PathElem{
+		{Name: "interfaces"},
+		{Name: "interface", Key: map[string]string{"name": "Ethernet1"}},
+		{Name: "ethernet"},
+		{Name: "state"},
+		{Name: "port-speed"},
+	}}
+	descPath = &gpb.Path{Elem: []*gpb.PathElem{
+		{Name: "interfaces"},
+		{Name: "interface", Key: map[string]string{"name": "Ethernet1"}},
+		{Name: "state"},
+		{Name: "description"},
+	}}
+	norms = []*mpb.Metadata_ValueNormalization{{
+		Path:   "/interfaces/interface/ethernet/state/port-speed",
+		Values: map[string]string{"SPEED_2500MB": "SPEED_UNKNOWN", "SPEED_1GB": "SPEED_BOGUS", "SPEED_2_5G": "SPEED_2500MB"},
+	}, {
+		Path:         "/interfaces/interface/state/description",
+		Values:       map[string]string{"n/a": "none"},
+		EmptyAsUnset: true,
+	}}
+)
+
+func TestNormalizeEnum(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   oc.E_IfEthernet_ETHERNET_SPEED
+		want oc.E_IfEthernet_ETHERNET_SPEED
+	}{{
+		desc: "translated",
+		in:   oc.IfEthernet_ETHERNET_SPEED_SPEED_2500MB,
+		want: oc.IfEthernet_ETHERNET_SPEED_SPEED_UNKNOWN,
+	}, {
+		desc: "not listed",
+		in:   oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB,
+		want: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB,
+	}, {
+		desc: "unknown target name",
+		in:   oc.IfEthernet_ETHERNET_SPEED_SPEED_1GB,
+		want: oc.IfEthernet_ETHERNET_SPEED_SPEED_1GB,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			v := (&ygnmi.Value[oc.E_IfEthernet_ETHERNET_SPEED]{Path: speedPath}).SetVal(tc.in)
+			got, ok := normalize(norms, v).Val()
+			if !ok || got != tc.want {
+				t.Errorf("normalize(%v) got %v, %v, want %v, true", tc.in, got, ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeNoncompliant(t *testing.T) {
+	typeErr := func(path *gpb.Path, s string) *ygnmi.ComplianceErrors {
+		return &ygnmi.ComplianceErrors{TypeErrors: []*ygnmi.TelemetryError{{
+			Path:  path,
+			Value: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s}},
+			Err:   errors.New("not a valid enum value"),
+		}}}
+	}
+	tests := []struct {
+		desc        string
+		errs        *ygnmi.ComplianceErrors
+		want        oc.E_IfEthernet_ETHERNET_SPEED
+		wantPresent bool
+	}{{
+		desc:        "vendor specific value",
+		errs:        typeErr(speedPath, "SPEED_2_5G"),
+		want:        oc.IfEthernet_ETHERNET_SPEED_SPEED_2500MB,
+		wantPresent: true,
+	}, {
+		desc: "not listed",
+		errs: typeErr(speedPath, "SPEED_3G"),
+	}, {
+		desc: "other path",
+		errs: typeErr(descPath, "SPEED_2_5G"),
+	}, {
+		desc: "no compliance errors",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			v := &ygnmi.Value[oc.E_IfEthernet_ETHERNET_SPEED]{Path: speedPath, ComplianceErrors: tc.errs}
+			got, present := normalize(norms, v).Val()
+			if got != tc.want || present != tc.wantPresent {
+				t.Errorf("normalize() got %v, %v, want %v, %v", got, present, tc.want, tc.wantPresent)
+			}
+		})
+	}
+}
+
+func TestNormalizeString(t *testing.T) {
+	tests := []struct {
+		desc        string
+		path        *gpb.Path
+		in          string
+		want        string
+		wantPresent bool
+	}{{
+		desc:        "translated",
+		path:        descPath,
+		in:          "n/a",
+		want:        "none",
+		wantPresent: true,
+	}, {
+		desc: "empty as unset",
+		path: descPath,
+		in:   "",
+	}, {
+		desc:        "not listed",
+		path:        descPath,
+		in:          "uplink",
+		want:        "uplink",
+		wantPresent: true,
+	}, {
+		desc:        "other path",
+		path:        &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}, {Name: "state"}, {Name: "hostname"}}},
+		in:          "",
+		want:        "",
+		wantPresent: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			v := (&ygnmi.Value[string]{Path: tc.path}).SetVal(tc.in)
+			got, present := normalize(norms, v).Val()
+			if got != tc.want || present != tc.wantPresent {
+				t.Errorf("normalize(%q) got %q, %v, want %q, %v", tc.in, got, present, tc.want, tc.wantPresent)
+			}
+		})
+	}
+}
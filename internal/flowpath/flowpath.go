@@ -0,0 +1,248 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flowpath determines which DUT egress interface each OTG flow took,
+// so that hashing and ECMP tests can assert the placement of individual flows
+// rather than only the aggregate distribution.
+//
+// Each flow is given a unique DSCP or source port marking so that the DUT
+// hashes it as a distinct flow.  The flows are then sent one at a time, and a
+// flow is attributed to the egress interface whose out-unicast-pkts counter
+// increased by at least MinFraction of the packets the flow sent.
+//
+// Distribution and CheckDistribution analyze the aggregate distribution of
+// packet counts, such as over the members of a weighted next-hop group.
+package flowpath
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// Marking is the header field used to make each flow unique.
+type Marking int
+
+const (
+	// DSCP sets the IPv4 or IPv6 DSCP of each flow.
+	DSCP Marking = iota
+	// SrcPort sets the TCP or UDP source port of each flow.
+	SrcPort
+)
+
+var (
+	// MinFraction is the fraction of the packets sent by a flow that an
+	// egress interface must carry for the flow to be attributed to it.
+	MinFraction = 0.9
+	// CounterSettle is how long to wait after a flow stops for the DUT
+	// interface counters to be updated.
+	CounterSettle = 15 * time.Second
+	// FlowTimeout is how long to wait for a flow to stop.
+	FlowTimeout = time.Minute
+)
+
+// Mark sets marking m on every flow in top to base plus the index of the
+// flow.  Each flow must have an IP header for DSCP, or a TCP or UDP header
+// for SrcPort.
+func Mark(top gosnappi.Config, m Marking, base uint32) error {
+	for i, f := range top.Flows().Items() {
+		v := base + uint32(i)
+		if m == DSCP && v > 63 {
+			return fmt.Errorf("flow %s: DSCP %d out of range", f.Name(), v)
+		}
+		if err := mark(f, m, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mark(f gosnappi.Flow, m Marking, v uint32) error {
+	for _, h := range f.Packet().Items() {
+		switch {
+		case m == DSCP && h.HasIpv4():
+			h.Ipv4().Priority().Dscp().Phb().SetValue(v)
+			return nil
+		case m == DSCP && h.HasIpv6():
+			// The IPv6 traffic class is the DSCP followed by the 2-bit ECN.
+			h.Ipv6().TrafficClass().SetValue(v << 2)
+			return nil
+		case m == SrcPort && h.HasTcp():
+			h.Tcp().SrcPort().SetValue(v)
+			return nil
+		case m == SrcPort && h.HasUdp():
+			h.Udp().SrcPort().SetValue(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("flow %s has no header for marking %v", f.Name(), m)
+}
+
+func (m Marking) String() string {
+	switch m {
+	case DSCP:
+		return "DSCP"
+	case SrcPort:
+		return "SrcPort"
+	}
+	return fmt.Sprintf("Marking(%d)", int(m))
+}
+
+// Attribute sends each flow in top one at a time and returns the DUT egress
+// interface in intfs that carried each flow, keyed by flow name.  The config
+// must already be pushed to the ATE with protocols started.  Flows that cannot
+// be attributed to exactly one interface are reported as test errors and are
+// left out of the result.
+func Attribute(t testing.TB, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, top gosnappi.Config, intfs []string) map[string]string {
+	t.Helper()
+	paths := make(map[string]string)
+	for _, f := range top.Flows().Items() {
+		before := outPkts(t, dut, intfs)
+		sent := send(t, ate, f.Name())
+		time.Sleep(CounterSettle)
+		after := outPkts(t, dut, intfs)
+
+		intf, err := attribute(deltas(before, after), sent)
+		if err != nil {
+			t.Errorf("Flow %s: %v", f.Name(), err)
+			continue
+		}
+		t.Logf("Flow %s egressed on %s", f.Name(), intf)
+		paths[f.Name()] = intf
+	}
+	return paths
+}
+
+// Placement returns the flows attributed to each interface by Attribute,
+// sorted by flow name.
+func Placement(paths map[string]string) map[string][]string {
+	p := make(map[string][]string)
+	for flow, intf := range paths {
+		p[intf] = append(p[intf], flow)
+	}
+	for _, flows := range p {
+		sort.Strings(flows)
+	}
+	return p
+}
+
+// send starts flow, waits for it to stop and returns the number of packets it
+// sent.
+func send(t testing.TB, ate *ondatra.ATEDevice, flow string) uint64 {
+	t.Helper()
+	cs := gosnappi.NewControlState()
+	cs.Traffic().FlowTransmit().SetState(gosnappi.StateTrafficFlowTransmitState.START).SetFlowNames([]string{flow})
+	ate.OTG().SetControlState(t, cs)
+	gnmi.Await(t, ate.OTG(), gnmi.OTG().Flow(flow).Transmit().State(), FlowTimeout, false)
+	cs.Traffic().FlowTransmit().SetState(gosnappi.StateTrafficFlowTransmitState.STOP)
+	ate.OTG().SetControlState(t, cs)
+	return gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(flow).Counters().OutPkts().State())
+}
+
+// outPkts returns the out-unicast-pkts counter of each interface.  The
+// counters container is read so that devices which only support querying the
+// container are handled too.
+func outPkts(t testing.TB, dut *ondatra.DUTDevice, intfs []string) map[string]uint64 {
+	t.Helper()
+	c := make(map[string]uint64)
+	for _, intf := range intfs {
+		c[intf] = gnmi.Get(t, dut, gnmi.OC().Interface(intf).Counters().State()).GetOutUnicastPkts()
+	}
+	return c
+}
+
+func deltas(before, after map[string]uint64) map[string]uint64 {
+	d := make(map[string]uint64)
+	for intf, a := range after {
+		if b := before[intf]; a >= b {
+			d[intf] = a - b
+		}
+	}
+	return d
+}
+
+// attribute returns the interface whose counter delta accounts for at least
+// MinFraction of sent packets.
+func attribute(deltas map[string]uint64, sent uint64) (string, error) {
+	if sent == 0 {
+		return "", fmt.Errorf("no packets sent")
+	}
+	var found []string
+	for intf, d := range deltas {
+		if float64(d) >= MinFraction*float64(sent) {
+			found = append(found, intf)
+		}
+	}
+	sort.Strings(found)
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no interface carried %.0f%% of %d packets sent, counter deltas: %v", MinFraction*100, sent, deltas)
+	case 1:
+		return found[0], nil
+	}
+	return "", fmt.Errorf("interfaces %v each carried %.0f%% of %d packets sent, the interface counters include other traffic", found, MinFraction*100, sent)
+}
+
+// Distribution returns the share of the total of counts of each key, as a
+// percentage.  All shares are 0 if the total is 0.
+func Distribution(counts map[string]uint64) map[string]float64 {
+	var total uint64
+	for _, c := range counts {
+		total += c
+	}
+	d := make(map[string]float64)
+	for k, c := range counts {
+		if total > 0 {
+			d[k] = 100 * float64(c) / float64(total)
+		} else {
+			d[k] = 0
+		}
+	}
+	return d
+}
+
+// CheckDistribution returns an error if the share of counts of any key in
+// weights differs from its share of the total weight by more than tolerance
+// percentage points.  Keys of counts that are not in weights are expected to
+// carry nothing.
+func CheckDistribution(counts, weights map[string]uint64, tolerance float64) error {
+	got := Distribution(counts)
+	want := Distribution(weights)
+	keys := make([]string, 0, len(got)+len(want))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var errs []string
+	for _, k := range keys {
+		if diff := got[k] - want[k]; diff > tolerance || diff < -tolerance {
+			errs = append(errs, fmt.Sprintf("%s: got %.2f%%, want %.2f%%", k, got[k], want[k]))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("distribution not within %.2f percentage points of the weights: %s", tolerance, strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowpath
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestAttribute(t *testing.T) {
+	tests := []struct {
+		desc    string
+		deltas  map[string]uint64
+		sent    uint64
+		want    string
+		wantErr bool
+	}{{
+		desc:   "single interface",
+		deltas: map[string]uint64{"Ethernet1": 1000, "Ethernet2": 3},
+		sent:   1000,
+		want:   "Ethernet1",
+	}, {
+		desc:   "interface with background traffic",
+		deltas: map[string]uint64{"Ethernet1": 2, "Ethernet2": 1010},
+		sent:   1000,
+		want:   "Ethernet2",
+	}, {
+		desc:   "within tolerance of drops",
+		deltas: map[string]uint64{"Ethernet1": 950},
+		sent:   1000,
+		want:   "Ethernet1",
+	}, {
+		desc:    "flow split across interfaces",
+		deltas:  map[string]uint64{"Ethernet1": 500, "Ethernet2": 500},
+		sent:    1000,
+		wantErr: true,
+	}, {
+		desc:    "flow on several interfaces",
+		deltas:  map[string]uint64{"Ethernet1": 1000, "Ethernet2": 1000},
+		sent:    1000,
+		wantErr: true,
+	}, {
+		desc:    "nothing sent",
+		deltas:  map[string]uint64{"Ethernet1": 0},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := attribute(tc.deltas, tc.sent)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("attribute() got error %v, want error %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("attribute() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeltas(t *testing.T) {
+	before := map[string]uint64{"Ethernet1": 10, "Ethernet2": 100}
+	after := map[string]uint64{"Ethernet1": 25, "Ethernet2": 5, "Ethernet3": 7}
+	want := map[string]uint64{"Ethernet1": 15, "Ethernet3": 7}
+	if diff := cmp.Diff(want, deltas(before, after)); diff != "" {
+		t.Errorf("deltas() -want,+got:\n%s", diff)
+	}
+}
+
+func TestMark(t *testing.T) {
+	top := gosnappi.NewConfig()
+	for _, name := range []string{"v4", "v6"} {
+		f := top.Flows().Add().SetName(name)
+		f.Packet().Add().Ethernet()
+		if name == "v4" {
+			f.Packet().Add().Ipv4()
+		} else {
+			f.Packet().Add().Ipv6()
+		}
+		f.Packet().Add().Udp()
+	}
+
+	if err := Mark(top, DSCP, 10); err != nil {
+		t.Fatalf("Mark(DSCP) failed: %v", err)
+	}
+	if got := top.Flows().Items()[0].Packet().Items()[1].Ipv4().Priority().Dscp().Phb().Value(); got != 10 {
+		t.Errorf("Mark(DSCP) flow v4 DSCP: got %d, want 10", got)
+	}
+	if got := top.Flows().Items()[1].Packet().Items()[1].Ipv6().TrafficClass().Value(); got != 11<<2 {
+		t.Errorf("Mark(DSCP) flow v6 traffic class: got %d, want %d", got, 11<<2)
+	}
+
+	if err := Mark(top, SrcPort, 5000); err != nil {
+		t.Fatalf("Mark(SrcPort) failed: %v", err)
+	}
+	for i, f := range top.Flows().Items() {
+		if got, want := f.Packet().Items()[2].Udp().SrcPort().Value(), uint32(5000+i); got != want {
+			t.Errorf("Mark(SrcPort) flow %s source port: got %d, want %d", f.Name(), got, want)
+		}
+	}
+
+	if err := Mark(top, DSCP, 63); err == nil {
+		t.Errorf("Mark(DSCP) with out of range value succeeded, want error")
+	}
+	noL4 := gosnappi.NewConfig()
+	noL4.Flows().Add().SetName("eth").Packet().Add().Ethernet()
+	if err := Mark(noL4, SrcPort, 5000); err == nil {
+		t.Errorf("Mark(SrcPort) on flow without TCP or UDP succeeded, want error")
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	got := Distribution(map[string]uint64{"Ethernet1": 250, "Ethernet2": 750, "Ethernet3": 0})
+	want := map[string]float64{"Ethernet1": 25, "Ethernet2": 75, "Ethernet3": 0}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Distribution() -want,+got:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]float64{"Ethernet1": 0}, Distribution(map[string]uint64{"Ethernet1": 0})); diff != "" {
+		t.Errorf("Distribution() of no packets -want,+got:\n%s", diff)
+	}
+}
+
+func TestCheckDistribution(t *testing.T) {
+	weights := map[string]uint64{"Ethernet1": 1, "Ethernet2": 3}
+	tests := []struct {
+		desc    string
+		counts  map[string]uint64
+		wantErr bool
+	}{{
+		desc:   "exact",
+		counts: map[string]uint64{"Ethernet1": 250, "Ethernet2": 750},
+	}, {
+		desc:   "within tolerance",
+		counts: map[string]uint64{"Ethernet1": 265, "Ethernet2": 735},
+	}, {
+		desc:    "outside tolerance",
+		counts:  map[string]uint64{"Ethernet1": 500, "Ethernet2": 500},
+		wantErr: true,
+	}, {
+		desc:    "member missing",
+		counts:  map[string]uint64{"Ethernet2": 1000},
+		wantErr: true,
+	}, {
+		desc:    "traffic on unweighted interface",
+		counts:  map[string]uint64{"Ethernet1": 200, "Ethernet2": 600, "Ethernet3": 200},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := CheckDistribution(tc.counts, weights, 2); (err != nil) != tc.wantErr {
+				t.Errorf("CheckDistribution() got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
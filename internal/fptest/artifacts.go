@@ -0,0 +1,166 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	log "github.com/golang/glog"
+)
+
+var (
+	artifactRetention = flag.String("artifact_retention", retainAlways,
+		`which test artifacts to keep in --outputs_dir: "always" or "on_failure"`)
+	maxArtifactsSize = flag.Int64("max_artifacts_size", 0,
+		"maximum total size in bytes of the test artifacts kept in --outputs_dir, or 0 for no limit")
+)
+
+const (
+	retainAlways    = "always"
+	retainOnFailure = "on_failure"
+
+	// artifactsSubdir is the subdirectory of --outputs_dir with the
+	// artifact directories of the tests.
+	artifactsSubdir = "artifacts"
+)
+
+// artifactDir is the artifact directory of a test.
+type artifactDir struct {
+	path   string
+	failed bool
+	done   bool
+}
+
+var (
+	artifactsMu sync.Mutex
+	// artifactDirs are in the order they were created.
+	artifactDirs []*artifactDir
+)
+
+// ArtifactDir returns a new directory for the large artifacts of test t, such
+// as packet captures, Healthz artifacts or tech-support bundles.  The
+// directory is in --outputs_dir, or is a temporary directory if test outputs
+// are discarded.
+//
+// When t completes, its directory is removed if t passed and
+// --artifact_retention is "on_failure".  Then, if the artifacts of all tests
+// exceed --max_artifacts_size, the directories of completed tests are removed
+// until they do not: those of passed tests first, then those of failed tests,
+// oldest first.  The directory of a test that is still running is never
+// removed.
+func ArtifactDir(t testing.TB) string {
+	t.Helper()
+	if *outputsDir == "" {
+		return t.TempDir()
+	}
+	if err := checkRetention(*artifactRetention); err != nil {
+		t.Fatalf("Cannot create artifact directory: %v", err)
+	}
+	parent := filepath.Join(*outputsDir, artifactsSubdir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		t.Fatalf("Cannot create artifact directory: %v", err)
+	}
+	path, err := os.MkdirTemp(parent, sanitizeFilename(t.Name())+".*")
+	if err != nil {
+		t.Fatalf("Cannot create artifact directory: %v", err)
+	}
+	d := &artifactDir{path: path}
+	artifactsMu.Lock()
+	artifactDirs = append(artifactDirs, d)
+	artifactsMu.Unlock()
+
+	t.Cleanup(func() {
+		artifactsMu.Lock()
+		defer artifactsMu.Unlock()
+		d.failed = t.Failed()
+		d.done = true
+		if !d.failed && *artifactRetention == retainOnFailure {
+			removeArtifactDir(d)
+		}
+		artifactDirs = pruneArtifactDirs(artifactDirs, *maxArtifactsSize)
+	})
+	return path
+}
+
+func checkRetention(retention string) error {
+	switch retention {
+	case retainAlways, retainOnFailure:
+		return nil
+	}
+	return fmt.Errorf("invalid --artifact_retention %q, want %q or %q", retention, retainAlways, retainOnFailure)
+}
+
+// pruneArtifactDirs removes directories of completed tests in dirs until the
+// total size of dirs is at most max, and returns the directories left.
+func pruneArtifactDirs(dirs []*artifactDir, max int64) []*artifactDir {
+	var total int64
+	sizes := make(map[*artifactDir]int64)
+	for _, d := range dirs {
+		sizes[d] = dirSize(d.path)
+		total += sizes[d]
+	}
+	if max > 0 {
+		for _, failed := range []bool{false, true} {
+			for _, d := range dirs {
+				if total <= max {
+					break
+				}
+				if d.done && d.failed == failed && sizes[d] > 0 {
+					log.Infof("Removing test artifacts %s of %d bytes to keep the artifacts within %d bytes", d.path, sizes[d], max)
+					removeArtifactDir(d)
+					total -= sizes[d]
+					sizes[d] = 0
+				}
+			}
+		}
+		if total > max {
+			log.Warningf("Test artifacts of %d bytes exceed --max_artifacts_size %d bytes", total, max)
+		}
+	}
+	var left []*artifactDir
+	for _, d := range dirs {
+		if _, err := os.Stat(d.path); err == nil {
+			left = append(left, d)
+		}
+	}
+	return left
+}
+
+func removeArtifactDir(d *artifactDir) {
+	if err := os.RemoveAll(d.path); err != nil {
+		log.Errorf("Unable to remove test artifacts %s: %v", d.path, err)
+	}
+}
+
+// dirSize returns the total size of the regular files in dir.
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(_ string, e fs.DirEntry, err error) error {
+		if err != nil || !e.Type().IsRegular() {
+			return nil
+		}
+		if info, err := e.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setFlag sets the value of flag p to v for the duration of test t.
+func setFlag[T any](t *testing.T, p *T, v T) {
+	orig := *p
+	*p = v
+	t.Cleanup(func() { *p = orig })
+}
+
+func writeArtifact(t *testing.T, dir string, size int) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "artifact"), make([]byte, size), 0o644); err != nil {
+		t.Fatalf("Cannot write artifact: %v", err)
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestArtifactDir(t *testing.T) {
+	tests := []struct {
+		retention string
+		wantKept  bool
+	}{
+		{retainAlways, true},
+		{retainOnFailure, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.retention, func(t *testing.T) {
+			setFlag(t, outputsDir, t.TempDir())
+			setFlag(t, artifactRetention, tt.retention)
+			setFlag(t, maxArtifactsSize, 0)
+
+			var dir string
+			t.Run("passing test", func(t *testing.T) {
+				dir = ArtifactDir(t)
+				if !strings.HasPrefix(dir, filepath.Join(*outputsDir, artifactsSubdir)) {
+					t.Errorf("ArtifactDir() got %s, want a directory in %s", dir, *outputsDir)
+				}
+				writeArtifact(t, dir, 10)
+			})
+			if got := exists(dir); got != tt.wantKept {
+				t.Errorf("Artifact directory of passed test kept: got %t, want %t", got, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestArtifactDirDiscarded(t *testing.T) {
+	setFlag(t, outputsDir, "")
+	if dir := ArtifactDir(t); dir == "" || !exists(dir) {
+		t.Errorf("ArtifactDir() got %q, want a temporary directory", dir)
+	}
+}
+
+func TestPruneArtifactDirs(t *testing.T) {
+	newDir := func(size int, done, failed bool) *artifactDir {
+		d := &artifactDir{path: t.TempDir(), done: done, failed: failed}
+		writeArtifact(t, d.path, size)
+		return d
+	}
+	failedOld := newDir(100, true, true)
+	passedOld := newDir(100, true, false)
+	failedNew := newDir(100, true, true)
+	passedNew := newDir(100, true, false)
+	running := newDir(100, false, false)
+	all := []*artifactDir{failedOld, passedOld, failedNew, passedNew, running}
+
+	tests := []struct {
+		desc string
+		max  int64
+		want []*artifactDir
+	}{{
+		desc: "no limit",
+		max:  0,
+		want: all,
+	}, {
+		desc: "within limit",
+		max:  500,
+		want: all,
+	}, {
+		desc: "oldest passed removed",
+		max:  400,
+		want: []*artifactDir{failedOld, failedNew, passedNew, running},
+	}, {
+		desc: "passed removed before failed",
+		max:  300,
+		want: []*artifactDir{failedOld, failedNew, running},
+	}, {
+		desc: "oldest failed removed",
+		max:  200,
+		want: []*artifactDir{failedNew, running},
+	}, {
+		desc: "running test kept",
+		max:  50,
+		want: []*artifactDir{running},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Each case prunes what the previous one left.
+			got := pruneArtifactDirs(all, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("pruneArtifactDirs(%d) left %d directories, want %d", tt.max, len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("pruneArtifactDirs(%d) directory %d: got %s, want %s", tt.max, i, got[i].path, tt.want[i].path)
+				}
+			}
+			all = got
+		})
+	}
+}
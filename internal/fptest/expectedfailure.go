@@ -0,0 +1,198 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/metadata"
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	"github.com/openconfig/ondatra"
+
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// ExpectFailure runs f as the body of t, unless the test metadata declares
+// that t is expected to fail on the platform of dut.
+//
+// If a matching expected_failures entry sets skip, f is not run and t is
+// skipped.  Otherwise f is run and its errors and fatal failures are logged
+// instead of failing t, which is then skipped with an "EXPECTED FAILURE"
+// message.  If f skips without reporting an error, t is skipped.  If f
+// passes, t fails so the stale entry is removed from the metadata.
+//
+// Example:
+//
+//	t.Run("Queue Input Dropped packets", func(t *testing.T) {
+//	  fptest.ExpectFailure(t, dut, func(t testing.TB) {
+//	    ...
+//	  })
+//	})
+func ExpectFailure(t *testing.T, dut *ondatra.DUTDevice, f func(t testing.TB)) {
+	t.Helper()
+	ef, err := lookupExpectedFailure(metadata.Get(), dut.Vendor(), dut.Model(), dut.Version(), t.Name())
+	if err != nil {
+		t.Fatalf("Error looking up expected failures: %v", err)
+	}
+	if ef == nil {
+		f(t)
+		return
+	}
+	if ef.GetSkip() {
+		t.Skipf("EXPECTED FAILURE on %v %s, skipping: %s", dut.Vendor(), dut.Model(), ef.GetReason())
+	}
+	ft := captureFailures(t, f)
+	errs := ft.errs
+	if len(errs) == 0 {
+		if ft.skipped {
+			t.Skipf("Skipped before failing as expected on %v %s: %s", dut.Vendor(), dut.Model(), ft.skipMsg)
+		}
+		t.Fatalf("Test passed on %v %s but is listed in expected_failures (%s); remove the entry from metadata.textproto", dut.Vendor(), dut.Model(), ef.GetReason())
+	}
+	for _, err := range errs {
+		t.Logf("Expected error: %s", err)
+	}
+	t.Skipf("EXPECTED FAILURE on %v %s with %d error(s): %s", dut.Vendor(), dut.Model(), len(errs), ef.GetReason())
+}
+
+// lookupExpectedFailure returns the expected_failures entry in md matching the
+// platform and test name, or nil if there is none.
+func lookupExpectedFailure(md *mpb.Metadata, vendor ondatra.Vendor, model, version, name string) (*mpb.Metadata_ExpectedFailure, error) {
+	for _, ef := range md.GetExpectedFailures() {
+		platform := ef.GetPlatform()
+		if platform.GetVendor() == opb.Device_VENDOR_UNSPECIFIED {
+			return nil, fmt.Errorf("vendor should be specified in textproto %v", ef)
+		}
+		if vendor.String() != platform.GetVendor().String() {
+			continue
+		}
+		for _, m := range []struct{ re, s string }{
+			{platform.GetHardwareModelRegex(), model},
+			{platform.GetSoftwareVersionRegex(), version},
+			{ef.GetTestNameRegex(), name},
+		} {
+			if m.re == "" {
+				continue
+			}
+			ok, err := regexp.MatchString(m.re, m.s)
+			if err != nil {
+				return nil, fmt.Errorf("error with regex match %v", err)
+			}
+			if !ok {
+				ef = nil
+				break
+			}
+		}
+		if ef != nil {
+			return ef, nil
+		}
+	}
+	return nil, nil
+}
+
+// failureT records errors, fatal failures and skips instead of reporting them
+// to the embedded testing.TB, which must not be failed or skipped from the
+// goroutine running f.
+type failureT struct {
+	testing.TB
+	mu      sync.Mutex
+	errs    []string
+	skipped bool
+	skipMsg string
+}
+
+func (ft *failureT) Error(args ...any) {
+	ft.record(fmt.Sprint(args...))
+}
+
+func (ft *failureT) Errorf(format string, args ...any) {
+	ft.record(fmt.Sprintf(format, args...))
+}
+
+func (ft *failureT) Fail() {
+	ft.record("Fail called")
+}
+
+func (ft *failureT) FailNow() {
+	ft.record("FailNow called")
+	runtime.Goexit()
+}
+
+func (ft *failureT) Fatal(args ...any) {
+	ft.record(fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+func (ft *failureT) Fatalf(format string, args ...any) {
+	ft.record(fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func (ft *failureT) Failed() bool {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return len(ft.errs) > 0
+}
+
+func (ft *failureT) Skip(args ...any) {
+	ft.skip(fmt.Sprint(args...))
+}
+
+func (ft *failureT) Skipf(format string, args ...any) {
+	ft.skip(fmt.Sprintf(format, args...))
+}
+
+func (ft *failureT) SkipNow() {
+	ft.skip("SkipNow called")
+}
+
+func (ft *failureT) Skipped() bool {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.skipped
+}
+
+func (ft *failureT) Helper() {}
+
+func (ft *failureT) skip(msg string) {
+	ft.mu.Lock()
+	ft.skipped = true
+	ft.skipMsg = msg
+	ft.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (ft *failureT) record(msg string) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.errs = append(ft.errs, msg)
+}
+
+// captureFailures runs f and returns the failureT holding the errors, fatal
+// failures and skip it reported.
+func captureFailures(t testing.TB, f func(t testing.TB)) *failureT {
+	ft := &failureT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(ft)
+	}()
+	<-done
+	return ft
+}
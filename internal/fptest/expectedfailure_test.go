@@ -0,0 +1,174 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	"github.com/openconfig/ondatra"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+func TestLookupExpectedFailure(t *testing.T) {
+	juniperQueue := &mpb.Metadata_ExpectedFailure{
+		Platform:      &mpb.Metadata_Platform{Vendor: opb.Device_JUNIPER},
+		TestNameRegex: "/Queue_Input_Dropped_packets$",
+		Reason:        "input queue drops are not supported",
+	}
+	aristaModel := &mpb.Metadata_ExpectedFailure{
+		Platform: &mpb.Metadata_Platform{
+			Vendor:               opb.Device_ARISTA,
+			HardwareModelRegex:   "^7280",
+			SoftwareVersionRegex: "^4\\.3",
+		},
+		Skip: true,
+	}
+	md := &mpb.Metadata{ExpectedFailures: []*mpb.Metadata_ExpectedFailure{juniperQueue, aristaModel}}
+
+	tests := []struct {
+		desc    string
+		md      *mpb.Metadata
+		vendor  ondatra.Vendor
+		model   string
+		version string
+		name    string
+		want    *mpb.Metadata_ExpectedFailure
+		wantErr bool
+	}{{
+		desc:   "no expected failures",
+		md:     &mpb.Metadata{},
+		vendor: ondatra.JUNIPER,
+		name:   "TestHealth/et-0/0/0/Queue_Input_Dropped_packets",
+	}, {
+		desc:   "vendor and name match",
+		md:     md,
+		vendor: ondatra.JUNIPER,
+		name:   "TestHealth/et-0/0/0/Queue_Input_Dropped_packets",
+		want:   juniperQueue,
+	}, {
+		desc:   "name does not match",
+		md:     md,
+		vendor: ondatra.JUNIPER,
+		name:   "TestHealth/et-0/0/0/Queue_Output_Dropped_packets",
+	}, {
+		desc:   "vendor does not match",
+		md:     md,
+		vendor: ondatra.CISCO,
+		name:   "TestHealth/et-0/0/0/Queue_Input_Dropped_packets",
+	}, {
+		desc:    "model and version match with empty name regex",
+		md:      md,
+		vendor:  ondatra.ARISTA,
+		model:   "7280R3",
+		version: "4.31.1F",
+		name:    "TestAnything",
+		want:    aristaModel,
+	}, {
+		desc:    "version does not match",
+		md:      md,
+		vendor:  ondatra.ARISTA,
+		model:   "7280R3",
+		version: "4.29.2F",
+		name:    "TestAnything",
+	}, {
+		desc: "missing vendor",
+		md: &mpb.Metadata{ExpectedFailures: []*mpb.Metadata_ExpectedFailure{{
+			TestNameRegex: "TestAnything",
+		}}},
+		vendor:  ondatra.ARISTA,
+		name:    "TestAnything",
+		wantErr: true,
+	}, {
+		desc: "invalid regex",
+		md: &mpb.Metadata{ExpectedFailures: []*mpb.Metadata_ExpectedFailure{{
+			Platform:      &mpb.Metadata_Platform{Vendor: opb.Device_NOKIA},
+			TestNameRegex: "(",
+		}}},
+		vendor:  ondatra.NOKIA,
+		name:    "TestAnything",
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := lookupExpectedFailure(tc.md, tc.vendor, tc.model, tc.version, tc.name)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("lookupExpectedFailure() got error %v, want error %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("lookupExpectedFailure() -want,+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCaptureFailures(t *testing.T) {
+	tests := []struct {
+		desc        string
+		f           func(t testing.TB)
+		want        []string
+		wantSkipped bool
+	}{{
+		desc: "pass",
+		f:    func(t testing.TB) { t.Log("ok") },
+	}, {
+		desc: "errors",
+		f: func(t testing.TB) {
+			t.Errorf("first %d", 1)
+			t.Error("second")
+		},
+		want: []string{"first 1", "second"},
+	}, {
+		desc: "fatal stops the function",
+		f: func(t testing.TB) {
+			t.Error("before")
+			t.Fatalf("fatal %s", "error")
+			t.Error("after")
+		},
+		want: []string{"before", "fatal error"},
+	}, {
+		desc: "skip stops the function",
+		f: func(t testing.TB) {
+			t.Skipf("skip %s", "reason")
+			t.Error("after")
+		},
+		wantSkipped: true,
+	}, {
+		desc: "errors before skip",
+		f: func(t testing.TB) {
+			t.Error("before")
+			t.SkipNow()
+		},
+		want:        []string{"before"},
+		wantSkipped: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := captureFailures(t, tc.f)
+			if diff := cmp.Diff(tc.want, ft.errs); diff != "" {
+				t.Errorf("captureFailures() -want,+got:\n%s", diff)
+			}
+			if ft.skipped != tc.wantSkipped {
+				t.Errorf("captureFailures() got skipped %v, want %v", ft.skipped, tc.wantSkipped)
+			}
+			if t.Skipped() {
+				t.Errorf("captureFailures() skipped the calling test")
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jstemmer/go-junit-report/v2/gtr"
+	"github.com/jstemmer/go-junit-report/v2/junit"
+	"github.com/jstemmer/go-junit-report/v2/parser/gotest"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	rpb "github.com/openconfig/featureprofiles/proto/results_go_proto"
+)
+
+var (
+	resultsFormat = flag.String("results_format", "",
+		"format of the structured test results written to --results_file: junit, proto, textproto, or a format added by RegisterExporter")
+	resultsFile = flag.String("results_file", "",
+		"file to write the structured test results to in --results_format")
+)
+
+// junitFormat is the --results_format of JUnit XML.  JUnit XML is written
+// from the parsed test output rather than from the results proto, so that it
+// matches the report of go-junit-report.
+const junitFormat = "junit"
+
+// Exporter writes the results of a test run to a results sink.
+type Exporter interface {
+	Export(w io.Writer, results *rpb.Results) error
+}
+
+// ExporterFunc adapts a function to an Exporter.
+type ExporterFunc func(w io.Writer, results *rpb.Results) error
+
+// Export calls f(w, results).
+func (f ExporterFunc) Export(w io.Writer, results *rpb.Results) error {
+	return f(w, results)
+}
+
+var (
+	exportersMu sync.Mutex
+	exporters   = map[string]Exporter{
+		"proto":     ExporterFunc(exportProto),
+		"textproto": ExporterFunc(exportTextproto),
+	}
+)
+
+// RegisterExporter makes an Exporter available as --results_format=format.
+// It should be called from an init function of a package linked into the
+// test, so that the exporter is registered before RunTests.  Registering a
+// format again replaces its exporter.  The junit format is built in and
+// cannot be replaced.
+func RegisterExporter(format string, e Exporter) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	exporters[format] = e
+}
+
+func exporter(format string) (Exporter, error) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	e, ok := exporters[format]
+	if !ok {
+		formats := []string{junitFormat}
+		for f := range exporters {
+			formats = append(formats, f)
+		}
+		sort.Strings(formats)
+		return nil, fmt.Errorf("unknown results format %q, want one of %v", format, formats)
+	}
+	return e, nil
+}
+
+// resultsCapture parses the test output written to os.Stdout while passing
+// it through unchanged.
+type resultsCapture struct {
+	stdout *os.File
+	pw     *os.File
+	done   chan struct{}
+	report gtr.Report
+	err    error
+}
+
+// startResultsCapture replaces os.Stdout with a pipe whose output is parsed
+// as go test output.  The test verbose flag is needed for the results of
+// passed tests to be captured.
+func startResultsCapture(pkg string) (*resultsCapture, error) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pipe: %w", err)
+	}
+	c := &resultsCapture{stdout: os.Stdout, pw: pw, done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		parser := gotest.NewParser(gotest.PackageName(pkg), gotest.TimestampFunc(time.Now))
+		c.report, c.err = parser.Parse(io.TeeReader(pr, c.stdout))
+		pr.Close()
+	}()
+	os.Stdout = pw
+	return c, nil
+}
+
+// stop restores os.Stdout and returns the parsed results.
+func (c *resultsCapture) stop() (*rpb.Results, error) {
+	os.Stdout = c.stdout
+	if err := c.pw.Close(); err != nil {
+		return nil, err
+	}
+	<-c.done
+	if c.err != nil {
+		return nil, fmt.Errorf("error parsing test output: %w", c.err)
+	}
+	if len(c.report.Packages) != 1 {
+		return nil, fmt.Errorf("got %d packages in test output, want 1", len(c.report.Packages))
+	}
+	return resultsFromPackage(c.report.Packages[0]), nil
+}
+
+var statusFromResult = map[gtr.Result]rpb.Status{
+	gtr.Pass: rpb.Status_PASSED,
+	gtr.Fail: rpb.Status_FAILED,
+	gtr.Skip: rpb.Status_SKIPPED,
+}
+
+func resultsFromPackage(p gtr.Package) *rpb.Results {
+	res := &rpb.Results{
+		Package: p.Name,
+		Status:  rpb.Status_PASSED,
+		Output:  p.Output,
+	}
+	if !p.Timestamp.IsZero() {
+		res.StartTime = timestamppb.New(p.Timestamp)
+	}
+	var d time.Duration
+	for _, t := range p.Tests {
+		d += t.Duration
+		tr := &rpb.TestResult{
+			Name:     t.Name,
+			Status:   statusFromResult[t.Result],
+			Duration: durationpb.New(t.Duration),
+			Output:   t.Output,
+			Waivers:  waivers(t.Output),
+		}
+		if tr.GetStatus() == rpb.Status_FAILED {
+			res.Status = rpb.Status_FAILED
+		}
+		if len(tr.GetWaivers()) > 0 {
+			res.Waived = true
+		}
+		res.Tests = append(res.Tests, tr)
+	}
+	if p.RunError.Name != "" || p.BuildError.Name != "" {
+		res.Status = rpb.Status_FAILED
+	}
+	if p.Duration != 0 {
+		d = p.Duration
+	}
+	res.Duration = durationpb.New(d)
+	return res
+}
+
+// exportResults writes the results captured by c to --results_file in
+// --results_format.
+func exportResults(c *resultsCapture, results *rpb.Results) error {
+	var export func(w io.Writer) error
+	if *resultsFormat == junitFormat {
+		export = exportJUnit(c.report)
+	} else {
+		e, err := exporter(*resultsFormat)
+		if err != nil {
+			return err
+		}
+		export = func(w io.Writer) error { return e.Export(w, results) }
+	}
+	f, err := os.Create(*resultsFile)
+	if err != nil {
+		return fmt.Errorf("failed to create results file: %w", err)
+	}
+	if err := export(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to export %s results: %w", *resultsFormat, err)
+	}
+	return f.Close()
+}
+
+// packageName returns the package name reported in the results, which is
+// the name of the test binary without the .test suffix.
+func packageName() string {
+	return strings.TrimSuffix(filepath.Base(os.Args[0]), ".test")
+}
+
+func exportProto(w io.Writer, results *rpb.Results) error {
+	b, err := proto.Marshal(results)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func exportTextproto(w io.Writer, results *rpb.Results) error {
+	b, err := prototext.MarshalOptions{Multiline: true}.Marshal(results)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// exportJUnit returns a function writing report as JUnit XML.
+func exportJUnit(report gtr.Report) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		hostname, _ := os.Hostname()
+		suites := junit.CreateFromReport(report, hostname)
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		return suites.WriteXML(w)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jstemmer/go-junit-report/v2/junit"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	rpb "github.com/openconfig/featureprofiles/proto/results_go_proto"
+)
+
+const testOutput = `=== RUN   TestPass
+--- PASS: TestPass (1.50s)
+=== RUN   TestFail
+=== RUN   TestFail/Sub
+    foo_test.go:10: something went wrong
+--- FAIL: TestFail (2.00s)
+    --- FAIL: TestFail/Sub (2.00s)
+=== RUN   TestSkip
+    foo_test.go:20: not supported
+--- SKIP: TestSkip (0.00s)
+FAIL
+`
+
+func captureResults(t *testing.T, output string) *rpb.Results {
+	t.Helper()
+	res, _ := captureOutput(t, output)
+	return res
+}
+
+// captureOutput returns the results parsed from output and the capture that
+// parsed it.
+func captureOutput(t *testing.T, output string) (*rpb.Results, *resultsCapture) {
+	t.Helper()
+	// Discard the passed through output instead of mixing it into the output
+	// of this test.
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	c, err := startResultsCapture("foo")
+	if err != nil {
+		t.Fatalf("startResultsCapture() got error: %v", err)
+	}
+	fmt.Fprint(os.Stdout, output)
+	res, err := c.stop()
+	if err != nil {
+		t.Fatalf("stop() got error: %v", err)
+	}
+	if os.Stdout != devNull {
+		t.Errorf("stop() did not restore os.Stdout")
+	}
+	return res, c
+}
+
+func TestCaptureResults(t *testing.T) {
+	want := &rpb.Results{
+		Package: "foo",
+		Status:  rpb.Status_FAILED,
+		Tests: []*rpb.TestResult{{
+			Name:     "TestPass",
+			Status:   rpb.Status_PASSED,
+			Duration: durationpb.New(1500 * time.Millisecond),
+		}, {
+			Name:     "TestFail",
+			Status:   rpb.Status_FAILED,
+			Duration: durationpb.New(2 * time.Second),
+		}, {
+			Name:     "TestFail/Sub",
+			Status:   rpb.Status_FAILED,
+			Duration: durationpb.New(2 * time.Second),
+			Output:   []string{"    foo_test.go:10: something went wrong"},
+		}, {
+			Name:     "TestSkip",
+			Status:   rpb.Status_SKIPPED,
+			Duration: durationpb.New(0),
+			Output:   []string{"    foo_test.go:20: not supported"},
+		}},
+	}
+	got := captureResults(t, testOutput)
+	opts := []cmp.Option{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&rpb.Results{}, "start_time", "duration", "output"),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Captured results differ (-want +got):\n%s", diff)
+	}
+}
+
+func TestCaptureWaivers(t *testing.T) {
+	const output = `=== RUN   TestWaived
+    foo_test.go:10: WAIVED by deviation switch_chip_id_unsupported: not checking id of SwitchChip1
+    foo_test.go:11: checked name of SwitchChip1
+--- PASS: TestWaived (1.00s)
+=== RUN   TestClean
+    foo_test.go:20: WAIVED by nothing
+--- PASS: TestClean (1.00s)
+PASS
+`
+	want := &rpb.Results{
+		Package: "foo",
+		Status:  rpb.Status_PASSED,
+		Waived:  true,
+		Tests: []*rpb.TestResult{{
+			Name:   "TestWaived",
+			Status: rpb.Status_PASSED,
+			Waivers: []*rpb.Waiver{{
+				Deviation: "switch_chip_id_unsupported",
+				Reason:    "not checking id of SwitchChip1",
+			}},
+		}, {
+			Name:   "TestClean",
+			Status: rpb.Status_PASSED,
+		}},
+	}
+	got := captureResults(t, output)
+	opts := []cmp.Option{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&rpb.Results{}, "start_time", "duration", "output"),
+		protocmp.IgnoreFields(&rpb.TestResult{}, "duration", "output"),
+	}
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Errorf("Captured results differ (-want +got):\n%s", diff)
+	}
+
+}
+
+func TestExportJUnit(t *testing.T) {
+	_, c := captureOutput(t, testOutput)
+	var buf bytes.Buffer
+	if err := exportJUnit(c.report)(&buf); err != nil {
+		t.Fatalf("exportJUnit() got error: %v", err)
+	}
+	var suites junit.Testsuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("Unable to parse exported JUnit XML: %v", err)
+	}
+	if len(suites.Suites) != 1 {
+		t.Fatalf("Got %d test suites, want 1", len(suites.Suites))
+	}
+	s := suites.Suites[0]
+	if s.Name != "foo" || s.Tests != 4 || s.Failures != 2 || s.Skipped != 1 {
+		t.Errorf("Got test suite %q with %d tests, %d failures and %d skipped, want \"foo\" with 4 tests, 2 failures and 1 skipped", s.Name, s.Tests, s.Failures, s.Skipped)
+	}
+}
+
+func TestExportProto(t *testing.T) {
+	want := captureResults(t, testOutput)
+	for _, format := range []string{"proto", "textproto"} {
+		t.Run(format, func(t *testing.T) {
+			e, err := exporter(format)
+			if err != nil {
+				t.Fatalf("exporter(%q) got error: %v", format, err)
+			}
+			var buf bytes.Buffer
+			if err := e.Export(&buf, want); err != nil {
+				t.Fatalf("Export() got error: %v", err)
+			}
+			got := &rpb.Results{}
+			if format == "proto" {
+				err = proto.Unmarshal(buf.Bytes(), got)
+			} else {
+				err = prototext.Unmarshal(buf.Bytes(), got)
+			}
+			if err != nil {
+				t.Fatalf("Unable to parse exported results: %v", err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Exported results differ (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegisterExporter(t *testing.T) {
+	if _, err := exporter("custom"); err == nil {
+		t.Fatalf("exporter(%q) got no error before registration", "custom")
+	}
+	RegisterExporter("custom", ExporterFunc(func(w io.Writer, results *rpb.Results) error {
+		_, err := fmt.Fprintf(w, "%s %v", results.GetPackage(), results.GetStatus())
+		return err
+	}))
+	defer func() {
+		exportersMu.Lock()
+		delete(exporters, "custom")
+		exportersMu.Unlock()
+	}()
+	e, err := exporter("custom")
+	if err != nil {
+		t.Fatalf("exporter(%q) got error after registration: %v", "custom", err)
+	}
+	var buf bytes.Buffer
+	if err := e.Export(&buf, &rpb.Results{Package: "foo", Status: rpb.Status_PASSED}); err != nil {
+		t.Fatalf("Export() got error: %v", err)
+	}
+	if got, want := buf.String(), "foo PASSED"; !strings.Contains(got, want) {
+		t.Errorf("Export() wrote %q, want %q", got, want)
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	log "github.com/golang/glog"
 	"github.com/openconfig/featureprofiles/internal/metadata"
+	"github.com/openconfig/featureprofiles/internal/metrics"
 	"github.com/openconfig/featureprofiles/internal/pathutil"
 	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
 	"github.com/openconfig/featureprofiles/topologies/binding"
@@ -38,11 +39,54 @@ import (
 //	func TestMain(m *testing.M) {
 //	  fptest.RunTests(m)
 //	}
+//
+// When --results_format and --results_file are set, the results of the
+// tests are also written to --results_file in that format.  When
+// --metrics_push_url is set, the KPIs recorded by the tests with package
+// metrics are pushed to it.
 func RunTests(m *testing.M) {
 	if err := initMetadata(); err != nil {
 		log.Errorf("Unable to initialize test metadata: %v", err)
 	}
+	capture, err := initResults()
+	if err != nil {
+		log.Errorf("Unable to capture test results: %v", err)
+	}
 	ondatra.RunTests(m, binding.New)
+	if err := metrics.Push(); err != nil {
+		log.Errorf("Unable to push test metrics: %v", err)
+	}
+	if capture == nil {
+		return
+	}
+	results, err := capture.stop()
+	if err != nil {
+		log.Errorf("Unable to capture test results: %v", err)
+		return
+	}
+	if err := exportResults(capture, results); err != nil {
+		log.Errorf("Unable to export test results: %v", err)
+	}
+}
+
+// initResults starts capturing the test results if they are exported.
+func initResults() (*resultsCapture, error) {
+	// The results flags are read before ondatra.RunTests parses the flags.
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	if *resultsFormat == "" && *resultsFile == "" {
+		return nil, nil
+	}
+	if *resultsFormat == "" || *resultsFile == "" {
+		return nil, fmt.Errorf("--results_format and --results_file must be set together")
+	}
+	if *resultsFormat != junitFormat {
+		if _, err := exporter(*resultsFormat); err != nil {
+			return nil, err
+		}
+	}
+	return startResultsCapture(packageName())
 }
 
 func initMetadata() error {
@@ -68,13 +112,14 @@ func initMetadata() error {
 func testbedPathFromMetadata() (string, error) {
 	testbed := metadata.Get().Testbed
 	testbedToFile := map[mpb.Metadata_Testbed]string{
-		mpb.Metadata_TESTBED_DUT:                "dut.testbed",
-		mpb.Metadata_TESTBED_DUT_DUT_4LINKS:     "dutdut.testbed",
-		mpb.Metadata_TESTBED_DUT_ATE_2LINKS:     "atedut_2.testbed",
-		mpb.Metadata_TESTBED_DUT_ATE_4LINKS:     "atedut_4.testbed",
-		mpb.Metadata_TESTBED_DUT_ATE_9LINKS_LAG: "atedut_9_lag.testbed",
-		mpb.Metadata_TESTBED_DUT_DUT_ATE_2LINKS: "dutdutate.testbed",
-		mpb.Metadata_TESTBED_DUT_ATE_8LINKS:     "atedut_8.testbed",
+		mpb.Metadata_TESTBED_DUT:                    "dut.testbed",
+		mpb.Metadata_TESTBED_DUT_DUT_4LINKS:         "dutdut.testbed",
+		mpb.Metadata_TESTBED_DUT_ATE_2LINKS:         "atedut_2.testbed",
+		mpb.Metadata_TESTBED_DUT_ATE_4LINKS:         "atedut_4.testbed",
+		mpb.Metadata_TESTBED_DUT_ATE_9LINKS_LAG:     "atedut_9_lag.testbed",
+		mpb.Metadata_TESTBED_DUT_DUT_ATE_2LINKS:     "dutdutate.testbed",
+		mpb.Metadata_TESTBED_DUT_ATE_8LINKS:         "atedut_8.testbed",
+		mpb.Metadata_TESTBED_ATE_DUT_DUT_ATE_4LINKS: "atedutdutate_4.testbed",
 	}
 	testbedFile, ok := testbedToFile[testbed]
 	if !ok {
@@ -0,0 +1,173 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+)
+
+// ethernetOverhead is the preamble, start of frame delimiter and
+// inter-frame gap sent on the wire with each Ethernet frame.
+const ethernetOverhead = 20
+
+var speedBps = map[ondatra.Speed]uint64{
+	ondatra.Speed1Gb:   1_000_000_000,
+	ondatra.Speed5Gb:   5_000_000_000,
+	ondatra.Speed10Gb:  10_000_000_000,
+	ondatra.Speed40Gb:  40_000_000_000,
+	ondatra.Speed100Gb: 100_000_000_000,
+	ondatra.Speed400Gb: 400_000_000_000,
+}
+
+// PortInfo is what the testbed reservation says about a port.  The fields
+// are empty when the reservation does not specify them.
+type PortInfo struct {
+	ID   string
+	Name string
+	// Speed is the port speed in bits per second.
+	Speed uint64
+	// PMD is the physical medium dependent type of the optic, such as
+	// PMD_100GBASE_LR4.
+	PMD string
+	// CardModel is the model of the linecard of the port.
+	CardModel string
+}
+
+// PPS returns the packets per second of frameSize bytes that make up
+// linePct percent of the port speed, counting the Ethernet preamble and
+// inter-frame gap.  It returns fallback if the port speed is unknown.
+func (p *PortInfo) PPS(frameSize uint32, linePct float64, fallback uint64) uint64 {
+	if p.Speed == 0 || frameSize == 0 {
+		return fallback
+	}
+	return uint64(float64(p.Speed) * linePct / 100 / float64(8*(frameSize+ethernetOverhead)))
+}
+
+// DeviceInfo is what the testbed reservation says about a device and its
+// ports.
+type DeviceInfo struct {
+	ID     string
+	Name   string
+	Vendor ondatra.Vendor
+	Model  string
+	// Ports are the ports of the device by ID.
+	Ports map[string]*PortInfo
+}
+
+// MinSpeed returns the lowest speed in bits per second of the ports with
+// ids, or of all the ports of the device if none are given, or 0 if none of
+// them have a speed.
+func (d *DeviceInfo) MinSpeed(ids ...string) uint64 {
+	if len(ids) == 0 {
+		for id := range d.Ports {
+			ids = append(ids, id)
+		}
+	}
+	var slowest uint64
+	for _, id := range ids {
+		p, ok := d.Ports[id]
+		if !ok || p.Speed == 0 {
+			continue
+		}
+		if slowest == 0 || p.Speed < slowest {
+			slowest = p.Speed
+		}
+	}
+	return slowest
+}
+
+// TestbedInfo is what the testbed reservation says about the devices and
+// ports of the testbed, so tests can choose parameters such as traffic rates
+// from the port speeds instead of hard-coding them.
+type TestbedInfo struct {
+	// DUTs and ATEs are the devices by ID.
+	DUTs map[string]*DeviceInfo
+	ATEs map[string]*DeviceInfo
+}
+
+// NewTestbedInfo returns the TestbedInfo of the reserved testbed.
+func NewTestbedInfo(t testing.TB) *TestbedInfo {
+	t.Helper()
+	tb := &TestbedInfo{
+		DUTs: make(map[string]*DeviceInfo),
+		ATEs: make(map[string]*DeviceInfo),
+	}
+	for id, dut := range ondatra.DUTs(t) {
+		tb.DUTs[id] = newDeviceInfo(dut.Device)
+	}
+	for id, ate := range ondatra.ATEs(t) {
+		tb.ATEs[id] = newDeviceInfo(ate.Device)
+	}
+	return tb
+}
+
+func newDeviceInfo(d *ondatra.Device) *DeviceInfo {
+	di := &DeviceInfo{
+		ID:     d.ID(),
+		Name:   d.Name(),
+		Vendor: d.Vendor(),
+		Model:  d.Model(),
+		Ports:  make(map[string]*PortInfo),
+	}
+	for _, p := range d.Ports() {
+		pi := &PortInfo{
+			ID:        p.ID(),
+			Name:      p.Name(),
+			Speed:     speedBps[p.Speed()],
+			CardModel: p.CardModel(),
+		}
+		if pmd := p.PMD(); pmd != 0 {
+			pi.PMD = pmd.String()
+		}
+		di.Ports[p.ID()] = pi
+	}
+	return di
+}
+
+// String returns a table of the devices and ports of the testbed.
+func (tb *TestbedInfo) String() string {
+	var b strings.Builder
+	for _, devs := range []map[string]*DeviceInfo{tb.DUTs, tb.ATEs} {
+		for _, id := range sortedKeys(devs) {
+			d := devs[id]
+			fmt.Fprintf(&b, "%s (%s): %v %s\n", d.ID, d.Name, d.Vendor, d.Model)
+			for _, pid := range sortedKeys(d.Ports) {
+				p := d.Ports[pid]
+				fmt.Fprintf(&b, "  %-8s %-20s speed %-14d pmd %-20s card %s\n", p.ID, p.Name, p.Speed, p.PMD, p.CardModel)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Log logs the devices and ports of the testbed.
+func (tb *TestbedInfo) Log(t testing.TB) {
+	t.Helper()
+	t.Logf("Testbed reservation:\n%s", tb)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+)
+
+func TestPPS(t *testing.T) {
+	tests := []struct {
+		desc      string
+		speed     uint64
+		frameSize uint32
+		linePct   float64
+		want      uint64
+	}{{
+		desc:      "10G line rate of 64 byte frames",
+		speed:     10_000_000_000,
+		frameSize: 64,
+		linePct:   100,
+		want:      14880952,
+	}, {
+		desc:      "100G 10% of 512 byte frames",
+		speed:     100_000_000_000,
+		frameSize: 512,
+		linePct:   10,
+		want:      2349624,
+	}, {
+		desc:      "unknown speed",
+		frameSize: 512,
+		linePct:   10,
+		want:      500,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p := &PortInfo{Speed: tc.speed}
+			if got := p.PPS(tc.frameSize, tc.linePct, 500); got != tc.want {
+				t.Errorf("PPS(%d, %v, 500) got %d, want %d", tc.frameSize, tc.linePct, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMinSpeed(t *testing.T) {
+	d := &DeviceInfo{Ports: map[string]*PortInfo{
+		"port1": {ID: "port1", Speed: 100_000_000_000},
+		"port2": {ID: "port2", Speed: 10_000_000_000},
+		"port3": {ID: "port3"},
+	}}
+	tests := []struct {
+		desc string
+		ids  []string
+		want uint64
+	}{
+		{desc: "all ports", want: 10_000_000_000},
+		{desc: "some ports", ids: []string{"port1", "port3"}, want: 100_000_000_000},
+		{desc: "no speed", ids: []string{"port3"}, want: 0},
+		{desc: "unknown port", ids: []string{"port4"}, want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := d.MinSpeed(tc.ids...); got != tc.want {
+				t.Errorf("MinSpeed(%v) got %d, want %d", tc.ids, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSpeedBps(t *testing.T) {
+	if got, want := speedBps[ondatra.Speed100Gb], uint64(100_000_000_000); got != want {
+		t.Errorf("speedBps[Speed100Gb] got %d, want %d", got, want)
+	}
+	if got := speedBps[ondatra.Speed(0)]; got != 0 {
+		t.Errorf("speedBps of unspecified speed got %d, want 0", got)
+	}
+}
+
+func TestTestbedInfoString(t *testing.T) {
+	tb := &TestbedInfo{
+		DUTs: map[string]*DeviceInfo{"dut": {
+			ID: "dut", Name: "dut1", Vendor: ondatra.ARISTA, Model: "7280",
+			Ports: map[string]*PortInfo{
+				"port2": {ID: "port2", Name: "Ethernet2", Speed: 10_000_000_000},
+				"port1": {ID: "port1", Name: "Ethernet1", Speed: 100_000_000_000, PMD: "PMD_100GBASE_LR4", CardModel: "LC1"},
+			},
+		}},
+		ATEs: map[string]*DeviceInfo{"ate": {ID: "ate", Name: "ate1", Ports: map[string]*PortInfo{}}},
+	}
+	got := tb.String()
+	for _, want := range []string{"dut (dut1): ARISTA 7280", "PMD_100GBASE_LR4", "card LC1", "ate (ate1)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() got %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Index(got, "port1") > strings.Index(got, "port2") {
+		t.Errorf("String() got %q, want ports sorted by ID", got)
+	}
+}
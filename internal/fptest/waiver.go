@@ -0,0 +1,60 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	rpb "github.com/openconfig/featureprofiles/proto/results_go_proto"
+)
+
+// waiverPrefix starts the message logged by Waive.
+const waiverPrefix = "WAIVED by deviation "
+
+// waiverRE matches a line of test output logged by Waive, which go test
+// prefixes with indentation and the file and line of the caller.
+var waiverRE = regexp.MustCompile(`^\s*\S+:\d+: ` + waiverPrefix + `(\S+): (.*)$`)
+
+// Waive records that a check of the test was not made, or was made less
+// strictly, because deviation is set.  The deviation is named as its field
+// in the Deviations message of metadata.proto.  It should be called by
+// verification helpers and tests instead of silently skipping the check:
+//
+//	if deviations.SwitchChipIDUnsupported(dut) {
+//		fptest.Waive(t, "switch_chip_id_unsupported", "not checking id of %s", name)
+//	} else {
+//		...
+//	}
+//
+// The waiver is logged, and when the structured test results are written
+// with --results_format, it is reported in the waivers of the test, so that
+// a test that passed with waivers can be told apart from a clean pass.
+func Waive(t testing.TB, deviation, format string, args ...any) {
+	t.Helper()
+	t.Logf("%s%s: %s", waiverPrefix, deviation, fmt.Sprintf(format, args...))
+}
+
+// waivers returns the waivers logged by Waive in the output of a test.
+func waivers(output []string) []*rpb.Waiver {
+	var ws []*rpb.Waiver
+	for _, line := range output {
+		if m := waiverRE.FindStringSubmatch(line); m != nil {
+			ws = append(ws, &rpb.Waiver{Deviation: m[1], Reason: m[2]})
+		}
+	}
+	return ws
+}
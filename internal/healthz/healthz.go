@@ -0,0 +1,220 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthz retrieves gNOI Healthz artifacts over the streaming Artifact
+// RPC and validates them against the hash and size in the artifact header.
+package healthz
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	hpb "github.com/openconfig/gnoi/healthz"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+// Options controls how an artifact is fetched.
+type Options struct {
+	// MaxChunkSize is the largest chunk the target may send.  Zero means no
+	// limit.
+	MaxChunkSize int
+	// Retries is the number of times the Artifact RPC is restarted after a
+	// transient error.
+	Retries int
+	// RetryDelay is the time to wait before restarting the Artifact RPC.
+	RetryDelay time.Duration
+}
+
+// Artifact describes a file artifact stored by Fetch.
+type Artifact struct {
+	// Header is the header sent by the target.
+	Header *hpb.ArtifactHeader
+	// Path is the local file the artifact was written to.
+	Path string
+	// Size is the number of bytes received.
+	Size int64
+	// Chunks is the number of chunks received.
+	Chunks int
+	// MaxChunk is the size of the largest chunk received.
+	MaxChunk int
+	// Attempts is the number of Artifact RPCs needed to retrieve the artifact.
+	Attempts int
+}
+
+// newHash returns a hash for method.
+func newHash(method tpb.HashType_HashMethod) (hash.Hash, error) {
+	switch method {
+	case tpb.HashType_SHA256:
+		return sha256.New(), nil
+	case tpb.HashType_SHA512:
+		return sha512.New(), nil
+	case tpb.HashType_MD5:
+		return md5.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash method %v", method)
+	}
+}
+
+// transient reports whether err is an error after which the Artifact RPC may
+// be restarted.
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// Fetch retrieves the file artifact id using c and writes it to a new file in
+// dir.  Each chunk is checked against the size in the header as it arrives,
+// and the hash of the received bytes is checked against the hash in the
+// header once the trailer is received.
+//
+// The Artifact RPC has no offset, so after a transient error the artifact is
+// retrieved again from the start.  The header of each retry must match the
+// header of the first attempt.  The file is removed if Fetch fails.
+func Fetch(ctx context.Context, c hpb.HealthzClient, id, dir string, opts *Options) (*Artifact, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	f, err := os.CreateTemp(dir, "healthz-artifact-*")
+	if err != nil {
+		return nil, err
+	}
+	a := &Artifact{Path: f.Name()}
+	err = fetch(ctx, c, id, f, a, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return a, nil
+}
+
+func fetch(ctx context.Context, c hpb.HealthzClient, id string, f *os.File, a *Artifact, opts *Options) error {
+	for {
+		a.Attempts++
+		err := fetchOnce(ctx, c, id, f, a, opts)
+		if err == nil || !transient(err) || a.Attempts > opts.Retries {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.RetryDelay):
+		}
+	}
+}
+
+// fetchOnce runs a single Artifact RPC, writing the received bytes to f and
+// recording the result in a.
+func fetchOnce(ctx context.Context, c hpb.HealthzClient, id string, f *os.File, a *Artifact, opts *Options) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Artifact(ctx, &hpb.ArtifactRequest{Id: id})
+	if err != nil {
+		return err
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	header := resp.GetHeader()
+	if header == nil {
+		return fmt.Errorf("artifact %q: first response is %T, want header", id, resp.GetContents())
+	}
+	if header.GetId() != id {
+		return fmt.Errorf("artifact %q: header has id %q", id, header.GetId())
+	}
+	file := header.GetFile()
+	if file == nil {
+		return fmt.Errorf("artifact %q: header has type %T, want file", id, header.GetArtifactType())
+	}
+	if a.Header != nil && !proto.Equal(a.Header, header) {
+		return fmt.Errorf("artifact %q: header changed on retry: got %v, want %v", id, header, a.Header)
+	}
+	a.Header = header
+	h, err := newHash(file.GetHash().GetMethod())
+	if err != nil {
+		return fmt.Errorf("artifact %q: %w", id, err)
+	}
+
+	a.Size, a.Chunks, a.MaxChunk = 0, 0, 0
+	w := io.MultiWriter(f, h)
+	for done := false; !done; {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("artifact %q: stream ended without trailer after %d bytes", id, a.Size)
+			}
+			return err
+		}
+		switch c := resp.GetContents().(type) {
+		case *hpb.ArtifactResponse_Trailer:
+			done = true
+		case *hpb.ArtifactResponse_Bytes:
+			chunk := c.Bytes
+			if len(chunk) == 0 {
+				return fmt.Errorf("artifact %q: chunk %d is empty", id, a.Chunks)
+			}
+			if opts.MaxChunkSize > 0 && len(chunk) > opts.MaxChunkSize {
+				return fmt.Errorf("artifact %q: chunk %d has %d bytes, want at most %d", id, a.Chunks, len(chunk), opts.MaxChunkSize)
+			}
+			if size := file.GetSize(); size > 0 && a.Size+int64(len(chunk)) > size {
+				return fmt.Errorf("artifact %q: received more than the %d bytes in the header", id, size)
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return err
+			}
+			a.Size += int64(len(chunk))
+			a.Chunks++
+			a.MaxChunk = max(a.MaxChunk, len(chunk))
+		default:
+			return fmt.Errorf("artifact %q: unexpected response %T in file artifact", id, resp.GetContents())
+		}
+	}
+
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("artifact %q: stream did not end after trailer: %v", id, err)
+	}
+	if size := file.GetSize(); size > 0 && a.Size != size {
+		return fmt.Errorf("artifact %q: received %d bytes, want %d", id, a.Size, size)
+	}
+	if got, want := h.Sum(nil), file.GetHash().GetHash(); !bytes.Equal(got, want) {
+		return fmt.Errorf("artifact %q: %v hash mismatch: got %x, want %x", id, file.GetHash().GetMethod(), got, want)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	hpb "github.com/openconfig/gnoi/healthz"
+	tpb "github.com/openconfig/gnoi/types"
+)
+
+const artifactID = "artifact-1"
+
+// fakeStream returns resps followed by err, or io.EOF if err is nil.
+type fakeStream struct {
+	grpc.ClientStream
+	resps []*hpb.ArtifactResponse
+	err   error
+}
+
+func (s *fakeStream) Recv() (*hpb.ArtifactResponse, error) {
+	if len(s.resps) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	resp := s.resps[0]
+	s.resps = s.resps[1:]
+	return resp, nil
+}
+
+// fakeClient serves one stream per Artifact call.
+type fakeClient struct {
+	hpb.HealthzClient
+	streams []*fakeStream
+	calls   int
+}
+
+func (c *fakeClient) Artifact(context.Context, *hpb.ArtifactRequest, ...grpc.CallOption) (hpb.Healthz_ArtifactClient, error) {
+	if c.calls >= len(c.streams) {
+		return nil, status.Error(codes.Unavailable, "no more streams")
+	}
+	s := c.streams[c.calls]
+	c.calls++
+	return s, nil
+}
+
+func header(data []byte) *hpb.ArtifactResponse {
+	sum := sha256.Sum256(data)
+	return &hpb.ArtifactResponse{Contents: &hpb.ArtifactResponse_Header{Header: &hpb.ArtifactHeader{
+		Id: artifactID,
+		ArtifactType: &hpb.ArtifactHeader_File{File: &hpb.FileArtifactType{
+			Name: "core.tar.gz",
+			Size: int64(len(data)),
+			Hash: &tpb.HashType{Method: tpb.HashType_SHA256, Hash: sum[:]},
+		}},
+	}}}
+}
+
+func chunk(b []byte) *hpb.ArtifactResponse {
+	return &hpb.ArtifactResponse{Contents: &hpb.ArtifactResponse_Bytes{Bytes: b}}
+}
+
+func trailer() *hpb.ArtifactResponse {
+	return &hpb.ArtifactResponse{Contents: &hpb.ArtifactResponse_Trailer{Trailer: &hpb.ArtifactTrailer{}}}
+}
+
+// stream returns a stream sending data in chunks of size n.
+func stream(data []byte, n int) *fakeStream {
+	s := &fakeStream{resps: []*hpb.ArtifactResponse{header(data)}}
+	for len(data) > 0 {
+		l := min(n, len(data))
+		s.resps = append(s.resps, chunk(data[:l]))
+		data = data[l:]
+	}
+	s.resps = append(s.resps, trailer())
+	return s
+}
+
+func TestFetch(t *testing.T) {
+	data := bytes.Repeat([]byte("healthz"), 1000)
+	broken := stream(data, 1024)
+	broken.resps = broken.resps[:3]
+	broken.err = status.Error(codes.Unavailable, "connection reset")
+	corrupt := stream(data, 1024)
+	corrupt.resps[2] = chunk(bytes.Repeat([]byte("x"), 1024))
+
+	tests := []struct {
+		desc         string
+		streams      []*fakeStream
+		opts         *Options
+		wantErr      bool
+		wantChunks   int
+		wantAttempts int
+	}{{
+		desc:         "single stream",
+		streams:      []*fakeStream{stream(data, 1024)},
+		wantChunks:   7,
+		wantAttempts: 1,
+	}, {
+		desc:         "retry after connection loss",
+		streams:      []*fakeStream{broken, stream(data, 1024)},
+		opts:         &Options{Retries: 1},
+		wantChunks:   7,
+		wantAttempts: 2,
+	}, {
+		desc:    "connection loss without retries",
+		streams: []*fakeStream{broken, stream(data, 1024)},
+		wantErr: true,
+	}, {
+		desc:    "hash mismatch",
+		streams: []*fakeStream{corrupt},
+		wantErr: true,
+	}, {
+		desc:    "chunk too large",
+		streams: []*fakeStream{stream(data, 4096)},
+		opts:    &Options{MaxChunkSize: 1024},
+		wantErr: true,
+	}, {
+		desc: "missing trailer",
+		streams: []*fakeStream{{
+			resps: stream(data, 1024).resps[:8],
+		}},
+		wantErr: true,
+	}, {
+		desc: "missing header",
+		streams: []*fakeStream{{
+			resps: stream(data, 1024).resps[1:],
+		}},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Copy the streams since the cases share the underlying responses.
+			var streams []*fakeStream
+			for _, s := range tc.streams {
+				c := *s
+				streams = append(streams, &c)
+			}
+			dir := t.TempDir()
+			a, err := Fetch(context.Background(), &fakeClient{streams: streams}, artifactID, dir, tc.opts)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Fetch() succeeded, want error")
+				}
+				if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+					t.Errorf("Fetch() left files %v after error", files)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch() failed: %v", err)
+			}
+			if a.Chunks != tc.wantChunks || a.Attempts != tc.wantAttempts || a.Size != int64(len(data)) {
+				t.Errorf("Fetch() got %d chunks, %d attempts, %d bytes, want %d chunks, %d attempts, %d bytes", a.Chunks, a.Attempts, a.Size, tc.wantChunks, tc.wantAttempts, len(data))
+			}
+			got, err := os.ReadFile(a.Path)
+			if err != nil {
+				t.Fatalf("ReadFile(%q) failed: %v", a.Path, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("Fetch() wrote %d bytes that differ from the artifact", len(got))
+			}
+		})
+	}
+}
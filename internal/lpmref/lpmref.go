@@ -0,0 +1,187 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lpmref is a software reference for longest prefix match, used to
+// compute the route a DUT is expected to forward an address with.
+//
+// Table is a binary trie with one level per prefix bit.  It favors being
+// obviously correct over being fast or compact.
+package lpmref
+
+import (
+	"net/netip"
+	"sort"
+)
+
+type node[V any] struct {
+	child [2]*node[V]
+	// prefix and val are set if a route ends at this node.
+	prefix netip.Prefix
+	val    V
+	set    bool
+}
+
+// Table is a longest prefix match table of IPv4 and IPv6 routes with values
+// of type V.  The zero value is an empty table.
+type Table[V any] struct {
+	v4, v6 *node[V]
+	n      int
+}
+
+func (t *Table[V]) root(a netip.Addr, create bool) *node[V] {
+	r := &t.v6
+	if a.Is4() {
+		r = &t.v4
+	}
+	if *r == nil && create {
+		*r = &node[V]{}
+	}
+	return *r
+}
+
+// bit returns bit i of a, counting from the most significant bit.
+func bit(a netip.Addr, i int) int {
+	b := a.AsSlice()
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+// Insert adds the route p with value v, replacing any route for p.
+func (t *Table[V]) Insert(p netip.Prefix, v V) {
+	p = p.Masked()
+	n := t.root(p.Addr(), true)
+	for i := 0; i < p.Bits(); i++ {
+		b := bit(p.Addr(), i)
+		if n.child[b] == nil {
+			n.child[b] = &node[V]{}
+		}
+		n = n.child[b]
+	}
+	if !n.set {
+		t.n++
+	}
+	n.prefix, n.val, n.set = p, v, true
+}
+
+// find returns the node for p, or nil if there is none.
+func (t *Table[V]) find(p netip.Prefix) *node[V] {
+	p = p.Masked()
+	n := t.root(p.Addr(), false)
+	for i := 0; n != nil && i < p.Bits(); i++ {
+		n = n.child[bit(p.Addr(), i)]
+	}
+	return n
+}
+
+// Get returns the value of the route p, which must match exactly.
+func (t *Table[V]) Get(p netip.Prefix) (V, bool) {
+	if n := t.find(p); n != nil && n.set {
+		return n.val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the route p and reports whether it was present.  Nodes are
+// not pruned.
+func (t *Table[V]) Delete(p netip.Prefix) bool {
+	n := t.find(p)
+	if n == nil || !n.set {
+		return false
+	}
+	var zero V
+	n.prefix, n.val, n.set = netip.Prefix{}, zero, false
+	t.n--
+	return true
+}
+
+// Lookup returns the longest route containing a and its value.
+func (t *Table[V]) Lookup(a netip.Addr) (netip.Prefix, V, bool) {
+	var best *node[V]
+	n := t.root(a, false)
+	for i := 0; n != nil; i++ {
+		if n.set {
+			best = n
+		}
+		if i == a.BitLen() {
+			break
+		}
+		n = n.child[bit(a, i)]
+	}
+	if best == nil {
+		var zero V
+		return netip.Prefix{}, zero, false
+	}
+	return best.prefix, best.val, true
+}
+
+// Len returns the number of routes in the table.
+func (t *Table[V]) Len() int {
+	return t.n
+}
+
+// Prefixes returns the routes in the table, IPv4 first, in address then
+// length order.
+func (t *Table[V]) Prefixes() []netip.Prefix {
+	var ps []netip.Prefix
+	var walk func(n *node[V])
+	walk = func(n *node[V]) {
+		if n == nil {
+			return
+		}
+		if n.set {
+			ps = append(ps, n.prefix)
+		}
+		walk(n.child[0])
+		walk(n.child[1])
+	}
+	walk(t.v4)
+	walk(t.v6)
+	return ps
+}
+
+// Boundaries returns the addresses at the edges of each prefix in ps: the
+// first and last address of the prefix, and the addresses just outside it.
+// The result is sorted and has no duplicates.
+func Boundaries(ps []netip.Prefix) []netip.Addr {
+	seen := make(map[netip.Addr]bool)
+	add := func(a netip.Addr) {
+		if a.IsValid() {
+			seen[a] = true
+		}
+	}
+	for _, p := range ps {
+		p = p.Masked()
+		first, last := p.Addr(), LastAddr(p)
+		add(first)
+		add(last)
+		add(first.Prev())
+		add(last.Next())
+	}
+	as := make([]netip.Addr, 0, len(seen))
+	for a := range seen {
+		as = append(as, a)
+	}
+	sort.Slice(as, func(i, j int) bool { return as[i].Less(as[j]) })
+	return as
+}
+
+// LastAddr returns the last address in p.
+func LastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
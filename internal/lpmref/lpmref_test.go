@@ -0,0 +1,159 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lpmref
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLookup(t *testing.T) {
+	var tbl Table[string]
+	for p, v := range map[string]string{
+		"0.0.0.0/0":       "default",
+		"198.18.0.0/15":   "a",
+		"198.18.128.0/17": "b",
+		"198.18.128.1/32": "c",
+		"2001:db8::/33":   "v6",
+		"2001:db8:1::/48": "v6-long",
+	} {
+		tbl.Insert(netip.MustParsePrefix(p), v)
+	}
+
+	tests := []struct {
+		addr       string
+		wantPrefix string
+		want       string
+		wantOK     bool
+	}{
+		{"198.18.0.0", "198.18.0.0/15", "a", true},
+		{"198.19.255.255", "198.18.0.0/15", "a", true},
+		{"203.0.113.1", "0.0.0.0/0", "default", true},
+		{"198.18.128.0", "198.18.128.0/17", "b", true},
+		{"198.18.128.1", "198.18.128.1/32", "c", true},
+		{"198.18.128.2", "198.18.128.0/17", "b", true},
+		{"2001:db8:1::1", "2001:db8:1::/48", "v6-long", true},
+		{"2001:db8:2::1", "2001:db8::/33", "v6", true},
+		{"2001:db8:8000::1", "", "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.addr, func(t *testing.T) {
+			p, v, ok := tbl.Lookup(netip.MustParseAddr(tc.addr))
+			var wantPrefix netip.Prefix
+			if tc.wantPrefix != "" {
+				wantPrefix = netip.MustParsePrefix(tc.wantPrefix)
+			}
+			if p != wantPrefix || v != tc.want || ok != tc.wantOK {
+				t.Errorf("Lookup(%s) got %v, %q, %v, want %v, %q, %v", tc.addr, p, v, ok, wantPrefix, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestInsertDelete(t *testing.T) {
+	var tbl Table[int]
+	p := netip.MustParsePrefix("198.18.1.0/24")
+	tbl.Insert(p, 1)
+	tbl.Insert(netip.MustParsePrefix("198.18.1.7/24"), 2)
+	if got := tbl.Len(); got != 1 {
+		t.Errorf("Len() after inserting the same prefix twice got %d, want 1", got)
+	}
+	if v, ok := tbl.Get(p); !ok || v != 2 {
+		t.Errorf("Get(%v) got %d, %v, want 2, true", p, v, ok)
+	}
+	if !tbl.Delete(p) {
+		t.Errorf("Delete(%v) got false, want true", p)
+	}
+	if tbl.Delete(p) {
+		t.Errorf("Delete(%v) twice got true, want false", p)
+	}
+	if _, _, ok := tbl.Lookup(netip.MustParseAddr("198.18.1.1")); ok {
+		t.Errorf("Lookup() after Delete found a route")
+	}
+	if got := tbl.Len(); got != 0 {
+		t.Errorf("Len() after Delete got %d, want 0", got)
+	}
+}
+
+// TestLookupRandom compares Lookup with a linear search over random
+// overlapping prefixes.
+func TestLookupRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var tbl Table[int]
+	var ps []netip.Prefix
+	for i := 0; i < 200; i++ {
+		a := netip.AddrFrom4([4]byte{198, 18, byte(r.Intn(4)), byte(r.Intn(256))})
+		p := netip.PrefixFrom(a, 16+r.Intn(17)).Masked()
+		if _, ok := tbl.Get(p); ok {
+			continue
+		}
+		tbl.Insert(p, len(ps))
+		ps = append(ps, p)
+	}
+	for _, a := range Boundaries(ps) {
+		want := -1
+		for i, p := range ps {
+			if p.Contains(a) && (want < 0 || p.Bits() > ps[want].Bits()) {
+				want = i
+			}
+		}
+		_, got, ok := tbl.Lookup(a)
+		if !ok {
+			got = -1
+		}
+		if got != want {
+			t.Errorf("Lookup(%v) got route %d, want %d", a, got, want)
+		}
+	}
+}
+
+func TestBoundaries(t *testing.T) {
+	got := Boundaries([]netip.Prefix{
+		netip.MustParsePrefix("198.18.1.0/24"),
+		netip.MustParsePrefix("198.18.2.0/24"),
+		netip.MustParsePrefix("0.0.0.0/0"),
+	})
+	want := []netip.Addr{
+		netip.MustParseAddr("0.0.0.0"),
+		netip.MustParseAddr("198.18.0.255"),
+		netip.MustParseAddr("198.18.1.0"),
+		netip.MustParseAddr("198.18.1.255"),
+		netip.MustParseAddr("198.18.2.0"),
+		netip.MustParseAddr("198.18.2.255"),
+		netip.MustParseAddr("198.18.3.0"),
+		netip.MustParseAddr("255.255.255.255"),
+	}
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Errorf("Boundaries() -want,+got:\n%s", diff)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	var tbl Table[bool]
+	for _, p := range []string{"2001:db8::/32", "198.18.1.0/24", "198.18.0.0/15", "198.18.1.128/25"} {
+		tbl.Insert(netip.MustParsePrefix(p), true)
+	}
+	var got []string
+	for _, p := range tbl.Prefixes() {
+		got = append(got, p.String())
+	}
+	want := []string{"198.18.0.0/15", "198.18.1.0/24", "198.18.1.128/25", "2001:db8::/32"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Prefixes() -want,+got:\n%s", diff)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics pushes key performance indicators (KPIs) of a test run,
+// such as convergence times, programming rates and reboot durations, to a
+// Prometheus Pushgateway, so that they can be graphed across runs.
+//
+// Tests record KPIs with Record and RecordDuration:
+//
+//	start := time.Now()
+//	...
+//	metrics.RecordDuration(t, "standby_controller_boot", time.Since(start))
+//
+// fptest.RunTests pushes the recorded KPIs after the tests ran if
+// --metrics_push_url is set, and otherwise they are discarded.  Each KPI is
+// pushed as a gauge with a "test" label for the name of the test that
+// recorded it, and is grouped by job and test plan ID, so that a run
+// replaces the KPIs of the previous run of the same test plan.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/metadata"
+)
+
+var (
+	pushURL = flag.String("metrics_push_url", "",
+		"URL of a Prometheus Pushgateway to push the test KPIs to, e.g. http://pushgateway:9091; KPIs are not pushed if empty")
+	jobName = flag.String("metrics_job", "featureprofiles",
+		"job label of the KPIs pushed to --metrics_push_url")
+)
+
+// pushTimeout bounds the time to push the KPIs.
+const pushTimeout = 30 * time.Second
+
+var (
+	nameRE  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// labelEscaper escapes a label value in the text exposition format.
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// sample is a recorded value of a KPI.
+type sample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+var (
+	mu      sync.Mutex
+	samples []sample
+)
+
+// Record records value for the KPI name of test t, e.g. "ipv4_entries_per_second".
+// labels are label name and value pairs that tell apart values of the KPI
+// recorded by the same test, e.g. "component", "Linecard0".  Recording a
+// value again for the same name and labels replaces it.
+func Record(t testing.TB, name string, value float64, labels ...string) {
+	t.Helper()
+	if !nameRE.MatchString(name) {
+		t.Errorf("Invalid KPI name %q", name)
+		return
+	}
+	if len(labels)%2 != 0 {
+		t.Errorf("KPI %s labels %q are not name and value pairs", name, labels)
+		return
+	}
+	s := sample{name: name, labels: map[string]string{"test": t.Name()}, value: value}
+	for i := 0; i < len(labels); i += 2 {
+		if !labelRE.MatchString(labels[i]) || labels[i] == "test" || labels[i] == "job" {
+			t.Errorf("Invalid KPI %s label name %q", name, labels[i])
+			return
+		}
+		s.labels[labels[i]] = labels[i+1]
+	}
+	t.Logf("KPI %s%s = %g", name, formatLabels(s.labels), value)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, old := range samples {
+		if old.name == s.name && formatLabels(old.labels) == formatLabels(s.labels) {
+			samples[i] = s
+			return
+		}
+	}
+	samples = append(samples, s)
+}
+
+// RecordDuration records d in seconds for the KPI name of test t, with the
+// "_seconds" unit suffix added to name.
+func RecordDuration(t testing.TB, name string, d time.Duration, labels ...string) {
+	t.Helper()
+	Record(t, name+"_seconds", d.Seconds(), labels...)
+}
+
+// formatLabels returns labels in the text exposition format, sorted by name.
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for n := range labels {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, n := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `%s="%s"`, n, labelEscaper.Replace(labels[n]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// exposition returns samples in the Prometheus text exposition format.
+func exposition(samples []sample) []byte {
+	byName := map[string][]sample{}
+	var names []string
+	for _, s := range samples {
+		if _, ok := byName[s.name]; !ok {
+			names = append(names, s.name)
+		}
+		byName[s.name] = append(byName[s.name], s)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, n := range names {
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", n)
+		for _, s := range byName[n] {
+			fmt.Fprintf(&b, "%s%s %s\n", n, formatLabels(s.labels), strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+	return b.Bytes()
+}
+
+// groupingURL returns the Pushgateway URL of the KPIs of job and test plan
+// planID.
+func groupingURL(base, job, planID string) string {
+	u := strings.TrimSuffix(base, "/") + "/metrics/job/" + url.PathEscape(job)
+	if planID != "" {
+		u += "/plan_id/" + url.PathEscape(planID)
+	}
+	return u
+}
+
+// push replaces the KPIs of the group at u with samples.
+func push(ctx context.Context, u string, samples []sample) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(exposition(samples)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("push to %s failed with status %s: %s", u, resp.Status, body)
+	}
+	return nil
+}
+
+// Push pushes the recorded KPIs to --metrics_push_url.  It does nothing if
+// the flag is not set or no KPI was recorded.
+func Push() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if *pushURL == "" || len(samples) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pushTimeout)
+	defer cancel()
+	return push(ctx, groupingURL(*pushURL, *jobName, metadata.Get().GetPlanId()), samples)
+}
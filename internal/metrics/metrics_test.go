@@ -0,0 +1,137 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecord(t *testing.T) {
+	samples = nil
+	defer func() { samples = nil }()
+
+	RecordDuration(t, "reboot", 90*time.Second, "component", "Linecard0")
+	RecordDuration(t, "reboot", 95*time.Second, "component", "Linecard0")
+	RecordDuration(t, "reboot", 100*time.Second, "component", "Linecard1")
+	Record(t, "ipv4_entries_per_second", 2500)
+
+	want := `# TYPE ipv4_entries_per_second gauge
+ipv4_entries_per_second{test="TestRecord"} 2500
+# TYPE reboot_seconds gauge
+reboot_seconds{component="Linecard0",test="TestRecord"} 95
+reboot_seconds{component="Linecard1",test="TestRecord"} 100
+`
+	if diff := cmp.Diff(want, string(exposition(samples))); diff != "" {
+		t.Errorf("exposition() got diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecordInvalid(t *testing.T) {
+	samples = nil
+	defer func() { samples = nil }()
+
+	tests := []struct {
+		desc   string
+		name   string
+		labels []string
+	}{
+		{"invalid name", "reboot-time", nil},
+		{"odd labels", "reboot", []string{"component"}},
+		{"invalid label name", "reboot", []string{"line card", "Linecard0"}},
+		{"reserved label name", "reboot", []string{"test", "TestFoo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ft := &fakeTB{TB: t}
+			Record(ft, tt.name, 1, tt.labels...)
+			if !ft.failed {
+				t.Errorf("Record(%q, %q) did not fail", tt.name, tt.labels)
+			}
+		})
+	}
+	if len(samples) != 0 {
+		t.Errorf("Recorded %d invalid samples, want 0", len(samples))
+	}
+}
+
+// fakeTB records errors instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Errorf(string, ...any) { f.failed = true }
+
+func TestFormatLabels(t *testing.T) {
+	got := formatLabels(map[string]string{"test": "TestFoo/a \"b\"", "path": `c:\d`})
+	if want := `{path="c:\\d",test="TestFoo/a \"b\""}`; got != want {
+		t.Errorf("formatLabels() got %s, want %s", got, want)
+	}
+}
+
+func TestGroupingURL(t *testing.T) {
+	tests := []struct {
+		base, job, planID string
+		want              string
+	}{
+		{"http://gw:9091/", "featureprofiles", "gNOI-3.2", "http://gw:9091/metrics/job/featureprofiles/plan_id/gNOI-3.2"},
+		{"http://gw:9091", "fp", "", "http://gw:9091/metrics/job/fp"},
+		{"http://gw:9091", "fp", "RT-1.1/a", "http://gw:9091/metrics/job/fp/plan_id/RT-1.1%2Fa"},
+	}
+	for _, tt := range tests {
+		if got := groupingURL(tt.base, tt.job, tt.planID); got != tt.want {
+			t.Errorf("groupingURL(%q, %q, %q) got %q, want %q", tt.base, tt.job, tt.planID, got, tt.want)
+		}
+	}
+}
+
+func TestPush(t *testing.T) {
+	s := []sample{{name: "reboot_seconds", labels: map[string]string{"test": "TestFoo"}, value: 1.5}}
+	var gotMethod, gotPath, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+	}))
+	defer srv.Close()
+
+	if err := push(context.Background(), srv.URL+"/metrics/job/fp", s); err != nil {
+		t.Fatalf("push() got error %v", err)
+	}
+	if gotMethod != http.MethodPut || gotPath != "/metrics/job/fp" {
+		t.Errorf("push() sent %s %s, want PUT /metrics/job/fp", gotMethod, gotPath)
+	}
+	if want := string(exposition(s)); gotBody != want {
+		t.Errorf("push() sent body %q, want %q", gotBody, want)
+	}
+}
+
+func TestPushError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metrics", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	if err := push(context.Background(), srv.URL, nil); err == nil {
+		t.Errorf("push() got no error, want error")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mgmtvrf provides helpers to constrain DUT management services to a
+// management VRF for the duration of a test and to check from the test host
+// which addresses those services answer on, and with which certificates.
+package mgmtvrf
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// Service is a management service that can be probed from the test host.
+type Service string
+
+const (
+	// GNMI is probed with a gNMI Capabilities RPC.
+	GNMI Service = "gNMI"
+	// GNOI is probed with a gNOI System.Time RPC.
+	GNOI Service = "gNOI"
+	// GRIBI is probed with a gRIBI Get RPC.
+	GRIBI Service = "gRIBI"
+	// SSH is probed by reading the SSH protocol version banner.
+	SSH Service = "SSH"
+)
+
+// Configure creates the L3VRF network instance vrf containing the interface
+// intf and binds every gRPC server on the DUT to it.  The gRPC server
+// bindings, the interface membership and the network instance are restored
+// when the test ends.
+func Configure(t testing.TB, dut *ondatra.DUTDevice, vrf, intf string) {
+	t.Helper()
+	ni := &oc.NetworkInstance{
+		Name: ygot.String(vrf),
+		Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+	}
+	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrf).Config(), ni)
+	t.Cleanup(func() {
+		gnmi.Delete(t, dut, gnmi.OC().NetworkInstance(vrf).Config())
+	})
+	fptest.AssignToNetworkInstance(t, dut, intf, vrf, 0)
+	t.Cleanup(func() {
+		gnmi.Delete(t, dut, gnmi.OC().NetworkInstance(vrf).Interface(intf+".0").Config())
+	})
+	BindGRPCServers(t, dut, vrf)
+}
+
+// BindGRPCServers sets the network instance of every gRPC server on the DUT
+// to vrf.  The previous bindings are restored when the test ends.
+func BindGRPCServers(t testing.TB, dut *ondatra.DUTDevice, vrf string) {
+	t.Helper()
+	for _, s := range gnmi.GetAll(t, dut, gnmi.OC().System().GrpcServerAny().State()) {
+		name, prev := s.GetName(), s.GetNetworkInstance()
+		path := gnmi.OC().System().GrpcServer(name).NetworkInstance().Config()
+		gnmi.Update(t, dut, path, vrf)
+		t.Cleanup(func() {
+			if prev == "" {
+				gnmi.Delete(t, dut, path)
+				return
+			}
+			gnmi.Update(t, dut, path, prev)
+		})
+	}
+}
+
+// VerifyGRPCServers checks that every gRPC server on the DUT reports vrf as
+// its network instance.
+func VerifyGRPCServers(t testing.TB, dut *ondatra.DUTDevice, vrf string) {
+	t.Helper()
+	for _, s := range gnmi.GetAll(t, dut, gnmi.OC().System().GrpcServerAny().State()) {
+		if got := s.GetNetworkInstance(); got != vrf {
+			t.Errorf("gRPC server %s network-instance: got %q, want %q", s.GetName(), got, vrf)
+		}
+	}
+}
+
+// DefaultDialOpts are the dial options used by Probe when none are given.
+// Probe only checks that the service answers, so the server certificate is
+// not verified.
+func DefaultDialOpts() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: true, // NOLINT
+		})),
+	}
+}
+
+// Probe returns nil if svc answers at addr before ctx is done.  For gRPC
+// services any response, including an error status from the server such as
+// UNAUTHENTICATED, counts as an answer.  opts are used to dial gRPC services;
+// DefaultDialOpts are used if none are given.
+func Probe(ctx context.Context, svc Service, addr string, opts ...grpc.DialOption) error {
+	switch svc {
+	case GNMI, GNOI, GRIBI:
+		return probeGRPC(ctx, svc, addr, opts)
+	case SSH:
+		return probeSSH(ctx, addr)
+	default:
+		return fmt.Errorf("unknown service %q", svc)
+	}
+}
+
+func probeGRPC(ctx context.Context, svc Service, addr string, opts []grpc.DialOption) error {
+	if len(opts) == 0 {
+		opts = DefaultDialOpts()
+	}
+	conn, err := grpc.DialContext(ctx, addr, opts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	switch svc {
+	case GNMI:
+		_, err = gpb.NewGNMIClient(conn).Capabilities(ctx, &gpb.CapabilityRequest{}, grpc.WaitForReady(true))
+	case GNOI:
+		_, err = spb.NewSystemClient(conn).Time(ctx, &spb.TimeRequest{}, grpc.WaitForReady(true))
+	case GRIBI:
+		var stream grpb.GRIBI_GetClient
+		stream, err = grpb.NewGRIBIClient(conn).Get(ctx, &grpb.GetRequest{
+			NetworkInstance: &grpb.GetRequest_All{All: &grpb.Empty{}},
+			Aft:             grpb.AFTType_ALL,
+		}, grpc.WaitForReady(true))
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return fmt.Errorf("%s at %s did not answer: %w", svc, addr, err)
+	}
+	return nil
+}
+
+func probeSSH(ctx context.Context, addr string) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("SSH at %s did not answer: %w", addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetReadDeadline(deadline)
+	}
+	banner, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("SSH at %s did not send a banner: %w", addr, err)
+	}
+	if !strings.HasPrefix(banner, "SSH-") {
+		return fmt.Errorf("SSH at %s sent unexpected banner %q", addr, banner)
+	}
+	return nil
+}
+
+// VerifyCertificate returns nil if the TLS server at the host:port address
+// addr presents a certificate that chains to roots and is valid for the host
+// of addr, which for an IPv4 or IPv6 address must be one of the IP address
+// SANs of the certificate.
+func VerifyCertificate(ctx context.Context, addr string, roots *x509.CertPool) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	d := tls.Dialer{Config: &tls.Config{
+		RootCAs:    roots,
+		ServerName: host,
+		NextProtos: []string{"h2"},
+	}}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("certificate of %s is not valid for %s: %w", addr, host, err)
+	}
+	return conn.Close()
+}
+
+// VerifyReachable checks whether each service in addrs answers at its
+// host:port address within timeout.  If want is false, an answer is an
+// error.
+func VerifyReachable(t testing.TB, addrs map[Service]string, want bool, timeout time.Duration) {
+	t.Helper()
+	for svc, addr := range addrs {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := Probe(ctx, svc, addr)
+		cancel()
+		switch {
+		case want && err != nil:
+			t.Errorf("%s at %s: got no answer, want answer: %v", svc, addr, err)
+		case !want && err == nil:
+			t.Errorf("%s at %s: got answer, want no answer", svc, addr)
+		default:
+			t.Logf("%s at %s answered: %v", svc, addr, want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgmtvrf
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/security/certgen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// startGRPC starts a gRPC server with unimplemented gNMI, gNOI System and
+// gRIBI services and returns its address.
+func startGRPC(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() failed: %v", err)
+	}
+	s := grpc.NewServer()
+	gpb.RegisterGNMIServer(s, &gpb.UnimplementedGNMIServer{})
+	spb.RegisterSystemServer(s, &spb.UnimplementedSystemServer{})
+	grpb.RegisterGRIBIServer(s, &grpb.UnimplementedGRIBIServer{})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+// startBanner starts a TCP server that writes banner to every connection
+// and returns its address.
+func startBanner(t *testing.T, banner string) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() failed: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conn.Write([]byte(banner))
+			conn.Close()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// closedAddr returns an address nothing is listening on.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() failed: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func TestProbe(t *testing.T) {
+	grpcAddr := startGRPC(t)
+	sshAddr := startBanner(t, "SSH-2.0-OpenSSH_9.0\r\n")
+	httpAddr := startBanner(t, "HTTP/1.1 400 Bad Request\r\n")
+	closed := closedAddr(t)
+
+	tests := []struct {
+		desc    string
+		svc     Service
+		addr    string
+		wantErr bool
+	}{
+		{desc: "gNMI answers", svc: GNMI, addr: grpcAddr},
+		{desc: "gNOI answers", svc: GNOI, addr: grpcAddr},
+		{desc: "gRIBI answers", svc: GRIBI, addr: grpcAddr},
+		{desc: "SSH answers", svc: SSH, addr: sshAddr},
+		{desc: "gNMI closed port", svc: GNMI, addr: closed, wantErr: true},
+		{desc: "gNOI closed port", svc: GNOI, addr: closed, wantErr: true},
+		{desc: "gRIBI closed port", svc: GRIBI, addr: closed, wantErr: true},
+		{desc: "SSH closed port", svc: SSH, addr: closed, wantErr: true},
+		{desc: "SSH wrong banner", svc: SSH, addr: httpAddr, wantErr: true},
+		{desc: "unknown service", svc: Service("telnet"), addr: sshAddr, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := Probe(ctx, tt.svc, tt.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("Probe(%v, %v) got err %v, want error %v", tt.svc, tt.addr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// startTLS starts a TLS server on the loopback address of network, tcp4 or
+// tcp6, presenting cert, and returns its address.
+func startTLS(t *testing.T, network string, cert *tls.Certificate) string {
+	t.Helper()
+	host := "127.0.0.1"
+	if network == "tcp6" {
+		host = "::1"
+	}
+	lis, err := tls.Listen(network, net.JoinHostPort(host, "0"), &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		NextProtos:   []string{"h2"},
+	})
+	if err != nil {
+		t.Skipf("Cannot listen on %s: %v", network, err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	ca, err := certgen.NewCA("ca", x509.ECDSA)
+	if err != nil {
+		t.Fatalf("certgen.NewCA() got error: %v", err)
+	}
+	other, err := certgen.NewCA("other", x509.ECDSA)
+	if err != nil {
+		t.Fatalf("certgen.NewCA() got error: %v", err)
+	}
+	dualStack, err := ca.Issue(certgen.Spec{CommonName: "dut", IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}})
+	if err != nil {
+		t.Fatalf("Issue() got error: %v", err)
+	}
+	v4Only, err := ca.Issue(certgen.Spec{CommonName: "dut", IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}})
+	if err != nil {
+		t.Fatalf("Issue() got error: %v", err)
+	}
+
+	tests := []struct {
+		desc    string
+		network string
+		cert    *tls.Certificate
+		roots   *x509.CertPool
+		wantErr bool
+	}{
+		{desc: "IPv4 SAN", network: "tcp4", cert: dualStack, roots: ca.Pool()},
+		{desc: "IPv6 SAN", network: "tcp6", cert: dualStack, roots: ca.Pool()},
+		{desc: "missing IPv6 SAN", network: "tcp6", cert: v4Only, roots: ca.Pool(), wantErr: true},
+		{desc: "untrusted CA", network: "tcp4", cert: dualStack, roots: other.Pool(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			addr := startTLS(t, tt.network, tt.cert)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := VerifyCertificate(ctx, addr, tt.roots)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("VerifyCertificate(%v) got err %v, want error %v", addr, err, tt.wantErr)
+			}
+		})
+	}
+}
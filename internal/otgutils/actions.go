@@ -15,9 +15,11 @@
 package otgutils
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/open-traffic-generator/snappi/gosnappi"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/otg"
 	"github.com/openconfig/ygnmi/ygnmi"
@@ -55,3 +57,67 @@ func GetFlowLossPct(t testing.TB, otg *otg.OTG, flowName string, timeout time.Du
 	tx, rx := GetFlowStats(t, otg, flowName, timeout)
 	return (float64(tx) - float64(rx)) * 100 / float64(tx)
 }
+
+// GetPortLossPct checks to see if all the flows in the config are completely
+// stopped and returns the loss percentage of the flows received on each port,
+// keyed by port name.  Flows are attributed to the port of the device named by
+// their first rx name, and a port with no packets transmitted to it has 100%
+// loss.
+func GetPortLossPct(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) map[string]float64 {
+	ports := make(map[string]string)
+	for _, d := range c.Devices().Items() {
+		for _, e := range d.Ethernets().Items() {
+			if !e.Connection().HasPortName() {
+				continue
+			}
+			for _, ip := range e.Ipv4Addresses().Items() {
+				ports[ip.Name()] = e.Connection().PortName()
+			}
+			for _, ip := range e.Ipv6Addresses().Items() {
+				ports[ip.Name()] = e.Connection().PortName()
+			}
+		}
+	}
+	txByPort := make(map[string]uint64)
+	rxByPort := make(map[string]uint64)
+	for _, f := range c.Flows().Items() {
+		var port string
+		switch {
+		case f.TxRx().HasDevice() && len(f.TxRx().Device().RxNames()) > 0:
+			port = ports[f.TxRx().Device().RxNames()[0]]
+		case f.TxRx().HasPort() && f.TxRx().Port().HasRxName():
+			port = f.TxRx().Port().RxName()
+		}
+		if port == "" {
+			t.Logf("Flow %s has no rx port, skipping", f.Name())
+			continue
+		}
+		tx, rx := GetFlowStats(t, otg, f.Name(), timeout)
+		txByPort[port] += tx
+		rxByPort[port] += rx
+	}
+	lossPct := make(map[string]float64)
+	for port, tx := range txByPort {
+		if tx == 0 {
+			lossPct[port] = 100
+			continue
+		}
+		lossPct[port] = (float64(tx) - float64(rxByPort[port])) * 100 / float64(tx)
+	}
+	return lossPct
+}
+
+// GetFlowOutage checks to see if all the flows are completely stopped and
+// returns how long the given flow was not forwarded, computed from its lost
+// packets and its rate in packets per second.  It returns an error if pps is
+// 0, as for a flow whose rate is set by percentage of line rate.
+func GetFlowOutage(t testing.TB, otg *otg.OTG, flowName string, pps uint64, timeout time.Duration) (time.Duration, error) {
+	if pps == 0 {
+		return 0, fmt.Errorf("cannot compute outage of flow %s with a rate of 0 pps", flowName)
+	}
+	tx, rx := GetFlowStats(t, otg, flowName, timeout)
+	if rx >= tx {
+		return 0, nil
+	}
+	return time.Duration(tx-rx) * time.Second / time.Duration(pps), nil
+}
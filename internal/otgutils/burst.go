@@ -0,0 +1,45 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// BurstProfile is a traffic profile of bursts of packets sent at line rate.
+// Flows with the same profile on different ports that are started together
+// send their bursts at the same time, so they can be used to generate
+// synchronized microbursts into a common egress port.
+type BurstProfile struct {
+	// Packets is the number of packets in each burst.
+	Packets uint32
+	// Bursts is the number of bursts to send, or 0 to send bursts until
+	// traffic is stopped.
+	Bursts uint32
+	// Gap is the idle time between consecutive bursts.
+	Gap time.Duration
+}
+
+// Apply configures flow f to send traffic with the burst profile.
+func (p BurstProfile) Apply(f gosnappi.Flow) {
+	f.Rate().SetPercentage(100)
+	b := f.Duration().Burst().SetPackets(p.Packets)
+	if p.Bursts > 0 {
+		b.SetBursts(p.Bursts)
+	}
+	b.InterBurstGap().SetNanoseconds(float64(p.Gap.Nanoseconds()))
+}
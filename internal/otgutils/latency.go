@@ -0,0 +1,86 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/otg"
+)
+
+// FlowLatency is the latency of the packets of a flow received by the ATE,
+// measured from the timestamps the ATE inserts in them.
+type FlowLatency struct {
+	Min, Avg, Max time.Duration
+}
+
+// Jitter returns the peak-to-peak delay variation of the flow.
+func (l FlowLatency) Jitter() time.Duration {
+	return l.Max - l.Min
+}
+
+// String returns the latency and jitter of the flow.
+func (l FlowLatency) String() string {
+	return fmt.Sprintf("min %v, avg %v, max %v, jitter %v", l.Min, l.Avg, l.Max, l.Jitter())
+}
+
+// EnableLatency configures flow f to measure the latency of its packets.  In
+// cut-through mode, latency is measured from the first bit of a packet sent
+// to the first bit received, and so does not depend on the packet size.
+func EnableLatency(f gosnappi.Flow) {
+	f.Metrics().SetEnable(true)
+	f.Metrics().Latency().SetEnable(true).SetMode(gosnappi.FlowLatencyMetricsMode.CUT_THROUGH)
+}
+
+// GetFlowLatency returns the latency of flow flowName, which must have been
+// configured with EnableLatency.  It fails the test if the ATE does not report
+// the latency of the flow.
+func GetFlowLatency(t testing.TB, otg *otg.OTG, flowName string) FlowLatency {
+	t.Helper()
+	f := gnmi.Get(t, otg, gnmi.OTG().Flow(flowName).State())
+	if f.MinimumLatency == nil || f.AverageLatency == nil || f.MaximumLatency == nil {
+		t.Fatalf("ATE reports no latency for flow %s: min %v, avg %v, max %v", flowName, f.MinimumLatency, f.AverageLatency, f.MaximumLatency)
+	}
+	return FlowLatency{
+		Min: time.Duration(f.GetMinimumLatency()),
+		Avg: time.Duration(f.GetAverageLatency()),
+		Max: time.Duration(f.GetMaximumLatency()),
+	}
+}
+
+// LogFlowLatency displays the latency of the flows of c that measure it.
+func LogFlowLatency(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
+	t.Helper()
+	var out strings.Builder
+	out.WriteString("\nOTG Flow Latency\n")
+	fmt.Fprintln(&out, strings.Repeat("-", 80))
+	out.WriteString("\n")
+	fmt.Fprintf(&out, "%-25v%-15v%-15v%-15v%-15v\n", "Name", "Min", "Avg", "Max", "Jitter")
+	for _, f := range c.Flows().Items() {
+		if !f.Metrics().Latency().Enable() {
+			continue
+		}
+		l := GetFlowLatency(t, otg, f.Name())
+		fmt.Fprintf(&out, "%-25v%-15v%-15v%-15v%-15v\n", f.Name(), l.Min, l.Avg, l.Max, l.Jitter())
+	}
+	fmt.Fprintln(&out, strings.Repeat("-", 80))
+	out.WriteString("\n\n")
+	t.Log(out.String())
+}
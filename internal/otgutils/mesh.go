@@ -0,0 +1,80 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"fmt"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+// endpoint is the first IPv4 address of the first Ethernet interface of a
+// device.
+type endpoint struct {
+	name string // Name of the IPv4 address, used as the flow tx or rx name.
+	mac  string
+	ipv4 string
+	port string
+}
+
+// endpoints returns the endpoint of every device in c that has an IPv4
+// address on a port.
+func endpoints(c gosnappi.Config) []endpoint {
+	var eps []endpoint
+	for _, d := range c.Devices().Items() {
+		eths := d.Ethernets().Items()
+		if len(eths) == 0 || !eths[0].Connection().HasPortName() {
+			continue
+		}
+		v4s := eths[0].Ipv4Addresses().Items()
+		if len(v4s) == 0 {
+			continue
+		}
+		eps = append(eps, endpoint{name: v4s[0].Name(), mac: eths[0].Mac(), ipv4: v4s[0].Address(), port: eths[0].Connection().PortName()})
+	}
+	return eps
+}
+
+// AddMeshFlows adds an IPv4 flow with the IMIX packet size distribution from
+// every device in c with an IPv4 address on a port to every other such
+// device.  The flows from each device share lineRatePct percent of the line
+// rate of its port equally.  It returns the names of the flows added.
+func AddMeshFlows(c gosnappi.Config, lineRatePct float32) []string {
+	eps := endpoints(c)
+	if len(eps) < 2 {
+		return nil
+	}
+	pct := lineRatePct / float32(len(eps)-1)
+	var names []string
+	for _, src := range eps {
+		for _, dst := range eps {
+			if src.name == dst.name {
+				continue
+			}
+			name := fmt.Sprintf("mesh-%s-%s", src.port, dst.port)
+			f := c.Flows().Add().SetName(name)
+			f.Metrics().SetEnable(true)
+			f.TxRx().Device().SetTxNames([]string{src.name}).SetRxNames([]string{dst.name})
+			f.Size().WeightPairs().SetPredefined(gosnappi.FlowSizeWeightPairsPredefined.IMIX)
+			f.Rate().SetPercentage(pct)
+			f.Packet().Add().Ethernet().Src().SetValue(src.mac)
+			v4 := f.Packet().Add().Ipv4()
+			v4.Src().SetValue(src.ipv4)
+			v4.Dst().SetValue(dst.ipv4)
+			names = append(names, name)
+		}
+	}
+	return names
+}
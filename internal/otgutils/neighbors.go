@@ -0,0 +1,159 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/gnmi"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+	"github.com/openconfig/ondatra/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// gateway is the gateway of an IPv4 or IPv6 address of an OTG interface.
+type gateway struct {
+	intf    string // Ethernet interface name.
+	port    string // Port name, empty when the interface is connected to a LAG.
+	ipType  string // "IPv4" or "IPv6".
+	address string // Address of the interface.
+	gateway string
+	vlans   []uint32
+}
+
+func (g gateway) String() string {
+	return fmt.Sprintf("%s %s %s gateway %s", g.intf, g.ipType, g.address, g.gateway)
+}
+
+// gateways returns the gateways of every IPv4 and IPv6 address of every
+// Ethernet interface in c.
+func gateways(c gosnappi.Config) []gateway {
+	var gws []gateway
+	for _, d := range c.Devices().Items() {
+		for _, eth := range d.Ethernets().Items() {
+			var port string
+			if eth.Connection().HasPortName() {
+				port = eth.Connection().PortName()
+			}
+			var vlans []uint32
+			for _, v := range eth.Vlans().Items() {
+				vlans = append(vlans, v.Id())
+			}
+			for _, a := range eth.Ipv4Addresses().Items() {
+				gws = append(gws, gateway{intf: eth.Name(), port: port, ipType: "IPv4", address: a.Address(), gateway: a.Gateway(), vlans: vlans})
+			}
+			for _, a := range eth.Ipv6Addresses().Items() {
+				gws = append(gws, gateway{intf: eth.Name(), port: port, ipType: "IPv6", address: a.Address(), gateway: a.Gateway(), vlans: vlans})
+			}
+		}
+	}
+	return gws
+}
+
+// neighborMAC returns a query for the link layer address of the neighbor
+// entry of g.
+func neighborMAC(g gateway) ygnmi.SingletonQuery[string] {
+	if g.ipType == "IPv6" {
+		return gnmi.OTG().Interface(g.intf).Ipv6Neighbor(g.gateway).LinkLayerAddress().State()
+	}
+	return gnmi.OTG().Interface(g.intf).Ipv4Neighbor(g.gateway).LinkLayerAddress().State()
+}
+
+// learnedNeighbors returns the resolved neighbors of the interface of g, keyed
+// by IP address.
+func learnedNeighbors(t testing.TB, otg *otg.OTG, g gateway) map[string]string {
+	t.Helper()
+	learned := make(map[string]string)
+	if g.ipType == "IPv6" {
+		for _, v := range gnmi.LookupAll(t, otg, gnmi.OTG().Interface(g.intf).Ipv6NeighborAny().State()) {
+			if n, ok := v.Val(); ok {
+				learned[n.GetIpv6Address()] = n.GetLinkLayerAddress()
+			}
+		}
+		return learned
+	}
+	for _, v := range gnmi.LookupAll(t, otg, gnmi.OTG().Interface(g.intf).Ipv4NeighborAny().State()) {
+		if n, ok := v.Val(); ok {
+			learned[n.GetIpv4Address()] = n.GetLinkLayerAddress()
+		}
+	}
+	return learned
+}
+
+// diagnose explains why the gateway g is not resolved, given the link state
+// of its port and the neighbors learned on its interface.
+func diagnose(g gateway, link otgtelemetry.E_Port_Link, learned map[string]string) string {
+	if link != otgtelemetry.Port_Link_UP && g.port != "" {
+		return fmt.Sprintf("%v: port %s link is %v", g, g.port, link)
+	}
+	if mac, ok := learned[g.gateway]; ok {
+		return fmt.Sprintf("%v: neighbor entry has no link layer address (%q), resolution is incomplete", g, mac)
+	}
+	var others []string
+	for ip, mac := range learned {
+		if mac != "" {
+			others = append(others, fmt.Sprintf("%s (%s)", ip, mac))
+		}
+	}
+	sort.Strings(others)
+	switch {
+	case len(others) > 0:
+		return fmt.Sprintf("%v: gateway MAC missing, but learned %s; check the gateway address matches the DUT", g, strings.Join(others, ", "))
+	case len(g.vlans) > 0:
+		return fmt.Sprintf("%v: no neighbors learned with VLANs %v; check the DUT interface is up, addressed and on a matching VLAN", g, g.vlans)
+	default:
+		return fmt.Sprintf("%v: no neighbors learned untagged; check the DUT interface is up and addressed, and is not expecting a VLAN tag", g)
+	}
+}
+
+// AwaitNeighborResolution waits up to timeout for the gateway of every IPv4
+// and IPv6 address of every OTG interface in c to be resolved by ARP or ND.
+// It should be called after otg.StartProtocols.  If any gateway is not
+// resolved, it fails the test with a diagnosis for each of them, such as a
+// port being down, a missing gateway MAC or a likely VLAN mismatch, instead of
+// leaving traffic to be silently dropped.
+func AwaitNeighborResolution(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	var unresolved []gateway
+	for _, g := range gateways(c) {
+		// Give every gateway a chance to be checked once the deadline passes.
+		remaining := max(time.Until(deadline), time.Second)
+		_, ok := gnmi.Watch(t, otg, neighborMAC(g), remaining, func(v *ygnmi.Value[string]) bool {
+			mac, present := v.Val()
+			return present && mac != ""
+		}).Await(t)
+		if !ok {
+			unresolved = append(unresolved, g)
+		}
+	}
+	if len(unresolved) == 0 {
+		return
+	}
+	var diags []string
+	for _, g := range unresolved {
+		link := otgtelemetry.Port_Link_UP
+		if g.port != "" {
+			link, _ = gnmi.Lookup(t, otg, gnmi.OTG().Port(g.port).Link().State()).Val()
+		}
+		diags = append(diags, diagnose(g, link, learnedNeighbors(t, otg, g)))
+	}
+	t.Fatalf("%d of %d OTG gateways not resolved after %v:\n  %s", len(unresolved), len(gateways(c)), timeout, strings.Join(diags, "\n  "))
+}
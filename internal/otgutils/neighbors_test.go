@@ -0,0 +1,127 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+)
+
+func TestGateways(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config func() gosnappi.Config
+		want   []gateway
+	}{{
+		desc:   "no devices",
+		config: gosnappi.NewConfig,
+	}, {
+		desc: "port with IPv4 and IPv6",
+		config: func() gosnappi.Config {
+			c := gosnappi.NewConfig()
+			eth := c.Devices().Add().SetName("dev1").Ethernets().Add().SetName("dev1.Eth").SetMac("02:00:01:01:01:01")
+			eth.Connection().SetPortName("port1")
+			eth.Ipv4Addresses().Add().SetName("dev1.IPv4").SetAddress("192.0.2.2").SetGateway("192.0.2.1").SetPrefix(30)
+			eth.Ipv6Addresses().Add().SetName("dev1.IPv6").SetAddress("2001:db8::2").SetGateway("2001:db8::1").SetPrefix(126)
+			return c
+		},
+		want: []gateway{
+			{intf: "dev1.Eth", port: "port1", ipType: "IPv4", address: "192.0.2.2", gateway: "192.0.2.1"},
+			{intf: "dev1.Eth", port: "port1", ipType: "IPv6", address: "2001:db8::2", gateway: "2001:db8::1"},
+		},
+	}, {
+		desc: "VLAN and LAG interfaces",
+		config: func() gosnappi.Config {
+			c := gosnappi.NewConfig()
+			vlan := c.Devices().Add().SetName("dev1").Ethernets().Add().SetName("dev1.Eth").SetMac("02:00:01:01:01:01")
+			vlan.Connection().SetPortName("port1")
+			vlan.Vlans().Add().SetName("dev1.VLAN").SetId(10)
+			vlan.Ipv4Addresses().Add().SetName("dev1.IPv4").SetAddress("192.0.2.2").SetGateway("192.0.2.1").SetPrefix(30)
+			lag := c.Devices().Add().SetName("dev2").Ethernets().Add().SetName("dev2.Eth").SetMac("02:00:02:01:01:01")
+			lag.Connection().SetLagName("lag1")
+			lag.Ipv4Addresses().Add().SetName("dev2.IPv4").SetAddress("192.0.2.6").SetGateway("192.0.2.5").SetPrefix(30)
+			return c
+		},
+		want: []gateway{
+			{intf: "dev1.Eth", port: "port1", ipType: "IPv4", address: "192.0.2.2", gateway: "192.0.2.1", vlans: []uint32{10}},
+			{intf: "dev2.Eth", ipType: "IPv4", address: "192.0.2.6", gateway: "192.0.2.5"},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := gateways(tc.config())
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(gateway{})); diff != "" {
+				t.Errorf("gateways() got unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	gw := gateway{intf: "dev1.Eth", port: "port1", ipType: "IPv4", address: "192.0.2.2", gateway: "192.0.2.1"}
+	lagGW := gateway{intf: "dev2.Eth", ipType: "IPv4", address: "192.0.2.6", gateway: "192.0.2.5"}
+	vlanGW := gateway{intf: "dev1.Eth", port: "port1", ipType: "IPv4", address: "192.0.2.2", gateway: "192.0.2.1", vlans: []uint32{10}}
+	tests := []struct {
+		desc    string
+		gw      gateway
+		link    otgtelemetry.E_Port_Link
+		learned map[string]string
+		want    string
+	}{{
+		desc: "port down",
+		gw:   gw,
+		link: otgtelemetry.Port_Link_DOWN,
+		want: "port port1 link is DOWN",
+	}, {
+		desc: "LAG ignores link state",
+		gw:   lagGW,
+		link: otgtelemetry.Port_Link_DOWN,
+		want: "no neighbors learned untagged",
+	}, {
+		desc:    "incomplete entry",
+		gw:      gw,
+		link:    otgtelemetry.Port_Link_UP,
+		learned: map[string]string{"192.0.2.1": ""},
+		want:    "resolution is incomplete",
+	}, {
+		desc:    "other neighbor learned",
+		gw:      gw,
+		link:    otgtelemetry.Port_Link_UP,
+		learned: map[string]string{"192.0.2.9": "02:00:00:00:00:09", "192.0.2.10": ""},
+		want:    "gateway MAC missing, but learned 192.0.2.9 (02:00:00:00:00:09);",
+	}, {
+		desc: "nothing learned with VLAN",
+		gw:   vlanGW,
+		link: otgtelemetry.Port_Link_UP,
+		want: "no neighbors learned with VLANs [10]",
+	}, {
+		desc: "nothing learned untagged",
+		gw:   gw,
+		link: otgtelemetry.Port_Link_UP,
+		want: "no neighbors learned untagged",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := diagnose(tc.gw, tc.link, tc.learned)
+			if !strings.HasPrefix(got, tc.gw.String()+": ") || !strings.Contains(got, tc.want) {
+				t.Errorf("diagnose() got %q, want it to start with %q and contain %q", got, tc.gw.String()+": ", tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package programming waits for the DUT to report through telemetry that its
+// dataplane is programmed, so that tests start traffic as soon as the DUT is
+// ready instead of after a fixed sleep.
+//
+// A test lists the signals that its traffic depends on and awaits them
+// before starting traffic:
+//
+//	programming.Await(t, dut, 2*time.Minute,
+//		programming.IPv4Entry(ni, "203.0.113.0/24"),
+//		programming.IPv4Neighbor(p2.Name(), atePort2.IPv4),
+//	)
+//	ate.OTG().StartTraffic(t)
+package programming
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// Signal is a telemetry condition that the DUT reports when a part of its
+// dataplane is ready.
+type Signal struct {
+	desc  string
+	await func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool
+}
+
+// String describes the condition of s.
+func (s Signal) String() string {
+	return s.desc
+}
+
+// Await waits up to timeout for the DUT to report all signals, and returns
+// how long it took.  It fails the test with the signals that were not
+// reported in time.
+func Await(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration, signals ...Signal) time.Duration {
+	t.Helper()
+	start := time.Now()
+	deadline := start.Add(timeout)
+	var missing []string
+	for _, s := range signals {
+		// The signals are awaited in turn, but the DUT programs them
+		// concurrently, so they share the deadline.
+		if !s.await(t, dut, max(time.Until(deadline), time.Second)) {
+			missing = append(missing, s.desc)
+		}
+	}
+	elapsed := time.Since(start)
+	if len(missing) > 0 {
+		t.Fatalf("DUT %s not ready after %v: missing %s", dut.Name(), elapsed, strings.Join(missing, "; "))
+	}
+	t.Logf("DUT %s ready after %v: %d signals reported", dut.Name(), elapsed, len(signals))
+	return elapsed
+}
+
+// IPv4Entry is reported when the AFT of network instance ni has an entry for
+// prefix.
+func IPv4Entry(ni, prefix string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("AFT IPv4 entry %s in %s", prefix, ni),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			_, ok := gnmi.Watch(t, dut, gnmi.OC().NetworkInstance(ni).Afts().Ipv4Entry(prefix).State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv4Entry]) bool {
+				return v.IsPresent()
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// IPv6Entry is reported when the AFT of network instance ni has an entry for
+// prefix.
+func IPv6Entry(ni, prefix string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("AFT IPv6 entry %s in %s", prefix, ni),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			_, ok := gnmi.Watch(t, dut, gnmi.OC().NetworkInstance(ni).Afts().Ipv6Entry(prefix).State(), timeout, func(v *ygnmi.Value[*oc.NetworkInstance_Afts_Ipv6Entry]) bool {
+				return v.IsPresent()
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// IPv4Neighbor is reported when subinterface 0 of interface intf has
+// resolved the link layer address of IPv4 neighbor ip.
+func IPv4Neighbor(intf, ip string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("ARP entry %s on %s", ip, intf),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			_, ok := gnmi.Watch(t, dut, gnmi.OC().Interface(intf).Subinterface(0).Ipv4().Neighbor(ip).LinkLayerAddress().State(), timeout, func(v *ygnmi.Value[string]) bool {
+				mac, present := v.Val()
+				return present && mac != ""
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// IPv6Neighbor is reported when subinterface 0 of interface intf has
+// resolved the link layer address of IPv6 neighbor ip.
+func IPv6Neighbor(intf, ip string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("ND entry %s on %s", ip, intf),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			_, ok := gnmi.Watch(t, dut, gnmi.OC().Interface(intf).Subinterface(0).Ipv6().Neighbor(ip).LinkLayerAddress().State(), timeout, func(v *ygnmi.Value[string]) bool {
+				mac, present := v.Val()
+				return present && mac != ""
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// ISISAdjacency is reported when IS-IS instance of network instance ni has an
+// adjacency UP at any level on interface intf.  intf is named as in the
+// IS-IS configuration of the DUT, e.g. with a ".0" suffix when the
+// explicit_interface_in_default_vrf deviation is set.
+func ISISAdjacency(ni, instance, intf string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("IS-IS adjacency on %s", intf),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			isis := gnmi.OC().NetworkInstance(ni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS, instance).Isis()
+			_, ok := gnmi.WatchAll(t, dut, isis.Interface(intf).LevelAny().AdjacencyAny().AdjacencyState().State(), timeout, func(v *ygnmi.Value[oc.E_Isis_IsisInterfaceAdjState]) bool {
+				state, present := v.Val()
+				return present && state == oc.Isis_IsisInterfaceAdjState_UP
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// BGPNeighbor is reported when the BGP session of network instance ni with
+// neighbor is ESTABLISHED.  instance is the name of the BGP protocol, which
+// most tests configure as "BGP".
+func BGPNeighbor(ni, instance, neighbor string) Signal {
+	return Signal{
+		desc: fmt.Sprintf("BGP session with %s", neighbor),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			bgp := gnmi.OC().NetworkInstance(ni).Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, instance).Bgp()
+			_, ok := gnmi.Watch(t, dut, bgp.Neighbor(neighbor).SessionState().State(), timeout, func(v *ygnmi.Value[oc.E_Bgp_Neighbor_SessionState]) bool {
+				state, present := v.Val()
+				return present && state == oc.Bgp_Neighbor_SessionState_ESTABLISHED
+			}).Await(t)
+			return ok
+		},
+	}
+}
+
+// ControllerReady is reported when controller card name has rebooted since
+// the given time and is back in a redundant role.  The reboot is seen either
+// as a last-reboot-time after since, or as the redundant role disappearing
+// or the oper-status leaving ACTIVE, so that the signal is not reported
+// before the reboot started.
+func ControllerReady(name string, since time.Time) Signal {
+	return Signal{
+		desc: fmt.Sprintf("controller %s rebooted since %v", name, since.Format(time.RFC3339)),
+		await: func(t testing.TB, dut *ondatra.DUTDevice, timeout time.Duration) bool {
+			rebooted := false
+			_, ok := gnmi.Watch(t, dut, gnmi.OC().Component(name).State(), timeout, func(v *ygnmi.Value[*oc.Component]) bool {
+				c, present := v.Val()
+				switch {
+				case !present || c.GetRedundantRole() == oc.Platform_ComponentRedundantRole_UNSET:
+					rebooted = true
+					return false
+				case c.GetOperStatus() != oc.PlatformTypes_COMPONENT_OPER_STATUS_ACTIVE:
+					rebooted = true
+					return false
+				case c.GetLastRebootTime() > uint64(since.UnixNano()):
+					rebooted = true
+				}
+				return rebooted
+			}).Await(t)
+			return ok
+		},
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ribcheck compares the routes advertised by the OTG over BGP and
+// IS-IS with the routes the DUT reports in its AFT telemetry, so that protocol
+// scale tests can report which prefixes are missing or extra rather than only
+// a mismatched count.
+//
+// Advertised expands the BGP and IS-IS route ranges of an OTG config into
+// prefixes.  FIB reads all the IPv4 and IPv6 AFT entries of a network
+// instance, and LookupSample reads only the entries of a sample of the
+// advertised prefixes, for scales where reading the whole AFT is too slow.
+// Compare then produces a Report of the differences.
+//
+// The origin protocol of an AFT entry is compared with the protocol a prefix
+// was advertised with only if the DUT reports it.
+package ribcheck
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+// MaxDiagnostics is the most prefixes of each kind of difference listed by
+// Report.Err.
+var MaxDiagnostics = 10
+
+// Advertisement is a prefix advertised by the OTG and the protocol it was
+// advertised with.
+type Advertisement struct {
+	Prefix   netip.Prefix
+	Protocol oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE
+}
+
+// Advertised returns the prefixes of the BGP and IS-IS route ranges of all
+// devices in top.  A prefix advertised by several devices or protocols is
+// returned once, with the protocol it is first advertised with.
+func Advertised(top gosnappi.Config) ([]Advertisement, error) {
+	var adv []Advertisement
+	seen := make(map[netip.Prefix]bool)
+	add := func(addrs []routeAddress, proto oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE) error {
+		for _, a := range addrs {
+			prefixes, err := expand(a)
+			if err != nil {
+				return err
+			}
+			for _, p := range prefixes {
+				if !seen[p] {
+					seen[p] = true
+					adv = append(adv, Advertisement{Prefix: p, Protocol: proto})
+				}
+			}
+		}
+		return nil
+	}
+	bgp := oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP
+	isis := oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS
+	for _, d := range top.Devices().Items() {
+		if d.HasBgp() {
+			for _, intf := range d.Bgp().Ipv4Interfaces().Items() {
+				for _, peer := range intf.Peers().Items() {
+					for _, r := range peer.V4Routes().Items() {
+						if err := add(v4Addresses(r.Addresses().Items()), bgp); err != nil {
+							return nil, fmt.Errorf("BGP route %s: %w", r.Name(), err)
+						}
+					}
+					for _, r := range peer.V6Routes().Items() {
+						if err := add(v6Addresses(r.Addresses().Items()), bgp); err != nil {
+							return nil, fmt.Errorf("BGP route %s: %w", r.Name(), err)
+						}
+					}
+				}
+			}
+			for _, intf := range d.Bgp().Ipv6Interfaces().Items() {
+				for _, peer := range intf.Peers().Items() {
+					for _, r := range peer.V4Routes().Items() {
+						if err := add(v4Addresses(r.Addresses().Items()), bgp); err != nil {
+							return nil, fmt.Errorf("BGP route %s: %w", r.Name(), err)
+						}
+					}
+					for _, r := range peer.V6Routes().Items() {
+						if err := add(v6Addresses(r.Addresses().Items()), bgp); err != nil {
+							return nil, fmt.Errorf("BGP route %s: %w", r.Name(), err)
+						}
+					}
+				}
+			}
+		}
+		if d.HasIsis() {
+			for _, r := range d.Isis().V4Routes().Items() {
+				if err := add(v4Addresses(r.Addresses().Items()), isis); err != nil {
+					return nil, fmt.Errorf("IS-IS route %s: %w", r.Name(), err)
+				}
+			}
+			for _, r := range d.Isis().V6Routes().Items() {
+				if err := add(v6Addresses(r.Addresses().Items()), isis); err != nil {
+					return nil, fmt.Errorf("IS-IS route %s: %w", r.Name(), err)
+				}
+			}
+		}
+	}
+	return adv, nil
+}
+
+// routeAddress is a block of routes of an OTG route range.
+type routeAddress struct {
+	addr        string
+	length      int
+	count, step uint32
+}
+
+func v4Addresses(items []gosnappi.V4RouteAddress) []routeAddress {
+	var addrs []routeAddress
+	for _, a := range items {
+		addrs = append(addrs, routeAddress{a.Address(), int(a.Prefix()), a.Count(), a.Step()})
+	}
+	return addrs
+}
+
+func v6Addresses(items []gosnappi.V6RouteAddress) []routeAddress {
+	var addrs []routeAddress
+	for _, a := range items {
+		addrs = append(addrs, routeAddress{a.Address(), int(a.Prefix()), a.Count(), a.Step()})
+	}
+	return addrs
+}
+
+// expand returns the count prefixes of a, each step prefixes of its length
+// after the previous one.
+func expand(a routeAddress) ([]netip.Prefix, error) {
+	addr, err := netip.ParseAddr(a.addr)
+	if err != nil {
+		return nil, err
+	}
+	p, err := addr.Prefix(a.length)
+	if err != nil {
+		return nil, err
+	}
+	prefixes := []netip.Prefix{p}
+	for i := uint32(1); i < a.count; i++ {
+		if p, err = next(p, a.step); err != nil {
+			return nil, fmt.Errorf("%s count %d step %d: %w", a.addr, a.count, a.step, err)
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes, nil
+}
+
+// next returns the prefix step prefixes of the length of p after p.
+func next(p netip.Prefix, step uint32) (netip.Prefix, error) {
+	b := p.Addr().AsSlice()
+	n := new(big.Int).SetBytes(b)
+	n.Add(n, new(big.Int).Lsh(big.NewInt(int64(step)), uint(len(b)*8-p.Bits())))
+	if n.BitLen() > len(b)*8 {
+		return netip.Prefix{}, fmt.Errorf("prefix after %s overflows the address space", p)
+	}
+	addr, _ := netip.AddrFromSlice(n.FillBytes(b))
+	return netip.PrefixFrom(addr, p.Bits()), nil
+}
+
+// FIB returns the origin protocol of each IPv4 and IPv6 AFT entry of network
+// instance ni of dut.
+func FIB(t testing.TB, dut *ondatra.DUTDevice, ni string) map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(ni).Afts()
+	fib := make(map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE)
+	for _, v := range gnmi.LookupAll(t, dut, afts.Ipv4EntryAny().State()) {
+		if e, ok := v.Val(); ok {
+			addEntry(t, fib, e.GetPrefix(), e.GetOriginProtocol())
+		}
+	}
+	for _, v := range gnmi.LookupAll(t, dut, afts.Ipv6EntryAny().State()) {
+		if e, ok := v.Val(); ok {
+			addEntry(t, fib, e.GetPrefix(), e.GetOriginProtocol())
+		}
+	}
+	return fib
+}
+
+func addEntry(t testing.TB, fib map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE, prefix string, proto oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE) {
+	t.Helper()
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		t.Errorf("AFT entry with invalid prefix %q: %v", prefix, err)
+		return
+	}
+	fib[p.Masked()] = proto
+}
+
+// Sample returns n of adv spread evenly over adv, including the first and
+// last.  All of adv are returned if n is not less than its length.
+func Sample(adv []Advertisement, n int) []Advertisement {
+	if n >= len(adv) {
+		return adv
+	}
+	if n <= 0 {
+		return nil
+	}
+	if n == 1 {
+		return adv[:1]
+	}
+	sample := make([]Advertisement, n)
+	for i := range sample {
+		sample[i] = adv[i*(len(adv)-1)/(n-1)]
+	}
+	return sample
+}
+
+// LookupSample returns the origin protocol of the AFT entries of network
+// instance ni of dut for the prefixes of sample.  Prefixes with no AFT entry
+// are left out.
+func LookupSample(t testing.TB, dut *ondatra.DUTDevice, ni string, sample []Advertisement) map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE {
+	t.Helper()
+	afts := gnmi.OC().NetworkInstance(ni).Afts()
+	fib := make(map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE)
+	for _, a := range sample {
+		if a.Prefix.Addr().Is4() {
+			if e, ok := gnmi.Lookup(t, dut, afts.Ipv4Entry(a.Prefix.String()).State()).Val(); ok {
+				fib[a.Prefix] = e.GetOriginProtocol()
+			}
+		} else {
+			if e, ok := gnmi.Lookup(t, dut, afts.Ipv6Entry(a.Prefix.String()).State()).Val(); ok {
+				fib[a.Prefix] = e.GetOriginProtocol()
+			}
+		}
+	}
+	return fib
+}
+
+// Report is the difference between the advertised and the installed routes.
+type Report struct {
+	// Advertised is the number of advertised prefixes.
+	Advertised int
+	// Installed is the number of installed prefixes with an advertised
+	// origin protocol, or with no origin protocol reported that were
+	// advertised.
+	Installed int
+	// Missing are the advertised prefixes that are not installed.
+	Missing []netip.Prefix
+	// Extra are the installed prefixes with an advertised origin protocol
+	// that were not advertised.
+	Extra []netip.Prefix
+	// WrongProtocol are the prefixes installed with another origin protocol
+	// than they were advertised with.
+	WrongProtocol []netip.Prefix
+}
+
+// Compare returns the difference between the advertised prefixes adv and the
+// installed prefixes fib, as returned by FIB or LookupSample.  Installed
+// prefixes with no origin protocol reported are never extra.
+func Compare(adv []Advertisement, fib map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE) *Report {
+	r := &Report{Advertised: len(adv)}
+	advertised := make(map[netip.Prefix]bool)
+	protos := make(map[oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE]bool)
+	for _, a := range adv {
+		advertised[a.Prefix] = true
+		protos[a.Protocol] = true
+		proto, ok := fib[a.Prefix]
+		switch {
+		case !ok:
+			r.Missing = append(r.Missing, a.Prefix)
+		case proto != oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_UNSET && proto != a.Protocol:
+			r.WrongProtocol = append(r.WrongProtocol, a.Prefix)
+		}
+	}
+	for p, proto := range fib {
+		switch {
+		case advertised[p]:
+			r.Installed++
+		case protos[proto]:
+			r.Installed++
+			r.Extra = append(r.Extra, p)
+		}
+	}
+	sortPrefixes(r.Missing)
+	sortPrefixes(r.Extra)
+	sortPrefixes(r.WrongProtocol)
+	return r
+}
+
+func sortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
+
+// Err returns an error listing up to MaxDiagnostics of the missing, extra and
+// wrong protocol prefixes, or nil if there are none.
+func (r *Report) Err() error {
+	var errs []string
+	for _, d := range []struct {
+		desc     string
+		prefixes []netip.Prefix
+	}{
+		{"missing", r.Missing},
+		{"extra", r.Extra},
+		{"with the wrong origin protocol", r.WrongProtocol},
+	} {
+		if len(d.prefixes) > 0 {
+			errs = append(errs, fmt.Sprintf("%d prefixes %s: %s", len(d.prefixes), d.desc, list(d.prefixes)))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d prefixes advertised, %d installed; %s", r.Advertised, r.Installed, strings.Join(errs, "; "))
+}
+
+// list returns the first MaxDiagnostics of prefixes.
+func list(prefixes []netip.Prefix) string {
+	var s []string
+	for i, p := range prefixes {
+		if i == MaxDiagnostics {
+			s = append(s, "...")
+			break
+		}
+		s = append(s, p.String())
+	}
+	return strings.Join(s, ", ")
+}
+
+// Check compares the routes advertised in top with the AFT entries of network
+// instance ni of dut.  If sample is positive, only that many of the
+// advertised prefixes are looked up, and no extra prefixes are reported.
+func Check(t testing.TB, dut *ondatra.DUTDevice, ni string, top gosnappi.Config, sample int) *Report {
+	t.Helper()
+	adv, err := Advertised(top)
+	if err != nil {
+		t.Fatalf("Cannot expand the routes advertised by the OTG: %v", err)
+	}
+	if sample > 0 {
+		adv = Sample(adv, sample)
+		return Compare(adv, LookupSample(t, dut, ni, adv))
+	}
+	return Compare(adv, FIB(t, dut, ni))
+}
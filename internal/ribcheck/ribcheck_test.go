@@ -0,0 +1,199 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ribcheck
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+)
+
+var (
+	bgp  = oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP
+	isis = oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_ISIS
+)
+
+func prefixes(s ...string) []netip.Prefix {
+	var p []netip.Prefix
+	for _, v := range s {
+		p = append(p, netip.MustParsePrefix(v))
+	}
+	return p
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		desc    string
+		a       routeAddress
+		want    []netip.Prefix
+		wantErr bool
+	}{{
+		desc: "single",
+		a:    routeAddress{"198.18.0.0", 24, 1, 1},
+		want: prefixes("198.18.0.0/24"),
+	}, {
+		desc: "host bits masked",
+		a:    routeAddress{"198.18.0.7", 24, 1, 1},
+		want: prefixes("198.18.0.0/24"),
+	}, {
+		desc: "IPv4 count",
+		a:    routeAddress{"198.18.0.0", 26, 3, 1},
+		want: prefixes("198.18.0.0/26", "198.18.0.64/26", "198.18.0.128/26"),
+	}, {
+		desc: "IPv4 step across octets",
+		a:    routeAddress{"198.18.0.0", 24, 3, 2},
+		want: prefixes("198.18.0.0/24", "198.18.2.0/24", "198.18.4.0/24"),
+	}, {
+		desc: "IPv6 count",
+		a:    routeAddress{"2001:db8::", 64, 2, 1},
+		want: prefixes("2001:db8::/64", "2001:db8:0:1::/64"),
+	}, {
+		desc:    "overflow",
+		a:       routeAddress{"255.255.255.0", 24, 2, 1},
+		wantErr: true,
+	}, {
+		desc:    "invalid address",
+		a:       routeAddress{"198.51.100", 24, 1, 1},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := expand(tt.a)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expand(%v) got error %v, want error: %t", tt.a, err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateComparable(netip.Prefix{})); diff != "" {
+				t.Errorf("expand(%v) returned diff (-want +got):\n%s", tt.a, diff)
+			}
+		})
+	}
+}
+
+func TestAdvertised(t *testing.T) {
+	top := gosnappi.NewConfig()
+	d := top.Devices().Add().SetName("d1")
+	peer := d.Bgp().SetRouterId("192.0.2.2").Ipv4Interfaces().Add().SetIpv4Name("d1.ipv4").Peers().Add().SetName("d1.bgp")
+	peer.V4Routes().Add().SetName("v4").Addresses().Add().SetAddress("198.18.0.0").SetPrefix(24).SetCount(2)
+	peer.V6Routes().Add().SetName("v6").Addresses().Add().SetAddress("2001:db8::").SetPrefix(48).SetCount(1)
+	d.Isis().V4Routes().Add().SetName("isis").Addresses().Add().SetAddress("203.0.113.0").SetPrefix(24).SetCount(1)
+	// Advertised by BGP already.
+	d.Isis().V4Routes().Add().SetName("dup").Addresses().Add().SetAddress("198.18.1.0").SetPrefix(24).SetCount(1)
+
+	got, err := Advertised(top)
+	if err != nil {
+		t.Fatalf("Advertised() returned error: %v", err)
+	}
+	want := []Advertisement{
+		{netip.MustParsePrefix("198.18.0.0/24"), bgp},
+		{netip.MustParsePrefix("198.18.1.0/24"), bgp},
+		{netip.MustParsePrefix("2001:db8::/48"), bgp},
+		{netip.MustParsePrefix("203.0.113.0/24"), isis},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateComparable(netip.Prefix{})); diff != "" {
+		t.Errorf("Advertised() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSample(t *testing.T) {
+	var adv []Advertisement
+	for _, p := range prefixes("192.0.2.0/32", "192.0.2.1/32", "192.0.2.2/32", "192.0.2.3/32", "192.0.2.4/32") {
+		adv = append(adv, Advertisement{p, bgp})
+	}
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{0, nil},
+		{1, []int{0}},
+		{2, []int{0, 4}},
+		{3, []int{0, 2, 4}},
+		{5, []int{0, 1, 2, 3, 4}},
+		{10, []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		var want []Advertisement
+		for _, i := range tt.want {
+			want = append(want, adv[i])
+		}
+		if diff := cmp.Diff(want, Sample(adv, tt.n), cmpopts.EquateComparable(netip.Prefix{})); diff != "" {
+			t.Errorf("Sample(%d) returned diff (-want +got):\n%s", tt.n, diff)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	adv := []Advertisement{
+		{netip.MustParsePrefix("198.18.0.0/24"), bgp},
+		{netip.MustParsePrefix("198.18.1.0/24"), bgp},
+		{netip.MustParsePrefix("198.18.2.0/24"), bgp},
+		{netip.MustParsePrefix("203.0.113.0/24"), isis},
+		{netip.MustParsePrefix("2001:db8::/48"), isis},
+	}
+	fib := map[netip.Prefix]oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE{
+		netip.MustParsePrefix("198.18.0.0/24"):  bgp,
+		netip.MustParsePrefix("198.18.2.0/24"):  oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_UNSET,
+		netip.MustParsePrefix("203.0.113.0/24"): bgp,
+		netip.MustParsePrefix("192.0.2.0/24"):   bgp,
+		netip.MustParsePrefix("192.0.2.128/25"): oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_DIRECTLY_CONNECTED,
+		netip.MustParsePrefix("100.64.0.0/10"):  oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_UNSET,
+	}
+	want := &Report{
+		Advertised:    5,
+		Installed:     4,
+		Missing:       prefixes("198.18.1.0/24", "2001:db8::/48"),
+		Extra:         prefixes("192.0.2.0/24"),
+		WrongProtocol: prefixes("203.0.113.0/24"),
+	}
+	got := Compare(adv, fib)
+	if diff := cmp.Diff(want, got, cmpopts.EquateComparable(netip.Prefix{})); diff != "" {
+		t.Errorf("Compare() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportErr(t *testing.T) {
+	if err := (&Report{Advertised: 2, Installed: 2}).Err(); err != nil {
+		t.Errorf("Err() of a report with no differences returned %v, want nil", err)
+	}
+
+	defer func(n int) { MaxDiagnostics = n }(MaxDiagnostics)
+	MaxDiagnostics = 2
+	r := &Report{
+		Advertised: 10,
+		Installed:  7,
+		Missing:    prefixes("198.18.0.0/24", "198.18.1.0/24", "198.18.2.0/24"),
+		Extra:      prefixes("192.0.2.0/24"),
+	}
+	err := r.Err()
+	if err == nil {
+		t.Fatal("Err() returned nil, want error")
+	}
+	for _, want := range []string{
+		"10 prefixes advertised, 7 installed",
+		"3 prefixes missing: 198.18.0.0/24, 198.18.1.0/24, ...",
+		"1 prefixes extra: 192.0.2.0/24",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Err() returned %q, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "wrong origin protocol") {
+		t.Errorf("Err() returned %q, want no wrong origin protocol prefixes", err)
+	}
+}
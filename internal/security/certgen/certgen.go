@@ -0,0 +1,338 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certgen mints CAs, server and client certificates and CRLs on the
+// fly, so security tests do not need to bundle static certificates.
+package certgen
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	organization = "OpenconfigFeatureProfiles"
+	// clockSkew is subtracted from NotBefore of generated certificates so
+	// that they are valid on devices whose clock is slightly behind.
+	clockSkew = time.Hour
+	// DefaultValidity is the validity of certificates when Spec does not
+	// set NotAfter.
+	DefaultValidity = 365 * 24 * time.Hour
+)
+
+// Spec describes a certificate to generate.
+type Spec struct {
+	// CommonName is the subject common name.
+	CommonName string
+	// DNSNames and IPAddresses are added as subject alternative names.
+	DNSNames    []string
+	IPAddresses []net.IP
+	// SPIFFEID, if set, is added as a URI subject alternative name, e.g.
+	// "spiffe://example.org/user/admin".
+	SPIFFEID string
+	// KeyAlgo is x509.RSA or x509.ECDSA.  It defaults to x509.ECDSA.
+	KeyAlgo x509.PublicKeyAlgorithm
+	// Server and Client select the extended key usages.  If neither is set,
+	// both are used.
+	Server, Client bool
+	// NotBefore and NotAfter default to an hour ago and DefaultValidity
+	// from now.
+	NotBefore, NotAfter time.Time
+}
+
+// Expired returns a copy of s that expired a day ago.
+func Expired(s Spec) Spec {
+	s.NotBefore = time.Now().Add(-2 * DefaultValidity)
+	s.NotAfter = time.Now().Add(-24 * time.Hour)
+	return s
+}
+
+// NotYetValid returns a copy of s that becomes valid in a day.
+func NotYetValid(s Spec) Spec {
+	s.NotBefore = time.Now().Add(24 * time.Hour)
+	s.NotAfter = s.NotBefore.Add(DefaultValidity)
+	return s
+}
+
+func (s Spec) template(serial *big.Int) (*x509.Certificate, error) {
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   s.CommonName,
+			Organization: []string{organization},
+		},
+		DNSNames:    s.DNSNames,
+		IPAddresses: s.IPAddresses,
+		NotBefore:   s.NotBefore,
+		NotAfter:    s.NotAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	if tmpl.NotBefore.IsZero() {
+		tmpl.NotBefore = time.Now().Add(-clockSkew)
+	}
+	if tmpl.NotAfter.IsZero() {
+		tmpl.NotAfter = time.Now().Add(DefaultValidity)
+	}
+	if s.SPIFFEID != "" {
+		uri, err := url.Parse(s.SPIFFEID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPIFFE ID %q: %w", s.SPIFFEID, err)
+		}
+		tmpl.URIs = []*url.URL{uri}
+	}
+	if s.Server || !s.Client {
+		tmpl.ExtKeyUsage = append(tmpl.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+	}
+	if s.Client || !s.Server {
+		tmpl.ExtKeyUsage = append(tmpl.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+	}
+	return tmpl, nil
+}
+
+// CA is a certificate authority that issues certificates and CRLs.
+type CA struct {
+	// Cert is the CA certificate and Key its private key.
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Chain is the chain of CA certificates from Cert up to, but not
+	// including, the root.  It is empty for a root CA.
+	Chain []*x509.Certificate
+
+	mu        sync.Mutex
+	crlNumber int64
+	revoked   []x509.RevocationListEntry
+}
+
+// NewCA returns a self-signed root CA using a key of the given algorithm.
+func NewCA(commonName string, keyAlgo x509.PublicKeyAlgorithm) (*CA, error) {
+	key, err := newKey(keyAlgo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := caTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := createCert(tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{Cert: cert, Key: key}, nil
+}
+
+// NewIntermediate returns an intermediate CA signed by ca.
+func (ca *CA) NewIntermediate(commonName string, keyAlgo x509.PublicKeyAlgorithm) (*CA, error) {
+	key, err := newKey(keyAlgo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := caTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := createCert(tmpl, ca.Cert, key.Public(), ca.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{Cert: cert, Key: key, Chain: append([]*x509.Certificate{ca.Cert}, ca.Chain...)}, nil
+}
+
+// Issue returns a certificate for s signed by ca.  The returned certificate
+// chain includes any intermediate CAs, and Leaf is set.
+func (ca *CA) Issue(s Spec) (*tls.Certificate, error) {
+	key, err := newKey(s.KeyAlgo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := newTemplate(s)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := createCert(tmpl, ca.Cert, key.Public(), ca.Key)
+	if err != nil {
+		return nil, err
+	}
+	tc := &tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}
+	if len(ca.Chain) > 0 {
+		tc.Certificate = append(tc.Certificate, ca.Cert.Raw)
+		for _, c := range ca.Chain[:len(ca.Chain)-1] {
+			tc.Certificate = append(tc.Certificate, c.Raw)
+		}
+	}
+	return tc, nil
+}
+
+// SelfSigned returns a certificate for s that is signed by its own key and
+// so is not trusted by any CA.
+func SelfSigned(s Spec) (*tls.Certificate, error) {
+	key, err := newKey(s.KeyAlgo)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := newTemplate(s)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := createCert(tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}, nil
+}
+
+// Pool returns a certificate pool containing the root of ca.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	if len(ca.Chain) == 0 {
+		pool.AddCert(ca.Cert)
+	} else {
+		pool.AddCert(ca.Chain[len(ca.Chain)-1])
+	}
+	return pool
+}
+
+// Revoke adds cert to the certificates revoked by ca.
+func (ca *CA) Revoke(cert *x509.Certificate) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.revoked = append(ca.revoked, x509.RevocationListEntry{
+		SerialNumber:   cert.SerialNumber,
+		RevocationTime: time.Now(),
+	})
+}
+
+// CRL returns a DER encoded CRL signed by ca listing the revoked
+// certificates, valid for validFor.  Each call increments the CRL number.
+func (ca *CA) CRL(validFor time.Duration) ([]byte, error) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.crlNumber++
+	now := time.Now()
+	return x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(ca.crlNumber),
+		ThisUpdate:                now.Add(-clockSkew),
+		NextUpdate:                now.Add(validFor),
+		RevokedCertificateEntries: append([]x509.RevocationListEntry(nil), ca.revoked...),
+	}, ca.Cert, ca.Key)
+}
+
+func newTemplate(s Spec) (*x509.Certificate, error) {
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	return s.template(serial)
+}
+
+func caTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{organization},
+		},
+		NotBefore:             time.Now().Add(-clockSkew),
+		NotAfter:              time.Now().Add(10 * DefaultValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func newKey(keyAlgo x509.PublicKeyAlgorithm) (crypto.Signer, error) {
+	switch keyAlgo {
+	case x509.RSA:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case x509.ECDSA, x509.UnknownPublicKeyAlgorithm:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("key algorithm %v is not supported", keyAlgo)
+	}
+}
+
+func createCert(tmpl, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer) (*x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, signer)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// CertPEM returns the PEM encoding of certs.
+func CertPEM(certs ...*x509.Certificate) []byte {
+	var b []byte
+	for _, c := range certs {
+		b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return b
+}
+
+// KeyPEM returns the PKCS #8 PEM encoding of key.
+func KeyPEM(key crypto.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// CRLPEM returns the PEM encoding of a DER encoded CRL.
+func CRLPEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+// WriteFiles writes the certificate chain and private key of tc to
+// <name>-cert.pem and <name>-key.pem in dir and returns their paths.
+func WriteFiles(dir, name string, tc *tls.Certificate) (certPath, keyPath string, err error) {
+	var chain []byte
+	for _, der := range tc.Certificate {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	key, err := KeyPEM(tc.PrivateKey)
+	if err != nil {
+		return "", "", err
+	}
+	certPath = filepath.Join(dir, name+"-cert.pem")
+	keyPath = filepath.Join(dir, name+"-key.pem")
+	if err := os.WriteFile(certPath, chain, 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(keyPath, key, 0o600); err != nil {
+		return "", "", err
+	}
+	return certPath, keyPath, nil
+}
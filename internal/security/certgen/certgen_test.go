@@ -0,0 +1,219 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certgen
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustCA(t *testing.T, keyAlgo x509.PublicKeyAlgorithm) *CA {
+	t.Helper()
+	ca, err := NewCA("test-ca", keyAlgo)
+	if err != nil {
+		t.Fatalf("NewCA() failed: %v", err)
+	}
+	return ca
+}
+
+// verify verifies the leaf of tc against the root of ca using any
+// intermediates in the chain of tc.
+func verify(ca *CA, tc *tls.Certificate, usage x509.ExtKeyUsage) error {
+	inter := x509.NewCertPool()
+	for _, der := range tc.Certificate[1:] {
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		inter.AddCert(c)
+	}
+	_, err := tc.Leaf.Verify(x509.VerifyOptions{
+		Roots:         ca.Pool(),
+		Intermediates: inter,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+func TestIssue(t *testing.T) {
+	for _, keyAlgo := range []x509.PublicKeyAlgorithm{x509.RSA, x509.ECDSA} {
+		t.Run(keyAlgo.String(), func(t *testing.T) {
+			ca := mustCA(t, keyAlgo)
+			spec := Spec{
+				CommonName:  "dut",
+				DNSNames:    []string{"dut.example.org"},
+				IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+				SPIFFEID:    "spiffe://example.org/role/dut",
+				KeyAlgo:     keyAlgo,
+				Server:      true,
+			}
+			tc, err := ca.Issue(spec)
+			if err != nil {
+				t.Fatalf("Issue() failed: %v", err)
+			}
+			if got := tc.Leaf.PublicKeyAlgorithm; got != keyAlgo {
+				t.Errorf("PublicKeyAlgorithm got %v, want %v", got, keyAlgo)
+			}
+			if got := tc.Leaf.Subject.CommonName; got != spec.CommonName {
+				t.Errorf("CommonName got %q, want %q", got, spec.CommonName)
+			}
+			if diff := cmp.Diff(spec.DNSNames, tc.Leaf.DNSNames); diff != "" {
+				t.Errorf("DNSNames diff (-want +got):\n%s", diff)
+			}
+			if len(tc.Leaf.IPAddresses) != 1 || !tc.Leaf.IPAddresses[0].Equal(spec.IPAddresses[0]) {
+				t.Errorf("IPAddresses got %v, want %v", tc.Leaf.IPAddresses, spec.IPAddresses)
+			}
+			if len(tc.Leaf.URIs) != 1 || tc.Leaf.URIs[0].String() != spec.SPIFFEID {
+				t.Errorf("URIs got %v, want [%s]", tc.Leaf.URIs, spec.SPIFFEID)
+			}
+			if err := verify(ca, tc, x509.ExtKeyUsageServerAuth); err != nil {
+				t.Errorf("Verify() for server auth failed: %v", err)
+			}
+			if err := verify(ca, tc, x509.ExtKeyUsageClientAuth); err == nil {
+				t.Errorf("Verify() for client auth of a server certificate succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestIssueIntermediate(t *testing.T) {
+	root := mustCA(t, x509.ECDSA)
+	inter1, err := root.NewIntermediate("inter1", x509.ECDSA)
+	if err != nil {
+		t.Fatalf("NewIntermediate() failed: %v", err)
+	}
+	inter2, err := inter1.NewIntermediate("inter2", x509.RSA)
+	if err != nil {
+		t.Fatalf("NewIntermediate() failed: %v", err)
+	}
+	tc, err := inter2.Issue(Spec{CommonName: "client", Client: true})
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	if got, want := len(tc.Certificate), 3; got != want {
+		t.Errorf("Certificate chain length got %d, want %d", got, want)
+	}
+	if err := verify(inter2, tc, x509.ExtKeyUsageClientAuth); err != nil {
+		t.Errorf("Verify() failed: %v", err)
+	}
+}
+
+func TestInvalidVariants(t *testing.T) {
+	ca := mustCA(t, x509.ECDSA)
+	spec := Spec{CommonName: "dut"}
+	selfSigned, err := SelfSigned(spec)
+	if err != nil {
+		t.Fatalf("SelfSigned() failed: %v", err)
+	}
+	other, err := mustCA(t, x509.ECDSA).Issue(spec)
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	tests := []struct {
+		desc string
+		spec Spec
+		tc   *tls.Certificate
+	}{
+		{desc: "expired", spec: Expired(spec)},
+		{desc: "not yet valid", spec: NotYetValid(spec)},
+		{desc: "self-signed", tc: selfSigned},
+		{desc: "other CA", tc: other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tc := tt.tc
+			if tc == nil {
+				var err error
+				if tc, err = ca.Issue(tt.spec); err != nil {
+					t.Fatalf("Issue() failed: %v", err)
+				}
+			}
+			if err := verify(ca, tc, x509.ExtKeyUsageServerAuth); err == nil {
+				t.Errorf("Verify() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestCRL(t *testing.T) {
+	ca := mustCA(t, x509.RSA)
+	good, err := ca.Issue(Spec{CommonName: "good"})
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	bad, err := ca.Issue(Spec{CommonName: "bad"})
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	ca.Revoke(bad.Leaf)
+
+	for wantNumber := int64(1); wantNumber <= 2; wantNumber++ {
+		der, err := ca.CRL(time.Hour)
+		if err != nil {
+			t.Fatalf("CRL() failed: %v", err)
+		}
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil {
+			t.Fatalf("ParseRevocationList() failed: %v", err)
+		}
+		if err := crl.CheckSignatureFrom(ca.Cert); err != nil {
+			t.Errorf("CRL signature check failed: %v", err)
+		}
+		if got := crl.Number.Int64(); got != wantNumber {
+			t.Errorf("CRL number got %d, want %d", got, wantNumber)
+		}
+		revoked := map[string]bool{}
+		for _, e := range crl.RevokedCertificateEntries {
+			revoked[e.SerialNumber.String()] = true
+		}
+		if !revoked[bad.Leaf.SerialNumber.String()] || revoked[good.Leaf.SerialNumber.String()] || len(revoked) != 1 {
+			t.Errorf("CRL revoked serials got %v, want only %v", revoked, bad.Leaf.SerialNumber)
+		}
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	ca := mustCA(t, x509.ECDSA)
+	inter, err := ca.NewIntermediate("inter", x509.ECDSA)
+	if err != nil {
+		t.Fatalf("NewIntermediate() failed: %v", err)
+	}
+	tc, err := inter.Issue(Spec{CommonName: "dut"})
+	if err != nil {
+		t.Fatalf("Issue() failed: %v", err)
+	}
+	certPath, keyPath, err := WriteFiles(t.TempDir(), "dut", tc)
+	if err != nil {
+		t.Fatalf("WriteFiles() failed: %v", err)
+	}
+	got, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair() failed: %v", err)
+	}
+	if diff := cmp.Diff(tc.Certificate, got.Certificate); diff != "" {
+		t.Errorf("Loaded certificate chain diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnsupportedKeyAlgo(t *testing.T) {
+	if _, err := NewCA("ca", x509.Ed25519); err == nil {
+		t.Errorf("NewCA() with Ed25519 succeeded, want error")
+	}
+}
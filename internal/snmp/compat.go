@@ -0,0 +1,106 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// SysUpTime is the OID of SNMPv2-MIB::sysUpTime.0.
+const SysUpTime = "1.3.6.1.2.1.1.3.0"
+
+// tick is the unit of TimeTicks.
+const tick = 10 * time.Millisecond
+
+// IfHCInOctets returns the OID of IF-MIB::ifHCInOctets of the interface with
+// ifIndex.
+func IfHCInOctets(ifIndex uint32) string {
+	return fmt.Sprintf("1.3.6.1.2.1.31.1.1.1.6.%d", ifIndex)
+}
+
+// CheckCounter returns an error if the SNMP value of a counter, read
+// between two gNMI reads of it, is not between them within tolerance.
+func CheckCounter(before, snmp, after, tolerance uint64) error {
+	if snmp+tolerance < before || snmp > after+tolerance {
+		return fmt.Errorf("SNMP value %d is outside of the gNMI values [%d, %d] with tolerance %d", snmp, before, after, tolerance)
+	}
+	return nil
+}
+
+// CheckUpTime returns an error if the SNMP sysUpTime in ticks, read between
+// two gNMI reads of the up-time, is not between them within tolerance.
+//
+// sysUpTime wraps around every 2^32 ticks, about 497 days, so the up-times
+// are compared modulo 2^32 ticks.
+func CheckUpTime(before time.Duration, ticks uint64, after, tolerance time.Duration) error {
+	const wrap = 1 << 32
+	lo := uint64(before/tick) % wrap
+	// d is how far ticks is after lo, between -wrap/2 and wrap/2.
+	d := time.Duration(int32(uint32(ticks-lo))) * tick
+	if d < -tolerance || d > after-before+tolerance {
+		return fmt.Errorf("SNMP sysUpTime %v is outside of the gNMI up-times [%v, %v] with tolerance %v", time.Duration(ticks)*tick, before, after, tolerance)
+	}
+	return nil
+}
+
+// getUint64 returns the numeric value of oid from c.
+func getUint64(t testing.TB, c *Client, oid string) uint64 {
+	t.Helper()
+	vars, err := c.Get(context.Background(), oid)
+	if err != nil {
+		t.Fatalf("Cannot get %s over SNMP: %v", oid, err)
+	}
+	v, err := vars[0].Uint64()
+	if err != nil {
+		t.Fatalf("Cannot get %s over SNMP: %v", oid, err)
+	}
+	return v
+}
+
+// CompareUpTime verifies the sysUpTime of the DUT over SNMP matches its
+// /system/state/up-time over gNMI within tolerance.
+//
+// sysUpTime is the time since the SNMP agent started, so tolerance must
+// allow for the agent starting after the system.
+func CompareUpTime(t testing.TB, dut *ondatra.DUTDevice, c *Client, tolerance time.Duration) {
+	t.Helper()
+	upTime := gnmi.OC().System().UpTime().State()
+	before := time.Duration(gnmi.Get(t, dut, upTime))
+	ticks := getUint64(t, c, SysUpTime)
+	after := time.Duration(gnmi.Get(t, dut, upTime))
+	if err := CheckUpTime(before, ticks, after, tolerance); err != nil {
+		t.Errorf("DUT %s up-time: %v", dut.Name(), err)
+	}
+}
+
+// CompareInOctets verifies the ifHCInOctets of the DUT interface over SNMP
+// matches its in-octets over gNMI within tolerance.
+func CompareInOctets(t testing.TB, dut *ondatra.DUTDevice, c *Client, intf string, tolerance uint64) {
+	t.Helper()
+	path := gnmi.OC().Interface(intf)
+	ifIndex := gnmi.Get(t, dut, path.Ifindex().State())
+	before := gnmi.Get(t, dut, path.Counters().InOctets().State())
+	octets := getUint64(t, c, IfHCInOctets(ifIndex))
+	after := gnmi.Get(t, dut, path.Counters().InOctets().State())
+	if err := CheckCounter(before, octets, after, tolerance); err != nil {
+		t.Errorf("Interface %s (ifIndex %d) in-octets: %v", intf, ifIndex, err)
+	}
+}
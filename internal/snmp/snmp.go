@@ -0,0 +1,403 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snmp polls a DUT over SNMPv2c and compares the values with the
+// ones the DUT reports over gNMI, for deployments that run both while they
+// migrate from SNMP to gNMI.
+//
+// Only the SNMPv2c Get operation is implemented, which is enough to read
+// the scalar and per-interface objects the checks compare.  The DUT SNMP
+// agent is configured outside of the test, since OpenConfig has no SNMP
+// model.
+package snmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the ASN.1 BER tag of an SNMP value.
+type Type byte
+
+// SNMP value types, from RFC 3416.
+const (
+	Integer          Type = 0x02
+	OctetString      Type = 0x04
+	Null             Type = 0x05
+	ObjectIdentifier Type = 0x06
+	IPAddress        Type = 0x40
+	Counter32        Type = 0x41
+	Gauge32          Type = 0x42
+	TimeTicks        Type = 0x43
+	Counter64        Type = 0x46
+	NoSuchObject     Type = 0x80
+	NoSuchInstance   Type = 0x81
+	EndOfMibView     Type = 0x82
+)
+
+const (
+	tagSequence    = 0x30
+	tagGetRequest  = 0xa0
+	tagGetResponse = 0xa2
+	// version2c is the version field of an SNMPv2c message.
+	version2c = 1
+)
+
+// Variable is the value of an object returned by the agent.
+type Variable struct {
+	OID  string
+	Type Type
+	// Value is the BER encoded contents of the value.
+	Value []byte
+}
+
+// Uint64 returns the value of a numeric variable.
+func (v Variable) Uint64() (uint64, error) {
+	switch v.Type {
+	case Counter32, Gauge32, TimeTicks, Counter64:
+		return decodeUint(v.Value)
+	case Integer:
+		i, err := decodeInt(v.Value)
+		if err != nil {
+			return 0, err
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("%s is negative: %d", v.OID, i)
+		}
+		return uint64(i), nil
+	case NoSuchObject, NoSuchInstance, EndOfMibView:
+		return 0, fmt.Errorf("%s does not exist on the agent: type %#x", v.OID, byte(v.Type))
+	default:
+		return 0, fmt.Errorf("%s is not numeric: type %#x", v.OID, byte(v.Type))
+	}
+}
+
+// Client polls an SNMPv2c agent.
+type Client struct {
+	// Addr is the host:port of the agent.
+	Addr string
+	// Community is the community string of the agent.
+	Community string
+	// Timeout is the time to wait for a response to each attempt, 5 seconds
+	// if zero.
+	Timeout time.Duration
+	// Retries is the number of attempts after the first one times out.
+	Retries int
+}
+
+// Get returns the values of oids, in the same order.
+func (c *Client) Get(ctx context.Context, oids ...string) ([]Variable, error) {
+	id := rand.Int31()
+	req, err := encodeGetRequest(c.Community, id, oids)
+	if err != nil {
+		return nil, err
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "udp", c.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to SNMP agent %s: %w", c.Addr, err)
+	}
+	defer conn.Close()
+
+	for attempt := 0; ; attempt++ {
+		vars, err := c.exchange(ctx, conn, req, id, timeout)
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() && attempt < c.Retries && ctx.Err() == nil {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("SNMP Get of %v from %s failed: %w", oids, c.Addr, err)
+		}
+		if len(vars) != len(oids) {
+			return nil, fmt.Errorf("SNMP Get of %v from %s returned %d variables, want %d", oids, c.Addr, len(vars), len(oids))
+		}
+		return vars, nil
+	}
+}
+
+// exchange sends req and returns the variables of the response with id,
+// ignoring responses to earlier requests.
+func (c *Client) exchange(ctx context.Context, conn net.Conn, req []byte, id int32, timeout time.Duration) ([]Variable, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 65535)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		respID, vars, err := decodeGetResponse(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		if respID == id {
+			return vars, nil
+		}
+	}
+}
+
+// encodeGetRequest returns an SNMPv2c GetRequest message.
+func encodeGetRequest(community string, id int32, oids []string) ([]byte, error) {
+	var bindings []byte
+	for _, oid := range oids {
+		o, err := encodeOID(oid)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, tlv(tagSequence, append(tlv(byte(ObjectIdentifier), o), tlv(byte(Null), nil)...))...)
+	}
+	pdu := tlv(byte(Integer), encodeInt(int64(id)))
+	pdu = append(pdu, tlv(byte(Integer), encodeInt(0))...)
+	pdu = append(pdu, tlv(byte(Integer), encodeInt(0))...)
+	pdu = append(pdu, tlv(tagSequence, bindings)...)
+
+	msg := tlv(byte(Integer), encodeInt(version2c))
+	msg = append(msg, tlv(byte(OctetString), []byte(community))...)
+	msg = append(msg, tlv(tagGetRequest, pdu)...)
+	return tlv(tagSequence, msg), nil
+}
+
+// decodeGetResponse returns the request ID and variables of an SNMPv2c
+// Response message, or an error if the agent returned an error status.
+func decodeGetResponse(b []byte) (int32, []Variable, error) {
+	msg, err := expect(b, tagSequence)
+	if err != nil {
+		return 0, nil, fmt.Errorf("bad message: %w", err)
+	}
+	var fields [3][]byte
+	for i, tag := range []byte{byte(Integer), byte(OctetString), tagGetResponse} {
+		if fields[i], msg, err = next(msg, tag); err != nil {
+			return 0, nil, fmt.Errorf("bad message: %w", err)
+		}
+	}
+	pdu := fields[2]
+	var ints [3]int64
+	for i := range ints {
+		var v []byte
+		if v, pdu, err = next(pdu, byte(Integer)); err != nil {
+			return 0, nil, fmt.Errorf("bad response PDU: %w", err)
+		}
+		if ints[i], err = decodeInt(v); err != nil {
+			return 0, nil, fmt.Errorf("bad response PDU: %w", err)
+		}
+	}
+	id, status, index := int32(ints[0]), ints[1], ints[2]
+	if status != 0 {
+		return id, nil, fmt.Errorf("agent returned error-status %d at error-index %d", status, index)
+	}
+	bindings, _, err := next(pdu, tagSequence)
+	if err != nil {
+		return 0, nil, fmt.Errorf("bad variable bindings: %w", err)
+	}
+	var vars []Variable
+	for len(bindings) > 0 {
+		var vb, o []byte
+		if vb, bindings, err = next(bindings, tagSequence); err != nil {
+			return 0, nil, fmt.Errorf("bad variable binding: %w", err)
+		}
+		if o, vb, err = next(vb, byte(ObjectIdentifier)); err != nil {
+			return 0, nil, fmt.Errorf("bad variable binding: %w", err)
+		}
+		oid, err := decodeOID(o)
+		if err != nil {
+			return 0, nil, err
+		}
+		tag, value, _, err := readTLV(vb)
+		if err != nil {
+			return 0, nil, fmt.Errorf("bad value of %s: %w", oid, err)
+		}
+		vars = append(vars, Variable{OID: oid, Type: Type(tag), Value: value})
+	}
+	return id, vars, nil
+}
+
+// tlv returns the BER encoding of a value with tag and contents.
+func tlv(tag byte, contents []byte) []byte {
+	b := []byte{tag}
+	n := len(contents)
+	switch {
+	case n < 0x80:
+		b = append(b, byte(n))
+	default:
+		var l []byte
+		for ; n > 0; n >>= 8 {
+			l = append([]byte{byte(n)}, l...)
+		}
+		b = append(b, 0x80|byte(len(l)))
+		b = append(b, l...)
+	}
+	return append(b, contents...)
+}
+
+// readTLV returns the tag and contents of the first BER value of b, and the
+// bytes after it.
+func readTLV(b []byte) (byte, []byte, []byte, error) {
+	if len(b) < 2 {
+		return 0, nil, nil, fmt.Errorf("truncated value: % x", b)
+	}
+	tag, n, b := b[0], int(b[1]), b[2:]
+	if n&0x80 != 0 {
+		size := n & 0x7f
+		if size == 0 || size > 4 || len(b) < size {
+			return 0, nil, nil, fmt.Errorf("bad length of value with tag %#x", tag)
+		}
+		n = 0
+		for _, l := range b[:size] {
+			n = n<<8 | int(l)
+		}
+		b = b[size:]
+	}
+	if len(b) < n {
+		return 0, nil, nil, fmt.Errorf("value with tag %#x has length %d, only %d bytes left", tag, n, len(b))
+	}
+	return tag, b[:n], b[n:], nil
+}
+
+// next returns the contents of the first BER value of b, which must have
+// tag, and the bytes after it.
+func next(b []byte, tag byte) ([]byte, []byte, error) {
+	got, contents, rest, err := readTLV(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if got != tag {
+		return nil, nil, fmt.Errorf("got tag %#x, want %#x", got, tag)
+	}
+	return contents, rest, nil
+}
+
+// expect returns the contents of b, which must be a single BER value with
+// tag.
+func expect(b []byte, tag byte) ([]byte, error) {
+	contents, rest, err := next(b, tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%d bytes after value with tag %#x", len(rest), tag)
+	}
+	return contents, nil
+}
+
+// encodeInt returns the minimal two's complement encoding of i.
+func encodeInt(i int64) []byte {
+	var b []byte
+	for {
+		b = append([]byte{byte(i)}, b...)
+		if i >= -0x80 && i < 0x80 {
+			return b
+		}
+		i >>= 8
+	}
+}
+
+func decodeInt(b []byte) (int64, error) {
+	if len(b) == 0 || len(b) > 8 {
+		return 0, fmt.Errorf("bad integer length %d", len(b))
+	}
+	i := int64(int8(b[0]))
+	for _, c := range b[1:] {
+		i = i<<8 | int64(c)
+	}
+	return i, nil
+}
+
+// decodeUint decodes the unsigned types, whose encoding has a leading zero
+// byte when the most significant bit is set.
+func decodeUint(b []byte) (uint64, error) {
+	if len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) == 0 || len(b) > 8 {
+		return 0, fmt.Errorf("bad unsigned integer length %d", len(b))
+	}
+	var u uint64
+	for _, c := range b {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func encodeOID(oid string) ([]byte, error) {
+	parts := strings.Split(strings.TrimPrefix(oid, "."), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("OID %q has fewer than 2 arcs", oid)
+	}
+	arcs := make([]uint64, len(parts))
+	for i, p := range parts {
+		a, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("bad OID %q: %w", oid, err)
+		}
+		arcs[i] = a
+	}
+	if arcs[0] > 2 || arcs[0] < 2 && arcs[1] >= 40 {
+		return nil, fmt.Errorf("bad OID %q: invalid first arcs", oid)
+	}
+	arcs = append([]uint64{arcs[0]*40 + arcs[1]}, arcs[2:]...)
+	var b []byte
+	for _, a := range arcs {
+		enc := []byte{byte(a & 0x7f)}
+		for a >>= 7; a > 0; a >>= 7 {
+			enc = append([]byte{0x80 | byte(a&0x7f)}, enc...)
+		}
+		b = append(b, enc...)
+	}
+	return b, nil
+}
+
+func decodeOID(b []byte) (string, error) {
+	if len(b) == 0 {
+		return "", errors.New("empty OID")
+	}
+	var arcs []uint64
+	var a uint64
+	for i, c := range b {
+		a = a<<7 | uint64(c&0x7f)
+		if c&0x80 != 0 {
+			if i == len(b)-1 {
+				return "", fmt.Errorf("truncated OID: % x", b)
+			}
+			continue
+		}
+		arcs = append(arcs, a)
+		a = 0
+	}
+	first, second := arcs[0]/40, arcs[0]%40
+	if first > 2 {
+		first, second = 2, arcs[0]-80
+	}
+	parts := []string{strconv.FormatUint(first, 10), strconv.FormatUint(second, 10)}
+	for _, a := range arcs[1:] {
+		parts = append(parts, strconv.FormatUint(a, 10))
+	}
+	return strings.Join(parts, "."), nil
+}
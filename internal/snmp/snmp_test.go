@@ -0,0 +1,283 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOID(t *testing.T) {
+	for _, oid := range []string{"1.3.6.1.2.1.1.3.0", "1.3.6.1.2.1.31.1.1.1.6.1000001", "2.999.3", "0.0"} {
+		b, err := encodeOID(oid)
+		if err != nil {
+			t.Fatalf("encodeOID(%q) got error: %v", oid, err)
+		}
+		got, err := decodeOID(b)
+		if err != nil {
+			t.Fatalf("decodeOID(% x) got error: %v", b, err)
+		}
+		if got != oid {
+			t.Errorf("decodeOID(encodeOID(%q)) got %q", oid, got)
+		}
+	}
+	for _, oid := range []string{"1", "1.x.3", "3.1", "1.40"} {
+		if _, err := encodeOID(oid); err == nil {
+			t.Errorf("encodeOID(%q) got no error, want error", oid)
+		}
+	}
+}
+
+func TestInt(t *testing.T) {
+	for _, tc := range []struct {
+		i    int64
+		want []byte
+	}{
+		{0, []byte{0}},
+		{127, []byte{0x7f}},
+		{128, []byte{0, 0x80}},
+		{-1, []byte{0xff}},
+		{-129, []byte{0xff, 0x7f}},
+		{1 << 31, []byte{0, 0x80, 0, 0, 0}},
+	} {
+		got := encodeInt(tc.i)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("encodeInt(%d) -want,+got:\n%s", tc.i, diff)
+		}
+		if i, err := decodeInt(got); err != nil || i != tc.i {
+			t.Errorf("decodeInt(% x) got %d, %v, want %d", got, i, err, tc.i)
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	contents := make([]byte, 300)
+	b := tlv(byte(OctetString), contents)
+	tag, got, rest, err := readTLV(b)
+	if err != nil || tag != byte(OctetString) || len(got) != len(contents) || len(rest) != 0 {
+		t.Errorf("readTLV(tlv(%d bytes)) got tag %#x, %d bytes, %d bytes left, %v", len(contents), tag, len(got), len(rest), err)
+	}
+	if _, _, _, err := readTLV(b[:100]); err == nil {
+		t.Error("readTLV() of truncated value got no error, want error")
+	}
+}
+
+func TestUint64(t *testing.T) {
+	tests := []struct {
+		desc    string
+		v       Variable
+		want    uint64
+		wantErr bool
+	}{{
+		desc: "Counter64 with leading zero",
+		v:    Variable{Type: Counter64, Value: []byte{0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		want: 1<<64 - 1,
+	}, {
+		desc: "TimeTicks",
+		v:    Variable{Type: TimeTicks, Value: []byte{0x01, 0x00}},
+		want: 256,
+	}, {
+		desc: "Integer",
+		v:    Variable{Type: Integer, Value: []byte{0x05}},
+		want: 5,
+	}, {
+		desc:    "negative Integer",
+		v:       Variable{Type: Integer, Value: []byte{0xff}},
+		wantErr: true,
+	}, {
+		desc:    "noSuchInstance",
+		v:       Variable{Type: NoSuchInstance},
+		wantErr: true,
+	}, {
+		desc:    "OctetString",
+		v:       Variable{Type: OctetString, Value: []byte("up")},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.v.Uint64()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Uint64() got error %v, want error %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Uint64() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+// response returns an SNMPv2c Response message to a request with id.
+func response(id int32, status int64, vars []Variable) []byte {
+	var bindings []byte
+	for _, v := range vars {
+		o, _ := encodeOID(v.OID)
+		bindings = append(bindings, tlv(tagSequence, append(tlv(byte(ObjectIdentifier), o), tlv(byte(v.Type), v.Value)...))...)
+	}
+	pdu := tlv(byte(Integer), encodeInt(int64(id)))
+	pdu = append(pdu, tlv(byte(Integer), encodeInt(status))...)
+	pdu = append(pdu, tlv(byte(Integer), encodeInt(0))...)
+	pdu = append(pdu, tlv(tagSequence, bindings)...)
+	msg := tlv(byte(Integer), encodeInt(version2c))
+	msg = append(msg, tlv(byte(OctetString), []byte("public"))...)
+	msg = append(msg, tlv(tagGetResponse, pdu)...)
+	return tlv(tagSequence, msg)
+}
+
+// fakeAgent answers each GetRequest with the variables from reply, after a
+// stale response to an earlier request.
+func fakeAgent(t *testing.T, reply func(oids []string) (int64, []Variable)) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, 65535)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			msg, err := expect(buf[:n], tagSequence)
+			if err != nil {
+				continue
+			}
+			var pdu []byte
+			for _, tag := range []byte{byte(Integer), byte(OctetString), tagGetRequest} {
+				pdu, msg, _ = next(msg, tag)
+			}
+			rawID, pdu, _ := next(pdu, byte(Integer))
+			id, _ := decodeInt(rawID)
+			_, pdu, _ = next(pdu, byte(Integer))
+			_, pdu, _ = next(pdu, byte(Integer))
+			bindings, _, _ := next(pdu, tagSequence)
+			var oids []string
+			for len(bindings) > 0 {
+				var vb []byte
+				vb, bindings, _ = next(bindings, tagSequence)
+				o, _, _ := next(vb, byte(ObjectIdentifier))
+				oid, _ := decodeOID(o)
+				oids = append(oids, oid)
+			}
+			status, vars := reply(oids)
+			conn.WriteTo(response(int32(id)-1, 0, vars), addr)
+			conn.WriteTo(response(int32(id), status, vars), addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestGet(t *testing.T) {
+	addr := fakeAgent(t, func(oids []string) (int64, []Variable) {
+		var vars []Variable
+		for _, oid := range oids {
+			switch oid {
+			case SysUpTime:
+				vars = append(vars, Variable{OID: oid, Type: TimeTicks, Value: encodeInt(123456)})
+			case IfHCInOctets(7):
+				vars = append(vars, Variable{OID: oid, Type: Counter64, Value: []byte{0, 0x80, 0, 0, 0, 0, 0, 0, 1}})
+			default:
+				return 2, nil
+			}
+		}
+		return 0, vars
+	})
+	c := &Client{Addr: addr, Community: "public", Timeout: time.Second}
+
+	vars, err := c.Get(context.Background(), SysUpTime, IfHCInOctets(7))
+	if err != nil {
+		t.Fatalf("Get() got error: %v", err)
+	}
+	var got []uint64
+	for _, v := range vars {
+		u, err := v.Uint64()
+		if err != nil {
+			t.Fatalf("Uint64() of %s got error: %v", v.OID, err)
+		}
+		got = append(got, u)
+	}
+	if diff := cmp.Diff([]uint64{123456, 1<<63 + 1}, got); diff != "" {
+		t.Errorf("Get() -want,+got:\n%s", diff)
+	}
+
+	if _, err := c.Get(context.Background(), "1.3.6.1.2.1.1.5.0"); err == nil {
+		t.Error("Get() of object with error-status got no error, want error")
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	defer conn.Close()
+	c := &Client{Addr: conn.LocalAddr().String(), Community: "public", Timeout: 10 * time.Millisecond, Retries: 2}
+	if _, err := c.Get(context.Background(), SysUpTime); err == nil {
+		t.Error("Get() from agent that does not answer got no error, want error")
+	}
+}
+
+func TestCheckCounter(t *testing.T) {
+	tests := []struct {
+		desc                string
+		before, snmp, after uint64
+		tolerance           uint64
+		wantErr             bool
+	}{
+		{desc: "between", before: 100, snmp: 150, after: 200},
+		{desc: "equal", before: 100, snmp: 100, after: 100},
+		{desc: "below within tolerance", before: 100, snmp: 90, after: 200, tolerance: 10},
+		{desc: "above within tolerance", before: 100, snmp: 210, after: 200, tolerance: 10},
+		{desc: "below", before: 100, snmp: 89, after: 200, tolerance: 10, wantErr: true},
+		{desc: "above", before: 100, snmp: 211, after: 200, tolerance: 10, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := CheckCounter(tc.before, tc.snmp, tc.after, tc.tolerance); (err != nil) != tc.wantErr {
+				t.Errorf("CheckCounter(%d, %d, %d, %d) got error %v, want error %v", tc.before, tc.snmp, tc.after, tc.tolerance, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckUpTime(t *testing.T) {
+	const day = 24 * time.Hour
+	// wrap is the up-time at which sysUpTime wraps around to 0.
+	const wrap = (1 << 32) * tick
+	tests := []struct {
+		desc          string
+		before, after time.Duration
+		ticks         uint64
+		wantErr       bool
+	}{
+		{desc: "between", before: day, after: day + time.Second, ticks: uint64((day + 500*time.Millisecond) / tick)},
+		{desc: "within tolerance", before: day, after: day + time.Second, ticks: uint64((day - 4*time.Second) / tick)},
+		{desc: "agent restarted", before: day, after: day + time.Second, ticks: uint64(time.Hour / tick), wantErr: true},
+		{desc: "after wrap", before: wrap + day, after: wrap + day + time.Second, ticks: uint64(day / tick)},
+		{desc: "across wrap", before: wrap - time.Second, after: wrap + time.Second, ticks: 10},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := CheckUpTime(tc.before, tc.ticks, tc.after, 5*time.Second); (err != nil) != tc.wantErr {
+				t.Errorf("CheckUpTime(%v, %d, %v) got error %v, want error %v", tc.before, tc.ticks, tc.after, err, tc.wantErr)
+			}
+		})
+	}
+}
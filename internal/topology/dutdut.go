@@ -0,0 +1,183 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/testt"
+)
+
+// edgePort is the ID of the DUT port connected to the ATE in a DUTDUT
+// topology with an ATE.
+const edgePort = "port1"
+
+// DUTLink is a port of DUT1 and the port of DUT2 connected to it, and the
+// attributes to configure on them.
+//
+// The link with index i (starting at 0) has the IPv4 subnet
+// 198.51.100.4i/30 and the IPv6 subnet 2001:db8:1::4i/126, with the DUT1
+// port on the first host address and the DUT2 port on the second.
+type DUTLink struct {
+	DUT1      *ondatra.Port
+	DUT2      *ondatra.Port
+	DUT1Attrs attrs.Attributes
+	DUT2Attrs attrs.Attributes
+}
+
+// DUTDUT is a topology of two DUTs "dut1" and "dut2" connected to each
+// other, such as topologies/dutdut.testbed, or
+// topologies/atedutdutate_4.testbed which also connects an ATE port to each
+// DUT.
+//
+// The deviations functions look up the deviations of the DUT they are passed,
+// by its vendor, model and software version, so the DUTs may be of different
+// platforms as long as the test passes each DUT its own.
+type DUTDUT struct {
+	DUT1, DUT2 *ondatra.DUTDevice
+	// ATE is nil if the testbed has no ATE.
+	ATE *ondatra.ATEDevice
+	// Edge1 is ATE port1 and DUT1 port1, and Edge2 ATE port2 and DUT2
+	// port1.  They are addressed like the first two pairs of PortPairs, and
+	// are zero if ATE is nil.
+	Edge1, Edge2 PortPair
+	// Links are the links between DUT1 and DUT2, sorted by port number.
+	Links []DUTLink
+}
+
+// NewDUTDUT returns the DUTDUT topology of the testbed, with up to maxLinks
+// links between the DUTs and at most MaxPortPairs.  It fails the test if
+// there are fewer than minLinks links.
+//
+// The ports of DUT1 and DUT2 with the same ID are assumed to be connected,
+// except port1 when the testbed has an ATE, which connects each DUT to the
+// ATE.
+func NewDUTDUT(t testing.TB, minLinks, maxLinks int) *DUTDUT {
+	t.Helper()
+	d := &DUTDUT{
+		DUT1: ondatra.DUT(t, "dut1"),
+		DUT2: ondatra.DUT(t, "dut2"),
+	}
+	if len(ondatra.ATEs(t)) > 0 {
+		d.ATE = ondatra.ATE(t, "ate")
+		dutAttrs, ateAttrs := pairAttrs(0)
+		d.Edge1 = PortPair{DUT: d.DUT1.Port(t, edgePort), ATE: d.ATE.Port(t, "port1"), DUTAttrs: dutAttrs, ATEAttrs: ateAttrs}
+		dutAttrs, ateAttrs = pairAttrs(1)
+		d.Edge2 = PortPair{DUT: d.DUT2.Port(t, edgePort), ATE: d.ATE.Port(t, "port2"), DUTAttrs: dutAttrs, ATEAttrs: ateAttrs}
+	}
+
+	dut2Ports := map[string]bool{}
+	for _, p := range d.DUT2.Ports() {
+		dut2Ports[p.ID()] = true
+	}
+	var ids []string
+	for _, p := range d.DUT1.Ports() {
+		if dut2Ports[p.ID()] && (d.ATE == nil || p.ID() != edgePort) {
+			ids = append(ids, p.ID())
+		}
+	}
+	sortPortIDs(ids)
+	n := len(ids)
+	if n < minLinks {
+		t.Fatalf("Testbed has %d DUT-DUT links, want at least %d", n, minLinks)
+	}
+	n = min(n, maxLinks, MaxPortPairs)
+	d.Links = make([]DUTLink, n)
+	for i, id := range ids[:n] {
+		dut1Attrs, dut2Attrs := linkAttrs(i)
+		d.Links[i] = DUTLink{DUT1: d.DUT1.Port(t, id), DUT2: d.DUT2.Port(t, id), DUT1Attrs: dut1Attrs, DUT2Attrs: dut2Attrs}
+	}
+	return d
+}
+
+// linkAttrs returns the DUT1 and DUT2 attributes of the link with index i.
+func linkAttrs(i int) (attrs.Attributes, attrs.Attributes) {
+	n := i + 1
+	dut1Attrs := attrs.Attributes{
+		Desc:    fmt.Sprintf("dut1Link%d", n),
+		IPv4:    fmt.Sprintf("198.51.100.%d", 4*i+1),
+		IPv6:    fmt.Sprintf("2001:db8:1::%x", 4*i+1),
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	dut2Attrs := attrs.Attributes{
+		Desc:    fmt.Sprintf("dut2Link%d", n),
+		IPv4:    fmt.Sprintf("198.51.100.%d", 4*i+2),
+		IPv6:    fmt.Sprintf("2001:db8:1::%x", 4*i+2),
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	return dut1Attrs, dut2Attrs
+}
+
+// ForEachDUT calls fn for DUT1 and DUT2 concurrently, e.g. to configure
+// both DUTs at once, and waits for both calls to return.  It fails the test
+// after both calls returned if fn failed the test fatally for either DUT.
+func (d *DUTDUT) ForEachDUT(t testing.TB, fn func(t testing.TB, dut *ondatra.DUTDevice)) {
+	t.Helper()
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errMsg []string
+	)
+	for _, dut := range []*ondatra.DUTDevice{d.DUT1, d.DUT2} {
+		wg.Add(1)
+		go func(dut *ondatra.DUTDevice) {
+			defer wg.Done()
+			if msg := testt.CaptureFatal(t, func(t testing.TB) { fn(t, dut) }); msg != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errMsg = append(errMsg, fmt.Sprintf("%s: %s", dut.ID(), *msg))
+			}
+		}(dut)
+	}
+	wg.Wait()
+	if len(errMsg) > 0 {
+		t.Fatalf("Failed on DUTs:\n%s", strings.Join(errMsg, "\n"))
+	}
+}
+
+// PortAttrs is a DUT port and the attributes to configure on it.
+type PortAttrs struct {
+	Port  *ondatra.Port
+	Attrs attrs.Attributes
+}
+
+// Ports returns the edge port, if any, and the link ports of dut, which is
+// DUT1 or DUT2.
+func (d *DUTDUT) Ports(dut *ondatra.DUTDevice) []PortAttrs {
+	var ports []PortAttrs
+	if dut == d.DUT1 {
+		if d.ATE != nil {
+			ports = append(ports, PortAttrs{d.Edge1.DUT, d.Edge1.DUTAttrs})
+		}
+		for _, l := range d.Links {
+			ports = append(ports, PortAttrs{l.DUT1, l.DUT1Attrs})
+		}
+	} else {
+		if d.ATE != nil {
+			ports = append(ports, PortAttrs{d.Edge2.DUT, d.Edge2.DUTAttrs})
+		}
+		for _, l := range d.Links {
+			ports = append(ports, PortAttrs{l.DUT2, l.DUT2Attrs})
+		}
+	}
+	return ports
+}
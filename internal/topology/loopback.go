@@ -0,0 +1,106 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+var noATE = flag.Bool("no_ate", false, "Run the tests that support it without an ATE, looping traffic back on DUT ports with interface loopback-mode instead.")
+
+// loopbackTimeout is how long a DUT port may take to come up after it is
+// put in loopback mode.
+const loopbackTimeout = 2 * time.Minute
+
+// NoATE reports whether tests should run without an ATE.  Tests that support
+// this mode should reserve no ATE, get their ports from LoopbackPorts, and
+// send traffic from the DUT itself, e.g. with gNOI System.Ping.
+func NoATE() bool {
+	return *noATE
+}
+
+// LoopbackPorts returns the DUT ports of the testbed as port pairs without
+// an ATE port, sorted by port number, up to maxPairs pairs and at most
+// MaxPortPairs.  It fails the test if there are fewer than minPairs ports.
+//
+// The pairs are addressed like those of PortPairs.  The ATEAttrs of a pair
+// are the address of a neighbor that ConfigureLoopback resolves to the DUT
+// port itself, so that traffic routed to the neighbor is received back by
+// the DUT port.
+func LoopbackPorts(t testing.TB, dut *ondatra.DUTDevice, minPairs, maxPairs int) []PortPair {
+	t.Helper()
+	var ids []string
+	for _, dp := range dut.Ports() {
+		ids = append(ids, dp.ID())
+	}
+	sortPortIDs(ids)
+	n := len(ids)
+	if n < minPairs {
+		t.Fatalf("Testbed has %d DUT ports, want at least %d", n, minPairs)
+	}
+	n = min(n, maxPairs, MaxPortPairs)
+	pairs := make([]PortPair, n)
+	for i, id := range ids[:n] {
+		dutAttrs, ateAttrs := pairAttrs(i)
+		pairs[i] = PortPair{DUT: dut.Port(t, id), DUTAttrs: dutAttrs, ATEAttrs: ateAttrs}
+	}
+	return pairs
+}
+
+// ConfigureLoopback configures the DUT port of p with its DUTAttrs and puts
+// it in TERMINAL loopback mode, so that the traffic it sends is received
+// back.  It then adds static IPv4 and IPv6 neighbors for the addresses of
+// the ATEAttrs of p with the MAC address of the DUT port, so that traffic
+// routed to them is received back addressed to the DUT and forwarded again.
+// The loopback mode and the neighbors are removed when the test ends.
+func ConfigureLoopback(t testing.TB, dut *ondatra.DUTDevice, p PortPair) {
+	t.Helper()
+	name := p.DUT.Name()
+	intf := gnmi.OC().Interface(name)
+	gnmi.Replace(t, dut, intf.Config(), p.DUTAttrs.NewOCInterface(name, dut))
+	gnmi.Replace(t, dut, intf.LoopbackMode().Config(), oc.Interfaces_LoopbackModeType_TERMINAL)
+	t.Cleanup(func() {
+		gnmi.Replace(t, dut, intf.LoopbackMode().Config(), oc.Interfaces_LoopbackModeType_NONE)
+	})
+	gnmi.Await(t, dut, intf.OperStatus().State(), loopbackTimeout, oc.Interface_OperStatus_UP)
+
+	mac := gnmi.Get(t, dut, intf.Ethernet().MacAddress().State())
+	sub := intf.Subinterface(0)
+	if p.ATEAttrs.IPv4 != "" {
+		gnmi.Replace(t, dut, sub.Ipv4().Neighbor(p.ATEAttrs.IPv4).Config(), &oc.Interface_Subinterface_Ipv4_Neighbor{
+			Ip:               ygot.String(p.ATEAttrs.IPv4),
+			LinkLayerAddress: ygot.String(mac),
+		})
+		t.Cleanup(func() {
+			gnmi.Delete(t, dut, sub.Ipv4().Neighbor(p.ATEAttrs.IPv4).Config())
+		})
+	}
+	if p.ATEAttrs.IPv6 != "" {
+		gnmi.Replace(t, dut, sub.Ipv6().Neighbor(p.ATEAttrs.IPv6).Config(), &oc.Interface_Subinterface_Ipv6_Neighbor{
+			Ip:               ygot.String(p.ATEAttrs.IPv6),
+			LinkLayerAddress: ygot.String(mac),
+		})
+		t.Cleanup(func() {
+			gnmi.Delete(t, dut, sub.Ipv6().Neighbor(p.ATEAttrs.IPv6).Config())
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package topology lets tests use as many DUT and ATE port pairs as the
+// reserved testbed has, instead of a fixed set of ports.
+//
+// A test that declares a 2 port testbed can be run with a larger testbed,
+// e.g. with -testbed=topologies/atedut_12.testbed, and PortPairs returns
+// all of its port pairs.
+//
+// Tests that support it can also run on a testbed without an ATE when
+// -no_ate is set, looping their traffic back on DUT ports from
+// LoopbackPorts.
+//
+// Tests between two DUTs get the DUTs, their links and the ATE ports
+// connected to them from NewDUTDUT.
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/ondatra"
+)
+
+// MaxPortPairs is the maximum number of port pairs PortPairs returns.
+const MaxPortPairs = 16
+
+// PortPair is a DUT port and the ATE port connected to it, and the
+// attributes to configure on them.
+//
+// The pair with index i (starting at 0) has the IPv4 subnet 192.0.2.4i/30
+// and the IPv6 subnet 2001:db8::4i/126, with the DUT port on the first host
+// address and the ATE port on the second.  This matches the addressing of the
+// port1 and port2 attributes used by many tests.
+type PortPair struct {
+	DUT      *ondatra.Port
+	ATE      *ondatra.Port
+	DUTAttrs attrs.Attributes
+	ATEAttrs attrs.Attributes
+}
+
+// PortPairs returns the DUT and ATE ports of the testbed with the same
+// ID, sorted by port number, up to maxPairs pairs and at most MaxPortPairs.
+// It fails the test if there are fewer than minPairs pairs.
+func PortPairs(t testing.TB, dut *ondatra.DUTDevice, ate *ondatra.ATEDevice, minPairs, maxPairs int) []PortPair {
+	t.Helper()
+	atePorts := map[string]bool{}
+	for _, ap := range ate.Ports() {
+		atePorts[ap.ID()] = true
+	}
+	var ids []string
+	for _, dp := range dut.Ports() {
+		if atePorts[dp.ID()] {
+			ids = append(ids, dp.ID())
+		}
+	}
+	sortPortIDs(ids)
+	n := len(ids)
+	if n < minPairs {
+		t.Fatalf("Testbed has %d DUT-ATE port pairs, want at least %d", n, minPairs)
+	}
+	n = min(n, maxPairs, MaxPortPairs)
+	pairs := make([]PortPair, n)
+	for i, id := range ids[:n] {
+		dutAttrs, ateAttrs := pairAttrs(i)
+		pairs[i] = PortPair{DUT: dut.Port(t, id), ATE: ate.Port(t, id), DUTAttrs: dutAttrs, ATEAttrs: ateAttrs}
+	}
+	return pairs
+}
+
+// pairAttrs returns the DUT and ATE attributes of the port pair with index
+// i.
+func pairAttrs(i int) (attrs.Attributes, attrs.Attributes) {
+	n := i + 1
+	dutAttrs := attrs.Attributes{
+		Desc:    fmt.Sprintf("dutPort%d", n),
+		IPv4:    fmt.Sprintf("192.0.2.%d", 4*i+1),
+		IPv6:    fmt.Sprintf("2001:db8::%x", 4*i+1),
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	ateAttrs := attrs.Attributes{
+		Name:    fmt.Sprintf("atePort%d", n),
+		MAC:     fmt.Sprintf("02:00:%02x:01:01:01", n),
+		IPv4:    fmt.Sprintf("192.0.2.%d", 4*i+2),
+		IPv6:    fmt.Sprintf("2001:db8::%x", 4*i+2),
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	return dutAttrs, ateAttrs
+}
+
+// sortPortIDs sorts port IDs such as "port2" and "port10" by the number at
+// the end of the ID, and IDs without a number after those with one.
+func sortPortIDs(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		ni, oki := portNumber(ids[i])
+		nj, okj := portNumber(ids[j])
+		switch {
+		case oki && okj && ni != nj:
+			return ni < nj
+		case oki != okj:
+			return oki
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// portNumber returns the number at the end of a port ID.
+func portNumber(id string) (int, bool) {
+	digits := strings.TrimLeftFunc(id, func(r rune) bool { return r < '0' || r > '9' })
+	if digits == "" || !strings.HasSuffix(id, digits) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortPortIDs(t *testing.T) {
+	ids := []string{"port10", "port2", "mgmt", "port1", "port16", "port9"}
+	sortPortIDs(ids)
+	want := []string{"port1", "port2", "port9", "port10", "port16", "mgmt"}
+	if diff := cmp.Diff(want, ids); diff != "" {
+		t.Errorf("sortPortIDs() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPairAttrs(t *testing.T) {
+	tests := []struct {
+		i                           int
+		dutV4, ateV4, dutV6, ateMAC string
+	}{
+		{i: 0, dutV4: "192.0.2.1", ateV4: "192.0.2.2", dutV6: "2001:db8::1", ateMAC: "02:00:01:01:01:01"},
+		{i: 1, dutV4: "192.0.2.5", ateV4: "192.0.2.6", dutV6: "2001:db8::5", ateMAC: "02:00:02:01:01:01"},
+		{i: 15, dutV4: "192.0.2.61", ateV4: "192.0.2.62", dutV6: "2001:db8::3d", ateMAC: "02:00:10:01:01:01"},
+	}
+	for _, tc := range tests {
+		dutAttrs, ateAttrs := pairAttrs(tc.i)
+		if dutAttrs.IPv4 != tc.dutV4 || ateAttrs.IPv4 != tc.ateV4 || dutAttrs.IPv6 != tc.dutV6 || ateAttrs.MAC != tc.ateMAC {
+			t.Errorf("pairAttrs(%d) got DUT %s %s, ATE %s %s, want DUT %s %s, ATE %s %s", tc.i,
+				dutAttrs.IPv4, dutAttrs.IPv6, ateAttrs.IPv4, ateAttrs.MAC, tc.dutV4, tc.dutV6, tc.ateV4, tc.ateMAC)
+		}
+	}
+}
+
+func TestLinkAttrs(t *testing.T) {
+	tests := []struct {
+		i                      int
+		dut1V4, dut2V4, dut2V6 string
+	}{
+		{i: 0, dut1V4: "198.51.100.1", dut2V4: "198.51.100.2", dut2V6: "2001:db8:1::2"},
+		{i: 15, dut1V4: "198.51.100.61", dut2V4: "198.51.100.62", dut2V6: "2001:db8:1::3e"},
+	}
+	for _, tc := range tests {
+		dut1Attrs, dut2Attrs := linkAttrs(tc.i)
+		if dut1Attrs.IPv4 != tc.dut1V4 || dut2Attrs.IPv4 != tc.dut2V4 || dut2Attrs.IPv6 != tc.dut2V6 {
+			t.Errorf("linkAttrs(%d) got DUT1 %s, DUT2 %s %s, want DUT1 %s, DUT2 %s %s", tc.i,
+				dut1Attrs.IPv4, dut2Attrs.IPv4, dut2Attrs.IPv6, tc.dut1V4, tc.dut2V4, tc.dut2V6)
+		}
+	}
+}
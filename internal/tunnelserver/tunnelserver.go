@@ -0,0 +1,196 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tunnelserver provides an in-process gRPC tunnel server (collector)
+// that a DUT dials out to.  Once the DUT registers its targets, gNMI and gNOI
+// clients can be created over the tunnel using Dial.
+package tunnelserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/grpctunnel/tunnel"
+	"google.golang.org/grpc"
+
+	tpb "github.com/openconfig/grpctunnel/proto/tunnel"
+)
+
+// TargetTypeGNMIGNOI is the target type registered by DUTs that expose gNMI
+// and gNOI over the tunnel.
+var TargetTypeGNMIGNOI = tpb.TargetType_GNMI_GNOI.String()
+
+// Server is a gRPC tunnel server listening for DUT tunnel registrations.
+type Server struct {
+	ts   *tunnel.Server
+	gs   *grpc.Server
+	lis  net.Listener
+	done chan struct{} // Closed by Stop.
+
+	mu      sync.Mutex
+	targets map[tunnel.Target]int // Number of times each target has been registered.
+	active  map[tunnel.Target]bool
+	changed chan struct{} // Closed and replaced whenever targets change.
+}
+
+// New starts a tunnel server listening on addr, which is a host:port.  Use
+// port 0 to pick an available port; the chosen address is returned by Addr.
+func New(addr string, opts ...grpc.ServerOption) (*Server, error) {
+	s := &Server{
+		targets: make(map[tunnel.Target]int),
+		active:  make(map[tunnel.Target]bool),
+		changed: make(chan struct{}),
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on %q: %w", addr, err)
+	}
+	ts, err := tunnel.NewServer(tunnel.ServerConfig{
+		AddTargetHandler:    s.addTarget,
+		DeleteTargetHandler: s.deleteTarget,
+	})
+	if err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("could not create tunnel server: %w", err)
+	}
+	s.ts = ts
+	s.lis = lis
+	s.gs = grpc.NewServer(opts...)
+	s.done = make(chan struct{})
+	tpb.RegisterTunnelServer(s.gs, ts)
+	go func() {
+		if err := s.gs.Serve(lis); err != nil {
+			log.Errorf("Tunnel server on %v stopped: %v", lis.Addr(), err)
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case err := <-ts.ErrorChan():
+				log.Warningf("Tunnel server error: %v", err)
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+func (s *Server) addTarget(t tunnel.Target) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	log.Infof("Tunnel target registered: %s (%s)", t.ID, t.Type)
+	s.targets[t]++
+	s.active[t] = true
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return nil
+}
+
+func (s *Server) deleteTarget(t tunnel.Target) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	log.Infof("Tunnel target removed: %s (%s)", t.ID, t.Type)
+	delete(s.active, t)
+	close(s.changed)
+	s.changed = make(chan struct{})
+	return nil
+}
+
+// Addr returns the address the tunnel server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.lis.Addr()
+}
+
+// Stop stops the tunnel server and closes all tunnel sessions.
+func (s *Server) Stop() {
+	s.gs.Stop()
+	close(s.done)
+}
+
+// Targets returns the currently registered targets sorted by ID and type.
+func (s *Server) Targets() []tunnel.Target {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var targets []tunnel.Target
+	for t := range s.active {
+		targets = append(targets, t)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].ID != targets[j].ID {
+			return targets[i].ID < targets[j].ID
+		}
+		return targets[i].Type < targets[j].Type
+	})
+	return targets
+}
+
+// Registrations returns the number of times the target has been registered
+// since the server started.  A target that reconnects after losing its tunnel
+// is counted again.
+func (s *Server) Registrations(t tunnel.Target) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.targets[t]
+}
+
+// await blocks until cond returns true or ctx is done.
+func (s *Server) await(ctx context.Context, cond func() bool) error {
+	for {
+		s.mu.Lock()
+		ok := cond()
+		changed := s.changed
+		s.mu.Unlock()
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// AwaitTarget blocks until the target is registered or ctx is done.
+func (s *Server) AwaitTarget(ctx context.Context, t tunnel.Target) error {
+	if err := s.await(ctx, func() bool { return s.active[t] }); err != nil {
+		return fmt.Errorf("target %s (%s) not registered: %w", t.ID, t.Type, err)
+	}
+	return nil
+}
+
+// AwaitRegistrations blocks until the target has been registered at least n
+// times or ctx is done.
+func (s *Server) AwaitRegistrations(ctx context.Context, t tunnel.Target, n int) error {
+	if err := s.await(ctx, func() bool { return s.active[t] && s.targets[t] >= n }); err != nil {
+		return fmt.Errorf("target %s (%s) not registered %d times: %w", t.ID, t.Type, n, err)
+	}
+	return nil
+}
+
+// Dial creates a gRPC client connection to the target carried over the
+// tunnel.  The caller is responsible for passing transport credentials
+// suitable for the target in opts.
+func (s *Server) Dial(ctx context.Context, t tunnel.Target, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return tunnel.ServerConn(ctx, s.ts, &t)
+	}
+	opts = append(opts, grpc.WithContextDialer(dialer))
+	return grpc.DialContext(ctx, t.ID, opts...)
+}
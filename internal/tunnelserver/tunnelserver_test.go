@@ -0,0 +1,89 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tunnelserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/grpctunnel/tunnel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+
+	hpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestDialOverTunnel(t *testing.T) {
+	s, err := New("localhost:0")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	target := tunnel.Target{ID: "dut", Type: TargetTypeGNMIGNOI}
+	lis, err := tunnel.Listen(ctx, s.Addr().String(), "", map[tunnel.Target]struct{}{target: {}})
+	if err != nil {
+		t.Fatalf("tunnel.Listen() failed: %v", err)
+	}
+	defer lis.Close()
+
+	gs := grpc.NewServer()
+	hpb.RegisterHealthServer(gs, health.NewServer())
+	go gs.Serve(lis)
+	defer gs.Stop()
+
+	if err := s.AwaitTarget(ctx, target); err != nil {
+		t.Fatalf("AwaitTarget() failed: %v", err)
+	}
+	if diff := cmp.Diff([]tunnel.Target{target}, s.Targets()); diff != "" {
+		t.Errorf("Targets() returned diff (-want +got):\n%s", diff)
+	}
+	if got, want := s.Registrations(target), 1; got != want {
+		t.Errorf("Registrations() got %d, want %d", got, want)
+	}
+
+	conn, err := s.Dial(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() failed: %v", err)
+	}
+	defer conn.Close()
+	resp, err := hpb.NewHealthClient(conn).Check(ctx, &hpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() over tunnel failed: %v", err)
+	}
+	if got, want := resp.GetStatus(), hpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("Check() status got %v, want %v", got, want)
+	}
+}
+
+func TestAwaitTargetTimeout(t *testing.T) {
+	s, err := New("localhost:0")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.AwaitTarget(ctx, tunnel.Target{ID: "missing", Type: TargetTypeGNMIGNOI}); err == nil {
+		t.Errorf("AwaitTarget() for unregistered target got nil error, want error")
+	}
+}
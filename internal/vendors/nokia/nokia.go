@@ -0,0 +1,44 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nokia registers the Nokia vendor module with internal/vendors.
+package nokia
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openconfig/featureprofiles/internal/vendors"
+	"github.com/openconfig/ondatra"
+)
+
+func init() {
+	vendors.RegisterSlotMapper(ondatra.NOKIA, SlotMapper{})
+}
+
+// portNameRE matches SR Linux port names, ethernet-<slot>/<port>, optionally
+// followed by a breakout index.
+var portNameRE = regexp.MustCompile(`^ethernet-([0-9]+)/([0-9]+)(/[0-9]+)?$`)
+
+// SlotMapper maps SR Linux port names to their linecard slot.
+type SlotMapper struct{}
+
+// SlotOfPort returns the slot number in the port name.
+func (SlotMapper) SlotOfPort(port string) (string, error) {
+	m := portNameRE.FindStringSubmatch(port)
+	if m == nil {
+		return "", fmt.Errorf("port name %q does not match %v", port, portNameRE)
+	}
+	return m[1], nil
+}
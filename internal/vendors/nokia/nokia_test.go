@@ -0,0 +1,57 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nokia
+
+import (
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/vendors"
+	"github.com/openconfig/ondatra"
+)
+
+func TestSlotOfPort(t *testing.T) {
+	tests := []struct {
+		port    string
+		want    string
+		wantErr bool
+	}{
+		{port: "ethernet-1/1", want: "1"},
+		{port: "ethernet-2/36", want: "2"},
+		{port: "ethernet-3/1/4", want: "3"},
+		{port: "mgmt0", wantErr: true},
+		{port: "ethernet-1", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.port, func(t *testing.T) {
+			got, err := SlotMapper{}.SlotOfPort(tc.port)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("SlotOfPort(%q) got error %v, want error %v", tc.port, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("SlotOfPort(%q) got %q, want %q", tc.port, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	m, err := vendors.SlotMapperFor(ondatra.NOKIA)
+	if err != nil {
+		t.Fatalf("SlotMapperFor(NOKIA) got error: %v", err)
+	}
+	if _, ok := m.(SlotMapper); !ok {
+		t.Errorf("SlotMapperFor(NOKIA) got %T, want %T", m, SlotMapper{})
+	}
+}
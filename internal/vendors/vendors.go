@@ -0,0 +1,110 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vendors is a registry of vendor specific helpers that are not
+// covered by OpenConfig, so that tests look them up by DUT vendor instead of
+// switching on the vendor themselves.
+//
+// Vendor modules live in subpackages, e.g. internal/vendors/nokia, and
+// register themselves from init.  A test imports the modules it supports for
+// their side effect:
+//
+//	import _ "github.com/openconfig/featureprofiles/internal/vendors/nokia"
+package vendors
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+)
+
+// SlotMapper maps DUT ports to the linecard slot hosting them.
+type SlotMapper interface {
+	// SlotOfPort returns the slot hosting the port named port.
+	SlotOfPort(port string) (string, error)
+}
+
+// DropInspector reads packet drop counters that are not modelled in
+// OpenConfig, such as per-reason trap or discard counters.
+type DropInspector interface {
+	// Drops returns the drop counters of the DUT keyed by reason.
+	Drops(t testing.TB, dut *ondatra.DUTDevice) (map[string]uint64, error)
+}
+
+var (
+	mu             sync.Mutex
+	slotMappers    = make(map[ondatra.Vendor]SlotMapper)
+	dropInspectors = make(map[ondatra.Vendor]DropInspector)
+)
+
+// RegisterSlotMapper registers m as the SlotMapper of vendor v.  It panics if
+// one is already registered.
+func RegisterSlotMapper(v ondatra.Vendor, m SlotMapper) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := slotMappers[v]; ok {
+		panic(fmt.Sprintf("vendors: SlotMapper already registered for %v", v))
+	}
+	slotMappers[v] = m
+}
+
+// RegisterDropInspector registers d as the DropInspector of vendor v.  It
+// panics if one is already registered.
+func RegisterDropInspector(v ondatra.Vendor, d DropInspector) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := dropInspectors[v]; ok {
+		panic(fmt.Sprintf("vendors: DropInspector already registered for %v", v))
+	}
+	dropInspectors[v] = d
+}
+
+// SlotMapperFor returns the SlotMapper registered for vendor v.
+func SlotMapperFor(v ondatra.Vendor) (SlotMapper, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	m, ok := slotMappers[v]
+	if !ok {
+		return nil, fmt.Errorf("no SlotMapper registered for vendor %v", v)
+	}
+	return m, nil
+}
+
+// DropInspectorFor returns the DropInspector registered for vendor v.
+func DropInspectorFor(v ondatra.Vendor) (DropInspector, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	d, ok := dropInspectors[v]
+	if !ok {
+		return nil, fmt.Errorf("no DropInspector registered for vendor %v", v)
+	}
+	return d, nil
+}
+
+// SlotOfPort returns the slot hosting DUT port p using the SlotMapper of the
+// DUT vendor.  It skips the test if the vendor has none.
+func SlotOfPort(t testing.TB, dut *ondatra.DUTDevice, p *ondatra.Port) string {
+	t.Helper()
+	m, err := SlotMapperFor(dut.Vendor())
+	if err != nil {
+		t.Skip(err)
+	}
+	slot, err := m.SlotOfPort(p.Name())
+	if err != nil {
+		t.Fatalf("Cannot map %s to a slot: %v", p.Name(), err)
+	}
+	return slot
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vendors
+
+import (
+	"testing"
+
+	"github.com/openconfig/ondatra"
+)
+
+type fakeSlotMapper struct{}
+
+func (fakeSlotMapper) SlotOfPort(port string) (string, error) { return port, nil }
+
+func TestSlotMapperRegistry(t *testing.T) {
+	if _, err := SlotMapperFor(ondatra.CIENA); err == nil {
+		t.Fatalf("SlotMapperFor(CIENA) before registration got no error, want error")
+	}
+	RegisterSlotMapper(ondatra.CIENA, fakeSlotMapper{})
+	m, err := SlotMapperFor(ondatra.CIENA)
+	if err != nil {
+		t.Fatalf("SlotMapperFor(CIENA) got error: %v", err)
+	}
+	if got, _ := m.SlotOfPort("1/1"); got != "1/1" {
+		t.Errorf("SlotOfPort(1/1) got %q, want %q", got, "1/1")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterSlotMapper(CIENA) twice did not panic")
+		}
+	}()
+	RegisterSlotMapper(ondatra.CIENA, fakeSlotMapper{})
+}
+
+func TestDropInspectorFor(t *testing.T) {
+	if _, err := DropInspectorFor(ondatra.CIENA); err == nil {
+		t.Errorf("DropInspectorFor(CIENA) without registration got no error, want error")
+	}
+}
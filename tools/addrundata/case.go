@@ -122,6 +122,7 @@ func (tc *testcase) fix() error {
 		tc.fixed.Testbed = tc.existing.Testbed
 		tc.fixed.PlatformExceptions = tc.existing.PlatformExceptions
 		tc.fixed.Tags = tc.existing.Tags
+		tc.fixed.ExpectedFailures = tc.existing.ExpectedFailures
 		u, err := uuid.Parse(tc.existing.Uuid)
 		if err == nil && u.Variant() == uuid.RFC4122 && u.Version() == 4 {
 			// Existing UUID is valid, but make sure it is normalized.
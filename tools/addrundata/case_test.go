@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	mpb "github.com/openconfig/featureprofiles/proto/metadata_go_proto"
+	opb "github.com/openconfig/ondatra/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
@@ -228,6 +229,13 @@ func TestCase_FixWithPlatformExceptions(t *testing.T) {
 				},
 			},
 			Tags: []mpb.Metadata_Tags{mpb.Metadata_TAGS_AGGREGATION},
+			ExpectedFailures: []*mpb.Metadata_ExpectedFailure{
+				{
+					Platform:      &mpb.Metadata_Platform{Vendor: opb.Device_JUNIPER},
+					TestNameRegex: "/Subtest$",
+					Reason:        "not supported",
+				},
+			},
 		},
 	}
 	if err := tc.fix(); err != nil {
@@ -250,6 +258,13 @@ func TestCase_FixWithPlatformExceptions(t *testing.T) {
 			},
 		},
 		Tags: []mpb.Metadata_Tags{mpb.Metadata_TAGS_AGGREGATION},
+		ExpectedFailures: []*mpb.Metadata_ExpectedFailure{
+			{
+				Platform:      &mpb.Metadata_Platform{Vendor: opb.Device_JUNIPER},
+				TestNameRegex: "/Subtest$",
+				Reason:        "not supported",
+			},
+		},
 	}
 	if diff := cmp.Diff(want, got, tcopts...); diff != "" {
 		t.Errorf("fixed -want,+got:\n%s", diff)
@@ -0,0 +1,240 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// listKeys are the leaves that identify an element of a list in JSON_IETF
+// encoded config, in the order they appear in the element's path.  Elements
+// with none of these leaves are identified by their position after sorting.
+var listKeys = []string{
+	"name",
+	"identifier",
+	"index",
+	"id",
+	"sequence-id",
+	"prefix",
+	"address",
+	"ip",
+	"neighbor-address",
+	"peer-group-name",
+	"type",
+}
+
+// goldenPath returns the path of the golden config of vendor and model under
+// dir.
+func goldenPath(dir, vendor, model string) string {
+	clean := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+				return r
+			case r >= 'A' && r <= 'Z':
+				return r - 'A' + 'a'
+			}
+			return '_'
+		}, s)
+	}
+	return filepath.Join(dir, clean(vendor), clean(model)+".json")
+}
+
+// writeGolden writes the JSON_IETF encoded config to path, indented so that
+// changes to the golden config review well.
+func writeGolden(path string, config []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, config, "", "  "); err != nil {
+		return fmt.Errorf("config is not valid JSON: %w", err)
+	}
+	buf.WriteByte('\n')
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// flatten returns the leaves of the JSON_IETF encoded config, keyed by their
+// path with module prefixes removed and list elements identified by their
+// keys, and with their values JSON encoded.
+func flatten(config []byte) (map[string]string, error) {
+	var v any
+	d := json.NewDecoder(bytes.NewReader(config))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("config is not valid JSON: %w", err)
+	}
+	leaves := make(map[string]string)
+	if err := flattenValue("", v, leaves); err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+func flattenValue(path string, v any, leaves map[string]string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, c := range v {
+			if i := strings.LastIndex(k, ":"); i >= 0 {
+				k = k[i+1:]
+			}
+			if err := flattenValue(path+"/"+k, c, leaves); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if !isList(v) {
+			// A leaf-list is compared as a whole, ignoring order.
+			return flattenLeafList(path, v, leaves)
+		}
+		elems := make(map[string]any)
+		var unkeyed []string
+		for _, e := range v {
+			if key := elemKey(e.(map[string]any)); key != "" {
+				elems[path+key] = e
+				continue
+			}
+			b, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			unkeyed = append(unkeyed, string(b))
+		}
+		sort.Strings(unkeyed)
+		for i, b := range unkeyed {
+			var e any
+			if err := json.Unmarshal([]byte(b), &e); err != nil {
+				return err
+			}
+			elems[fmt.Sprintf("%s[%d]", path, i)] = e
+		}
+		for p, e := range elems {
+			if err := flattenValue(p, e, leaves); err != nil {
+				return err
+			}
+		}
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		leaves[path] = string(b)
+	}
+	return nil
+}
+
+func flattenLeafList(path string, v []any, leaves map[string]string) error {
+	var vals []string
+	for _, e := range v {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		vals = append(vals, string(b))
+	}
+	sort.Strings(vals)
+	leaves[path] = "[" + strings.Join(vals, ",") + "]"
+	return nil
+}
+
+// isList reports whether v is a list, rather than a leaf-list.
+func isList(v []any) bool {
+	for _, e := range v {
+		if _, ok := e.(map[string]any); !ok {
+			return false
+		}
+	}
+	return len(v) > 0
+}
+
+// elemKey returns the key of list element e in gNMI path syntax, such as
+// "[name=Ethernet1]", or "" if e has no known key leaves.
+func elemKey(e map[string]any) string {
+	var b strings.Builder
+	for _, k := range listKeys {
+		for ek, v := range e {
+			if i := strings.LastIndex(ek, ":"); i >= 0 {
+				ek = ek[i+1:]
+			}
+			if ek != k {
+				continue
+			}
+			switch v.(type) {
+			case map[string]any, []any:
+				continue
+			}
+			fmt.Fprintf(&b, "[%s=%v]", k, v)
+		}
+	}
+	return b.String()
+}
+
+// drift is a difference between the golden and the current config of a leaf.
+// golden or current is empty if the leaf is only present in the other.
+type drift struct {
+	path    string
+	golden  string
+	current string
+}
+
+func (d drift) String() string {
+	switch {
+	case d.golden == "":
+		return fmt.Sprintf("+ %s: %s", d.path, d.current)
+	case d.current == "":
+		return fmt.Sprintf("- %s: %s", d.path, d.golden)
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", d.path, d.golden, d.current)
+}
+
+// diff returns the leaves whose value differs between golden and current,
+// sorted by path, ignoring those under any of the ignore path prefixes.
+func diff(golden, current map[string]string, ignore []string) []drift {
+	paths := make(map[string]bool)
+	for p := range golden {
+		paths[p] = true
+	}
+	for p := range current {
+		paths[p] = true
+	}
+	var drifts []drift
+	for p := range paths {
+		if ignored(p, ignore) {
+			continue
+		}
+		if g, c := golden[p], current[p]; g != c {
+			drifts = append(drifts, drift{path: p, golden: g, current: c})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].path < drifts[j].path })
+	return drifts
+}
+
+// ignored reports whether path is one of prefixes or under one of them.
+func ignored(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		p = strings.TrimSuffix(p, "/")
+		if path == p || strings.HasPrefix(path, p+"/") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
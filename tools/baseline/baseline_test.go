@@ -0,0 +1,182 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const goldenConfig = `{
+  "openconfig-interfaces:interfaces": {
+    "interface": [
+      {
+        "name": "Ethernet1",
+        "config": {"name": "Ethernet1", "mtu": 9000, "enabled": true}
+      },
+      {
+        "name": "Ethernet2",
+        "config": {"name": "Ethernet2", "enabled": true}
+      }
+    ]
+  },
+  "openconfig-system:system": {
+    "config": {"hostname": "dut"},
+    "dns": {"config": {"search": ["example.com", "example.net"]}}
+  }
+}`
+
+func TestGoldenPath(t *testing.T) {
+	if got, want := goldenPath("golden", "ARISTA", "7280R3 Series"), filepath.Join("golden", "arista", "7280r3_series.json"); got != want {
+		t.Errorf("goldenPath() got %q, want %q", got, want)
+	}
+}
+
+func TestWriteGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "arista", "ceos.json")
+	if err := writeGolden(path, []byte(goldenConfig)); err != nil {
+		t.Fatalf("writeGolden() got error %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Cannot read golden config: %v", err)
+	}
+	got, err := flatten(b)
+	if err != nil {
+		t.Fatalf("flatten() of written golden config got error %v", err)
+	}
+	want, err := flatten([]byte(goldenConfig))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Written golden config -want,+got:\n%s", diff)
+	}
+	if err := writeGolden(path, []byte("{")); err == nil {
+		t.Errorf("writeGolden() of invalid JSON got no error")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	got, err := flatten([]byte(goldenConfig))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+	want := map[string]string{
+		"/interfaces/interface[name=Ethernet1]/name":           `"Ethernet1"`,
+		"/interfaces/interface[name=Ethernet1]/config/name":    `"Ethernet1"`,
+		"/interfaces/interface[name=Ethernet1]/config/mtu":     `9000`,
+		"/interfaces/interface[name=Ethernet1]/config/enabled": `true`,
+		"/interfaces/interface[name=Ethernet2]/name":           `"Ethernet2"`,
+		"/interfaces/interface[name=Ethernet2]/config/name":    `"Ethernet2"`,
+		"/interfaces/interface[name=Ethernet2]/config/enabled": `true`,
+		"/system/config/hostname":                              `"dut"`,
+		"/system/dns/config/search":                            `["example.com","example.net"]`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("flatten() -want,+got:\n%s", diff)
+	}
+	if _, err := flatten([]byte("not json")); err == nil {
+		t.Errorf("flatten() of invalid JSON got no error")
+	}
+}
+
+func TestFlattenOrderInsensitive(t *testing.T) {
+	a, err := flatten([]byte(`{"x": {"entry": [{"seq": 1}, {"seq": 2}], "list": [{"name": "a"}, {"name": "b"}], "leaves": [1, 2]}}`))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+	b, err := flatten([]byte(`{"x": {"entry": [{"seq": 2}, {"seq": 1}], "list": [{"name": "b"}, {"name": "a"}], "leaves": [2, 1]}}`))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+	if diff := cmp.Diff(a, b); diff != "" {
+		t.Errorf("flatten() of reordered lists -first,+second:\n%s", diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	golden, err := flatten([]byte(goldenConfig))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+	current, err := flatten([]byte(`{
+  "openconfig-interfaces:interfaces": {
+    "interface": [
+      {
+        "name": "Ethernet1",
+        "config": {"name": "Ethernet1", "mtu": 1500, "enabled": true}
+      }
+    ]
+  },
+  "openconfig-system:system": {
+    "config": {"hostname": "dut", "domain-name": "lab"},
+    "dns": {"config": {"search": ["example.net", "example.com"]}}
+  }
+}`))
+	if err != nil {
+		t.Fatalf("flatten() got error %v", err)
+	}
+
+	tests := []struct {
+		desc   string
+		ignore []string
+		want   []drift
+	}{{
+		desc: "all drift",
+		want: []drift{
+			{path: "/interfaces/interface[name=Ethernet1]/config/mtu", golden: "9000", current: "1500"},
+			{path: "/interfaces/interface[name=Ethernet2]/config/enabled", golden: "true"},
+			{path: "/interfaces/interface[name=Ethernet2]/config/name", golden: `"Ethernet2"`},
+			{path: "/interfaces/interface[name=Ethernet2]/name", golden: `"Ethernet2"`},
+			{path: "/system/config/domain-name", current: `"lab"`},
+		},
+	}, {
+		desc:   "ignored",
+		ignore: []string{"/interfaces/interface[name=Ethernet2]", "/system/config/"},
+		want: []drift{
+			{path: "/interfaces/interface[name=Ethernet1]/config/mtu", golden: "9000", current: "1500"},
+		},
+	}, {
+		desc:   "ignore all",
+		ignore: []string{"/interfaces", "/system"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := diff(golden, current, tc.ignore)
+			if d := cmp.Diff(tc.want, got, cmp.AllowUnexported(drift{})); d != "" {
+				t.Errorf("diff() -want,+got:\n%s", d)
+			}
+		})
+	}
+}
+
+func TestDriftString(t *testing.T) {
+	for _, tc := range []struct {
+		d    drift
+		want string
+	}{
+		{drift{path: "/a", current: "1"}, "+ /a: 1"},
+		{drift{path: "/a", golden: "1"}, "- /a: 1"},
+		{drift{path: "/a", golden: "1", current: "2"}, "~ /a: 1 -> 2"},
+	} {
+		if got := tc.d.String(); got != tc.want {
+			t.Errorf("drift.String() got %q, want %q", got, tc.want)
+		}
+	}
+}
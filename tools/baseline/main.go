@@ -0,0 +1,204 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command baseline captures the full config of a DUT as the golden config of
+// its platform, and checks the config of a DUT against the golden config of
+// its platform, so that config left behind on a lab DUT by earlier runs can
+// be caught before a test suite runs.
+//
+// Usage:
+//
+//	baseline -mode=capture -target=dut:9339 -vendor=ARISTA -model=7280R3 -dir=golden
+//	baseline -mode=check -target=dut:9339 -vendor=ARISTA -model=7280R3 -dir=golden
+//
+// In check mode, baseline prints every leaf that was added, removed or
+// changed relative to the golden config, and exits with status 1 if there
+// are any.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// Config is the set of flags for this binary.
+type Config struct {
+	Mode       string
+	Target     string
+	Username   string
+	Password   string
+	Insecure   bool
+	SkipVerify bool
+	Vendor     string
+	Model      string
+	Dir        string
+	Ignore     stringList
+	Timeout    time.Duration
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// New registers a flagset with the configuration needed by this binary.
+func New(fs *flag.FlagSet) *Config {
+	c := &Config{}
+
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.StringVar(&c.Mode, "mode", "check", "capture to write the golden config, or check to diff the current config against it")
+	fs.StringVar(&c.Target, "target", "", "gNMI target of the DUT, as host:port")
+	fs.StringVar(&c.Username, "username", "", "gNMI username")
+	fs.StringVar(&c.Password, "password", "", "gNMI password")
+	fs.BoolVar(&c.Insecure, "insecure", false, "dial without TLS")
+	fs.BoolVar(&c.SkipVerify, "skip_verify", false, "dial with TLS without verifying the DUT certificate")
+	fs.StringVar(&c.Vendor, "vendor", "", "vendor of the DUT, selecting its golden config")
+	fs.StringVar(&c.Model, "model", "", "model of the DUT, selecting its golden config")
+	fs.StringVar(&c.Dir, "dir", "baseline", "directory of the golden configs, stored as <vendor>/<model>.json")
+	fs.Var(&c.Ignore, "ignore", "path prefix of config to exclude from the check, such as /system/aaa (can be specified multiple times)")
+	fs.DurationVar(&c.Timeout, "timeout", time.Minute, "timeout to dial the DUT and get its config")
+
+	return c
+}
+
+var (
+	config *Config
+)
+
+func init() {
+	config = New(nil)
+}
+
+// creds implements the grpc.PerRPCCredentials interface.
+type creds struct {
+	username, password string
+	secure             bool
+}
+
+func (c *creds) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{
+		"username": c.username,
+		"password": c.password,
+	}, nil
+}
+
+func (c *creds) RequireTransportSecurity() bool {
+	return c.secure
+}
+
+// getConfig returns the full JSON_IETF encoded config of the DUT.
+func getConfig(ctx context.Context, c *Config) ([]byte, error) {
+	var opts []grpc.DialOption
+	switch {
+	case c.Insecure:
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	case c.SkipVerify:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	default:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+	if c.Username != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&creds{c.Username, c.Password, !c.Insecure}))
+	}
+	conn, err := grpc.DialContext(ctx, c.Target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot dial %s: %w", c.Target, err)
+	}
+	defer conn.Close()
+
+	resp, err := gpb.NewGNMIClient(conn).Get(ctx, &gpb.GetRequest{
+		Path:     []*gpb.Path{{Origin: "openconfig"}},
+		Type:     gpb.GetRequest_CONFIG,
+		Encoding: gpb.Encoding_JSON_IETF,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get config from %s: %w", c.Target, err)
+	}
+	for _, n := range resp.GetNotification() {
+		for _, u := range n.GetUpdate() {
+			if b := u.GetVal().GetJsonIetfVal(); b != nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no JSON_IETF config in Get response from %s", c.Target)
+}
+
+func main() {
+	flag.Parse()
+	if config.Target == "" || config.Vendor == "" || config.Model == "" {
+		log.Exit("-target, -vendor and -model must be set")
+	}
+	path := goldenPath(config.Dir, config.Vendor, config.Model)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	current, err := getConfig(ctx, config)
+	if err != nil {
+		log.Exit(err)
+	}
+
+	switch config.Mode {
+	case "capture":
+		if err := writeGolden(path, current); err != nil {
+			log.Exitf("Cannot write golden config %s: %v", path, err)
+		}
+		fmt.Printf("Wrote golden config %s\n", path)
+	case "check":
+		b, err := os.ReadFile(path)
+		if err != nil {
+			log.Exitf("Cannot read golden config: %v", err)
+		}
+		golden, err := flatten(b)
+		if err != nil {
+			log.Exitf("Golden config %s: %v", path, err)
+		}
+		leaves, err := flatten(current)
+		if err != nil {
+			log.Exitf("Config from %s: %v", config.Target, err)
+		}
+		drifts := diff(golden, leaves, config.Ignore)
+		if len(drifts) == 0 {
+			fmt.Printf("Config of %s matches golden config %s\n", config.Target, path)
+			return
+		}
+		fmt.Printf("Config of %s has drifted from golden config %s in %d leaves:\n", config.Target, path, len(drifts))
+		for _, d := range drifts {
+			fmt.Println(d)
+		}
+		os.Exit(1)
+	default:
+		log.Exitf("Unknown -mode %q, want capture or check", config.Mode)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxChains bounds the number of chains a path expression resolves to
+// through variables, so that a variable assigned many times does not blow up
+// the audit.
+const maxChains = 64
+
+// chain is a ygnmi path expression of the source, rooted at OC(), such as
+// gnmi.OC().Interface(p).Counters().InPkts().State().
+type chain struct {
+	// elems are the normalized names of the path methods, e.g. "interface",
+	// "counters" and "inpkts".
+	elems []string
+	// query is "state" or "config", the method that ends the chain.
+	query string
+}
+
+// source is what the audit knows about the Go source of a test.
+type source struct {
+	chains []chain
+	// fields are the normalized names of the selectors of the source, e.g.
+	// "operstatus" for c.GetOperStatus() or i.OperStatus.
+	fields map[string]bool
+}
+
+// normalize returns name lower case without dashes, so that a YANG element
+// name and the Go name generated for it compare equal.
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", ""))
+}
+
+// methodElem returns the normalized path element of a ygnmi path method, or
+// "" if the method does not select an element, such as WithName.
+func methodElem(name string) string {
+	if strings.HasPrefix(name, "With") {
+		return ""
+	}
+	for _, suffix := range []string{"Any", "Map"} {
+		if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+			name = strings.TrimSuffix(name, suffix)
+			break
+		}
+	}
+	return normalize(name)
+}
+
+// fieldName returns the normalized field of a selector, without the Get and
+// GetOrCreate prefixes of the ygot accessors.
+func fieldName(name string) string {
+	for _, prefix := range []string{"GetOrCreate", "Get"} {
+		if len(name) > len(prefix) && strings.HasPrefix(name, prefix) {
+			return normalize(strings.TrimPrefix(name, prefix))
+		}
+	}
+	return normalize(name)
+}
+
+// parseSource parses the non-generated Go files of dir.
+func parseSource(dir string) (*source, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	src := &source{fields: map[string]bool{}}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, file, b, 0)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		src.addFile(f)
+	}
+	return src, nil
+}
+
+// addFile adds the chains and fields of f to src.
+func (src *source) addFile(f *ast.File) {
+	// vars are the chains assigned to each variable of the file, so that
+	// chains built in steps, as in sub := gnmi.OC().Interface(p).Subinterface(0)
+	// and sub.Ipv4().State(), are found.  The scope of the variables is
+	// ignored.
+	vars := map[string][][]string{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		as, ok := n.(*ast.AssignStmt)
+		if !ok || len(as.Lhs) != len(as.Rhs) {
+			return true
+		}
+		for i, lhs := range as.Lhs {
+			id, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			for _, c := range resolve(as.Rhs[i], vars) {
+				if len(vars[id.Name]) < maxChains {
+					vars[id.Name] = append(vars[id.Name], c)
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			src.fields[fieldName(n.Sel.Name)] = true
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "State" && sel.Sel.Name != "Config") {
+				return true
+			}
+			for _, elems := range resolve(sel.X, vars) {
+				src.chains = append(src.chains, chain{elems: elems, query: normalize(sel.Sel.Name)})
+			}
+		}
+		return true
+	})
+}
+
+// resolve returns the path elements of expression e if it is a chain of
+// path methods rooted at OC() or at a variable assigned such a chain.
+func resolve(e ast.Expr, vars map[string][][]string) [][]string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return vars[e.Name]
+	case *ast.ParenExpr:
+		return resolve(e.X, vars)
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		if sel.Sel.Name == "OC" {
+			return [][]string{nil}
+		}
+		elem := methodElem(sel.Sel.Name)
+		var chains [][]string
+		for _, c := range resolve(sel.X, vars) {
+			c = append([]string(nil), c...)
+			if elem != "" {
+				c = append(c, elem)
+			}
+			chains = append(chains, c)
+		}
+		return chains
+	}
+	return nil
+}
+
+var keyRE = regexp.MustCompile(`\[[^\]]*\]`)
+
+// ocPath is an OC path of a README.
+type ocPath struct {
+	// elems are the normalized elements of the path, without keys and
+	// without the config and state containers.
+	elems []string
+	// query is "state" or "config", or "" if the path has neither.
+	query string
+}
+
+func parseOCPath(p string) ocPath {
+	var op ocPath
+	for _, e := range strings.Split(keyRE.ReplaceAllString(p, ""), "/") {
+		switch e {
+		case "":
+		case "state", "config":
+			op.query = e
+		default:
+			op.elems = append(op.elems, normalize(e))
+		}
+	}
+	return op
+}
+
+// match returns whether the elements of chain c are the elements of path p
+// in order, skipping only containers around a list, which ygnmi omits, and
+// returns the number of elements of p matched up to the last element of c.
+// A container is skipped only if the next element matches, so that a chain
+// does not match a path of another subtree.
+func match(c, p []string) (bool, int) {
+	var rec func(ci, pi int, skipped bool) (bool, int)
+	rec = func(ci, pi int, skipped bool) (bool, int) {
+		if ci == len(c) {
+			return true, pi
+		}
+		if pi == len(p) {
+			return false, 0
+		}
+		if c[ci] == p[pi] {
+			if ok, n := rec(ci+1, pi+1, false); ok {
+				return true, n
+			}
+		}
+		if !skipped && pi < len(p)-1 {
+			return rec(ci, pi+1, true)
+		}
+		return false, 0
+	}
+	return rec(0, 0, false)
+}
+
+// covers returns whether the source uses path p: a chain of the source is
+// either the path of the leaf of p, or the path of a container of p queried
+// as a whole, with the leaf read or set as a field of the container.
+func (src *source) covers(p string) bool {
+	op := parseOCPath(p)
+	if len(op.elems) == 0 {
+		return false
+	}
+	leaf := op.elems[len(op.elems)-1]
+	for _, c := range src.chains {
+		if op.query != "" && c.query != op.query {
+			continue
+		}
+		ok, n := match(c.elems, op.elems)
+		if !ok {
+			continue
+		}
+		if n == len(op.elems) || src.fields[leaf] {
+			return true
+		}
+	}
+	return false
+}
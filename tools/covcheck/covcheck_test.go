@@ -0,0 +1,145 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSource = `package foo_test
+
+func TestFoo(t *testing.T) {
+	p := dut.Port(t, "port1")
+	gnmi.Await(t, dut, gnmi.OC().Interface(p.Name()).OperStatus().State(), time.Minute, oc.Interface_OperStatus_UP)
+
+	sub := gnmi.OC().Interface(p.Name()).Subinterface(0)
+	gnmi.Get(t, dut, sub.Ipv4().Address("192.0.2.1").PrefixLength().State())
+
+	c := gnmi.Get(t, dut, gnmi.OC().Component("FPC0").State())
+	t.Log(c.GetOperStatus())
+
+	bgp := gnmi.OC().NetworkInstance("DEFAULT").Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, "BGP").Bgp()
+	gnmi.Replace(t, dut, bgp.Global().As().Config(), 65000)
+	gnmi.GetAll(t, dut, bgp.NeighborAny().SessionState().State())
+}
+`
+
+func TestCovers(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "foo_test.go"), []byte(testSource), 0600); err != nil {
+		t.Fatal(err)
+	}
+	src, err := parseSource(dir)
+	if err != nil {
+		t.Fatalf("parseSource() got error %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		path string
+		want bool
+	}{{
+		desc: "leaf chain",
+		path: "/interfaces/interface/state/oper-status",
+		want: true,
+	}, {
+		desc: "chain through variable",
+		path: "/interfaces/interface/subinterfaces/subinterface/ipv4/addresses/address/state/prefix-length",
+		want: true,
+	}, {
+		desc: "container chain and field",
+		path: "/components/component/state/oper-status",
+		want: true,
+	}, {
+		desc: "container chain without field",
+		path: "/components/component/state/temperature/instant",
+		want: false,
+	}, {
+		desc: "config leaf",
+		path: "/network-instances/network-instance/protocols/protocol/bgp/global/config/as",
+		want: true,
+	}, {
+		desc: "wildcard list",
+		path: "/network-instances/network-instance/protocols/protocol/bgp/neighbors/neighbor/state/session-state",
+		want: true,
+	}, {
+		desc: "state path used as config",
+		path: "/network-instances/network-instance/protocols/protocol/bgp/global/state/as",
+		want: false,
+	}, {
+		desc: "other subtree",
+		path: "/interfaces/interface/state/admin-status",
+		want: false,
+	}, {
+		desc: "unused path",
+		path: "/lacp/interfaces/interface/state/system-id-mac",
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := src.covers(tt.path); got != tt.want {
+				t.Errorf("covers(%q) got %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		desc  string
+		chain []string
+		path  []string
+		want  bool
+		wantN int
+	}{{
+		desc:  "exact",
+		chain: []string{"interface", "operstatus"},
+		path:  []string{"interface", "operstatus"},
+		want:  true,
+		wantN: 2,
+	}, {
+		desc:  "skipped containers",
+		chain: []string{"interface", "subinterface", "ipv4", "address", "ip"},
+		path:  []string{"interfaces", "interface", "subinterfaces", "subinterface", "ipv4", "addresses", "address", "ip"},
+		want:  true,
+		wantN: 8,
+	}, {
+		desc:  "container",
+		chain: []string{"interface", "counters"},
+		path:  []string{"interfaces", "interface", "counters", "inpkts"},
+		want:  true,
+		wantN: 3,
+	}, {
+		desc:  "leaf not skipped",
+		chain: []string{"interface", "name"},
+		path:  []string{"interfaces", "interface", "counters", "inpkts"},
+		want:  false,
+	}, {
+		desc:  "two elements skipped",
+		chain: []string{"interface", "ipv4"},
+		path:  []string{"interfaces", "interface", "subinterfaces", "subinterface", "ipv4"},
+		want:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, gotN := match(tt.chain, tt.path)
+			if got != tt.want || (got && gotN != tt.wantN) {
+				t.Errorf("match(%v, %v) got %v, %d, want %v, %d", tt.chain, tt.path, got, gotN, tt.want, tt.wantN)
+			}
+		})
+	}
+}
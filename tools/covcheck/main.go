@@ -0,0 +1,191 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command covcheck audits the OC paths listed in the coverage spec of test
+// READMEs against the Go source of each test, and reports the paths that the
+// test does not use through ygnmi, so that drift between a test plan and its
+// code can be caught in CI.
+//
+// Usage:
+//
+//	covcheck -feature-dir=feature
+//	covcheck feature/interface/singleton/otg_tests/singleton_test/README.md
+//
+// covcheck prints the uncovered paths of every README, and exits with status
+// 1 if there are any, unless -report_only is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/openconfig/featureprofiles/tools/internal/fpciutil"
+	"github.com/openconfig/featureprofiles/tools/internal/mdocspec"
+)
+
+// Config is the set of flags for this binary.
+type Config struct {
+	FeatureDir     string
+	NonTestREADMEs stringList
+	ReportOnly     bool
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// New registers a flagset with the configuration needed by this binary.
+func New(fs *flag.FlagSet) *Config {
+	c := &Config{}
+
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.StringVar(&c.FeatureDir, "feature-dir", "", "path to the feature directory of featureprofiles, for which all README.md files are audited")
+	fs.Var(&c.NonTestREADMEs, "non-test-readme", "README that's exempt from the audit (can be specified multiple times)")
+	fs.BoolVar(&c.ReportOnly, "report_only", false, "report uncovered paths without failing")
+
+	return c
+}
+
+var config = New(nil)
+
+func readmeFiles(featureDir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(featureDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == fpciutil.READMEname {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// readmePaths returns the sorted, unique OC paths of the coverage spec of a
+// README.
+func readmePaths(file string) ([]string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ocPaths, _, err := mdocspec.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var paths []string
+	for _, p := range ocPaths.GetOcpaths() {
+		if name := p.GetName(); !seen[name] {
+			seen[name] = true
+			paths = append(paths, name)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// audit returns the paths of a README that are not covered by the Go source
+// in the directory of the README.  It returns ok false if the directory has
+// no Go source to audit.
+func audit(file string) (uncovered []string, total int, ok bool, err error) {
+	dir := filepath.Dir(file)
+	goFiles, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil || len(goFiles) == 0 {
+		return nil, 0, false, err
+	}
+	paths, err := readmePaths(file)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	src, err := parseSource(dir)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	for _, p := range paths {
+		if !src.covers(p) {
+			uncovered = append(uncovered, p)
+		}
+	}
+	return uncovered, len(paths), true, nil
+}
+
+func main() {
+	flag.Parse()
+
+	var files []string
+	switch {
+	case flag.NArg() != 0 && config.FeatureDir != "":
+		log.Exit("If -feature-dir flag is specified, README files must not be specified as positional arguments.")
+	case flag.NArg() != 0:
+		files = flag.Args()
+	default:
+		if config.FeatureDir == "" {
+			var err error
+			config.FeatureDir, err = fpciutil.FeatureDir()
+			if err != nil {
+				log.Exitf("Unable to locate feature root: %v", err)
+			}
+		}
+		var err error
+		files, err = readmeFiles(config.FeatureDir)
+		if err != nil {
+			log.Exitf("Error gathering README.md files for audit: %v", err)
+		}
+	}
+
+	exempt := map[string]bool{}
+	for _, file := range config.NonTestREADMEs {
+		exempt[file] = true
+	}
+
+	failed := false
+	for _, file := range files {
+		if exempt[file] {
+			continue
+		}
+		uncovered, total, ok, err := audit(file)
+		if err != nil {
+			log.Errorf("file %v: %v", file, err)
+			failed = true
+			continue
+		}
+		if !ok || len(uncovered) == 0 {
+			continue
+		}
+		failed = true
+		fmt.Printf("%s: %d/%d paths covered\n", file, total-len(uncovered), total)
+		for _, p := range uncovered {
+			fmt.Printf("  uncovered: %s\n", p)
+		}
+	}
+	if failed && !config.ReportOnly {
+		os.Exit(1)
+	}
+}